@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ocmetric
+
+import (
+	"fmt"
+	"time"
+
+	"go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+)
+
+// lastValue is an aggregation.LastValue of an OpenCensus gauge point.
+type lastValue struct {
+	value number.Number
+	time  time.Time
+}
+
+var _ aggregation.LastValue = lastValue{}
+
+// Kind returns aggregation.LastValueKind.
+func (lastValue) Kind() aggregation.Kind {
+	return aggregation.LastValueKind
+}
+
+// LastValue returns the value and time of the point.
+func (lv lastValue) LastValue() (number.Number, time.Time, error) {
+	return lv.value, lv.time, nil
+}
+
+// sum is an aggregation.Sum of an OpenCensus cumulative point.
+type sum struct {
+	value number.Number
+}
+
+var _ aggregation.Sum = sum{}
+
+// Kind returns aggregation.SumKind.
+func (sum) Kind() aggregation.Kind {
+	return aggregation.SumKind
+}
+
+// Sum returns the value of the point.
+func (s sum) Sum() (number.Number, error) {
+	return s.value, nil
+}
+
+// histogram is an aggregation.Histogram of an OpenCensus distribution
+// point.
+type histogram struct {
+	distribution *metricdata.Distribution
+}
+
+var _ aggregation.Histogram = histogram{}
+
+// Kind returns aggregation.HistogramKind.
+func (histogram) Kind() aggregation.Kind {
+	return aggregation.HistogramKind
+}
+
+// Count returns the number of values in the distribution.
+func (h histogram) Count() (uint64, error) {
+	return uint64(h.distribution.Count), nil
+}
+
+// Sum returns the sum of the values in the distribution.
+func (h histogram) Sum() (number.Number, error) {
+	return number.NewFloat64Number(h.distribution.Sum), nil
+}
+
+// Histogram returns the bucket boundaries and counts of the distribution.
+func (h histogram) Histogram() (aggregation.Buckets, error) {
+	var bounds []float64
+	if h.distribution.BucketOptions != nil {
+		bounds = h.distribution.BucketOptions.Bounds
+	}
+	counts := make([]uint64, len(h.distribution.Buckets))
+	for i, b := range h.distribution.Buckets {
+		counts[i] = uint64(b.Count)
+	}
+	return aggregation.Buckets{Boundaries: bounds, Counts: counts}, nil
+}
+
+// convertPoint converts an OpenCensus point of a metric of type t to an
+// OpenTelemetry aggregation.
+func convertPoint(t metricdata.Type, p metricdata.Point) (aggregation.Aggregation, error) {
+	switch t {
+	case metricdata.TypeGaugeInt64, metricdata.TypeGaugeFloat64:
+		n, err := convertNumber(p.Value)
+		if err != nil {
+			return nil, err
+		}
+		return lastValue{value: n, time: p.Time}, nil
+	case metricdata.TypeCumulativeInt64, metricdata.TypeCumulativeFloat64:
+		n, err := convertNumber(p.Value)
+		if err != nil {
+			return nil, err
+		}
+		return sum{value: n}, nil
+	case metricdata.TypeGaugeDistribution, metricdata.TypeCumulativeDistribution:
+		d, ok := p.Value.(*metricdata.Distribution)
+		if !ok || d == nil {
+			return nil, fmt.Errorf("%w: unexpected distribution value %T", errConversion, p.Value)
+		}
+		if d.BucketOptions != nil && len(d.Buckets) != len(d.BucketOptions.Bounds)+1 {
+			return nil, fmt.Errorf("%w: %d buckets for %d bounds", errConversion, len(d.Buckets), len(d.BucketOptions.Bounds))
+		}
+		return histogram{distribution: d}, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported type %v", errConversion, t)
+}
+
+// convertNumber converts an OpenCensus numeric point value to a
+// number.Number.
+func convertNumber(v interface{}) (number.Number, error) {
+	switch n := v.(type) {
+	case int64:
+		return number.NewInt64Number(n), nil
+	case float64:
+		return number.NewFloat64Number(n), nil
+	}
+	return 0, fmt.Errorf("%w: unexpected numeric value %T", errConversion, v)
+}
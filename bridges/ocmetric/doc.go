@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ocmetric bridges OpenCensus metrics to OpenTelemetry metric
+// exporters.
+//
+// NewExporter returns an OpenCensus metricexport.Exporter which converts
+// the OpenCensus metrics it is given, such as the data of the registered
+// views, into OpenTelemetry export records and passes them to an
+// OpenTelemetry exporter. This allows a codebase instrumented with both
+// OpenCensus and OpenTelemetry to export all of its metrics with a single
+// OpenTelemetry exporter, such as the Cortex exporter, while migrating:
+//
+//	exporter := ocmetric.NewExporter(cortexExporter)
+//	reader, err := metricexport.NewIntervalReader(metricexport.NewReader(), exporter)
+//	if err != nil {
+//	        ...
+//	}
+//	reader.ReportingInterval = 10 * time.Second
+//	if err := reader.Start(); err != nil {
+//	        ...
+//	}
+//	defer reader.Stop()
+//
+// Gauges are exported as last values, cumulative metrics as sums and
+// distributions as histograms. OpenCensus reports cumulative metrics and
+// distributions as cumulative values, which are exported as such regardless
+// of the export kind selected by the OpenTelemetry exporter. Summaries are
+// not supported.
+package ocmetric // import "go.opentelemetry.io/contrib/bridges/ocmetric"
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ocmetric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricexport"
+	ocresource "go.opencensus.io/resource"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/metric/sdkapi"
+	"go.opentelemetry.io/otel/metric/unit"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const instrumentationName = "go.opentelemetry.io/contrib/bridges/ocmetric"
+
+var errConversion = errors.New("unable to convert OpenCensus metric")
+
+// config contains optional settings for the exporter.
+type config struct {
+	// Resource is the resource the metrics are exported with. If nil,
+	// the resource of the OpenCensus metrics is converted.
+	Resource *resource.Resource
+}
+
+// Option supports configuring optional settings for the exporter.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithResource sets the resource the metrics are exported with, such as
+// the resource used by the OpenTelemetry metric controller. By default the
+// labels of the OpenCensus resource of the metrics are used.
+func WithResource(res *resource.Resource) Option {
+	return optionFunc(func(c *config) {
+		c.Resource = res
+	})
+}
+
+// exporter is an OpenCensus metricexport.Exporter exporting to an
+// OpenTelemetry exporter.
+type exporter struct {
+	base   export.Exporter
+	config config
+}
+
+var _ metricexport.Exporter = (*exporter)(nil)
+
+// NewExporter returns an OpenCensus metricexport.Exporter that converts
+// OpenCensus metrics and exports them with base.
+func NewExporter(base export.Exporter, opts ...Option) metricexport.Exporter {
+	var c config
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return &exporter{base: base, config: c}
+}
+
+// ExportMetrics converts metrics and exports them with the base exporter.
+func (e *exporter) ExportMetrics(ctx context.Context, metrics []*metricdata.Metric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+	res := e.config.Resource
+	if res == nil {
+		res = convertResource(metrics[0].Resource)
+	}
+	return e.base.Export(ctx, res, libraryReader{reader: &reader{metrics: metrics}})
+}
+
+// libraryReader is an export.InstrumentationLibraryReader of OpenCensus
+// metrics.
+type libraryReader struct {
+	reader *reader
+}
+
+// ForEach calls readerFunc once for all the metrics.
+func (r libraryReader) ForEach(readerFunc func(instrumentation.Library, export.Reader) error) error {
+	return readerFunc(instrumentation.Library{
+		Name:    instrumentationName,
+		Version: SemVersion(),
+	}, r.reader)
+}
+
+// reader is an export.Reader of OpenCensus metrics.
+type reader struct {
+	// RWMutex implements the sync.Locker of export.Reader.
+	sync.RWMutex
+	metrics []*metricdata.Metric
+}
+
+var _ export.Reader = (*reader)(nil)
+
+// ForEach calls recordFunc with a record for every time series of the
+// metrics. Metrics and time series that cannot be converted are reported to
+// the global error handler and skipped.
+func (r *reader) ForEach(_ export.ExportKindSelector, recordFunc func(export.Record) error) error {
+	for _, m := range r.metrics {
+		descriptor, err := convertDescriptor(m.Descriptor)
+		if err != nil {
+			otel.Handle(err)
+			continue
+		}
+		for _, ts := range m.TimeSeries {
+			if len(ts.Points) == 0 {
+				continue
+			}
+			attrs, err := convertLabels(m.Descriptor.LabelKeys, ts.LabelValues)
+			if err != nil {
+				otel.Handle(fmt.Errorf("%s: %w", m.Descriptor.Name, err))
+				continue
+			}
+			// Only the most recent point of a time series is exported.
+			point := ts.Points[len(ts.Points)-1]
+			agg, err := convertPoint(m.Descriptor.Type, point)
+			if err != nil {
+				otel.Handle(fmt.Errorf("%s: %w", m.Descriptor.Name, err))
+				continue
+			}
+			err = recordFunc(export.NewRecord(&descriptor, &attrs, agg, ts.StartTime, point.Time))
+			if err != nil && !errors.Is(err, aggregation.ErrNoData) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// convertDescriptor converts an OpenCensus descriptor to an OpenTelemetry
+// descriptor.
+func convertDescriptor(d metricdata.Descriptor) (metric.Descriptor, error) {
+	var (
+		ikind sdkapi.InstrumentKind
+		nkind number.Kind
+	)
+	switch d.Type {
+	case metricdata.TypeGaugeInt64:
+		ikind, nkind = sdkapi.GaugeObserverInstrumentKind, number.Int64Kind
+	case metricdata.TypeGaugeFloat64:
+		ikind, nkind = sdkapi.GaugeObserverInstrumentKind, number.Float64Kind
+	case metricdata.TypeCumulativeInt64:
+		ikind, nkind = sdkapi.CounterObserverInstrumentKind, number.Int64Kind
+	case metricdata.TypeCumulativeFloat64:
+		ikind, nkind = sdkapi.CounterObserverInstrumentKind, number.Float64Kind
+	case metricdata.TypeGaugeDistribution, metricdata.TypeCumulativeDistribution:
+		ikind, nkind = sdkapi.HistogramInstrumentKind, number.Float64Kind
+	default:
+		return metric.Descriptor{}, fmt.Errorf("%w %s: unsupported type %v", errConversion, d.Name, d.Type)
+	}
+
+	var u unit.Unit
+	switch d.Unit {
+	case metricdata.UnitDimensionless:
+		u = unit.Dimensionless
+	case metricdata.UnitBytes:
+		u = unit.Bytes
+	case metricdata.UnitMilliseconds:
+		u = unit.Milliseconds
+	}
+	return metric.NewDescriptor(d.Name, ikind, nkind, d.Description, u), nil
+}
+
+// convertLabels converts OpenCensus label keys and values to an
+// OpenTelemetry attribute set. Labels without a value are omitted.
+func convertLabels(keys []metricdata.LabelKey, values []metricdata.LabelValue) (attribute.Set, error) {
+	if len(keys) != len(values) {
+		return attribute.NewSet(), fmt.Errorf("%w: %d label keys and %d values", errConversion, len(keys), len(values))
+	}
+	attrs := make([]attribute.KeyValue, 0, len(values))
+	for i, v := range values {
+		if v.Present {
+			attrs = append(attrs, attribute.String(keys[i].Key, v.Value))
+		}
+	}
+	return attribute.NewSet(attrs...), nil
+}
+
+// convertResource converts an OpenCensus resource to an OpenTelemetry
+// resource. The type of the OpenCensus resource is not used.
+func convertResource(res *ocresource.Resource) *resource.Resource {
+	if res == nil {
+		return resource.Empty()
+	}
+	attrs := make([]attribute.KeyValue, 0, len(res.Labels))
+	for k, v := range res.Labels {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	return resource.NewSchemaless(attrs...)
+}
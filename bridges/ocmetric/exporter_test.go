@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ocmetric
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	ocresource "go.opencensus.io/resource"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/metric/sdkapi"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+type fakeExporter struct {
+	export.ExportKindSelector
+	resource *resource.Resource
+	records  []export.Record
+}
+
+func (e *fakeExporter) Export(_ context.Context, res *resource.Resource, ilr export.InstrumentationLibraryReader) error {
+	e.resource = res
+	return ilr.ForEach(func(_ instrumentation.Library, r export.Reader) error {
+		r.RLock()
+		defer r.RUnlock()
+		return r.ForEach(e, func(rec export.Record) error {
+			e.records = append(e.records, rec)
+			return nil
+		})
+	})
+}
+
+var (
+	start = time.Unix(1000, 0)
+	end   = time.Unix(1010, 0)
+)
+
+func export1(t *testing.T, m *metricdata.Metric, opts ...Option) *fakeExporter {
+	fake := &fakeExporter{ExportKindSelector: export.CumulativeExportKindSelector()}
+	require.NoError(t, NewExporter(fake, opts...).ExportMetrics(context.Background(), []*metricdata.Metric{m}))
+	return fake
+}
+
+func TestExportGauge(t *testing.T) {
+	fake := export1(t, &metricdata.Metric{
+		Descriptor: metricdata.Descriptor{
+			Name:      "queue.size",
+			Unit:      metricdata.UnitDimensionless,
+			Type:      metricdata.TypeGaugeInt64,
+			LabelKeys: []metricdata.LabelKey{{Key: "queue"}, {Key: "shard"}},
+		},
+		TimeSeries: []*metricdata.TimeSeries{{
+			LabelValues: []metricdata.LabelValue{metricdata.NewLabelValue("jobs"), {}},
+			Points: []metricdata.Point{
+				metricdata.NewInt64Point(start, 3),
+				metricdata.NewInt64Point(end, 5),
+			},
+			StartTime: start,
+		}},
+	})
+
+	require.Len(t, fake.records, 1)
+	rec := fake.records[0]
+	assert.Equal(t, "queue.size", rec.Descriptor().Name())
+	assert.Equal(t, sdkapi.GaugeObserverInstrumentKind, rec.Descriptor().InstrumentKind())
+	assert.Equal(t, number.Int64Kind, rec.Descriptor().NumberKind())
+	assert.Equal(t, attribute.NewSet(attribute.String("queue", "jobs")), *rec.Labels())
+	assert.Equal(t, end, rec.EndTime())
+
+	lv, ok := rec.Aggregation().(aggregation.LastValue)
+	require.True(t, ok)
+	value, ts, err := lv.LastValue()
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), value.AsInt64())
+	assert.Equal(t, end, ts)
+}
+
+func TestExportCumulative(t *testing.T) {
+	fake := export1(t, &metricdata.Metric{
+		Descriptor: metricdata.Descriptor{
+			Name: "bytes.sent",
+			Unit: metricdata.UnitBytes,
+			Type: metricdata.TypeCumulativeFloat64,
+		},
+		TimeSeries: []*metricdata.TimeSeries{{
+			Points:    []metricdata.Point{metricdata.NewFloat64Point(end, 12.5)},
+			StartTime: start,
+		}},
+	})
+
+	require.Len(t, fake.records, 1)
+	rec := fake.records[0]
+	assert.Equal(t, sdkapi.CounterObserverInstrumentKind, rec.Descriptor().InstrumentKind())
+	assert.Equal(t, number.Float64Kind, rec.Descriptor().NumberKind())
+	assert.Equal(t, "By", string(rec.Descriptor().Unit()))
+	assert.Equal(t, start, rec.StartTime())
+
+	s, ok := rec.Aggregation().(aggregation.Sum)
+	require.True(t, ok)
+	value, err := s.Sum()
+	require.NoError(t, err)
+	assert.Equal(t, 12.5, value.AsFloat64())
+}
+
+func TestExportDistribution(t *testing.T) {
+	fake := export1(t, &metricdata.Metric{
+		Descriptor: metricdata.Descriptor{
+			Name: "latency",
+			Unit: metricdata.UnitMilliseconds,
+			Type: metricdata.TypeCumulativeDistribution,
+		},
+		TimeSeries: []*metricdata.TimeSeries{{
+			Points: []metricdata.Point{metricdata.NewDistributionPoint(end, &metricdata.Distribution{
+				Count:         4,
+				Sum:           42,
+				BucketOptions: &metricdata.BucketOptions{Bounds: []float64{10, 20}},
+				Buckets:       []metricdata.Bucket{{Count: 1}, {Count: 2}, {Count: 1}},
+			})},
+			StartTime: start,
+		}},
+	})
+
+	require.Len(t, fake.records, 1)
+	rec := fake.records[0]
+	assert.Equal(t, sdkapi.HistogramInstrumentKind, rec.Descriptor().InstrumentKind())
+
+	h, ok := rec.Aggregation().(aggregation.Histogram)
+	require.True(t, ok)
+	count, err := h.Count()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), count)
+	s, err := h.Sum()
+	require.NoError(t, err)
+	assert.Equal(t, 42.0, s.AsFloat64())
+	buckets, err := h.Histogram()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{10, 20}, buckets.Boundaries)
+	assert.Equal(t, []uint64{1, 2, 1}, buckets.Counts)
+}
+
+func TestExportSummaryIsSkipped(t *testing.T) {
+	fake := export1(t, &metricdata.Metric{
+		Descriptor: metricdata.Descriptor{
+			Name: "summary",
+			Type: metricdata.TypeSummary,
+		},
+		TimeSeries: []*metricdata.TimeSeries{{
+			Points: []metricdata.Point{metricdata.NewSummaryPoint(end, &metricdata.Summary{})},
+		}},
+	})
+	assert.Empty(t, fake.records)
+}
+
+func TestExportResource(t *testing.T) {
+	m := &metricdata.Metric{
+		Descriptor: metricdata.Descriptor{Name: "gauge", Type: metricdata.TypeGaugeFloat64},
+		Resource:   &ocresource.Resource{Type: "host", Labels: map[string]string{"host.name": "example"}},
+	}
+
+	fake := export1(t, m)
+	assert.Equal(t, resource.NewSchemaless(attribute.String("host.name", "example")), fake.resource)
+
+	res := resource.NewSchemaless(attribute.String("service.name", "bridge"))
+	fake = export1(t, m, WithResource(res))
+	assert.Equal(t, res, fake.resource)
+
+	m.Resource = nil
+	fake = export1(t, m)
+	assert.Equal(t, resource.Empty(), fake.resource)
+}
@@ -0,0 +1,12 @@
+module go.opentelemetry.io/contrib/bridges/ocmetric
+
+go 1.15
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opencensus.io v0.23.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/metric v0.24.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/sdk/export/metric v0.24.0
+)
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package autodetect provides a resource detector that detects the cloud
+// provider a process runs on, and registers the resource detectors of this
+// repository for use by the resource detector selection of the
+// go.opentelemetry.io/contrib/detectors module.
+//
+// The detectors are registered with the following names:
+//
+//	cloud         the detector of this package, for AWS, GCP, and Azure
+//	aws           the AWS detector, probing all AWS environments
+//	lambda        AWS Lambda
+//	ecs           Amazon ECS
+//	eks           Amazon EKS
+//	beanstalk     AWS Elastic Beanstalk
+//	ec2           Amazon EC2
+//	gce           Google Compute Engine
+//	gke           Google Kubernetes Engine
+//	cloudrun      Google Cloud Run
+//	azurevm       Azure Virtual Machines
+//	aks           Azure Kubernetes Service
+//	appservice    Azure App Service and Azure Functions
+//	k8s           any Kubernetes cluster, using the downward API
+//	nomad         HashiCorp Nomad
+//	cloudfoundry  Cloud Foundry
+package autodetect
+
+import (
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"go.opentelemetry.io/contrib/detectors"
+	"go.opentelemetry.io/contrib/detectors/aws"
+	"go.opentelemetry.io/contrib/detectors/aws/beanstalk"
+	"go.opentelemetry.io/contrib/detectors/aws/ec2"
+	"go.opentelemetry.io/contrib/detectors/aws/ecs"
+	"go.opentelemetry.io/contrib/detectors/aws/eks"
+	"go.opentelemetry.io/contrib/detectors/aws/lambda"
+	"go.opentelemetry.io/contrib/detectors/azure/aks"
+	"go.opentelemetry.io/contrib/detectors/azure/appservice"
+	"go.opentelemetry.io/contrib/detectors/azure/vm"
+	"go.opentelemetry.io/contrib/detectors/cloudfoundry"
+	"go.opentelemetry.io/contrib/detectors/gcp"
+	"go.opentelemetry.io/contrib/detectors/k8s"
+	"go.opentelemetry.io/contrib/detectors/nomad"
+)
+
+func init() {
+	detectors.Register("cloud", func() resource.Detector { return NewResourceDetector() })
+	detectors.Register("aws", func() resource.Detector { return aws.NewResourceDetector() })
+	detectors.Register("lambda", func() resource.Detector { return lambda.NewResourceDetector() })
+	detectors.Register("ecs", func() resource.Detector { return ecs.NewResourceDetector() })
+	detectors.Register("eks", func() resource.Detector { return eks.NewResourceDetector() })
+	detectors.Register("beanstalk", func() resource.Detector { return beanstalk.NewResourceDetector() })
+	detectors.Register("ec2", func() resource.Detector { return ec2.NewResourceDetector() })
+	detectors.Register("gce", func() resource.Detector { return &gcp.GCE{} })
+	detectors.Register("gke", func() resource.Detector { return &gcp.GKE{} })
+	detectors.Register("cloudrun", func() resource.Detector { return gcp.NewCloudRun() })
+	detectors.Register("azurevm", func() resource.Detector { return vm.NewResourceDetector() })
+	detectors.Register("aks", func() resource.Detector { return aks.NewResourceDetector() })
+	detectors.Register("appservice", func() resource.Detector { return appservice.NewResourceDetector() })
+	detectors.Register("k8s", func() resource.Detector { return k8s.NewResourceDetector() })
+	detectors.Register("nomad", func() resource.Detector { return nomad.NewResourceDetector() })
+	detectors.Register("cloudfoundry", func() resource.Detector { return cloudfoundry.NewResourceDetector() })
+}
+
+// FromEnv returns a resource detector of the detectors named by the
+// OTEL_RESOURCE_DETECTORS environment variable, e.g. "eks,ec2,host,process".
+// It is detectors.FromEnv with the detectors of this repository registered.
+func FromEnv(opts ...detectors.Option) (resource.Detector, error) {
+	return detectors.FromEnv(opts...)
+}
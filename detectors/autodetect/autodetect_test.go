@@ -12,36 +12,30 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package cortex
+package autodetect
 
 import (
-	"strings"
-	"unicode"
-)
+	"testing"
 
-// This is a copy of opentelemetry-go/sdk/internal/sanitize.go
+	"github.com/stretchr/testify/assert"
 
-// sanitize replaces non-alphanumeric characters with underscores
-func sanitize(s string) string {
-	if len(s) == 0 {
-		return s
-	}
+	"go.opentelemetry.io/contrib/detectors"
+)
 
-	s = strings.Map(sanitizeRune, s)
-	if unicode.IsDigit(rune(s[0])) {
-		s = "key_" + s
+func TestRegistered(t *testing.T) {
+	registered := detectors.Registered()
+	for _, name := range []string{
+		"cloud", "aws", "lambda", "ecs", "eks", "beanstalk", "ec2",
+		"gce", "gke", "cloudrun",
+		"azurevm", "aks", "appservice",
+		"k8s", "nomad", "cloudfoundry",
+		"host", "process",
+	} {
+		assert.Contains(t, registered, name)
 	}
-	if s[0] == '_' {
-		s = "key" + s
-	}
-	return s
 }
 
-// converts anything that is not a letter or digit to an underscore
-func sanitizeRune(r rune) rune {
-	if unicode.IsLetter(r) || unicode.IsDigit(r) {
-		return r
-	}
-	// Everything else turns into an underscore
-	return '_'
+func TestNew(t *testing.T) {
+	_, err := detectors.New([]string{"eks", "ec2", "host", "process"})
+	assert.NoError(t, err)
 }
@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"go.opentelemetry.io/contrib/detectors/aws"
+	"go.opentelemetry.io/contrib/detectors/azure/aks"
+	"go.opentelemetry.io/contrib/detectors/azure/appservice"
+	"go.opentelemetry.io/contrib/detectors/azure/vm"
+	"go.opentelemetry.io/contrib/detectors/gcp"
+)
+
+// Provider is a cloud provider detected by probing its metadata endpoint.
+type Provider string
+
+// The cloud providers, with the values of the cloud.provider attribute.
+const (
+	// NoProvider is returned when no cloud provider is detected.
+	NoProvider Provider = ""
+	AWS        Provider = "aws"
+	GCP        Provider = "gcp"
+	Azure      Provider = "azure"
+)
+
+// The environment variables set by the serverless and container platforms
+// that have no metadata endpoint reachable from the process.
+var providerEnvVars = []struct {
+	name     string
+	provider Provider
+}{
+	{"AWS_LAMBDA_FUNCTION_NAME", AWS},
+	{"ECS_CONTAINER_METADATA_URI_V4", AWS},
+	{"ECS_CONTAINER_METADATA_URI", AWS},
+	{"K_SERVICE", GCP},
+	{"WEBSITE_SITE_NAME", Azure},
+}
+
+// cloudDetector probes the metadata endpoints of the cloud providers and
+// runs the detectors of the detected provider.
+type cloudDetector struct {
+	cfg       *config
+	detectors map[Provider]resource.Detector
+
+	mu       sync.Mutex
+	probed   bool
+	provider Provider
+	// expiresAt is when the cached provider expires. It is zero if the
+	// provider does not expire.
+	expiresAt time.Time
+}
+
+// compile time assertion that cloudDetector implements the resource.Detector interface.
+var _ resource.Detector = (*cloudDetector)(nil)
+
+// NewResourceDetector returns a resource detector that detects the cloud
+// provider a process runs on and only runs the resource detectors of that
+// provider:
+//
+//	aws    the AWS detector, probing Lambda, ECS, EKS, Elastic Beanstalk, and EC2
+//	gcp    Cloud Run, or else GKE, which includes GCE
+//	azure  App Service, AKS, and Azure VM, the first one detected
+//
+// The provider is detected from the environment variables of Lambda, ECS,
+// Cloud Run, and App Service, or else by probing the metadata endpoints of
+// AWS, GCP, and Azure in parallel, bounded by the probe timeout. The
+// detected provider is cached, so that the endpoints are only probed once.
+// When no provider is detected because a probe got no answer, e.g. because
+// it timed out, the endpoints are probed again once the probe retry interval
+// elapsed.
+//
+// It is registered as "cloud", and can be combined with the detectors that
+// do not depend on the provider, such as the Kubernetes detector, with
+// detectors.Composite.
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &cloudDetector{
+		cfg: c,
+		detectors: map[Provider]resource.Detector{
+			AWS: aws.NewResourceDetector(),
+			GCP: &gcpDetector{getenv: c.getenv},
+			Azure: firstDetected{
+				appservice.NewResourceDetector(),
+				aks.NewResourceDetector(),
+				vm.NewResourceDetector(),
+			},
+		},
+	}
+}
+
+// Detect returns the resource detected by the detectors of the cloud
+// provider the process runs on, or an empty resource if no provider is
+// detected.
+func (detector *cloudDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	provider, err := detector.detectProvider(ctx)
+	if err != nil {
+		return resource.Empty(), err
+	}
+	d, ok := detector.detectors[provider]
+	if !ok {
+		return resource.Empty(), nil
+	}
+	return d.Detect(ctx)
+}
+
+// detectProvider returns the cached provider, or detects it.
+func (detector *cloudDetector) detectProvider(ctx context.Context) (Provider, error) {
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+	if detector.probed && (detector.expiresAt.IsZero() || time.Now().Before(detector.expiresAt)) {
+		return detector.provider, nil
+	}
+
+	provider, definitive := detector.probe(ctx)
+	// The probes may have failed because ctx is done rather than because
+	// the process does not run on a cloud provider.
+	if err := ctx.Err(); err != nil {
+		return NoProvider, err
+	}
+	detector.probed = true
+	detector.provider = provider
+	detector.expiresAt = time.Time{}
+	if !definitive {
+		detector.expiresAt = time.Now().Add(detector.cfg.probeRetryInterval)
+	}
+	return provider, nil
+}
+
+// probeResult is the result of the probe of a metadata endpoint.
+type probeResult int
+
+const (
+	// probeFailed means that the endpoint did not answer, e.g. because the
+	// probe timed out or the endpoint is temporarily unavailable.
+	probeFailed probeResult = iota
+	// probeNotFound means that the endpoint answered, but is not the one of
+	// the probed provider.
+	probeNotFound
+	// probeFound means that the endpoint of the probed provider answered.
+	probeFound
+)
+
+// probe detects the provider from the environment, or else probes the
+// metadata endpoints in parallel and returns the first provider whose
+// endpoint responds. It also returns whether the result is definitive,
+// which is not the case if no provider is found and a probe failed.
+func (detector *cloudDetector) probe(ctx context.Context) (Provider, bool) {
+	cfg := detector.cfg
+	for _, v := range providerEnvVars {
+		if cfg.getenv(v.name) != "" {
+			return v.provider, true
+		}
+	}
+
+	if cfg.probeTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.probeTimeout)
+		defer cancel()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		provider Provider
+		result   probeResult
+	}
+	probes := map[Provider]func(context.Context) probeResult{
+		AWS:   detector.probeAWS,
+		GCP:   detector.probeGCP,
+		Azure: detector.probeAzure,
+	}
+	results := make(chan result, len(probes))
+	for provider, probe := range probes {
+		go func(provider Provider, probe func(context.Context) probeResult) {
+			results <- result{provider: provider, result: probe(ctx)}
+		}(provider, probe)
+	}
+	definitive := true
+	for range probes {
+		r := <-results
+		switch r.result {
+		case probeFound:
+			return r.provider, true
+		case probeFailed:
+			definitive = false
+		}
+	}
+	return NoProvider, definitive
+}
+
+// probeAWS requests an IMDSv2 session token, or else the instance ID with
+// IMDSv1.
+func (detector *cloudDetector) probeAWS(ctx context.Context) probeResult {
+	endpoint := detector.cfg.awsEndpoint
+	token := detector.get(ctx, http.MethodPut, endpoint+"/latest/api/token", "X-aws-ec2-metadata-token-ttl-seconds", "60", nil)
+	if token == probeFound {
+		return probeFound
+	}
+	instanceID := detector.get(ctx, http.MethodGet, endpoint+"/latest/meta-data/instance-id", "", "", nil)
+	if token == probeFailed && instanceID == probeNotFound {
+		return probeFailed
+	}
+	return instanceID
+}
+
+// probeGCP requests the metadata server, which identifies itself with the
+// Metadata-Flavor header.
+func (detector *cloudDetector) probeGCP(ctx context.Context) probeResult {
+	return detector.get(ctx, http.MethodGet, detector.cfg.gcpEndpoint+"/computeMetadata/v1/", "Metadata-Flavor", "Google", func(resp *http.Response) bool {
+		return resp.Header.Get("Metadata-Flavor") == "Google"
+	})
+}
+
+// probeAzure requests the compute metadata of the Instance Metadata Service.
+func (detector *cloudDetector) probeAzure(ctx context.Context) probeResult {
+	return detector.get(ctx, http.MethodGet, detector.cfg.azureEndpoint+"/metadata/instance/compute?api-version=2021-02-01", "Metadata", "true", nil)
+}
+
+// get sends a request with the header, if any, and returns whether it
+// succeeded and the response is accepted by check, if any. The probe fails
+// if the request cannot be sent or the endpoint answers with a server error.
+func (detector *cloudDetector) get(ctx context.Context, method, url, header, value string, check func(*http.Response) bool) probeResult {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return probeFailed
+	}
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	resp, err := detector.cfg.client.Do(req)
+	if err != nil {
+		return probeFailed
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusOK && (check == nil || check(resp)):
+		return probeFound
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return probeFailed
+	}
+	return probeNotFound
+}
+
+// gcpDetector runs the Cloud Run detector on Cloud Run, and the GKE detector
+// otherwise, which falls back to the GCE detector outside of Kubernetes.
+type gcpDetector struct {
+	getenv func(string) string
+}
+
+func (d *gcpDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if d.getenv("K_SERVICE") != "" {
+		return gcp.NewCloudRun().Detect(ctx)
+	}
+	return (&gcp.GKE{}).Detect(ctx)
+}
+
+// firstDetected runs detectors in order and returns the first resource that
+// is not empty. As with the AWS detector, a detector that fails is skipped
+// unless it returns a partial resource.
+type firstDetected []resource.Detector
+
+func (ds firstDetected) Detect(ctx context.Context) (*resource.Resource, error) {
+	for _, d := range ds {
+		res, err := d.Detect(ctx)
+		if res == nil || res.Len() == 0 {
+			continue
+		}
+		if err == nil || errors.Is(err, resource.ErrPartialResource) {
+			return res, err
+		}
+	}
+	return resource.Empty(), nil
+}
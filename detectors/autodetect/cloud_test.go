@@ -0,0 +1,262 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+type detectorFunc func(context.Context) (*resource.Resource, error)
+
+func (fn detectorFunc) Detect(ctx context.Context) (*resource.Resource, error) {
+	return fn(ctx)
+}
+
+// newTestDetector returns a cloud detector probing the endpoints of
+// metadata, whose providers return a resource with their name.
+func newTestDetector(t *testing.T, metadata http.Handler, env map[string]string, opts ...Option) *cloudDetector {
+	srv := httptest.NewServer(metadata)
+	t.Cleanup(srv.Close)
+
+	c := newConfig(opts...)
+	c.awsEndpoint, c.gcpEndpoint, c.azureEndpoint = srv.URL, srv.URL, srv.URL
+	c.client = srv.Client()
+	c.getenv = func(key string) string { return env[key] }
+
+	detectors := make(map[Provider]resource.Detector)
+	for _, p := range []Provider{AWS, GCP, Azure} {
+		res := resource.NewSchemaless(attribute.String("provider", string(p)))
+		detectors[p] = detectorFunc(func(context.Context) (*resource.Resource, error) {
+			return res, nil
+		})
+	}
+	return &cloudDetector{cfg: c, detectors: detectors}
+}
+
+func detectedProvider(t *testing.T, d *cloudDetector) string {
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	for _, kv := range res.Attributes() {
+		if kv.Key == "provider" {
+			return kv.Value.AsString()
+		}
+	}
+	return ""
+}
+
+func TestDetectProviderFromMetadata(t *testing.T) {
+	testCases := []struct {
+		name     string
+		handler  http.HandlerFunc
+		expected Provider
+	}{
+		{
+			name: "AWS IMDSv2",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut || r.URL.Path != "/latest/api/token" {
+					http.NotFound(w, r)
+				}
+			},
+			expected: AWS,
+		},
+		{
+			name: "AWS IMDSv1",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/latest/meta-data/instance-id" {
+					http.NotFound(w, r)
+				}
+			},
+			expected: AWS,
+		},
+		{
+			name: "GCP",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/computeMetadata/v1/" || r.Header.Get("Metadata-Flavor") != "Google" {
+					http.NotFound(w, r)
+					return
+				}
+				w.Header().Set("Metadata-Flavor", "Google")
+			},
+			expected: GCP,
+		},
+		{
+			name: "GCP without flavor",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/computeMetadata/v1/" {
+					http.NotFound(w, r)
+				}
+			},
+			expected: NoProvider,
+		},
+		{
+			name: "Azure",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/metadata/instance/compute" || r.Header.Get("Metadata") != "true" {
+					http.NotFound(w, r)
+				}
+			},
+			expected: Azure,
+		},
+		{
+			name:     "none",
+			handler:  http.NotFound,
+			expected: NoProvider,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newTestDetector(t, tc.handler, nil)
+			assert.Equal(t, string(tc.expected), detectedProvider(t, d))
+		})
+	}
+}
+
+func TestDetectProviderFromEnv(t *testing.T) {
+	var requests int64
+	metadata := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+	})
+
+	for env, expected := range map[string]Provider{
+		"AWS_LAMBDA_FUNCTION_NAME":      AWS,
+		"ECS_CONTAINER_METADATA_URI_V4": AWS,
+		"K_SERVICE":                     GCP,
+		"WEBSITE_SITE_NAME":             Azure,
+	} {
+		d := newTestDetector(t, metadata, map[string]string{env: "x"})
+		assert.Equal(t, string(expected), detectedProvider(t, d), env)
+	}
+	assert.Equal(t, int64(0), atomic.LoadInt64(&requests))
+}
+
+func TestDetectProviderCached(t *testing.T) {
+	var requests int64
+	d := newTestDetector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		http.NotFound(w, r)
+	}), nil)
+
+	assert.Equal(t, "", detectedProvider(t, d))
+	probed := atomic.LoadInt64(&requests)
+	assert.Equal(t, "", detectedProvider(t, d))
+	assert.Equal(t, probed, atomic.LoadInt64(&requests))
+}
+
+func TestDetectProviderTimeout(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	d := newTestDetector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}), nil, WithProbeTimeout(10*time.Millisecond))
+
+	start := time.Now()
+	assert.Equal(t, "", detectedProvider(t, d))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestDetectProviderRetriedAfterTimeout(t *testing.T) {
+	var available int32
+	done := make(chan struct{})
+	defer close(done)
+	metadata := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&available) == 0 {
+			// Simulate a metadata endpoint that is slow to start.
+			select {
+			case <-done:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		if r.URL.Path != "/latest/api/token" {
+			http.NotFound(w, r)
+		}
+	})
+
+	d := newTestDetector(t, metadata, nil, WithProbeTimeout(10*time.Millisecond), WithProbeRetryInterval(0))
+	assert.Equal(t, "", detectedProvider(t, d))
+	atomic.StoreInt32(&available, 1)
+	assert.Equal(t, "aws", detectedProvider(t, d))
+
+	// The provider is not probed again before the retry interval elapsed.
+	atomic.StoreInt32(&available, 0)
+	d = newTestDetector(t, metadata, nil, WithProbeTimeout(10*time.Millisecond), WithProbeRetryInterval(time.Hour))
+	assert.Equal(t, "", detectedProvider(t, d))
+	atomic.StoreInt32(&available, 1)
+	assert.Equal(t, "", detectedProvider(t, d))
+}
+
+func TestDetectProviderRetriedAfterServerError(t *testing.T) {
+	var available int32
+	d := newTestDetector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&available) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path != "/metadata/instance/compute" {
+			http.NotFound(w, r)
+		}
+	}), nil, WithProbeRetryInterval(0))
+
+	assert.Equal(t, "", detectedProvider(t, d))
+	atomic.StoreInt32(&available, 1)
+	assert.Equal(t, "azure", detectedProvider(t, d))
+}
+
+func TestDetectCanceled(t *testing.T) {
+	d := newTestDetector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/latest/api/token" {
+			http.NotFound(w, r)
+		}
+	}), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := d.Detect(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// The provider is detected again.
+	assert.Equal(t, "aws", detectedProvider(t, d))
+}
+
+func TestFirstDetected(t *testing.T) {
+	static := func(res *resource.Resource, err error) resource.Detector {
+		return detectorFunc(func(context.Context) (*resource.Resource, error) {
+			return res, err
+		})
+	}
+	vm := resource.NewSchemaless(attribute.String("env", "vm"))
+	res, err := firstDetected{
+		static(nil, nil),
+		static(resource.NewSchemaless(attribute.String("env", "aks")), assert.AnError),
+		static(vm, nil),
+	}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, vm, res)
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+import (
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	// defaultProbeTimeout bounds the probes of the metadata endpoints.
+	defaultProbeTimeout = time.Second
+	// defaultProbeRetryInterval is how long no provider is cached when a
+	// probe got no answer.
+	defaultProbeRetryInterval = time.Minute
+	// metadataEndpoint is the link-local address of the metadata endpoints
+	// of AWS, GCP, and Azure.
+	metadataEndpoint = "http://169.254.169.254"
+)
+
+type config struct {
+	probeTimeout       time.Duration
+	probeRetryInterval time.Duration
+
+	// The endpoints probed for each cloud provider.
+	awsEndpoint, gcpEndpoint, azureEndpoint string
+	// client sends the probes.
+	client *http.Client
+	getenv func(string) string
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{
+		probeTimeout:       defaultProbeTimeout,
+		probeRetryInterval: defaultProbeRetryInterval,
+		awsEndpoint:        metadataEndpoint,
+		gcpEndpoint:        metadataEndpoint,
+		azureEndpoint:      metadataEndpoint,
+		// The metadata endpoints are never reached through a proxy.
+		client: &http.Client{Transport: &http.Transport{}},
+		getenv: os.Getenv,
+	}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies a cloud resource detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithProbeTimeout sets the maximum duration of the probes of the metadata
+// endpoints. The default is 1 second. A value of zero means no timeout
+// other than the deadline of the context passed to Detect.
+func WithProbeTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.probeTimeout = timeout
+	})
+}
+
+// WithProbeRetryInterval sets how long the detector caches that no provider
+// was detected when a probe got no answer, e.g. because it timed out or the
+// endpoint was temporarily unavailable. The endpoints are probed again by the
+// first detection after it elapsed. The default is 1 minute. A provider
+// detected, or the absence of a provider confirmed by every endpoint, is
+// cached for the life of the detector.
+func WithProbeRetryInterval(interval time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.probeRetryInterval = interval
+	})
+}
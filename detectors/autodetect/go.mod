@@ -0,0 +1,38 @@
+module go.opentelemetry.io/contrib/detectors/autodetect
+
+go 1.15
+
+replace (
+	go.opentelemetry.io/contrib => ../..
+	go.opentelemetry.io/contrib/detectors => ../
+	go.opentelemetry.io/contrib/detectors/aws => ../aws
+	go.opentelemetry.io/contrib/detectors/aws/beanstalk => ../aws/beanstalk
+	go.opentelemetry.io/contrib/detectors/aws/ec2 => ../aws/ec2
+	go.opentelemetry.io/contrib/detectors/aws/ecs => ../aws/ecs
+	go.opentelemetry.io/contrib/detectors/aws/eks => ../aws/eks
+	go.opentelemetry.io/contrib/detectors/aws/internal => ../aws/internal
+	go.opentelemetry.io/contrib/detectors/aws/lambda => ../aws/lambda
+	go.opentelemetry.io/contrib/detectors/azure => ../azure
+	go.opentelemetry.io/contrib/detectors/cloudfoundry => ../cloudfoundry
+	go.opentelemetry.io/contrib/detectors/gcp => ../gcp
+	go.opentelemetry.io/contrib/detectors/k8s => ../k8s
+	go.opentelemetry.io/contrib/detectors/nomad => ../nomad
+)
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/detectors v0.26.0
+	go.opentelemetry.io/contrib/detectors/aws v0.26.0
+	go.opentelemetry.io/contrib/detectors/aws/beanstalk v0.26.0
+	go.opentelemetry.io/contrib/detectors/aws/ec2 v1.1.0
+	go.opentelemetry.io/contrib/detectors/aws/ecs v1.1.0
+	go.opentelemetry.io/contrib/detectors/aws/eks v1.1.0
+	go.opentelemetry.io/contrib/detectors/aws/lambda v0.26.0
+	go.opentelemetry.io/contrib/detectors/azure v0.26.0
+	go.opentelemetry.io/contrib/detectors/cloudfoundry v0.26.0
+	go.opentelemetry.io/contrib/detectors/gcp v1.1.0
+	go.opentelemetry.io/contrib/detectors/k8s v0.26.0
+	go.opentelemetry.io/contrib/detectors/nomad v0.26.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+)
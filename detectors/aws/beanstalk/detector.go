@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beanstalk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// The AWS X-Ray daemon configuration written by Elastic Beanstalk on every
+// instance of an environment, see
+// https://docs.aws.amazon.com/xray/latest/devguide/xray-services-beanstalk.html
+const (
+	linuxConfPath   = "/var/elasticbeanstalk/xray/environment.conf"
+	windowsConfPath = "C:\\Program Files\\Amazon\\XRay\\environment.conf"
+)
+
+var (
+	empty             = resource.Empty()
+	errNotOnBeanstalk = errors.New("process is not on Elastic Beanstalk, cannot read environment configuration")
+)
+
+// environment holds the fields of the environment configuration.
+type environment struct {
+	DeploymentID    json.Number `json:"deployment_id"`
+	VersionLabel    string      `json:"version_label"`
+	EnvironmentName string      `json:"environment_name"`
+}
+
+// resource detector collects resource information from Elastic Beanstalk environment
+type resourceDetector struct {
+	confPath  string
+	schemaURL string
+}
+
+// compile time assertion that resource detector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that will detect AWS Elastic Beanstalk resources.
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	confPath := linuxConfPath
+	if runtime.GOOS == "windows" {
+		confPath = windowsConfPath
+	}
+	return &resourceDetector{confPath: confPath, schemaURL: c.schemaURL}
+}
+
+// Detect collects resource attributes from the environment configuration of
+// Elastic Beanstalk. The environment name is used as the service namespace,
+// the deployment ID as the service instance ID, and the version label as the
+// service version.
+func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, error) {
+	data, err := ioutil.ReadFile(detector.confPath)
+	if os.IsNotExist(err) {
+		return empty, errNotOnBeanstalk
+	}
+	if err != nil {
+		return empty, fmt.Errorf("failed to read Elastic Beanstalk environment configuration: %w", err)
+	}
+
+	var env environment
+	if err := json.Unmarshal(data, &env); err != nil {
+		return empty, fmt.Errorf("failed to parse Elastic Beanstalk environment configuration: %w", err)
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSElasticBeanstalk,
+	}
+	if env.EnvironmentName != "" {
+		attrs = append(attrs, semconv.ServiceNamespaceKey.String(env.EnvironmentName))
+	}
+	if env.DeploymentID != "" {
+		attrs = append(attrs, semconv.ServiceInstanceIDKey.String(env.DeploymentID.String()))
+	}
+	if env.VersionLabel != "" {
+		attrs = append(attrs, semconv.ServiceVersionKey.String(env.VersionLabel))
+	}
+
+	return resource.NewWithAttributes(detector.schemaURL, attrs...), nil
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beanstalk
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func writeConf(t *testing.T, data string) string {
+	path := filepath.Join(t.TempDir(), "environment.conf")
+	require.NoError(t, ioutil.WriteFile(path, []byte(data), 0600))
+	return path
+}
+
+// successfully return resource when process is running on Elastic Beanstalk
+func TestDetectSuccess(t *testing.T) {
+	path := writeConf(t, `{"deployment_id":23,"version_label":"env-version-1234","environment_name":"BETA"}`)
+
+	attributes := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSElasticBeanstalk,
+		semconv.ServiceNamespaceKey.String("BETA"),
+		semconv.ServiceInstanceIDKey.String("23"),
+		semconv.ServiceVersionKey.String("env-version-1234"),
+	}
+	expectedResource := resource.NewWithAttributes(semconv.SchemaURL, attributes...)
+	detector := resourceDetector{confPath: path, schemaURL: semconv.SchemaURL}
+	res, err := detector.Detect(context.Background())
+
+	assert.Nil(t, err, "Detector unexpectedly returned error")
+	assert.Equal(t, expectedResource, res, "Resource returned is incorrect")
+}
+
+// return a resource with the configured schema URL
+func TestDetectSchemaURL(t *testing.T) {
+	detector := NewResourceDetector(WithSchemaURL("")).(*resourceDetector)
+	detector.confPath = writeConf(t, `{"environment_name":"BETA"}`)
+	res, err := detector.Detect(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewSchemaless(
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSElasticBeanstalk,
+		semconv.ServiceNamespaceKey.String("BETA"),
+	), res)
+}
+
+// return error when the configuration cannot be parsed
+func TestDetectInvalidConf(t *testing.T) {
+	detector := resourceDetector{confPath: writeConf(t, "not json")}
+	res, err := detector.Detect(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, len(res.Attributes()))
+}
+
+// return empty resource when not running on Elastic Beanstalk
+func TestReturnsIfNoConf(t *testing.T) {
+	detector := resourceDetector{confPath: filepath.Join(t.TempDir(), "missing.conf")}
+	res, err := detector.Detect(context.Background())
+
+	assert.Equal(t, errNotOnBeanstalk, err)
+	assert.Equal(t, 0, len(res.Attributes()))
+}
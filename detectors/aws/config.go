@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"time"
+
+	"go.opentelemetry.io/contrib/detectors"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// defaultTimeout bounds the detection of all environments.
+const defaultTimeout = 10 * time.Second
+
+type config struct {
+	// timeout bounds the detection of all environments. A value of zero
+	// means no timeout other than the deadline of the context passed to
+	// Detect.
+	timeout time.Duration
+	// schemaURL is the schema URL of the detected Resource.
+	schemaURL string
+	// probeFunc is notified of the probes of the detection, if not nil.
+	probeFunc detectors.ProbeFunc
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{
+		timeout:   defaultTimeout,
+		schemaURL: semconv.SchemaURL,
+	}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies an AWS resource detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithTimeout sets the maximum duration of the detection of all
+// environments. Detectors still running when it expires are abandoned. The
+// default is 10 seconds. A value of zero disables the timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.timeout = timeout
+	})
+}
+
+// WithSchemaURL sets the schema URL of the Resource of every environment.
+func WithSchemaURL(schemaURL string) Option {
+	return optionFunc(func(c *config) {
+		c.schemaURL = schemaURL
+	})
+}
+
+// WithProbeFunc sets a ProbeFunc notified of the probe of each environment,
+// and of the probes of the EKS and ECS detectors.
+func WithProbeFunc(fn detectors.ProbeFunc) Option {
+	return optionFunc(func(c *config) {
+		c.probeFunc = fn
+	})
+}
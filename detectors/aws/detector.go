@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aws provides a resource detector that detects the AWS environment
+// a process runs in.
+package aws
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/contrib/detectors"
+	"go.opentelemetry.io/contrib/detectors/aws/beanstalk"
+	"go.opentelemetry.io/contrib/detectors/aws/ec2"
+	"go.opentelemetry.io/contrib/detectors/aws/ecs"
+	"go.opentelemetry.io/contrib/detectors/aws/eks"
+	"go.opentelemetry.io/contrib/detectors/aws/lambda"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// resourceDetector runs the detectors of the AWS environments in order and
+// returns the resource of the first one that detects its environment.
+type resourceDetector struct {
+	environments []environment
+	timeout      time.Duration
+	schemaURL    string
+	probeFunc    detectors.ProbeFunc
+}
+
+// environment is an AWS environment and the detector probing it.
+type environment struct {
+	name     string
+	detector resource.Detector
+}
+
+// compile time assertion that resourceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that detects the AWS
+// environment a process runs in. The environments are probed in the order
+// Lambda, ECS, EKS, Elastic Beanstalk, and EC2, so that the most specific
+// environment is reported when they overlap, e.g. for EKS worker nodes that
+// are also EC2 instances.
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &resourceDetector{
+		environments: []environment{
+			{"lambda", lambda.NewResourceDetector()},
+			{"ecs", ecs.NewResourceDetector(ecs.WithProbeFunc(c.probeFunc))},
+			{"eks", eks.NewResourceDetector(eks.WithProbeFunc(c.probeFunc))},
+			{"beanstalk", beanstalk.NewResourceDetector()},
+			{"ec2", ec2.NewResourceDetector()},
+		},
+		timeout:   c.timeout,
+		schemaURL: c.schemaURL,
+		probeFunc: c.probeFunc,
+	}
+}
+
+// Detect returns the Resource of the first AWS environment detected. A
+// detector that fails or returns an empty Resource is skipped, unless it
+// returns a partial Resource along with an error wrapping
+// resource.ErrPartialResource, which is returned as well. The schema URL of
+// the Resource is set to the one configured with WithSchemaURL. An empty
+// Resource is returned if no AWS environment is detected.
+func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if detector.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, detector.timeout)
+		defer cancel()
+	}
+
+	for _, env := range detector.environments {
+		start := time.Now()
+		res, err := detect(ctx, env.detector)
+		detector.probed(env.name, start, res, err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return resource.Empty(), ctxErr
+		}
+		if res == nil || res.Len() == 0 {
+			continue
+		}
+		if err == nil || errors.Is(err, resource.ErrPartialResource) {
+			if res.SchemaURL() != detector.schemaURL {
+				res = resource.NewWithAttributes(detector.schemaURL, res.Attributes()...)
+			}
+			return res, err
+		}
+	}
+	return resource.Empty(), nil
+}
+
+// probed notifies the configured ProbeFunc, if any, of the probe of an
+// environment started at start.
+func (detector *resourceDetector) probed(env string, start time.Time, res *resource.Resource, err error) {
+	if detector.probeFunc == nil {
+		return
+	}
+	detector.probeFunc(detectors.ProbeEvent{
+		Detector: "aws",
+		Probe:    env,
+		Found:    res != nil && res.Len() > 0,
+		Err:      err,
+		Duration: time.Since(start),
+	})
+}
+
+type detectResult struct {
+	res *resource.Resource
+	err error
+}
+
+// detect runs d, returning early if ctx is done. Not all detectors honor
+// the context passed to them, so they are run in a separate goroutine.
+func detect(ctx context.Context, d resource.Detector) (*resource.Resource, error) {
+	ch := make(chan detectResult, 1)
+	go func() {
+		res, err := d.Detect(ctx)
+		ch <- detectResult{res: res, err: err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.res, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"go.opentelemetry.io/contrib/detectors"
+	"go.opentelemetry.io/contrib/detectors/aws/eks"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+type detectorFunc func(context.Context) (*resource.Resource, error)
+
+func (fn detectorFunc) Detect(ctx context.Context) (*resource.Resource, error) {
+	return fn(ctx)
+}
+
+func staticDetector(res *resource.Resource, err error) resource.Detector {
+	return detectorFunc(func(context.Context) (*resource.Resource, error) {
+		return res, err
+	})
+}
+
+func blockingDetector() resource.Detector {
+	return detectorFunc(func(context.Context) (*resource.Resource, error) {
+		// Simulate a detector that does not honor its context.
+		time.Sleep(time.Second)
+		return resource.NewSchemaless(attribute.String("env", "slow")), nil
+	})
+}
+
+// testEnvironments returns the environments probed by detectors, named by
+// their index.
+func testEnvironments(detectors ...resource.Detector) []environment {
+	environments := make([]environment, len(detectors))
+	for i, d := range detectors {
+		environments[i] = environment{name: fmt.Sprint(i), detector: d}
+	}
+	return environments
+}
+
+func TestDetectFirstMatch(t *testing.T) {
+	ecs := resource.NewSchemaless(attribute.String("env", "ecs"))
+	detector := &resourceDetector{environments: testEnvironments(
+		staticDetector(resource.Empty(), errors.New("not on lambda")),
+		staticDetector(ecs, nil),
+		staticDetector(resource.NewSchemaless(attribute.String("env", "ec2")), nil),
+	)}
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, ecs, res)
+}
+
+func TestDetectSkipsEmpty(t *testing.T) {
+	ec2 := resource.NewSchemaless(attribute.String("env", "ec2"))
+	detector := &resourceDetector{environments: testEnvironments(
+		staticDetector(nil, nil),
+		staticDetector(resource.Empty(), nil),
+		staticDetector(resource.NewSchemaless(attribute.String("env", "failed")), errors.New("failed")),
+		staticDetector(ec2, nil),
+	)}
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, ec2, res)
+}
+
+func TestDetectPartial(t *testing.T) {
+	eks := resource.NewSchemaless(attribute.String("env", "eks"))
+	partialErr := fmt.Errorf("%w: cluster name", resource.ErrPartialResource)
+	detector := &resourceDetector{environments: testEnvironments(
+		staticDetector(eks, partialErr),
+		staticDetector(resource.NewSchemaless(attribute.String("env", "ec2")), nil),
+	)}
+
+	res, err := detector.Detect(context.Background())
+	assert.Equal(t, partialErr, err)
+	assert.Equal(t, eks, res)
+}
+
+// Tests that a Kubernetes cluster on EC2 without the aws-auth configmap of EKS
+// is detected as EC2.
+func TestDetectKubernetesOnEC2(t *testing.T) {
+	dir := t.TempDir()
+	tokenPath, caPath := filepath.Join(dir, "token"), filepath.Join(dir, "ca.crt")
+	for _, path := range []string{tokenPath, caPath} {
+		require.NoError(t, ioutil.WriteFile(path, []byte("test"), 0600))
+	}
+
+	ec2 := resource.NewSchemaless(semconv.CloudProviderAWS, semconv.CloudPlatformAWSEC2)
+	detector := &resourceDetector{environments: testEnvironments(
+		eks.NewResourceDetector(
+			eks.WithKubernetesClient(fake.NewSimpleClientset()),
+			eks.WithServiceAccountPaths(tokenPath, caPath, ""),
+		),
+		staticDetector(ec2, nil),
+	)}
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, ec2, res)
+}
+
+func TestDetectSchemaURL(t *testing.T) {
+	detector := &resourceDetector{
+		environments: testEnvironments(
+			staticDetector(resource.NewWithAttributes(semconv.SchemaURL, semconv.CloudProviderAWS), nil),
+		),
+		schemaURL: "https://opentelemetry.io/schemas/1.4.0",
+	}
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes("https://opentelemetry.io/schemas/1.4.0", semconv.CloudProviderAWS), res)
+}
+
+func TestDetectNoMatch(t *testing.T) {
+	detector := &resourceDetector{environments: testEnvironments(
+		staticDetector(resource.Empty(), errors.New("not on lambda")),
+	)}
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), res)
+}
+
+func TestDetectProbes(t *testing.T) {
+	var events []detectors.ProbeEvent
+	errNotOnLambda := errors.New("not on lambda")
+	ecs := resource.NewSchemaless(attribute.String("env", "ecs"))
+	detector := &resourceDetector{
+		environments: testEnvironments(
+			staticDetector(resource.Empty(), errNotOnLambda),
+			staticDetector(ecs, nil),
+			staticDetector(resource.NewSchemaless(attribute.String("env", "ec2")), nil),
+		),
+		probeFunc: func(e detectors.ProbeEvent) { events = append(events, e) },
+	}
+
+	_, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	// The environments after the detected one are not probed.
+	require.Len(t, events, 2)
+	assert.Equal(t, "aws", events[0].Detector)
+	assert.Equal(t, "0", events[0].Probe)
+	assert.False(t, events[0].Found)
+	assert.Equal(t, errNotOnLambda, events[0].Err)
+	assert.Equal(t, "1", events[1].Probe)
+	assert.True(t, events[1].Found)
+	assert.NoError(t, events[1].Err)
+}
+
+func TestDetectTimeout(t *testing.T) {
+	detector := &resourceDetector{
+		environments: testEnvironments(blockingDetector()),
+		timeout:      time.Millisecond,
+	}
+
+	start := time.Now()
+	res, err := detector.Detect(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, resource.Empty(), res)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestNewConfig(t *testing.T) {
+	assert.Equal(t, defaultTimeout, newConfig().timeout)
+	assert.Equal(t, time.Second, newConfig(WithTimeout(time.Second)).timeout)
+	assert.Equal(t, semconv.SchemaURL, newConfig().schemaURL)
+	assert.Equal(t, "", newConfig(WithSchemaURL("")).schemaURL)
+}
+
+func TestNewResourceDetector(t *testing.T) {
+	detector, ok := NewResourceDetector(WithTimeout(time.Second)).(*resourceDetector)
+	require.True(t, ok)
+	assert.Len(t, detector.environments, 5)
+	assert.Equal(t, time.Second, detector.timeout)
+	assert.Nil(t, detector.probeFunc)
+
+	detector, ok = NewResourceDetector(WithProbeFunc(detectors.LogProbes(nil))).(*resourceDetector)
+	require.True(t, ok)
+	assert.NotNil(t, detector.probeFunc)
+}
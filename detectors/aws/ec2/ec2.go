@@ -16,25 +16,31 @@ package ec2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
-	"github.com/aws/aws-sdk-go/aws/session"
 
+	"go.opentelemetry.io/contrib/detectors/aws/internal/imds"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 )
 
 type config struct {
-	c Client
+	c        Client
+	endpoint string
+	timeout   time.Duration
+	hopLimit  int
+	schemaURL string
 }
 
 // newConfig returns an appropriately configured config.
 func newConfig(options ...Option) *config {
-	c := new(config)
+	c := &config{schemaURL: semconv.SchemaURL}
 	for _, option := range options {
 		option.apply(c)
 	}
@@ -59,13 +65,53 @@ func WithClient(t Client) Option {
 	})
 }
 
+// WithEndpoint sets the endpoint of the instance metadata service used by
+// the default client. It has no effect if a client is set with WithClient. If
+// unset, the AWS_EC2_METADATA_SERVICE_ENDPOINT environment variable is used,
+// and then "http://169.254.169.254".
+func WithEndpoint(endpoint string) Option {
+	return optionFunc(func(c *config) {
+		c.endpoint = endpoint
+	})
+}
+
+// WithTimeout sets the timeout of each request the default client makes to
+// the instance metadata service. It has no effect if a client is set with
+// WithClient. If unset, requests time out after 2 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.timeout = timeout
+	})
+}
+
+// WithHopLimit sets the hop limit, or IP time to live, of the packets the
+// default client sends to the instance metadata service. It has no effect if a
+// client is set with WithClient, and is not supported on Windows. If unset,
+// the hop limit of the system is used.
+func WithHopLimit(hops int) Option {
+	return optionFunc(func(c *config) {
+		c.hopLimit = hops
+	})
+}
+
+// WithSchemaURL sets the schema URL of the detected Resource.
+func WithSchemaURL(schemaURL string) Option {
+	return optionFunc(func(c *config) {
+		c.schemaURL = schemaURL
+	})
+}
+
 func (cfg *config) getClient() Client {
 	return cfg.c
 }
 
 // resource detector collects resource information from EC2 environment
 type resourceDetector struct {
-	c Client
+	c         Client
+	endpoint  string
+	timeout   time.Duration
+	hopLimit  int
+	schemaURL string
 }
 
 // Client implements methods to capture EC2 environment metadata information
@@ -81,16 +127,12 @@ var _ resource.Detector = (*resourceDetector)(nil)
 //NewResourceDetector returns a resource detector that will detect AWS EC2 resources.
 func NewResourceDetector(opts ...Option) resource.Detector {
 	c := newConfig(opts...)
-	return &resourceDetector{c.getClient()}
+	return &resourceDetector{c: c.getClient(), endpoint: c.endpoint, timeout: c.timeout, hopLimit: c.hopLimit, schemaURL: c.schemaURL}
 }
 
 // Detect detects associated resources when running in AWS environment.
 func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resource, error) {
-	client, err := detector.client()
-	if err != nil {
-		return nil, err
-	}
-
+	client := detector.client(ctx)
 	if !client.Available() {
 		return nil, nil
 	}
@@ -120,20 +162,57 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 		err = fmt.Errorf("%w: %s", resource.ErrPartialResource, m.errs)
 	}
 
-	return resource.NewWithAttributes(semconv.SchemaURL, attributes...), err
+	return resource.NewWithAttributes(detector.schemaURL, attributes...), err
 }
 
-func (detector *resourceDetector) client() (Client, error) {
+func (detector *resourceDetector) client(ctx context.Context) Client {
 	if detector.c != nil {
-		return detector.c, nil
+		return detector.c
 	}
 
-	s, err := session.NewSession()
+	// The client uses IMDSv2 session tokens and only falls back to IMDSv1
+	// if no token can be retrieved.
+	return imdsClient{ctx: ctx, client: imds.NewClient(imds.Config{
+		Endpoint: detector.endpoint,
+		Timeout:  detector.timeout,
+		HopLimit: detector.hopLimit,
+	})}
+}
+
+// imdsClient is the default Client, which makes the requests of the detection
+// with its context.
+type imdsClient struct {
+	ctx    context.Context
+	client *imds.Client
+}
+
+func (c imdsClient) Available() bool {
+	return c.client.Available(c.ctx)
+}
+
+func (c imdsClient) GetInstanceIdentityDocument() (ec2metadata.EC2InstanceIdentityDocument, error) {
+	doc, err := c.client.GetInstanceIdentityDocument(c.ctx)
 	if err != nil {
-		return nil, err
+		return ec2metadata.EC2InstanceIdentityDocument{}, err
 	}
+	return ec2metadata.EC2InstanceIdentityDocument{
+		AccountID:        doc.AccountID,
+		Architecture:     doc.Architecture,
+		AvailabilityZone: doc.AvailabilityZone,
+		ImageID:          doc.ImageID,
+		InstanceID:       doc.InstanceID,
+		InstanceType:     doc.InstanceType,
+		KernelID:         doc.KernelID,
+		PendingTime:      doc.PendingTime,
+		PrivateIP:        doc.PrivateIP,
+		RamdiskID:        doc.RamdiskID,
+		Region:           doc.Region,
+		Version:          doc.Version,
+	}, nil
+}
 
-	return ec2metadata.New(s), nil
+func (c imdsClient) GetMetadata(p string) (string, error) {
+	return c.client.GetMetadata(c.ctx, p)
 }
 
 type metadata struct {
@@ -148,6 +227,9 @@ func (m *metadata) add(k attribute.Key, n string) {
 		m.attributes = append(m.attributes, k.String(v))
 		return
 	}
+	if errors.Is(err, imds.ErrNotFound) {
+		return
+	}
 
 	rf, ok := err.(awserr.RequestFailure)
 	if !ok {
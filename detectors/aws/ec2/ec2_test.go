@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -200,3 +201,64 @@ func (c *clientMock) GetMetadata(p string) (string, error) {
 
 	return v.value, v.err
 }
+
+func TestAWS_DetectIMDSv2(t *testing.T) {
+	const token = "test-token"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest/api/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("X-aws-ec2-metadata-token-ttl-seconds", r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds"))
+		_, _ = w.Write([]byte(token))
+	})
+	handle := func(path, body string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			// Only IMDSv2 requests are accepted.
+			if r.Header.Get("X-aws-ec2-metadata-token") != token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(body))
+		})
+	}
+	handle("/latest/meta-data/instance-id", "i-1234567890abcdef0")
+	handle("/latest/meta-data/hostname", "ip-10-158-112-84.us-west-2.compute.internal")
+	handle("/latest/dynamic/instance-identity/document", `{
+		"availabilityZone": "us-west-2b",
+		"region": "us-west-2",
+		"instanceId": "i-1234567890abcdef0",
+		"instanceType": "t2.micro",
+		"accountId": "123456789012",
+		"imageId": "ami-5fb8c835"
+	}`)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	detector := NewResourceDetector(WithEndpoint(srv.URL), WithTimeout(time.Second))
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEC2,
+		semconv.CloudRegionKey.String("us-west-2"),
+		semconv.CloudAvailabilityZoneKey.String("us-west-2b"),
+		semconv.CloudAccountIDKey.String("123456789012"),
+		semconv.HostIDKey.String("i-1234567890abcdef0"),
+		semconv.HostImageIDKey.String("ami-5fb8c835"),
+		semconv.HostTypeKey.String("t2.micro"),
+		semconv.HostNameKey.String("ip-10-158-112-84.us-west-2.compute.internal"),
+	), r)
+}
+
+func TestNewConfig(t *testing.T) {
+	c := newConfig(WithEndpoint("http://localhost:1338"), WithTimeout(time.Second), WithHopLimit(2))
+	assert.Equal(t, "http://localhost:1338", c.endpoint)
+	assert.Equal(t, time.Second, c.timeout)
+	assert.Equal(t, 2, c.hopLimit)
+	assert.Nil(t, c.getClient())
+	assert.Equal(t, semconv.SchemaURL, c.schemaURL)
+	assert.Equal(t, "", newConfig(WithSchemaURL("")).schemaURL)
+}
@@ -2,9 +2,12 @@ module go.opentelemetry.io/contrib/detectors/aws/ec2
 
 go 1.15
 
+replace go.opentelemetry.io/contrib/detectors/aws/internal => ../internal
+
 require (
 	github.com/aws/aws-sdk-go v1.41.11
 	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/detectors/aws/internal v1.1.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/sdk v1.1.0
 )
@@ -17,10 +17,15 @@ package ecs
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
+	"go.opentelemetry.io/contrib/detectors"
+	"go.opentelemetry.io/contrib/detectors/aws/internal/imds"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
@@ -33,6 +38,7 @@ const (
 	metadataV4EnvVar  = "ECS_CONTAINER_METADATA_URI_V4"
 	containerIDLength = 64
 	defaultCgroupPath = "/proc/self/cgroup"
+	metadataTimeout   = 5 * time.Second
 )
 
 var (
@@ -40,6 +46,7 @@ var (
 	errCannotReadContainerID   = errors.New("failed to read container ID from cGroupFile")
 	errCannotReadContainerName = errors.New("failed to read hostname")
 	errCannotReadCGroupFile    = errors.New("ECS resource detector failed to read cGroupFile")
+	errCannotReadMetadata      = errors.New("ECS resource detector failed to read task metadata")
 	errNotOnECS                = errors.New("process is not on ECS, cannot detect environment variables from ECS")
 )
 
@@ -47,14 +54,21 @@ var (
 type detectorUtils interface {
 	getContainerName() (string, error)
 	getContainerID() (string, error)
+	getMetadataV4(ctx context.Context, uri string) (*metadataV4, error)
+	getAvailabilityZone(ctx context.Context) (string, error)
 }
 
 // struct implements detectorUtils interface
-type ecsDetectorUtils struct{}
+type ecsDetectorUtils struct {
+	client *http.Client
+	imds   *imds.Client
+}
 
 // resource detector collects resource information from Elastic Container Service environment
 type resourceDetector struct {
-	utils detectorUtils
+	utils     detectorUtils
+	schemaURL string
+	probeFunc detectors.ProbeFunc
 }
 
 // compile time assertion that ecsDetectorUtils implements detectorUtils interface
@@ -64,11 +78,26 @@ var _ detectorUtils = (*ecsDetectorUtils)(nil)
 var _ resource.Detector = (*resourceDetector)(nil)
 
 // NewResourceDetector returns a resource detector that will detect AWS ECS resources.
-func NewResourceDetector() resource.Detector {
-	return &resourceDetector{utils: ecsDetectorUtils{}}
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &resourceDetector{
+		utils: ecsDetectorUtils{
+			client: &http.Client{Timeout: metadataTimeout},
+			imds:   imds.NewClient(imds.Config{}),
+		},
+		schemaURL: c.schemaURL,
+		probeFunc: c.probeFunc,
+	}
 }
 
-// Detect finds associated resources when running on ECS environment.
+// Detect finds associated resources when running on ECS environment. When
+// the Task Metadata Endpoint v4 is available, the task and container
+// attributes, including the name and image of the container and its
+// CloudWatch log group and stream, are read from it as well. The
+// availability zone of tasks running on EC2 is read from the instance
+// metadata service when the task metadata does not have it. If the task
+// metadata cannot be read, the other attributes are returned along with an
+// error wrapping resource.ErrPartialResource.
 func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resource, error) {
 	metadataURIV3 := os.Getenv(metadataV3EnvVar)
 	metadataURIV4 := os.Getenv(metadataV4EnvVar)
@@ -76,22 +105,78 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 	if len(metadataURIV3) == 0 && len(metadataURIV4) == 0 {
 		return empty, errNotOnECS
 	}
+	start := time.Now()
 	hostName, err := detector.utils.getContainerName()
+	detector.probed("container name", start, hostName != "", err)
 	if err != nil {
 		return empty, err
 	}
+	start = time.Now()
 	containerID, err := detector.utils.getContainerID()
+	detector.probed("container id", start, containerID != "", err)
 	if err != nil {
 		return empty, err
 	}
+
+	var (
+		metadata    *metadataV4
+		metadataErr error
+	)
+	if len(metadataURIV4) > 0 {
+		start = time.Now()
+		metadata, metadataErr = detector.utils.getMetadataV4(ctx, metadataURIV4)
+		detector.probed("task metadata", start, metadata != nil, metadataErr)
+	}
+	if metadata != nil {
+		if metadata.task.AvailabilityZone == "" && strings.EqualFold(metadata.task.LaunchType, "EC2") {
+			// The availability zone is omitted when the instance metadata
+			// service cannot be reached from the task.
+			start = time.Now()
+			zone, err := detector.utils.getAvailabilityZone(ctx)
+			detector.probed("availability zone", start, zone != "", err)
+			if err == nil {
+				metadata.task.AvailabilityZone = zone
+			}
+		}
+		// The name of the container in the task definition is preferred
+		// over the host name, which is the container ID by default.
+		if metadata.container.Name != "" {
+			hostName = metadata.container.Name
+		}
+	}
+
 	attributes := []attribute.KeyValue{
 		semconv.CloudProviderAWS,
 		semconv.CloudPlatformAWSECS,
 		semconv.ContainerNameKey.String(hostName),
 		semconv.ContainerIDKey.String(containerID),
 	}
+	if metadata != nil {
+		attributes = append(attributes, metadata.attributes()...)
+	}
 
-	return resource.NewWithAttributes(semconv.SchemaURL, attributes...), nil
+	res := resource.NewWithAttributes(detector.schemaURL, attributes...)
+	if metadataErr != nil {
+		// The attributes that do not depend on the task metadata are still
+		// returned.
+		return res, fmt.Errorf("%w: %v", resource.ErrPartialResource, metadataErr)
+	}
+	return res, nil
+}
+
+// probed notifies the configured ProbeFunc, if any, of a probe started at
+// start.
+func (detector *resourceDetector) probed(probe string, start time.Time, found bool, err error) {
+	if detector.probeFunc == nil {
+		return
+	}
+	detector.probeFunc(detectors.ProbeEvent{
+		Detector: TypeStr,
+		Probe:    probe,
+		Found:    found,
+		Err:      err,
+		Duration: time.Since(start),
+	})
 }
 
 // returns docker container ID from default c group path
@@ -109,6 +194,12 @@ func (ecsUtils ecsDetectorUtils) getContainerID() (string, error) {
 	return "", errCannotReadContainerID
 }
 
+// returns the availability zone of the EC2 instance from the instance
+// metadata service
+func (ecsUtils ecsDetectorUtils) getAvailabilityZone(ctx context.Context) (string, error) {
+	return ecsUtils.imds.GetMetadata(ctx, "placement/availability-zone")
+}
+
 // returns host name reported by the kernel
 func (ecsUtils ecsDetectorUtils) getContainerName() (string, error) {
 	hostName, err := os.Hostname()
@@ -16,12 +16,15 @@ package ecs
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/contrib/detectors"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
@@ -42,6 +45,17 @@ func (detectorUtils *MockDetectorUtils) getContainerName() (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+func (detectorUtils *MockDetectorUtils) getAvailabilityZone(_ context.Context) (string, error) {
+	args := detectorUtils.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (detectorUtils *MockDetectorUtils) getMetadataV4(_ context.Context, uri string) (*metadataV4, error) {
+	args := detectorUtils.Called(uri)
+	m, _ := args.Get(0).(*metadataV4)
+	return m, args.Error(1)
+}
+
 //succesfully return resource when process is running on Amazon ECS environment
 func TestDetect(t *testing.T) {
 	os.Clearenv()
@@ -52,20 +66,71 @@ func TestDetect(t *testing.T) {
 
 	detectorUtils.On("getContainerName").Return("container-Name", nil)
 	detectorUtils.On("getContainerID").Return("0123456789A", nil)
+	detectorUtils.On("getMetadataV4", "4").Return(&metadataV4{
+		container: containerMetadataV4{
+			Name:         "curl",
+			ContainerARN: "arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9",
+		},
+		task: taskMetadataV4{
+			Cluster:    "default",
+			TaskARN:    "arn:aws:ecs:us-west-2:111122223333:task/default/158d1c8083dd49d6b527399fd6414f5c",
+			Family:     "curltest",
+			Revision:   "26",
+			LaunchType: "EC2",
+		},
+	}, nil)
+	detectorUtils.On("getAvailabilityZone").Return("us-west-2a", nil)
 
 	attributes := []attribute.KeyValue{
 		semconv.CloudProviderAWS,
 		semconv.CloudPlatformAWSECS,
-		semconv.ContainerNameKey.String("container-Name"),
+		semconv.ContainerNameKey.String("curl"),
 		semconv.ContainerIDKey.String("0123456789A"),
+		semconv.CloudRegionKey.String("us-west-2"),
+		semconv.CloudAccountIDKey.String("111122223333"),
+		semconv.CloudAvailabilityZoneKey.String("us-west-2a"),
+		semconv.AWSECSContainerARNKey.String("arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9"),
+		semconv.AWSECSClusterARNKey.String("arn:aws:ecs:us-west-2:111122223333:cluster/default"),
+		semconv.AWSECSLaunchtypeEC2,
+		semconv.AWSECSTaskARNKey.String("arn:aws:ecs:us-west-2:111122223333:task/default/158d1c8083dd49d6b527399fd6414f5c"),
+		semconv.AWSECSTaskFamilyKey.String("curltest"),
+		semconv.AWSECSTaskRevisionKey.String("26"),
 	}
 	expectedResource := resource.NewWithAttributes(semconv.SchemaURL, attributes...)
-	detector := &resourceDetector{utils: detectorUtils}
+	detector := &resourceDetector{utils: detectorUtils, schemaURL: semconv.SchemaURL}
 	res, _ := detector.Detect(context.Background())
 
 	assert.Equal(t, res, expectedResource, "Resource returned is incorrect")
 }
 
+func TestDetectProbes(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv(metadataV4EnvVar, "4")
+
+	detectorUtils := new(MockDetectorUtils)
+
+	errUnreachable := errors.New("unreachable")
+	detectorUtils.On("getContainerName").Return("container-Name", nil)
+	detectorUtils.On("getContainerID").Return("0123456789A", nil)
+	detectorUtils.On("getMetadataV4", "4").Return(nil, errUnreachable)
+
+	var events []detectors.ProbeEvent
+	detector := &resourceDetector{utils: detectorUtils, probeFunc: func(e detectors.ProbeEvent) {
+		events = append(events, e)
+	}}
+	_, err := detector.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+
+	require.Len(t, events, 3)
+	for i, probe := range []string{"container name", "container id", "task metadata"} {
+		assert.Equal(t, "ecs", events[i].Detector)
+		assert.Equal(t, probe, events[i].Probe)
+	}
+	assert.True(t, events[1].Found)
+	assert.False(t, events[2].Found)
+	assert.Equal(t, errUnreachable, events[2].Err)
+}
+
 //returns empty resource when detector cannot read container ID
 func TestDetectCannotReadContainerID(t *testing.T) {
 	os.Clearenv()
@@ -76,7 +141,7 @@ func TestDetectCannotReadContainerID(t *testing.T) {
 	detectorUtils.On("getContainerName").Return("container-Name", nil)
 	detectorUtils.On("getContainerID").Return("", errCannotReadContainerID)
 
-	detector := &resourceDetector{utils: detectorUtils}
+	detector := &resourceDetector{utils: detectorUtils, schemaURL: semconv.SchemaURL}
 	res, err := detector.Detect(context.Background())
 
 	assert.Equal(t, errCannotReadContainerID, err)
@@ -93,13 +158,54 @@ func TestDetectCannotReadContainerName(t *testing.T) {
 	detectorUtils.On("getContainerName").Return("", errCannotReadContainerName)
 	detectorUtils.On("getContainerID").Return("0123456789A", nil)
 
-	detector := &resourceDetector{utils: detectorUtils}
+	detector := &resourceDetector{utils: detectorUtils, schemaURL: semconv.SchemaURL}
 	res, err := detector.Detect(context.Background())
 
 	assert.Equal(t, errCannotReadContainerName, err)
 	assert.Equal(t, 0, len(res.Attributes()))
 }
 
+//returns a partial resource when detector cannot read the task metadata
+func TestDetectCannotReadMetadata(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv(metadataV4EnvVar, "4")
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("getContainerName").Return("container-Name", nil)
+	detectorUtils.On("getContainerID").Return("0123456789A", nil)
+	detectorUtils.On("getMetadataV4", "4").Return(nil, errCannotReadMetadata)
+
+	detector := &resourceDetector{utils: detectorUtils, schemaURL: semconv.SchemaURL}
+	res, err := detector.Detect(context.Background())
+
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.Contains(t, err.Error(), errCannotReadMetadata.Error())
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSECS,
+		semconv.ContainerNameKey.String("container-Name"),
+		semconv.ContainerIDKey.String("0123456789A"),
+	), res)
+	detectorUtils.AssertExpectations(t)
+}
+
+//does not read the task metadata when only the v3 endpoint is available
+func TestDetectMetadataV3(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv(metadataV3EnvVar, "3")
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("getContainerName").Return("container-Name", nil)
+	detectorUtils.On("getContainerID").Return("0123456789A", nil)
+
+	detector := &resourceDetector{utils: detectorUtils, schemaURL: semconv.SchemaURL}
+	res, err := detector.Detect(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(res.Attributes()))
+	detectorUtils.AssertExpectations(t)
+}
+
 //returns empty resource when process is not running ECS
 func TestReturnsIfNoEnvVars(t *testing.T) {
 	os.Clearenv()
@@ -109,3 +215,20 @@ func TestReturnsIfNoEnvVars(t *testing.T) {
 	assert.Equal(t, errNotOnECS, err)
 	assert.Equal(t, 0, len(res.Attributes()))
 }
+
+//returns a resource with the configured schema URL
+func TestDetectSchemaURL(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv(metadataV3EnvVar, "3")
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("getContainerName").Return("container-Name", nil)
+	detectorUtils.On("getContainerID").Return("0123456789A", nil)
+
+	detector := NewResourceDetector(WithSchemaURL("https://opentelemetry.io/schemas/1.4.0")).(*resourceDetector)
+	detector.utils = detectorUtils
+	res, err := detector.Detect(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.4.0", res.SchemaURL())
+}
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// containerMetadataV4 holds the fields of the container metadata returned by
+// the Task Metadata Endpoint v4 used by the detector.
+type containerMetadataV4 struct {
+	Name         string `json:"Name"`
+	ContainerARN string `json:"ContainerARN"`
+	Image        string `json:"Image"`
+	LogDriver    string `json:"LogDriver"`
+	LogOptions   struct {
+		Group  string `json:"awslogs-group"`
+		Region string `json:"awslogs-region"`
+		Stream string `json:"awslogs-stream"`
+	} `json:"LogOptions"`
+}
+
+// taskMetadataV4 holds the fields of the task metadata returned by the Task
+// Metadata Endpoint v4 used by the detector.
+type taskMetadataV4 struct {
+	Cluster          string `json:"Cluster"`
+	TaskARN          string `json:"TaskARN"`
+	Family           string `json:"Family"`
+	Revision         string `json:"Revision"`
+	LaunchType       string `json:"LaunchType"`
+	AvailabilityZone string `json:"AvailabilityZone"`
+}
+
+// metadataV4 is the metadata of the container and the task it belongs to.
+type metadataV4 struct {
+	container containerMetadataV4
+	task      taskMetadataV4
+}
+
+// getMetadataV4 retrieves the container and task metadata from the Task
+// Metadata Endpoint v4 at uri.
+func (ecsUtils ecsDetectorUtils) getMetadataV4(ctx context.Context, uri string) (*metadataV4, error) {
+	var m metadataV4
+	if err := ecsUtils.getJSON(ctx, uri, &m.container); err != nil {
+		return nil, err
+	}
+	if err := ecsUtils.getJSON(ctx, uri+"/task", &m.task); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (ecsUtils ecsDetectorUtils) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errCannotReadMetadata, err)
+	}
+	resp, err := ecsUtils.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errCannotReadMetadata, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s returned %s", errCannotReadMetadata, url, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", errCannotReadMetadata, err)
+	}
+	return nil
+}
+
+// attributes returns the resource attributes described by the metadata.
+func (m *metadataV4) attributes() []attribute.KeyValue {
+	var attributes []attribute.KeyValue
+
+	// Task ARNs have the form
+	// arn:<partition>:ecs:<region>:<account>:task/<cluster>/<id>.
+	var arn taskARN
+	if parts := strings.SplitN(m.task.TaskARN, ":", 6); len(parts) == 6 {
+		arn = taskARN{partition: parts[1], region: parts[3], account: parts[4]}
+	}
+	if arn.region != "" {
+		attributes = append(attributes, semconv.CloudRegionKey.String(arn.region))
+	}
+	if arn.account != "" {
+		attributes = append(attributes, semconv.CloudAccountIDKey.String(arn.account))
+	}
+	if m.task.AvailabilityZone != "" {
+		attributes = append(attributes, semconv.CloudAvailabilityZoneKey.String(m.task.AvailabilityZone))
+	}
+
+	if name, tag := m.image(); name != "" {
+		attributes = append(attributes, semconv.ContainerImageNameKey.String(name))
+		if tag != "" {
+			attributes = append(attributes, semconv.ContainerImageTagKey.String(tag))
+		}
+	}
+
+	if m.container.ContainerARN != "" {
+		attributes = append(attributes, semconv.AWSECSContainerARNKey.String(m.container.ContainerARN))
+	}
+	if cluster := m.clusterARN(arn); cluster != "" {
+		attributes = append(attributes, semconv.AWSECSClusterARNKey.String(cluster))
+	}
+	if m.task.LaunchType != "" {
+		attributes = append(attributes, semconv.AWSECSLaunchtypeKey.String(strings.ToLower(m.task.LaunchType)))
+	}
+	if m.task.TaskARN != "" {
+		attributes = append(attributes, semconv.AWSECSTaskARNKey.String(m.task.TaskARN))
+	}
+	if m.task.Family != "" {
+		attributes = append(attributes, semconv.AWSECSTaskFamilyKey.String(m.task.Family))
+	}
+	if m.task.Revision != "" {
+		attributes = append(attributes, semconv.AWSECSTaskRevisionKey.String(m.task.Revision))
+	}
+	return append(attributes, m.logAttributes(arn)...)
+}
+
+// image returns the name and tag of the image of the container. The tag is
+// empty when the image is referenced by digest or has no tag.
+func (m *metadataV4) image() (string, string) {
+	image := m.container.Image
+	if i := strings.IndexByte(image, '@'); i >= 0 {
+		return image[:i], ""
+	}
+	// The tag follows the last colon after the registry, which may have a
+	// port.
+	if i := strings.LastIndexByte(image, ':'); i > strings.LastIndexByte(image, '/') {
+		return image[:i], image[i+1:]
+	}
+	return image, ""
+}
+
+// logAttributes returns the attributes of the CloudWatch log group and
+// stream the awslogs log driver sends the logs of the container to. The
+// ARNs are built when the partition, region and account are known.
+func (m *metadataV4) logAttributes(arn taskARN) []attribute.KeyValue {
+	options := m.container.LogOptions
+	if m.container.LogDriver != "awslogs" || options.Group == "" {
+		return nil
+	}
+	if options.Region != "" {
+		arn.region = options.Region
+	}
+
+	attributes := []attribute.KeyValue{semconv.AWSLogGroupNamesKey.StringSlice([]string{options.Group})}
+	var groupARN string
+	if arn.valid() {
+		groupARN = fmt.Sprintf("arn:%s:logs:%s:%s:log-group:%s", arn.partition, arn.region, arn.account, options.Group)
+		attributes = append(attributes, semconv.AWSLogGroupARNsKey.StringSlice([]string{groupARN + ":*"}))
+	}
+	if options.Stream != "" {
+		attributes = append(attributes, semconv.AWSLogStreamNamesKey.StringSlice([]string{options.Stream}))
+		if groupARN != "" {
+			attributes = append(attributes, semconv.AWSLogStreamARNsKey.StringSlice([]string{groupARN + ":log-stream:" + options.Stream}))
+		}
+	}
+	return attributes
+}
+
+// clusterARN returns the ARN of the cluster. The metadata of tasks running
+// on EC2 only holds the name of the cluster, in which case the ARN is built
+// from it.
+func (m *metadataV4) clusterARN(arn taskARN) string {
+	cluster := m.task.Cluster
+	if cluster == "" || strings.HasPrefix(cluster, "arn:") {
+		return cluster
+	}
+	if !arn.valid() {
+		return ""
+	}
+	return fmt.Sprintf("arn:%s:ecs:%s:%s:cluster/%s", arn.partition, arn.region, arn.account, cluster)
+}
+
+// taskARN holds the parts of the task ARN the ARNs of other resources of the
+// task are built from.
+type taskARN struct {
+	partition string
+	region    string
+	account   string
+}
+
+// valid returns whether ARNs can be built from arn.
+func (arn taskARN) valid() bool {
+	return arn.partition != "" && arn.region != "" && arn.account != ""
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+const (
+	testContainerMetadata = `{
+  "DockerId": "cd189a933e5849daa93386466019ab50-2495160603",
+  "Name": "curl",
+  "ContainerARN": "arn:aws:ecs:us-west-2:111122223333:container/05966557-f16c-49cb-9352-24b3a0dcd0e1",
+  "Image": "111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest:latest",
+  "LogDriver": "awslogs",
+  "LogOptions": {
+    "awslogs-create-group": "true",
+    "awslogs-group": "/ecs/curltest",
+    "awslogs-region": "us-west-2",
+    "awslogs-stream": "ecs/curl/cd189a933e5849daa93386466019ab50"
+  }
+}`
+	testTaskMetadata = `{
+  "Cluster": "arn:aws:ecs:us-west-2:111122223333:cluster/default",
+  "TaskARN": "arn:aws:ecs:us-west-2:111122223333:task/default/e9028f8d5d8e4f258373e7b93ce9a3c3",
+  "Family": "curltest",
+  "Revision": "3",
+  "LaunchType": "FARGATE",
+  "AvailabilityZone": "us-west-2d"
+}`
+)
+
+func TestGetMetadataV4(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v4/id", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testContainerMetadata))
+	})
+	mux.HandleFunc("/v4/id/task", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testTaskMetadata))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	utils := ecsDetectorUtils{client: srv.Client()}
+	m, err := utils.getMetadataV4(context.Background(), srv.URL+"/v4/id")
+	require.NoError(t, err)
+
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.CloudRegionKey.String("us-west-2"),
+		semconv.CloudAccountIDKey.String("111122223333"),
+		semconv.CloudAvailabilityZoneKey.String("us-west-2d"),
+		semconv.ContainerImageNameKey.String("111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest"),
+		semconv.ContainerImageTagKey.String("latest"),
+		semconv.AWSECSContainerARNKey.String("arn:aws:ecs:us-west-2:111122223333:container/05966557-f16c-49cb-9352-24b3a0dcd0e1"),
+		semconv.AWSECSClusterARNKey.String("arn:aws:ecs:us-west-2:111122223333:cluster/default"),
+		semconv.AWSECSLaunchtypeFargate,
+		semconv.AWSECSTaskARNKey.String("arn:aws:ecs:us-west-2:111122223333:task/default/e9028f8d5d8e4f258373e7b93ce9a3c3"),
+		semconv.AWSECSTaskFamilyKey.String("curltest"),
+		semconv.AWSECSTaskRevisionKey.String("3"),
+		semconv.AWSLogGroupNamesKey.StringSlice([]string{"/ecs/curltest"}),
+		semconv.AWSLogGroupARNsKey.StringSlice([]string{"arn:aws:logs:us-west-2:111122223333:log-group:/ecs/curltest:*"}),
+		semconv.AWSLogStreamNamesKey.StringSlice([]string{"ecs/curl/cd189a933e5849daa93386466019ab50"}),
+		semconv.AWSLogStreamARNsKey.StringSlice([]string{"arn:aws:logs:us-west-2:111122223333:log-group:/ecs/curltest:log-stream:ecs/curl/cd189a933e5849daa93386466019ab50"}),
+	}, m.attributes())
+	assert.Equal(t, "curl", m.container.Name)
+}
+
+func TestMetadataV4Image(t *testing.T) {
+	testCases := []struct {
+		image string
+		name  string
+		tag   string
+	}{
+		{"", "", ""},
+		{"nginx", "nginx", ""},
+		{"amazon/amazon-ecs-sample:latest", "amazon/amazon-ecs-sample", "latest"},
+		{"registry.example.com:5000/team/app", "registry.example.com:5000/team/app", ""},
+		{"registry.example.com:5000/team/app:1.2", "registry.example.com:5000/team/app", "1.2"},
+		{"app@sha256:0123456789abcdef", "app", ""},
+	}
+
+	for _, tc := range testCases {
+		m := metadataV4{container: containerMetadataV4{Image: tc.image}}
+		name, tag := m.image()
+		assert.Equal(t, tc.name, name, tc.image)
+		assert.Equal(t, tc.tag, tag, tc.image)
+	}
+}
+
+func TestMetadataV4LogAttributes(t *testing.T) {
+	m := metadataV4{}
+	m.container.LogDriver = "awslogs"
+	m.container.LogOptions.Group = "/ecs/curltest"
+
+	// Without the partition, region and account, only the names are known.
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.AWSLogGroupNamesKey.StringSlice([]string{"/ecs/curltest"}),
+	}, m.logAttributes(taskARN{}))
+
+	// The logs of other log drivers are not sent to CloudWatch.
+	m.container.LogDriver = "json-file"
+	assert.Nil(t, m.logAttributes(taskARN{partition: "aws", region: "us-west-2", account: "111122223333"}))
+}
+
+func TestGetMetadataV4Error(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	utils := ecsDetectorUtils{client: srv.Client()}
+	_, err := utils.getMetadataV4(context.Background(), srv.URL+"/v4/id")
+	assert.ErrorIs(t, err, errCannotReadMetadata)
+}
+
+func TestMetadataV4Partition(t *testing.T) {
+	m := metadataV4{task: taskMetadataV4{
+		Cluster: "default",
+		TaskARN: "arn:aws-cn:ecs:cn-north-1:111122223333:task/default/e9028f8d5d8e4f258373e7b93ce9a3c3",
+	}}
+	m.container.LogDriver = "awslogs"
+	m.container.LogOptions.Group = "/ecs/curltest"
+
+	attributes := m.attributes()
+	assert.Contains(t, attributes, semconv.AWSECSClusterARNKey.String("arn:aws-cn:ecs:cn-north-1:111122223333:cluster/default"))
+	assert.Contains(t, attributes, semconv.AWSLogGroupARNsKey.StringSlice([]string{"arn:aws-cn:logs:cn-north-1:111122223333:log-group:/ecs/curltest:*"}))
+}
@@ -0,0 +1,364 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/detectors"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	defaultAPITimeout           = 5 * time.Second
+	defaultRetries              = 2
+	defaultRetryBackoff         = 100 * time.Millisecond
+	defaultAuthConfigmap        = authConfigmapNS + "/" + authConfigmapName
+	defaultClusterInfoConfigmap = cwConfigmapNS + "/" + cwConfigmapName
+
+	// clusterNameEnvVar is the environment variable holding the name of the
+	// cluster.
+	clusterNameEnvVar = "OTEL_EKS_CLUSTER_NAME"
+	// clusterNameFileEnvVar is the environment variable holding the path of
+	// a file, e.g. mounted from a configmap, holding the name of the cluster.
+	clusterNameFileEnvVar = "OTEL_EKS_CLUSTER_NAME_FILE"
+	// serviceHostEnvVar and servicePortEnvVar are the environment variables
+	// Kubernetes sets in every container to the address of the API server.
+	serviceHostEnvVar = "KUBERNETES_SERVICE_HOST"
+	servicePortEnvVar = "KUBERNETES_SERVICE_PORT"
+)
+
+type config struct {
+	// apiTimeout is the timeout of each request made to the Kubernetes
+	// API. A value of zero means no timeout other than the deadline of the
+	// context passed to Detect.
+	apiTimeout time.Duration
+	// cacheTTL is the duration a detected Resource is reused for. A value
+	// of zero disables caching.
+	cacheTTL time.Duration
+	// client is the Kubernetes client used to make requests to the
+	// Kubernetes API. If nil, a client is created from restConfig.
+	client kubernetes.Interface
+	// restConfig is the configuration used to create a Kubernetes client.
+	// If nil, the in-cluster configuration is used.
+	restConfig *rest.Config
+	// serviceHost and servicePort are the address of the API server of the
+	// in-cluster configuration, set by the KUBERNETES_SERVICE_HOST and
+	// KUBERNETES_SERVICE_PORT environment variables.
+	serviceHost string
+	servicePort string
+	// tokenPath, caPath, and namespacePath are the paths of the token, CA
+	// certificate, and namespace of the service account.
+	tokenPath     string
+	caPath        string
+	namespacePath string
+	// clusterName is the name of the cluster. If empty, it is detected.
+	clusterName string
+	// envClusterName is the name of the cluster set by the
+	// OTEL_EKS_CLUSTER_NAME environment variable.
+	envClusterName string
+	// clusterNameFile is the path of the file holding the name of the
+	// cluster. If empty, no file is read.
+	clusterNameFile string
+	// authConfigmap is the "namespace/name" path of the configmap used to
+	// confirm the cluster is an EKS cluster. If empty, the lookup is
+	// disabled.
+	authConfigmap string
+	// clusterInfoConfigmap is the "namespace/name" path of the configmap
+	// holding the cluster name. If empty, the lookup is disabled.
+	clusterInfoConfigmap string
+	// withoutContainerID disables the detection of the container ID.
+	withoutContainerID bool
+	// cgroupPath is the path of the file the container ID is read from.
+	cgroupPath string
+	// retries is the number of times a failed request to the Kubernetes
+	// API is retried.
+	retries int
+	// retryBackoff is the delay before the first retry. It is doubled for
+	// each subsequent retry.
+	retryBackoff time.Duration
+	// imdsEndpoint is the endpoint of the instance metadata service. If
+	// empty, the default endpoint is used.
+	imdsEndpoint string
+	// imdsHopLimit is the hop limit of the packets sent to the instance
+	// metadata service. If zero, the default of the system is used.
+	imdsHopLimit int
+	// utils is the DetectorUtils used for detection. If nil, the utils
+	// accessing the file system, Kubernetes API, and instance metadata
+	// service are used.
+	utils DetectorUtils
+	// schemaURL is the schema URL of the detected Resource.
+	schemaURL string
+	// probeFunc is notified of the probes of the detection, if not nil.
+	probeFunc detectors.ProbeFunc
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{
+		apiTimeout:           defaultAPITimeout,
+		authConfigmap:        defaultAuthConfigmap,
+		clusterInfoConfigmap: defaultClusterInfoConfigmap,
+		cgroupPath:           defaultCgroupPath,
+		retries:              defaultRetries,
+		retryBackoff:         defaultRetryBackoff,
+		schemaURL:            semconv.SchemaURL,
+		envClusterName:       strings.TrimSpace(os.Getenv(clusterNameEnvVar)),
+		clusterNameFile:      os.Getenv(clusterNameFileEnvVar),
+		serviceHost:          os.Getenv(serviceHostEnvVar),
+		servicePort:          os.Getenv(servicePortEnvVar),
+		tokenPath:            k8sTokenPath,
+		caPath:               k8sCertPath,
+		namespacePath:        k8sNamespacePath,
+	}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// validate returns an error if the configuration is invalid.
+func (c *config) validate() error {
+	for _, path := range []string{c.authConfigmap, c.clusterInfoConfigmap} {
+		if path == "" {
+			continue
+		}
+		if _, _, err := splitConfigmapPath(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// needsClient returns true if the configuration requires requests to the
+// Kubernetes API.
+func (c *config) needsClient() bool {
+	if c.deployedClusterName() {
+		return false
+	}
+	return c.authConfigmap != "" || (c.clusterInfoConfigmap != "" && c.clusterName == "")
+}
+
+// deployedClusterName returns true if the name of the cluster is set by the
+// deployment, with the OTEL_EKS_CLUSTER_NAME environment variable or a file.
+// The deployment then asserts that the cluster is an EKS cluster, and neither
+// configmap is read.
+func (c *config) deployedClusterName() bool {
+	return c.envClusterName != "" || c.clusterNameFile != ""
+}
+
+// splitConfigmapPath splits a "namespace/name" configmap path.
+func splitConfigmapPath(path string) (namespace, name string, err error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid configmap path %q, expected \"namespace/name\"", path)
+	}
+	return parts[0], parts[1], nil
+}
+
+// Option applies an EKS resource detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithAPITimeout sets the timeout of each request made to the Kubernetes
+// API during detection. The default is 5 seconds. A timeout of zero
+// disables the per-request timeout, leaving only the deadline of the
+// context passed to Detect.
+func WithAPITimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.apiTimeout = timeout
+	})
+}
+
+// WithCacheTTL enables caching of the detected Resource for ttl. Detect
+// calls made within ttl of a successful detection return the cached
+// Resource without reading any file or making any request to the
+// Kubernetes API. By default detection results are not cached.
+func WithCacheTTL(ttl time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.cacheTTL = ttl
+	})
+}
+
+// WithKubernetesClient sets the client used to make requests to the
+// Kubernetes API. By default a client is created from the in-cluster
+// configuration of the service account the process runs as. This option
+// takes precedence over WithRESTConfig.
+func WithKubernetesClient(client kubernetes.Interface) Option {
+	return optionFunc(func(c *config) {
+		c.client = client
+	})
+}
+
+// WithRESTConfig sets the configuration used to create the client that makes
+// requests to the Kubernetes API. This can be used to set custom rate
+// limits, proxies, or service account credentials. By default the in-cluster
+// configuration is used.
+func WithRESTConfig(restConfig *rest.Config) Option {
+	return optionFunc(func(c *config) {
+		c.restConfig = restConfig
+	})
+}
+
+// WithServiceAccountPaths sets the paths of the token, CA certificate, and
+// namespace files of the service account the detector authenticates with to
+// the Kubernetes API, e.g. of a projected service account token mounted in a
+// custom location. The detector only runs in a pod where the token and CA
+// certificate files exist. The token is read again when it is rotated. An
+// empty path keeps the default path in
+// "/var/run/secrets/kubernetes.io/serviceaccount".
+func WithServiceAccountPaths(tokenPath, caPath, namespacePath string) Option {
+	return optionFunc(func(c *config) {
+		if tokenPath != "" {
+			c.tokenPath = tokenPath
+		}
+		if caPath != "" {
+			c.caPath = caPath
+		}
+		if namespacePath != "" {
+			c.namespacePath = namespacePath
+		}
+	})
+}
+
+// WithClusterName sets the name of the EKS cluster reported by the detector
+// instead of detecting it. This avoids reading the cluster-info configmap of
+// Container Insights, which is not installed in all clusters, and the
+// instance metadata tags the detector falls back to. It takes precedence over
+// the OTEL_EKS_CLUSTER_NAME environment variable and WithClusterNameFile.
+func WithClusterName(name string) Option {
+	return optionFunc(func(c *config) {
+		c.clusterName = name
+	})
+}
+
+// WithClusterNameFile sets the path of a file holding the name of the EKS
+// cluster, e.g. a configmap mounted in the pod, which is read on detection.
+// The default is the path set by the OTEL_EKS_CLUSTER_NAME_FILE environment
+// variable. Like the name set by the OTEL_EKS_CLUSTER_NAME environment
+// variable, which takes precedence, it replaces the lookups of the aws-auth
+// and cluster-info configmaps, so that the service account of the detector
+// does not need to be permitted to read them. An empty path disables the
+// file.
+func WithClusterNameFile(path string) Option {
+	return optionFunc(func(c *config) {
+		c.clusterNameFile = path
+	})
+}
+
+// WithConfigmapPaths sets the "namespace/name" paths of the configmaps read
+// from the Kubernetes API. The awsAuth configmap is used to confirm the
+// cluster is an EKS cluster and defaults to "kube-system/aws-auth". The
+// clusterInfo configmap holds the cluster name and defaults to the
+// "amazon-cloudwatch/cluster-info" configmap of Container Insights.
+//
+// Passing an empty path disables the corresponding lookup, which allows the
+// detector to be used when its service account is not permitted to read the
+// configmap. Without the awsAuth configmap any Kubernetes cluster is assumed
+// to be an EKS cluster.
+func WithConfigmapPaths(awsAuth, clusterInfo string) Option {
+	return optionFunc(func(c *config) {
+		c.authConfigmap = awsAuth
+		c.clusterInfoConfigmap = clusterInfo
+	})
+}
+
+// WithoutContainerID disables the detection of the container ID.
+func WithoutContainerID() Option {
+	return optionFunc(func(c *config) {
+		c.withoutContainerID = true
+	})
+}
+
+// WithCgroupPath sets the path of the cgroup file the container ID is read
+// from. The default is "/proc/self/cgroup".
+func WithCgroupPath(path string) Option {
+	return optionFunc(func(c *config) {
+		c.cgroupPath = path
+	})
+}
+
+// WithRetry sets how many times a failed request to the Kubernetes API is
+// retried and the delay before the first retry, which is doubled for each
+// subsequent retry. Requests that fail because the configmap does not exist
+// or cannot be accessed are not retried. By default a request is retried
+// twice with an initial delay of 100 milliseconds. A retries value of zero
+// disables retries.
+func WithRetry(retries int, backoff time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.retries = retries
+		c.retryBackoff = backoff
+	})
+}
+
+// WithIMDSEndpoint sets the endpoint of the instance metadata service the
+// region, account, availability zone, and cluster name tags are read from. By
+// default the AWS_EC2_METADATA_SERVICE_ENDPOINT environment variable is used,
+// and then "http://169.254.169.254", or "http://[fd00:ec2::254]" when the
+// AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE environment variable is "IPv6" or the
+// address of the Kubernetes API server is an IPv6 address, as in IPv6 clusters.
+func WithIMDSEndpoint(endpoint string) Option {
+	return optionFunc(func(c *config) {
+		c.imdsEndpoint = endpoint
+	})
+}
+
+// WithIMDSHopLimit sets the hop limit, or IP time to live, of the packets sent
+// to the instance metadata service. By default the hop limit of the system is
+// used. It is not supported on Windows.
+func WithIMDSHopLimit(hops int) Option {
+	return optionFunc(func(c *config) {
+		c.imdsHopLimit = hops
+	})
+}
+
+// WithUtils sets the DetectorUtils the detector uses to read files, request the
+// Kubernetes API and the instance metadata service, and find the container ID.
+// No Kubernetes client is created, and the options configuring it, such as
+// WithKubernetesClient and WithCgroupPath, have no effect.
+func WithUtils(utils DetectorUtils) Option {
+	return optionFunc(func(c *config) {
+		c.utils = utils
+	})
+}
+
+// WithSchemaURL sets the schema URL of the detected Resource.
+func WithSchemaURL(schemaURL string) Option {
+	return optionFunc(func(c *config) {
+		c.schemaURL = schemaURL
+	})
+}
+
+// WithProbeFunc sets a ProbeFunc notified of the probes of each detection:
+// whether the cluster is EKS, the instance identity of the worker node, the
+// pod on Fargate, the cluster name, and the container ID.
+func WithProbeFunc(fn detectors.ProbeFunc) Option {
+	return optionFunc(func(c *config) {
+		c.probeFunc = fn
+	})
+}
@@ -16,114 +16,437 @@ package eks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
+	"go.opentelemetry.io/contrib/detectors"
+	"go.opentelemetry.io/contrib/detectors/aws/internal/imds"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 )
 
 const (
-	k8sTokenPath      = "/var/run/secrets/kubernetes.io/serviceaccount/token"
-	k8sCertPath       = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
-	authConfigmapNS   = "kube-system"
-	authConfigmapName = "aws-auth"
-	cwConfigmapNS     = "amazon-cloudwatch"
-	cwConfigmapName   = "cluster-info"
-	defaultCgroupPath = "/proc/self/cgroup"
-	containerIDLength = 64
+	k8sTokenPath         = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	k8sCertPath          = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+	k8sNamespacePath     = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	defaultServiceHost   = "kubernetes.default.svc"
+	defaultServicePort   = "443"
+	authConfigmapNS      = "kube-system"
+	authConfigmapName    = "aws-auth"
+	cwConfigmapNS        = "amazon-cloudwatch"
+	cwConfigmapName      = "cluster-info"
+	defaultCgroupPath    = "/proc/self/cgroup"
+	defaultMountinfoPath = "/proc/self/mountinfo"
 )
 
-// detectorUtils is used for testing the resourceDetector by abstracting functions that rely on external systems.
-type detectorUtils interface {
-	fileExists(filename string) bool
-	getConfigMap(ctx context.Context, namespace string, name string) (map[string]string, error)
-	getContainerID() (string, error)
+const (
+	// executionEnvVar is the environment variable describing the AWS
+	// compute environment the process runs in.
+	executionEnvVar = "AWS_EXECUTION_ENV"
+	// fargateExecutionEnv is the value of executionEnvVar on EKS Fargate.
+	fargateExecutionEnv = "AWS_EKS_FARGATE"
+	// computeTypeAnnotation is the annotation EKS sets on pods to the type
+	// of compute they are scheduled on.
+	computeTypeAnnotation = "eks.amazonaws.com/compute-type"
+	// fargateComputeType is the value of computeTypeAnnotation for pods
+	// running on Fargate.
+	fargateComputeType = "fargate"
+)
+
+// clusterNameTagKeys are the EC2 instance tags that hold the name of the EKS
+// cluster a worker node belongs to.
+var clusterNameTagKeys = []string{"eks:cluster-name", "aws:eks:cluster-name"}
+
+var (
+	// cgroupContainerIDRe matches the container ID at the end of a line of
+	// /proc/self/cgroup. This covers the cgroupfs and systemd cgroup drivers
+	// of Docker, containerd, and CRI-O for both cgroup v1 and v2, e.g.
+	//
+	//   12:pids:/kubepods/burstable/pod<uid>/<id>
+	//   0::/kubepods.slice/kubepods-pod<uid>.slice/cri-containerd-<id>.scope
+	//   1:name=systemd:/kubepods-pod<uid>.slice:cri-containerd:<id>
+	cgroupContainerIDRe = regexp.MustCompile(`(?:^|[/:-])([0-9a-f]{64})(?:\.scope)?$`)
+	// mountinfoContainerIDRe matches a container ID anywhere in the root of
+	// the mounts listed in /proc/self/mountinfo. It is used with cgroup v2
+	// private cgroup namespaces, where /proc/self/cgroup only contains
+	// "0::/", e.g.
+	//
+	//   /var/lib/docker/containers/<id>/hostname
+	//   /var/lib/containerd/io.containerd.grpc.v1.cri/sandboxes/<id>/hostname
+	//   /kubepods.slice/kubepods-pod<uid>.slice/cri-containerd-<id>.scope
+	mountinfoContainerIDRe = regexp.MustCompile(`(?:^|[^0-9a-f])([0-9a-f]{64})(?:[^0-9a-f]|$)`)
+)
+
+// partialResourceError is returned by Detect along with a Resource holding
+// the attributes that could be detected when the detection of others failed.
+type partialResourceError struct {
+	errs []error
+}
+
+func (e *partialResourceError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%s: %s", resource.ErrPartialResource, strings.Join(msgs, "; "))
 }
 
-// This struct will implement the detectorUtils interface
+// Is returns true for resource.ErrPartialResource.
+func (e *partialResourceError) Is(target error) bool {
+	return target == resource.ErrPartialResource
+}
+
+// Unwrap returns the first error that caused the detection to fail.
+func (e *partialResourceError) Unwrap() error {
+	return e.errs[0]
+}
+
+// DetectorUtils abstracts the access of the resource detector to the systems it
+// detects the resource from. A custom implementation can be supplied with
+// WithUtils to adapt the detection to hardened environments, or to test code
+// using the detector.
+type DetectorUtils interface {
+	// FileExists returns whether a file with the given name exists. It is
+	// used to check for the service account token and CA certificate of a
+	// Kubernetes pod.
+	FileExists(filename string) bool
+	// GetConfigMap returns the data of the configmap with the given
+	// namespace and name.
+	GetConfigMap(ctx context.Context, namespace string, name string) (map[string]string, error)
+	// GetContainerID returns the ID of the container the process runs in.
+	GetContainerID() (string, error)
+	// GetInstanceTag returns the value of a tag of the EC2 instance from the
+	// instance metadata service.
+	GetInstanceTag(ctx context.Context, key string) (string, error)
+	// GetInstanceIdentity returns the identity document of the EC2 instance
+	// from the instance metadata service.
+	GetInstanceIdentity(ctx context.Context) (InstanceIdentity, error)
+	// GetPod returns the pod the process runs in.
+	GetPod(ctx context.Context) (*corev1.Pod, error)
+}
+
+// This struct will implement the DetectorUtils interface
 type eksDetectorUtils struct {
-	clientset *kubernetes.Clientset
+	clientset     kubernetes.Interface
+	imds          *imds.Client
+	cgroupPath    string
+	namespacePath string
 }
 
 // resourceDetector for detecting resources running on Amazon EKS
 type resourceDetector struct {
-	utils detectorUtils
+	utils DetectorUtils
 	err   error
+	cfg   *config
+
+	mu        sync.Mutex
+	cached    *resource.Resource
+	expiresAt time.Time
 }
 
 // Compile time assertion that resourceDetector implements the resource.Detector interface.
 var _ resource.Detector = (*resourceDetector)(nil)
 
-// Compile time assertion that eksDetectorUtils implements the detectorUtils interface.
-var _ detectorUtils = (*eksDetectorUtils)(nil)
+// Compile time assertion that eksDetectorUtils implements the DetectorUtils interface.
+var _ DetectorUtils = (*eksDetectorUtils)(nil)
 
 // NewResourceDetector returns a resource detector that will detect AWS EKS resources.
-func NewResourceDetector() resource.Detector {
-	utils, err := newK8sDetectorUtils()
-	return &resourceDetector{utils: utils, err: err}
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	if err := c.validate(); err != nil {
+		return &resourceDetector{err: err, cfg: c}
+	}
+	if c.utils != nil {
+		return &resourceDetector{utils: c.utils, cfg: c}
+	}
+	utils, err := newK8sDetectorUtils(c)
+	return &resourceDetector{utils: utils, err: err, cfg: c}
 }
 
 // Detect returns a Resource describing the Amazon EKS environment being run in.
+//
+// If only some of the attributes can be detected, a Resource holding them is
+// returned along with an error wrapping resource.ErrPartialResource and the
+// errors that occurred. Requests made to the Kubernetes API are bound by ctx
+// and by the timeout configured with WithAPITimeout. If caching is enabled
+// with WithCacheTTL, a previously detected Resource is returned until it
+// expires.
 func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resource, error) {
-	if detector.err != nil {
-		return nil, detector.err
+	if detector.cfg.cacheTTL <= 0 {
+		return detector.detect(ctx)
 	}
 
-	isEks, err := isEKS(ctx, detector.utils)
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+
+	if detector.cached != nil && time.Now().Before(detector.expiresAt) {
+		return detector.cached, nil
+	}
+
+	res, err := detector.detect(ctx)
 	if err != nil {
+		return res, err
+	}
+	detector.cached = res
+	detector.expiresAt = time.Now().Add(detector.cfg.cacheTTL)
+	return res, nil
+}
+
+// detect performs the detection of the Amazon EKS environment.
+func (detector *resourceDetector) detect(ctx context.Context) (*resource.Resource, error) {
+	if detector.err != nil {
+		return nil, detector.err
+	}
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	// Return empty resource object if not running in EKS
-	if !isEks {
+	var (
+		attributes []attribute.KeyValue
+		errs       []error
+	)
+
+	// If it cannot be confirmed that this is an EKS cluster, only the
+	// attributes that do not depend on it are detected.
+	start := time.Now()
+	isEks, err := isEKS(ctx, detector)
+	detector.probed("eks", start, isEks, err)
+	if err != nil {
+		errs = append(errs, err)
+	} else if !isEks {
+		// Return empty resource object if not running in EKS
 		return resource.Empty(), nil
+	} else {
+		attributes = append(attributes, semconv.CloudProviderAWS, semconv.CloudPlatformAWSEKS)
+
+		// The instance metadata service is not available on Fargate. The
+		// pod is only retrieved to tell Fargate apart when the instance
+		// identity cannot be read, so it is not requested on EC2 worker
+		// nodes.
+		fargate := os.Getenv(executionEnvVar) == fargateExecutionEnv
+		var pod *corev1.Pod
+		if !fargate {
+			start = time.Now()
+			cloudAttributes, err := getCloudAttributes(ctx, detector)
+			detector.probed("instance identity", start, err == nil, err)
+			if err == nil {
+				attributes = append(attributes, cloudAttributes...)
+			} else {
+				fargate, pod = isFargate(ctx, detector)
+			}
+		}
+
+		// Get clusterName and append to attributes
+		start = time.Now()
+		clusterName, err := getClusterName(ctx, detector, !fargate)
+		detector.probed("cluster name", start, clusterName != "", err)
+		if err != nil {
+			errs = append(errs, err)
+		} else if clusterName != "" {
+			attributes = append(attributes, semconv.K8SClusterNameKey.String(clusterName))
+		}
+
+		// Get the identity of the pod on Fargate, where it cannot be
+		// derived from the node.
+		if fargate {
+			var podErr error
+			if pod == nil {
+				start = time.Now()
+				pod, podErr = detector.getPod(ctx)
+				detector.probed("pod", start, podErr == nil, podErr)
+			}
+			if podErr != nil {
+				errs = append(errs, podErr)
+			} else {
+				attributes = append(attributes, podAttributes(pod)...)
+			}
+		}
 	}
 
-	// Create variable to hold resource attributes
-	attributes := []attribute.KeyValue{
-		semconv.CloudProviderAWS,
-		semconv.CloudPlatformAWSEKS,
+	// Get containerID and append to attributes
+	if !detector.cfg.withoutContainerID {
+		start := time.Now()
+		containerID, err := detector.utils.GetContainerID()
+		detector.probed("container id", start, containerID != "", err)
+		if err != nil {
+			errs = append(errs, err)
+		} else if containerID != "" {
+			attributes = append(attributes, semconv.ContainerIDKey.String(containerID))
+		}
+	}
+
+	// Return new resource object with clusterName and containerID as attributes
+	res := resource.NewWithAttributes(detector.cfg.schemaURL, attributes...)
+	if len(errs) > 0 {
+		return res, &partialResourceError{errs: errs}
+	}
+	return res, nil
+}
+
+// probed notifies the configured ProbeFunc, if any, of a probe started at
+// start.
+func (detector *resourceDetector) probed(probe string, start time.Time, found bool, err error) {
+	if detector.cfg.probeFunc == nil {
+		return
+	}
+	detector.cfg.probeFunc(detectors.ProbeEvent{
+		Detector: "eks",
+		Probe:    probe,
+		Found:    found,
+		Err:      err,
+		Duration: time.Since(start),
+	})
+}
+
+// withTimeout returns a copy of ctx bound by the configured API timeout.
+func (detector *resourceDetector) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if detector.cfg.apiTimeout > 0 {
+		return context.WithTimeout(ctx, detector.cfg.apiTimeout)
 	}
+	return context.WithCancel(ctx)
+}
 
-	// Get clusterName and append to attributes
-	clusterName, err := getClusterName(ctx, detector.utils)
+// getConfigMap retrieves the configuration map at the "namespace/name" path
+// from the k8s API. Each attempt is bounded by the configured API timeout,
+// and failed attempts are retried with exponential backoff.
+func (detector *resourceDetector) getConfigMap(ctx context.Context, path string) (map[string]string, error) {
+	namespace, name, err := splitConfigmapPath(path)
 	if err != nil {
 		return nil, err
 	}
-	if clusterName != "" {
-		attributes = append(attributes, semconv.K8SClusterNameKey.String(clusterName))
+
+	backoff := detector.cfg.retryBackoff
+	for attempt := 0; ; attempt++ {
+		var data map[string]string
+		data, err = detector.getConfigMapOnce(ctx, namespace, name)
+		if err == nil || attempt >= detector.cfg.retries || !isRetryable(err) {
+			return data, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+		backoff *= 2
 	}
+}
 
-	// Get containerID and append to attributes
-	containerID, err := detector.utils.getContainerID()
+func (detector *resourceDetector) getConfigMapOnce(ctx context.Context, namespace string, name string) (map[string]string, error) {
+	ctx, cancel := detector.withTimeout(ctx)
+	defer cancel()
+	return detector.utils.GetConfigMap(ctx, namespace, name)
+}
+
+// isRetryable returns false for errors of the k8s API that will not change
+// when the request is retried.
+func isRetryable(err error) bool {
+	return !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) && !apierrors.IsUnauthorized(err)
+}
+
+// getInstanceTag retrieves a tag of the EC2 instance from the instance
+// metadata service, bounding the request by the configured API timeout.
+func (detector *resourceDetector) getInstanceTag(ctx context.Context, key string) (string, error) {
+	ctx, cancel := detector.withTimeout(ctx)
+	defer cancel()
+	return detector.utils.GetInstanceTag(ctx, key)
+}
+
+// getPod retrieves the pod the detector runs in from the k8s API, bounding
+// the request by the configured API timeout.
+func (detector *resourceDetector) getPod(ctx context.Context) (*corev1.Pod, error) {
+	ctx, cancel := detector.withTimeout(ctx)
+	defer cancel()
+	return detector.utils.GetPod(ctx)
+}
+
+// isFargate checks if the current pod runs on EKS Fargate from the compute
+// type annotation of the pod. The pod is returned if it was retrieved.
+// Failing to retrieve the pod is not an error, as the service account of pods
+// on EC2 worker nodes is not required to be able to read it.
+func isFargate(ctx context.Context, detector *resourceDetector) (bool, *corev1.Pod) {
+	start := time.Now()
+	pod, err := detector.getPod(ctx)
+	detector.probed("pod", start, err == nil, err)
 	if err != nil {
-		return nil, err
+		return false, nil
+	}
+	return pod.Annotations[computeTypeAnnotation] == fargateComputeType, pod
+}
+
+// podAttributes returns the attributes identifying pod.
+func podAttributes(pod *corev1.Pod) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		semconv.K8SNamespaceNameKey.String(pod.Namespace),
+		semconv.K8SPodNameKey.String(pod.Name),
+	}
+	if pod.UID != "" {
+		attributes = append(attributes, semconv.K8SPodUIDKey.String(string(pod.UID)))
 	}
-	if containerID != "" {
-		attributes = append(attributes, semconv.ContainerIDKey.String(containerID))
+	if pod.Spec.NodeName != "" {
+		attributes = append(attributes, semconv.K8SNodeNameKey.String(pod.Spec.NodeName))
 	}
+	return attributes
+}
 
-	// Return new resource object with clusterName and containerID as attributes
-	return resource.NewWithAttributes(semconv.SchemaURL, attributes...), nil
+// getCloudAttributes returns the cloud region, account, and availability
+// zone of the worker node. They are read from the instance identity document,
+// and an error is returned if it is not available, e.g. on Fargate or when the
+// instance metadata service cannot be reached from the pod.
+func getCloudAttributes(ctx context.Context, detector *resourceDetector) ([]attribute.KeyValue, error) {
+	ctx, cancel := detector.withTimeout(ctx)
+	defer cancel()
+
+	identity, err := detector.utils.GetInstanceIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var attributes []attribute.KeyValue
+	if identity.Region != "" {
+		attributes = append(attributes, semconv.CloudRegionKey.String(identity.Region))
+	}
+	if identity.AccountID != "" {
+		attributes = append(attributes, semconv.CloudAccountIDKey.String(identity.AccountID))
+	}
+	if identity.AvailabilityZone != "" {
+		attributes = append(attributes, semconv.CloudAvailabilityZoneKey.String(identity.AvailabilityZone))
+	}
+	return attributes, nil
 }
 
-// isEKS checks if the current environment is running in EKS.
-func isEKS(ctx context.Context, utils detectorUtils) (bool, error) {
-	if !isK8s(utils) {
+// isEKS checks if the current environment is running in EKS. A Kubernetes
+// cluster without the aws-auth configmap, e.g. one installed on EC2 instances
+// by other means, is not EKS.
+func isEKS(ctx context.Context, detector *resourceDetector) (bool, error) {
+	if !isK8s(detector.utils, detector.cfg) {
 		return false, nil
 	}
+	if detector.cfg.authConfigmap == "" || detector.cfg.deployedClusterName() {
+		return true, nil
+	}
 
 	// Make HTTP GET request
-	awsAuth, err := utils.getConfigMap(ctx, authConfigmapNS, authConfigmapName)
+	awsAuth, err := detector.getConfigMap(ctx, detector.cfg.authConfigmap)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
 	if err != nil {
 		return false, fmt.Errorf("isEks() error retrieving auth configmap: %w", err)
 	}
@@ -131,36 +454,70 @@ func isEKS(ctx context.Context, utils detectorUtils) (bool, error) {
 	return awsAuth != nil, nil
 }
 
-// newK8sDetectorUtils creates the Kubernetes clientset
-func newK8sDetectorUtils() (*eksDetectorUtils, error) {
+// newK8sDetectorUtils creates the Kubernetes clientset. Without a REST
+// configuration, no clientset is created outside of a pod, where the
+// detection finds that it does not run in Kubernetes.
+func newK8sDetectorUtils(c *config) (*eksDetectorUtils, error) {
+	utils := &eksDetectorUtils{imds: newIMDSClient(c), cgroupPath: c.cgroupPath, namespacePath: c.namespacePath}
+	if c.client != nil || !c.needsClient() {
+		utils.clientset = c.client
+		return utils, nil
+	}
+
 	// Get cluster configuration
-	confs, err := rest.InClusterConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create config: %w", err)
+	confs := c.restConfig
+	if confs == nil {
+		if !isK8s(utils, c) {
+			return utils, nil
+		}
+		confs = inClusterConfig(c)
 	}
 
 	// Create clientset using generated configuration
 	clientset, err := kubernetes.NewForConfig(confs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create clientset for Kubernetes client")
+		return nil, fmt.Errorf("failed to create clientset for Kubernetes client: %w", err)
 	}
 
-	return &eksDetectorUtils{clientset: clientset}, nil
+	utils.clientset = clientset
+	return utils, nil
+}
+
+// inClusterConfig returns the configuration of a client authenticated with the
+// service account of the configuration to the API server at the address of the
+// KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT environment variables,
+// which may be an IPv6 address, or at kubernetes.default.svc if they are not
+// set. Unlike rest.InClusterConfig, the paths of the service account files
+// are configurable.
+func inClusterConfig(c *config) *rest.Config {
+	host, port := c.serviceHost, c.servicePort
+	if host == "" {
+		host = defaultServiceHost
+	}
+	if port == "" {
+		port = defaultServicePort
+	}
+	return &rest.Config{
+		Host:            "https://" + net.JoinHostPort(strings.Trim(host, "[]"), port),
+		TLSClientConfig: rest.TLSClientConfig{CAFile: c.caPath},
+		// The token file is read again when a projected token is rotated.
+		BearerTokenFile: c.tokenPath,
+	}
 }
 
 // isK8s checks if the current environment is running in a Kubernetes environment
-func isK8s(utils detectorUtils) bool {
-	return utils.fileExists(k8sTokenPath) && utils.fileExists(k8sCertPath)
+func isK8s(utils DetectorUtils, c *config) bool {
+	return utils.FileExists(c.tokenPath) && utils.FileExists(c.caPath)
 }
 
-// fileExists checks if a file with a given filename exists.
-func (eksUtils eksDetectorUtils) fileExists(filename string) bool {
+// FileExists checks if a file with a given filename exists.
+func (eksUtils eksDetectorUtils) FileExists(filename string) bool {
 	info, err := os.Stat(filename)
 	return err == nil && !info.IsDir()
 }
 
-// getConfigMap retrieves the configuration map from the k8s API
-func (eksUtils eksDetectorUtils) getConfigMap(ctx context.Context, namespace string, name string) (map[string]string, error) {
+// GetConfigMap retrieves the configuration map from the k8s API
+func (eksUtils eksDetectorUtils) GetConfigMap(ctx context.Context, namespace string, name string) (map[string]string, error) {
 	cm, err := eksUtils.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve ConfigMap %s/%s: %w", namespace, name, err)
@@ -169,35 +526,139 @@ func (eksUtils eksDetectorUtils) getConfigMap(ctx context.Context, namespace str
 	return cm.Data, nil
 }
 
-// getClusterName retrieves the clusterName resource attribute
-func getClusterName(ctx context.Context, utils detectorUtils) (string, error) {
-	resp, err := utils.getConfigMap(ctx, cwConfigmapNS, cwConfigmapName)
+// GetInstanceTag retrieves a tag of the EC2 instance from the instance
+// metadata service. Instance metadata tags need to be enabled on the
+// instance for this to succeed.
+func (eksUtils eksDetectorUtils) GetInstanceTag(ctx context.Context, key string) (string, error) {
+	return eksUtils.imds.GetMetadata(ctx, imdsTagsPath+key)
+}
+
+// GetPod retrieves the pod the detector runs in from the k8s API. The pod is
+// identified by the namespace of its service account and its hostname.
+func (eksUtils eksDetectorUtils) GetPod(ctx context.Context) (*corev1.Pod, error) {
+	if eksUtils.clientset == nil {
+		return nil, errors.New("getPod() error: no Kubernetes client configured")
+	}
+
+	namespacePath := eksUtils.namespacePath
+	if namespacePath == "" {
+		namespacePath = k8sNamespacePath
+	}
+	namespace, err := ioutil.ReadFile(namespacePath)
+	if err != nil {
+		return nil, fmt.Errorf("getPod() error: cannot read namespace: %w", err)
+	}
+	name, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("getPod() error: cannot get hostname: %w", err)
+	}
+
+	pod, err := eksUtils.clientset.CoreV1().Pods(strings.TrimSpace(string(namespace))).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("getClusterName() error: %w", err)
+		return nil, fmt.Errorf("getPod() error: %w", err)
 	}
+	return pod, nil
+}
 
-	return resp["cluster.name"], nil
+// GetInstanceIdentity retrieves the identity document of the EC2 instance
+// from the instance metadata service.
+func (eksUtils eksDetectorUtils) GetInstanceIdentity(ctx context.Context) (InstanceIdentity, error) {
+	return getInstanceIdentity(ctx, eksUtils.imds)
 }
 
-// getContainerID returns the containerID if currently running within a container.
-func (eksUtils eksDetectorUtils) getContainerID() (string, error) {
-	fileData, err := ioutil.ReadFile(defaultCgroupPath)
-	if err != nil {
-		return "", fmt.Errorf("getContainerID() error: cannot read file with path %s: %w", defaultCgroupPath, err)
+// getClusterName retrieves the clusterName resource attribute. It uses the
+// configured cluster name if there is one, then the OTEL_EKS_CLUSTER_NAME
+// environment variable and the cluster name file, otherwise the cluster-info
+// configmap of Container Insights, and then the tags of the EC2 instance if
+// useIMDS is true.
+func getClusterName(ctx context.Context, detector *resourceDetector, useIMDS bool) (string, error) {
+	if detector.cfg.clusterName != "" {
+		return detector.cfg.clusterName, nil
+	}
+	if detector.cfg.envClusterName != "" {
+		return detector.cfg.envClusterName, nil
+	}
+	if detector.cfg.clusterNameFile != "" {
+		data, err := ioutil.ReadFile(detector.cfg.clusterNameFile)
+		if err != nil {
+			return "", fmt.Errorf("getClusterName() error: cannot read file with path %s: %w", detector.cfg.clusterNameFile, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	var cmErr error
+	if detector.cfg.clusterInfoConfigmap != "" {
+		var resp map[string]string
+		resp, cmErr = detector.getConfigMap(ctx, detector.cfg.clusterInfoConfigmap)
+		if cmErr == nil && resp["cluster.name"] != "" {
+			return resp["cluster.name"], nil
+		}
+		// The configmap only exists if Container Insights is installed.
+		if apierrors.IsNotFound(cmErr) {
+			cmErr = nil
+		}
 	}
 
-	// is this going to stop working with 1.20 when Docker is deprecated?
-	r, err := regexp.Compile(`^.*/docker/(.+)$`)
+	if useIMDS {
+		for _, key := range clusterNameTagKeys {
+			name, err := detector.getInstanceTag(ctx, key)
+			if err == nil && name != "" {
+				return name, nil
+			}
+		}
+	}
+
+	if cmErr != nil {
+		return "", fmt.Errorf("getClusterName() error: %w", cmErr)
+	}
+	return "", nil
+}
+
+// GetContainerID returns the containerID if currently running within a container.
+func (eksUtils eksDetectorUtils) GetContainerID() (string, error) {
+	fileData, err := ioutil.ReadFile(eksUtils.cgroupPath)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("getContainerID() error: cannot read file with path %s: %w", eksUtils.cgroupPath, err)
+	}
+	if containerID := containerIDFromCgroup(string(fileData)); containerID != "" {
+		return containerID, nil
 	}
 
-	// Retrieve containerID from file
-	splitData := strings.Split(strings.TrimSpace(string(fileData)), "\n")
-	for _, str := range splitData {
-		if r.MatchString(str) {
-			return str[len(str)-containerIDLength:], nil
+	// Fall back to the mount points of the container with cgroup v2.
+	fileData, err = ioutil.ReadFile(defaultMountinfoPath)
+	if err == nil {
+		if containerID := containerIDFromMountinfo(string(fileData)); containerID != "" {
+			return containerID, nil
+		}
+	}
+	return "", fmt.Errorf("getContainerID() error: cannot read containerID from files %s and %s", eksUtils.cgroupPath, defaultMountinfoPath)
+}
+
+// containerIDFromCgroup returns the container ID found in the contents of a
+// /proc/self/cgroup file, or an empty string if there is none.
+func containerIDFromCgroup(data string) string {
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		if m := cgroupContainerIDRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// containerIDFromMountinfo returns the container ID found in the contents of
+// a /proc/self/mountinfo file, or an empty string if there is none. Only the
+// root of the mounts, the fourth field of a line, is searched, as the mount
+// options hold the IDs of image layers, e.g. those of an overlay root
+// filesystem.
+func containerIDFromMountinfo(data string) string {
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		if m := mountinfoContainerIDRe.FindStringSubmatch(fields[3]); m != nil {
+			return m[1]
 		}
 	}
-	return "", fmt.Errorf("getContainerID() error: cannot read containerID from file %s", defaultCgroupPath)
+	return ""
 }
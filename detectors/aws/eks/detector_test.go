@@ -16,12 +16,24 @@ package eks
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
 
+	"go.opentelemetry.io/contrib/detectors"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
@@ -32,50 +44,110 @@ type MockDetectorUtils struct {
 }
 
 // Mock function for fileExists()
-func (detectorUtils *MockDetectorUtils) fileExists(filename string) bool {
+func (detectorUtils *MockDetectorUtils) FileExists(filename string) bool {
 	args := detectorUtils.Called(filename)
 	return args.Bool(0)
 }
 
 // Mock function for getConfigMap()
-func (detectorUtils *MockDetectorUtils) getConfigMap(_ context.Context, namespace string, name string) (map[string]string, error) {
+func (detectorUtils *MockDetectorUtils) GetConfigMap(_ context.Context, namespace string, name string) (map[string]string, error) {
 	args := detectorUtils.Called(namespace, name)
 	return args.Get(0).(map[string]string), args.Error(1)
 }
 
 // Mock function for getContainerID()
-func (detectorUtils *MockDetectorUtils) getContainerID() (string, error) {
+func (detectorUtils *MockDetectorUtils) GetContainerID() (string, error) {
 	args := detectorUtils.Called()
 	return args.String(0), args.Error(1)
 }
 
+// Mock function for getInstanceTag()
+func (detectorUtils *MockDetectorUtils) GetInstanceIdentity(_ context.Context) (InstanceIdentity, error) {
+	args := detectorUtils.Called()
+	return args.Get(0).(InstanceIdentity), args.Error(1)
+}
+
+func (detectorUtils *MockDetectorUtils) GetPod(_ context.Context) (*corev1.Pod, error) {
+	args := detectorUtils.Called()
+	pod, _ := args.Get(0).(*corev1.Pod)
+	return pod, args.Error(1)
+}
+
+func (detectorUtils *MockDetectorUtils) GetInstanceTag(_ context.Context, key string) (string, error) {
+	args := detectorUtils.Called(key)
+	return args.String(0), args.Error(1)
+}
+
 // Tests EKS resource detector running in EKS environment
 func TestEks(t *testing.T) {
 	detectorUtils := new(MockDetectorUtils)
 
 	// Mock functions and set expectations
-	detectorUtils.On("fileExists", k8sTokenPath).Return(true)
-	detectorUtils.On("fileExists", k8sCertPath).Return(true)
-	detectorUtils.On("getConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
-	detectorUtils.On("getConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string{"cluster.name": "my-cluster"}, nil)
-	detectorUtils.On("getContainerID").Return("0123456789A", nil)
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("GetConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string{"cluster.name": "my-cluster"}, nil)
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{
+		AccountID:        "123456789012",
+		Region:           "us-west-2",
+		AvailabilityZone: "us-west-2b",
+	}, nil)
 
 	// Expected resource object
 	eksResourceLabels := []attribute.KeyValue{
 		semconv.CloudProviderAWS,
 		semconv.CloudPlatformAWSEKS,
+		semconv.CloudRegionKey.String("us-west-2"),
+		semconv.CloudAccountIDKey.String("123456789012"),
+		semconv.CloudAvailabilityZoneKey.String("us-west-2b"),
 		semconv.K8SClusterNameKey.String("my-cluster"),
 		semconv.ContainerIDKey.String("0123456789A"),
 	}
 	expectedResource := resource.NewWithAttributes(semconv.SchemaURL, eksResourceLabels...)
 
 	// Call EKS Resource detector to detect resources
-	eksResourceDetector := resourceDetector{utils: detectorUtils}
+	eksResourceDetector := resourceDetector{utils: detectorUtils, cfg: newConfig()}
 	resourceObj, err := eksResourceDetector.Detect(context.Background())
 	require.NoError(t, err)
 
 	assert.Equal(t, expectedResource, resourceObj, "Resource object returned is incorrect")
 	detectorUtils.AssertExpectations(t)
+	// The pod is not needed on an EC2 worker node.
+	detectorUtils.AssertNotCalled(t, "GetPod")
+}
+
+func TestEksProbes(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errors.New("unreachable"))
+	detectorUtils.On("GetConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string{"cluster.name": "my-cluster"}, nil)
+	detectorUtils.On("GetPod").Return(fargatePod(), nil).Once()
+	detectorUtils.On("GetContainerID").Return("", nil)
+
+	var events []detectors.ProbeEvent
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithProbeFunc(func(e detectors.ProbeEvent) {
+		events = append(events, e)
+	}))}
+	_, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	var probes []string
+	for _, e := range events {
+		assert.Equal(t, "eks", e.Detector)
+		probes = append(probes, e.Probe)
+	}
+	assert.Equal(t, []string{"eks", "instance identity", "pod", "cluster name", "container id"}, probes)
+	assert.True(t, events[0].Found)
+	assert.False(t, events[1].Found)
+	assert.EqualError(t, events[1].Err, "unreachable")
+	assert.True(t, events[2].Found)
+	assert.True(t, events[3].Found)
+	assert.False(t, events[4].Found)
+	assert.NoError(t, events[4].Err)
 }
 
 // Tests EKS resource detector not running in EKS environment
@@ -85,11 +157,785 @@ func TestNotEKS(t *testing.T) {
 	k8sTokenPath := "/var/run/secrets/kubernetes.io/serviceaccount/token"
 
 	// Mock functions and set expectations
-	detectorUtils.On("fileExists", k8sTokenPath).Return(false)
+	detectorUtils.On("FileExists", k8sTokenPath).Return(false)
 
-	detector := resourceDetector{utils: detectorUtils}
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig()}
 	r, err := detector.Detect(context.Background())
 	require.NoError(t, err)
 	assert.Equal(t, resource.Empty(), r, "Resource object should be empty")
 	detectorUtils.AssertExpectations(t)
 }
+
+// Tests the EKS resource detector created with custom utils.
+func TestWithUtils(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden"))
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound)
+
+	expectedResource := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEKS,
+		semconv.K8SClusterNameKey.String("my-cluster"),
+		semconv.ContainerIDKey.String("0123456789A"),
+	)
+
+	detector := NewResourceDetector(
+		WithUtils(detectorUtils),
+		WithConfigmapPaths("", ""),
+		WithClusterName("my-cluster"),
+	)
+	resourceObj, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedResource, resourceObj, "Resource object returned is incorrect")
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestContainerIDFromCgroup(t *testing.T) {
+	const id = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	testCases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name:     "docker cgroup v1",
+			data:     "13:name=systemd:/docker/" + id + "\n12:pids:/docker/" + id,
+			expected: id,
+		},
+		{
+			name:     "kubepods cgroupfs",
+			data:     "12:pids:/kubepods/burstable/pod2c48913c-b29f-11e7-9350-020968147796/" + id,
+			expected: id,
+		},
+		{
+			name:     "containerd systemd cgroup v2",
+			data:     "0::/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod2c48913c.slice/cri-containerd-" + id + ".scope",
+			expected: id,
+		},
+		{
+			name:     "containerd systemd cgroup v1",
+			data:     "1:name=systemd:/kubepods-burstable-pod2c48913c.slice:cri-containerd:" + id,
+			expected: id,
+		},
+		{
+			name:     "crio",
+			data:     "0::/kubepods.slice/kubepods-pod2c48913c.slice/crio-" + id + ".scope",
+			expected: id,
+		},
+		{
+			name:     "docker systemd",
+			data:     "0::/system.slice/docker-" + id + ".scope",
+			expected: id,
+		},
+		{
+			name:     "private cgroup namespace",
+			data:     "0::/",
+			expected: "",
+		},
+		{
+			name:     "not a container",
+			data:     "12:pids:/user.slice/user-1000.slice/session-3.scope",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, containerIDFromCgroup(tc.data))
+		})
+	}
+}
+
+func TestContainerIDFromMountinfo(t *testing.T) {
+	const id = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	testCases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name: "docker",
+			data: "626 619 254:1 /docker/volumes/a/_data /data rw,relatime - ext4 /dev/vda1 rw\n" +
+				"628 619 254:1 /docker/containers/" + id + "/hostname /etc/hostname rw,relatime - ext4 /dev/vda1 rw",
+			expected: id,
+		},
+		{
+			name:     "podman",
+			data:     "1070 1056 0:93 /containers/storage/overlay-containers/" + id + "/userdata/hostname /etc/hostname rw - tmpfs tmpfs rw",
+			expected: id,
+		},
+		{
+			name: "containerd",
+			data: "2088 2079 0:391 / / rw,relatime - overlay overlay rw,lowerdir=/var/lib/containerd/io.containerd.snapshotter.v1.overlayfs/snapshots/17/fs\n" +
+				"2090 2079 259:1 /var/lib/containerd/io.containerd.grpc.v1.cri/sandboxes/" + id + "/hostname /etc/hostname rw,nosuid,nodev,relatime - ext4 /dev/nvme0n1p1 rw",
+			expected: id,
+		},
+		{
+			name:     "containerd cgroup v2 scope",
+			data:     "1987 1976 0:26 /kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod2c48913c.slice/cri-containerd-" + id + ".scope /sys/fs/cgroup ro,nosuid,nodev,noexec,relatime - cgroup2 cgroup rw",
+			expected: id,
+		},
+		{
+			name:     "docker cgroup v2 scope",
+			data:     "1987 1976 0:26 /system.slice/docker-" + id + ".scope /sys/fs/cgroup ro,nosuid,nodev,noexec,relatime - cgroup2 cgroup rw",
+			expected: id,
+		},
+		{
+			name:     "image layer in the mount options",
+			data:     "619 571 0:56 / / rw,relatime master:309 - overlay overlay rw,lowerdir=/var/lib/docker/overlay2/l/BDYSDLBNQ4SE5C5MAWDHVPYQHO,upperdir=/var/lib/docker/overlay2/" + id + "/diff",
+			expected: "",
+		},
+		{
+			name:     "longer hexadecimal string",
+			data:     "1070 1056 0:93 /data/" + id + "0 /data rw - tmpfs tmpfs rw",
+			expected: "",
+		},
+		{
+			name:     "none",
+			data:     "24 30 0:22 / /sys rw,nosuid,nodev,noexec,relatime shared:7 - sysfs sysfs rw",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, containerIDFromMountinfo(tc.data))
+		})
+	}
+}
+
+// blockingDetectorUtils simulates a Kubernetes API that never responds.
+type blockingDetectorUtils struct{}
+
+func (blockingDetectorUtils) FileExists(string) bool { return true }
+
+func (blockingDetectorUtils) GetConfigMap(ctx context.Context, _ string, _ string) (map[string]string, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingDetectorUtils) GetContainerID() (string, error) { return "", nil }
+
+func (blockingDetectorUtils) GetInstanceIdentity(ctx context.Context) (InstanceIdentity, error) {
+	<-ctx.Done()
+	return InstanceIdentity{}, ctx.Err()
+}
+
+func (blockingDetectorUtils) GetPod(ctx context.Context) (*corev1.Pod, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingDetectorUtils) GetInstanceTag(ctx context.Context, _ string) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func TestEksAPITimeout(t *testing.T) {
+	detector := resourceDetector{utils: blockingDetectorUtils{}, cfg: newConfig(WithAPITimeout(time.Millisecond), WithRetry(0, 0))}
+	r, err := detector.Detect(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.Equal(t, 0, r.Len())
+}
+
+func TestEksCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	detector := resourceDetector{utils: blockingDetectorUtils{}, cfg: newConfig()}
+	r, err := detector.Detect(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, r)
+}
+
+func TestNewConfig(t *testing.T) {
+	assert.Equal(t, defaultAPITimeout, newConfig().apiTimeout)
+	assert.Equal(t, time.Second, newConfig(WithAPITimeout(time.Second)).apiTimeout)
+	assert.Equal(t, time.Duration(0), newConfig(WithAPITimeout(0)).apiTimeout)
+	assert.Equal(t, time.Duration(0), newConfig().cacheTTL)
+	assert.Equal(t, time.Minute, newConfig(WithCacheTTL(time.Minute)).cacheTTL)
+	assert.Equal(t, "kube-system/aws-auth", newConfig().authConfigmap)
+	assert.Equal(t, "amazon-cloudwatch/cluster-info", newConfig().clusterInfoConfigmap)
+	assert.Equal(t, defaultCgroupPath, newConfig().cgroupPath)
+	assert.Equal(t, "/tmp/cgroup", newConfig(WithCgroupPath("/tmp/cgroup")).cgroupPath)
+	assert.True(t, newConfig(WithoutContainerID()).withoutContainerID)
+	assert.Equal(t, defaultRetries, newConfig().retries)
+	assert.Equal(t, defaultRetryBackoff, newConfig().retryBackoff)
+	assert.Equal(t, 5, newConfig(WithRetry(5, time.Second)).retries)
+	assert.Equal(t, time.Second, newConfig(WithRetry(5, time.Second)).retryBackoff)
+	assert.Equal(t, semconv.SchemaURL, newConfig().schemaURL)
+	assert.Equal(t, "", newConfig(WithSchemaURL("")).schemaURL)
+
+	c := newConfig(WithConfigmapPaths("ns/auth", ""))
+	assert.Equal(t, "ns/auth", c.authConfigmap)
+	assert.Equal(t, "", c.clusterInfoConfigmap)
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, newConfig().validate())
+	assert.NoError(t, newConfig(WithConfigmapPaths("", "")).validate())
+	for _, path := range []string{"aws-auth", "/aws-auth", "kube-system/", "a/b/c"} {
+		assert.Error(t, newConfig(WithConfigmapPaths(path, "")).validate(), path)
+		assert.Error(t, newConfig(WithConfigmapPaths("", path)).validate(), path)
+	}
+}
+
+func TestNewResourceDetectorInvalidConfigmapPath(t *testing.T) {
+	r, err := NewResourceDetector(WithConfigmapPaths("aws-auth", "")).Detect(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, r)
+}
+
+func TestEksCustomConfigmapPaths(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", "ns", "auth").Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound)
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden"))
+	detectorUtils.On("GetConfigMap", "ns", "info").Return(map[string]string{"cluster.name": "my-cluster"}, nil)
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithConfigmapPaths("ns/auth", "ns/info"))}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	v, ok := r.Set().Value(semconv.K8SClusterNameKey)
+	assert.True(t, ok)
+	assert.Equal(t, "my-cluster", v.AsString())
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestEksDisabledConfigmaps(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	// Without configmaps the detector must not call the Kubernetes API.
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetInstanceTag", "eks:cluster-name").Return("tag-cluster", nil)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound)
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden"))
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithConfigmapPaths("", ""), WithoutContainerID())}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	v, ok := r.Set().Value(semconv.K8SClusterNameKey)
+	assert.True(t, ok)
+	assert.Equal(t, "tag-cluster", v.AsString())
+	_, ok = r.Set().Value(semconv.ContainerIDKey)
+	assert.False(t, ok)
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestNewK8sDetectorUtilsWithoutClient(t *testing.T) {
+	utils, err := newK8sDetectorUtils(newConfig(WithConfigmapPaths("", ""), WithCgroupPath("/tmp/cgroup")))
+	require.NoError(t, err)
+	assert.Nil(t, utils.clientset)
+	assert.Equal(t, "/tmp/cgroup", utils.cgroupPath)
+}
+
+func TestEksCache(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	// Each external call is only expected once, the second Detect must be
+	// served from the cache.
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true).Once()
+	detectorUtils.On("FileExists", k8sCertPath).Return(true).Once()
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil).Once()
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound).Once()
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden")).Once()
+	detectorUtils.On("GetConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string{"cluster.name": "my-cluster"}, nil).Once()
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil).Once()
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithCacheTTL(time.Hour))}
+	first, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	second, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestEksCacheExpired(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true).Twice()
+	detectorUtils.On("FileExists", k8sCertPath).Return(true).Twice()
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil).Twice()
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound).Twice()
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden")).Twice()
+	detectorUtils.On("GetConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string{"cluster.name": "my-cluster"}, nil).Twice()
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil).Twice()
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithCacheTTL(time.Hour))}
+	_, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	detector.expiresAt = time.Now().Add(-time.Second)
+	_, err = detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestEksCacheError(t *testing.T) {
+	detector := resourceDetector{utils: blockingDetectorUtils{}, cfg: newConfig(WithAPITimeout(time.Millisecond), WithCacheTTL(time.Hour))}
+	_, err := detector.Detect(context.Background())
+	require.Error(t, err)
+	assert.Nil(t, detector.cached, "failed detection must not be cached")
+}
+
+func TestNewK8sDetectorUtilsWithClient(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: cwConfigmapNS, Name: cwConfigmapName},
+		Data:       map[string]string{"cluster.name": "my-cluster"},
+	})
+
+	utils, err := newK8sDetectorUtils(newConfig(WithKubernetesClient(clientset)))
+	require.NoError(t, err)
+
+	data, err := utils.GetConfigMap(context.Background(), cwConfigmapNS, cwConfigmapName)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"cluster.name": "my-cluster"}, data)
+
+	_, err = utils.GetConfigMap(context.Background(), authConfigmapNS, authConfigmapName)
+	assert.Error(t, err)
+}
+
+func TestNewK8sDetectorUtilsWithRESTConfig(t *testing.T) {
+	utils, err := newK8sDetectorUtils(newConfig(WithRESTConfig(&rest.Config{Host: "https://127.0.0.1:6443"})))
+	require.NoError(t, err)
+	assert.NotNil(t, utils.clientset)
+}
+
+func TestEksClusterNameOverride(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound)
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden"))
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithClusterName("override"))}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Contains(t, r.Attributes(), semconv.K8SClusterNameKey.String("override"))
+	detectorUtils.AssertExpectations(t)
+}
+
+// setenv sets the environment variable key to value for the duration of the
+// test.
+func setenv(t *testing.T, key, value string) {
+	orig, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, orig)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
+
+func TestEksClusterNameFromEnv(t *testing.T) {
+	setenv(t, clusterNameEnvVar, " env-cluster\n")
+	setenv(t, clusterNameFileEnvVar, "/nonexistent")
+
+	detectorUtils := new(MockDetectorUtils)
+
+	// Neither configmap is read.
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound)
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden"))
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	c := newConfig()
+	assert.False(t, c.needsClient())
+	detector := resourceDetector{utils: detectorUtils, cfg: c}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Contains(t, r.Attributes(), semconv.K8SClusterNameKey.String("env-cluster"))
+	detectorUtils.AssertExpectations(t)
+
+	// WithClusterName takes precedence.
+	detector = resourceDetector{utils: detectorUtils, cfg: newConfig(WithClusterName("override"))}
+	r, err = detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, r.Attributes(), semconv.K8SClusterNameKey.String("override"))
+}
+
+func TestEksClusterNameFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cluster-name")
+	require.NoError(t, ioutil.WriteFile(path, []byte("file-cluster\n"), 0600))
+	setenv(t, clusterNameFileEnvVar, path)
+
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound)
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden"))
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	c := newConfig()
+	assert.Equal(t, path, c.clusterNameFile)
+	assert.False(t, c.needsClient())
+	detector := resourceDetector{utils: detectorUtils, cfg: c}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, r.Attributes(), semconv.K8SClusterNameKey.String("file-cluster"))
+	detectorUtils.AssertExpectations(t)
+
+	// A file that cannot be read fails the detection of the cluster name.
+	detector = resourceDetector{utils: detectorUtils, cfg: newConfig(WithClusterNameFile(filepath.Join(dir, "missing")))}
+	r, err = detector.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.Contains(t, r.Attributes(), semconv.CloudPlatformAWSEKS)
+
+	// An empty path disables the file.
+	assert.True(t, newConfig(WithClusterNameFile("")).needsClient())
+}
+
+func TestEksClusterNameFromInstanceTag(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound)
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden"))
+	detectorUtils.On("GetConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string(nil), errors.New("not found"))
+	detectorUtils.On("GetInstanceTag", "eks:cluster-name").Return("", errIMDSNotFound)
+	detectorUtils.On("GetInstanceTag", "aws:eks:cluster-name").Return("tagged-cluster", nil)
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig()}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Contains(t, r.Attributes(), semconv.K8SClusterNameKey.String("tagged-cluster"))
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestEksClusterNameNotFound(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound)
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden"))
+	detectorUtils.On("GetConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string(nil), errors.New("not found"))
+	detectorUtils.On("GetInstanceTag", mock.Anything).Return("", errIMDSNotFound)
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithRetry(0, 0))}
+	r, err := detector.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+
+	// The attributes that could be detected are still returned.
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEKS,
+		semconv.ContainerIDKey.String("0123456789A"),
+	), r)
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestEksAuthConfigmapError(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string(nil), errors.New("unavailable")).Times(3)
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithRetry(2, time.Millisecond))}
+	r, err := detector.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.Contains(t, err.Error(), "unavailable")
+
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL, semconv.ContainerIDKey.String("0123456789A")), r)
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestEksRetry(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string(nil), errors.New("unavailable")).Once()
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil).Once()
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound).Once()
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden")).Once()
+	detectorUtils.On("GetConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string{"cluster.name": "my-cluster"}, nil).Once()
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithRetry(2, time.Millisecond))}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, r.Attributes(), semconv.K8SClusterNameKey.String("my-cluster"))
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestEksNotFoundNotRetried(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	notFound := apierrors.NewNotFound(corev1.Resource("configmaps"), cwConfigmapName)
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound)
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden"))
+	detectorUtils.On("GetConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string(nil), fmt.Errorf("wrapped: %w", notFound)).Once()
+	detectorUtils.On("GetInstanceTag", "eks:cluster-name").Return("tag-cluster", nil)
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithRetry(2, time.Hour))}
+	_, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	detectorUtils.AssertExpectations(t)
+}
+
+// Tests that a Kubernetes cluster on EC2 without the aws-auth configmap is
+// not detected as EKS, so that the detection can fall back to EC2.
+func TestKubernetesOnEC2(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	notFound := apierrors.NewNotFound(corev1.Resource("configmaps"), authConfigmapName)
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string(nil), fmt.Errorf("wrapped: %w", notFound)).Once()
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithRetry(2, time.Hour))}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), r)
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestEksClusterInfoNotFound(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	notFound := apierrors.NewNotFound(corev1.Resource("configmaps"), cwConfigmapName)
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound)
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden"))
+	detectorUtils.On("GetConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string(nil), notFound)
+	detectorUtils.On("GetInstanceTag", mock.Anything).Return("", errIMDSNotFound)
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig()}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEKS,
+		semconv.ContainerIDKey.String("0123456789A"),
+	), r)
+	detectorUtils.AssertExpectations(t)
+}
+
+func fargatePod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "my-pod",
+			UID:         "8ec3e7a1-2f5c-4bf0-8a0d-1d5d5b3e6c1f",
+			Annotations: map[string]string{computeTypeAnnotation: fargateComputeType},
+		},
+		Spec: corev1.PodSpec{NodeName: "fargate-ip-192-168-1-1.us-west-2.compute.internal"},
+	}
+}
+
+func TestEksFargate(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	// The instance metadata service is not available on Fargate.
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errors.New("unreachable"))
+	detectorUtils.On("GetConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string{"cluster.name": "my-cluster"}, nil)
+	detectorUtils.On("GetPod").Return(fargatePod(), nil).Once()
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig()}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEKS,
+		semconv.K8SClusterNameKey.String("my-cluster"),
+		semconv.K8SNamespaceNameKey.String("default"),
+		semconv.K8SPodNameKey.String("my-pod"),
+		semconv.K8SPodUIDKey.String("8ec3e7a1-2f5c-4bf0-8a0d-1d5d5b3e6c1f"),
+		semconv.K8SNodeNameKey.String("fargate-ip-192-168-1-1.us-west-2.compute.internal"),
+		semconv.ContainerIDKey.String("0123456789A"),
+	), r)
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestEksFargateClusterNameError(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errors.New("unreachable"))
+	detectorUtils.On("GetConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string(nil), errors.New("forbidden"))
+	detectorUtils.On("GetPod").Return(fargatePod(), nil).Once()
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithRetry(0, 0))}
+	r, err := detector.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	var partialErr *partialResourceError
+	require.True(t, errors.As(err, &partialErr))
+	assert.Len(t, partialErr.errs, 1)
+
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEKS,
+		semconv.K8SNamespaceNameKey.String("default"),
+		semconv.K8SPodNameKey.String("my-pod"),
+		semconv.K8SPodUIDKey.String("8ec3e7a1-2f5c-4bf0-8a0d-1d5d5b3e6c1f"),
+		semconv.K8SNodeNameKey.String("fargate-ip-192-168-1-1.us-west-2.compute.internal"),
+		semconv.ContainerIDKey.String("0123456789A"),
+	), r)
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestEksFargateExecutionEnv(t *testing.T) {
+	orig, ok := os.LookupEnv(executionEnvVar)
+	require.NoError(t, os.Setenv(executionEnvVar, fargateExecutionEnv))
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(executionEnvVar, orig)
+		} else {
+			_ = os.Unsetenv(executionEnvVar)
+		}
+	})
+
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("FileExists", k8sCertPath).Return(true)
+	detectorUtils.On("GetConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("GetConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string(nil), errors.New("not found"))
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden"))
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	detector := resourceDetector{utils: detectorUtils, cfg: newConfig(WithRetry(0, 0))}
+	r, err := detector.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.Contains(t, err.Error(), "forbidden")
+
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEKS,
+		semconv.ContainerIDKey.String("0123456789A"),
+	), r)
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestGetPodWithoutClient(t *testing.T) {
+	_, err := eksDetectorUtils{}.GetPod(context.Background())
+	assert.Error(t, err)
+}
+
+func TestInClusterConfig(t *testing.T) {
+	c := newConfig(WithServiceAccountPaths("/tokens/otel", "/certs/ca.crt", ""))
+	assert.Equal(t, k8sNamespacePath, c.namespacePath)
+
+	for _, tc := range []struct {
+		host, port, expected string
+	}{
+		{"10.100.0.1", "443", "https://10.100.0.1:443"},
+		{"fd00:10:96::1", "443", "https://[fd00:10:96::1]:443"},
+		{"[fd00:10:96::1]", "6443", "https://[fd00:10:96::1]:6443"},
+		{"", "", "https://kubernetes.default.svc:443"},
+	} {
+		c.serviceHost, c.servicePort = tc.host, tc.port
+		config := inClusterConfig(c)
+		assert.Equal(t, tc.expected, config.Host)
+		assert.Equal(t, "/tokens/otel", config.BearerTokenFile)
+		assert.Equal(t, "/certs/ca.crt", config.TLSClientConfig.CAFile)
+	}
+}
+
+func TestNewConfigServiceAddress(t *testing.T) {
+	setenv(t, serviceHostEnvVar, "fd00:10:96::1")
+	setenv(t, servicePortEnvVar, "443")
+	c := newConfig()
+	assert.Equal(t, "fd00:10:96::1", c.serviceHost)
+	assert.Equal(t, "443", c.servicePort)
+}
+
+func TestNewK8sDetectorUtilsServiceAccountPaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	tokenPath, caPath, namespacePath := filepath.Join(dir, "token"), filepath.Join(dir, "ca.crt"), filepath.Join(dir, "namespace")
+
+	// No client is created outside of a pod.
+	utils, err := newK8sDetectorUtils(newConfig(WithServiceAccountPaths(tokenPath, caPath, namespacePath)))
+	require.NoError(t, err)
+	assert.Nil(t, utils.clientset)
+
+	require.NoError(t, ioutil.WriteFile(tokenPath, []byte("token"), 0600))
+	require.NoError(t, ioutil.WriteFile(caPath, nil, 0600))
+	require.NoError(t, ioutil.WriteFile(namespacePath, []byte("team-a"), 0600))
+	utils, err = newK8sDetectorUtils(newConfig(WithServiceAccountPaths(tokenPath, caPath, namespacePath)))
+	require.NoError(t, err)
+	assert.NotNil(t, utils.clientset)
+	assert.Equal(t, namespacePath, utils.namespacePath)
+}
+
+func TestEksServiceAccountPaths(t *testing.T) {
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("FileExists", "/tokens/otel").Return(true)
+	detectorUtils.On("FileExists", "/certs/ca.crt").Return(true)
+	detectorUtils.On("GetInstanceIdentity").Return(InstanceIdentity{}, errIMDSNotFound)
+	detectorUtils.On("GetPod").Return(nil, errors.New("forbidden"))
+	detectorUtils.On("GetContainerID").Return("0123456789A", nil)
+
+	c := newConfig(WithServiceAccountPaths("/tokens/otel", "/certs/ca.crt", ""), WithConfigmapPaths("", ""), WithClusterName("my-cluster"))
+	detector := resourceDetector{utils: detectorUtils, cfg: c}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, r.Attributes(), semconv.CloudPlatformAWSEKS)
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestIsIPv6(t *testing.T) {
+	assert.True(t, isIPv6("fd00:10:96::1"))
+	assert.True(t, isIPv6("[fd00:10:96::1]"))
+	assert.False(t, isIPv6("10.100.0.1"))
+	assert.False(t, isIPv6("::ffff:10.100.0.1"))
+	assert.False(t, isIPv6("kubernetes.default.svc"))
+	assert.False(t, isIPv6(""))
+}
@@ -2,10 +2,20 @@ module go.opentelemetry.io/contrib/detectors/aws/eks
 
 go 1.15
 
+replace (
+	go.opentelemetry.io/contrib => ../../..
+	go.opentelemetry.io/contrib/detectors => ../..
+	go.opentelemetry.io/contrib/detectors/aws/internal => ../internal
+)
+
 require (
 	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib v1.1.0
+	go.opentelemetry.io/contrib/detectors v0.26.0
+	go.opentelemetry.io/contrib/detectors/aws/internal v1.1.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/sdk v1.1.0
+	k8s.io/api v0.21.3
 	k8s.io/apimachinery v0.21.3
 	k8s.io/client-go v0.21.3
 )
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/contrib/detectors/aws/internal/imds"
+)
+
+const imdsTagsPath = "tags/instance/"
+
+// errIMDSNotFound is returned when the requested instance metadata does not
+// exist.
+var errIMDSNotFound = imds.ErrNotFound
+
+// InstanceIdentity holds the fields of the instance identity document used
+// by the detector.
+type InstanceIdentity struct {
+	AccountID        string `json:"accountId"`
+	Region           string `json:"region"`
+	AvailabilityZone string `json:"availabilityZone"`
+}
+
+// newIMDSClient returns the instance metadata service client of the
+// configuration. The IPv6 endpoint is used in the clusters whose API server has
+// an IPv6 address, where the pods only have IPv6 addresses, unless the endpoint
+// mode is set by the AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE environment
+// variable.
+func newIMDSClient(c *config) *imds.Client {
+	cfg := imds.Config{Endpoint: c.imdsEndpoint, HopLimit: c.imdsHopLimit}
+	if os.Getenv(imds.EndpointModeEnvVar) == "" && isIPv6(c.serviceHost) {
+		cfg.EndpointMode = imds.EndpointModeIPv6
+	}
+	return imds.NewClient(cfg)
+}
+
+// isIPv6 returns whether host is an IPv6 address, with or without brackets.
+func isIPv6(host string) bool {
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.To4() == nil
+}
+
+// getInstanceIdentity returns the fields of the identity document of the
+// instance used by the detector.
+func getInstanceIdentity(ctx context.Context, client *imds.Client) (InstanceIdentity, error) {
+	doc, err := client.GetInstanceIdentityDocument(ctx)
+	if err != nil {
+		return InstanceIdentity{}, err
+	}
+	return InstanceIdentity{
+		AccountID:        doc.AccountID,
+		Region:           doc.Region,
+		AvailabilityZone: doc.AvailabilityZone,
+	}, nil
+}
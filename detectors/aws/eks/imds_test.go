@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/detectors/aws/internal/imds"
+)
+
+const testIdentityDocument = `{
+  "accountId" : "123456789012",
+  "availabilityZone" : "us-west-2b",
+  "instanceId" : "i-1234567890abcdef0",
+  "region" : "us-west-2"
+}`
+
+const (
+	testTokenHeader  = "X-aws-ec2-metadata-token"
+	testTagsPath     = "/latest/meta-data/" + imdsTagsPath
+	testIdentityPath = "/latest/dynamic/instance-identity/document"
+)
+
+func newTestIMDS(t *testing.T, tags map[string]string) *imds.Client {
+	const token = "test-token"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest/api/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(token))
+	})
+	mux.HandleFunc(testTagsPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(testTokenHeader) != token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		v, ok := tags[r.URL.Path[len(testTagsPath):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(v))
+	})
+	mux.HandleFunc(testIdentityPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(testTokenHeader) != token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(testIdentityDocument))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return newIMDSClient(&config{imdsEndpoint: srv.URL})
+}
+
+func TestIMDSGetInstanceTag(t *testing.T) {
+	utils := eksDetectorUtils{imds: newTestIMDS(t, map[string]string{"eks:cluster-name": "my-cluster"})}
+
+	name, err := utils.GetInstanceTag(context.Background(), "eks:cluster-name")
+	require.NoError(t, err)
+	assert.Equal(t, "my-cluster", name)
+
+	_, err = utils.GetInstanceTag(context.Background(), "missing")
+	assert.ErrorIs(t, err, errIMDSNotFound)
+}
+
+func TestIMDSGetInstanceIdentity(t *testing.T) {
+	utils := eksDetectorUtils{imds: newTestIMDS(t, nil)}
+
+	identity, err := utils.GetInstanceIdentity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, InstanceIdentity{
+		AccountID:        "123456789012",
+		Region:           "us-west-2",
+		AvailabilityZone: "us-west-2b",
+	}, identity)
+}
+
+func TestIMDSUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	utils := eksDetectorUtils{imds: newIMDSClient(&config{imdsEndpoint: srv.URL})}
+	_, err := utils.GetInstanceTag(context.Background(), "eks:cluster-name")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, errIMDSNotFound)
+}
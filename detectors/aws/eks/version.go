@@ -14,6 +14,20 @@
 
 package eks
 
+import (
+	"go.opentelemetry.io/contrib/registry"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func init() {
+	registry.Register(registry.Component{
+		Name:      "go.opentelemetry.io/contrib/detectors/aws/eks",
+		Kind:      registry.KindDetector,
+		Version:   Version(),
+		SchemaURL: semconv.SchemaURL,
+	})
+}
+
 // Version is the current release version of the EKS resource detector.
 func Version() string {
 	return "1.1.0"
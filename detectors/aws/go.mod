@@ -0,0 +1,27 @@
+module go.opentelemetry.io/contrib/detectors/aws
+
+go 1.15
+
+replace (
+	go.opentelemetry.io/contrib => ../..
+	go.opentelemetry.io/contrib/detectors => ..
+	go.opentelemetry.io/contrib/detectors/aws/beanstalk => ./beanstalk
+	go.opentelemetry.io/contrib/detectors/aws/ec2 => ./ec2
+	go.opentelemetry.io/contrib/detectors/aws/ecs => ./ecs
+	go.opentelemetry.io/contrib/detectors/aws/eks => ./eks
+	go.opentelemetry.io/contrib/detectors/aws/internal => ./internal
+	go.opentelemetry.io/contrib/detectors/aws/lambda => ./lambda
+)
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/detectors v0.26.0
+	go.opentelemetry.io/contrib/detectors/aws/beanstalk v0.26.0
+	go.opentelemetry.io/contrib/detectors/aws/ec2 v1.1.0
+	go.opentelemetry.io/contrib/detectors/aws/ecs v1.1.0
+	go.opentelemetry.io/contrib/detectors/aws/eks v1.1.0
+	go.opentelemetry.io/contrib/detectors/aws/lambda v0.26.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	k8s.io/client-go v0.21.3
+)
@@ -0,0 +1,5 @@
+module go.opentelemetry.io/contrib/detectors/aws/internal
+
+go 1.15
+
+require github.com/stretchr/testify v1.7.0
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package imds
+
+import (
+	"strings"
+	"syscall"
+)
+
+// hopLimitControl returns a net.Dialer Control function that sets the time to
+// live, or the hop limit, of the packets of the dialed connections.
+func hopLimitControl(hops int) func(network, address string, c syscall.RawConn) error {
+	return func(network, address string, c syscall.RawConn) error {
+		level, opt := syscall.IPPROTO_IP, syscall.IP_TTL
+		if strings.HasSuffix(network, "6") {
+			level, opt = syscall.IPPROTO_IPV6, syscall.IPV6_UNICAST_HOPS
+		}
+		var sockErr error
+		err := c.Control(func(fd uintptr) {
+			sockErr = syscall.SetsockoptInt(int(fd), level, opt, hops)
+		})
+		if err != nil {
+			return err
+		}
+		return sockErr
+	}
+}
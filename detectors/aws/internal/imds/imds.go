@@ -0,0 +1,314 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package imds provides a client of the EC2 instance metadata service shared
+// by the AWS resource detectors.
+package imds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultEndpoint is the endpoint of the instance metadata service.
+	DefaultEndpoint = "http://169.254.169.254"
+	// IPv6Endpoint is the endpoint of the instance metadata service on the
+	// IPv6 network of the Nitro instances, used in IPv6-only subnets and pods.
+	IPv6Endpoint = "http://[fd00:ec2::254]"
+	// DefaultTimeout is the default timeout of requests to the instance
+	// metadata service. The service is local to the host, so it is short to
+	// fail fast when not running on EC2.
+	DefaultTimeout = 2 * time.Second
+	// DefaultTokenTTL is the default lifetime of the IMDSv2 session tokens.
+	DefaultTokenTTL = 6 * time.Hour
+
+	// EndpointEnvVar and DisabledEnvVar are the environment variables of the
+	// AWS SDKs that override the endpoint and disable the service.
+	EndpointEnvVar = "AWS_EC2_METADATA_SERVICE_ENDPOINT"
+	DisabledEnvVar = "AWS_EC2_METADATA_DISABLED"
+	// EndpointModeEnvVar is the environment variable of the AWS SDKs
+	// selecting the default endpoint, EndpointModeIPv4 or EndpointModeIPv6.
+	EndpointModeEnvVar = "AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE"
+
+	// EndpointModeIPv4 and EndpointModeIPv6 select DefaultEndpoint and
+	// IPv6Endpoint when no endpoint is set.
+	EndpointModeIPv4 = "IPv4"
+	EndpointModeIPv6 = "IPv6"
+
+	tokenPath      = "/latest/api/token"
+	tokenTTLHeader = "X-aws-ec2-metadata-token-ttl-seconds"
+	tokenHeader    = "X-aws-ec2-metadata-token"
+	metadataPath   = "/latest/meta-data/"
+	identityPath   = "/latest/dynamic/instance-identity/document"
+
+	// tokenRefreshMargin is how long before its expiry a token is replaced,
+	// so that it does not expire while a request is sent.
+	tokenRefreshMargin = time.Minute
+)
+
+var (
+	// ErrNotFound is returned when the requested instance metadata does not
+	// exist.
+	ErrNotFound = errors.New("instance metadata not found")
+	// ErrDisabled is returned when the instance metadata service is disabled
+	// with the AWS_EC2_METADATA_DISABLED environment variable.
+	ErrDisabled = errors.New("instance metadata service disabled")
+)
+
+// InstanceIdentityDocument holds the fields of the identity document of an
+// EC2 instance.
+type InstanceIdentityDocument struct {
+	AccountID        string    `json:"accountId"`
+	Architecture     string    `json:"architecture"`
+	AvailabilityZone string    `json:"availabilityZone"`
+	ImageID          string    `json:"imageId"`
+	InstanceID       string    `json:"instanceId"`
+	InstanceType     string    `json:"instanceType"`
+	KernelID         string    `json:"kernelId"`
+	PendingTime      time.Time `json:"pendingTime"`
+	PrivateIP        string    `json:"privateIp"`
+	RamdiskID        string    `json:"ramdiskId"`
+	Region           string    `json:"region"`
+	Version          string    `json:"version"`
+}
+
+// Config configures a Client. The zero value is valid and uses the defaults.
+type Config struct {
+	// Endpoint is the endpoint of the instance metadata service. If empty,
+	// the AWS_EC2_METADATA_SERVICE_ENDPOINT environment variable is used,
+	// and then the endpoint of the EndpointMode.
+	Endpoint string
+	// EndpointMode selects IPv6Endpoint when it is EndpointModeIPv6, and
+	// DefaultEndpoint otherwise, when no endpoint is set. If empty, the
+	// AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE environment variable is used.
+	EndpointMode string
+	// Timeout is the timeout of each request. If not positive,
+	// DefaultTimeout is used.
+	Timeout time.Duration
+	// HopLimit is the IP time to live, or IPv6 hop limit, of the packets
+	// sent to the instance metadata service. If not positive, the default of
+	// the system is used.
+	HopLimit int
+	// TokenTTL is the lifetime requested for the IMDSv2 session tokens,
+	// which is rounded down to seconds. If less than a second,
+	// DefaultTokenTTL is used.
+	TokenTTL time.Duration
+	// HTTPClient is the client sending the requests, which replaces the
+	// client built from Timeout and HopLimit.
+	HTTPClient *http.Client
+}
+
+// Client retrieves metadata from the instance metadata service with IMDSv2
+// session tokens. The token is cached and shared by all the requests until it
+// expires. When the service does not support tokens, or the token request
+// times out because the response exceeds the hop limit of the instance, e.g.
+// in a container, the requests are sent without a token as with IMDSv1.
+//
+// A Client is safe for concurrent use.
+type Client struct {
+	endpoint   string
+	tokenTTL   time.Duration
+	disabled   bool
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+	// withoutToken is set when the requests are sent without a token.
+	withoutToken bool
+}
+
+// NewClient returns a Client configured by cfg.
+func NewClient(cfg Config) *Client {
+	c := &Client{
+		endpoint:   cfg.Endpoint,
+		tokenTTL:   cfg.TokenTTL.Truncate(time.Second),
+		httpClient: cfg.HTTPClient,
+		now:        time.Now,
+	}
+	if c.endpoint == "" {
+		c.endpoint = os.Getenv(EndpointEnvVar)
+	}
+	if c.endpoint == "" {
+		c.endpoint = defaultEndpoint(cfg.EndpointMode)
+	}
+	c.endpoint = strings.TrimSuffix(c.endpoint, "/")
+	if c.tokenTTL <= 0 {
+		c.tokenTTL = DefaultTokenTTL
+	}
+	c.disabled = strings.EqualFold(os.Getenv(DisabledEnvVar), "true")
+
+	if c.httpClient == nil {
+		timeout := cfg.Timeout
+		if timeout <= 0 {
+			timeout = DefaultTimeout
+		}
+		dialer := &net.Dialer{Timeout: timeout}
+		if cfg.HopLimit > 0 {
+			dialer.Control = hopLimitControl(cfg.HopLimit)
+		}
+		c.httpClient = &http.Client{
+			Timeout: timeout,
+			// The instance metadata service is never reached through a proxy.
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+		}
+	}
+	return c
+}
+
+// defaultEndpoint returns the endpoint of mode, or of the
+// AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE environment variable if it is empty.
+func defaultEndpoint(mode string) string {
+	if mode == "" {
+		mode = os.Getenv(EndpointModeEnvVar)
+	}
+	if strings.EqualFold(mode, EndpointModeIPv6) {
+		return IPv6Endpoint
+	}
+	return DefaultEndpoint
+}
+
+// Available returns whether the instance metadata service can be reached,
+// which is the case when running on EC2.
+func (c *Client) Available(ctx context.Context) bool {
+	_, err := c.GetMetadata(ctx, "instance-id")
+	return err == nil
+}
+
+// GetMetadata returns the instance metadata at path, relative to
+// /latest/meta-data/. It returns ErrNotFound if the metadata does not exist.
+func (c *Client) GetMetadata(ctx context.Context, path string) (string, error) {
+	return c.get(ctx, metadataPath+strings.TrimPrefix(path, "/"))
+}
+
+// GetInstanceIdentityDocument returns the identity document of the instance.
+func (c *Client) GetInstanceIdentityDocument(ctx context.Context) (InstanceIdentityDocument, error) {
+	var doc InstanceIdentityDocument
+	body, err := c.get(ctx, identityPath)
+	if err != nil {
+		return doc, err
+	}
+	if err := json.Unmarshal([]byte(body), &doc); err != nil {
+		return doc, fmt.Errorf("invalid instance identity document: %w", err)
+	}
+	return doc, nil
+}
+
+// get sends a GET request for path with a session token. A request rejected
+// because the token expired early is sent again with a new token.
+func (c *Client) get(ctx context.Context, path string) (string, error) {
+	if c.disabled {
+		return "", ErrDisabled
+	}
+
+	for retried := false; ; retried = true {
+		token, err := c.getToken(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+path, nil)
+		if err != nil {
+			return "", err
+		}
+		if token != "" {
+			req.Header.Set(tokenHeader, token)
+		}
+		body, status, err := c.do(req)
+		if status == http.StatusUnauthorized && token != "" && !retried {
+			c.resetToken(token)
+			continue
+		}
+		return body, err
+	}
+}
+
+// getToken returns the cached session token, or a new one if it is about to
+// expire. It returns an empty token when the requests are sent without one.
+func (c *Client) getToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.withoutToken {
+		return "", nil
+	}
+	if c.token != "" && c.now().Before(c.expires.Add(-tokenRefreshMargin)) {
+		return c.token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.endpoint+tokenPath, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(tokenTTLHeader, strconv.Itoa(int(c.tokenTTL/time.Second)))
+	requested := c.now()
+	token, status, err := c.do(req)
+	if err != nil {
+		var netErr net.Error
+		if status == http.StatusNotFound || status == http.StatusMethodNotAllowed ||
+			(errors.As(err, &netErr) && netErr.Timeout() && ctx.Err() == nil) {
+			c.withoutToken = true
+			return "", nil
+		}
+		return "", err
+	}
+
+	c.token = token
+	c.expires = requested.Add(c.tokenTTL)
+	return token, nil
+}
+
+// resetToken drops the cached token if it is still token, so that the next
+// request gets a new one.
+func (c *Client) resetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == token {
+		c.token = ""
+	}
+}
+
+// do sends req and returns the trimmed body of the response and its status
+// code. The status code is 0 when no response was received.
+func (c *Client) do(req *http.Request) (string, int, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("instance metadata request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound && req.Method == http.MethodGet {
+		return "", resp.StatusCode, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", resp.StatusCode, fmt.Errorf("instance metadata request %s %s failed: %s", req.Method, req.URL.Path, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", resp.StatusCode, err
+	}
+	return strings.TrimSpace(string(body)), resp.StatusCode, nil
+}
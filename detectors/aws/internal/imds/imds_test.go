@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imds
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIMDS is an instance metadata service serving the metadata of one
+// instance. Unless v1 is set, only the requests with a valid token are
+// accepted.
+type testIMDS struct {
+	v1     bool
+	tokens int
+	ttls   []string
+}
+
+func (s *testIMDS) token() string {
+	return "token-" + strconv.Itoa(s.tokens)
+}
+
+func (s *testIMDS) start(t *testing.T) *httptest.Server {
+	metadata := map[string]string{
+		metadataPath + "instance-id": "i-1234567890abcdef0",
+		metadataPath + "hostname":    "ip-10-158-112-84.us-west-2.compute.internal",
+		identityPath: `{
+			"accountId": "123456789012",
+			"availabilityZone": "us-west-2b",
+			"imageId": "ami-5fb8c835",
+			"instanceId": "i-1234567890abcdef0",
+			"instanceType": "t2.micro",
+			"region": "us-west-2"
+		}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == tokenPath {
+			if s.v1 || r.Method != http.MethodPut {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			s.tokens++
+			s.ttls = append(s.ttls, r.Header.Get(tokenTTLHeader))
+			_, _ = w.Write([]byte(s.token()))
+			return
+		}
+		if !s.v1 && r.Header.Get(tokenHeader) != s.token() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		v, ok := metadata[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(v))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGetMetadata(t *testing.T) {
+	s := &testIMDS{}
+	c := NewClient(Config{Endpoint: s.start(t).URL + "/", TokenTTL: time.Hour})
+	ctx := context.Background()
+
+	assert.True(t, c.Available(ctx))
+	hostname, err := c.GetMetadata(ctx, "hostname")
+	require.NoError(t, err)
+	assert.Equal(t, "ip-10-158-112-84.us-west-2.compute.internal", hostname)
+
+	_, err = c.GetMetadata(ctx, "/tags/instance/missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	doc, err := c.GetInstanceIdentityDocument(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, InstanceIdentityDocument{
+		AccountID:        "123456789012",
+		AvailabilityZone: "us-west-2b",
+		ImageID:          "ami-5fb8c835",
+		InstanceID:       "i-1234567890abcdef0",
+		InstanceType:     "t2.micro",
+		Region:           "us-west-2",
+	}, doc)
+
+	// The token is requested once and shared by the requests.
+	assert.Equal(t, []string{"3600"}, s.ttls)
+}
+
+func TestTokenRefresh(t *testing.T) {
+	s := &testIMDS{}
+	c := NewClient(Config{Endpoint: s.start(t).URL})
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := c.GetMetadata(ctx, "instance-id")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"21600"}, s.ttls)
+
+	// A new token is requested shortly before the token expires.
+	now = now.Add(DefaultTokenTTL - time.Second)
+	_, err = c.GetMetadata(ctx, "instance-id")
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.tokens)
+
+	// A request rejected because the token was revoked is sent again with a
+	// new token.
+	s.tokens++
+	_, err = c.GetMetadata(ctx, "instance-id")
+	require.NoError(t, err)
+	assert.Equal(t, 4, s.tokens)
+}
+
+func TestWithoutToken(t *testing.T) {
+	s := &testIMDS{v1: true}
+	c := NewClient(Config{Endpoint: s.start(t).URL})
+
+	id, err := c.GetMetadata(context.Background(), "instance-id")
+	require.NoError(t, err)
+	assert.Equal(t, "i-1234567890abcdef0", id)
+	assert.True(t, c.withoutToken)
+}
+
+func TestTokenTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == tokenPath {
+			// The response of the token request exceeds the hop limit.
+			time.Sleep(100 * time.Millisecond)
+			return
+		}
+		_, _ = w.Write([]byte("i-1234567890abcdef0"))
+	}))
+	defer srv.Close()
+	c := NewClient(Config{Endpoint: srv.URL, Timeout: 10 * time.Millisecond})
+
+	id, err := c.GetMetadata(context.Background(), "instance-id")
+	require.NoError(t, err)
+	assert.Equal(t, "i-1234567890abcdef0", id)
+}
+
+func TestUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+	c := NewClient(Config{Endpoint: srv.URL})
+
+	_, err := c.GetMetadata(context.Background(), "instance-id")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotFound)
+	assert.False(t, c.Available(context.Background()))
+}
+
+func TestHopLimit(t *testing.T) {
+	s := &testIMDS{}
+	c := NewClient(Config{Endpoint: s.start(t).URL, HopLimit: 2})
+	assert.True(t, c.Available(context.Background()))
+}
+
+func TestEnvironment(t *testing.T) {
+	s := &testIMDS{}
+	endpoint := s.start(t).URL
+	defer os.Unsetenv(EndpointEnvVar)
+	defer os.Unsetenv(DisabledEnvVar)
+
+	require.NoError(t, os.Setenv(EndpointEnvVar, endpoint))
+	c := NewClient(Config{})
+	assert.Equal(t, endpoint, c.endpoint)
+	assert.True(t, c.Available(context.Background()))
+
+	require.NoError(t, os.Setenv(DisabledEnvVar, "true"))
+	c = NewClient(Config{})
+	_, err := c.GetMetadata(context.Background(), "instance-id")
+	assert.ErrorIs(t, err, ErrDisabled)
+}
+
+func TestEndpointMode(t *testing.T) {
+	defer os.Unsetenv(EndpointModeEnvVar)
+
+	assert.Equal(t, DefaultEndpoint, NewClient(Config{}).endpoint)
+	assert.Equal(t, IPv6Endpoint, NewClient(Config{EndpointMode: EndpointModeIPv6}).endpoint)
+	assert.Equal(t, "http://localhost", NewClient(Config{Endpoint: "http://localhost", EndpointMode: EndpointModeIPv6}).endpoint)
+
+	require.NoError(t, os.Setenv(EndpointModeEnvVar, "ipv6"))
+	assert.Equal(t, IPv6Endpoint, NewClient(Config{}).endpoint)
+	assert.Equal(t, DefaultEndpoint, NewClient(Config{EndpointMode: EndpointModeIPv4}).endpoint)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strconv"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -30,6 +31,8 @@ const (
 	lambdaFunctionNameEnvVar    = "AWS_LAMBDA_FUNCTION_NAME"
 	awsRegionEnvVar             = "AWS_REGION"
 	lambdaFunctionVersionEnvVar = "AWS_LAMBDA_FUNCTION_VERSION"
+	lambdaLogStreamNameEnvVar   = "AWS_LAMBDA_LOG_STREAM_NAME"
+	lambdaMemoryLimitEnvVar     = "AWS_LAMBDA_FUNCTION_MEMORY_SIZE"
 )
 
 var (
@@ -38,14 +41,17 @@ var (
 )
 
 // resource detector collects resource information from Lambda environment
-type resourceDetector struct{}
+type resourceDetector struct {
+	schemaURL string
+}
 
 // compile time assertion that resource detector implements the resource.Detector interface.
 var _ resource.Detector = (*resourceDetector)(nil)
 
 // NewResourceDetector returns a resource detector that will detect AWS Lambda resources.
-func NewResourceDetector() resource.Detector {
-	return &resourceDetector{}
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &resourceDetector{schemaURL: c.schemaURL}
 }
 
 // Detect collects resource attributes available when running on lambda
@@ -61,10 +67,19 @@ func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, e
 
 	attrs := []attribute.KeyValue{
 		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSLambda,
 		semconv.CloudRegionKey.String(awsRegion),
 		semconv.FaaSNameKey.String(lambdaName),
 		semconv.FaaSVersionKey.String(functionVersion),
 	}
 
-	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+	// The log stream name is unique to each execution environment.
+	if instance := os.Getenv(lambdaLogStreamNameEnvVar); len(instance) > 0 {
+		attrs = append(attrs, semconv.FaaSInstanceKey.String(instance))
+	}
+	if maxMemory, err := strconv.Atoi(os.Getenv(lambdaMemoryLimitEnvVar)); err == nil {
+		attrs = append(attrs, semconv.FaaSMaxMemoryKey.Int(maxMemory))
+	}
+
+	return resource.NewWithAttributes(detector.schemaURL, attrs...), nil
 }
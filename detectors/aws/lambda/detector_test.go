@@ -32,21 +32,56 @@ func TestDetectSuccess(t *testing.T) {
 	_ = os.Setenv(lambdaFunctionNameEnvVar, "testFunction")
 	_ = os.Setenv(awsRegionEnvVar, "us-texas-1")
 	_ = os.Setenv(lambdaFunctionVersionEnvVar, "$LATEST")
+	_ = os.Setenv(lambdaLogStreamNameEnvVar, "2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc")
+	_ = os.Setenv(lambdaMemoryLimitEnvVar, "128")
 
 	attributes := []attribute.KeyValue{
 		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSLambda,
 		semconv.CloudRegionKey.String("us-texas-1"),
 		semconv.FaaSNameKey.String("testFunction"),
 		semconv.FaaSVersionKey.String("$LATEST"),
+		semconv.FaaSInstanceKey.String("2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"),
+		semconv.FaaSMaxMemoryKey.Int(128),
 	}
 	expectedResource := resource.NewWithAttributes(semconv.SchemaURL, attributes...)
-	detector := resourceDetector{}
+	detector := resourceDetector{schemaURL: semconv.SchemaURL}
 	res, err := detector.Detect(context.Background())
 
 	assert.Nil(t, err, "Detector unexpectedly returned error")
 	assert.Equal(t, expectedResource, res, "Resource returned is incorrect")
 }
 
+// omit the optional attributes when their environment variables are not set
+func TestDetectMinimal(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv(lambdaFunctionNameEnvVar, "testFunction")
+	_ = os.Setenv(awsRegionEnvVar, "us-texas-1")
+	_ = os.Setenv(lambdaFunctionVersionEnvVar, "$LATEST")
+	_ = os.Setenv(lambdaMemoryLimitEnvVar, "invalid")
+
+	detector := resourceDetector{}
+	res, err := detector.Detect(context.Background())
+
+	assert.Nil(t, err, "Detector unexpectedly returned error")
+	_, ok := res.Set().Value(semconv.FaaSInstanceKey)
+	assert.False(t, ok)
+	_, ok = res.Set().Value(semconv.FaaSMaxMemoryKey)
+	assert.False(t, ok)
+}
+
+// return a resource with the configured schema URL
+func TestDetectSchemaURL(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv(lambdaFunctionNameEnvVar, "testFunction")
+
+	detector := NewResourceDetector(WithSchemaURL("https://opentelemetry.io/schemas/1.4.0"))
+	res, err := detector.Detect(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.4.0", res.SchemaURL())
+}
+
 // return empty resource when not running on lambda
 func TestReturnsIfNoEnvVars(t *testing.T) {
 	os.Clearenv()
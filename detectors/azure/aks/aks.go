@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aks provides a resource detector for Azure Kubernetes Service.
+package aks
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/detectors/azure/internal/attributes"
+	"go.opentelemetry.io/contrib/detectors/azure/internal/imds"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+const (
+	k8sServiceHostEnvVar = "KUBERNETES_SERVICE_HOST"
+	k8sNamespacePath     = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+	// The environment variables the pod name, namespace, and node name
+	// are expected in. They need to be set with the downward API.
+	podNameEnvVar      = "POD_NAME"
+	podNamespaceEnvVar = "POD_NAMESPACE"
+	nodeNameEnvVar     = "NODE_NAME"
+
+	// clusterNameTag is the tag AKS sets on the virtual machines of a
+	// cluster to the name of the cluster.
+	clusterNameTag = "aks-managed-cluster-name"
+	// nodeResourceGroupPrefix is the prefix of the name of the resource
+	// group AKS creates for the nodes of a cluster, which is named
+	// "MC_<resource group>_<cluster name>_<location>" by default.
+	nodeResourceGroupPrefix = "MC_"
+)
+
+type config struct {
+	endpoint    string
+	timeout     time.Duration
+	clusterName string
+	schemaURL   string
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{schemaURL: semconv.SchemaURL}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies an AKS resource detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithEndpoint sets the endpoint of the Instance Metadata Service. The
+// default is "http://169.254.169.254".
+func WithEndpoint(endpoint string) Option {
+	return optionFunc(func(c *config) {
+		c.endpoint = endpoint
+	})
+}
+
+// WithTimeout sets the timeout of the request made to the Instance Metadata
+// Service. The default is 2 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.timeout = timeout
+	})
+}
+
+// WithClusterName sets the name of the cluster instead of deriving it from
+// the metadata of the node.
+func WithClusterName(name string) Option {
+	return optionFunc(func(c *config) {
+		c.clusterName = name
+	})
+}
+
+// WithSchemaURL sets the schema URL of the detected Resource.
+func WithSchemaURL(schemaURL string) Option {
+	return optionFunc(func(c *config) {
+		c.schemaURL = schemaURL
+	})
+}
+
+// resource detector collects resource information from Azure Kubernetes Service
+type resourceDetector struct {
+	client        *imds.Client
+	clusterName   string
+	getenv        func(string) string
+	namespacePath string
+	schemaURL     string
+}
+
+// compile time assertion that resourceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that will detect Azure Kubernetes Service resources.
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &resourceDetector{
+		client:        imds.NewClient(c.endpoint, c.timeout),
+		clusterName:   c.clusterName,
+		getenv:        os.Getenv,
+		namespacePath: k8sNamespacePath,
+		schemaURL:     c.schemaURL,
+	}
+}
+
+// Detect detects associated resources when running in an AKS cluster. A nil
+// Resource is returned if not running in Kubernetes or if the Instance
+// Metadata Service cannot be reached.
+//
+// The cluster name is read from the aks-managed-cluster-name tag of the node
+// or derived from the name of its resource group. The pod attributes are read
+// from the POD_NAME, POD_NAMESPACE, and NODE_NAME environment variables, which
+// need to be set with the downward API. If they are not set, the pod name
+// falls back to the hostname and the namespace to the namespace of the service
+// account.
+func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if detector.getenv(k8sServiceHostEnvVar) == "" {
+		return nil, nil
+	}
+
+	compute, err := detector.client.GetCompute(ctx)
+	if err != nil {
+		var unavailable *imds.UnavailableError
+		if errors.As(err, &unavailable) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureAKS,
+	}
+	add := func(k attribute.Key, v string) {
+		if v != "" {
+			attrs = append(attrs, k.String(v))
+		}
+	}
+	add(semconv.CloudRegionKey, compute.Location)
+	add(semconv.CloudAccountIDKey, compute.SubscriptionID)
+	add(attributes.ResourceGroupKey, compute.ResourceGroupName)
+
+	clusterName := detector.clusterName
+	if clusterName == "" {
+		clusterName = clusterNameFromCompute(compute)
+	}
+	add(semconv.K8SClusterNameKey, clusterName)
+
+	add(semconv.K8SNamespaceNameKey, detector.namespace())
+	add(semconv.K8SPodNameKey, detector.podName())
+	add(semconv.K8SNodeNameKey, detector.getenv(nodeNameEnvVar))
+
+	return resource.NewWithAttributes(detector.schemaURL, attrs...), nil
+}
+
+func (detector *resourceDetector) namespace() string {
+	if ns := detector.getenv(podNamespaceEnvVar); ns != "" {
+		return ns
+	}
+	data, err := ioutil.ReadFile(detector.namespacePath)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func (detector *resourceDetector) podName() string {
+	if name := detector.getenv(podNameEnvVar); name != "" {
+		return name
+	}
+	return detector.getenv("HOSTNAME")
+}
+
+// clusterNameFromCompute returns the name of the cluster the node belongs
+// to. The cluster name is read from the tags of the node if present, and
+// derived from the default name of the node resource group otherwise. As
+// both resource group and cluster names may contain underscores, the name is
+// assumed to be the part of the resource group name following its last
+// underscore, excluding the location.
+func clusterNameFromCompute(compute *imds.Compute) string {
+	if name, ok := compute.Tag(clusterNameTag); ok {
+		return name
+	}
+
+	rg := compute.ResourceGroupName
+	if !strings.HasPrefix(rg, nodeResourceGroupPrefix) {
+		return ""
+	}
+	rg = strings.TrimSuffix(rg[len(nodeResourceGroupPrefix):], "_"+compute.Location)
+	if i := strings.LastIndex(rg, "_"); i >= 0 {
+		return rg[i+1:]
+	}
+	return ""
+}
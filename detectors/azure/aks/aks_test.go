@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aks
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/detectors/azure/internal/attributes"
+	"go.opentelemetry.io/contrib/detectors/azure/internal/imds"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+const computeMetadata = `{
+  "location": "westeurope",
+  "name": "aks-nodepool1-12345678-vmss_0",
+  "resourceGroupName": "MC_my-rg_my-cluster_westeurope",
+  "subscriptionId": "xxxxxxxx-xxxxx-xxx-xxx-xxxx",
+  "tags": "aksEngineVersion:v0.47.0;aks-managed-cluster-name:tagged-cluster"
+}`
+
+func getenv(m map[string]string) func(string) string {
+	return func(s string) string {
+		return m[s]
+	}
+}
+
+func newTestDetector(t *testing.T, env map[string]string) *resourceDetector {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(computeMetadata))
+	}))
+	t.Cleanup(srv.Close)
+
+	nsPath := filepath.Join(t.TempDir(), "namespace")
+	require.NoError(t, ioutil.WriteFile(nsPath, []byte("file-namespace\n"), 0600))
+
+	return &resourceDetector{
+		client:        &imds.Client{Endpoint: srv.URL, HTTPClient: srv.Client()},
+		getenv:        getenv(env),
+		namespacePath: nsPath,
+		schemaURL:     semconv.SchemaURL,
+	}
+}
+
+func TestDetect(t *testing.T) {
+	detector := newTestDetector(t, map[string]string{
+		"KUBERNETES_SERVICE_HOST": "10.0.0.1",
+		"POD_NAME":                "my-pod",
+		"POD_NAMESPACE":           "my-namespace",
+		"NODE_NAME":               "aks-nodepool1-12345678-vmss000000",
+	})
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureAKS,
+		semconv.CloudRegionKey.String("westeurope"),
+		semconv.CloudAccountIDKey.String("xxxxxxxx-xxxxx-xxx-xxx-xxxx"),
+		attributes.ResourceGroupKey.String("MC_my-rg_my-cluster_westeurope"),
+		semconv.K8SClusterNameKey.String("tagged-cluster"),
+		semconv.K8SNamespaceNameKey.String("my-namespace"),
+		semconv.K8SPodNameKey.String("my-pod"),
+		semconv.K8SNodeNameKey.String("aks-nodepool1-12345678-vmss000000"),
+	), res)
+}
+
+func TestDetectFallbacks(t *testing.T) {
+	detector := newTestDetector(t, map[string]string{
+		"KUBERNETES_SERVICE_HOST": "10.0.0.1",
+		"HOSTNAME":                "my-pod-hostname",
+	})
+	detector.clusterName = "override"
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	attrs := res.Set()
+	for k, want := range map[attribute.Key]string{
+		semconv.K8SClusterNameKey:   "override",
+		semconv.K8SNamespaceNameKey: "file-namespace",
+		semconv.K8SPodNameKey:       "my-pod-hostname",
+	} {
+		v, ok := attrs.Value(k)
+		assert.True(t, ok, k)
+		assert.Equal(t, want, v.AsString(), k)
+	}
+	_, ok := attrs.Value(semconv.K8SNodeNameKey)
+	assert.False(t, ok)
+}
+
+func TestDetectNotOnKubernetes(t *testing.T) {
+	detector := newTestDetector(t, nil)
+
+	res, err := detector.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestClusterNameFromCompute(t *testing.T) {
+	testCases := []struct {
+		compute  imds.Compute
+		expected string
+	}{
+		{
+			compute:  imds.Compute{Tags: "aks-managed-cluster-name:tagged", ResourceGroupName: "MC_rg_cluster_westus"},
+			expected: "tagged",
+		},
+		{
+			compute:  imds.Compute{ResourceGroupName: "MC_my_rg_my-cluster_westus", Location: "westus"},
+			expected: "my-cluster",
+		},
+		{
+			compute:  imds.Compute{ResourceGroupName: "custom-node-rg", Location: "westus"},
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, clusterNameFromCompute(&tc.compute), tc.compute.ResourceGroupName)
+	}
+}
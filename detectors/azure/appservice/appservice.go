@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package appservice provides a resource detector for Azure App Service and
+// Azure Functions.
+package appservice
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/contrib/detectors/azure/internal/attributes"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// For a complete list of the environment variables set in App Service, see:
+// https://docs.microsoft.com/en-us/azure/app-service/reference-app-settings
+const (
+	siteNameEnvVar         = "WEBSITE_SITE_NAME"
+	instanceIDEnvVar       = "WEBSITE_INSTANCE_ID"
+	ownerNameEnvVar        = "WEBSITE_OWNER_NAME"
+	resourceGroupEnvVar    = "WEBSITE_RESOURCE_GROUP"
+	slotNameEnvVar         = "WEBSITE_SLOT_NAME"
+	memoryLimitEnvVar      = "WEBSITE_MEMORY_LIMIT_MB"
+	regionEnvVar           = "REGION_NAME"
+	functionsVersionEnvVar = "FUNCTIONS_EXTENSION_VERSION"
+)
+
+// resource detector collects resource information from Azure App Service and Azure Functions
+type resourceDetector struct {
+	getenv    func(string) string
+	schemaURL string
+}
+
+// compile time assertion that resourceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that will detect Azure App Service and Azure Functions resources.
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &resourceDetector{getenv: os.Getenv, schemaURL: c.schemaURL}
+}
+
+// Detect detects associated resources when running in Azure App Service or
+// Azure Functions from the WEBSITE_* environment variables. A nil Resource
+// is returned if they are not set.
+func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, error) {
+	siteName := detector.getenv(siteNameEnvVar)
+	if siteName == "" {
+		return nil, nil
+	}
+
+	attrs := []attribute.KeyValue{semconv.CloudProviderAzure}
+	add := func(k attribute.Key, v string) {
+		if v != "" {
+			attrs = append(attrs, k.String(v))
+		}
+	}
+
+	instanceID := detector.getenv(instanceIDEnvVar)
+	if detector.getenv(functionsVersionEnvVar) != "" {
+		attrs = append(attrs, semconv.CloudPlatformAzureFunctions)
+		add(semconv.FaaSNameKey, siteName)
+		add(semconv.FaaSInstanceKey, instanceID)
+		if maxMemory, err := strconv.Atoi(detector.getenv(memoryLimitEnvVar)); err == nil {
+			attrs = append(attrs, semconv.FaaSMaxMemoryKey.Int(maxMemory))
+		}
+	} else {
+		attrs = append(attrs, semconv.CloudPlatformAzureAppService)
+		add(semconv.ServiceNameKey, siteName)
+		add(semconv.ServiceInstanceIDKey, instanceID)
+		add(semconv.DeploymentEnvironmentKey, detector.getenv(slotNameEnvVar))
+	}
+
+	add(semconv.CloudRegionKey, detector.getenv(regionEnvVar))
+	add(semconv.CloudAccountIDKey, subscriptionID(detector.getenv(ownerNameEnvVar)))
+	add(attributes.ResourceGroupKey, detector.getenv(resourceGroupEnvVar))
+
+	return resource.NewWithAttributes(detector.schemaURL, attrs...), nil
+}
+
+// subscriptionID returns the subscription ID from the owner name, which has
+// the form "<subscription id>+<resource group>-<region>webspace".
+func subscriptionID(ownerName string) string {
+	if i := strings.Index(ownerName, "+"); i > 0 {
+		return ownerName[:i]
+	}
+	return ""
+}
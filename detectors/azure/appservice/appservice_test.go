@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/detectors/azure/internal/attributes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func getenv(m map[string]string) func(string) string {
+	return func(s string) string {
+		return m[s]
+	}
+}
+
+var appServiceEnv = map[string]string{
+	"WEBSITE_SITE_NAME":      "my-app",
+	"WEBSITE_INSTANCE_ID":    "4f4d2d3f1c3b2a1",
+	"WEBSITE_OWNER_NAME":     "xxxxxxxx-xxxxx-xxx-xxx-xxxx+my-rg-WestEuropewebspace",
+	"WEBSITE_RESOURCE_GROUP": "my-rg",
+	"WEBSITE_SLOT_NAME":      "staging",
+	"REGION_NAME":            "West Europe",
+}
+
+func TestDetectAppService(t *testing.T) {
+	detector := &resourceDetector{getenv: getenv(appServiceEnv), schemaURL: semconv.SchemaURL}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureAppService,
+		semconv.ServiceNameKey.String("my-app"),
+		semconv.ServiceInstanceIDKey.String("4f4d2d3f1c3b2a1"),
+		semconv.DeploymentEnvironmentKey.String("staging"),
+		semconv.CloudRegionKey.String("West Europe"),
+		semconv.CloudAccountIDKey.String("xxxxxxxx-xxxxx-xxx-xxx-xxxx"),
+		attributes.ResourceGroupKey.String("my-rg"),
+	), res)
+}
+
+func TestDetectFunctions(t *testing.T) {
+	env := map[string]string{
+		"FUNCTIONS_EXTENSION_VERSION": "~4",
+		"WEBSITE_MEMORY_LIMIT_MB":     "1536",
+	}
+	for k, v := range appServiceEnv {
+		env[k] = v
+	}
+
+	detector := &resourceDetector{getenv: getenv(env), schemaURL: semconv.SchemaURL}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureFunctions,
+		semconv.FaaSNameKey.String("my-app"),
+		semconv.FaaSInstanceKey.String("4f4d2d3f1c3b2a1"),
+		semconv.FaaSMaxMemoryKey.Int(1536),
+		semconv.CloudRegionKey.String("West Europe"),
+		semconv.CloudAccountIDKey.String("xxxxxxxx-xxxxx-xxx-xxx-xxxx"),
+		attributes.ResourceGroupKey.String("my-rg"),
+	), res)
+}
+
+func TestDetectNotOnAppService(t *testing.T) {
+	detector := &resourceDetector{getenv: getenv(nil), schemaURL: semconv.SchemaURL}
+	res, err := detector.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
@@ -0,0 +1,9 @@
+module go.opentelemetry.io/contrib/detectors/azure
+
+go 1.15
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+)
@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package attributes defines the Azure specific resource attributes shared by
+// the Azure resource detectors. There are no semantic conventions for them.
+package attributes
+
+import "go.opentelemetry.io/otel/attribute"
+
+var (
+	// ResourceGroupKey is the attribute key of the resource group of a
+	// resource.
+	ResourceGroupKey = attribute.Key("azure.resourcegroup.name")
+	// VMScaleSetNameKey is the attribute key of the scale set a virtual
+	// machine belongs to.
+	VMScaleSetNameKey = attribute.Key("azure.vm.scaleset.name")
+)
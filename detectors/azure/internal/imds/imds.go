@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package imds provides a client of the Azure Instance Metadata Service.
+package imds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultEndpoint is the endpoint of the Instance Metadata Service.
+	DefaultEndpoint = "http://169.254.169.254"
+	// DefaultTimeout is the default timeout of requests to the Instance
+	// Metadata Service. The service is local to the host, so it is short to
+	// fail fast when not running on Azure.
+	DefaultTimeout = 2 * time.Second
+
+	computePath = "/metadata/instance/compute"
+	apiVersion  = "2021-02-01"
+)
+
+// Compute holds the fields of the compute metadata of a virtual machine.
+type Compute struct {
+	Location          string `json:"location"`
+	Name              string `json:"name"`
+	ResourceGroupName string `json:"resourceGroupName"`
+	SubscriptionID    string `json:"subscriptionId"`
+	VMID              string `json:"vmId"`
+	VMScaleSetName    string `json:"vmScaleSetName"`
+	VMSize            string `json:"vmSize"`
+	OSType            string `json:"osType"`
+	// Tags is the list of tags of the virtual machine in the form
+	// "key1:value1;key2:value2".
+	Tags string `json:"tags"`
+}
+
+// Tag returns the value of the tag with the given key.
+func (c *Compute) Tag(key string) (string, bool) {
+	for _, tag := range strings.Split(c.Tags, ";") {
+		kv := strings.SplitN(tag, ":", 2)
+		if len(kv) == 2 && kv[0] == key {
+			return kv[1], true
+		}
+	}
+	return "", false
+}
+
+// Client retrieves metadata from the Instance Metadata Service.
+type Client struct {
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client for endpoint whose requests time out after
+// timeout. If endpoint is empty, DefaultEndpoint is used, and if timeout is
+// not positive, DefaultTimeout is used.
+func NewClient(endpoint string, timeout time.Duration) *Client {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Client{Endpoint: endpoint, HTTPClient: &http.Client{Timeout: timeout}}
+}
+
+// UnavailableError is returned when the Instance Metadata Service cannot be
+// reached, which is the case when not running on Azure.
+type UnavailableError struct {
+	Err error
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("instance metadata service unavailable: %v", e.Err)
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// GetCompute returns the compute metadata of the virtual machine.
+func (c *Client) GetCompute(ctx context.Context) (*Compute, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Endpoint+computePath, nil)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Set("api-version", apiVersion)
+	q.Set("format", "json")
+	req.URL.RawQuery = q.Encode()
+	req.Header.Set("Metadata", "true")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, &UnavailableError{Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("instance metadata request failed: %s", resp.Status)
+	}
+
+	var compute Compute
+	if err := json.NewDecoder(resp.Body).Decode(&compute); err != nil {
+		return nil, fmt.Errorf("invalid instance metadata: %w", err)
+	}
+	return &compute, nil
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vm provides a resource detector for Azure Virtual Machines.
+package vm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/contrib/detectors/azure/internal/attributes"
+	"go.opentelemetry.io/contrib/detectors/azure/internal/imds"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+type config struct {
+	endpoint  string
+	timeout   time.Duration
+	schemaURL string
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{schemaURL: semconv.SchemaURL}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies an Azure VM resource detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithEndpoint sets the endpoint of the Instance Metadata Service. The
+// default is "http://169.254.169.254".
+func WithEndpoint(endpoint string) Option {
+	return optionFunc(func(c *config) {
+		c.endpoint = endpoint
+	})
+}
+
+// WithTimeout sets the timeout of the request made to the Instance Metadata
+// Service. The default is 2 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.timeout = timeout
+	})
+}
+
+// WithSchemaURL sets the schema URL of the detected Resource.
+func WithSchemaURL(schemaURL string) Option {
+	return optionFunc(func(c *config) {
+		c.schemaURL = schemaURL
+	})
+}
+
+// resource detector collects resource information from Azure Virtual Machines
+type resourceDetector struct {
+	client    *imds.Client
+	schemaURL string
+}
+
+// compile time assertion that resourceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that will detect Azure Virtual Machine resources.
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &resourceDetector{client: imds.NewClient(c.endpoint, c.timeout), schemaURL: c.schemaURL}
+}
+
+// Detect detects associated resources when running on an Azure Virtual
+// Machine. A nil Resource is returned if the Instance Metadata Service
+// cannot be reached.
+func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	compute, err := detector.client.GetCompute(ctx)
+	if err != nil {
+		var unavailable *imds.UnavailableError
+		if errors.As(err, &unavailable) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureVM,
+	}
+	add := func(k attribute.Key, v string) {
+		if v != "" {
+			attrs = append(attrs, k.String(v))
+		}
+	}
+	add(semconv.CloudRegionKey, compute.Location)
+	add(semconv.CloudAccountIDKey, compute.SubscriptionID)
+	add(semconv.HostIDKey, compute.VMID)
+	add(semconv.HostNameKey, compute.Name)
+	add(semconv.HostTypeKey, compute.VMSize)
+	add(attributes.ResourceGroupKey, compute.ResourceGroupName)
+	add(attributes.VMScaleSetNameKey, compute.VMScaleSetName)
+
+	return resource.NewWithAttributes(detector.schemaURL, attrs...), nil
+}
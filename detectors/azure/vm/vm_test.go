@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/detectors/azure/internal/attributes"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// Example from https://docs.microsoft.com/en-us/azure/virtual-machines/windows/instance-metadata-service
+const computeMetadata = `{
+  "location": "westus",
+  "name": "examplevmname",
+  "osType": "Linux",
+  "resourceGroupName": "macikgo-test-may-23",
+  "subscriptionId": "xxxxxxxx-xxxxx-xxx-xxx-xxxx",
+  "vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+  "vmScaleSetName": "crpteste9vflji9",
+  "vmSize": "Standard_A3"
+}`
+
+func TestDetect(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("api-version") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(computeMetadata))
+	}))
+	defer srv.Close()
+
+	res, err := NewResourceDetector(WithEndpoint(srv.URL)).Detect(context.Background())
+	require.NoError(t, err)
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureVM,
+		semconv.CloudRegionKey.String("westus"),
+		semconv.CloudAccountIDKey.String("xxxxxxxx-xxxxx-xxx-xxx-xxxx"),
+		semconv.HostIDKey.String("02aab8a4-74ef-476e-8182-f6d2ba4166a6"),
+		semconv.HostNameKey.String("examplevmname"),
+		semconv.HostTypeKey.String("Standard_A3"),
+		attributes.ResourceGroupKey.String("macikgo-test-may-23"),
+		attributes.VMScaleSetNameKey.String("crpteste9vflji9"),
+	}
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL, attrs...), res)
+}
+
+func TestDetectNotOnAzure(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	// Close the server so that requests fail to connect.
+	srv.Close()
+
+	res, err := NewResourceDetector(WithEndpoint(srv.URL), WithTimeout(time.Second)).Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestDetectError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	res, err := NewResourceDetector(WithEndpoint(srv.URL)).Detect(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package detectors
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// CachedDetector is a resource detector caching the resource detected by
+// another detector.
+type CachedDetector struct {
+	detector             resource.Detector
+	ttl                  time.Duration
+	timeout              time.Duration
+	backgroundRefresh    bool
+	staleWhileRevalidate bool
+	hook                 Hook
+	now                  func() time.Time
+
+	// detectMu serializes the detections.
+	detectMu sync.Mutex
+
+	mu         sync.Mutex
+	res        *resource.Resource
+	detected   time.Time
+	refreshing bool
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stop      chan struct{}
+}
+
+// compile time assertion that CachedDetector implements the resource.Detector interface.
+var _ resource.Detector = (*CachedDetector)(nil)
+
+// Cached returns a resource detector that caches the resource detected by d
+// for ttl. A ttl of zero or less caches it forever. Only resources detected
+// without error are cached, so a failed or partial detection is retried by
+// the next call to Detect.
+//
+// The WithTimeout, WithBackgroundRefresh, WithStaleWhileRevalidate, and
+// WithHook options apply to the returned detector. Stop needs to be called to release
+// its resources when WithBackgroundRefresh is used.
+func Cached(d resource.Detector, ttl time.Duration, opts ...Option) *CachedDetector {
+	c := newConfig(opts...)
+	return &CachedDetector{
+		detector:             d,
+		ttl:                  ttl,
+		timeout:              c.timeout,
+		backgroundRefresh:    c.backgroundRefresh && ttl > 0,
+		staleWhileRevalidate: c.staleWhileRevalidate,
+		hook:                 c.hook,
+		now:                  time.Now,
+		stop:                 make(chan struct{}),
+	}
+}
+
+// Detect returns the cached resource if it has not expired, and detects it
+// otherwise. An expired resource is returned if WithStaleWhileRevalidate is
+// used, while it is detected again in the background.
+func (c *CachedDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if res, ok := c.cached(); ok {
+		return res, nil
+	}
+
+	c.detectMu.Lock()
+	defer c.detectMu.Unlock()
+	// Another call may have detected it while waiting for the lock.
+	if res, ok := c.cached(); ok {
+		return res, nil
+	}
+
+	res, err := c.detect(ctx)
+	if err == nil && c.backgroundRefresh {
+		c.startOnce.Do(func() { go c.run() })
+	}
+	return res, err
+}
+
+// Stop stops the background refresh. The cached resource is still returned
+// by Detect, and detected again when it expires.
+func (c *CachedDetector) Stop() {
+	c.stopOnce.Do(func() { close(c.stop) })
+}
+
+// cached returns the cached resource and whether it can be returned.
+func (c *CachedDetector) cached() (*resource.Resource, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.res == nil {
+		return nil, false
+	}
+	if c.ttl <= 0 || c.now().Sub(c.detected) < c.ttl {
+		return c.res, true
+	}
+	if !c.staleWhileRevalidate {
+		return nil, false
+	}
+	if !c.refreshing {
+		c.refreshing = true
+		go func() {
+			c.refresh()
+			c.mu.Lock()
+			c.refreshing = false
+			c.mu.Unlock()
+		}()
+	}
+	return c.res, true
+}
+
+// detect detects the resource and caches it if no error occurred.
+func (c *CachedDetector) detect(ctx context.Context) (*resource.Resource, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	res, err := observe(ctx, detectorName(c.detector), c.detector, c.hook)
+	if err == nil {
+		if res == nil {
+			res = resource.Empty()
+		}
+		c.mu.Lock()
+		c.res = res
+		c.detected = c.now()
+		c.mu.Unlock()
+	}
+	return res, err
+}
+
+// refresh detects the resource in the background. Errors are ignored, the
+// cached resource being kept until a detection succeeds.
+func (c *CachedDetector) refresh() {
+	c.detectMu.Lock()
+	defer c.detectMu.Unlock()
+	_, _ = c.detect(context.Background())
+}
+
+// run refreshes the resource every ttl until Stop is called.
+func (c *CachedDetector) run() {
+	ticker := time.NewTicker(c.ttl)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.refresh()
+		case <-c.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package detectors
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// countingDetector returns a resource holding the number of times it was
+// called.
+type countingDetector struct {
+	calls int64
+	err   error
+}
+
+func (d *countingDetector) Detect(context.Context) (*resource.Resource, error) {
+	n := atomic.AddInt64(&d.calls, 1)
+	return resource.NewWithAttributes("", attribute.Int64("calls", n)), d.err
+}
+
+func (d *countingDetector) count() int64 {
+	return atomic.LoadInt64(&d.calls)
+}
+
+// fakeClock is a clock advanced by the tests.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func calls(t *testing.T, res *resource.Resource) int64 {
+	v, ok := res.Set().Value("calls")
+	require.True(t, ok)
+	return v.AsInt64()
+}
+
+func TestCached(t *testing.T) {
+	d := &countingDetector{}
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := Cached(d, time.Minute)
+	c.now = clock.Now
+
+	for i := 0; i < 3; i++ {
+		res, err := c.Detect(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), calls(t, res))
+	}
+
+	clock.Advance(time.Minute)
+	res, err := c.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), calls(t, res))
+}
+
+func TestCachedForever(t *testing.T) {
+	d := &countingDetector{}
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := Cached(d, 0)
+	c.now = clock.Now
+
+	_, err := c.Detect(context.Background())
+	require.NoError(t, err)
+	clock.Advance(24 * time.Hour)
+	res, err := c.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), calls(t, res))
+}
+
+func TestCachedErrorNotCached(t *testing.T) {
+	d := &countingDetector{err: errors.New("failed")}
+	c := Cached(d, time.Minute)
+
+	_, err := c.Detect(context.Background())
+	assert.Error(t, err)
+	res, err := c.Detect(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, int64(2), calls(t, res))
+}
+
+func TestCachedStaleWhileRevalidate(t *testing.T) {
+	d := &countingDetector{}
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := Cached(d, time.Minute, WithStaleWhileRevalidate())
+	c.now = clock.Now
+
+	_, err := c.Detect(context.Background())
+	require.NoError(t, err)
+
+	clock.Advance(time.Minute)
+	res, err := c.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), calls(t, res), "stale resource returned")
+
+	require.Eventually(t, func() bool {
+		res, err := c.Detect(context.Background())
+		return err == nil && calls(t, res) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int64(2), d.count())
+}
+
+func TestCachedBackgroundRefresh(t *testing.T) {
+	d := &countingDetector{}
+	c := Cached(d, 10*time.Millisecond, WithBackgroundRefresh())
+
+	_, err := c.Detect(context.Background())
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return d.count() >= 3
+	}, time.Second, time.Millisecond)
+
+	c.Stop()
+	// Wait for a refresh that may be in progress.
+	time.Sleep(20 * time.Millisecond)
+	n := d.count()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, n, d.count())
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudfoundry provides a resource detector for applications
+// running in Cloud Foundry, including VMware Tanzu Application Service.
+package cloudfoundry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// The environment variables set by Cloud Foundry in the container of an
+// application instance.
+const (
+	vcapApplicationEnvVar = "VCAP_APPLICATION"
+	vcapServicesEnvVar    = "VCAP_SERVICES"
+	instanceIndexEnvVar   = "CF_INSTANCE_INDEX"
+	instanceGUIDEnvVar    = "CF_INSTANCE_GUID"
+)
+
+// The attribute keys of the Cloud Foundry resource.
+const (
+	AppIDKey            = attribute.Key("cloudfoundry.app.id")
+	AppNameKey          = attribute.Key("cloudfoundry.app.name")
+	AppInstanceIndexKey = attribute.Key("cloudfoundry.app.instance.index")
+	SpaceIDKey          = attribute.Key("cloudfoundry.space.id")
+	SpaceNameKey        = attribute.Key("cloudfoundry.space.name")
+	OrgIDKey            = attribute.Key("cloudfoundry.org.id")
+	OrgNameKey          = attribute.Key("cloudfoundry.org.name")
+	ServicesKey         = attribute.Key("cloudfoundry.services")
+)
+
+// vcapApplication holds the fields of VCAP_APPLICATION used by the detector.
+type vcapApplication struct {
+	ApplicationID    string `json:"application_id"`
+	ApplicationName  string `json:"application_name"`
+	SpaceID          string `json:"space_id"`
+	SpaceName        string `json:"space_name"`
+	OrganizationID   string `json:"organization_id"`
+	OrganizationName string `json:"organization_name"`
+	InstanceIndex    *int   `json:"instance_index"`
+}
+
+// vcapService holds the fields of a service instance of VCAP_SERVICES used
+// by the detector.
+type vcapService struct {
+	Name string `json:"name"`
+}
+
+// resource detector collects resource information from the environment of Cloud Foundry applications
+type resourceDetector struct {
+	getenv    func(string) string
+	schemaURL string
+}
+
+// compile time assertion that resourceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that will detect Cloud Foundry application resources.
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &resourceDetector{getenv: os.Getenv, schemaURL: c.schemaURL}
+}
+
+// Detect detects the Cloud Foundry application instance from the
+// VCAP_APPLICATION and VCAP_SERVICES environment variables. A nil Resource is
+// returned if not running in Cloud Foundry.
+func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, error) {
+	rawApp := detector.getenv(vcapApplicationEnvVar)
+	if rawApp == "" {
+		return nil, nil
+	}
+
+	var app vcapApplication
+	if err := json.Unmarshal([]byte(rawApp), &app); err != nil {
+		return resource.Empty(), fmt.Errorf("invalid %s: %w", vcapApplicationEnvVar, err)
+	}
+
+	var attrs []attribute.KeyValue
+	add := func(k attribute.Key, v string) {
+		if v != "" {
+			attrs = append(attrs, k.String(v))
+		}
+	}
+
+	add(AppIDKey, app.ApplicationID)
+	add(AppNameKey, app.ApplicationName)
+	add(SpaceIDKey, app.SpaceID)
+	add(SpaceNameKey, app.SpaceName)
+	add(OrgIDKey, app.OrganizationID)
+	add(OrgNameKey, app.OrganizationName)
+
+	// CF_INSTANCE_INDEX is set in all stacks, instance_index of
+	// VCAP_APPLICATION only in some of them.
+	if index, err := strconv.Atoi(detector.getenv(instanceIndexEnvVar)); err == nil {
+		attrs = append(attrs, AppInstanceIndexKey.Int(index))
+	} else if app.InstanceIndex != nil {
+		attrs = append(attrs, AppInstanceIndexKey.Int(*app.InstanceIndex))
+	}
+	add(semconv.ServiceInstanceIDKey, detector.getenv(instanceGUIDEnvVar))
+
+	services, err := detector.services()
+	if len(services) > 0 {
+		attrs = append(attrs, ServicesKey.StringSlice(services))
+	}
+
+	return resource.NewWithAttributes(detector.schemaURL, attrs...), err
+}
+
+// services returns the sorted names of the service instances bound to the
+// application.
+func (detector *resourceDetector) services() ([]string, error) {
+	raw := detector.getenv(vcapServicesEnvVar)
+	if raw == "" {
+		return nil, nil
+	}
+
+	// VCAP_SERVICES maps the service offerings to their instances.
+	var offerings map[string][]vcapService
+	if err := json.Unmarshal([]byte(raw), &offerings); err != nil {
+		return nil, fmt.Errorf("%w: invalid %s: %v", resource.ErrPartialResource, vcapServicesEnvVar, err)
+	}
+
+	var names []string
+	for _, instances := range offerings {
+		for _, s := range instances {
+			if s.Name != "" {
+				names = append(names, s.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+const (
+	testVCAPApplication = `{
+		"application_id": "fa05c1a9-0fc1-4fbd-bae1-139850dec7a3",
+		"application_name": "my-app",
+		"application_uris": ["my-app.example.com"],
+		"application_version": "fb8fbcc6-8d58-479e-bcc7-3b4ce5a7f0ca",
+		"cf_api": "https://api.example.com",
+		"instance_index": 2,
+		"organization_id": "c0134f2e-5c6d-4f0e-95ad-c5fae4b2e20a",
+		"organization_name": "my-org",
+		"space_id": "06450c72-4669-4dc6-8096-45f9777db68a",
+		"space_name": "my-space"
+	}`
+	testVCAPServices = `{
+		"p-mysql": [{"name": "mysql", "label": "p-mysql", "plan": "100mb"}],
+		"p-redis": [{"name": "redis-b"}, {"name": "redis-a"}]
+	}`
+)
+
+func newTestDetector(env map[string]string) *resourceDetector {
+	return &resourceDetector{getenv: func(key string) string { return env[key] }, schemaURL: semconv.SchemaURL}
+}
+
+func TestDetect(t *testing.T) {
+	detector := newTestDetector(map[string]string{
+		vcapApplicationEnvVar: testVCAPApplication,
+		vcapServicesEnvVar:    testVCAPServices,
+		instanceIndexEnvVar:   "3",
+		instanceGUIDEnvVar:    "de4bc3c5-1a9e-4b1c-6f4d-5ab9",
+	})
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		AppIDKey.String("fa05c1a9-0fc1-4fbd-bae1-139850dec7a3"),
+		AppNameKey.String("my-app"),
+		SpaceIDKey.String("06450c72-4669-4dc6-8096-45f9777db68a"),
+		SpaceNameKey.String("my-space"),
+		OrgIDKey.String("c0134f2e-5c6d-4f0e-95ad-c5fae4b2e20a"),
+		OrgNameKey.String("my-org"),
+		AppInstanceIndexKey.Int(3),
+		semconv.ServiceInstanceIDKey.String("de4bc3c5-1a9e-4b1c-6f4d-5ab9"),
+		ServicesKey.StringSlice([]string{"mysql", "redis-a", "redis-b"}),
+	)
+	assert.Equal(t, expected, res)
+}
+
+func TestDetectInstanceIndexFromVCAPApplication(t *testing.T) {
+	detector := newTestDetector(map[string]string{
+		vcapApplicationEnvVar: testVCAPApplication,
+	})
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	v, ok := res.Set().Value(AppInstanceIndexKey)
+	require.True(t, ok)
+	assert.Equal(t, int64(2), v.AsInt64())
+}
+
+func TestDetectNotOnCloudFoundry(t *testing.T) {
+	res, err := newTestDetector(nil).Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestDetectInvalidVCAPApplication(t *testing.T) {
+	detector := newTestDetector(map[string]string{
+		vcapApplicationEnvVar: "{",
+	})
+
+	res, err := detector.Detect(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, resource.Empty(), res)
+}
+
+func TestDetectInvalidVCAPServices(t *testing.T) {
+	detector := newTestDetector(map[string]string{
+		vcapApplicationEnvVar: `{"application_name": "my-app"}`,
+		vcapServicesEnvVar:    "[]",
+	})
+
+	res, err := detector.Detect(context.Background())
+	assert.True(t, errors.Is(err, resource.ErrPartialResource))
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL, AppNameKey.String("my-app")), res)
+}
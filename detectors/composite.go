@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package detectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// compositeDetector runs several detectors concurrently and merges their
+// resources.
+type compositeDetector struct {
+	detectors  []resource.Detector
+	timeout    time.Duration
+	precedence Precedence
+	hook       Hook
+}
+
+// compile time assertion that compositeDetector implements the resource.Detector interface.
+var _ resource.Detector = (*compositeDetector)(nil)
+
+// Composite returns a resource detector that runs detectors concurrently and
+// merges the resources they return. Nil detectors are ignored.
+func Composite(detectors []resource.Detector, opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	ds := make([]resource.Detector, 0, len(detectors))
+	for _, d := range detectors {
+		if d != nil {
+			ds = append(ds, d)
+		}
+	}
+	return &compositeDetector{
+		detectors:  ds,
+		timeout:    c.timeout,
+		precedence: c.precedence,
+		hook:       c.hook,
+	}
+}
+
+// Detect runs all detectors concurrently and returns the merge of the
+// resources they return, resolving conflicting keys by the configured
+// precedence in the order the detectors were passed to Composite. The
+// schema URL of the resources is kept if they all agree on it.
+//
+// If some detectors fail, the resource of the others is returned along with
+// an error reporting every failure. The error wraps
+// resource.ErrPartialResource if the returned resource is not empty.
+func (detector *compositeDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if detector.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, detector.timeout)
+		defer cancel()
+	}
+
+	results := make([]chan detectResult, len(detector.detectors))
+	for i, d := range detector.detectors {
+		results[i] = make(chan detectResult, 1)
+		go func(d resource.Detector, ch chan<- detectResult) {
+			res, err := observe(ctx, detectorName(d), d, detector.hook)
+			ch <- detectResult{res: res, err: err}
+		}(d, results[i])
+	}
+
+	resources := make([]*resource.Resource, 0, len(results))
+	var errs []error
+	for _, ch := range results {
+		r := wait(ctx, ch)
+		if r.err != nil {
+			errs = append(errs, r.err)
+		}
+		if r.res != nil {
+			resources = append(resources, r.res)
+		}
+	}
+
+	res := detector.merge(resources)
+	if len(errs) > 0 {
+		return res, &detectError{errs: errs, partial: res.Len() > 0}
+	}
+	return res, nil
+}
+
+// merge merges resources, which are in the order of the detectors.
+func (detector *compositeDetector) merge(resources []*resource.Resource) *resource.Resource {
+	schemaURL := mergeSchemaURL(resources)
+
+	if detector.precedence == LastWins {
+		for i, j := 0, len(resources)-1; i < j; i, j = i+1, j-1 {
+			resources[i], resources[j] = resources[j], resources[i]
+		}
+	}
+
+	seen := make(map[attribute.Key]struct{})
+	var attrs []attribute.KeyValue
+	for _, res := range resources {
+		for iter := res.Iter(); iter.Next(); {
+			kv := iter.Attribute()
+			if _, ok := seen[kv.Key]; ok {
+				continue
+			}
+			seen[kv.Key] = struct{}{}
+			attrs = append(attrs, kv)
+		}
+	}
+	if len(attrs) == 0 {
+		return resource.Empty()
+	}
+	return resource.NewWithAttributes(schemaURL, attrs...)
+}
+
+// wait returns the result sent to ch, or the error of ctx if it is done
+// first. A result already sent is returned even if ctx is done.
+func wait(ctx context.Context, ch <-chan detectResult) detectResult {
+	select {
+	case r := <-ch:
+		return r
+	default:
+	}
+	select {
+	case r := <-ch:
+		return r
+	case <-ctx.Done():
+		return detectResult{err: ctx.Err()}
+	}
+}
+
+// mergeSchemaURL returns the schema URL of resources, ignoring those
+// without one. An empty string is returned if they conflict, since the
+// merged attributes cannot be associated with either of them.
+func mergeSchemaURL(resources []*resource.Resource) string {
+	var schemaURL string
+	for _, res := range resources {
+		switch s := res.SchemaURL(); {
+		case s == "" || s == schemaURL:
+		case schemaURL == "":
+			schemaURL = s
+		default:
+			return ""
+		}
+	}
+	return schemaURL
+}
+
+type detectResult struct {
+	res *resource.Resource
+	err error
+}
+
+// detectError is returned by the composite detector when some of its
+// detectors failed.
+type detectError struct {
+	errs []error
+	// partial is true if other detectors returned attributes.
+	partial bool
+}
+
+func (e *detectError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	msg := fmt.Sprintf("%d detector(s) failed: %s", len(e.errs), strings.Join(msgs, "; "))
+	if e.partial {
+		return fmt.Sprintf("%s: %s", resource.ErrPartialResource, msg)
+	}
+	return msg
+}
+
+// Is returns true for resource.ErrPartialResource if the resource returned
+// along with e is not empty, and for any error a detector failed with.
+func (e *detectError) Is(target error) bool {
+	if e.partial && target == resource.ErrPartialResource {
+		return true
+	}
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the first error a detector failed with.
+func (e *detectError) Unwrap() error {
+	return e.errs[0]
+}
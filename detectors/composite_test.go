@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package detectors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+type detectorFunc func(context.Context) (*resource.Resource, error)
+
+func (f detectorFunc) Detect(ctx context.Context) (*resource.Resource, error) {
+	return f(ctx)
+}
+
+func staticDetector(res *resource.Resource, err error) resource.Detector {
+	return detectorFunc(func(context.Context) (*resource.Resource, error) {
+		return res, err
+	})
+}
+
+func TestCompositePrecedence(t *testing.T) {
+	first := staticDetector(resource.NewWithAttributes("https://example.com/1",
+		attribute.String("k1", "first"),
+		attribute.String("k2", "first"),
+	), nil)
+	last := staticDetector(resource.NewWithAttributes("",
+		attribute.String("k2", "last"),
+		attribute.String("k3", "last"),
+	), nil)
+
+	testCases := []struct {
+		name       string
+		precedence Precedence
+		expected   *resource.Resource
+	}{
+		{
+			name:       "last wins",
+			precedence: LastWins,
+			expected: resource.NewWithAttributes("https://example.com/1",
+				attribute.String("k1", "first"),
+				attribute.String("k2", "last"),
+				attribute.String("k3", "last"),
+			),
+		},
+		{
+			name:       "first wins",
+			precedence: FirstWins,
+			expected: resource.NewWithAttributes("https://example.com/1",
+				attribute.String("k1", "first"),
+				attribute.String("k2", "first"),
+				attribute.String("k3", "last"),
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			detector := Composite([]resource.Detector{first, nil, last}, WithPrecedence(tc.precedence))
+			res, err := detector.Detect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+}
+
+func TestCompositeConflictingSchemaURLs(t *testing.T) {
+	detector := Composite([]resource.Detector{
+		staticDetector(resource.NewWithAttributes("https://example.com/1", attribute.String("k1", "v1")), nil),
+		staticDetector(resource.NewWithAttributes("https://example.com/2", attribute.String("k2", "v2")), nil),
+	})
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes("",
+		attribute.String("k1", "v1"),
+		attribute.String("k2", "v2"),
+	), res)
+}
+
+func TestCompositeErrors(t *testing.T) {
+	errFailed := errors.New("failed")
+	detector := Composite([]resource.Detector{
+		staticDetector(nil, errFailed),
+		staticDetector(resource.NewWithAttributes("", attribute.String("k1", "v1")), nil),
+	})
+	res, err := detector.Detect(context.Background())
+	assert.True(t, errors.Is(err, resource.ErrPartialResource))
+	assert.True(t, errors.Is(err, errFailed))
+	assert.Equal(t, resource.NewWithAttributes("", attribute.String("k1", "v1")), res)
+
+	detector = Composite([]resource.Detector{staticDetector(nil, errFailed)})
+	res, err = detector.Detect(context.Background())
+	assert.False(t, errors.Is(err, resource.ErrPartialResource))
+	assert.True(t, errors.Is(err, errFailed))
+	assert.Equal(t, resource.Empty(), res)
+}
+
+func TestCompositeRunsConcurrently(t *testing.T) {
+	started := make(chan struct{})
+	waiting := detectorFunc(func(ctx context.Context) (*resource.Resource, error) {
+		select {
+		case <-started:
+			return resource.NewWithAttributes("", attribute.String("k1", "v1")), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+	starting := detectorFunc(func(context.Context) (*resource.Resource, error) {
+		close(started)
+		return resource.NewWithAttributes("", attribute.String("k2", "v2")), nil
+	})
+
+	res, err := Composite([]resource.Detector{waiting, starting}, WithTimeout(time.Second)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Len())
+}
+
+func TestCompositeTimeout(t *testing.T) {
+	blocking := detectorFunc(func(context.Context) (*resource.Resource, error) {
+		select {}
+	})
+	detector := Composite([]resource.Detector{
+		blocking,
+		staticDetector(resource.NewWithAttributes("", attribute.String("k1", "v1")), nil),
+	}, WithTimeout(10*time.Millisecond))
+
+	res, err := detector.Detect(context.Background())
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, errors.Is(err, resource.ErrPartialResource))
+	assert.Equal(t, resource.NewWithAttributes("", attribute.String("k1", "v1")), res)
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package detectors
+
+import (
+	"time"
+)
+
+// defaultTimeout bounds the detection of all detectors of a composite
+// detector.
+const defaultTimeout = 10 * time.Second
+
+// Precedence defines which value is used when detectors of a composite
+// detector return the same attribute key.
+type Precedence int
+
+const (
+	// LastWins uses the value of the last detector returning the key, like
+	// resource.Merge and resource.New do.
+	LastWins Precedence = iota
+	// FirstWins uses the value of the first detector returning the key.
+	FirstWins
+)
+
+type config struct {
+	// timeout bounds the detection of all detectors. A value of zero means
+	// no timeout other than the deadline of the context passed to Detect.
+	timeout    time.Duration
+	precedence Precedence
+
+	backgroundRefresh    bool
+	staleWhileRevalidate bool
+
+	hook Hook
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{
+		timeout:    defaultTimeout,
+		precedence: LastWins,
+	}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies a configuration option to the detectors of this package.
+// Options that do not apply to a detector are ignored.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithTimeout sets the maximum duration of the detection of all detectors
+// of a composite detector, or of a detection by a cached detector. Detectors
+// still running when it expires are abandoned and reported as failed. The
+// default is 10 seconds. A value of zero disables the timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.timeout = timeout
+	})
+}
+
+// WithPrecedence sets which value is used when several detectors return
+// the same attribute key. The default is LastWins.
+func WithPrecedence(p Precedence) Option {
+	return optionFunc(func(c *config) {
+		c.precedence = p
+	})
+}
+
+// WithBackgroundRefresh makes a cached detector detect the resource again
+// in the background every time it expires, once it has been detected a first
+// time, so that Detect does not block on an expired resource.
+func WithBackgroundRefresh() Option {
+	return optionFunc(func(c *config) {
+		c.backgroundRefresh = true
+	})
+}
+
+// WithStaleWhileRevalidate makes a cached detector return an expired
+// resource immediately while detecting it again in the background, instead
+// of blocking until the detection is complete.
+func WithStaleWhileRevalidate() Option {
+	return optionFunc(func(c *config) {
+		c.staleWhileRevalidate = true
+	})
+}
+
+// WithHook sets a Hook notified of the detections run by a composite or
+// cached detector. A composite detector reports the detection of each of
+// its detectors, and a cached detector each detection it does not serve
+// from its cache.
+func WithHook(h Hook) Option {
+	return optionFunc(func(c *config) {
+		c.hook = h
+	})
+}
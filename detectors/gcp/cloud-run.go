@@ -28,13 +28,10 @@ import (
 
 const serviceNamespace = "cloud-run-managed"
 
-// The minimal list of metadata.Client methods we use. Use an interface so we
-// can replace it with a fake implementation in the unit test.
-type metadataClient interface {
-	ProjectID() (string, error)
-	Get(string) (string, error)
-	InstanceID() (string, error)
-}
+// cloudRunConfigurationKey is the attribute key of the Cloud Run
+// configuration that created the revision. There is no semantic convention
+// for it.
+var cloudRunConfigurationKey = attribute.Key("gcp.cloud_run.configuration")
 
 // CloudRun collects resource information of Cloud Run instance.
 type CloudRun struct {
@@ -50,13 +47,15 @@ var _ resource.Detector = (*CloudRun)(nil)
 // NewCloudRun creates a CloudRun detector.
 func NewCloudRun() *CloudRun {
 	return &CloudRun{
-		mc:     metadata.NewClient(nil),
+		mc:     defaultMetadataClient,
 		onGCE:  metadata.OnGCE,
 		getenv: os.Getenv,
 	}
 }
 
-// Detect detects associated resources when running on Cloud Run hosts.
+// Detect detects associated resources when running on Cloud Run hosts. The
+// service, revision, and configuration are read from the K_SERVICE,
+// K_REVISION, and K_CONFIGURATION environment variables.
 // NOTE: the service.namespace attribute is currently hardcoded to be
 // "cloud-run-managed". This may change in the future, please do not rely on
 // this behavior yet.
@@ -69,6 +68,7 @@ func (c *CloudRun) Detect(ctx context.Context) (*resource.Resource, error) {
 
 	attributes := []attribute.KeyValue{
 		semconv.CloudProviderGCP,
+		semconv.CloudPlatformGCPCloudRun,
 		semconv.ServiceNamespaceKey.String(serviceNamespace),
 	}
 
@@ -83,7 +83,8 @@ func (c *CloudRun) Detect(ctx context.Context) (*resource.Resource, error) {
 	if region, err := c.mc.Get("instance/region"); hasProblem(err) {
 		errInfo = append(errInfo, err.Error())
 	} else if region != "" {
-		attributes = append(attributes, semconv.CloudRegionKey.String(region))
+		// The region is returned as "projects/<number>/regions/<region>".
+		attributes = append(attributes, semconv.CloudRegionKey.String(lastPathSegment(region)))
 	}
 
 	if instanceID, err := c.mc.InstanceID(); hasProblem(err) {
@@ -97,7 +98,16 @@ func (c *CloudRun) Detect(ctx context.Context) (*resource.Resource, error) {
 	if service := c.getenv("K_SERVICE"); service == "" {
 		errInfo = append(errInfo, "envvar K_SERVICE contains empty string.")
 	} else {
-		attributes = append(attributes, semconv.ServiceNameKey.String(service))
+		attributes = append(attributes,
+			semconv.ServiceNameKey.String(service),
+			semconv.FaaSNameKey.String(service),
+		)
+	}
+	if revision := c.getenv("K_REVISION"); revision != "" {
+		attributes = append(attributes, semconv.FaaSVersionKey.String(revision))
+	}
+	if configuration := c.getenv("K_CONFIGURATION"); configuration != "" {
+		attributes = append(attributes, cloudRunConfigurationKey.String(configuration))
 	}
 	res := resource.NewWithAttributes(semconv.SchemaURL, attributes...)
 
@@ -67,6 +67,19 @@ func (c *client) ProjectID() (string, error) {
 	return c.Get("project/project-id")
 }
 
+func (c *client) InstanceName() (string, error) {
+	return c.Get("instance/name")
+}
+
+func (c *client) Zone() (string, error) {
+	zone, err := c.Get("instance/zone")
+	return lastPathSegment(zone), err
+}
+
+func (c *client) InstanceAttributeValue(attr string) (string, error) {
+	return c.Get("instance/attributes/" + attr)
+}
+
 var _ metadataClient = (*client)(nil)
 
 func TestCloudRunDetectorNotOnGCE(t *testing.T) {
@@ -85,17 +98,23 @@ func TestCloudRunDetectorExpectSuccess(t *testing.T) {
 	metadata := map[string]string{
 		"project/project-id": "foo",
 		"instance/id":        "bar",
-		"instance/region":    "utopia",
+		"instance/region":    "projects/123/regions/utopia",
 	}
 	envvars := map[string]string{
-		"K_SERVICE": "x-service",
+		"K_SERVICE":       "x-service",
+		"K_REVISION":      "x-service-00001-abc",
+		"K_CONFIGURATION": "x-config",
 	}
 	want, err := resource.New(
 		ctx,
 		resource.WithAttributes(
 			attribute.String("cloud.account.id", "foo"),
+			attribute.String("cloud.platform", "gcp_cloud_run"),
 			attribute.String("cloud.provider", "gcp"),
 			attribute.String("cloud.region", "utopia"),
+			attribute.String("faas.name", "x-service"),
+			attribute.String("faas.version", "x-service-00001-abc"),
+			attribute.String("gcp.cloud_run.configuration", "x-config"),
 			attribute.String("service.instance.id", "bar"),
 			attribute.String("service.name", "x-service"),
 			attribute.String("service.namespace", "cloud-run-managed"),
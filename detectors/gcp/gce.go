@@ -28,47 +28,59 @@ import (
 )
 
 // GCE collects resource information of GCE computing instances
-type GCE struct{}
+type GCE struct {
+	mc    metadataClient
+	onGCE func() bool
+}
 
 // compile time assertion that GCE implements the resource.Detector interface.
 var _ resource.Detector = (*GCE)(nil)
 
 // Detect detects associated resources when running on GCE hosts.
 func (gce *GCE) Detect(ctx context.Context) (*resource.Resource, error) {
-	if !metadata.OnGCE() {
+	mc, onGCE := gce.mc, gce.onGCE
+	if mc == nil {
+		mc = defaultMetadataClient
+	}
+	if onGCE == nil {
+		onGCE = metadata.OnGCE
+	}
+
+	if !onGCE() {
 		return nil, nil
 	}
 
 	attributes := []attribute.KeyValue{
 		semconv.CloudProviderGCP,
+		semconv.CloudPlatformGCPComputeEngine,
 	}
 
 	var errInfo []string
 
-	if projectID, err := metadata.ProjectID(); hasProblem(err) {
+	if projectID, err := mc.ProjectID(); hasProblem(err) {
 		errInfo = append(errInfo, err.Error())
 	} else if projectID != "" {
 		attributes = append(attributes, semconv.CloudAccountIDKey.String(projectID))
 	}
 
-	if zone, err := metadata.Zone(); hasProblem(err) {
+	if zone, err := mc.Zone(); hasProblem(err) {
 		errInfo = append(errInfo, err.Error())
 	} else if zone != "" {
 		attributes = append(attributes, semconv.CloudAvailabilityZoneKey.String(zone))
 
 		splitArr := strings.SplitN(zone, "-", 3)
 		if len(splitArr) == 3 {
-			semconv.CloudRegionKey.String(strings.Join(splitArr[0:2], "-"))
+			attributes = append(attributes, semconv.CloudRegionKey.String(strings.Join(splitArr[0:2], "-")))
 		}
 	}
 
-	if instanceID, err := metadata.InstanceID(); hasProblem(err) {
+	if instanceID, err := mc.InstanceID(); hasProblem(err) {
 		errInfo = append(errInfo, err.Error())
 	} else if instanceID != "" {
 		attributes = append(attributes, semconv.HostIDKey.String(instanceID))
 	}
 
-	if name, err := metadata.InstanceName(); hasProblem(err) {
+	if name, err := mc.InstanceName(); hasProblem(err) {
 		errInfo = append(errInfo, err.Error())
 	} else if name != "" {
 		attributes = append(attributes, semconv.HostNameKey.String(name))
@@ -80,10 +92,12 @@ func (gce *GCE) Detect(ctx context.Context) (*resource.Resource, error) {
 		attributes = append(attributes, semconv.HostNameKey.String(hostname))
 	}
 
-	if hostType, err := metadata.Get("instance/machine-type"); hasProblem(err) {
+	if hostType, err := mc.Get("instance/machine-type"); hasProblem(err) {
 		errInfo = append(errInfo, err.Error())
 	} else if hostType != "" {
-		attributes = append(attributes, semconv.HostTypeKey.String(hostType))
+		// The machine type is returned as
+		// "projects/<number>/machineTypes/<type>".
+		attributes = append(attributes, semconv.HostTypeKey.String(lastPathSegment(hostType)))
 	}
 
 	var aggregatedErr error
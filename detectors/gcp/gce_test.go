@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func TestGCEDetectorNotOnGCE(t *testing.T) {
+	gce := &GCE{mc: &client{}, onGCE: notOnGCE}
+
+	if res, err := gce.Detect(context.Background()); res != nil || err != nil {
+		t.Errorf("Expect gce.Detect(ctx) to return (nil, nil), got (%v, %v)", res, err)
+	}
+}
+
+func TestGCEDetectorExpectSuccess(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Skipf("hostname not available: %v", err)
+	}
+
+	metadata := map[string]string{
+		"project/project-id":    "foo",
+		"instance/id":           "1234567890",
+		"instance/name":         "my-instance",
+		"instance/zone":         "projects/123/zones/us-central1-a",
+		"instance/machine-type": "projects/123/machineTypes/n1-standard-1",
+	}
+	want := resource.NewWithAttributes(semconv.SchemaURL,
+		attribute.String("cloud.account.id", "foo"),
+		attribute.String("cloud.availability_zone", "us-central1-a"),
+		attribute.String("cloud.platform", "gcp_compute_engine"),
+		attribute.String("cloud.provider", "gcp"),
+		attribute.String("cloud.region", "us-central1"),
+		attribute.String("host.id", "1234567890"),
+		attribute.String("host.name", hostname),
+		attribute.String("host.type", "n1-standard-1"),
+	)
+
+	gce := &GCE{mc: &client{m: metadata}, onGCE: onGCE}
+	res, err := gce.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("got unexpected failure: %v", err)
+	}
+	if diff := cmp.Diff(want, res); diff != "" {
+		t.Errorf("detected resource differ from expected (-want, +got)\n%s", diff)
+	}
+}
+
+func TestGCEDetectorExpectFail(t *testing.T) {
+	gce := &GCE{mc: &client{m: map[string]string{"project/project-id": "foo"}}, onGCE: onGCE}
+
+	if res, err := gce.Detect(context.Background()); err == nil {
+		t.Errorf("Expect gce.Detect(ctx) to return error, got nil (resource: %v)", res)
+	}
+}
@@ -19,8 +19,6 @@ import (
 	"fmt"
 	"os"
 
-	"cloud.google.com/go/compute/metadata"
-
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
@@ -47,6 +45,7 @@ func (gke *GKE) Detect(ctx context.Context) (*resource.Resource, error) {
 	}
 
 	attributes := []attribute.KeyValue{
+		semconv.CloudPlatformGCPKubernetesEngine,
 		semconv.K8SNamespaceNameKey.String(os.Getenv("NAMESPACE")),
 		semconv.K8SPodNameKey.String(os.Getenv("HOSTNAME")),
 	}
@@ -55,7 +54,7 @@ func (gke *GKE) Detect(ctx context.Context) (*resource.Resource, error) {
 		attributes = append(attributes, semconv.ContainerNameKey.String(containerName))
 	}
 
-	if clusterName, err := metadata.InstanceAttributeValue("cluster-name"); hasProblem(err) {
+	if clusterName, err := defaultMetadataClient.InstanceAttributeValue("cluster-name"); hasProblem(err) {
 		errInfo = append(errInfo, err.Error())
 	} else if clusterName != "" {
 		attributes = append(attributes, semconv.K8SClusterNameKey.String(clusterName))
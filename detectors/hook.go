@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package detectors
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// DetectionEvent describes a detection run by a resource detector.
+type DetectionEvent struct {
+	// Detector is the name of the detector.
+	Detector string
+	// Resource is the resource returned by the detector. It may be nil or
+	// empty, e.g. if the detector is not in the environment it detects.
+	Resource *resource.Resource
+	// Err is the error returned by the detector.
+	Err error
+	// Duration is how long the detection took.
+	Duration time.Duration
+}
+
+// Hook is notified of the detections run by resource detectors. It is
+// notified once per detector. The probes a detector runs internally, such as
+// the environments of the AWS detector, are reported to a ProbeFunc.
+//
+// Detections may run concurrently, so implementations need to be safe for
+// concurrent use.
+type Hook interface {
+	Detected(DetectionEvent)
+}
+
+// HookFunc is an adapter to use an ordinary function as a Hook.
+type HookFunc func(DetectionEvent)
+
+// Detected calls f(e).
+func (f HookFunc) Detected(e DetectionEvent) {
+	f(e)
+}
+
+// LogHook returns a Hook that logs every detection to l, or to the standard
+// logger if l is nil.
+func LogHook(l *log.Logger) Hook {
+	printf := log.Printf
+	if l != nil {
+		printf = l.Printf
+	}
+	return HookFunc(func(e DetectionEvent) {
+		switch {
+		case e.Err != nil:
+			printf("resource detector %s failed in %s: %v", e.Detector, e.Duration, e.Err)
+		case e.Resource.Len() == 0:
+			printf("resource detector %s detected no attributes in %s", e.Detector, e.Duration)
+		default:
+			printf("resource detector %s detected %d attributes in %s", e.Detector, e.Resource.Len(), e.Duration)
+		}
+	})
+}
+
+// observedDetector notifies a hook of the detections of a detector.
+type observedDetector struct {
+	name     string
+	detector resource.Detector
+	hook     Hook
+}
+
+// compile time assertion that observedDetector implements the resource.Detector interface.
+var _ resource.Detector = (*observedDetector)(nil)
+
+// Observe returns a resource detector that runs d and notifies hook of each
+// detection, reporting d by name. If name is empty, the type of d is used.
+func Observe(name string, d resource.Detector, hook Hook) resource.Detector {
+	if name == "" {
+		name = detectorName(d)
+	}
+	return &observedDetector{name: name, detector: d, hook: hook}
+}
+
+// Detect runs the observed detector and notifies the hook.
+func (o *observedDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	return observe(ctx, o.name, o.detector, o.hook)
+}
+
+// observe runs d and notifies hook, if not nil, of the detection.
+func observe(ctx context.Context, name string, d resource.Detector, hook Hook) (*resource.Resource, error) {
+	if hook == nil {
+		return d.Detect(ctx)
+	}
+	start := time.Now()
+	res, err := d.Detect(ctx)
+	hook.Detected(DetectionEvent{
+		Detector: name,
+		Resource: res,
+		Err:      err,
+		Duration: time.Since(start),
+	})
+	return res, err
+}
+
+// detectorName returns the name of d used in the events of a Hook.
+func detectorName(d resource.Detector) string {
+	if o, ok := d.(*observedDetector); ok {
+		return o.name
+	}
+	return fmt.Sprintf("%T", d)
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package detectors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// recordingHook records the events it is notified of.
+type recordingHook struct {
+	mu     sync.Mutex
+	events []DetectionEvent
+}
+
+func (h *recordingHook) Detected(e DetectionEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, e)
+}
+
+func TestObserve(t *testing.T) {
+	hook := &recordingHook{}
+	res := resource.NewWithAttributes("", attribute.String("k1", "v1"))
+	d := Observe("static", staticDetector(res, nil), hook)
+
+	got, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, res, got)
+	require.Len(t, hook.events, 1)
+	assert.Equal(t, "static", hook.events[0].Detector)
+	assert.Equal(t, res, hook.events[0].Resource)
+	assert.NoError(t, hook.events[0].Err)
+
+	d = Observe("", &countingDetector{}, hook)
+	_, err = d.Detect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, hook.events, 2)
+	assert.Equal(t, "*detectors.countingDetector", hook.events[1].Detector)
+}
+
+func TestCompositeHook(t *testing.T) {
+	hook := &recordingHook{}
+	errFailed := errors.New("failed")
+	d := Composite([]resource.Detector{
+		Observe("ok", staticDetector(resource.NewWithAttributes("", attribute.String("k1", "v1")), nil), nil),
+		Observe("failing", staticDetector(nil, errFailed), nil),
+	}, WithHook(hook))
+
+	_, err := d.Detect(context.Background())
+	assert.Error(t, err)
+
+	require.Len(t, hook.events, 2)
+	sort.Slice(hook.events, func(i, j int) bool { return hook.events[i].Detector < hook.events[j].Detector })
+	assert.Equal(t, "failing", hook.events[0].Detector)
+	assert.Equal(t, errFailed, hook.events[0].Err)
+	assert.Equal(t, "ok", hook.events[1].Detector)
+	assert.Equal(t, 1, hook.events[1].Resource.Len())
+}
+
+func TestCachedHook(t *testing.T) {
+	hook := &recordingHook{}
+	c := Cached(&countingDetector{}, time.Minute, WithHook(hook))
+
+	for i := 0; i < 3; i++ {
+		_, err := c.Detect(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Len(t, hook.events, 1)
+}
+
+func TestLogHook(t *testing.T) {
+	var buf bytes.Buffer
+	hook := LogHook(log.New(&buf, "", 0))
+
+	hook.Detected(DetectionEvent{Detector: "d1", Resource: resource.Empty(), Duration: time.Millisecond})
+	hook.Detected(DetectionEvent{Detector: "d2", Resource: resource.NewWithAttributes("", attribute.String("k1", "v1")), Duration: time.Millisecond})
+	hook.Detected(DetectionEvent{Detector: "d3", Err: errors.New("failed"), Duration: time.Millisecond})
+
+	assert.Equal(t, "resource detector d1 detected no attributes in 1ms\n"+
+		"resource detector d2 detected 1 attributes in 1ms\n"+
+		"resource detector d3 failed in 1ms: failed\n", buf.String())
+}
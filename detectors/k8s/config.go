@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+const defaultDownwardAPIPath = "/etc/podinfo"
+
+type config struct {
+	downwardAPIPath string
+	clusterName     string
+	schemaURL       string
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{
+		downwardAPIPath: defaultDownwardAPIPath,
+		schemaURL:       semconv.SchemaURL,
+	}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies a Kubernetes resource detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithDownwardAPIPath sets the directory a downward API volume is mounted
+// at. The default is "/etc/podinfo".
+func WithDownwardAPIPath(path string) Option {
+	return optionFunc(func(c *config) {
+		c.downwardAPIPath = path
+	})
+}
+
+// WithClusterName sets the name of the cluster, which cannot be detected
+// from within a pod.
+func WithClusterName(name string) Option {
+	return optionFunc(func(c *config) {
+		c.clusterName = name
+	})
+}
+
+// WithSchemaURL sets the schema URL of the detected Resource.
+func WithSchemaURL(schemaURL string) Option {
+	return optionFunc(func(c *config) {
+		c.schemaURL = schemaURL
+	})
+}
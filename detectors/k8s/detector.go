@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package k8s provides a resource detector for pods running in any
+// Kubernetes cluster, using the information exposed by the downward API.
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+const (
+	k8sServiceHostEnvVar = "KUBERNETES_SERVICE_HOST"
+	k8sNamespacePath     = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+	// The environment variables the pod attributes are read from. They
+	// need to be set with the downward API.
+	podNameEnvVar      = "POD_NAME"
+	podNamespaceEnvVar = "POD_NAMESPACE"
+	podUIDEnvVar       = "POD_UID"
+	nodeNameEnvVar     = "NODE_NAME"
+
+	// The files of a downward API volume the pod attributes are read from.
+	podNameFile      = "name"
+	podNamespaceFile = "namespace"
+	podUIDFile       = "uid"
+	podLabelsFile    = "labels"
+
+	// podLabelPrefix is the prefix of the attribute keys of pod labels.
+	podLabelPrefix = "k8s.pod.label."
+)
+
+// resource detector collects resource information from the downward API of Kubernetes pods
+type resourceDetector struct {
+	cfg           *config
+	getenv        func(string) string
+	namespacePath string
+}
+
+// compile time assertion that resourceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that will detect Kubernetes pod resources.
+func NewResourceDetector(opts ...Option) resource.Detector {
+	return &resourceDetector{
+		cfg:           newConfig(opts...),
+		getenv:        os.Getenv,
+		namespacePath: k8sNamespacePath,
+	}
+}
+
+// Detect detects the pod the process runs in. A nil Resource is returned if
+// not running in Kubernetes.
+//
+// Each attribute is read from an environment variable set with the downward
+// API, and otherwise from the corresponding file of a downward API volume:
+// the pod name from POD_NAME or the name file, the namespace from
+// POD_NAMESPACE or the namespace file, the pod UID from POD_UID or the uid
+// file, and the node name from NODE_NAME. The pod labels are read from the
+// labels file. If not set, the pod name falls back to the hostname and the
+// namespace to the namespace of the service account.
+func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, error) {
+	if detector.getenv(k8sServiceHostEnvVar) == "" {
+		return nil, nil
+	}
+
+	var attrs []attribute.KeyValue
+	add := func(k attribute.Key, v string) {
+		if v != "" {
+			attrs = append(attrs, k.String(v))
+		}
+	}
+
+	add(semconv.K8SClusterNameKey, detector.cfg.clusterName)
+	add(semconv.K8SNamespaceNameKey, detector.lookup(podNamespaceEnvVar, podNamespaceFile, detector.namespacePath))
+	podName := detector.lookup(podNameEnvVar, podNameFile, "")
+	if podName == "" {
+		podName = detector.getenv("HOSTNAME")
+	}
+	add(semconv.K8SPodNameKey, podName)
+	add(semconv.K8SPodUIDKey, detector.lookup(podUIDEnvVar, podUIDFile, ""))
+	add(semconv.K8SNodeNameKey, detector.getenv(nodeNameEnvVar))
+
+	labels, err := detector.labels()
+	attrs = append(attrs, labels...)
+
+	return resource.NewWithAttributes(detector.cfg.schemaURL, attrs...), err
+}
+
+// lookup returns the value of the environment variable env, or the content of
+// file in the downward API volume, or the content of fallbackPath.
+func (detector *resourceDetector) lookup(env, file, fallbackPath string) string {
+	if v := detector.getenv(env); v != "" {
+		return v
+	}
+	if v := readFile(filepath.Join(detector.cfg.downwardAPIPath, file)); v != "" {
+		return v
+	}
+	if fallbackPath != "" {
+		return readFile(fallbackPath)
+	}
+	return ""
+}
+
+// labels returns the pod labels of the labels file in the downward API
+// volume. The file has one label per line in the form key="value".
+func (detector *resourceDetector) labels() ([]attribute.KeyValue, error) {
+	data, err := ioutil.ReadFile(filepath.Join(detector.cfg.downwardAPIPath, podLabelsFile))
+	if err != nil {
+		// The labels are optional.
+		return nil, nil
+	}
+
+	var attrs []attribute.KeyValue
+	var errs []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			errs = append(errs, fmt.Sprintf("invalid label %q", line))
+			continue
+		}
+		value, err := strconv.Unquote(kv[1])
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid label %q", line))
+			continue
+		}
+		attrs = append(attrs, attribute.String(podLabelPrefix+kv[0], value))
+	}
+
+	if len(errs) > 0 {
+		return attrs, fmt.Errorf("%w: %s", resource.ErrPartialResource, strings.Join(errs, "; "))
+	}
+	return attrs, nil
+}
+
+func readFile(path string) string {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func newTestDetector(t *testing.T, env map[string]string, files map[string]string, opts ...Option) *resourceDetector {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	return &resourceDetector{
+		cfg:           newConfig(append([]Option{WithDownwardAPIPath(dir)}, opts...)...),
+		getenv:        func(key string) string { return env[key] },
+		namespacePath: filepath.Join(dir, "serviceaccount-namespace"),
+	}
+}
+
+func TestDetectNotOnKubernetes(t *testing.T) {
+	detector := newTestDetector(t, nil, nil)
+	res, err := detector.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestDetectFromEnv(t *testing.T) {
+	detector := newTestDetector(t, map[string]string{
+		k8sServiceHostEnvVar: "10.0.0.1",
+		podNameEnvVar:        "pod-1",
+		podNamespaceEnvVar:   "default",
+		podUIDEnvVar:         "uid-1",
+		nodeNameEnvVar:       "node-1",
+		"HOSTNAME":           "hostname",
+	}, map[string]string{
+		podNameFile: "pod-from-file",
+	}, WithClusterName("cluster-1"))
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.K8SClusterNameKey.String("cluster-1"),
+		semconv.K8SNamespaceNameKey.String("default"),
+		semconv.K8SPodNameKey.String("pod-1"),
+		semconv.K8SPodUIDKey.String("uid-1"),
+		semconv.K8SNodeNameKey.String("node-1"),
+	)
+	assert.Equal(t, expected, res)
+}
+
+func TestDetectSchemaURL(t *testing.T) {
+	detector := newTestDetector(t, map[string]string{
+		k8sServiceHostEnvVar: "10.0.0.1",
+		podNameEnvVar:        "pod-1",
+	}, nil, WithSchemaURL("https://opentelemetry.io/schemas/1.4.0"))
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.4.0", res.SchemaURL())
+}
+
+func TestDetectFromFiles(t *testing.T) {
+	detector := newTestDetector(t, map[string]string{
+		k8sServiceHostEnvVar: "10.0.0.1",
+	}, map[string]string{
+		podNameFile:      "pod-1\n",
+		podNamespaceFile: "default",
+		podUIDFile:       "uid-1",
+		podLabelsFile:    "app=\"web\"\ntier=\"frontend \\\"a\\\"\"\n",
+	})
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.K8SNamespaceNameKey.String("default"),
+		semconv.K8SPodNameKey.String("pod-1"),
+		semconv.K8SPodUIDKey.String("uid-1"),
+		attribute.String("k8s.pod.label.app", "web"),
+		attribute.String("k8s.pod.label.tier", `frontend "a"`),
+	)
+	assert.Equal(t, expected, res)
+}
+
+func TestDetectFallbacks(t *testing.T) {
+	detector := newTestDetector(t, map[string]string{
+		k8sServiceHostEnvVar: "10.0.0.1",
+		"HOSTNAME":           "hostname",
+	}, map[string]string{
+		"serviceaccount-namespace": "kube-system",
+	})
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.K8SNamespaceNameKey.String("kube-system"),
+		semconv.K8SPodNameKey.String("hostname"),
+	)
+	assert.Equal(t, expected, res)
+}
+
+func TestDetectInvalidLabels(t *testing.T) {
+	detector := newTestDetector(t, map[string]string{
+		k8sServiceHostEnvVar: "10.0.0.1",
+		podNameEnvVar:        "pod-1",
+	}, map[string]string{
+		podLabelsFile: "app=\"web\"\ninvalid\ntier=unquoted\n",
+	})
+
+	res, err := detector.Detect(context.Background())
+	assert.True(t, errors.Is(err, resource.ErrPartialResource))
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.K8SPodNameKey.String("pod-1"),
+		attribute.String("k8s.pod.label.app", "web"),
+	)
+	assert.Equal(t, expected, res)
+}
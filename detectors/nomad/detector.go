@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nomad provides a resource detector for workloads scheduled by
+// HashiCorp Nomad.
+package nomad
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// The environment variables set by Nomad in the environment of a task.
+const (
+	allocIDEnvVar    = "NOMAD_ALLOC_ID"
+	allocNameEnvVar  = "NOMAD_ALLOC_NAME"
+	jobNameEnvVar    = "NOMAD_JOB_NAME"
+	groupNameEnvVar  = "NOMAD_GROUP_NAME"
+	taskNameEnvVar   = "NOMAD_TASK_NAME"
+	namespaceEnvVar  = "NOMAD_NAMESPACE"
+	datacenterEnvVar = "NOMAD_DC"
+	regionEnvVar     = "NOMAD_REGION"
+)
+
+// The attribute keys of the Nomad resource.
+const (
+	AllocIDKey    = attribute.Key("nomad.alloc.id")
+	AllocNameKey  = attribute.Key("nomad.alloc.name")
+	JobNameKey    = attribute.Key("nomad.job.name")
+	GroupNameKey  = attribute.Key("nomad.group.name")
+	TaskNameKey   = attribute.Key("nomad.task.name")
+	NamespaceKey  = attribute.Key("nomad.namespace")
+	DatacenterKey = attribute.Key("nomad.datacenter")
+	RegionKey     = attribute.Key("nomad.region")
+)
+
+// resource detector collects resource information from the environment of Nomad tasks
+type resourceDetector struct {
+	getenv    func(string) string
+	schemaURL string
+}
+
+// compile time assertion that resourceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that will detect Nomad allocation resources.
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &resourceDetector{getenv: os.Getenv, schemaURL: c.schemaURL}
+}
+
+// Detect detects the Nomad allocation the task runs in from the NOMAD_*
+// environment variables. A nil Resource is returned if not running in a task
+// scheduled by Nomad.
+func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, error) {
+	allocID := detector.getenv(allocIDEnvVar)
+	if allocID == "" {
+		return nil, nil
+	}
+
+	attrs := []attribute.KeyValue{
+		AllocIDKey.String(allocID),
+		semconv.ServiceInstanceIDKey.String(allocID),
+	}
+	for _, a := range []struct {
+		key attribute.Key
+		env string
+	}{
+		{AllocNameKey, allocNameEnvVar},
+		{JobNameKey, jobNameEnvVar},
+		{GroupNameKey, groupNameEnvVar},
+		{TaskNameKey, taskNameEnvVar},
+		{NamespaceKey, namespaceEnvVar},
+		{DatacenterKey, datacenterEnvVar},
+		{RegionKey, regionEnvVar},
+	} {
+		if v := detector.getenv(a.env); v != "" {
+			attrs = append(attrs, a.key.String(v))
+		}
+	}
+
+	return resource.NewWithAttributes(detector.schemaURL, attrs...), nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nomad
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func newTestDetector(env map[string]string) *resourceDetector {
+	return &resourceDetector{getenv: func(key string) string { return env[key] }, schemaURL: semconv.SchemaURL}
+}
+
+func TestDetect(t *testing.T) {
+	detector := newTestDetector(map[string]string{
+		allocIDEnvVar:    "5456bd7a-9fc0-c0dd-6131-cbee77f57577",
+		allocNameEnvVar:  "example.cache[0]",
+		jobNameEnvVar:    "example",
+		groupNameEnvVar:  "cache",
+		taskNameEnvVar:   "redis",
+		namespaceEnvVar:  "default",
+		datacenterEnvVar: "dc1",
+		regionEnvVar:     "global",
+	})
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		AllocIDKey.String("5456bd7a-9fc0-c0dd-6131-cbee77f57577"),
+		semconv.ServiceInstanceIDKey.String("5456bd7a-9fc0-c0dd-6131-cbee77f57577"),
+		AllocNameKey.String("example.cache[0]"),
+		JobNameKey.String("example"),
+		GroupNameKey.String("cache"),
+		TaskNameKey.String("redis"),
+		NamespaceKey.String("default"),
+		DatacenterKey.String("dc1"),
+		RegionKey.String("global"),
+	)
+	assert.Equal(t, expected, res)
+}
+
+func TestNewConfig(t *testing.T) {
+	assert.Equal(t, semconv.SchemaURL, newConfig().schemaURL)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.4.0", newConfig(WithSchemaURL("https://opentelemetry.io/schemas/1.4.0")).schemaURL)
+}
+
+func TestDetectPartialEnv(t *testing.T) {
+	detector := newTestDetector(map[string]string{
+		allocIDEnvVar: "5456bd7a-9fc0-c0dd-6131-cbee77f57577",
+		jobNameEnvVar: "example",
+	})
+
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		AllocIDKey.String("5456bd7a-9fc0-c0dd-6131-cbee77f57577"),
+		semconv.ServiceInstanceIDKey.String("5456bd7a-9fc0-c0dd-6131-cbee77f57577"),
+		JobNameKey.String("example"),
+	)
+	assert.Equal(t, expected, res)
+}
+
+func TestDetectNotOnNomad(t *testing.T) {
+	res, err := newTestDetector(nil).Detect(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package detectors
+
+import (
+	"log"
+	"time"
+)
+
+// ProbeEvent describes a probe run by a resource detector within a
+// detection, such as an environment probed by the AWS detector or a lookup
+// of the EKS and ECS detectors.
+type ProbeEvent struct {
+	// Detector is the name of the detector running the probe.
+	Detector string
+	// Probe is the name of the probe.
+	Probe string
+	// Found is whether the probe found what it looked for, e.g. the
+	// environment it probed or the attribute it looked up.
+	Found bool
+	// Err is the error of the probe.
+	Err error
+	// Duration is how long the probe took.
+	Duration time.Duration
+}
+
+// ProbeFunc is notified of the probes run by a resource detector. It is set
+// with the WithProbeFunc option of the detectors reporting their probes, the
+// AWS, EKS, and ECS detectors.
+//
+// Probes may run concurrently, so implementations need to be safe for
+// concurrent use.
+type ProbeFunc func(ProbeEvent)
+
+// LogProbes returns a ProbeFunc that logs every probe to l, or to the
+// standard logger if l is nil.
+func LogProbes(l *log.Logger) ProbeFunc {
+	printf := log.Printf
+	if l != nil {
+		printf = l.Printf
+	}
+	return func(e ProbeEvent) {
+		switch {
+		case e.Err != nil:
+			printf("resource detector %s probe %s failed in %s: %v", e.Detector, e.Probe, e.Duration, e.Err)
+		case !e.Found:
+			printf("resource detector %s probe %s found nothing in %s", e.Detector, e.Probe, e.Duration)
+		default:
+			printf("resource detector %s probe %s succeeded in %s", e.Detector, e.Probe, e.Duration)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package detectors
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogProbes(t *testing.T) {
+	var buf bytes.Buffer
+	probe := LogProbes(log.New(&buf, "", 0))
+
+	probe(ProbeEvent{Detector: "aws", Probe: "lambda", Duration: time.Millisecond})
+	probe(ProbeEvent{Detector: "eks", Probe: "cluster name", Found: true, Duration: time.Millisecond})
+	probe(ProbeEvent{Detector: "ecs", Probe: "task metadata", Err: errors.New("failed"), Duration: time.Millisecond})
+
+	assert.Equal(t, "resource detector aws probe lambda found nothing in 1ms\n"+
+		"resource detector eks probe cluster name succeeded in 1ms\n"+
+		"resource detector ecs probe task metadata failed in 1ms: failed\n", buf.String())
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package detectors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// ResourceDetectorsEnvVar is the environment variable holding the
+// comma-separated names of the resource detectors used by FromEnv, e.g.
+// "eks,ec2,host,process".
+const ResourceDetectorsEnvVar = "OTEL_RESOURCE_DETECTORS"
+
+// Factory returns a new resource detector.
+type Factory func() resource.Detector
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{
+		"env":          sdkDetector(resource.WithFromEnv()),
+		"host":         sdkDetector(resource.WithHost()),
+		"os":           sdkDetector(resource.WithOS()),
+		"process":      sdkDetector(resource.WithProcess()),
+		"telemetrysdk": sdkDetector(resource.WithTelemetrySDK()),
+	}
+)
+
+// Register registers the factory of the resource detector named name, for
+// use by New and FromEnv. A factory already registered with the same name is
+// replaced.
+//
+// The detectors of the SDK are registered as "env", "host", "os",
+// "process", and "telemetrysdk". The contrib detectors are registered by the
+// go.opentelemetry.io/contrib/detectors/autodetect module.
+func Register(name string, f Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(name)] = f
+}
+
+// Registered returns the sorted names of the registered resource detectors.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns a composite detector of the registered resource detectors
+// named names, in order. Names are case insensitive, and empty names are
+// ignored. The options are passed to Composite.
+func New(names []string, opts ...Option) (resource.Detector, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	var ds []resource.Detector
+	var unknown []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		f, ok := registry[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		ds = append(ds, Observe(name, f(), nil))
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown resource detectors: %s", strings.Join(unknown, ", "))
+	}
+	return Composite(ds, opts...), nil
+}
+
+// FromEnv returns a composite detector of the registered resource detectors
+// named by the OTEL_RESOURCE_DETECTORS environment variable, as New does. A
+// detector returning an empty Resource is returned if it is not set.
+func FromEnv(opts ...Option) (resource.Detector, error) {
+	return New(strings.Split(os.Getenv(ResourceDetectorsEnvVar), ","), opts...)
+}
+
+// sdkDetectorFunc runs the detectors of an SDK resource option.
+type sdkDetectorFunc func(context.Context) (*resource.Resource, error)
+
+func (f sdkDetectorFunc) Detect(ctx context.Context) (*resource.Resource, error) {
+	return f(ctx)
+}
+
+// sdkDetector returns the factory of a detector running the detectors of
+// the SDK resource option opt, which are not exported.
+func sdkDetector(opt resource.Option) Factory {
+	return func() resource.Detector {
+		return sdkDetectorFunc(func(ctx context.Context) (*resource.Resource, error) {
+			return resource.New(ctx, opt)
+		})
+	}
+}
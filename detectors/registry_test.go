@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package detectors
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func registerTestDetector(t *testing.T, name string, res *resource.Resource) {
+	Register(name, func() resource.Detector { return staticDetector(res, nil) })
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		delete(registry, name)
+	})
+}
+
+func TestRegistered(t *testing.T) {
+	registerTestDetector(t, "test", resource.Empty())
+	assert.Equal(t, []string{"env", "host", "os", "process", "telemetrysdk", "test"}, Registered())
+}
+
+func TestNew(t *testing.T) {
+	registerTestDetector(t, "first", resource.NewWithAttributes("", attribute.String("k", "first")))
+	registerTestDetector(t, "last", resource.NewWithAttributes("", attribute.String("k", "last")))
+
+	d, err := New([]string{" first", "", "LAST "})
+	require.NoError(t, err)
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes("", attribute.String("k", "last")), res)
+
+	d, err = New([]string{"first", "last"}, WithPrecedence(FirstWins))
+	require.NoError(t, err)
+	res, err = d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes("", attribute.String("k", "first")), res)
+}
+
+func TestNewUnknown(t *testing.T) {
+	_, err := New([]string{"host", "unknown1", "unknown2"})
+	assert.EqualError(t, err, "unknown resource detectors: unknown1, unknown2")
+}
+
+func TestFromEnv(t *testing.T) {
+	orig, ok := os.LookupEnv(ResourceDetectorsEnvVar)
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(ResourceDetectorsEnvVar, orig)
+		} else {
+			_ = os.Unsetenv(ResourceDetectorsEnvVar)
+		}
+	})
+
+	require.NoError(t, os.Setenv(ResourceDetectorsEnvVar, "host,telemetrysdk"))
+	d, err := FromEnv()
+	require.NoError(t, err)
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	host, err := os.Hostname()
+	require.NoError(t, err)
+	v, ok := res.Set().Value(semconv.HostNameKey)
+	assert.True(t, ok)
+	assert.Equal(t, host, v.AsString())
+	_, ok = res.Set().Value(semconv.TelemetrySDKNameKey)
+	assert.True(t, ok)
+
+	require.NoError(t, os.Unsetenv(ResourceDetectorsEnvVar))
+	d, err = FromEnv()
+	require.NoError(t, err)
+	res, err = d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Len())
+}
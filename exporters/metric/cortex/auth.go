@@ -21,29 +21,39 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 )
 
-// ErrFailedToReadFile occurs when a password / bearer token file exists, but could
-// not be read.
-var ErrFailedToReadFile = fmt.Errorf("failed to read password / bearer token file")
+// ErrFailedToReadFile occurs when a username / password / bearer token file exists, but
+// could not be read.
+var ErrFailedToReadFile = fmt.Errorf("failed to read username / password / bearer token file")
 
-// addBasicAuth sets the Authorization header for basic authentication using a username
-// and a password / password file. The header value is not changed if an Authorization
-// header already exists and no action is taken if the Exporter is not configured with
-// basic authorization credentials.
+// addBasicAuth sets the Authorization header for basic authentication using a username /
+// username file and a password / password file. The files are read for every request,
+// so that rotated credentials are used without a restart. The header value is not
+// changed if an Authorization header already exists and no action is taken if the
+// Exporter is not configured with basic authorization credentials.
 func (e *Exporter) addBasicAuth(req *http.Request) error {
 	// No need to add basic auth if it isn't provided or if the Authorization header is
 	// already set.
-	if _, exists := e.config.Headers["Authorization"]; exists {
+	if e.hasHeader("Authorization", nil) {
 		return nil
 	}
 	if e.config.BasicAuth == nil {
 		return nil
 	}
 
+	// Use username from username file if it exists.
 	username := e.config.BasicAuth["username"]
+	if usernameFile := e.config.BasicAuth["username_file"]; usernameFile != "" {
+		file, err := ioutil.ReadFile(usernameFile)
+		if err != nil {
+			return ErrFailedToReadFile
+		}
+		username = string(file)
+	}
 
 	// Use password from password file if it exists.
 	passwordFile := e.config.BasicAuth["password_file"]
@@ -70,7 +80,7 @@ func (e *Exporter) addBasicAuth(req *http.Request) error {
 // bearer token credentials.
 func (e *Exporter) addBearerTokenAuth(req *http.Request) error {
 	// No need to add bearer token auth if the Authorization header is already set.
-	if _, exists := e.config.Headers["Authorization"]; exists {
+	if e.hasHeader("Authorization", nil) {
 		return nil
 	}
 
@@ -104,9 +114,8 @@ func (e *Exporter) buildClient() (*http.Client, error) {
 	}
 
 	// Create a custom HTTP Transport for the client. This is the same as
-	// http.DefaultTransport other than the TLSClientConfig.
+	// http.DefaultTransport other than the TLSClientConfig and the proxy.
 	transport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
 			KeepAlive: 30 * time.Second,
@@ -120,19 +129,96 @@ func (e *Exporter) buildClient() (*http.Client, error) {
 		TLSClientConfig:       tlsConfig,
 	}
 
-	// Convert proxy url to proxy function for use in the created Transport.
+	// Convert proxy url to proxy function for use in the created Transport, or use
+	// the proxy of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
 	if e.config.ProxyURL != nil {
 		proxy := http.ProxyURL(e.config.ProxyURL)
 		transport.Proxy = proxy
+	} else if e.config.ProxyFromEnvironment {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+
+	// Authenticate to the proxy with the proxy basic authentication credentials.
+	// The credentials of the proxy URL are sent by the Transport both in the
+	// CONNECT requests and in the requests forwarded by the proxy.
+	if e.config.ProxyBasicAuth != nil && transport.Proxy != nil {
+		user, err := e.proxyUser()
+		if err != nil {
+			return nil, err
+		}
+		proxy := transport.Proxy
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			proxyURL, err := proxy(req)
+			if err != nil || proxyURL == nil {
+				return proxyURL, err
+			}
+			authenticatedURL := *proxyURL
+			authenticatedURL.User = user
+			return &authenticatedURL, nil
+		}
+	}
+
+	// Add the custom headers of the CONNECT requests sent to the proxy.
+	if len(e.config.ProxyConnectHeaders) > 0 {
+		transport.ProxyConnectHeader = make(http.Header, len(e.config.ProxyConnectHeaders))
+		for name, value := range e.config.ProxyConnectHeaders {
+			transport.ProxyConnectHeader.Set(name, value)
+		}
+	}
+
+	// Resolve the endpoint periodically instead of reusing the connections to the
+	// addresses removed from the DNS.
+	var roundTripper http.RoundTripper = transport
+	if e.config.ResolveInterval > 0 {
+		var endpointHost string
+		if endpoint, err := url.Parse(e.config.Endpoint); err == nil {
+			endpointHost = endpoint.Hostname()
+		}
+		roundTripper = newResolvingTransport(transport, e.config.ResolveInterval, e.config.SRVName, endpointHost)
 	}
 
 	client := http.Client{
-		Transport: transport,
-		Timeout:   e.config.RemoteTimeout,
+		Transport:     roundTripper,
+		Timeout:       e.config.RemoteTimeout,
+		CheckRedirect: e.checkRedirect,
 	}
 	return &client, nil
 }
 
+// checkRedirect follows the redirects of the requests sent by the built client, up to
+// Config.MaxRedirects redirects or 10 by default, unless Config.FollowRedirects is
+// false. The Authorization header is not forwarded to another host.
+func (e *Exporter) checkRedirect(req *http.Request, via []*http.Request) error {
+	if e.config.FollowRedirects != nil && !*e.config.FollowRedirects {
+		return http.ErrUseLastResponse
+	}
+	maxRedirects := e.config.MaxRedirects
+	if maxRedirects == 0 {
+		maxRedirects = 10
+	}
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if req.URL.Host != via[0].URL.Host {
+		req.Header.Del("Authorization")
+	}
+	return nil
+}
+
+// proxyUser returns the proxy basic authentication credentials, using the password
+// from the password file if it exists.
+func (e *Exporter) proxyUser() (*url.Userinfo, error) {
+	password := e.config.ProxyBasicAuth["password"]
+	if passwordFile := e.config.ProxyBasicAuth["password_file"]; passwordFile != "" {
+		file, err := ioutil.ReadFile(passwordFile)
+		if err != nil {
+			return nil, ErrFailedToReadFile
+		}
+		password = string(file)
+	}
+	return url.UserPassword(e.config.ProxyBasicAuth["username"], password), nil
+}
+
 // buildTLSConfig creates a new TLS Config struct with the properties from the exporter's
 // Config struct.
 func (e *Exporter) buildTLSConfig() (*tls.Config, error) {
@@ -154,23 +240,28 @@ func (e *Exporter) buildTLSConfig() (*tls.Config, error) {
 		}
 	}
 
-	// Load certificates from CA file if it exists.
-	caFile := e.config.TLSConfig["ca_file"]
-	if caFile != "" {
-		caFileData, err := ioutil.ReadFile(caFile)
-		if err != nil {
-			return nil, err
-		}
+	// Load certificates from the CA file or PEM data if they exist.
+	caData, err := e.tlsData("ca")
+	if err != nil {
+		return nil, err
+	}
+	if caData != nil {
 		certPool := x509.NewCertPool()
-		certPool.AppendCertsFromPEM(caFileData)
+		certPool.AppendCertsFromPEM(caData)
 		tlsConfig.RootCAs = certPool
 	}
 
 	// Load the client certificate if it exists.
-	certFile := e.config.TLSConfig["cert_file"]
-	keyFile := e.config.TLSConfig["key_file"]
-	if certFile != "" && keyFile != "" {
-		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	certData, err := e.tlsData("cert")
+	if err != nil {
+		return nil, err
+	}
+	keyData, err := e.tlsData("key")
+	if err != nil {
+		return nil, err
+	}
+	if certData != nil && keyData != nil {
+		cert, err := tls.X509KeyPair(certData, keyData)
 		if err != nil {
 			return nil, err
 		}
@@ -179,3 +270,16 @@ func (e *Exporter) buildTLSConfig() (*tls.Config, error) {
 
 	return tlsConfig, nil
 }
+
+// tlsData returns the PEM data of a TLS certificate or key, which is either read
+// from the name_file property or set inline by the name_pem property of the TLS
+// configuration. It returns nil if neither is set.
+func (e *Exporter) tlsData(name string) ([]byte, error) {
+	if pemData := e.config.TLSConfig[name+"_pem"]; pemData != "" {
+		return []byte(pemData), nil
+	}
+	if file := e.config.TLSConfig[name+"_file"]; file != "" {
+		return ioutil.ReadFile(file)
+	}
+	return nil, nil
+}
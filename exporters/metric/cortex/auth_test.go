@@ -31,6 +31,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -45,6 +46,7 @@ func TestAuthentication(t *testing.T) {
 	tests := []struct {
 		testName                      string
 		basicAuth                     map[string]string
+		basicAuthUsernameFileContents []byte
 		basicAuthPasswordFileContents []byte
 		bearerToken                   string
 		bearerTokenFile               string
@@ -75,6 +77,28 @@ func TestAuthentication(t *testing.T) {
 			),
 			expectedError: nil,
 		},
+		{
+			testName: "Basic Auth with username file",
+			basicAuth: map[string]string{
+				"username_file": "usernameFile",
+				"password_file": "passwordFile",
+			},
+			basicAuthUsernameFileContents: []byte("TestUser"),
+			basicAuthPasswordFileContents: []byte("TestPassword"),
+			expectedAuthHeaderValue: "Basic " + base64.StdEncoding.EncodeToString(
+				[]byte("TestUser:TestPassword"),
+			),
+			expectedError: nil,
+		},
+		{
+			testName: "Basic Auth with bad username file",
+			basicAuth: map[string]string{
+				"username_file": "missingUsernameFile",
+				"password":      "TestPassword",
+			},
+			expectedAuthHeaderValue: "",
+			expectedError:           ErrFailedToReadFile,
+		},
 		{
 			testName: "Basic Auth with bad password file",
 			basicAuth: map[string]string{
@@ -119,6 +143,13 @@ func TestAuthentication(t *testing.T) {
 
 			// Create the necessary files for tests.
 			if test.basicAuth != nil {
+				usernameFile := test.basicAuth["username_file"]
+				if usernameFile != "" && test.basicAuthUsernameFileContents != nil {
+					filepath := "./" + usernameFile
+					err := createFile(test.basicAuthUsernameFileContents, filepath)
+					require.NoError(t, err)
+					defer os.Remove(filepath)
+				}
 				passwordFile := test.basicAuth["password_file"]
 				if passwordFile != "" && test.basicAuthPasswordFileContents != nil {
 					filepath := "./" + test.basicAuth["password_file"]
@@ -137,7 +168,7 @@ func TestAuthentication(t *testing.T) {
 			// Create a HTTP request and add headers to it through an Exporter. Since the
 			// Exporter has an empty Headers map, authentication methods will be called.
 			exporter := Exporter{
-				Config{
+				config: Config{
 					BasicAuth:       test.basicAuth,
 					BearerToken:     test.bearerToken,
 					BearerTokenFile: test.bearerTokenFile,
@@ -259,6 +290,180 @@ func TestBuildClient(t *testing.T) {
 	}
 }
 
+// TestProxy checks whether the Exporter's client authenticates to the proxy and sends
+// the custom CONNECT headers.
+func TestProxy(t *testing.T) {
+	tests := []struct {
+		testName              string
+		target                string
+		expectedMethod        string
+		expectedConnectHeader string
+	}{
+		{
+			testName:       "HTTP target",
+			target:         "http://cortex.test/api/prom/push",
+			expectedMethod: http.MethodGet,
+		},
+		{
+			testName:              "HTTPS target",
+			target:                "https://cortex.test/api/prom/push",
+			expectedMethod:        http.MethodConnect,
+			expectedConnectHeader: "value",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			// Create a proxy server that records the requests and rejects them.
+			var received *http.Request
+			proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				received = req
+				rw.WriteHeader(http.StatusForbidden)
+			}))
+			defer proxy.Close()
+			proxyURL, err := url.Parse(proxy.URL)
+			require.NoError(t, err)
+
+			exporter := Exporter{
+				config: Config{
+					ProxyURL: proxyURL,
+					ProxyBasicAuth: map[string]string{
+						"username": "user",
+						"password": "password",
+					},
+					ProxyConnectHeaders: map[string]string{
+						"X-Connect-Header": "value",
+					},
+				},
+			}
+			client, err := exporter.buildClient()
+			require.NoError(t, err)
+
+			res, err := client.Get(test.target)
+			if err == nil {
+				res.Body.Close()
+			}
+
+			// Verify the request received by the proxy.
+			require.NotNil(t, received)
+			assert.Equal(t, test.expectedMethod, received.Method)
+			credentials := base64.StdEncoding.EncodeToString([]byte("user:password"))
+			assert.Equal(t, "Basic "+credentials, received.Header.Get("Proxy-Authorization"))
+			assert.Equal(t, test.expectedConnectHeader, received.Header.Get("X-Connect-Header"))
+		})
+	}
+}
+
+// TestProxyFromEnvironment checks whether the Exporter's client only uses the proxy of
+// the environment variables when it is configured to.
+func TestProxyFromEnvironment(t *testing.T) {
+	// The net/http package reads the proxy environment variables once per process,
+	// so the proxy function of the transport is checked instead of the requests.
+	for _, fromEnvironment := range []bool{false, true} {
+		exporter := Exporter{config: Config{ProxyFromEnvironment: fromEnvironment}}
+		client, err := exporter.buildClient()
+		require.NoError(t, err)
+
+		transport, ok := client.Transport.(*http.Transport)
+		require.True(t, ok)
+		if fromEnvironment {
+			require.NotNil(t, transport.Proxy)
+			assert.Equal(t, reflect.ValueOf(http.ProxyFromEnvironment).Pointer(), reflect.ValueOf(transport.Proxy).Pointer())
+		} else {
+			assert.Nil(t, transport.Proxy)
+		}
+	}
+}
+
+// TestRedirects checks whether the Exporter's client follows the redirects as configured
+// and only sends the Authorization header to the host of the request.
+func TestRedirects(t *testing.T) {
+	var received *http.Request
+	target := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		received = req
+	}))
+	defer target.Close()
+	targetURL, err := url.Parse(target.URL)
+	require.NoError(t, err)
+
+	// The redirecting server redirects /same to /target on the same server, /other to
+	// the target server on another host, and /chain to /same.
+	redirecting := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/same":
+			http.Redirect(rw, req, "/target", http.StatusTemporaryRedirect)
+		case "/other":
+			http.Redirect(rw, req, "http://localhost:"+targetURL.Port(), http.StatusTemporaryRedirect)
+		case "/chain":
+			http.Redirect(rw, req, "/same", http.StatusTemporaryRedirect)
+		default:
+			received = req
+		}
+	}))
+	defer redirecting.Close()
+
+	follow := false
+	tests := []struct {
+		testName              string
+		config                Config
+		path                  string
+		expectedStatus        int
+		expectedAuthorization string
+		expectedError         string
+	}{
+		{
+			testName:              "Same host",
+			path:                  "/same",
+			expectedStatus:        http.StatusOK,
+			expectedAuthorization: "Bearer token",
+		},
+		{
+			testName:       "Other host",
+			path:           "/other",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			testName:       "Redirects not followed",
+			config:         Config{FollowRedirects: &follow},
+			path:           "/same",
+			expectedStatus: http.StatusTemporaryRedirect,
+		},
+		{
+			testName:      "Too many redirects",
+			config:        Config{MaxRedirects: 1},
+			path:          "/chain",
+			expectedError: "stopped after 1 redirects",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			received = nil
+			exporter := Exporter{config: test.config}
+			client, err := exporter.buildClient()
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, redirecting.URL+test.path, strings.NewReader("message"))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer token")
+			res, err := client.Do(req)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			res.Body.Close()
+
+			assert.Equal(t, test.expectedStatus, res.StatusCode)
+			if test.expectedStatus == http.StatusOK {
+				require.NotNil(t, received)
+				assert.Equal(t, test.expectedAuthorization, received.Header.Get("Authorization"))
+			} else {
+				assert.Nil(t, received)
+			}
+		})
+	}
+}
+
 // TestMutualTLS is an integration test that checks whether the Exporter's client can
 // successfully verify a server and send a HTTP request and whether a server can
 // successfully verify the Exporter client and receive the HTTP request.
@@ -266,6 +471,7 @@ func TestMutualTLS(t *testing.T) {
 	tests := []struct {
 		testName      string
 		generateCerts bool
+		inlinePEM     bool
 		caCert        string
 		caKey         string
 		servingCert   string
@@ -283,6 +489,17 @@ func TestMutualTLS(t *testing.T) {
 			clientCert:    "client.crt",
 			clientKey:     "client.key",
 		},
+		{
+			testName:      "Generated ECDSA Certs with inline PEM",
+			generateCerts: true,
+			inlinePEM:     true,
+			caCert:        "ca.crt",
+			caKey:         "ca.key",
+			servingCert:   "server.crt",
+			servingKey:    "server.key",
+			clientCert:    "client.crt",
+			clientKey:     "client.key",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.testName, func(t *testing.T) {
@@ -335,15 +552,25 @@ func TestMutualTLS(t *testing.T) {
 			server.StartTLS()
 			defer server.Close()
 
-			// Create an Exporter client with the client and CA certificate files.
+			// Create an Exporter client with the client and CA certificate files, or
+			// with their PEM data.
+			tlsConfig := map[string]string{
+				"ca_file":              test.caCert,
+				"cert_file":            test.clientCert,
+				"key_file":             test.clientKey,
+				"insecure_skip_verify": "0",
+			}
+			if test.inlinePEM {
+				for _, name := range []string{"ca", "cert", "key"} {
+					data, err := ioutil.ReadFile(tlsConfig[name+"_file"])
+					require.NoError(t, err)
+					delete(tlsConfig, name+"_file")
+					tlsConfig[name+"_pem"] = string(data)
+				}
+			}
 			exporter := Exporter{
-				Config{
-					TLSConfig: map[string]string{
-						"ca_file":              test.caCert,
-						"cert_file":            test.clientCert,
-						"key_file":             test.clientKey,
-						"insecure_skip_verify": "0",
-					},
+				config: Config{
+					TLSConfig: tlsConfig,
 				},
 			}
 			client, err := exporter.buildClient()
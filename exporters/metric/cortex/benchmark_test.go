@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+)
+
+// getSeriesCountReader returns a checkpoint set with series sum aggregation records.
+func getSeriesCountReader(b *testing.B, series int) export.InstrumentationLibraryReader {
+	ctx, meter, cont := testMeter(b)
+	counter := metric.Must(meter).NewInt64Counter("metric_sum")
+	for i := 0; i < series; i++ {
+		counter.Add(ctx, int64(i), attribute.String("series", strconv.Itoa(i)))
+	}
+	require.NoError(b, cont.Collect(ctx))
+	return cont
+}
+
+// BenchmarkExport measures the pushes of series time series to a server discarding
+// them, reporting the samples pushed per second. The results can be compared with a
+// baseline with benchstat, see the README.
+func BenchmarkExport(b *testing.B) {
+	configs := []struct {
+		name   string
+		config Config
+	}{
+		{name: "snappy", config: Config{Compression: CompressionSnappy}},
+		{name: "zstd", config: Config{Compression: CompressionZstd}},
+		{name: "victoriametrics", config: Config{Format: FormatVictoriaMetricsImport}},
+	}
+	for _, series := range []int{100, 1000, 10000} {
+		for _, c := range configs {
+			b.Run(fmt.Sprintf("series=%d/%s", series, c.name), func(b *testing.B) {
+				benchmarkExport(b, series, c.config)
+			})
+		}
+	}
+}
+
+func benchmarkExport(b *testing.B, series int, config Config) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(ioutil.Discard, r.Body)
+	}))
+	defer server.Close()
+
+	config.Endpoint = server.URL
+	exporter, err := NewRawExporter(config)
+	require.NoError(b, err)
+	reader := getSeriesCountReader(b, series)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	var elapsed time.Duration
+	for i := 0; i < b.N; i++ {
+		// Forget the timestamps of the previous push, so that the same samples
+		// are not skipped as out of order.
+		b.StopTimer()
+		exporter.series.timestamps.last = nil
+		b.StartTimer()
+
+		start := time.Now()
+		if err := exporter.Export(ctx, testResource, reader); err != nil {
+			b.Fatal(err)
+		}
+		elapsed += time.Since(start)
+	}
+	b.ReportMetric(float64(series*b.N)/elapsed.Seconds(), "samples/s")
+}
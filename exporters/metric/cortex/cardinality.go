@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/prometheus/prompb"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex/prometheusremotewrite"
+	"go.opentelemetry.io/otel/attribute"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+// OverflowLabel is the label of the series aggregating the series of a metric over
+// Config.MaxSeriesPerMetric.
+const OverflowLabel = "otel_overflow"
+
+var overflowSet = attribute.NewSet(attribute.String(OverflowLabel, "true"))
+
+// cardinalityLimiter admits the first series of every metric, up to a maximum.
+type cardinalityLimiter struct {
+	mu      sync.Mutex
+	series  map[string]map[attribute.Distinct]struct{}
+	dropped uint64
+}
+
+// admit reports whether the series of a metric with attrs is admitted. The series
+// admitted once stay admitted.
+func (l *cardinalityLimiter) admit(name string, attrs attribute.Distinct, max int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.series == nil {
+		l.series = make(map[string]map[attribute.Distinct]struct{})
+	}
+	series, ok := l.series[name]
+	if !ok {
+		series = make(map[attribute.Distinct]struct{})
+		l.series[name] = series
+	}
+	if _, ok := series[attrs]; ok {
+		return true
+	}
+	if len(series) >= max {
+		return false
+	}
+	series[attrs] = struct{}{}
+	return true
+}
+
+// drop counts a dropped series.
+func (l *cardinalityLimiter) drop() {
+	l.mu.Lock()
+	l.dropped++
+	l.mu.Unlock()
+}
+
+// droppedSeries returns the number of series dropped.
+func (l *cardinalityLimiter) droppedSeries() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dropped
+}
+
+// reader returns a reader of the records of checkpointSet where the series over
+// max of every metric are dropped, or have their attributes replaced with the
+// overflow label so that add aggregates them. opts are the options of the
+// conversion of the records.
+func (l *cardinalityLimiter) reader(checkpointSet export.InstrumentationLibraryReader, max int, drop bool, opts []prometheusremotewrite.Option) *limitedLibraryReader {
+	return &limitedLibraryReader{
+		InstrumentationLibraryReader: checkpointSet,
+		limiter:                      l,
+		max:                          max,
+		drop:                         drop,
+		opts:                         opts,
+		merges:                       make(map[string]mergeFunc),
+	}
+}
+
+// mergeFunc merges the sample of an overflow time series into the sample of
+// the time series aggregating them.
+type mergeFunc func(into *prompb.Sample, sample prompb.Sample)
+
+// mergeSum adds the values, which is how the counts, sums and histogram
+// buckets are merged.
+func mergeSum(into *prompb.Sample, sample prompb.Sample) {
+	into.Value += sample.Value
+	if sample.Timestamp > into.Timestamp {
+		into.Timestamp = sample.Timestamp
+	}
+}
+
+// mergeLast keeps the most recent value.
+func mergeLast(into *prompb.Sample, sample prompb.Sample) {
+	if sample.Timestamp >= into.Timestamp {
+		*into = sample
+	}
+}
+
+func mergeMin(into *prompb.Sample, sample prompb.Sample) {
+	if sample.Value < into.Value {
+		into.Value = sample.Value
+	}
+	if sample.Timestamp > into.Timestamp {
+		into.Timestamp = sample.Timestamp
+	}
+}
+
+func mergeMax(into *prompb.Sample, sample prompb.Sample) {
+	if sample.Value > into.Value {
+		into.Value = sample.Value
+	}
+	if sample.Timestamp > into.Timestamp {
+		into.Timestamp = sample.Timestamp
+	}
+}
+
+// limitedLibraryReader is an export.InstrumentationLibraryReader limiting the
+// number of series of every metric.
+type limitedLibraryReader struct {
+	export.InstrumentationLibraryReader
+	limiter *cardinalityLimiter
+	max     int
+	drop    bool
+	opts    []prometheusremotewrite.Option
+	// merges are the merge functions of the overflow time series that are not
+	// merged with mergeSum, by metric name.
+	merges map[string]mergeFunc
+	// overflowSeries are the merged overflow time series, indexed by the key
+	// of their labels.
+	overflowSeries []prompb.TimeSeries
+	indexes        map[string]int
+}
+
+// ForEach calls readerFunc with readers limiting the number of series of every
+// metric.
+func (r *limitedLibraryReader) ForEach(readerFunc func(instrumentation.Library, export.Reader) error) error {
+	return r.InstrumentationLibraryReader.ForEach(func(library instrumentation.Library, reader export.Reader) error {
+		return readerFunc(library, limitedReader{Reader: reader, r: r})
+	})
+}
+
+// overflow returns the record replacing record when its series is over the
+// limit.
+func (r *limitedLibraryReader) overflow(record export.Record) export.Record {
+	name := prometheusremotewrite.MetricName(record.Descriptor(), r.opts...)
+	switch agg := record.Aggregation().(type) {
+	case aggregation.Histogram:
+	case aggregation.Sum:
+		if _, ok := agg.(aggregation.MinMaxSumCount); ok {
+			r.merges[name+"_min"] = mergeMin
+			r.merges[name+"_max"] = mergeMax
+		}
+	case aggregation.LastValue:
+		r.merges[name] = mergeLast
+	}
+	return export.NewRecord(record.Descriptor(), &overflowSet, record.Aggregation(), record.StartTime(), record.EndTime())
+}
+
+// add merges ts into the overflow time series with the same labels when it was
+// created from series over the limit, and reports whether it was.
+func (r *limitedLibraryReader) add(ts prompb.TimeSeries) bool {
+	if !isOverflow(ts) || len(ts.Samples) != 1 {
+		return false
+	}
+	key, name := labelsKey(ts.Labels)
+	if i, ok := r.indexes[key]; ok {
+		merge, ok := r.merges[name]
+		if !ok {
+			merge = mergeSum
+		}
+		merge(&r.overflowSeries[i].Samples[0], ts.Samples[0])
+		return true
+	}
+	if r.indexes == nil {
+		r.indexes = make(map[string]int)
+	}
+	r.indexes[key] = len(r.overflowSeries)
+	r.overflowSeries = append(r.overflowSeries, ts)
+	return true
+}
+
+// isOverflow reports whether ts was created from series over the limit.
+func isOverflow(ts prompb.TimeSeries) bool {
+	for _, l := range ts.Labels {
+		if l.Name == OverflowLabel {
+			return true
+		}
+	}
+	return false
+}
+
+// labelsKey returns a key identifying labels whatever their order, and the
+// metric name of the labels.
+func labelsKey(labels []prompb.Label) (string, string) {
+	var name string
+	pairs := make([]string, 0, len(labels))
+	for _, l := range labels {
+		if l.Name == "__name__" {
+			name = l.Value
+		}
+		pairs = append(pairs, l.Name+"\x00"+l.Value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\x01"), name
+}
+
+// limitedReader is an export.Reader limiting the number of series of every
+// metric.
+type limitedReader struct {
+	export.Reader
+	r *limitedLibraryReader
+}
+
+// ForEach calls recordFunc with the records of the series under the limit, and
+// with the records replacing the records over it unless they are dropped.
+func (r limitedReader) ForEach(selector export.ExportKindSelector, recordFunc func(export.Record) error) error {
+	return r.Reader.ForEach(selector, func(record export.Record) error {
+		if r.r.limiter.admit(record.Descriptor().Name(), record.Labels().Equivalent(), r.r.max) {
+			return recordFunc(record)
+		}
+		if r.r.drop {
+			r.r.limiter.drop()
+			return nil
+		}
+		return recordFunc(r.r.overflow(record))
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+)
+
+// getSeriesReader returns a checkpoint set with a sum and a last value record for
+// every value, with a distinct "id" attribute.
+func getSeriesReader(t *testing.T, values ...int64) export.InstrumentationLibraryReader {
+	ctx, meter, cont := testMeter(t)
+	counter := metric.Must(meter).NewInt64Counter("metric_sum")
+	_ = metric.Must(meter).NewInt64GaugeObserver("metric_lastvalue", func(_ context.Context, res metric.Int64ObserverResult) {
+		for i, value := range values {
+			res.Observe(value, attribute.Int("id", i))
+		}
+	})
+	for i, value := range values {
+		counter.Add(ctx, value, attribute.Int("id", i))
+	}
+	require.NoError(t, cont.Collect(ctx))
+	return cont
+}
+
+// seriesValues returns the values of the series by metric name and id label.
+func seriesValues(timeseries []prompb.TimeSeries) map[string]float64 {
+	values := make(map[string]float64)
+	for _, ts := range timeseries {
+		var name, id string
+		for _, l := range ts.Labels {
+			switch l.Name {
+			case "__name__":
+				name = l.Value
+			case "id":
+				id = l.Value
+			case OverflowLabel:
+				id = "overflow"
+			}
+		}
+		values[name+"/"+id] = ts.Samples[0].Value
+	}
+	return values
+}
+
+func TestCardinalityLimitOverflow(t *testing.T) {
+	exporter := Exporter{config: Config{MaxSeriesPerMetric: 2}}
+
+	timeseries, err := exporter.ConvertToTimeSeries(testResource, getSeriesReader(t, 1, 2, 3, 4))
+	require.NoError(t, err)
+	require.Len(t, timeseries, 6)
+	values := seriesValues(timeseries)
+	require.Len(t, values, 6)
+
+	// Each metric has the 2 series admitted and the overflow series, which adds
+	// the sums of the other 2 series.
+	var sumSeries, total float64
+	for key, value := range values {
+		if strings.HasPrefix(key, "metric_sum/") {
+			sumSeries++
+			total += value
+		}
+	}
+	assert.Equal(t, 3.0, sumSeries)
+	assert.Equal(t, 10.0, total)
+	assert.Contains(t, values, "metric_sum/overflow")
+	assert.Contains(t, values, "metric_lastvalue/overflow")
+
+	// The series admitted first stay admitted.
+	timeseries, err = exporter.ConvertToTimeSeries(testResource, getSeriesReader(t, 1, 2, 3, 4))
+	require.NoError(t, err)
+	for key := range seriesValues(timeseries) {
+		assert.Contains(t, values, key)
+	}
+	assert.Zero(t, exporter.DroppedSeries())
+}
+
+func TestCardinalityLimitDrop(t *testing.T) {
+	exporter := Exporter{config: Config{MaxSeriesPerMetric: 1, DropOverflow: true}}
+
+	timeseries, err := exporter.ConvertToTimeSeries(testResource, getSeriesReader(t, 1, 2, 3))
+	require.NoError(t, err)
+	assert.Len(t, timeseries, 2)
+	assert.Equal(t, uint64(4), exporter.DroppedSeries())
+}
+
+func TestMergeOverflow(t *testing.T) {
+	overflow := func(name string, value float64, timestamp int64) prompb.TimeSeries {
+		return prompb.TimeSeries{
+			Labels:  []prompb.Label{{Name: OverflowLabel, Value: "true"}, {Name: "__name__", Value: name}},
+			Samples: []prompb.Sample{{Value: value, Timestamp: timestamp}},
+		}
+	}
+	reader := &limitedLibraryReader{merges: map[string]mergeFunc{
+		"gauge":   mergeLast,
+		"svc_min": mergeMin,
+		"svc_max": mergeMax,
+	}}
+	for _, ts := range []prompb.TimeSeries{
+		overflow("counter", 1, 1), overflow("counter", 2, 2),
+		overflow("gauge", 3, 2), overflow("gauge", 4, 1),
+		overflow("svc_min", 5, 1), overflow("svc_min", 6, 1),
+		overflow("svc_max", 7, 1), overflow("svc_max", 8, 1),
+	} {
+		assert.True(t, reader.add(ts))
+	}
+	assert.False(t, reader.add(prompb.TimeSeries{Samples: []prompb.Sample{{}}}))
+	merged := reader.overflowSeries
+	assert.Equal(t, map[string]float64{
+		"counter/overflow": 3,
+		"gauge/overflow":   3,
+		"svc_min/overflow": 5,
+		"svc_max/overflow": 8,
+	}, seriesValues(merged))
+	assert.Len(t, merged, 4)
+}
+
+func TestValidateMaxSeriesPerMetric(t *testing.T) {
+	config := Config{MaxSeriesPerMetric: -1}
+	assert.Equal(t, ErrInvalidMaxSeriesPerMetric, config.Validate())
+}
@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fault is a failure injected by a chaosProxy into the response to a request.
+type fault struct {
+	// drop closes the connection without a response.
+	drop bool
+	// status is the status code returned instead of forwarding the request.
+	status int
+	// latency delays the forwarding of the request.
+	latency time.Duration
+	// truncate closes the connection in the middle of the response headers.
+	truncate bool
+}
+
+// chaosProxy forwards the requests to a backend, injecting the faults queued with
+// inject into the responses to the next requests.
+type chaosProxy struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	faults    []fault
+	forwarded int
+}
+
+// newChaosProxy returns a chaosProxy forwarding the requests to backend, closed when
+// the test ends.
+func newChaosProxy(t *testing.T, backend *httptest.Server) *chaosProxy {
+	backendURL, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+	forward := httputil.NewSingleHostReverseProxy(backendURL)
+
+	p := &chaosProxy{}
+	p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Read the request first, so that its context is canceled when the
+		// client gives up during the latency.
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		f := p.next()
+		if f.latency > 0 {
+			select {
+			case <-time.After(f.latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		switch {
+		case f.drop || f.truncate:
+			conn, buf, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if f.truncate {
+				_, _ = buf.WriteString("HTTP/1.1 200 OK\r\nContent-Le")
+				_ = buf.Flush()
+			}
+			_ = conn.Close()
+		case f.status != 0:
+			w.WriteHeader(f.status)
+		default:
+			p.mu.Lock()
+			p.forwarded++
+			p.mu.Unlock()
+			forward.ServeHTTP(w, r)
+		}
+	}))
+	t.Cleanup(p.Close)
+	return p
+}
+
+// inject queues faults for the next requests.
+func (p *chaosProxy) inject(faults ...fault) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.faults = append(p.faults, faults...)
+}
+
+// next returns the fault of the next request, which is forwarded unchanged when
+// no fault is queued.
+func (p *chaosProxy) next() fault {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.faults) == 0 {
+		return fault{}
+	}
+	f := p.faults[0]
+	p.faults = p.faults[1:]
+	return f
+}
+
+// forwardedRequests returns the number of requests forwarded to the backend.
+func (p *chaosProxy) forwardedRequests() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.forwarded
+}
+
+// newChaosBackend returns a backend checking that the requests of the Exporter hold
+// a remote write request.
+func newChaosBackend(t *testing.T) *httptest.Server {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		compressed, err := ioutil.ReadAll(r.Body)
+		if err == nil {
+			var uncompressed []byte
+			if uncompressed, err = snappy.Decode(nil, compressed); err == nil {
+				err = (&prompb.WriteRequest{}).Unmarshal(uncompressed)
+			}
+		}
+		if err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(backend.Close)
+	return backend
+}
+
+// TestChaosFaults checks whether every fault fails the push, is recorded in the
+// Stats, and does not prevent the next pushes.
+func TestChaosFaults(t *testing.T) {
+	testCases := []struct {
+		name  string
+		fault fault
+		// err is the expected error, if the push fails.
+		err string
+	}{
+		{name: "no fault"},
+		{name: "latency", fault: fault{latency: 10 * time.Millisecond}},
+		{name: "dropped", fault: fault{drop: true}, err: "EOF"},
+		{name: "too many requests", fault: fault{status: http.StatusTooManyRequests}, err: "429 Too Many Requests"},
+		{name: "internal server error", fault: fault{status: http.StatusInternalServerError}, err: "500 Internal Server Error"},
+		{name: "truncated", fault: fault{truncate: true}, err: "malformed MIME header"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			proxy := newChaosProxy(t, newChaosBackend(t))
+			config := validConfig
+			config.Endpoint = proxy.URL
+			exporter, err := NewRawExporter(config)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			proxy.inject(tc.fault)
+			err = exporter.Export(ctx, testResource, getSumReader(t, 1))
+			stats := exporter.Stats()
+			if tc.err == "" {
+				require.NoError(t, err)
+				assert.Equal(t, 1, proxy.forwardedRequests())
+				assert.Zero(t, stats.FailedPushes)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+				assert.Equal(t, 0, proxy.forwardedRequests())
+				assert.Equal(t, uint64(1), stats.FailedPushes)
+				assert.NotEmpty(t, stats.LastError)
+			}
+
+			// The next push succeeds.
+			require.NoError(t, exporter.Export(ctx, testResource, getSumReader(t, 2)))
+			assert.Equal(t, stats.FailedPushes, exporter.Stats().FailedPushes)
+			assert.Equal(t, uint64(2), exporter.Stats().Pushes)
+		})
+	}
+}
+
+// TestChaosTimeout checks whether the pushes to a backend responding too slowly fail
+// after Config.RemoteTimeout.
+func TestChaosTimeout(t *testing.T) {
+	proxy := newChaosProxy(t, newChaosBackend(t))
+	config := Config{Endpoint: proxy.URL, RemoteTimeout: 30 * time.Second}
+	config.RemoteTimeout = 20 * time.Millisecond
+	exporter, err := NewRawExporter(config)
+	require.NoError(t, err)
+
+	proxy.inject(fault{latency: time.Minute})
+	start := time.Now()
+	err = exporter.Export(context.Background(), testResource, getSumReader(t, 1))
+	require.Error(t, err)
+	assert.Less(t, int64(time.Since(start)), int64(10*time.Second))
+	assert.Equal(t, 0, proxy.forwardedRequests())
+}
+
+// TestChaosResendUnchanged checks whether the series skipped because they did not
+// change are sent again after a failed push.
+func TestChaosResendUnchanged(t *testing.T) {
+	proxy := newChaosProxy(t, newChaosBackend(t))
+	var samples []int
+	config := Config{Endpoint: proxy.URL, RemoteTimeout: 30 * time.Second}
+	config.SuppressUnchanged = true
+	config.PushCallback = func(summary PushSummary) {
+		if summary.Err == nil {
+			samples = append(samples, summary.Samples)
+		}
+	}
+	exporter, err := NewRawExporter(config)
+	require.NoError(t, err)
+
+	export := func(faults ...fault) error {
+		// Wait for the samples to have a timestamp after the samples of the
+		// previous export, as out of order samples are skipped.
+		time.Sleep(time.Millisecond)
+		proxy.inject(faults...)
+		return exporter.Export(context.Background(), testResource, getLastValueReader(t, 5))
+	}
+	require.NoError(t, export())
+	require.NoError(t, export())
+	require.Error(t, export(fault{status: http.StatusServiceUnavailable}))
+	require.NoError(t, export())
+	assert.Equal(t, []int{1, 0, 1}, samples)
+}
+
+// TestChaosQueue checks whether the worker sending the queued requests keeps sending
+// them when some fail.
+func TestChaosQueue(t *testing.T) {
+	proxy := newChaosProxy(t, newChaosBackend(t))
+	config := Config{Endpoint: proxy.URL, RemoteTimeout: 30 * time.Second}
+	config.QueueSize = 10
+	config.DrainOnShutdown = true
+	exporter, err := NewRawExporter(config)
+	require.NoError(t, err)
+
+	proxy.inject(
+		fault{drop: true},
+		fault{latency: 10 * time.Millisecond},
+		fault{status: http.StatusTooManyRequests},
+		fault{truncate: true},
+	)
+	ctx := context.Background()
+	for i := 0; i < 6; i++ {
+		require.NoError(t, exporter.Export(ctx, testResource, getSumReader(t, 1)))
+	}
+	require.NoError(t, exporter.Shutdown(ctx))
+
+	stats := exporter.Stats()
+	assert.Equal(t, uint64(6), stats.Pushes)
+	assert.Equal(t, uint64(3), stats.FailedPushes)
+	assert.Equal(t, 3, proxy.forwardedRequests())
+}
@@ -19,6 +19,13 @@ import (
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/prometheus/pkg/relabel"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex/prometheusremotewrite"
 )
 
 var (
@@ -34,33 +41,141 @@ var (
 	// bearer token authorization
 	ErrConflictingAuthorization = fmt.Errorf("cannot have both basic auth and bearer token authorization")
 
-	// ErrNoBasicAuthUsername occurs when no username was provided for basic
-	// authentication.
-	ErrNoBasicAuthUsername = fmt.Errorf("no username provided for basic authentication")
+	// ErrTwoUsernames occurs when the YAML file contains both `username` and
+	// `username_file`.
+	ErrTwoUsernames = fmt.Errorf("cannot have two usernames in the YAML file")
+
+	// ErrNoBasicAuthUsername occurs when no username or username file was provided for
+	// basic authentication.
+	ErrNoBasicAuthUsername = fmt.Errorf("no username or username file provided for basic authentication")
 
 	// ErrNoBasicAuthPassword occurs when no password or password file was provided for
 	// basic authentication.
 	ErrNoBasicAuthPassword = fmt.Errorf("no password or password file provided for basic authentication")
 
+	// ErrTwoTLSSources occurs when the TLS configuration contains both a file and PEM
+	// data for the same certificate or key, such as `ca_file` and `ca_pem`.
+	ErrTwoTLSSources = fmt.Errorf("cannot have both a file and PEM data for a TLS certificate or key")
+
+	// ErrTwoProxies occurs when the YAML file contains both `proxy_url` and
+	// `proxy_from_environment`.
+	ErrTwoProxies = fmt.Errorf("cannot have both a proxy URL and a proxy from the environment")
+
 	// ErrInvalidQuantiles occurs when the supplied quantiles are not between 0 and 1.
 	ErrInvalidQuantiles = fmt.Errorf("cannot have quantiles that are less than 0 or greater than 1")
+
+	// ErrInvalidFormat occurs when the format is not supported.
+	ErrInvalidFormat = fmt.Errorf("invalid format")
+
+	// ErrInvalidCompression occurs when the compression is not supported.
+	ErrInvalidCompression = fmt.Errorf("invalid compression")
+
+	// ErrInvalidLabelConflictPolicy occurs when the label conflict policy is not
+	// supported.
+	ErrInvalidLabelConflictPolicy = fmt.Errorf("invalid label conflict policy")
+
+	// ErrInvalidMaxSeriesPerMetric occurs when the maximum number of series per
+	// metric is negative.
+	ErrInvalidMaxSeriesPerMetric = fmt.Errorf("cannot have a negative maximum number of series per metric")
+
+	// ErrInvalidQueueSize occurs when the size of the export queue is negative.
+	ErrInvalidQueueSize = fmt.Errorf("cannot have a negative queue size")
+
+	// ErrInvalidMaxRedirects occurs when the maximum number of redirects is
+	// negative.
+	ErrInvalidMaxRedirects = fmt.Errorf("cannot have a negative maximum number of redirects")
+
+	// ErrInvalidResolveInterval occurs when the interval between the resolutions of
+	// the endpoint is negative.
+	ErrInvalidResolveInterval = fmt.Errorf("cannot have a negative resolve interval")
+)
+
+// The formats of the data sent by the Exporter.
+const (
+	// FormatRemoteWrite sends Snappy-compressed protobuf messages with the
+	// Prometheus Remote Write API. It is the default format.
+	FormatRemoteWrite = "remote_write"
+
+	// FormatVictoriaMetricsImport sends JSON lines to the /api/v1/import
+	// endpoint of VictoriaMetrics, one line per time series.
+	FormatVictoriaMetricsImport = "victoriametrics_import"
+)
+
+// The compressions of the Remote Write requests.
+const (
+	// CompressionSnappy compresses the requests with Snappy, as required by
+	// the Prometheus Remote Write API. It is the default compression.
+	CompressionSnappy = "snappy"
+
+	// CompressionZstd compresses the requests with Zstandard, which is smaller
+	// than Snappy for large label sets. It is only accepted by some backends.
+	// A request rejected with 415 Unsupported Media Type is sent again with
+	// Snappy.
+	CompressionZstd = "zstd"
 )
 
+// TenantHeader is the header identifying the tenant of the requests to a
+// multi-tenant backend, set to the Config Tenant.
+const TenantHeader = "X-Scope-OrgID"
+
 // Config contains properties the Exporter uses to export metrics data to Cortex.
 type Config struct {
-	Endpoint            string            `mapstructure:"url"`
-	RemoteTimeout       time.Duration     `mapstructure:"remote_timeout"`
-	Name                string            `mapstructure:"name"`
-	BasicAuth           map[string]string `mapstructure:"basic_auth"`
-	BearerToken         string            `mapstructure:"bearer_token"`
-	BearerTokenFile     string            `mapstructure:"bearer_token_file"`
-	TLSConfig           map[string]string `mapstructure:"tls_config"`
-	ProxyURL            *url.URL          `mapstructure:"proxy_url"`
-	PushInterval        time.Duration     `mapstructure:"push_interval"`
-	Quantiles           []float64         `mapstructure:"quantiles"`
-	HistogramBoundaries []float64         `mapstructure:"histogram_boundaries"`
-	Headers             map[string]string `mapstructure:"headers"`
-	Client              *http.Client
+	Endpoint             string            `mapstructure:"url"`
+	RemoteTimeout        time.Duration     `mapstructure:"remote_timeout"`
+	Name                 string            `mapstructure:"name"`
+	BasicAuth            map[string]string `mapstructure:"basic_auth"`
+	BearerToken          string            `mapstructure:"bearer_token"`
+	BearerTokenFile      string            `mapstructure:"bearer_token_file"`
+	TLSConfig            map[string]string `mapstructure:"tls_config"`
+	ProxyURL             *url.URL          `mapstructure:"proxy_url"`
+	ProxyFromEnvironment bool              `mapstructure:"proxy_from_environment"`
+	ProxyBasicAuth       map[string]string `mapstructure:"proxy_basic_auth"`
+	ProxyConnectHeaders  map[string]string `mapstructure:"proxy_connect_headers"`
+	PushInterval         time.Duration     `mapstructure:"push_interval"`
+	Quantiles            []float64         `mapstructure:"quantiles"`
+	HistogramBoundaries  []float64         `mapstructure:"histogram_boundaries"`
+	Headers              map[string]string `mapstructure:"headers"`
+	Tenant               string            `mapstructure:"tenant"`
+	Format               string            `mapstructure:"format"`
+	Compression          string            `mapstructure:"compression"`
+	ExtraLabels          map[string]string `mapstructure:"extra_labels"`
+	SuppressUnchanged    bool              `mapstructure:"suppress_unchanged"`
+	HeartbeatInterval    time.Duration     `mapstructure:"heartbeat_interval"`
+	MaxSeriesPerMetric   int               `mapstructure:"max_series_per_metric"`
+	DropOverflow         bool              `mapstructure:"drop_overflow"`
+	QueueSize            int               `mapstructure:"queue_size"`
+	DrainOnShutdown      bool              `mapstructure:"drain_on_shutdown"`
+	PrometheusNaming     bool              `mapstructure:"prometheus_naming"`
+	LabelConflictPolicy  string            `mapstructure:"label_conflict_policy"`
+	RenamedLabelPrefix   string            `mapstructure:"renamed_label_prefix"`
+	SendMetadata         bool              `mapstructure:"send_metadata"`
+	SendUpSeries         bool              `mapstructure:"send_up_series"`
+	FollowRedirects      *bool             `mapstructure:"follow_redirects"`
+	MaxRedirects         int               `mapstructure:"max_redirects"`
+	ResolveInterval      time.Duration     `mapstructure:"resolve_interval"`
+	SRVName              string            `mapstructure:"srv_name"`
+	HeadersFile          string            `mapstructure:"headers_file"`
+	WriteRelabelConfigs  []*relabel.Config `mapstructure:"-"`
+	// HeaderValues are added to every request like Headers, but with all the
+	// values of every header and with the header names exactly as configured
+	// instead of canonicalized, for the gateways sensitive to the casing or the
+	// multiplicity of the headers. HTTP/2 still sends the names lower-cased.
+	HeaderValues map[string][]string `mapstructure:"-"`
+	Client       *http.Client
+	// Logger logs the records that cannot be converted and, at the V(1)
+	// level, the time series of every push. Nothing is logged when nil.
+	Logger logr.Logger
+	// TracerProvider creates a client span around every request sent to
+	// Cortex, with the endpoint, request size, and response status code as
+	// attributes, so that the latency of the pushes shows up in the traces.
+	// The span is a child of the span of the context passed to Export, or a
+	// root span for the requests sent by the worker of Config.QueueSize.
+	// Nothing is traced when nil.
+	TracerProvider trace.TracerProvider
+	// PushCallback is called with the PushSummary of every request sent to
+	// Cortex, from the goroutine sending it, so that the health of the export
+	// can be reported to other systems. It must not block.
+	PushCallback func(PushSummary)
 }
 
 // Validate checks a Config struct for missing required properties and property conflicts.
@@ -68,9 +183,15 @@ type Config struct {
 func (c *Config) Validate() error {
 	// Check for valid basic authentication and bearer token configuration.
 	if c.BasicAuth != nil {
-		if c.BasicAuth["username"] == "" {
+		username := c.BasicAuth["username"]
+		usernameFile := c.BasicAuth["username_file"]
+
+		if username == "" && usernameFile == "" {
 			return ErrNoBasicAuthUsername
 		}
+		if username != "" && usernameFile != "" {
+			return ErrTwoUsernames
+		}
 
 		password := c.BasicAuth["password"]
 		passwordFile := c.BasicAuth["password_file"]
@@ -89,6 +210,32 @@ func (c *Config) Validate() error {
 		return ErrTwoBearerTokens
 	}
 
+	if c.ProxyURL != nil && c.ProxyFromEnvironment {
+		return ErrTwoProxies
+	}
+
+	// Check for valid proxy basic authentication configuration.
+	if c.ProxyBasicAuth != nil {
+		if c.ProxyBasicAuth["username"] == "" {
+			return ErrNoBasicAuthUsername
+		}
+		password := c.ProxyBasicAuth["password"]
+		passwordFile := c.ProxyBasicAuth["password_file"]
+		if password == "" && passwordFile == "" {
+			return ErrNoBasicAuthPassword
+		}
+		if password != "" && passwordFile != "" {
+			return ErrTwoPasswords
+		}
+	}
+
+	// Check that the certificates and keys are either files or PEM data.
+	for _, name := range []string{"ca", "cert", "key"} {
+		if c.TLSConfig[name+"_file"] != "" && c.TLSConfig[name+"_pem"] != "" {
+			return ErrTwoTLSSources
+		}
+	}
+
 	// Verify that provided quantiles are between 0 and 1.
 	if c.Quantiles != nil {
 		for _, quantile := range c.Quantiles {
@@ -98,9 +245,40 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.MaxSeriesPerMetric < 0 {
+		return ErrInvalidMaxSeriesPerMetric
+	}
+	if c.QueueSize < 0 {
+		return ErrInvalidQueueSize
+	}
+	if c.MaxRedirects < 0 {
+		return ErrInvalidMaxRedirects
+	}
+	if c.ResolveInterval < 0 {
+		return ErrInvalidResolveInterval
+	}
+
+	switch c.Format {
+	case "", FormatRemoteWrite, FormatVictoriaMetricsImport:
+	default:
+		return ErrInvalidFormat
+	}
+	switch c.Compression {
+	case "", CompressionSnappy, CompressionZstd:
+	default:
+		return ErrInvalidCompression
+	}
+	if !prometheusremotewrite.LabelConflictPolicy(c.LabelConflictPolicy).Valid() {
+		return ErrInvalidLabelConflictPolicy
+	}
+
 	// Add default values for missing properties.
 	if c.Endpoint == "" {
-		c.Endpoint = "/api/prom/push"
+		if c.Format == FormatVictoriaMetricsImport {
+			c.Endpoint = "/api/v1/import"
+		} else {
+			c.Endpoint = "/api/prom/push"
+		}
 	}
 	if c.RemoteTimeout == 0 {
 		c.RemoteTimeout = 30 * time.Second
@@ -112,6 +290,14 @@ func (c *Config) Validate() error {
 	if c.Quantiles == nil {
 		c.Quantiles = []float64{0.5, 0.9, 0.95, 0.99}
 	}
+	// The SRV records are resolved again every 30s by default.
+	if c.SRVName != "" && c.ResolveInterval == 0 {
+		c.ResolveInterval = 30 * time.Second
+	}
+	// Unchanged series are sent again every 5m by default.
+	if c.SuppressUnchanged && c.HeartbeatInterval == 0 {
+		c.HeartbeatInterval = 5 * time.Minute
+	}
 
 	return nil
 }
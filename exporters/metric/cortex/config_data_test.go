@@ -15,6 +15,7 @@
 package cortex_test
 
 import (
+	"net/url"
 	"time"
 
 	"go.opentelemetry.io/contrib/exporters/metric/cortex"
@@ -96,6 +97,29 @@ var exampleTwoBearerTokenConfig = cortex.Config{
 	BearerTokenFile: "bearer_token_file",
 }
 
+// Example Config struct with both a CA file and CA PEM data.
+var exampleTwoCAConfig = cortex.Config{
+	Endpoint:      "/api/prom/push",
+	Name:          "Config",
+	RemoteTimeout: 30 * time.Second,
+	PushInterval:  10 * time.Second,
+	TLSConfig: map[string]string{
+		"ca_file": "cafile",
+		"ca_pem":  "-----BEGIN CERTIFICATE-----",
+	},
+}
+
+// Example Config struct with proxy basic authentication without a password.
+var exampleNoProxyPasswordConfig = cortex.Config{
+	Endpoint:      "/api/prom/push",
+	Name:          "Config",
+	RemoteTimeout: 30 * time.Second,
+	PushInterval:  10 * time.Second,
+	ProxyBasicAuth: map[string]string{
+		"username": "user",
+	},
+}
+
 // Example Config struct with two passwords.
 var exampleTwoPasswordConfig = cortex.Config{
 	Endpoint:      "/api/prom/push",
@@ -144,6 +168,30 @@ var exampleNoUsernameConfig = cortex.Config{
 	},
 }
 
+// Example Config struct with both a username and a username file for basic
+// authentication.
+var exampleTwoUsernameConfig = cortex.Config{
+	Endpoint:      "/api/prom/push",
+	Name:          "Config",
+	RemoteTimeout: 30 * time.Second,
+	PushInterval:  10 * time.Second,
+	BasicAuth: map[string]string{
+		"username":      "user",
+		"username_file": "usernameFile",
+		"password":      "password",
+	},
+}
+
+// Example Config struct with both a proxy URL and a proxy from the environment.
+var exampleTwoProxiesConfig = cortex.Config{
+	Endpoint:             "/api/prom/push",
+	Name:                 "Config",
+	RemoteTimeout:        30 * time.Second,
+	PushInterval:         10 * time.Second,
+	ProxyURL:             &url.URL{Scheme: "http", Host: "proxy:8080"},
+	ProxyFromEnvironment: true,
+}
+
 // Example Config struct with invalid quantiles.
 var exampleInvalidQuantilesConfig = cortex.Config{
 	Endpoint:      "/api/prom/push",
@@ -55,6 +55,18 @@ func TestValidate(t *testing.T) {
 			expectedConfig: nil,
 			expectedError:  cortex.ErrNoBasicAuthUsername,
 		},
+		{
+			testName:       "Config with Conflicting Usernames",
+			config:         &exampleTwoUsernameConfig,
+			expectedConfig: nil,
+			expectedError:  cortex.ErrTwoUsernames,
+		},
+		{
+			testName:       "Config with both a Proxy URL and a Proxy from the Environment",
+			config:         &exampleTwoProxiesConfig,
+			expectedConfig: nil,
+			expectedError:  cortex.ErrTwoProxies,
+		},
 		{
 			testName:       "Config with Custom Timeout",
 			config:         &exampleRemoteTimeoutConfig,
@@ -91,6 +103,18 @@ func TestValidate(t *testing.T) {
 			expectedConfig: nil,
 			expectedError:  cortex.ErrConflictingAuthorization,
 		},
+		{
+			testName:       "Config with no Proxy Password",
+			config:         &exampleNoProxyPasswordConfig,
+			expectedConfig: nil,
+			expectedError:  cortex.ErrNoBasicAuthPassword,
+		},
+		{
+			testName:       "Config with both a CA file and CA PEM data",
+			config:         &exampleTwoCAConfig,
+			expectedConfig: nil,
+			expectedError:  cortex.ErrTwoTLSSources,
+		},
 		{
 			testName:       "Config with Invalid Quantiles",
 			config:         &exampleInvalidQuantilesConfig,
@@ -17,39 +17,62 @@ package cortex
 import (
 	"bytes"
 	"context"
-	"fmt"
-	"log"
+	"errors"
 	"net/http"
-	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 	"github.com/prometheus/prometheus/prompb"
 
-	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/contrib/exporters/metric/cortex/prometheusremotewrite"
 	apimetric "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/global"
-	"go.opentelemetry.io/otel/metric/number"
 	"go.opentelemetry.io/otel/sdk/export/metric"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
-	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
 	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Exporter forwards metrics to a Cortex instance
 type Exporter struct {
 	config Config
-}
-
-type exportData struct {
-	export.Record
 
-	Resource *resource.Resource
+	// bufferMu guards the buffers reused by buildMessage. It is held while
+	// a message built in them is sent.
+	bufferMu sync.Mutex
+	// marshalBuffer and compressBuffer keep the largest protobuf and
+	// Snappy buffers of the previous pushes.
+	marshalBuffer  []byte
+	compressBuffer []byte
+	// zstdEncoder compresses the messages when Config.Compression is
+	// CompressionZstd. It is created with the first message.
+	zstdEncoder *zstd.Encoder
+
+	// series is the state of the series of the pushes without a tenant set by
+	// ContextWithTenant.
+	series seriesState
+	// tenantsMu guards tenants.
+	tenantsMu sync.Mutex
+	// tenants is the state of the series of the pushes of every tenant set by
+	// ContextWithTenant, as the tenants have distinct series.
+	tenants map[string]*seriesState
+	// worker sends the requests queued by Export when Config.QueueSize is
+	// set.
+	worker *exportWorker
+	// stats are the statistics returned by Stats.
+	stats exportStats
+	// headersFile caches the headers of Config.HeadersFile.
+	headersFile headersFile
 }
 
 // ExportKindFor returns CumulativeExporter so the Processor correctly aggregates data
@@ -57,31 +80,136 @@ func (e *Exporter) ExportKindFor(*apimetric.Descriptor, aggregation.Kind) metric
 	return metric.CumulativeExportKind
 }
 
-// Export forwards metrics to Cortex from the SDK
-func (e *Exporter) Export(_ context.Context, res *resource.Resource, checkpointSet metric.InstrumentationLibraryReader) error {
-	timeseries, err := e.ConvertToTimeSeries(res, checkpointSet)
-	if err != nil {
-		return err
+// Export forwards metrics to Cortex from the SDK. With the Remote Write format, the
+// time series are encoded as soon as they are converted, without keeping them all in
+// memory. When Config.QueueSize is set, Export only queues the request, which is sent
+// by the worker of the Exporter. The metrics are pushed to the tenant set by
+// ContextWithTenant in ctx, if any.
+func (e *Exporter) Export(ctx context.Context, res *resource.Resource, checkpointSet metric.InstrumentationLibraryReader) error {
+	e.bufferMu.Lock()
+	defer e.bufferMu.Unlock()
+
+	var message pushMessage
+	message.tenant, _ = TenantFromContext(ctx)
+	state := e.seriesState(message.tenant)
+	if e.config.Format == FormatVictoriaMetricsImport {
+		timeseries, err := e.convertToTimeSeries(state, res, checkpointSet)
+		if err != nil {
+			return err
+		}
+		e.logger().V(1).Info("Exporting time series", "timeseries", timeseries)
+		e.stats.exported(len(timeseries))
+
+		message.body, err = buildImportMessage(timeseries)
+		if err != nil {
+			return err
+		}
+		for _, ts := range timeseries {
+			message.samples += len(ts.Samples)
+		}
+	} else {
+		var err error
+		message.body, message.samples, err = e.encodeMessage(state, res, checkpointSet)
+		if err != nil {
+			return err
+		}
 	}
 
-	message, buildMessageErr := e.buildMessage(timeseries)
-	if buildMessageErr != nil {
-		return buildMessageErr
+	if e.worker != nil {
+		// The message is sent after the buffers are reused.
+		message.body = append([]byte(nil), message.body...)
+		return e.worker.enqueue(message)
 	}
+	return e.send(ctx, message)
+}
+
+// pushMessage is a message built by Export, the number of samples it contains, and
+// the tenant of the context of Export, kept for the worker.
+type pushMessage struct {
+	body    []byte
+	samples int
+	tenant  string
+}
 
-	request, buildRequestErr := e.buildRequest(message)
+// send sends a message built by Export, in a client span started from ctx when
+// Config.TracerProvider is set, and calls Config.PushCallback with its PushSummary.
+func (e *Exporter) send(ctx context.Context, message pushMessage) error {
+	start := time.Now()
+	if message.tenant != "" {
+		ctx = ContextWithTenant(ctx, message.tenant)
+	}
+	err := e.sendMessage(ctx, message.body)
+	if e.config.PushCallback != nil {
+		e.config.PushCallback(PushSummary{
+			Samples:  message.samples,
+			Bytes:    len(message.body),
+			Duration: time.Since(start),
+			Err:      err,
+		})
+	}
+	return err
+}
+
+// sendMessage sends the body of a message built by Export.
+func (e *Exporter) sendMessage(ctx context.Context, message []byte) error {
+	ctx, span := e.startPushSpan(ctx, len(message))
+	request, buildRequestErr := e.buildRequest(ctx, message)
 	if buildRequestErr != nil {
+		endPushSpan(span, buildRequestErr)
 		return buildRequestErr
 	}
 
 	sendRequestErr := e.sendRequest(request)
+	e.stats.sent(len(message), sendRequestErr)
+	if e.rejectedZstd(sendRequestErr) {
+		// The endpoint does not support Zstandard. The message is sent again
+		// with the Snappy compression required by the Remote Write API.
+		e.logger().Info("Compression not supported by the endpoint, sending the request with Snappy", "compression", CompressionZstd)
+		sendRequestErr = e.sendSnappy(ctx, message)
+	}
+	endPushSpan(span, sendRequestErr)
 	if sendRequestErr != nil {
+		// Send the skipped series again with the next push.
+		tenant, _ := TenantFromContext(ctx)
+		e.seriesState(tenant).unchanged.reset()
 		return sendRequestErr
 	}
 
 	return nil
 }
 
+// rejectedZstd returns whether err is the 415 Unsupported Media Type response to
+// a request compressed with Zstandard.
+func (e *Exporter) rejectedZstd(err error) bool {
+	var statusErr *statusError
+	return e.config.Format != FormatVictoriaMetricsImport && e.compression() == CompressionZstd &&
+		errors.As(err, &statusErr) && statusErr.code == http.StatusUnsupportedMediaType
+}
+
+// sendSnappy sends a message compressed with Zstandard again, compressed with
+// Snappy.
+func (e *Exporter) sendSnappy(ctx context.Context, message []byte) error {
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return err
+	}
+	defer decoder.Close()
+	decoded, err := decoder.DecodeAll(message, nil)
+	if err != nil {
+		return err
+	}
+	message = snappy.Encode(nil, decoded)
+
+	request, err := e.buildRequest(ctx, message)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Encoding", CompressionSnappy)
+	err = e.sendRequest(request)
+	e.stats.sent(len(message), err)
+	return err
+}
+
 // NewRawExporter validates the Config struct and creates an Exporter with it.
 func NewRawExporter(config Config) (*Exporter, error) {
 	// This is redundant when the user creates the Config struct with the NewConfig
@@ -90,8 +218,11 @@ func NewRawExporter(config Config) (*Exporter, error) {
 		return nil, err
 	}
 
-	exporter := Exporter{config}
-	return &exporter, nil
+	exporter := &Exporter{config: config}
+	if config.QueueSize > 0 {
+		exporter.worker = newExportWorker(exporter, config.QueueSize, config.DrainOnShutdown)
+	}
+	return exporter, nil
 }
 
 // NewExportPipeline sets up a complete export pipeline with a push Controller and
@@ -125,302 +256,352 @@ func InstallNewPipeline(config Config, options ...controller.Option) (*controlle
 	return cont, nil
 }
 
-// ConvertToTimeSeries converts a InstrumentationLibraryReader to a slice of TimeSeries pointers
-// Based on the aggregation type, ConvertToTimeSeries will call helper functions like
-// convertFromSum to generate the correct number of TimeSeries.
+// ConvertToTimeSeries converts a InstrumentationLibraryReader to a slice of TimeSeries
+// with the prometheusremotewrite package. When Config.SuppressUnchanged is set, the
+// last-value records whose value did not change since the previous call are skipped
+// until Config.HeartbeatInterval elapsed. When Config.MaxSeriesPerMetric is set, the
+// series of a metric over it are aggregated into a series with the OverflowLabel, or
+// dropped when Config.DropOverflow is set. The UpMetricName series is added when
+// Config.SendUpSeries is set. The Config.WriteRelabelConfigs are applied to the
+// labels of the time series. The samples whose timestamp is not after
+// the timestamp of the previous sample of their series are skipped, as Cortex rejects
+// them.
 func (e *Exporter) ConvertToTimeSeries(res *resource.Resource, checkpointSet export.InstrumentationLibraryReader) ([]prompb.TimeSeries, error) {
-	var aggError error
-	var timeSeries []prompb.TimeSeries
-
-	// Iterate over each record in the checkpoint set and convert to TimeSeries
-	aggError = checkpointSet.ForEach(func(library instrumentation.Library, reader export.Reader) error {
-		return reader.ForEach(e, func(record metric.Record) error {
-			// Convert based on aggregation type
-			edata := exportData{
-				Resource: res,
-				Record:   record,
-			}
-			agg := record.Aggregation()
-
-			// The following section uses loose type checking to determine how to
-			// convert aggregations to timeseries. More "expensive" timeseries are
-			// checked first.
-			//
-			// See the Aggregator Kind for more information
-			// https://github.com/open-telemetry/opentelemetry-go/blob/main/sdk/export/metric/aggregation/aggregation.go#L123-L138
-			if histogram, ok := agg.(aggregation.Histogram); ok {
-				tSeries, err := convertFromHistogram(edata, histogram)
-				if err != nil {
-					return err
-				}
-				timeSeries = append(timeSeries, tSeries...)
-			} else if sum, ok := agg.(aggregation.Sum); ok {
-				tSeries, err := convertFromSum(edata, sum)
-				if err != nil {
-					return err
-				}
-				timeSeries = append(timeSeries, tSeries)
-				if minMaxSumCount, ok := agg.(aggregation.MinMaxSumCount); ok {
-					tSeries, err := convertFromMinMaxSumCount(edata, minMaxSumCount)
-					if err != nil {
-						return err
-					}
-					timeSeries = append(timeSeries, tSeries...)
-				}
-			} else if lastValue, ok := agg.(aggregation.LastValue); ok {
-				tSeries, err := convertFromLastValue(edata, lastValue)
-				if err != nil {
-					return err
-				}
-				timeSeries = append(timeSeries, tSeries)
-			} else {
-				// Report to the user when no conversion was found
-				fmt.Printf("No conversion found for record: %s\n", edata.Descriptor().Name())
-			}
+	return e.convertToTimeSeries(&e.series, res, checkpointSet)
+}
 
-			return nil
-		})
+// convertToTimeSeries converts a InstrumentationLibraryReader like
+// ConvertToTimeSeries, with the state of the series of a tenant.
+func (e *Exporter) convertToTimeSeries(state *seriesState, res *resource.Resource, checkpointSet export.InstrumentationLibraryReader) ([]prompb.TimeSeries, error) {
+	var timeseries []prompb.TimeSeries
+	err := e.forEachTimeSeries(state, res, checkpointSet, func(ts prompb.TimeSeries) error {
+		timeseries = append(timeseries, ts)
+		return nil
 	})
-
-	// Check if error was returned in checkpointSet.ForEach()
-	if aggError != nil {
-		return nil, aggError
+	if err != nil {
+		return nil, err
 	}
-
-	return timeSeries, nil
+	return timeseries, nil
 }
 
-// createTimeSeries is a helper function to create a timeseries from a value and attributes
-func createTimeSeries(edata exportData, value number.Number, valueNumberKind number.Kind, extraAttributes ...attribute.KeyValue) prompb.TimeSeries {
-	sample := prompb.Sample{
-		Value:     value.CoerceToFloat64(valueNumberKind),
-		Timestamp: int64(time.Nanosecond) * edata.EndTime().UnixNano() / int64(time.Millisecond),
+// conversionOptions returns the options of the prometheusremotewrite conversions.
+func (e *Exporter) conversionOptions() []prometheusremotewrite.Option {
+	opts := []prometheusremotewrite.Option{
+		prometheusremotewrite.WithExportKindSelector(e),
+		prometheusremotewrite.WithLogger(e.config.Logger),
 	}
-
-	attributes := createLabelSet(edata, extraAttributes...)
-
-	return prompb.TimeSeries{
-		Samples: []prompb.Sample{sample},
-		Labels:  attributes,
+	if e.config.PrometheusNaming {
+		opts = append(opts, prometheusremotewrite.WithPrometheusNaming())
+	}
+	if e.config.LabelConflictPolicy != "" {
+		opts = append(opts, prometheusremotewrite.WithLabelConflictPolicy(prometheusremotewrite.LabelConflictPolicy(e.config.LabelConflictPolicy)))
+	}
+	if e.config.RenamedLabelPrefix != "" {
+		opts = append(opts, prometheusremotewrite.WithRenamedLabelPrefix(e.config.RenamedLabelPrefix))
 	}
+	return opts
 }
 
-// convertFromSum returns a single TimeSeries based on a Record with a Sum aggregation
-func convertFromSum(edata exportData, sum aggregation.Sum) (prompb.TimeSeries, error) {
-	// Get Sum value
-	value, err := sum.Sum()
-	if err != nil {
-		return prompb.TimeSeries{}, err
+// forEachTimeSeries converts a InstrumentationLibraryReader like ConvertToTimeSeries,
+// calling timeSeriesFunc with every TimeSeries. Only the overflow time series are
+// kept in memory, until all the records are converted. The unchanged records, the
+// series over the cardinality limit, and the out of order samples are tracked in state.
+func (e *Exporter) forEachTimeSeries(state *seriesState, res *resource.Resource, checkpointSet export.InstrumentationLibraryReader, timeSeriesFunc func(prompb.TimeSeries) error) error {
+	if e.config.SuppressUnchanged {
+		checkpointSet = state.unchanged.reader(checkpointSet, e.config.HeartbeatInterval)
+	}
+	opts := e.conversionOptions()
+	var limited *limitedLibraryReader
+	if e.config.MaxSeriesPerMetric > 0 {
+		limited = state.cardinality.reader(checkpointSet, e.config.MaxSeriesPerMetric, e.config.DropOverflow, opts)
+		checkpointSet = limited
+	}
+
+	push := state.timestamps.start()
+	guarded := func(ts prompb.TimeSeries) error {
+		if len(e.config.WriteRelabelConfigs) > 0 && !relabelTimeSeries(&ts, e.config.WriteRelabelConfigs) {
+			return nil
+		}
+		if ts, ok := push.guard(ts); ok {
+			return timeSeriesFunc(ts)
+		}
+		return nil
+	}
+	err := prometheusremotewrite.ForEachTimeSeries(
+		res,
+		checkpointSet,
+		func(ts prompb.TimeSeries) error {
+			if limited != nil && limited.add(ts) {
+				return nil
+			}
+			return guarded(ts)
+		},
+		opts...,
+	)
+	if err == nil && limited != nil {
+		for _, ts := range limited.overflowSeries {
+			if err = guarded(ts); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil && e.config.SendUpSeries {
+		err = guarded(e.upTimeSeries(res))
+	}
+	if skipped := push.end(); skipped > 0 {
+		e.logger().Info("Skipped out of order samples", "count", skipped)
 	}
+	return err
+}
 
-	// Create TimeSeries. Note that Cortex requires the name attribute to be in the format
-	// "__name__". This is the case for all time series created by this exporter.
-	name := sanitize(edata.Descriptor().Name())
-	numberKind := edata.Descriptor().NumberKind()
-	tSeries := createTimeSeries(edata, value, numberKind, attribute.String("__name__", name))
+// DroppedSeries returns the number of times a series was dropped because its metric
+// had Config.MaxSeriesPerMetric series, when Config.DropOverflow is set.
+func (e *Exporter) DroppedSeries() uint64 {
+	var dropped uint64
+	e.forEachSeriesState(func(state *seriesState) {
+		dropped += state.cardinality.droppedSeries()
+	})
+	return dropped
+}
 
-	return tSeries, nil
+// OutOfOrderSamples returns the number of samples skipped because their timestamp was
+// not after the timestamp of the previous sample of their series.
+func (e *Exporter) OutOfOrderSamples() uint64 {
+	var skipped uint64
+	e.forEachSeriesState(func(state *seriesState) {
+		skipped += state.timestamps.skippedSamples()
+	})
+	return skipped
 }
 
-// convertFromLastValue returns a single TimeSeries based on a Record with a LastValue aggregation
-func convertFromLastValue(edata exportData, lastValue aggregation.LastValue) (prompb.TimeSeries, error) {
-	// Get value
-	value, _, err := lastValue.LastValue()
-	if err != nil {
-		return prompb.TimeSeries{}, err
+// logger returns the Logger of the Config, or a logger discarding everything when
+// it is nil.
+func (e *Exporter) logger() logr.Logger {
+	if e.config.Logger == nil {
+		return logr.Discard()
 	}
+	return e.config.Logger
+}
 
-	// Create TimeSeries
-	name := sanitize(edata.Descriptor().Name())
-	numberKind := edata.Descriptor().NumberKind()
-	tSeries := createTimeSeries(edata, value, numberKind, attribute.String("__name__", name))
+// addHeaders adds required headers, an Authorization header, and all headers in the
+// Config Headers map to a http request.
+func (e *Exporter) addHeaders(req *http.Request) error {
+	if e.config.Format == FormatVictoriaMetricsImport {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		// Cortex expects Snappy-compressed protobuf messages. These three headers are
+		// hard-coded as they should be on every request, except for the encoding of
+		// the messages compressed with Zstandard.
+		req.Header.Add("X-Prometheus-Remote-Write-Version", "0.1.0")
+		req.Header.Add("Content-Encoding", e.compression())
+		req.Header.Set("Content-Type", "application/x-protobuf")
+	}
 
-	return tSeries, nil
+	return e.addConfigHeaders(req)
 }
 
-// convertFromMinMaxSumCount returns 4 TimeSeries for the min, max, sum, and count from the mmsc aggregation
-func convertFromMinMaxSumCount(edata exportData, minMaxSumCount aggregation.MinMaxSumCount) ([]prompb.TimeSeries, error) {
-	numberKind := edata.Descriptor().NumberKind()
-
-	// Convert Min
-	min, err := minMaxSumCount.Min()
-	if err != nil {
-		return nil, err
+// addConfigHeaders adds all the headers of the Config Headers and HeaderValues maps and
+// of the headers file to a http request, and an Authorization header and the
+// X-Scope-OrgID header of the Config Tenant if they do not contain them. The tenant
+// set by ContextWithTenant in the context of the request replaces both.
+func (e *Exporter) addConfigHeaders(req *http.Request) error {
+	var fileHeaders map[string][]string
+	if e.config.HeadersFile != "" {
+		var err error
+		if fileHeaders, err = e.headersFile.read(e.config.HeadersFile); err != nil {
+			return err
+		}
 	}
-	name := sanitize(edata.Descriptor().Name() + "_min")
-	minTimeSeries := createTimeSeries(edata, min, numberKind, attribute.String("__name__", name))
 
-	// Convert Max
-	max, err := minMaxSumCount.Max()
-	if err != nil {
-		return nil, err
+	// Add all user-supplied headers to the request.
+	for name, field := range e.config.Headers {
+		req.Header.Add(name, field)
 	}
-	name = sanitize(edata.Descriptor().Name() + "_max")
-	maxTimeSeries := createTimeSeries(edata, max, numberKind, attribute.String("__name__", name))
+	addHeaderValues(req, e.config.HeaderValues)
+	addHeaderValues(req, fileHeaders)
 
-	// Convert Count
-	count, err := minMaxSumCount.Count()
-	if err != nil {
-		return nil, err
+	if !setContextTenant(req) && e.config.Tenant != "" && !e.hasHeader(TenantHeader, fileHeaders) {
+		req.Header.Set(TenantHeader, e.config.Tenant)
 	}
-	name = sanitize(edata.Descriptor().Name() + "_count")
-	countTimeSeries := createTimeSeries(edata, number.NewInt64Number(int64(count)), number.Int64Kind, attribute.String("__name__", name))
 
-	// Return all timeSeries
-	tSeries := []prompb.TimeSeries{
-		minTimeSeries, maxTimeSeries, countTimeSeries,
+	// Add Authorization header if it wasn't already set.
+	if !e.hasHeader("Authorization", fileHeaders) {
+		if err := e.addBearerTokenAuth(req); err != nil {
+			return err
+		}
+		if err := e.addBasicAuth(req); err != nil {
+			return err
+		}
 	}
 
-	return tSeries, nil
+	return nil
 }
 
-// convertFromHistogram returns len(histogram.Buckets) timeseries for a histogram aggregation
-func convertFromHistogram(edata exportData, histogram aggregation.Histogram) ([]prompb.TimeSeries, error) {
-	var timeSeries []prompb.TimeSeries
-	metricName := sanitize(edata.Descriptor().Name())
-	numberKind := edata.Descriptor().NumberKind()
-
-	// Create Sum TimeSeries
-	sum, err := histogram.Sum()
-	if err != nil {
-		return nil, err
+// addHeaderValues adds the values of headers to a http request, with the header names
+// as they are in headers.
+func addHeaderValues(req *http.Request, headers map[string][]string) {
+	for name, values := range headers {
+		// Move the values of the header already set with another casing, such as
+		// the canonical Content-Type, to the configured name.
+		for key, existing := range req.Header {
+			if key != name && strings.EqualFold(key, name) {
+				req.Header[name] = append(req.Header[name], existing...)
+				delete(req.Header, key)
+			}
+		}
+		req.Header[name] = append(req.Header[name], values...)
 	}
-	sumTimeSeries := createTimeSeries(edata, sum, numberKind, attribute.String("__name__", metricName+"_sum"))
-	timeSeries = append(timeSeries, sumTimeSeries)
+}
 
-	// Handle Histogram buckets
-	buckets, err := histogram.Histogram()
-	if err != nil {
-		return nil, err
+// hasHeader returns whether the Config Headers or HeaderValues maps or the headers of
+// the headers file contain a header, ignoring the case of its name.
+func (e *Exporter) hasHeader(name string, fileHeaders map[string][]string) bool {
+	for key := range e.config.Headers {
+		if strings.EqualFold(key, name) {
+			return true
+		}
 	}
-
-	var totalCount float64
-	// counts maps from the bucket upper-bound to the cumulative count.
-	// The bucket with upper-bound +inf is not included.
-	counts := make(map[float64]float64, len(buckets.Boundaries))
-	for i, boundary := range buckets.Boundaries {
-		// Add bucket count to totalCount and record in map
-		totalCount += float64(buckets.Counts[i])
-		counts[boundary] = totalCount
-
-		// Add upper boundary as a attribute. e.g. {le="5"}
-		boundaryStr := strconv.FormatFloat(boundary, 'f', -1, 64)
-
-		// Create timeSeries and append
-		boundaryTimeSeries := createTimeSeries(edata, number.NewFloat64Number(totalCount), number.Float64Kind, attribute.String("__name__", metricName), attribute.String("le", boundaryStr))
-		timeSeries = append(timeSeries, boundaryTimeSeries)
+	for _, headers := range []map[string][]string{e.config.HeaderValues, fileHeaders} {
+		for key := range headers {
+			if strings.EqualFold(key, name) {
+				return true
+			}
+		}
 	}
-
-	// Include the +inf boundary in the total count
-	totalCount += float64(buckets.Counts[len(buckets.Counts)-1])
-
-	// Create a timeSeries for the +inf bucket and total count
-	// These are the same and are both required by Prometheus-based backends
-
-	upperBoundTimeSeries := createTimeSeries(edata, number.NewFloat64Number(totalCount), number.Float64Kind, attribute.String("__name__", metricName), attribute.String("le", "+inf"))
-
-	countTimeSeries := createTimeSeries(edata, number.NewFloat64Number(totalCount), number.Float64Kind, attribute.String("__name__", metricName+"_count"))
-
-	timeSeries = append(timeSeries, upperBoundTimeSeries)
-	timeSeries = append(timeSeries, countTimeSeries)
-
-	return timeSeries, nil
+	return false
 }
 
-// createLabelSet combines attributes from a Record, resource, and extra attributes to create a
-// slice of prompb.Label.
-func createLabelSet(edata exportData, extraAttributes ...attribute.KeyValue) []prompb.Label {
-	// Map ensure no duplicate label names.
-	labelMap := map[string]prompb.Label{}
+// buildMessage creates a compressed protobuf message from a slice of TimeSeries,
+// or JSON lines for the VictoriaMetrics import format. A protobuf message is built in
+// the buffers of the Exporter and is only valid until the next call.
+func (e *Exporter) buildMessage(timeseries []prompb.TimeSeries) ([]byte, error) {
+	if e.config.Format == FormatVictoriaMetricsImport {
+		return buildImportMessage(timeseries)
+	}
 
-	// mergeAttributes merges Record and Resource attributes into a single set, giving precedence
-	// to the record's attributes.
-	mi := attribute.NewMergeIterator(edata.Labels(), edata.Resource.Set())
-	for mi.Next() {
-		attribute := mi.Label()
-		key := string(attribute.Key)
-		labelMap[key] = prompb.Label{
-			Name:  sanitize(key),
-			Value: attribute.Value.Emit(),
+	message := e.marshalBuffer[:0]
+	for _, ts := range timeseries {
+		var err error
+		if message, err = appendField(message, timeSeriesField, &ts); err != nil {
+			return nil, err
 		}
 	}
+	e.marshalBuffer = message
+	return e.compress(message)
+}
 
-	// Add extra attributes created by the exporter like the metric name or attributes to
-	// represent histogram buckets.
-	for _, attribute := range extraAttributes {
-		// Ensure attribute doesn't exist. If it does, notify user that a user created attribute
-		// is being overwritten by a Prometheus reserved label (e.g. 'le' for histograms)
-		key := string(attribute.Key)
-		value := attribute.Value.AsString()
-		_, found := labelMap[key]
-		if found {
-			log.Printf("Attribute %s is overwritten. Check if Prometheus reserved labels are used.\n", key)
+// encodeMessage creates a compressed protobuf message from the time series
+// converted from a InstrumentationLibraryReader, encoding them as soon as they are
+// converted, and returns the number of samples of the message. The message is built
+// in the buffers of the Exporter and is only valid until the next call.
+func (e *Exporter) encodeMessage(state *seriesState, res *resource.Resource, checkpointSet export.InstrumentationLibraryReader) ([]byte, int, error) {
+	debug := e.logger().V(1)
+	message := e.marshalBuffer[:0]
+	var series, samples int
+	err := e.forEachTimeSeries(state, res, checkpointSet, func(ts prompb.TimeSeries) error {
+		if debug.Enabled() {
+			debug.Info("Exporting time series", "timeseries", ts)
 		}
-		labelMap[key] = prompb.Label{
-			Name:  key,
-			Value: value,
+		series++
+		samples += len(ts.Samples)
+		var err error
+		message, err = appendField(message, timeSeriesField, &ts)
+		return err
+	})
+	if err == nil && e.config.SendMetadata {
+		var metadata []prompb.MetricMetadata
+		metadata, err = prometheusremotewrite.ConvertToMetadata(checkpointSet, e.conversionOptions()...)
+		for i := 0; err == nil && i < len(metadata); i++ {
+			message, err = appendField(message, metadataField, &metadata[i])
 		}
 	}
-
-	// Create slice of labels from labelMap and return
-	res := make([]prompb.Label, 0, len(labelMap))
-	for _, lb := range labelMap {
-		res = append(res, lb)
+	e.marshalBuffer = message
+	if err != nil {
+		return nil, 0, err
 	}
-
-	return res
+	e.stats.exported(series)
+	compressed, err := e.compress(message)
+	return compressed, samples, err
 }
 
-// addHeaders adds required headers, an Authorization header, and all headers in the
-// Config Headers map to a http request.
-func (e *Exporter) addHeaders(req *http.Request) error {
-	// Cortex expects Snappy-compressed protobuf messages. These three headers are
-	// hard-coded as they should be on every request.
-	req.Header.Add("X-Prometheus-Remote-Write-Version", "0.1.0")
-	req.Header.Add("Content-Encoding", "snappy")
-	req.Header.Set("Content-Type", "application/x-protobuf")
-
-	// Add all user-supplied headers to the request.
-	for name, field := range e.config.Headers {
-		req.Header.Add(name, field)
+// compression returns the compression of the messages, Config.Compression or
+// CompressionSnappy when it is not set.
+func (e *Exporter) compression() string {
+	if e.config.Compression == "" {
+		return CompressionSnappy
 	}
+	return e.config.Compression
+}
 
-	// Add Authorization header if it wasn't already set.
-	if _, exists := e.config.Headers["Authorization"]; !exists {
-		if err := e.addBearerTokenAuth(req); err != nil {
-			return err
-		}
-		if err := e.addBasicAuth(req); err != nil {
-			return err
+// compress compresses message with Snappy, or with Zstandard when Config.Compression
+// is CompressionZstd, in the buffer of the Exporter, which is reused when it is large
+// enough.
+func (e *Exporter) compress(message []byte) ([]byte, error) {
+	if e.compression() == CompressionZstd {
+		if e.zstdEncoder == nil {
+			encoder, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+			if err != nil {
+				return nil, err
+			}
+			e.zstdEncoder = encoder
 		}
+		e.compressBuffer = e.zstdEncoder.EncodeAll(message, e.compressBuffer[:0])
+		return e.compressBuffer, nil
 	}
 
-	return nil
+	if maxLen := snappy.MaxEncodedLen(len(message)); len(e.compressBuffer) < maxLen {
+		e.compressBuffer = make([]byte, maxLen)
+	}
+	return snappy.Encode(e.compressBuffer, message), nil
 }
 
-// buildMessage creates a Snappy-compressed protobuf message from a slice of TimeSeries.
-func (e *Exporter) buildMessage(timeseries []prompb.TimeSeries) ([]byte, error) {
-	// Wrap the TimeSeries as a WriteRequest since Cortex requires it.
-	writeRequest := &prompb.WriteRequest{
-		Timeseries: timeseries,
+// The field numbers of the protobuf WriteRequest.
+const (
+	timeSeriesField = 1
+	metadataField   = 3
+)
+
+// protoMessage is a protobuf message generated by gogoproto.
+type protoMessage interface {
+	Size() int
+	MarshalToSizedBuffer([]byte) (int, error)
+}
+
+// appendField appends m to buffer, encoded as an element of a repeated field of a
+// protobuf WriteRequest. A WriteRequest is encoded as the concatenation of its
+// fields, so that the time series, which Cortex requires to be wrapped in a
+// WriteRequest, are encoded one at a time.
+func appendField(buffer []byte, field int, m protoMessage) ([]byte, error) {
+	size := m.Size()
+	// The fields have the length-delimited wire type.
+	buffer = append(buffer, byte(field<<3|2))
+	for v := uint64(size); ; v >>= 7 {
+		if v < 0x80 {
+			buffer = append(buffer, byte(v))
+			break
+		}
+		buffer = append(buffer, byte(v)|0x80)
 	}
 
-	// Convert the struct to a slice of bytes and then compress it.
-	message := make([]byte, writeRequest.Size())
-	written, err := writeRequest.MarshalToSizedBuffer(message)
-	if err != nil {
+	start := len(buffer)
+	if cap(buffer)-start < size {
+		// Grow the buffer like append does.
+		grown := make([]byte, start, 2*cap(buffer)+size)
+		copy(grown, buffer)
+		buffer = grown
+	}
+	buffer = buffer[:start+size]
+	if _, err := m.MarshalToSizedBuffer(buffer[start:]); err != nil {
 		return nil, err
 	}
-	message = message[:written]
-	compressed := snappy.Encode(nil, message)
-
-	return compressed, nil
+	return buffer, nil
 }
 
-// buildRequest creates an http POST request with a Snappy-compressed protocol buffer
-// message as the body and with all the headers attached.
-func (e *Exporter) buildRequest(message []byte) (*http.Request, error) {
-	req, err := http.NewRequest(
+// buildRequest creates an http POST request with a compressed protocol buffer
+// message as the body and with all the headers attached, including the tenant of ctx.
+func (e *Exporter) buildRequest(ctx context.Context, message []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
 		e.config.Endpoint,
 		bytes.NewBuffer(message),
@@ -428,6 +609,7 @@ func (e *Exporter) buildRequest(message []byte) (*http.Request, error) {
 	if err != nil {
 		return nil, err
 	}
+	addExtraLabels(req.URL, e.config.ExtraLabels)
 
 	// Add the required headers and the headers from Config.Headers.
 	err = e.addHeaders(req)
@@ -455,10 +637,23 @@ func (e *Exporter) sendRequest(req *http.Request) error {
 		return err
 	}
 	defer res.Body.Close()
+	trace.SpanFromContext(req.Context()).SetAttributes(semconv.HTTPStatusCodeKey.Int(res.StatusCode))
 
-	// The response should have a status code of 200.
-	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("%v", res.Status)
+	// The response should have a 2xx status code, e.g. 204 No Content from the
+	// VictoriaMetrics import endpoint.
+	if res.StatusCode/100 != 2 {
+		return &statusError{code: res.StatusCode, status: res.Status}
 	}
 	return nil
 }
+
+// statusError is the error of a request whose response does not have a 2xx
+// status code.
+type statusError struct {
+	code   int
+	status string
+}
+
+func (e *statusError) Error() string {
+	return e.status
+}
@@ -15,6 +15,7 @@
 package cortex
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -25,11 +26,13 @@ import (
 
 	"github.com/golang/snappy"
 	"github.com/google/go-cmp/cmp"
+	"github.com/klauspost/compress/zstd"
 	"github.com/prometheus/prometheus/prompb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/attribute"
+	apimetric "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/sdk/export/metric"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
@@ -164,6 +167,34 @@ func TestConvertToTimeSeries(t *testing.T) {
 	}
 }
 
+// TestConvertToTimeSeriesPrometheusNaming tests whether the names of the time series
+// follow the Prometheus naming conventions when PrometheusNaming is set.
+func TestConvertToTimeSeriesPrometheusNaming(t *testing.T) {
+	exporter := Exporter{config: Config{PrometheusNaming: true}}
+	timeseries, err := exporter.ConvertToTimeSeries(testResource, getSumReader(t, 1))
+	require.NoError(t, err)
+	require.Len(t, timeseries, 1)
+	assert.Contains(t, timeseries[0].Labels, prompb.Label{Name: "__name__", Value: "metric_sum_total"})
+}
+
+// TestConvertToTimeSeriesLabelConflictPolicy tests whether the record attributes
+// conflicting with the resource attributes are resolved with the LabelConflictPolicy.
+func TestConvertToTimeSeriesLabelConflictPolicy(t *testing.T) {
+	_, meter, cont := testMeter(t)
+	apimetric.Must(meter).NewInt64Counter("metric_sum").Add(context.Background(), 1, attribute.String("R", "record"))
+	require.NoError(t, cont.Collect(context.Background()))
+
+	exporter := Exporter{config: Config{LabelConflictPolicy: "rename", RenamedLabelPrefix: "otel_"}}
+	timeseries, err := exporter.ConvertToTimeSeries(testResource, cont)
+	require.NoError(t, err)
+	require.Len(t, timeseries, 1)
+	assert.Contains(t, timeseries[0].Labels, prompb.Label{Name: "R", Value: "V"})
+	assert.Contains(t, timeseries[0].Labels, prompb.Label{Name: "otel_R", Value: "record"})
+
+	config := Config{LabelConflictPolicy: "first_wins"}
+	require.Equal(t, ErrInvalidLabelConflictPolicy, config.Validate())
+}
+
 // TestNewRawExporter tests whether NewRawExporter successfully creates an Exporter with
 // the same Config struct as the one passed in.
 func TestNewRawExporter(t *testing.T) {
@@ -207,7 +238,7 @@ func TestAddHeaders(t *testing.T) {
 			"TestHeaderTwo": "TestFieldTwo",
 		},
 	}
-	exporter := Exporter{testConfig}
+	exporter := Exporter{config: testConfig}
 
 	// Create http request to add headers to.
 	req, err := http.NewRequest("POST", "test.com", nil)
@@ -224,10 +255,54 @@ func TestAddHeaders(t *testing.T) {
 	require.Equal(t, req.Header.Get("X-Prometheus-Remote-Write-Version"), "0.1.0")
 }
 
+// TestAddHeaderValues tests whether the headers of HeaderValues are added with all their
+// values and their names as configured.
+func TestAddHeaderValues(t *testing.T) {
+	exporter := Exporter{config: Config{
+		HeaderValues: map[string][]string{
+			"X-Scope-OrgID": {"tenant"},
+			"x-api-key":     {"first", "second"},
+			"content-type":  {"application/x-protobuf; proto=prometheus.WriteRequest"},
+			"authorization": {"Bearer token"},
+		},
+		BearerToken: "ignored",
+	}}
+
+	req, err := http.NewRequest("POST", "test.com", nil)
+	require.NoError(t, err)
+	require.NoError(t, exporter.addHeaders(req))
+
+	require.Equal(t, []string{"tenant"}, req.Header["X-Scope-OrgID"])
+	require.Equal(t, []string{"first", "second"}, req.Header["x-api-key"])
+	require.Equal(t, []string{"application/x-protobuf", "application/x-protobuf; proto=prometheus.WriteRequest"}, req.Header["content-type"])
+	require.NotContains(t, req.Header, "Content-Type")
+	require.Equal(t, []string{"Bearer token"}, req.Header["authorization"])
+	require.NotContains(t, req.Header, "Authorization")
+}
+
+// TestAddTenant tests whether the tenant is sent with the X-Scope-OrgID header, unless
+// the configured headers contain it.
+func TestAddTenant(t *testing.T) {
+	tenantHeader := func(config Config) []string {
+		req, err := http.NewRequest("POST", "test.com", nil)
+		require.NoError(t, err)
+		exporter := Exporter{config: config}
+		require.NoError(t, exporter.addHeaders(req))
+		return req.Header.Values(TenantHeader)
+	}
+
+	require.Equal(t, []string{"team-a"}, tenantHeader(Config{Tenant: "team-a"}))
+	require.Empty(t, tenantHeader(Config{}))
+	require.Equal(t, []string{"team-b"}, tenantHeader(Config{
+		Tenant:  "team-a",
+		Headers: map[string]string{"x-scope-orgid": "team-b"},
+	}))
+}
+
 // TestBuildMessage tests whether BuildMessage successfully returns a Snappy-compressed
 // protobuf message.
 func TestBuildMessage(t *testing.T) {
-	exporter := Exporter{validConfig}
+	exporter := Exporter{config: validConfig}
 	timeseries := []prompb.TimeSeries{}
 
 	// buildMessage returns the error that proto.Marshal() returns. Since the proto
@@ -237,15 +312,160 @@ func TestBuildMessage(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestBuildMessageReusesBuffers tests whether buildMessage reuses the buffers of
+// the previous messages while still returning valid messages.
+func TestBuildMessageReusesBuffers(t *testing.T) {
+	exporter := Exporter{config: validConfig}
+	newTimeSeries := func(n int) []prompb.TimeSeries {
+		timeseries := make([]prompb.TimeSeries, n)
+		for i := range timeseries {
+			timeseries[i] = prompb.TimeSeries{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "metric_" + strconv.Itoa(i)}},
+				Samples: []prompb.Sample{{Value: float64(i), Timestamp: int64(i)}},
+			}
+		}
+		return timeseries
+	}
+	decode := func(message []byte) prompb.WriteRequest {
+		uncompressed, err := snappy.Decode(nil, message)
+		require.NoError(t, err)
+		var writeRequest prompb.WriteRequest
+		require.NoError(t, writeRequest.Unmarshal(uncompressed))
+		return writeRequest
+	}
+
+	large := newTimeSeries(10)
+	message, err := exporter.buildMessage(large)
+	require.NoError(t, err)
+	assert.Equal(t, large, decode(message).Timeseries)
+	marshalBuffer, compressBuffer := &exporter.marshalBuffer[0], &exporter.compressBuffer[0]
+
+	small := newTimeSeries(2)
+	message, err = exporter.buildMessage(small)
+	require.NoError(t, err)
+	assert.Equal(t, small, decode(message).Timeseries)
+	assert.Same(t, marshalBuffer, &exporter.marshalBuffer[0])
+	assert.Same(t, compressBuffer, &exporter.compressBuffer[0])
+}
+
+// TestBuildMessageZstd tests whether buildMessage compresses the messages with
+// Zstandard when Config.Compression is CompressionZstd.
+func TestBuildMessageZstd(t *testing.T) {
+	config := validConfig
+	config.Compression = CompressionZstd
+	exporter := Exporter{config: config}
+	timeseries := []prompb.TimeSeries{{
+		Labels:  []prompb.Label{{Name: "__name__", Value: "metric"}},
+		Samples: []prompb.Sample{{Value: 1, Timestamp: 1}},
+	}}
+
+	message, err := exporter.buildMessage(timeseries)
+	require.NoError(t, err)
+	decoder, err := zstd.NewReader(nil)
+	require.NoError(t, err)
+	defer decoder.Close()
+	uncompressed, err := decoder.DecodeAll(message, nil)
+	require.NoError(t, err)
+	var writeRequest prompb.WriteRequest
+	require.NoError(t, writeRequest.Unmarshal(uncompressed))
+	assert.Equal(t, timeseries, writeRequest.Timeseries)
+
+	req, err := exporter.buildRequest(context.Background(), message)
+	require.NoError(t, err)
+	assert.Equal(t, "zstd", req.Header.Get("Content-Encoding"))
+}
+
+// TestExportZstdUnsupported tests whether a request compressed with Zstandard is sent
+// again with Snappy when the endpoint responds 415 Unsupported Media Type, and only
+// then.
+func TestExportZstdUnsupported(t *testing.T) {
+	for _, compression := range []string{CompressionZstd, CompressionSnappy} {
+		t.Run(compression, func(t *testing.T) {
+			var encodings []string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				encoding := req.Header.Get("Content-Encoding")
+				encodings = append(encodings, encoding)
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					rw.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if _, err := snappy.Decode(nil, body); encoding != "snappy" || err != nil || compression == CompressionSnappy {
+					rw.WriteHeader(http.StatusUnsupportedMediaType)
+					return
+				}
+				rw.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			exporter, err := NewRawExporter(Config{Endpoint: server.URL, Compression: compression})
+			require.NoError(t, err)
+
+			err = exporter.Export(context.Background(), testResource, getSumReader(t, 1))
+			stats := exporter.Stats()
+			if compression == CompressionSnappy {
+				assert.EqualError(t, err, "415 Unsupported Media Type")
+				assert.Equal(t, []string{"snappy"}, encodings)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"zstd", "snappy"}, encodings)
+			assert.Equal(t, uint64(2), stats.Pushes)
+			assert.Equal(t, uint64(1), stats.FailedPushes)
+		})
+	}
+}
+
+func TestInvalidCompression(t *testing.T) {
+	config := Config{Compression: "gzip"}
+	require.Equal(t, ErrInvalidCompression, config.Validate())
+}
+
+// TestEncodeMessage tests whether encodeMessage encodes the converted time series as
+// a Snappy-compressed WriteRequest.
+func TestEncodeMessage(t *testing.T) {
+	exporter := Exporter{config: validConfig}
+	message, _, err := exporter.encodeMessage(&exporter.series, testResource, getHistogramReader(t))
+	require.NoError(t, err)
+
+	uncompressed, err := snappy.Decode(nil, message)
+	require.NoError(t, err)
+	var writeRequest prompb.WriteRequest
+	require.NoError(t, writeRequest.Unmarshal(uncompressed))
+	assert.Len(t, writeRequest.Timeseries, len(wantHistogramTimeSeries))
+	for _, ts := range writeRequest.Timeseries {
+		assert.Len(t, ts.Samples, 1)
+		assert.NotEmpty(t, ts.Labels)
+	}
+}
+
+// TestEncodeMessageMetadata tests whether encodeMessage encodes the metadata of the
+// metric families when SendMetadata is set.
+func TestEncodeMessageMetadata(t *testing.T) {
+	exporter := Exporter{config: Config{SendMetadata: true}}
+	message, _, err := exporter.encodeMessage(&exporter.series, testResource, getHistogramReader(t))
+	require.NoError(t, err)
+
+	uncompressed, err := snappy.Decode(nil, message)
+	require.NoError(t, err)
+	var writeRequest prompb.WriteRequest
+	require.NoError(t, writeRequest.Unmarshal(uncompressed))
+	assert.Len(t, writeRequest.Timeseries, len(wantHistogramTimeSeries))
+	assert.Equal(t, []prompb.MetricMetadata{{
+		Type:             prompb.MetricMetadata_HISTOGRAM,
+		MetricFamilyName: "metric_histogram",
+	}}, writeRequest.Metadata)
+}
+
 // TestBuildRequest tests whether a http request is a POST request, has the correct body,
 // and has the correct headers.
 func TestBuildRequest(t *testing.T) {
 	// Make fake exporter and message for testing.
 	var testMessage = []byte(`Test Message`)
-	exporter := Exporter{validConfig}
+	exporter := Exporter{config: validConfig}
 
 	// Create the http request.
-	req, err := exporter.buildRequest(testMessage)
+	req, err := exporter.buildRequest(context.Background(), testMessage)
 	require.NoError(t, err)
 
 	// Verify the http method, url, and body.
@@ -370,7 +590,7 @@ func TestSendRequest(t *testing.T) {
 			test.config.Headers = map[string]string{
 				"isStatusNotFound": strconv.FormatBool(test.isStatusNotFound),
 			}
-			exporter := Exporter{*test.config}
+			exporter := Exporter{config: *test.config}
 
 			// Create a test TimeSeries struct.
 			timeSeries := []prompb.TimeSeries{
@@ -395,7 +615,7 @@ func TestSendRequest(t *testing.T) {
 			require.NoError(t, err)
 
 			// Create a http POST request with the compressed message.
-			req, err := exporter.buildRequest(msg)
+			req, err := exporter.buildRequest(context.Background(), msg)
 			require.NoError(t, err)
 
 			// Send the request to the test server and verify the error.
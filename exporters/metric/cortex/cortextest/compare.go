@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortextest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// Difference is a difference between the expected and actual time series found by
+// CompareTimeSeries.
+type Difference struct {
+	// Metric is the name of the metric of the series.
+	Metric string
+	// Series are the labels of the series, e.g. {__name__="requests",code="200"}.
+	Series string
+	// Field is the differing field of the series, e.g. "series" when the series
+	// is missing or unexpected, "samples", or "sample 2 value".
+	Field string
+	// Want and Got are the expected and actual values of the field.
+	Want, Got string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s: want %s, got %s", d.Series, d.Field, d.Want, d.Got)
+}
+
+type compareConfig struct {
+	epsilon          float64
+	ignoreTimestamps bool
+}
+
+// CompareOption configures CompareTimeSeries.
+type CompareOption interface {
+	applyCompare(*compareConfig)
+}
+
+type compareOptionFunc func(*compareConfig)
+
+func (fn compareOptionFunc) applyCompare(c *compareConfig) {
+	fn(c)
+}
+
+// WithEpsilon sets the tolerance of the comparison of the sample values, relative to
+// the expected value, or absolute when it is 0. By default the values must be equal.
+func WithEpsilon(epsilon float64) CompareOption {
+	return compareOptionFunc(func(c *compareConfig) {
+		c.epsilon = epsilon
+	})
+}
+
+// WithoutTimestamps ignores the timestamps of the samples, e.g. when the expected
+// series were not collected at the same time.
+func WithoutTimestamps() CompareOption {
+	return compareOptionFunc(func(c *compareConfig) {
+		c.ignoreTimestamps = true
+	})
+}
+
+// CompareTimeSeries compares the time series got, e.g. read back from Cortex, with
+// want, and returns their differences sorted by metric and series. The series are
+// matched by their labels, regardless of the order of the series and of their
+// labels. NaN values, e.g. staleness markers, are equal.
+func CompareTimeSeries(want, got []*prompb.TimeSeries, opts ...CompareOption) []Difference {
+	var c compareConfig
+	for _, opt := range opts {
+		opt.applyCompare(&c)
+	}
+
+	wantByKey := seriesByKey(want)
+	gotByKey := seriesByKey(got)
+	var diffs []Difference
+	for key, w := range wantByKey {
+		g, ok := gotByKey[key]
+		if !ok {
+			diffs = append(diffs, Difference{Metric: w.metric, Series: key, Field: "series", Want: "present", Got: "missing"})
+			continue
+		}
+		diffs = append(diffs, c.compareSamples(w, g, key)...)
+	}
+	for key, g := range gotByKey {
+		if _, ok := wantByKey[key]; !ok {
+			diffs = append(diffs, Difference{Metric: g.metric, Series: key, Field: "series", Want: "missing", Got: "present"})
+		}
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Metric != diffs[j].Metric {
+			return diffs[i].Metric < diffs[j].Metric
+		}
+		return diffs[i].Series < diffs[j].Series
+	})
+	return diffs
+}
+
+// DifferencesByMetric groups diffs by the name of their metric.
+func DifferencesByMetric(diffs []Difference) map[string][]Difference {
+	byMetric := make(map[string][]Difference)
+	for _, d := range diffs {
+		byMetric[d.Metric] = append(byMetric[d.Metric], d)
+	}
+	return byMetric
+}
+
+// series is a time series with the name of its metric.
+type series struct {
+	metric  string
+	samples []prompb.Sample
+}
+
+// seriesByKey returns the time series by their sorted labels. The samples of the
+// series with the same labels are merged.
+func seriesByKey(timeseries []*prompb.TimeSeries) map[string]series {
+	byKey := make(map[string]series, len(timeseries))
+	for _, ts := range timeseries {
+		labels := append([]prompb.Label(nil), ts.Labels...)
+		sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+
+		var metric string
+		pairs := make([]string, len(labels))
+		for i, l := range labels {
+			if l.Name == "__name__" {
+				metric = l.Value
+			}
+			pairs[i] = l.Name + "=" + strconv.Quote(l.Value)
+		}
+		key := "{" + strings.Join(pairs, ",") + "}"
+
+		s := byKey[key]
+		s.metric = metric
+		s.samples = append(s.samples, ts.Samples...)
+		byKey[key] = s
+	}
+	return byKey
+}
+
+// compareSamples returns the differences of the samples of the series key.
+func (c compareConfig) compareSamples(want, got series, key string) []Difference {
+	diff := func(field, w, g string) Difference {
+		return Difference{Metric: want.metric, Series: key, Field: field, Want: w, Got: g}
+	}
+	if len(want.samples) != len(got.samples) {
+		return []Difference{diff("samples", strconv.Itoa(len(want.samples)), strconv.Itoa(len(got.samples)))}
+	}
+
+	var diffs []Difference
+	for i, w := range want.samples {
+		g := got.samples[i]
+		if !c.equalValues(w.Value, g.Value) {
+			diffs = append(diffs, diff(fmt.Sprintf("sample %d value", i), formatValue(w.Value), formatValue(g.Value)))
+		}
+		if !c.ignoreTimestamps && w.Timestamp != g.Timestamp {
+			diffs = append(diffs, diff(fmt.Sprintf("sample %d timestamp", i), strconv.FormatInt(w.Timestamp, 10), strconv.FormatInt(g.Timestamp, 10)))
+		}
+	}
+	return diffs
+}
+
+// equalValues returns whether the sample values want and got are equal within the
+// epsilon.
+func (c compareConfig) equalValues(want, got float64) bool {
+	switch {
+	case math.IsNaN(want) || math.IsNaN(got):
+		return math.IsNaN(want) && math.IsNaN(got)
+	case want == got:
+		return true
+	case math.IsInf(want, 0) || math.IsInf(got, 0):
+		return false
+	case want == 0:
+		return math.Abs(got) <= c.epsilon
+	default:
+		return math.Abs(want-got) <= c.epsilon*math.Abs(want)
+	}
+}
+
+// formatValue formats a sample value as Prometheus does.
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortextest_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex/cortextest"
+)
+
+func timeSeries(value float64, labels ...string) *prompb.TimeSeries {
+	ts := &prompb.TimeSeries{Samples: []prompb.Sample{{Value: value, Timestamp: 1000}}}
+	for i := 0; i < len(labels); i += 2 {
+		ts.Labels = append(ts.Labels, prompb.Label{Name: labels[i], Value: labels[i+1]})
+	}
+	return ts
+}
+
+func TestCompareTimeSeries(t *testing.T) {
+	want := []*prompb.TimeSeries{
+		timeSeries(10, "__name__", "requests", "code", "200"),
+		timeSeries(0.1, "__name__", "latency", "le", "+inf"),
+		timeSeries(math.NaN(), "__name__", "stale"),
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		got := []*prompb.TimeSeries{
+			timeSeries(math.NaN(), "__name__", "stale"),
+			timeSeries(0.1, "le", "+inf", "__name__", "latency"),
+			timeSeries(10, "code", "200", "__name__", "requests"),
+		}
+		assert.Empty(t, cortextest.CompareTimeSeries(want, got))
+	})
+
+	t.Run("epsilon", func(t *testing.T) {
+		got := []*prompb.TimeSeries{
+			timeSeries(10.000001, "__name__", "requests", "code", "200"),
+			timeSeries(0.1000000001, "__name__", "latency", "le", "+inf"),
+			timeSeries(math.NaN(), "__name__", "stale"),
+		}
+		assert.Len(t, cortextest.CompareTimeSeries(want, got), 2)
+		assert.Empty(t, cortextest.CompareTimeSeries(want, got, cortextest.WithEpsilon(1e-6)))
+	})
+
+	t.Run("differences", func(t *testing.T) {
+		requests := timeSeries(11, "__name__", "requests", "code", "200")
+		requests.Samples[0].Timestamp = 2000
+		latency := timeSeries(0.1, "__name__", "latency", "le", "+inf")
+		latency.Samples = append(latency.Samples, prompb.Sample{Value: 0.2, Timestamp: 2000})
+		got := []*prompb.TimeSeries{
+			requests,
+			latency,
+			timeSeries(1, "__name__", "requests", "code", "500"),
+		}
+
+		diffs := cortextest.CompareTimeSeries(want, got)
+		assert.Equal(t, []cortextest.Difference{
+			{Metric: "latency", Series: `{__name__="latency",le="+inf"}`, Field: "samples", Want: "1", Got: "2"},
+			{Metric: "requests", Series: `{__name__="requests",code="200"}`, Field: "sample 0 value", Want: "10", Got: "11"},
+			{Metric: "requests", Series: `{__name__="requests",code="200"}`, Field: "sample 0 timestamp", Want: "1000", Got: "2000"},
+			{Metric: "requests", Series: `{__name__="requests",code="500"}`, Field: "series", Want: "missing", Got: "present"},
+			{Metric: "stale", Series: `{__name__="stale"}`, Field: "series", Want: "present", Got: "missing"},
+		}, diffs)
+		assert.Equal(t, `{__name__="requests",code="200"}: sample 0 value: want 10, got 11`, diffs[1].String())
+		assert.Len(t, cortextest.DifferencesByMetric(diffs)["requests"], 3)
+		assert.Len(t, cortextest.CompareTimeSeries(want, got, cortextest.WithoutTimestamps()), 4)
+	})
+}
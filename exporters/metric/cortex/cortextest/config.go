@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortextest
+
+import "time"
+
+// DefaultRemoteWriteVersion is the value of the X-Prometheus-Remote-Write-Version
+// header the Server expects by default.
+const DefaultRemoteWriteVersion = "0.1.0"
+
+type config struct {
+	tenants      map[string]bool
+	basicAuth    map[string]string
+	bearerTokens map[string]bool
+	sigV4        *sigV4Credentials
+	rateLimits   map[string]rateLimit
+	writeVersion string
+	now          func() time.Time
+}
+
+// rateLimit is the ingestion rate limit of a tenant.
+type rateLimit struct {
+	samplesPerSecond float64
+	burst            int
+}
+
+func newConfig(opts ...Option) config {
+	c := config{
+		writeVersion: DefaultRemoteWriteVersion,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// authRequired returns whether the requests must be authenticated.
+func (c config) authRequired() bool {
+	return len(c.basicAuth) > 0 || len(c.bearerTokens) > 0 || c.sigV4 != nil
+}
+
+// Option configures a Server.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithTenants sets the tenants accepted by the Server. The requests must then
+// identify one of them with the X-Scope-OrgID header, as with the multi-tenancy of
+// Cortex. By default the header is optional and any tenant is accepted.
+func WithTenants(tenants ...string) Option {
+	return optionFunc(func(c *config) {
+		if c.tenants == nil {
+			c.tenants = make(map[string]bool, len(tenants))
+		}
+		for _, tenant := range tenants {
+			c.tenants[tenant] = true
+		}
+	})
+}
+
+// WithBasicAuth accepts the requests authenticated with the basic authentication
+// credentials of username. When an authentication option is used, the requests not
+// authenticated by any of them are rejected with 401 Unauthorized.
+func WithBasicAuth(username, password string) Option {
+	return optionFunc(func(c *config) {
+		if c.basicAuth == nil {
+			c.basicAuth = make(map[string]string)
+		}
+		c.basicAuth[username] = password
+	})
+}
+
+// WithBearerToken accepts the requests authenticated with the bearer token.
+func WithBearerToken(token string) Option {
+	return optionFunc(func(c *config) {
+		if c.bearerTokens == nil {
+			c.bearerTokens = make(map[string]bool)
+		}
+		c.bearerTokens[token] = true
+	})
+}
+
+// WithSigV4 accepts the requests signed with AWS Signature Version 4 by the access
+// key for the region and service, e.g. "aps" for Amazon Managed Service for
+// Prometheus. The signatures are verified, including the hash of the body when the
+// X-Amz-Content-Sha256 header is not set, and must be dated within 5 minutes of the
+// time of the Server.
+func WithSigV4(region, service, accessKeyID, secretAccessKey string) Option {
+	return optionFunc(func(c *config) {
+		c.sigV4 = &sigV4Credentials{
+			region:          region,
+			service:         service,
+			accessKeyID:     accessKeyID,
+			secretAccessKey: secretAccessKey,
+		}
+	})
+}
+
+// WithRateLimit limits the samples ingested for tenant to samplesPerSecond, with
+// bursts of up to burst samples, as the ingestion rate limit of Cortex. The requests
+// over the limit are rejected with 429 Too Many Requests. The empty tenant limits the
+// requests without the X-Scope-OrgID header.
+func WithRateLimit(tenant string, samplesPerSecond float64, burst int) Option {
+	return optionFunc(func(c *config) {
+		if c.rateLimits == nil {
+			c.rateLimits = make(map[string]rateLimit)
+		}
+		c.rateLimits[tenant] = rateLimit{samplesPerSecond: samplesPerSecond, burst: burst}
+	})
+}
+
+// WithRemoteWriteVersion sets the value of the X-Prometheus-Remote-Write-Version
+// header the requests must have. The default is DefaultRemoteWriteVersion.
+func WithRemoteWriteVersion(version string) Option {
+	return optionFunc(func(c *config) {
+		c.writeVersion = version
+	})
+}
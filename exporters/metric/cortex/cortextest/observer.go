@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortextest
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+const instrumentationName = "go.opentelemetry.io/contrib/exporters/metric/cortex/cortextest"
+
+// Observer records the progress and results of a validation as metrics, so that
+// they can be exported with the Cortex exporter being validated, e.g. to follow the
+// validation runs on a dashboard.
+type Observer struct {
+	queries      metric.Int64Counter
+	queryLatency metric.Float64Histogram
+	compared     metric.Int64Counter
+	mismatches   metric.Int64Counter
+}
+
+// NewObserver returns an Observer recording the metrics with a meter of provider.
+func NewObserver(provider metric.MeterProvider) (*Observer, error) {
+	meter := provider.Meter(instrumentationName)
+	o := &Observer{}
+	var err error
+	if o.queries, err = meter.NewInt64Counter(
+		"cortextest.queries",
+		metric.WithDescription("Number of queries by outcome"),
+	); err != nil {
+		return nil, err
+	}
+	if o.queryLatency, err = meter.NewFloat64Histogram(
+		"cortextest.query.duration",
+		metric.WithDescription("Duration of the queries"),
+		metric.WithUnit(unit.Milliseconds),
+	); err != nil {
+		return nil, err
+	}
+	if o.compared, err = meter.NewInt64Counter(
+		"cortextest.series.compared",
+		metric.WithDescription("Number of expected series compared"),
+	); err != nil {
+		return nil, err
+	}
+	if o.mismatches, err = meter.NewInt64Counter(
+		"cortextest.mismatches",
+		metric.WithDescription("Number of differences by metric"),
+	); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Read reads the time series with querier, recording the outcome and duration of
+// the query.
+func (o *Observer) Read(ctx context.Context, querier cortex.Querier, start, end time.Time, matchers ...*prompb.LabelMatcher) ([]*prompb.TimeSeries, error) {
+	begin := time.Now()
+	timeseries, err := querier.Read(ctx, start, end, matchers...)
+	elapsed := float64(time.Since(begin)) / float64(time.Millisecond)
+
+	outcome := attribute.String("outcome", "success")
+	if err != nil {
+		outcome = attribute.String("outcome", "error")
+	}
+	o.queries.Add(ctx, 1, outcome)
+	o.queryLatency.Record(ctx, elapsed, outcome)
+	return timeseries, err
+}
+
+// Compare compares the time series got with want as CompareTimeSeries does,
+// recording the number of expected series compared and the differences of every
+// metric.
+func (o *Observer) Compare(ctx context.Context, want, got []*prompb.TimeSeries, opts ...CompareOption) []Difference {
+	diffs := CompareTimeSeries(want, got, opts...)
+	o.compared.Add(ctx, int64(len(want)))
+	for metric, metricDiffs := range DifferencesByMetric(diffs) {
+		o.mismatches.Add(ctx, int64(len(metricDiffs)), attribute.String("metric", metric))
+	}
+	return diffs
+}
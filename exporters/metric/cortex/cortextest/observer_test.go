@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortextest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex"
+	"go.opentelemetry.io/contrib/exporters/metric/cortex/cortextest"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+type querierFunc func() ([]*prompb.TimeSeries, error)
+
+func (fn querierFunc) Read(context.Context, time.Time, time.Time, ...*prompb.LabelMatcher) ([]*prompb.TimeSeries, error) {
+	return fn()
+}
+
+func TestObserver(t *testing.T) {
+	server := cortextest.NewServer()
+	defer server.Close()
+	exporter, err := cortex.NewRawExporter(cortex.Config{Endpoint: server.URL + "/api/v1/push"})
+	require.NoError(t, err)
+
+	cont := controller.New(
+		processor.NewFactory(simple.NewWithInexpensiveDistribution(), export.CumulativeExportKindSelector()),
+		controller.WithCollectPeriod(0),
+	)
+	observer, err := cortextest.NewObserver(cont)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	want := []*prompb.TimeSeries{timeSeries(1, "__name__", "requests"), timeSeries(2, "__name__", "errors")}
+	got, err := observer.Read(ctx, querierFunc(func() ([]*prompb.TimeSeries, error) {
+		return want[:1], nil
+	}), time.Time{}, time.Time{})
+	require.NoError(t, err)
+	_, err = observer.Read(ctx, querierFunc(func() ([]*prompb.TimeSeries, error) {
+		return nil, assert.AnError
+	}), time.Time{}, time.Time{})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Len(t, observer.Compare(ctx, want, got), 1)
+
+	// The metrics of the validation are exported with the exporter under validation.
+	require.NoError(t, cont.Collect(ctx))
+	require.NoError(t, exporter.Export(ctx, resource.Empty(), cont))
+	requests := server.Requests()
+	require.Len(t, requests, 1)
+
+	values := make(map[string]float64)
+	for _, ts := range requests[0].WriteRequest.Timeseries {
+		key := ""
+		for _, l := range ts.Labels {
+			if l.Name == "__name__" || l.Name == "outcome" || l.Name == "metric" {
+				key += "/" + l.Value
+			}
+		}
+		values[key] = ts.Samples[0].Value
+	}
+	assert.Equal(t, 1.0, values["/cortextest_queries/success"])
+	assert.Equal(t, 1.0, values["/cortextest_queries/error"])
+	assert.Equal(t, 1.0, values["/cortextest_query_duration_count/success"])
+	assert.Equal(t, 2.0, values["/cortextest_series_compared"])
+	assert.Equal(t, 1.0, values["/cortextest_mismatches/errors"])
+}
@@ -0,0 +1,285 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cortextest provides a Cortex remote write server for tests, which checks
+// the framing, headers, tenant, and authentication of every request as Cortex does,
+// and can simulate the ingestion rate limits of the tenants. It allows testing the
+// export to Cortex end to end without running Cortex.
+package cortextest
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// TenantHeader is the header identifying the tenant of a request.
+const TenantHeader = "X-Scope-OrgID"
+
+// Request is a remote write request accepted by a Server.
+type Request struct {
+	// Tenant is the tenant of the request, or empty if it has none.
+	Tenant string
+	// Header holds the headers of the request.
+	Header http.Header
+	// WriteRequest is the decoded body of the request.
+	WriteRequest prompb.WriteRequest
+}
+
+// Rejection is a remote write request rejected by a Server.
+type Rejection struct {
+	// Tenant is the tenant of the request, or empty if it has none.
+	Tenant string
+	// StatusCode is the status code of the response.
+	StatusCode int
+	// Reason describes why the request was rejected.
+	Reason string
+}
+
+// Server is a Cortex remote write endpoint for tests. It accepts the remote write
+// requests sent with POST to any path, and responds with:
+//
+//   - 400 Bad Request when the headers or framing are invalid or the tenant is
+//     unknown,
+//   - 401 Unauthorized when the request is not authenticated by any of the
+//     authentication options,
+//   - 429 Too Many Requests when the rate limit of the tenant is exceeded,
+//   - 200 OK when the request is accepted.
+//
+// A Server is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	cfg config
+
+	mu        sync.Mutex
+	requests  []Request
+	rejected  []Rejection
+	buckets   map[string]*tokenBucket
+	zstd      *zstd.Decoder
+	zstdError error
+}
+
+// NewServer starts and returns a Server configured with opts. It is stopped with
+// Close.
+func NewServer(opts ...Option) *Server {
+	s := &Server{cfg: newConfig(opts...), buckets: make(map[string]*tokenBucket)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Requests returns the requests accepted by the Server.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Rejections returns the requests rejected by the Server.
+func (s *Server) Rejections() []Rejection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Rejection(nil), s.rejected...)
+}
+
+// Samples returns the number of samples of the requests accepted for tenant.
+func (s *Server) Samples(tenant string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var samples int
+	for _, r := range s.requests {
+		if r.Tenant == tenant {
+			samples += countSamples(r.WriteRequest)
+		}
+	}
+	return samples
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	tenant := r.Header.Get(TenantHeader)
+	reject := func(code int, format string, args ...interface{}) {
+		reason := fmt.Sprintf(format, args...)
+		s.mu.Lock()
+		s.rejected = append(s.rejected, Rejection{Tenant: tenant, StatusCode: code, Reason: reason})
+		s.mu.Unlock()
+		http.Error(w, reason, code)
+	}
+
+	if r.Method != http.MethodPost {
+		reject(http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		reject(http.StatusBadRequest, "cannot read body: %v", err)
+		return
+	}
+
+	if err := s.authenticate(r, body); err != nil {
+		reject(http.StatusUnauthorized, "%v", err)
+		return
+	}
+	if s.cfg.tenants != nil && !s.cfg.tenants[tenant] {
+		if tenant == "" {
+			reject(http.StatusBadRequest, "no %s header", TenantHeader)
+		} else {
+			reject(http.StatusBadRequest, "unknown tenant %q", tenant)
+		}
+		return
+	}
+	if version := r.Header.Get("X-Prometheus-Remote-Write-Version"); version != s.cfg.writeVersion {
+		reject(http.StatusBadRequest, "invalid remote write version %q, expected %q", version, s.cfg.writeVersion)
+		return
+	}
+	if contentType := r.Header.Get("Content-Type"); contentType != "application/x-protobuf" {
+		reject(http.StatusBadRequest, "invalid content type %q", contentType)
+		return
+	}
+
+	message, err := s.decompress(r.Header.Get("Content-Encoding"), body)
+	if err != nil {
+		reject(http.StatusBadRequest, "%v", err)
+		return
+	}
+	var writeRequest prompb.WriteRequest
+	if err := writeRequest.Unmarshal(message); err != nil {
+		reject(http.StatusBadRequest, "invalid write request: %v", err)
+		return
+	}
+
+	if !s.allow(tenant, countSamples(writeRequest)) {
+		reject(http.StatusTooManyRequests, "ingestion rate limit of tenant %q exceeded", tenant)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Tenant: tenant, Header: r.Header.Clone(), WriteRequest: writeRequest})
+	s.mu.Unlock()
+}
+
+// authenticate returns an error if r with body is not authenticated by any of the
+// authentication options.
+func (s *Server) authenticate(r *http.Request, body []byte) error {
+	if !s.cfg.authRequired() {
+		return nil
+	}
+	authorization := r.Header.Get("Authorization")
+	switch {
+	case authorization == "":
+		return fmt.Errorf("no Authorization header")
+	case strings.HasPrefix(authorization, "Basic "):
+		username, password, _ := r.BasicAuth()
+		want, ok := s.cfg.basicAuth[username]
+		if !ok || subtle.ConstantTimeCompare([]byte(password), []byte(want)) != 1 {
+			return fmt.Errorf("invalid basic authentication credentials of %q", username)
+		}
+	case strings.HasPrefix(authorization, "Bearer "):
+		if !s.cfg.bearerTokens[strings.TrimPrefix(authorization, "Bearer ")] {
+			return fmt.Errorf("invalid bearer token")
+		}
+	case s.cfg.sigV4 != nil:
+		if err := s.cfg.sigV4.verify(r, body, s.cfg.now()); err != nil {
+			return fmt.Errorf("invalid signature: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported authentication")
+	}
+	return nil
+}
+
+// decompress returns the message compressed in body with encoding.
+func (s *Server) decompress(encoding string, body []byte) ([]byte, error) {
+	switch encoding {
+	case "snappy":
+		message, err := snappy.Decode(nil, body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snappy framing: %w", err)
+		}
+		return message, nil
+	case "zstd":
+		s.mu.Lock()
+		if s.zstd == nil && s.zstdError == nil {
+			s.zstd, s.zstdError = zstd.NewReader(nil)
+		}
+		decoder, err := s.zstd, s.zstdError
+		s.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		message, err := decoder.DecodeAll(body, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zstd framing: %w", err)
+		}
+		return message, nil
+	default:
+		return nil, fmt.Errorf("invalid content encoding %q", encoding)
+	}
+}
+
+// allow returns whether the samples of a request of tenant are within its rate limit.
+func (s *Server) allow(tenant string, samples int) bool {
+	limit, ok := s.cfg.rateLimits[tenant]
+	if !ok {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucket, ok := s.buckets[tenant]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(limit.burst), last: s.cfg.now()}
+		s.buckets[tenant] = bucket
+	}
+	return bucket.take(limit, float64(samples), s.cfg.now())
+}
+
+// tokenBucket limits the rate of the samples of a tenant.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take takes n tokens at now if there are enough, returning whether it did.
+func (b *tokenBucket) take(limit rateLimit, n float64, now time.Time) bool {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * limit.samplesPerSecond
+		if b.tokens > float64(limit.burst) {
+			b.tokens = float64(limit.burst)
+		}
+	}
+	b.last = now
+	if n > b.tokens {
+		return false
+	}
+	b.tokens -= n
+	return true
+}
+
+// countSamples returns the number of samples of a write request.
+func countSamples(writeRequest prompb.WriteRequest) int {
+	var samples int
+	for _, ts := range writeRequest.Timeseries {
+		samples += len(ts.Samples)
+	}
+	return samples
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortextest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex"
+	"go.opentelemetry.io/contrib/exporters/metric/cortex/cortextest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// exportCounter exports a counter with series series to server with config.
+func exportCounter(t *testing.T, server *cortextest.Server, config cortex.Config, series int) error {
+	config.Endpoint = server.URL + "/api/v1/push"
+	exporter, err := cortex.NewRawExporter(config)
+	require.NoError(t, err)
+
+	cont := controller.New(
+		processor.NewFactory(simple.NewWithInexpensiveDistribution(), export.CumulativeExportKindSelector()),
+		controller.WithCollectPeriod(0),
+	)
+	ctx := context.Background()
+	counter := metric.Must(cont.Meter("test")).NewInt64Counter("requests")
+	for i := 0; i < series; i++ {
+		counter.Add(ctx, 1, attribute.Int("series", i))
+	}
+	require.NoError(t, cont.Collect(ctx))
+	return exporter.Export(ctx, resource.Empty(), cont)
+}
+
+func TestServerTenants(t *testing.T) {
+	server := cortextest.NewServer(cortextest.WithTenants("team-a", "team-b"))
+	defer server.Close()
+
+	require.NoError(t, exportCounter(t, server, cortex.Config{Headers: map[string]string{cortextest.TenantHeader: "team-a"}}, 2))
+	assert.EqualError(t, exportCounter(t, server, cortex.Config{Headers: map[string]string{cortextest.TenantHeader: "team-c"}}, 1), "400 Bad Request")
+	assert.EqualError(t, exportCounter(t, server, cortex.Config{}, 1), "400 Bad Request")
+
+	requests := server.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "team-a", requests[0].Tenant)
+	assert.Len(t, requests[0].WriteRequest.Timeseries, 2)
+	assert.Equal(t, 2, server.Samples("team-a"))
+	assert.Equal(t, []cortextest.Rejection{
+		{Tenant: "team-c", StatusCode: http.StatusBadRequest, Reason: `unknown tenant "team-c"`},
+		{StatusCode: http.StatusBadRequest, Reason: "no X-Scope-OrgID header"},
+	}, server.Rejections())
+}
+
+func TestServerAuthentication(t *testing.T) {
+	server := cortextest.NewServer(
+		cortextest.WithBasicAuth("user", "password"),
+		cortextest.WithBearerToken("token"),
+	)
+	defer server.Close()
+
+	testCases := []struct {
+		name   string
+		config cortex.Config
+		err    string
+	}{
+		{name: "basic", config: cortex.Config{BasicAuth: map[string]string{"username": "user", "password": "password"}}},
+		{name: "bearer", config: cortex.Config{BearerToken: "token"}},
+		{name: "invalid password", config: cortex.Config{BasicAuth: map[string]string{"username": "user", "password": "wrong"}}, err: "401 Unauthorized"},
+		{name: "invalid token", config: cortex.Config{BearerToken: "wrong"}, err: "401 Unauthorized"},
+		{name: "unauthenticated", config: cortex.Config{}, err: "401 Unauthorized"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := exportCounter(t, server, tc.config, 1)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+	assert.Len(t, server.Requests(), 2)
+}
+
+func TestServerRateLimit(t *testing.T) {
+	server := cortextest.NewServer(cortextest.WithRateLimit("team-a", 0.001, 5))
+	defer server.Close()
+	config := cortex.Config{Headers: map[string]string{cortextest.TenantHeader: "team-a"}}
+
+	require.NoError(t, exportCounter(t, server, config, 3))
+	assert.EqualError(t, exportCounter(t, server, config, 3), "429 Too Many Requests")
+	require.NoError(t, exportCounter(t, server, config, 2))
+	// The other tenants are not limited.
+	require.NoError(t, exportCounter(t, server, cortex.Config{}, 10))
+
+	assert.Equal(t, 5, server.Samples("team-a"))
+	assert.Equal(t, 10, server.Samples(""))
+	rejections := server.Rejections()
+	require.Len(t, rejections, 1)
+	assert.Equal(t, http.StatusTooManyRequests, rejections[0].StatusCode)
+}
+
+func TestServerFraming(t *testing.T) {
+	server := cortextest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, exportCounter(t, server, cortex.Config{Compression: cortex.CompressionZstd}, 1))
+	assert.EqualError(t, exportCounter(t, server, cortex.Config{Format: cortex.FormatVictoriaMetricsImport}, 1), "400 Bad Request")
+
+	versioned := cortextest.NewServer(cortextest.WithRemoteWriteVersion("2.0.0"))
+	defer versioned.Close()
+	assert.EqualError(t, exportCounter(t, versioned, cortex.Config{}, 1), "400 Bad Request")
+	assert.Equal(t, `invalid remote write version "0.1.0", expected "2.0.0"`, versioned.Rejections()[0].Reason)
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortextest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4DateFormat = "20060102T150405Z"
+	// sigV4MaxSkew is the maximum difference between the date of a signature
+	// and the time of the Server.
+	sigV4MaxSkew = 5 * time.Minute
+)
+
+// sigV4Credentials are the credentials the requests are signed with.
+type sigV4Credentials struct {
+	region          string
+	service         string
+	accessKeyID     string
+	secretAccessKey string
+}
+
+// verify returns an error if req with body is not signed with the credentials at now.
+func (c *sigV4Credentials) verify(req *http.Request, body []byte, now time.Time) error {
+	authorization := req.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, sigV4Algorithm+" ") {
+		return errors.New("not signed with " + sigV4Algorithm)
+	}
+	fields := make(map[string]string)
+	for _, field := range strings.Split(strings.TrimPrefix(authorization, sigV4Algorithm+" "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+
+	date, err := time.Parse(sigV4DateFormat, req.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return fmt.Errorf("invalid X-Amz-Date header: %w", err)
+	}
+	if skew := now.Sub(date); skew > sigV4MaxSkew || skew < -sigV4MaxSkew {
+		return fmt.Errorf("signature dated %v is expired", date)
+	}
+
+	scope := c.scope(date)
+	if want := c.accessKeyID + "/" + scope; fields["Credential"] != want {
+		return fmt.Errorf("invalid credential %q, expected %q", fields["Credential"], want)
+	}
+	signedHeaders := fields["SignedHeaders"]
+	if !strings.Contains(";"+signedHeaders+";", ";host;") {
+		return errors.New("host header not signed")
+	}
+
+	payloadHash := req.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		sum := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(sum[:])
+	}
+	signature := c.signature(date, canonicalRequest(req, signedHeaders, payloadHash))
+	if !hmac.Equal([]byte(signature), []byte(fields["Signature"])) {
+		return errors.New("signature does not match")
+	}
+	return nil
+}
+
+// scope returns the credential scope of the signatures dated date.
+func (c *sigV4Credentials) scope(date time.Time) string {
+	return date.Format("20060102") + "/" + c.region + "/" + c.service + "/aws4_request"
+}
+
+// signature returns the signature of canonicalRequest dated date.
+func (c *sigV4Credentials) signature(date time.Time, canonicalRequest string) string {
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm,
+		date.Format(sigV4DateFormat),
+		c.scope(date),
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.secretAccessKey), date.Format("20060102"))
+	for _, data := range []string{c.region, c.service, "aws4_request"} {
+		key = hmacSHA256(key, data)
+	}
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalRequest returns the canonical form of req with the signedHeaders, separated
+// by semicolons, and the hex encoded hash of its payload.
+func canonicalRequest(req *http.Request, signedHeaders, payloadHash string) string {
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, sigV4Escape(key)+"="+sigV4Escape(value))
+		}
+	}
+
+	var headers strings.Builder
+	for _, name := range strings.Split(signedHeaders, ";") {
+		values := req.Header.Values(name)
+		if name == "host" {
+			values = []string{req.Host}
+		}
+		trimmed := make([]string, len(values))
+		for i, value := range values {
+			trimmed[i] = strings.Join(strings.Fields(value), " ")
+		}
+		headers.WriteString(name + ":" + strings.Join(trimmed, ",") + "\n")
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		path,
+		strings.Join(params, "&"),
+		headers.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+}
+
+// sigV4Escape escapes s as the URI encoding of Signature Version 4, which escapes
+// every byte but the unreserved characters.
+func sigV4Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortextest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sign signs req with body with c at date, as the AWS SDKs do.
+func sign(c *sigV4Credentials, req *http.Request, body []byte, date time.Time) {
+	req.Header.Set("X-Amz-Date", date.Format(sigV4DateFormat))
+	sum := sha256.Sum256(body)
+	signedHeaders := "host;x-amz-date"
+	signature := c.signature(date, canonicalRequest(req, signedHeaders, hex.EncodeToString(sum[:])))
+	req.Header.Set("Authorization", sigV4Algorithm+" Credential="+c.accessKeyID+"/"+c.scope(date)+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// TestSigV4Signature checks the signature of the get-vanilla request of the Signature
+// Version 4 test suite.
+func TestSigV4Signature(t *testing.T) {
+	c := &sigV4Credentials{
+		region:          "us-east-1",
+		service:         "service",
+		accessKeyID:     "AKIDEXAMPLE",
+		secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+	date := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	req := httptest.NewRequest(http.MethodGet, "http://example.amazonaws.com/", nil)
+	sign(c, req, nil, date)
+
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+	assert.NoError(t, c.verify(req, nil, date.Add(time.Minute)))
+}
+
+func TestSigV4Verify(t *testing.T) {
+	c := &sigV4Credentials{region: "us-west-2", service: "aps", accessKeyID: "AKID", secretAccessKey: "secret"}
+	now := time.Date(2021, 10, 17, 12, 0, 0, 0, time.UTC)
+	body := []byte("body")
+	newRequest := func(c *sigV4Credentials, date time.Time) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "http://aps.example.com/api/v1/remote_write?a=b%20c&a=1", bytes.NewReader(body))
+		sign(c, req, body, date)
+		return req
+	}
+
+	require.NoError(t, c.verify(newRequest(c, now), body, now))
+
+	err := c.verify(newRequest(c, now), []byte("tampered"), now)
+	assert.EqualError(t, err, "signature does not match")
+
+	other := *c
+	other.secretAccessKey = "other"
+	assert.EqualError(t, c.verify(newRequest(&other, now), body, now), "signature does not match")
+
+	other = *c
+	other.region = "eu-west-1"
+	assert.Error(t, c.verify(newRequest(&other, now), body, now))
+
+	assert.Error(t, c.verify(newRequest(c, now.Add(-time.Hour)), body, now))
+
+	req := newRequest(c, now)
+	req.Header.Set("Authorization", "Bearer token")
+	assert.Error(t, c.verify(req, body, now))
+}
+
+func TestServerSigV4(t *testing.T) {
+	server := NewServer(WithSigV4("us-west-2", "aps", "AKID", "secret"))
+	defer server.Close()
+
+	message, err := (&prompb.WriteRequest{}).Marshal()
+	require.NoError(t, err)
+	body := snappy.Encode(nil, message)
+	send := func(secretAccessKey string) int {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/remote_write", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("X-Prometheus-Remote-Write-Version", DefaultRemoteWriteVersion)
+		req.Header.Set("Content-Encoding", "snappy")
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		sign(&sigV4Credentials{region: "us-west-2", service: "aps", accessKeyID: "AKID", secretAccessKey: secretAccessKey}, req, body, time.Now().UTC())
+
+		res, err := server.Client().Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, send("secret"))
+	assert.Equal(t, http.StatusUnauthorized, send("wrong"))
+	assert.Len(t, server.Requests(), 1)
+}
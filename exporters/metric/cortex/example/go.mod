@@ -3,6 +3,7 @@ module go.opentelemetry.io/contrib/exporters/metric/cortex/example
 go 1.15
 
 replace (
+	go.opentelemetry.io/contrib => ../../../..
 	go.opentelemetry.io/contrib/exporters/metric/cortex => ../
 	go.opentelemetry.io/contrib/exporters/metric/cortex/utils => ../utils/
 )
@@ -15,3 +16,5 @@ require (
 	go.opentelemetry.io/otel/sdk v1.1.0
 	go.opentelemetry.io/otel/sdk/metric v0.24.0
 )
+
+replace go.opentelemetry.io/contrib/exporters/metric/cortex/prometheusremotewrite => ../prometheusremotewrite
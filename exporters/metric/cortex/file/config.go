@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+)
+
+const (
+	// DefaultMaxSize is the default size in bytes of a file before it is
+	// rotated.
+	DefaultMaxSize = 100 << 20
+
+	// DefaultMaxBackups is the default number of rotated files that are kept.
+	DefaultMaxBackups = 5
+)
+
+var (
+	// ErrNoPath occurs when no file path was provided.
+	ErrNoPath = fmt.Errorf("no file path provided")
+
+	// ErrInvalidEncoding occurs when the encoding is not supported.
+	ErrInvalidEncoding = fmt.Errorf("invalid encoding")
+)
+
+// Encoding is the serialization of the WriteRequest messages written to the
+// file.
+type Encoding int
+
+const (
+	// EncodingProtobuf writes each WriteRequest as an uncompressed protobuf
+	// message, prefixed with its length as a varint.
+	EncodingProtobuf Encoding = iota
+	// EncodingJSON writes each WriteRequest as a line of JSON.
+	EncodingJSON
+)
+
+// Config contains properties the Exporter uses to write metrics data to a
+// file.
+type Config struct {
+	// Path is the path of the file the WriteRequest messages are written
+	// to. The rotated files are named by appending .1, .2, etc. to the path,
+	// .1 being the most recent.
+	Path string
+	// MaxSize is the size in bytes a file can reach before it is rotated.
+	// Defaults to DefaultMaxSize. A negative size disables the rotation.
+	MaxSize int64
+	// MaxBackups is the number of rotated files that are kept. Defaults to
+	// DefaultMaxBackups.
+	MaxBackups int
+	// Encoding is the serialization of the WriteRequest messages. Defaults
+	// to EncodingProtobuf.
+	Encoding Encoding
+	// HistogramBoundaries are the boundaries of the histogram aggregations
+	// of the export pipeline.
+	HistogramBoundaries []float64
+}
+
+// Validate checks a Config struct for missing required properties and
+// property conflicts. Additionally, it adds default values to missing
+// properties when there is a default.
+func (c *Config) Validate() error {
+	if c.Path == "" {
+		return ErrNoPath
+	}
+	if c.Encoding != EncodingProtobuf && c.Encoding != EncodingJSON {
+		return ErrInvalidEncoding
+	}
+	if c.MaxSize == 0 {
+		c.MaxSize = DefaultMaxSize
+	}
+	if c.MaxBackups == 0 {
+		c.MaxBackups = DefaultMaxBackups
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package file provides an exporter that writes the Prometheus remote write
+// WriteRequest messages to a rotating file instead of sending them, for
+// offline inspection, replay, or the capture of metrics in air-gapped
+// environments. The written messages are read back with a Reader.
+package file // import "go.opentelemetry.io/contrib/exporters/metric/cortex/file"
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/prometheus/prometheus/prompb"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex/prometheusremotewrite"
+	apimetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// Exporter writes metrics to a file.
+type Exporter struct {
+	config Config
+	file   *rotatingFile
+}
+
+var _ export.Exporter = (*Exporter)(nil)
+
+// NewRawExporter validates the Config struct and creates an Exporter
+// appending to the file of its Path.
+func NewRawExporter(config Config) (*Exporter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	file, err := openRotatingFile(config.Path, config.MaxSize, config.MaxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{config: config, file: file}, nil
+}
+
+// NewExportPipeline sets up a complete export pipeline with a push
+// Controller and Exporter.
+func NewExportPipeline(config Config, options ...controller.Option) (*controller.Controller, error) {
+	exporter, err := NewRawExporter(config)
+	if err != nil {
+		return nil, err
+	}
+
+	cont := controller.New(
+		processor.NewFactory(
+			simple.NewWithHistogramDistribution(
+				histogram.WithExplicitBoundaries(config.HistogramBoundaries),
+			),
+			exporter,
+		),
+		append(options, controller.WithExporter(exporter))...,
+	)
+
+	return cont, cont.Start(context.TODO())
+}
+
+// InstallNewPipeline registers a push Controller's MeterProvider globally.
+func InstallNewPipeline(config Config, options ...controller.Option) (*controller.Controller, error) {
+	cont, err := NewExportPipeline(config, options...)
+	if err != nil {
+		return nil, err
+	}
+	global.SetMeterProvider(cont)
+	return cont, nil
+}
+
+// ExportKindFor returns CumulativeExporter so the Processor correctly
+// aggregates data.
+func (e *Exporter) ExportKindFor(*apimetric.Descriptor, aggregation.Kind) export.ExportKind {
+	return export.CumulativeExportKind
+}
+
+// Export writes the metrics to the file as a WriteRequest.
+func (e *Exporter) Export(_ context.Context, res *resource.Resource, reader export.InstrumentationLibraryReader) error {
+	timeseries, err := prometheusremotewrite.ConvertToTimeSeries(res, reader, prometheusremotewrite.WithExportKindSelector(e))
+	if err != nil {
+		return err
+	}
+	if len(timeseries) == 0 {
+		return nil
+	}
+
+	record, err := e.encode(&prompb.WriteRequest{Timeseries: timeseries})
+	if err != nil {
+		return err
+	}
+	_, err = e.file.Write(record)
+	return err
+}
+
+// Close closes the file. The Exporter must not be used afterwards. As the
+// records are written to the file without buffering, no data is lost if
+// the Exporter of a pipeline is not closed.
+func (e *Exporter) Close() error {
+	return e.file.Close()
+}
+
+// encode serializes writeRequest as a record of the file.
+func (e *Exporter) encode(writeRequest *prompb.WriteRequest) ([]byte, error) {
+	if e.config.Encoding == EncodingJSON {
+		record, err := json.Marshal(writeRequest)
+		if err != nil {
+			return nil, err
+		}
+		return append(record, '\n'), nil
+	}
+
+	size := writeRequest.Size()
+	record := make([]byte, binary.MaxVarintLen64+size)
+	n := binary.PutUvarint(record, uint64(size))
+	written, err := writeRequest.MarshalToSizedBuffer(record[n : n+size])
+	if err != nil {
+		return nil, err
+	}
+	return record[:n+written], nil
+}
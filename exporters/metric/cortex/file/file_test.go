@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/metric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+)
+
+func TestExportAndRead(t *testing.T) {
+	for _, encoding := range []Encoding{EncodingProtobuf, EncodingJSON} {
+		path := filepath.Join(t.TempDir(), "metrics")
+		exp, err := NewRawExporter(Config{Path: path, Encoding: encoding})
+		require.NoError(t, err)
+		cont := controller.New(processor.NewFactory(simple.NewWithInexpensiveDistribution(), exp), controller.WithCollectPeriod(0))
+
+		ctx := context.Background()
+		counter := metric.Must(cont.Meter("test")).NewInt64Counter("requests")
+		for _, value := range []int64{2, 3} {
+			counter.Add(ctx, value)
+			require.NoError(t, cont.Collect(ctx))
+			require.NoError(t, exp.Export(ctx, cont.Resource(), cont))
+		}
+		require.NoError(t, exp.Close())
+
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		reader := NewReader(f, encoding)
+
+		for _, want := range []float64{2, 5} {
+			writeRequest, err := reader.Next()
+			require.NoError(t, err)
+			require.Len(t, writeRequest.Timeseries, 1)
+			require.Len(t, writeRequest.Timeseries[0].Samples, 1)
+			assert.Equal(t, want, writeRequest.Timeseries[0].Samples[0].Value)
+		}
+		_, err = reader.Next()
+		assert.Equal(t, io.EOF, err)
+		require.NoError(t, f.Close())
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	config := Config{Path: "metrics"}
+	require.NoError(t, config.Validate())
+	assert.Equal(t, int64(DefaultMaxSize), config.MaxSize)
+	assert.Equal(t, DefaultMaxBackups, config.MaxBackups)
+
+	config = Config{}
+	assert.Equal(t, ErrNoPath, config.Validate())
+	config = Config{Path: "metrics", Encoding: Encoding(42)}
+	assert.Equal(t, ErrInvalidEncoding, config.Validate())
+}
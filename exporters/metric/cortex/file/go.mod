@@ -0,0 +1,15 @@
+module go.opentelemetry.io/contrib/exporters/metric/cortex/file
+
+go 1.15
+
+replace go.opentelemetry.io/contrib/exporters/metric/cortex/prometheusremotewrite => ../prometheusremotewrite
+
+require (
+	github.com/prometheus/prometheus v1.8.2-0.20210928085443-fafb309d4027
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/exporters/metric/cortex/prometheusremotewrite v0.26.0
+	go.opentelemetry.io/otel/metric v0.24.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/sdk/export/metric v0.24.0
+	go.opentelemetry.io/otel/sdk/metric v0.24.0
+)
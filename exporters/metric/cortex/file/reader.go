@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// Reader reads the WriteRequest messages written by an Exporter.
+type Reader struct {
+	reader   *bufio.Reader
+	encoding Encoding
+}
+
+// NewReader returns a Reader of the WriteRequest messages of r, written with
+// encoding.
+func NewReader(r io.Reader, encoding Encoding) *Reader {
+	return &Reader{
+		reader:   bufio.NewReader(r),
+		encoding: encoding,
+	}
+}
+
+// Next returns the next WriteRequest. It returns io.EOF when there are no
+// more messages.
+func (r *Reader) Next() (*prompb.WriteRequest, error) {
+	var writeRequest prompb.WriteRequest
+	if r.encoding == EncodingJSON {
+		line, err := r.reader.ReadBytes('\n')
+		if err == io.EOF && len(line) > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(line, &writeRequest); err != nil {
+			return nil, err
+		}
+		return &writeRequest, nil
+	}
+
+	size, err := binary.ReadUvarint(r.reader)
+	if err != nil {
+		return nil, err
+	}
+	message := make([]byte, size)
+	if _, err := io.ReadFull(r.reader, message); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if err := writeRequest.Unmarshal(message); err != nil {
+		return nil, err
+	}
+	return &writeRequest, nil
+}
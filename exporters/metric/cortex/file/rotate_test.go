@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics")
+	f, err := openRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+
+	for _, record := range []string{"aaaaaa", "bbbbbb", "cccccc", "dddddd"} {
+		_, err := f.Write([]byte(record))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	// Each record would exceed the size of the file holding the previous
+	// one, and only two rotated files are kept.
+	for p, want := range map[string]string{
+		path:                "dddddd",
+		backupPath(path, 1): "cccccc",
+		backupPath(path, 2): "bbbbbb",
+	} {
+		content, err := ioutil.ReadFile(p)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(content), p)
+	}
+	assert.NoFileExists(t, backupPath(path, 3))
+}
+
+func TestRotatingFileAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics")
+	for _, record := range []string{"aaa", "bbb"} {
+		f, err := openRotatingFile(path, 10, 1)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(record))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "aaabbb", string(content))
+}
+
+func TestRotatingFileRotationError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics")
+	f, err := openRotatingFile(path, 10, 1)
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte("aaaaaa"))
+	require.NoError(t, err)
+
+	// The file cannot be renamed to a non-empty directory.
+	require.NoError(t, os.MkdirAll(filepath.Join(backupPath(path, 1), "dir"), 0o755))
+	_, err = f.Write([]byte("bbbbbb"))
+	assert.Error(t, err)
+
+	// The file stays open, and the rotation is retried by the next write.
+	require.NoError(t, os.RemoveAll(backupPath(path, 1)))
+	_, err = f.Write([]byte("cccccc"))
+	require.NoError(t, err)
+
+	for p, want := range map[string]string{
+		path:                "cccccc",
+		backupPath(path, 1): "aaaaaa",
+	} {
+		content, err := ioutil.ReadFile(p)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(content), p)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidHeadersFile occurs when a line of the headers file is not a header.
+var ErrInvalidHeadersFile = fmt.Errorf("invalid headers file")
+
+// headersFile caches the headers of Config.HeadersFile. The file is read again
+// when its modification time or size changed, so that the rotated API keys or
+// tenants are sent without a restart.
+type headersFile struct {
+	mu      sync.Mutex
+	path    string
+	modTime time.Time
+	size    int64
+	headers map[string][]string
+}
+
+// read returns the headers of the file at path, reading it only when it changed
+// since it was last read.
+func (f *headersFile) read(path string) (map[string][]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, ErrFailedToReadFile
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.headers != nil && f.path == path && f.modTime.Equal(info.ModTime()) && f.size == info.Size() {
+		return f.headers, nil
+	}
+
+	file, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, ErrFailedToReadFile
+	}
+	headers, err := parseHeaders(file)
+	if err != nil {
+		return nil, err
+	}
+	f.path = path
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	f.headers = headers
+	return headers, nil
+}
+
+// parseHeaders parses the "Name: value" lines of a headers file, ignoring the empty
+// lines and the comments starting with #. A header has the values of all its lines.
+func parseHeaders(file []byte) (map[string][]string, error) {
+	headers := make(map[string][]string)
+	scanner := bufio.NewScanner(bytes.NewReader(file))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		i := strings.IndexByte(text, ':')
+		if i <= 0 {
+			return nil, fmt.Errorf("%w: line %d is not a header", ErrInvalidHeadersFile, line)
+		}
+		name := strings.TrimSpace(text[:i])
+		headers[name] = append(headers[name], strings.TrimSpace(text[i+1:]))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHeadersFile checks whether the headers of the headers file are added to the
+// requests and read again when the file changed.
+func TestHeadersFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "headers")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "headers")
+
+	writeHeaders := func(contents string, modTime time.Time) {
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	headers := func(exporter *Exporter) (http.Header, error) {
+		req, err := http.NewRequest(http.MethodPost, "test.com", nil)
+		require.NoError(t, err)
+		return req.Header, exporter.addHeaders(req)
+	}
+
+	exporter := Exporter{config: Config{HeadersFile: path, BearerToken: "token"}}
+	modTime := time.Now().Add(-time.Hour)
+	writeHeaders("# Tenant of the requests.\nX-Scope-OrgID: first\n\nx-api-key: a\nx-api-key: b\n", modTime)
+
+	header, err := headers(&exporter)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first"}, header["X-Scope-OrgID"])
+	assert.Equal(t, []string{"a", "b"}, header["x-api-key"])
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+
+	// The cached headers are used while the file did not change.
+	exporter.headersFile.headers["X-Scope-OrgID"] = []string{"cached"}
+	header, err = headers(&exporter)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cached"}, header["X-Scope-OrgID"])
+
+	// The file is read again when it changed, and its Authorization header replaces
+	// the bearer token.
+	writeHeaders("X-Scope-OrgID: second\nAuthorization: Bearer rotated\n", modTime.Add(time.Minute))
+	header, err = headers(&exporter)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"second"}, header["X-Scope-OrgID"])
+	assert.Nil(t, header["x-api-key"])
+	assert.Equal(t, []string{"Bearer rotated"}, header["Authorization"])
+
+	writeHeaders("X-Scope-OrgID\n", modTime.Add(2*time.Minute))
+	_, err = headers(&exporter)
+	assert.True(t, errors.Is(err, ErrInvalidHeadersFile))
+
+	require.NoError(t, os.Remove(path))
+	_, err = headers(&exporter)
+	assert.Equal(t, ErrFailedToReadFile, err)
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex"
+)
+
+var (
+	// ErrNoTopic occurs when no Kafka topic was provided.
+	ErrNoTopic = fmt.Errorf("no Kafka topic provided")
+
+	// ErrNoTenant occurs when the messages are partitioned by tenant but no
+	// tenant was provided.
+	ErrNoTenant = fmt.Errorf("no tenant provided for partitioning by tenant")
+
+	// ErrInvalidEncoding occurs when the encoding is not supported.
+	ErrInvalidEncoding = fmt.Errorf("invalid encoding")
+)
+
+// Encoding is the serialization of the Prometheus WriteRequest messages
+// published to Kafka.
+type Encoding int
+
+const (
+	// EncodingProtobuf serializes the WriteRequest messages as
+	// Snappy-compressed protobuf, the body of a remote-write HTTP request.
+	EncodingProtobuf Encoding = iota
+	// EncodingJSON serializes the WriteRequest messages as JSON.
+	EncodingJSON
+)
+
+// Partitioning is the key of the published Kafka messages, which selects
+// their partition.
+type Partitioning int
+
+const (
+	// PartitionByMetricName publishes one message per metric name, keyed by
+	// the name, so that the samples of a metric stay ordered.
+	PartitionByMetricName Partitioning = iota
+	// PartitionByTenant publishes one message with all the time series,
+	// keyed by the tenant.
+	PartitionByTenant
+)
+
+// Config contains properties the Exporter uses to publish metrics data to
+// Kafka.
+type Config struct {
+	// Topic is the Kafka topic the messages are published to.
+	Topic string
+	// Tenant is the Cortex tenant of the metrics. If not empty, it is sent
+	// with the X-Scope-OrgID message header.
+	Tenant string
+	// Encoding is the serialization of the messages. Defaults to
+	// EncodingProtobuf.
+	Encoding Encoding
+	// Partitioning selects the key of the messages. Defaults to
+	// PartitionByMetricName.
+	Partitioning Partitioning
+	// Cortex configures the conversion of the metrics to time series, e.g.
+	// the histogram boundaries. Its HTTP properties are not used.
+	Cortex cortex.Config
+}
+
+// Validate checks a Config struct for missing required properties and
+// property conflicts.
+func (c *Config) Validate() error {
+	if c.Topic == "" {
+		return ErrNoTopic
+	}
+	if c.Partitioning == PartitionByTenant && c.Tenant == "" {
+		return ErrNoTenant
+	}
+	if c.Encoding != EncodingProtobuf && c.Encoding != EncodingJSON {
+		return ErrInvalidEncoding
+	}
+	return c.Cortex.Validate()
+}
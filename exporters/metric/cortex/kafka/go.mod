@@ -0,0 +1,21 @@
+module go.opentelemetry.io/contrib/exporters/metric/cortex/kafka
+
+go 1.15
+
+replace (
+	go.opentelemetry.io/contrib => ../../../..
+	go.opentelemetry.io/contrib/exporters/metric/cortex => ../
+	go.opentelemetry.io/contrib/exporters/metric/cortex/prometheusremotewrite => ../prometheusremotewrite
+)
+
+require (
+	github.com/Shopify/sarama v1.29.1
+	github.com/golang/snappy v0.0.4
+	github.com/prometheus/prometheus v1.8.2-0.20210928085443-fafb309d4027
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/exporters/metric/cortex v0.26.0
+	go.opentelemetry.io/otel/metric v0.24.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/sdk/export/metric v0.24.0
+	go.opentelemetry.io/otel/sdk/metric v0.24.0
+)
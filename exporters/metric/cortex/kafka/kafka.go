@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kafka provides a Cortex exporter variant that publishes the
+// Prometheus remote-write WriteRequest messages to a Kafka topic instead of
+// sending them over HTTP, for deployments that buffer metrics through Kafka
+// before Cortex.
+package kafka // import "go.opentelemetry.io/contrib/exporters/metric/cortex/kafka"
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/Shopify/sarama"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex"
+	apimetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const (
+	// TenantHeader is the message header carrying the Cortex tenant.
+	TenantHeader = "X-Scope-OrgID"
+
+	metricNameLabel = "__name__"
+)
+
+// Exporter publishes metrics to a Kafka topic.
+type Exporter struct {
+	config    Config
+	producer  sarama.SyncProducer
+	converter *cortex.Exporter
+}
+
+var _ export.Exporter = (*Exporter)(nil)
+
+// NewRawExporter validates the Config struct and creates an Exporter
+// publishing with producer.
+func NewRawExporter(producer sarama.SyncProducer, config Config) (*Exporter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	converter, err := cortex.NewRawExporter(config.Cortex)
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{
+		config:    config,
+		producer:  producer,
+		converter: converter,
+	}, nil
+}
+
+// NewExportPipeline sets up a complete export pipeline with a push
+// Controller and Exporter.
+func NewExportPipeline(producer sarama.SyncProducer, config Config, options ...controller.Option) (*controller.Controller, error) {
+	exporter, err := NewRawExporter(producer, config)
+	if err != nil {
+		return nil, err
+	}
+
+	cont := controller.New(
+		processor.NewFactory(
+			simple.NewWithHistogramDistribution(
+				histogram.WithExplicitBoundaries(config.Cortex.HistogramBoundaries),
+			),
+			exporter,
+		),
+		append(options, controller.WithExporter(exporter))...,
+	)
+
+	return cont, cont.Start(context.TODO())
+}
+
+// InstallNewPipeline registers a push Controller's MeterProvider globally.
+func InstallNewPipeline(producer sarama.SyncProducer, config Config, options ...controller.Option) (*controller.Controller, error) {
+	cont, err := NewExportPipeline(producer, config, options...)
+	if err != nil {
+		return nil, err
+	}
+	global.SetMeterProvider(cont)
+	return cont, nil
+}
+
+// ExportKindFor returns CumulativeExporter so the Processor correctly
+// aggregates data.
+func (e *Exporter) ExportKindFor(*apimetric.Descriptor, aggregation.Kind) export.ExportKind {
+	return export.CumulativeExportKind
+}
+
+// Export publishes the metrics to Kafka.
+func (e *Exporter) Export(_ context.Context, res *resource.Resource, reader export.InstrumentationLibraryReader) error {
+	timeseries, err := e.converter.ConvertToTimeSeries(res, reader)
+	if err != nil {
+		return err
+	}
+	if len(timeseries) == 0 {
+		return nil
+	}
+
+	var messages []*sarama.ProducerMessage
+	for _, p := range e.partition(timeseries) {
+		value, err := e.encode(p.timeseries)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, &sarama.ProducerMessage{
+			Topic:   e.config.Topic,
+			Key:     sarama.StringEncoder(p.key),
+			Value:   sarama.ByteEncoder(value),
+			Headers: e.headers(),
+		})
+	}
+	return e.producer.SendMessages(messages)
+}
+
+// partitioned are the time series of a message, with its key.
+type partitioned struct {
+	key        string
+	timeseries []prompb.TimeSeries
+}
+
+// partition splits timeseries into the messages to publish.
+func (e *Exporter) partition(timeseries []prompb.TimeSeries) []partitioned {
+	if e.config.Partitioning == PartitionByTenant {
+		return []partitioned{{key: e.config.Tenant, timeseries: timeseries}}
+	}
+
+	byName := make(map[string][]prompb.TimeSeries)
+	for _, ts := range timeseries {
+		name := metricName(ts)
+		byName[name] = append(byName[name], ts)
+	}
+	result := make([]partitioned, 0, len(byName))
+	for name, ts := range byName {
+		result = append(result, partitioned{key: name, timeseries: ts})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].key < result[j].key })
+	return result
+}
+
+// metricName returns the value of the __name__ label of ts.
+func metricName(ts prompb.TimeSeries) string {
+	for _, l := range ts.Labels {
+		if l.Name == metricNameLabel {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+// encode serializes timeseries as a WriteRequest.
+func (e *Exporter) encode(timeseries []prompb.TimeSeries) ([]byte, error) {
+	writeRequest := &prompb.WriteRequest{
+		Timeseries: timeseries,
+	}
+	if e.config.Encoding == EncodingJSON {
+		return json.Marshal(writeRequest)
+	}
+
+	message := make([]byte, writeRequest.Size())
+	written, err := writeRequest.MarshalToSizedBuffer(message)
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, message[:written]), nil
+}
+
+// headers returns the headers of the messages, which mirror the headers of
+// a remote-write HTTP request.
+func (e *Exporter) headers() []sarama.RecordHeader {
+	headers := []sarama.RecordHeader{
+		{Key: []byte("X-Prometheus-Remote-Write-Version"), Value: []byte("0.1.0")},
+	}
+	if e.config.Encoding == EncodingJSON {
+		headers = append(headers, sarama.RecordHeader{Key: []byte("Content-Type"), Value: []byte("application/json")})
+	} else {
+		headers = append(headers,
+			sarama.RecordHeader{Key: []byte("Content-Encoding"), Value: []byte("snappy")},
+			sarama.RecordHeader{Key: []byte("Content-Type"), Value: []byte("application/x-protobuf")},
+		)
+	}
+	if e.config.Tenant != "" {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(TenantHeader), Value: []byte(e.config.Tenant)})
+	}
+	return headers
+}
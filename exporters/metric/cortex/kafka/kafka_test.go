@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+)
+
+// fakeProducer is a sarama.SyncProducer recording the sent messages.
+type fakeProducer struct {
+	messages []*sarama.ProducerMessage
+}
+
+func (p *fakeProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	p.messages = append(p.messages, msg)
+	return 0, int64(len(p.messages)), nil
+}
+
+func (p *fakeProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	p.messages = append(p.messages, msgs...)
+	return nil
+}
+
+func (p *fakeProducer) Close() error {
+	return nil
+}
+
+// exportCounters records two counters with exp and stops the controller, which
+// exports them.
+func exportCounters(t *testing.T, exp *Exporter) {
+	cont := controller.New(
+		processor.NewFactory(simple.NewWithInexpensiveDistribution(), export.CumulativeExportKindSelector()),
+		controller.WithExporter(exp),
+	)
+	ctx := context.Background()
+	require.NoError(t, cont.Start(ctx))
+	meter := cont.Meter("test")
+	metric.Must(meter).NewInt64Counter("requests").Add(ctx, 3)
+	metric.Must(meter).NewInt64Counter("errors").Add(ctx, 1)
+	require.NoError(t, cont.Stop(ctx))
+}
+
+func header(msg *sarama.ProducerMessage, key string) string {
+	for _, h := range msg.Headers {
+		if string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func encoded(t *testing.T, e sarama.Encoder) []byte {
+	b, err := e.Encode()
+	require.NoError(t, err)
+	return b
+}
+
+func TestExportByMetricName(t *testing.T) {
+	producer := &fakeProducer{}
+	exp, err := NewRawExporter(producer, Config{Topic: "metrics", Tenant: "team-a"})
+	require.NoError(t, err)
+
+	exportCounters(t, exp)
+
+	require.Len(t, producer.messages, 2)
+	for i, name := range []string{"errors", "requests"} {
+		msg := producer.messages[i]
+		assert.Equal(t, "metrics", msg.Topic)
+		assert.Equal(t, name, string(encoded(t, msg.Key)))
+		assert.Equal(t, "team-a", header(msg, TenantHeader))
+		assert.Equal(t, "snappy", header(msg, "Content-Encoding"))
+		assert.Equal(t, "application/x-protobuf", header(msg, "Content-Type"))
+
+		message, err := snappy.Decode(nil, encoded(t, msg.Value))
+		require.NoError(t, err)
+		var writeRequest prompb.WriteRequest
+		require.NoError(t, writeRequest.Unmarshal(message))
+		require.Len(t, writeRequest.Timeseries, 1)
+		assert.Equal(t, name, metricName(writeRequest.Timeseries[0]))
+	}
+}
+
+func TestExportByTenantAsJSON(t *testing.T) {
+	producer := &fakeProducer{}
+	exp, err := NewRawExporter(producer, Config{
+		Topic:        "metrics",
+		Tenant:       "team-a",
+		Encoding:     EncodingJSON,
+		Partitioning: PartitionByTenant,
+	})
+	require.NoError(t, err)
+
+	exportCounters(t, exp)
+
+	require.Len(t, producer.messages, 1)
+	msg := producer.messages[0]
+	assert.Equal(t, "team-a", string(encoded(t, msg.Key)))
+	assert.Equal(t, "application/json", header(msg, "Content-Type"))
+	assert.Empty(t, header(msg, "Content-Encoding"))
+
+	var writeRequest prompb.WriteRequest
+	require.NoError(t, json.Unmarshal(encoded(t, msg.Value), &writeRequest))
+	assert.Len(t, writeRequest.Timeseries, 2)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		err    error
+	}{
+		{"valid", Config{Topic: "metrics"}, nil},
+		{"no topic", Config{}, ErrNoTopic},
+		{"no tenant", Config{Topic: "metrics", Partitioning: PartitionByTenant}, ErrNoTenant},
+		{"invalid encoding", Config{Topic: "metrics", Encoding: Encoding(42)}, ErrInvalidEncoding},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.err, tt.config.Validate())
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"github.com/go-logr/logr"
+
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+)
+
+// config contains the options of the translation.
+type config struct {
+	exportKindSelector export.ExportKindSelector
+	logger             logr.Logger
+	prometheusNaming   bool
+	// labelConflictPolicy resolves the conflicts between the record and
+	// resource attributes.
+	labelConflictPolicy LabelConflictPolicy
+	// renamedLabelPrefix prefixes the keys of the record attributes renamed
+	// by RenameRecordLabels.
+	renamedLabelPrefix string
+}
+
+// Option applies a configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithExportKindSelector sets the selector of the export kind of the records,
+// which must match the export kind selector of the processor that produced them.
+// Defaults to export.CumulativeExportKindSelector, as Prometheus expects
+// cumulative values.
+func WithExportKindSelector(selector export.ExportKindSelector) Option {
+	return optionFunc(func(c *config) {
+		if selector != nil {
+			c.exportKindSelector = selector
+		}
+	})
+}
+
+// WithLogger sets the logger of the records that cannot be converted and of
+// the record attributes overwritten by Prometheus reserved labels. Defaults to
+// a logger discarding everything.
+func WithLogger(logger logr.Logger) Option {
+	return optionFunc(func(c *config) {
+		if logger != nil {
+			c.logger = logger
+		}
+	})
+}
+
+// WithPrometheusNaming makes the names of the time series follow the Prometheus
+// naming conventions, so that the recording rules and dashboards written for the
+// scraped metrics keep working: the Prometheus suffix of the unit of the
+// instrument, such as "_seconds" or "_bytes", is appended to the names, and
+// "_total" is appended to the names of the monotonic counters.
+func WithPrometheusNaming() Option {
+	return optionFunc(func(c *config) {
+		c.prometheusNaming = true
+	})
+}
+
+// WithLabelConflictPolicy sets how an attribute of a record is merged with the
+// attribute of the resource with the same key. Defaults to RecordLabelsWin. The
+// unknown policies are ignored.
+func WithLabelConflictPolicy(policy LabelConflictPolicy) Option {
+	return optionFunc(func(c *config) {
+		if policy != "" && policy.Valid() {
+			c.labelConflictPolicy = policy
+		}
+	})
+}
+
+// WithRenamedLabelPrefix sets the prefix of the keys of the record attributes
+// renamed by the RenameRecordLabels policy. Defaults to
+// DefaultRenamedLabelPrefix.
+func WithRenamedLabelPrefix(prefix string) Option {
+	return optionFunc(func(c *config) {
+		if prefix != "" {
+			c.renamedLabelPrefix = prefix
+		}
+	})
+}
+
+func newConfig(opts ...Option) config {
+	c := config{
+		exportKindSelector:  export.CumulativeExportKindSelector(),
+		logger:              logr.Discard(),
+		labelConflictPolicy: RecordLabelsWin,
+		renamedLabelPrefix:  DefaultRenamedLabelPrefix,
+	}
+	for _, o := range opts {
+		o.apply(&c)
+	}
+	return c
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/prometheus/prompb"
+
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+)
+
+// LabelConflictPolicy is how an attribute of a record is merged with the
+// attribute of the resource with the same key.
+type LabelConflictPolicy string
+
+const (
+	// RecordLabelsWin keeps the attribute of the record. It is the default
+	// policy.
+	RecordLabelsWin LabelConflictPolicy = "record_wins"
+
+	// ResourceLabelsWin keeps the attribute of the resource, for the
+	// deployments treating the resource attributes as authoritative.
+	ResourceLabelsWin LabelConflictPolicy = "resource_wins"
+
+	// LabelConflictError fails the conversion with an ErrLabelConflict.
+	LabelConflictError LabelConflictPolicy = "error"
+
+	// RenameRecordLabels keeps the attribute of the resource, and the attribute
+	// of the record with its key prefixed, like Prometheus renames the labels of
+	// the scraped metrics conflicting with the labels of the target.
+	RenameRecordLabels LabelConflictPolicy = "rename"
+)
+
+// DefaultRenamedLabelPrefix is the default prefix of the keys of the record
+// attributes renamed by RenameRecordLabels.
+const DefaultRenamedLabelPrefix = "exported_"
+
+// ErrLabelConflict occurs with the LabelConflictError policy when a record has
+// an attribute with the key of a resource attribute.
+var ErrLabelConflict = errors.New("record attribute conflicts with a resource attribute")
+
+// Valid returns whether p is a known policy. The empty policy is valid and is
+// RecordLabelsWin.
+func (p LabelConflictPolicy) Valid() bool {
+	switch p {
+	case "", RecordLabelsWin, ResourceLabelsWin, LabelConflictError, RenameRecordLabels:
+		return true
+	}
+	return false
+}
+
+// mergeAttributes merges the attributes of a record with the converted resource
+// attributes, which are both sorted by key, resolving the conflicts with the policy
+// of cfg.
+func mergeAttributes(cfg config, record export.Record, resourceLabels []attributeLabel) ([]attributeLabel, error) {
+	iter := record.Labels().Iter()
+	merged := make([]attributeLabel, 0, iter.Len()+len(resourceLabels))
+
+	r := 0
+	for iter.Next() {
+		attribute := iter.Label()
+		key := string(attribute.Key)
+		for ; r < len(resourceLabels) && resourceLabels[r].key < key; r++ {
+			merged = append(merged, resourceLabels[r])
+		}
+		recordLabel := attributeLabel{
+			key: key,
+			label: prompb.Label{
+				Name:  Sanitize(key),
+				Value: attribute.Value.Emit(),
+			},
+		}
+		if r == len(resourceLabels) || resourceLabels[r].key != key {
+			merged = append(merged, recordLabel)
+			continue
+		}
+
+		resourceLabel := resourceLabels[r]
+		r++
+		switch cfg.labelConflictPolicy {
+		case ResourceLabelsWin:
+			merged = append(merged, resourceLabel)
+		case LabelConflictError:
+			return nil, fmt.Errorf("%w: %s", ErrLabelConflict, key)
+		case RenameRecordLabels:
+			renamed := cfg.renamedLabelPrefix + key
+			recordLabel.key = renamed
+			recordLabel.label.Name = Sanitize(renamed)
+			merged = append(merged, resourceLabel, recordLabel)
+		default:
+			merged = append(merged, recordLabel)
+		}
+	}
+	return append(merged, resourceLabels[r:]...), nil
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestLabelConflictPolicy(t *testing.T) {
+	cont := collect(t, func(ctx context.Context, meter metric.Meter) {
+		metric.Must(meter).NewInt64Counter("requests").Add(ctx, 1, attribute.String("zone", "record"), attribute.String("http.method", "GET"))
+	})
+
+	testCases := []struct {
+		name     string
+		opts     []Option
+		expected map[string]string
+	}{
+		{
+			name: "default",
+			expected: map[string]string{
+				"__name__": "requests", "http_method": "GET", "service_name": "test", "zone": "record",
+			},
+		},
+		{
+			name: "record wins",
+			opts: []Option{WithLabelConflictPolicy(RecordLabelsWin)},
+			expected: map[string]string{
+				"__name__": "requests", "http_method": "GET", "service_name": "test", "zone": "record",
+			},
+		},
+		{
+			name: "resource wins",
+			opts: []Option{WithLabelConflictPolicy(ResourceLabelsWin)},
+			expected: map[string]string{
+				"__name__": "requests", "http_method": "GET", "service_name": "test", "zone": "resource",
+			},
+		},
+		{
+			name: "rename",
+			opts: []Option{WithLabelConflictPolicy(RenameRecordLabels)},
+			expected: map[string]string{
+				"__name__": "requests", "http_method": "GET", "service_name": "test", "zone": "resource", "exported_zone": "record",
+			},
+		},
+		{
+			name: "rename with prefix",
+			opts: []Option{WithLabelConflictPolicy(RenameRecordLabels), WithRenamedLabelPrefix("otel.")},
+			expected: map[string]string{
+				"__name__": "requests", "http_method": "GET", "service_name": "test", "zone": "resource", "otel_zone": "record",
+			},
+		},
+		{
+			name: "unknown policy",
+			opts: []Option{WithLabelConflictPolicy(ResourceLabelsWin), WithLabelConflictPolicy("unknown")},
+			expected: map[string]string{
+				"__name__": "requests", "http_method": "GET", "service_name": "test", "zone": "resource",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeseries, err := ConvertToTimeSeries(testResource, cont, tc.opts...)
+			require.NoError(t, err)
+			require.Len(t, timeseries, 1)
+			assert.Equal(t, tc.expected, labelMap(timeseries[0]))
+		})
+	}
+}
+
+func TestLabelConflictError(t *testing.T) {
+	conflicting := collect(t, func(ctx context.Context, meter metric.Meter) {
+		metric.Must(meter).NewInt64Counter("requests").Add(ctx, 1, attribute.String("zone", "record"))
+	})
+	_, err := ConvertToTimeSeries(testResource, conflicting, WithLabelConflictPolicy(LabelConflictError))
+	assert.True(t, errors.Is(err, ErrLabelConflict))
+	assert.Contains(t, err.Error(), "zone")
+
+	distinct := collect(t, func(ctx context.Context, meter metric.Meter) {
+		metric.Must(meter).NewInt64Counter("requests").Add(ctx, 1, attribute.String("region", "record"))
+	})
+	_, err = ConvertToTimeSeries(testResource, distinct, WithLabelConflictPolicy(LabelConflictError))
+	assert.NoError(t, err)
+}
+
+func TestLabelConflictPolicyValid(t *testing.T) {
+	for _, policy := range []LabelConflictPolicy{"", RecordLabelsWin, ResourceLabelsWin, LabelConflictError, RenameRecordLabels} {
+		assert.True(t, policy.Valid(), policy)
+	}
+	assert.False(t, LabelConflictPolicy("first_wins").Valid())
+}
@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prometheusremotewrite converts OpenTelemetry metrics to the Prometheus remote
+// write TimeSeries.
+//
+// It is used by the Cortex exporter, and does not depend on its HTTP client or push
+// pipeline, so that other exporters, agents, and tests can convert the checkpoint sets of
+// the SDK to prompb.TimeSeries.
+package prometheusremotewrite // import "go.opentelemetry.io/contrib/exporters/metric/cortex/prometheusremotewrite"
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"github.com/prometheus/prometheus/prompb"
+
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+// ConvertToMetadata returns the metadata of the metrics of a
+// InstrumentationLibraryReader, one per metric family, with the type of the metric
+// family, the description of the instrument as help, and the Prometheus unit of the
+// unit of the instrument. The metric family names are the names of the time series
+// converted with the same options.
+func ConvertToMetadata(checkpointSet export.InstrumentationLibraryReader, opts ...Option) ([]prompb.MetricMetadata, error) {
+	cfg := newConfig(opts...)
+	seen := make(map[string]struct{})
+	var metadata []prompb.MetricMetadata
+	err := checkpointSet.ForEach(func(library instrumentation.Library, reader export.Reader) error {
+		return reader.ForEach(cfg.exportKindSelector, func(record export.Record) error {
+			md, ok := cfg.metadata(record)
+			if !ok {
+				return nil
+			}
+			if _, ok := seen[md.MetricFamilyName]; ok {
+				return nil
+			}
+			seen[md.MetricFamilyName] = struct{}{}
+			metadata = append(metadata, md)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// metadata returns the metadata of the metric of record, and whether its
+// aggregation is converted.
+func (c config) metadata(record export.Record) (prompb.MetricMetadata, bool) {
+	descriptor := record.Descriptor()
+	md := prompb.MetricMetadata{
+		MetricFamilyName: c.metricName(descriptor),
+		Help:             descriptor.Description(),
+		Unit:             PrometheusUnit(descriptor.Unit()),
+	}
+	// The aggregations are checked in the order of ForEachTimeSeries.
+	switch record.Aggregation().(type) {
+	case aggregation.Histogram:
+		md.Type = prompb.MetricMetadata_HISTOGRAM
+	case aggregation.Sum:
+		md.Type = prompb.MetricMetadata_GAUGE
+		if descriptor.InstrumentKind().Monotonic() {
+			md.Type = prompb.MetricMetadata_COUNTER
+			md.MetricFamilyName = c.sumName(descriptor)
+		}
+	case aggregation.LastValue:
+		md.Type = prompb.MetricMetadata_GAUGE
+	default:
+		return prompb.MetricMetadata{}, false
+	}
+	return md, true
+}
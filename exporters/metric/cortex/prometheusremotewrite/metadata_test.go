@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestConvertToMetadata(t *testing.T) {
+	cont := collect(t, func(ctx context.Context, meter metric.Meter) {
+		metric.Must(meter).NewInt64Counter("http.requests", metric.WithDescription("Requests")).Add(ctx, 1, attribute.String("a", "1"))
+		metric.Must(meter).NewInt64Counter("http.requests", metric.WithDescription("Requests")).Add(ctx, 1, attribute.String("a", "2"))
+		metric.Must(meter).NewInt64UpDownCounter("queue.size").Add(ctx, 1)
+		metric.Must(meter).NewFloat64Histogram("latency", metric.WithUnit("s")).Record(ctx, 5)
+	})
+
+	metadata, err := ConvertToMetadata(cont, WithPrometheusNaming())
+	require.NoError(t, err)
+	sort.Slice(metadata, func(i, j int) bool {
+		return metadata[i].MetricFamilyName < metadata[j].MetricFamilyName
+	})
+	assert.Equal(t, []prompb.MetricMetadata{
+		{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "http_requests_total", Help: "Requests"},
+		{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency_seconds", Unit: "seconds"},
+		{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "queue_size"},
+	}, metadata)
+}
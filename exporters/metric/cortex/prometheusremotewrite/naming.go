@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+// prometheusUnits are the Prometheus units of the UCUM units, which the
+// OpenTelemetry units are.
+var prometheusUnits = map[string]string{
+	// Time
+	"d":   "days",
+	"h":   "hours",
+	"min": "minutes",
+	"s":   "seconds",
+	"ms":  "milliseconds",
+	"us":  "microseconds",
+	"ns":  "nanoseconds",
+
+	// Bytes
+	"By":    "bytes",
+	"KiBy":  "kibibytes",
+	"MiBy":  "mebibytes",
+	"GiBy":  "gibibytes",
+	"TiBy":  "tibibytes",
+	"KBy":   "kilobytes",
+	"MBy":   "megabytes",
+	"GBy":   "gigabytes",
+	"TBy":   "terabytes",
+	"bit":   "bits",
+	"Kibit": "kibibits",
+	"Mibit": "mebibits",
+	"Kbit":  "kilobits",
+	"Mbit":  "megabits",
+
+	// SI
+	"m":   "meters",
+	"V":   "volts",
+	"A":   "amperes",
+	"J":   "joules",
+	"W":   "watts",
+	"g":   "grams",
+	"Hz":  "hertz",
+	"Cel": "celsius",
+
+	// Misc
+	"1": "",
+	"%": "percent",
+	"$": "dollars",
+}
+
+// prometheusPerUnits are the Prometheus units of the UCUM units used as the
+// denominator of a unit, e.g. "By/s".
+var prometheusPerUnits = map[string]string{
+	"s":  "second",
+	"m":  "minute",
+	"h":  "hour",
+	"d":  "day",
+	"w":  "week",
+	"mo": "month",
+	"y":  "year",
+}
+
+// PrometheusUnit returns the Prometheus unit of a UCUM unit, such as "seconds" for
+// "s", "bytes_per_second" for "By/s", or "" for "1". The annotations in curly braces,
+// such as "{requests}", have no Prometheus unit. The units missing from the
+// conversion tables are sanitized.
+func PrometheusUnit(u unit.Unit) string {
+	s := strings.TrimSpace(string(u))
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		return ""
+	}
+
+	parts := strings.SplitN(s, "/", 2)
+	name, ok := prometheusUnits[parts[0]]
+	if !ok && !strings.HasPrefix(parts[0], "{") {
+		name = Sanitize(parts[0])
+	}
+	if len(parts) == 1 || parts[1] == "" {
+		return name
+	}
+	per, ok := prometheusPerUnits[parts[1]]
+	if !ok {
+		per = Sanitize(parts[1])
+	}
+	if name == "" {
+		return "per_" + per
+	}
+	return name + "_per_" + per
+}
+
+// MetricName returns the name of the time series of the metrics of descriptor,
+// before the suffixes of the time series such as "_count". With
+// WithPrometheusNaming, the Prometheus suffix of the unit of descriptor is
+// appended to the name.
+func MetricName(descriptor *metric.Descriptor, opts ...Option) string {
+	return newConfig(opts...).metricName(descriptor)
+}
+
+func (c config) metricName(descriptor *metric.Descriptor) string {
+	name := descriptor.Name()
+	if c.prometheusNaming {
+		name = appendSuffix(name, PrometheusUnit(descriptor.Unit()))
+	}
+	return Sanitize(name)
+}
+
+// sumName returns the name of the time series of the sums of descriptor. With
+// WithPrometheusNaming, "_total" is appended to the names of the monotonic sums.
+func (c config) sumName(descriptor *metric.Descriptor) string {
+	name := c.metricName(descriptor)
+	if c.prometheusNaming && descriptor.InstrumentKind().Monotonic() {
+		name = appendSuffix(name, "total")
+	}
+	return name
+}
+
+// appendSuffix appends "_" and suffix to name, unless suffix is empty or name
+// already ends with it.
+func appendSuffix(name, suffix string) string {
+	if suffix == "" || strings.HasSuffix(name, "_"+suffix) {
+		return name
+	}
+	return name + "_" + suffix
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/metric/sdkapi"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+func TestPrometheusNaming(t *testing.T) {
+	cont := collect(t, func(ctx context.Context, meter metric.Meter) {
+		metric.Must(meter).NewInt64Counter("http.requests").Add(ctx, 1)
+		metric.Must(meter).NewInt64Counter("received", metric.WithUnit(unit.Bytes)).Add(ctx, 1)
+		metric.Must(meter).NewInt64UpDownCounter("queue.size").Add(ctx, 1)
+		metric.Must(meter).NewFloat64Histogram("latency", metric.WithUnit("s")).Record(ctx, 5)
+	})
+
+	names := func(opts ...Option) []string {
+		timeseries, err := ConvertToTimeSeries(testResource, cont, opts...)
+		require.NoError(t, err)
+		var names []string
+		for _, ts := range timeseries {
+			if name := labelMap(ts)["__name__"]; !contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+	assert.Equal(t, []string{"http_requests", "latency", "latency_count", "latency_sum", "queue_size", "received"}, names())
+	assert.Equal(t, []string{
+		"http_requests_total",
+		"latency_seconds",
+		"latency_seconds_count",
+		"latency_seconds_sum",
+		"queue_size",
+		"received_bytes_total",
+	}, names(WithPrometheusNaming()))
+}
+
+func TestMetricName(t *testing.T) {
+	descriptor := metric.NewDescriptor("request.duration", sdkapi.CounterInstrumentKind, number.Float64Kind, "", unit.Milliseconds)
+	assert.Equal(t, "request_duration", MetricName(&descriptor))
+	assert.Equal(t, "request_duration_milliseconds", MetricName(&descriptor, WithPrometheusNaming()))
+
+	descriptor = metric.NewDescriptor("size_bytes", sdkapi.HistogramInstrumentKind, number.Int64Kind, "", unit.Bytes)
+	assert.Equal(t, "size_bytes", MetricName(&descriptor, WithPrometheusNaming()))
+}
+
+func TestPrometheusUnit(t *testing.T) {
+	for u, want := range map[unit.Unit]string{
+		"s":           "seconds",
+		"ms":          "milliseconds",
+		"By":          "bytes",
+		"MiBy":        "mebibytes",
+		"1":           "",
+		"%":           "percent",
+		"{requests}":  "",
+		"By/s":        "bytes_per_second",
+		"{packets}/s": "per_second",
+		"1/s":         "per_second",
+		"furlongs":    "furlongs",
+		"kg.m":        "kg_m",
+		"":            "",
+	} {
+		assert.Equal(t, want, PrometheusUnit(u), "unit %q", u)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
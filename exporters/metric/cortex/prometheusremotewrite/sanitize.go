@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// maxSanitizeCacheSize is the maximum number of names held by the sanitized
+// name cache. The cache is emptied when it is full, so that a churning set of
+// names cannot grow it without bound.
+const maxSanitizeCacheSize = 4096
+
+// sanitizeCache memoizes the sanitized metric and label names, which are
+// mostly the same from one export to the next.
+var sanitizeCache = struct {
+	sync.RWMutex
+	names map[string]string
+}{names: make(map[string]string)}
+
+// Sanitize replaces the non-alphanumeric characters of s with underscores so
+// that it is a valid Prometheus metric or label name. A key prefix is added
+// if s starts with a digit or an underscore.
+//
+// The sanitized names are cached, so that the names of a stable set of
+// metrics are only sanitized once.
+func Sanitize(s string) string {
+	sanitizeCache.RLock()
+	name, ok := sanitizeCache.names[s]
+	sanitizeCache.RUnlock()
+	if ok {
+		return name
+	}
+
+	name = sanitize(s)
+
+	sanitizeCache.Lock()
+	if len(sanitizeCache.names) >= maxSanitizeCacheSize {
+		sanitizeCache.names = make(map[string]string)
+	}
+	sanitizeCache.names[s] = name
+	sanitizeCache.Unlock()
+	return name
+}
+
+// This is a copy of opentelemetry-go/sdk/internal/sanitize.go
+
+func sanitize(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+
+	s = strings.Map(sanitizeRune, s)
+	if unicode.IsDigit(rune(s[0])) {
+		s = "key_" + s
+	}
+	if s[0] == '_' {
+		s = "key" + s
+	}
+	return s
+}
+
+// converts anything that is not a letter or digit to an underscore
+func sanitizeRune(r rune) rune {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return r
+	}
+	// Everything else turns into an underscore
+	return '_'
+}
@@ -12,9 +12,10 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package cortex
+package prometheusremotewrite
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -58,9 +59,43 @@ func TestSanitize(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got, want := sanitize(tt.input), tt.want; got != want {
+			if got, want := Sanitize(tt.input), tt.want; got != want {
 				t.Errorf("Sanitize() = %q; want %q", got, want)
 			}
 		})
 	}
 }
+
+func TestSanitizeCache(t *testing.T) {
+	if got, want := Sanitize("cached/name"), "cached_name"; got != want {
+		t.Fatalf("Sanitize() = %q; want %q", got, want)
+	}
+	sanitizeCache.RLock()
+	got, ok := sanitizeCache.names["cached/name"]
+	sanitizeCache.RUnlock()
+	if !ok || got != "cached_name" {
+		t.Errorf("cached name = %q, %t; want %q, true", got, ok, "cached_name")
+	}
+	if got, want := Sanitize("cached/name"), "cached_name"; got != want {
+		t.Errorf("cached Sanitize() = %q; want %q", got, want)
+	}
+}
+
+func TestSanitizeCacheBounded(t *testing.T) {
+	for i := 0; i < 2*maxSanitizeCacheSize; i++ {
+		Sanitize(fmt.Sprintf("name-%d", i))
+	}
+	sanitizeCache.RLock()
+	size := len(sanitizeCache.names)
+	sanitizeCache.RUnlock()
+	if size > maxSanitizeCacheSize {
+		t.Errorf("cache size = %d; want at most %d", size, maxSanitizeCacheSize)
+	}
+}
+
+func BenchmarkSanitize(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Sanitize("http.server.request_duration")
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/prometheus/prompb"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+type exportData struct {
+	export.Record
+
+	// attributes are the attributes of the record merged with the attributes
+	// of the resource.
+	attributes []attributeLabel
+	config     config
+}
+
+// attributeLabel is an attribute converted to a prompb.Label, along with the
+// attribute key the label name was sanitized from.
+type attributeLabel struct {
+	key   string
+	label prompb.Label
+}
+
+// ConvertToTimeSeries converts a InstrumentationLibraryReader to a slice of TimeSeries.
+// Based on the aggregation type, ConvertToTimeSeries will call helper functions like
+// convertFromSum to generate the correct number of TimeSeries. The labels of each
+// TimeSeries are created like with Labels, but the conflicts between the record and
+// resource attributes are resolved with the policy set by WithLabelConflictPolicy.
+func ConvertToTimeSeries(res *resource.Resource, checkpointSet export.InstrumentationLibraryReader, opts ...Option) ([]prompb.TimeSeries, error) {
+	var timeSeries []prompb.TimeSeries
+	err := ForEachTimeSeries(res, checkpointSet, func(ts prompb.TimeSeries) error {
+		timeSeries = append(timeSeries, ts)
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return timeSeries, nil
+}
+
+// ForEachTimeSeries converts a InstrumentationLibraryReader like ConvertToTimeSeries,
+// but calls timeSeriesFunc with every TimeSeries as soon as it is converted instead of
+// returning them all, so that they can be encoded without keeping them in memory.
+// ForEachTimeSeries stops at the first error returned by timeSeriesFunc, or at the
+// first ErrLabelConflict with the LabelConflictError policy, and returns it.
+func ForEachTimeSeries(res *resource.Resource, checkpointSet export.InstrumentationLibraryReader, timeSeriesFunc func(prompb.TimeSeries) error, opts ...Option) error {
+	cfg := newConfig(opts...)
+
+	// The resource attributes are the same for every record, so they are only
+	// converted once.
+	resourceLabels := convertAttributes(res.Set())
+
+	// Iterate over each record in the checkpoint set and convert to TimeSeries
+	return checkpointSet.ForEach(func(library instrumentation.Library, reader export.Reader) error {
+		return reader.ForEach(cfg.exportKindSelector, func(record export.Record) error {
+			attributes, err := mergeAttributes(cfg, record, resourceLabels)
+			if err != nil {
+				return err
+			}
+
+			// Convert based on aggregation type
+			edata := exportData{
+				Record:     record,
+				attributes: attributes,
+				config:     cfg,
+			}
+			agg := record.Aggregation()
+
+			// The following section uses loose type checking to determine how to
+			// convert aggregations to timeseries. More "expensive" timeseries are
+			// checked first.
+			//
+			// See the Aggregator Kind for more information
+			// https://github.com/open-telemetry/opentelemetry-go/blob/main/sdk/export/metric/aggregation/aggregation.go#L123-L138
+			var timeSeries []prompb.TimeSeries
+			if histogram, ok := agg.(aggregation.Histogram); ok {
+				tSeries, err := convertFromHistogram(edata, histogram)
+				if err != nil {
+					return err
+				}
+				timeSeries = tSeries
+			} else if sum, ok := agg.(aggregation.Sum); ok {
+				tSeries, err := convertFromSum(edata, sum)
+				if err != nil {
+					return err
+				}
+				timeSeries = append(timeSeries, tSeries)
+				if minMaxSumCount, ok := agg.(aggregation.MinMaxSumCount); ok {
+					tSeries, err := convertFromMinMaxSumCount(edata, minMaxSumCount)
+					if err != nil {
+						return err
+					}
+					timeSeries = append(timeSeries, tSeries...)
+				}
+			} else if lastValue, ok := agg.(aggregation.LastValue); ok {
+				tSeries, err := convertFromLastValue(edata, lastValue)
+				if err != nil {
+					return err
+				}
+				timeSeries = append(timeSeries, tSeries)
+			} else {
+				// Report to the user when no conversion was found
+				cfg.logger.Info("No conversion found for record", "name", edata.Descriptor().Name())
+			}
+
+			for _, ts := range timeSeries {
+				if err := timeSeriesFunc(ts); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// createTimeSeries is a helper function to create a timeseries from a value and attributes
+func createTimeSeries(edata exportData, value number.Number, valueNumberKind number.Kind, extraAttributes ...attribute.KeyValue) prompb.TimeSeries {
+	sample := prompb.Sample{
+		Value:     value.CoerceToFloat64(valueNumberKind),
+		Timestamp: int64(time.Nanosecond) * edata.EndTime().UnixNano() / int64(time.Millisecond),
+	}
+
+	attributes := labels(edata.config.logger, edata.attributes, extraAttributes...)
+
+	return prompb.TimeSeries{
+		Samples: []prompb.Sample{sample},
+		Labels:  attributes,
+	}
+}
+
+// convertFromSum returns a single TimeSeries based on a Record with a Sum aggregation
+func convertFromSum(edata exportData, sum aggregation.Sum) (prompb.TimeSeries, error) {
+	// Get Sum value
+	value, err := sum.Sum()
+	if err != nil {
+		return prompb.TimeSeries{}, err
+	}
+
+	// Create TimeSeries. Note that Cortex requires the name attribute to be in the format
+	// "__name__". This is the case for all time series created by this exporter.
+	name := edata.config.sumName(edata.Descriptor())
+	numberKind := edata.Descriptor().NumberKind()
+	tSeries := createTimeSeries(edata, value, numberKind, attribute.String("__name__", name))
+
+	return tSeries, nil
+}
+
+// convertFromLastValue returns a single TimeSeries based on a Record with a LastValue aggregation
+func convertFromLastValue(edata exportData, lastValue aggregation.LastValue) (prompb.TimeSeries, error) {
+	// Get value
+	value, _, err := lastValue.LastValue()
+	if err != nil {
+		return prompb.TimeSeries{}, err
+	}
+
+	// Create TimeSeries
+	name := edata.config.metricName(edata.Descriptor())
+	numberKind := edata.Descriptor().NumberKind()
+	tSeries := createTimeSeries(edata, value, numberKind, attribute.String("__name__", name))
+
+	return tSeries, nil
+}
+
+// convertFromMinMaxSumCount returns 4 TimeSeries for the min, max, sum, and count from the mmsc aggregation
+func convertFromMinMaxSumCount(edata exportData, minMaxSumCount aggregation.MinMaxSumCount) ([]prompb.TimeSeries, error) {
+	numberKind := edata.Descriptor().NumberKind()
+	metricName := edata.config.metricName(edata.Descriptor())
+
+	// Convert Min
+	min, err := minMaxSumCount.Min()
+	if err != nil {
+		return nil, err
+	}
+	name := metricName + "_min"
+	minTimeSeries := createTimeSeries(edata, min, numberKind, attribute.String("__name__", name))
+
+	// Convert Max
+	max, err := minMaxSumCount.Max()
+	if err != nil {
+		return nil, err
+	}
+	name = metricName + "_max"
+	maxTimeSeries := createTimeSeries(edata, max, numberKind, attribute.String("__name__", name))
+
+	// Convert Count
+	count, err := minMaxSumCount.Count()
+	if err != nil {
+		return nil, err
+	}
+	name = metricName + "_count"
+	countTimeSeries := createTimeSeries(edata, number.NewInt64Number(int64(count)), number.Int64Kind, attribute.String("__name__", name))
+
+	// Return all timeSeries
+	tSeries := []prompb.TimeSeries{
+		minTimeSeries, maxTimeSeries, countTimeSeries,
+	}
+
+	return tSeries, nil
+}
+
+// convertFromHistogram returns len(histogram.Buckets) timeseries for a histogram aggregation
+func convertFromHistogram(edata exportData, histogram aggregation.Histogram) ([]prompb.TimeSeries, error) {
+	var timeSeries []prompb.TimeSeries
+	metricName := edata.config.metricName(edata.Descriptor())
+	numberKind := edata.Descriptor().NumberKind()
+
+	// Create Sum TimeSeries
+	sum, err := histogram.Sum()
+	if err != nil {
+		return nil, err
+	}
+	sumTimeSeries := createTimeSeries(edata, sum, numberKind, attribute.String("__name__", metricName+"_sum"))
+	timeSeries = append(timeSeries, sumTimeSeries)
+
+	// Handle Histogram buckets
+	buckets, err := histogram.Histogram()
+	if err != nil {
+		return nil, err
+	}
+
+	var totalCount float64
+	// counts maps from the bucket upper-bound to the cumulative count.
+	// The bucket with upper-bound +inf is not included.
+	counts := make(map[float64]float64, len(buckets.Boundaries))
+	for i, boundary := range buckets.Boundaries {
+		// Add bucket count to totalCount and record in map
+		totalCount += float64(buckets.Counts[i])
+		counts[boundary] = totalCount
+
+		// Add upper boundary as a attribute. e.g. {le="5"}
+		boundaryStr := strconv.FormatFloat(boundary, 'f', -1, 64)
+
+		// Create timeSeries and append
+		boundaryTimeSeries := createTimeSeries(edata, number.NewFloat64Number(totalCount), number.Float64Kind, attribute.String("__name__", metricName), attribute.String("le", boundaryStr))
+		timeSeries = append(timeSeries, boundaryTimeSeries)
+	}
+
+	// Include the +inf boundary in the total count
+	totalCount += float64(buckets.Counts[len(buckets.Counts)-1])
+
+	// Create a timeSeries for the +inf bucket and total count
+	// These are the same and are both required by Prometheus-based backends
+
+	upperBoundTimeSeries := createTimeSeries(edata, number.NewFloat64Number(totalCount), number.Float64Kind, attribute.String("__name__", metricName), attribute.String("le", "+inf"))
+
+	countTimeSeries := createTimeSeries(edata, number.NewFloat64Number(totalCount), number.Float64Kind, attribute.String("__name__", metricName+"_count"))
+
+	timeSeries = append(timeSeries, upperBoundTimeSeries)
+	timeSeries = append(timeSeries, countTimeSeries)
+
+	return timeSeries, nil
+}
+
+// Labels combines attributes from a Record, resource, and extra attributes to create a
+// slice of prompb.Label. The attributes of the record take precedence over the attributes
+// of the resource, and their names are sanitized with Sanitize. The extra attributes, e.g.
+// the metric name as __name__, take precedence over both and are not sanitized. The labels
+// are sorted by name.
+func Labels(record export.Record, res *resource.Resource, extraAttributes ...attribute.KeyValue) []prompb.Label {
+	// The record attributes win, so the attributes are merged without error.
+	attributes, _ := mergeAttributes(newConfig(), record, convertAttributes(res.Set()))
+	return labels(logr.Discard(), attributes, extraAttributes...)
+}
+
+// convertAttributes converts the attributes of set to labels with sanitized names,
+// in the order of the attribute keys.
+func convertAttributes(set *attribute.Set) []attributeLabel {
+	converted := make([]attributeLabel, 0, set.Len())
+	iter := set.Iter()
+	for iter.Next() {
+		attribute := iter.Label()
+		key := string(attribute.Key)
+		converted = append(converted, attributeLabel{
+			key: key,
+			label: prompb.Label{
+				Name:  Sanitize(key),
+				Value: attribute.Value.Emit(),
+			},
+		})
+	}
+	return converted
+}
+
+// labels implements Labels with the already merged record and resource attributes,
+// logging the attributes overwritten by the extra attributes with logger. The labels
+// are sorted by name.
+func labels(logger logr.Logger, attributes []attributeLabel, extraAttributes ...attribute.KeyValue) []prompb.Label {
+	labels := make([]prompb.Label, 0, len(attributes)+len(extraAttributes))
+
+	// Add the label converted from every attribute unless an extra attribute, e.g.
+	// the metric name or the attributes representing histogram buckets, overwrites
+	// it. In that case, the user is notified that a user created attribute is being
+	// overwritten by a Prometheus reserved label (e.g. 'le' for histograms).
+attributes:
+	for _, attribute := range attributes {
+		for _, extra := range extraAttributes {
+			if string(extra.Key) == attribute.key {
+				logger.Info("Attribute is overwritten. Check if Prometheus reserved labels are used.", "attribute", attribute.key)
+				continue attributes
+			}
+		}
+		labels = append(labels, attribute.label)
+	}
+
+	for _, attribute := range extraAttributes {
+		labels = append(labels, prompb.Label{
+			Name:  string(attribute.Key),
+			Value: attribute.Value.AsString(),
+		})
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Name < labels[j].Name
+	})
+	return labels
+}
@@ -0,0 +1,294 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var testResource = resource.NewSchemaless(attribute.String("service.name", "test"), attribute.String("zone", "resource"))
+
+// collect returns a controller that collected the measurements recorded by record.
+func collect(t *testing.T, record func(context.Context, metric.Meter)) *controller.Controller {
+	return collectHistograms(t, []float64{1, 10}, record)
+}
+
+// collectHistograms returns a controller that collected the measurements recorded by
+// record, with the histogram boundaries.
+func collectHistograms(t *testing.T, boundaries []float64, record func(context.Context, metric.Meter)) *controller.Controller {
+	cont := controller.New(
+		processor.NewFactory(
+			simple.NewWithHistogramDistribution(histogram.WithExplicitBoundaries(boundaries)),
+			export.CumulativeExportKindSelector(),
+		),
+		controller.WithResource(testResource),
+	)
+	ctx := context.Background()
+	record(ctx, cont.Meter("test"))
+	require.NoError(t, cont.Collect(ctx))
+	return cont
+}
+
+// labelMap returns the labels of ts as a map.
+func labelMap(ts prompb.TimeSeries) map[string]string {
+	m := make(map[string]string, len(ts.Labels))
+	for _, l := range ts.Labels {
+		m[l.Name] = l.Value
+	}
+	return m
+}
+
+func TestConvertSum(t *testing.T) {
+	cont := collect(t, func(ctx context.Context, meter metric.Meter) {
+		counter := metric.Must(meter).NewInt64Counter("http.requests")
+		counter.Add(ctx, 2, attribute.String("zone", "record"))
+		counter.Add(ctx, 3, attribute.String("zone", "record"))
+	})
+
+	timeseries, err := ConvertToTimeSeries(testResource, cont)
+	require.NoError(t, err)
+	require.Len(t, timeseries, 1)
+	assert.Equal(t, map[string]string{
+		"__name__":     "http_requests",
+		"service_name": "test",
+		"zone":         "record",
+	}, labelMap(timeseries[0]))
+	require.Len(t, timeseries[0].Samples, 1)
+	assert.Equal(t, 5.0, timeseries[0].Samples[0].Value)
+}
+
+func TestConvertHistogram(t *testing.T) {
+	cont := collect(t, func(ctx context.Context, meter metric.Meter) {
+		histo := metric.Must(meter).NewFloat64Histogram("latency")
+		for _, v := range []float64{0.5, 5, 50} {
+			histo.Record(ctx, v)
+		}
+	})
+
+	timeseries, err := ConvertToTimeSeries(testResource, cont)
+	require.NoError(t, err)
+
+	var got []string
+	values := make(map[string]float64)
+	for _, ts := range timeseries {
+		labels := labelMap(ts)
+		key := labels["__name__"]
+		if le, ok := labels["le"]; ok {
+			key += "{le=" + le + "}"
+		}
+		got = append(got, key)
+		values[key] = ts.Samples[0].Value
+	}
+	sort.Strings(got)
+	assert.Equal(t, []string{"latency_count", "latency_sum", "latency{le=+inf}", "latency{le=10}", "latency{le=1}"}, got)
+	assert.Equal(t, 55.5, values["latency_sum"])
+	assert.Equal(t, 1.0, values["latency{le=1}"])
+	assert.Equal(t, 2.0, values["latency{le=10}"])
+	assert.Equal(t, 3.0, values["latency{le=+inf}"])
+	assert.Equal(t, 3.0, values["latency_count"])
+}
+
+// TestConvertHistogramScenarios checks the buckets of histograms with custom boundaries,
+// empty buckets, and only the +Inf bucket.
+func TestConvertHistogramScenarios(t *testing.T) {
+	testCases := []struct {
+		name       string
+		boundaries []float64
+		values     []float64
+		// buckets are the cumulative counts by le label.
+		buckets map[string]float64
+		sum     float64
+	}{
+		{
+			name:       "custom boundaries",
+			boundaries: []float64{0.005, 0.25, 2.5, 1e3},
+			values:     []float64{0.001, 0.1, 0.2, 2, 999, 5000},
+			buckets:    map[string]float64{"0.005": 1, "0.25": 3, "2.5": 4, "1000": 5, "+inf": 6},
+			sum:        6001.301,
+		},
+		{
+			name:       "negative boundaries",
+			boundaries: []float64{-10, 0, 10},
+			values:     []float64{-20, -5, 5},
+			buckets:    map[string]float64{"-10": 1, "0": 2, "10": 3, "+inf": 3},
+			sum:        -20,
+		},
+		{
+			name:       "empty buckets",
+			boundaries: []float64{1, 2, 3, 4},
+			values:     []float64{3.5},
+			buckets:    map[string]float64{"1": 0, "2": 0, "3": 0, "4": 1, "+inf": 1},
+			sum:        3.5,
+		},
+		{
+			name:       "+Inf only",
+			boundaries: []float64{},
+			values:     []float64{1, 100, 1e6},
+			buckets:    map[string]float64{"+inf": 3},
+			sum:        1000101,
+		},
+		{
+			name:       "above all boundaries",
+			boundaries: []float64{1, 10},
+			values:     []float64{11, 12},
+			buckets:    map[string]float64{"1": 0, "10": 0, "+inf": 2},
+			sum:        23,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cont := collectHistograms(t, tc.boundaries, func(ctx context.Context, meter metric.Meter) {
+				histo := metric.Must(meter).NewFloat64Histogram("latency")
+				for _, v := range tc.values {
+					histo.Record(ctx, v)
+				}
+			})
+
+			timeseries, err := ConvertToTimeSeries(testResource, cont)
+			require.NoError(t, err)
+
+			buckets := make(map[string]float64)
+			var count, sum float64
+			for _, ts := range timeseries {
+				require.Len(t, ts.Samples, 1)
+				labels := labelMap(ts)
+				switch labels["__name__"] {
+				case "latency":
+					buckets[labels["le"]] = ts.Samples[0].Value
+				case "latency_count":
+					count = ts.Samples[0].Value
+				case "latency_sum":
+					sum = ts.Samples[0].Value
+				default:
+					t.Errorf("unexpected time series %v", labels)
+				}
+			}
+			assert.Equal(t, tc.buckets, buckets)
+			assert.Equal(t, float64(len(tc.values)), count)
+			assert.InDelta(t, tc.sum, sum, 1e-9)
+		})
+	}
+}
+
+func TestLabels(t *testing.T) {
+	cont := collect(t, func(ctx context.Context, meter metric.Meter) {
+		metric.Must(meter).NewInt64Counter("requests").Add(ctx, 1, attribute.String("http.method", "GET"), attribute.String("le", "user"))
+	})
+
+	var labels []prompb.Label
+	require.NoError(t, cont.ForEach(func(_ instrumentation.Library, reader export.Reader) error {
+		return reader.ForEach(export.CumulativeExportKindSelector(), func(record export.Record) error {
+			labels = Labels(record, testResource, attribute.String("__name__", "requests"), attribute.String("le", "+inf"))
+			return nil
+		})
+	}))
+
+	assert.Equal(t, map[string]string{
+		"__name__":     "requests",
+		"http_method":  "GET",
+		"le":           "+inf",
+		"service_name": "test",
+		"zone":         "resource",
+	}, labelMap(prompb.TimeSeries{Labels: labels}))
+	assert.True(t, sort.SliceIsSorted(labels, func(i, j int) bool {
+		return labels[i].Name < labels[j].Name
+	}))
+}
+
+// recordingLogger is a logr.Logger recording the messages of the Info calls.
+type recordingLogger struct {
+	logr.DiscardLogger
+	messages *[]string
+}
+
+func (l recordingLogger) Enabled() bool { return true }
+
+func (l recordingLogger) Info(msg string, _ ...interface{}) {
+	*l.messages = append(*l.messages, msg)
+}
+
+func (l recordingLogger) V(int) logr.Logger { return l }
+
+func TestLogger(t *testing.T) {
+	cont := collect(t, func(ctx context.Context, meter metric.Meter) {
+		metric.Must(meter).NewFloat64Histogram("latency").Record(ctx, 1, attribute.String("le", "user"))
+	})
+
+	var messages []string
+	_, err := ConvertToTimeSeries(testResource, cont, WithLogger(recordingLogger{messages: &messages}))
+	require.NoError(t, err)
+	assert.Contains(t, messages, "Attribute is overwritten. Check if Prometheus reserved labels are used.")
+
+	// The default logger discards everything.
+	messages = nil
+	_, err = ConvertToTimeSeries(testResource, cont)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestForEachTimeSeries(t *testing.T) {
+	cont := collect(t, func(ctx context.Context, meter metric.Meter) {
+		metric.Must(meter).NewFloat64Histogram("latency").Record(ctx, 5)
+		metric.Must(meter).NewInt64Counter("requests").Add(ctx, 1)
+	})
+
+	want, err := ConvertToTimeSeries(testResource, cont)
+	require.NoError(t, err)
+
+	var got []prompb.TimeSeries
+	require.NoError(t, ForEachTimeSeries(testResource, cont, func(ts prompb.TimeSeries) error {
+		got = append(got, ts)
+		return nil
+	}))
+	require.Len(t, got, len(want))
+	series := func(timeseries []prompb.TimeSeries) []map[string]string {
+		var maps []map[string]string
+		for _, ts := range timeseries {
+			m := labelMap(ts)
+			m["value"] = fmt.Sprint(ts.Samples[0].Value)
+			maps = append(maps, m)
+		}
+		return maps
+	}
+	assert.ElementsMatch(t, series(want), series(got))
+
+	// The first error stops the conversion.
+	var calls int
+	err = ForEachTimeSeries(testResource, cont, func(prompb.TimeSeries) error {
+		calls++
+		return assert.AnError
+	})
+	assert.Equal(t, assert.AnError, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,297 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// Querier queries the time series written to a Prometheus-based backend, e.g. by an
+// Exporter, to verify what was exported.
+type Querier interface {
+	// Read returns the time series matching matchers with samples between start
+	// and end.
+	Read(ctx context.Context, start, end time.Time, matchers ...*prompb.LabelMatcher) ([]*prompb.TimeSeries, error)
+}
+
+// Backend is a Prometheus-based backend the time series are queried from with a
+// Querier returned by NewQuerier.
+type Backend string
+
+const (
+	// BackendCortex queries Cortex with the Prometheus Remote Read API.
+	BackendCortex Backend = "cortex"
+	// BackendMimir queries Grafana Mimir with the Prometheus Remote Read API.
+	BackendMimir Backend = "mimir"
+	// BackendThanos queries the Thanos Query component, which serves the time
+	// series of Thanos Receive, with the Prometheus HTTP API. Thanos Receive
+	// does not serve queries itself.
+	BackendThanos Backend = "thanos"
+	// BackendVictoriaMetrics queries VictoriaMetrics with the Prometheus HTTP
+	// API. With the cluster version, the base URL is the Prometheus API of the
+	// tenant on vmselect, e.g. "http://vmselect:8481/select/0/prometheus".
+	BackendVictoriaMetrics Backend = "victoriametrics"
+)
+
+// ErrUnknownBackend occurs when NewQuerier is called with an unknown Backend.
+var ErrUnknownBackend = fmt.Errorf("unknown backend")
+
+// Compile time assertions that the clients implement the Querier interface.
+var (
+	_ Querier = (*ReadClient)(nil)
+	_ Querier = (*QueryClient)(nil)
+)
+
+// NewQuerier returns a Querier of backend at baseURL, e.g. "http://cortex:9009", which
+// uses the authentication, TLS configuration, proxy, headers, and client of the Config.
+// The same verifications can then be run against all the backends.
+func NewQuerier(backend Backend, baseURL string, config Config) (Querier, error) {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	switch backend {
+	case BackendCortex:
+		return NewReadClient(baseURL+"/api/prom/read", config)
+	case BackendMimir:
+		return NewReadClient(baseURL+"/prometheus/api/v1/read", config)
+	case BackendThanos, BackendVictoriaMetrics:
+		return NewQueryClient(baseURL, config)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, backend)
+	}
+}
+
+// QueryClient queries the time series written to a Prometheus-based backend with the
+// instant queries of the Prometheus HTTP API, for the backends not supporting the
+// Prometheus Remote Read API. The samples are selected with a range vector, so they
+// are returned as written.
+type QueryClient struct {
+	exporter Exporter
+	baseURL  string
+}
+
+// NewQueryClient validates the Config struct and creates a QueryClient querying the
+// Prometheus HTTP API at baseURL, e.g. "http://thanos-query:9090" for the
+// "http://thanos-query:9090/api/v1/query" endpoint. The authentication, TLS
+// configuration, proxy, headers, and client of the Config are used for the requests,
+// its Endpoint is not.
+func NewQueryClient(baseURL string, config Config) (*QueryClient, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &QueryClient{
+		exporter: Exporter{config: config},
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// queryResponse is the response of the query endpoint of the Prometheus HTTP API.
+type queryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string       `json:"resultType"`
+		Result     model.Matrix `json:"result"`
+	} `json:"data"`
+}
+
+// APIError is a failed response of the Prometheus HTTP API.
+type APIError struct {
+	// StatusCode and Status are the HTTP status of the response, e.g. 400 and
+	// "400 Bad Request".
+	StatusCode int
+	Status     string
+	// Type and Message are the errorType and error of the response, e.g.
+	// "bad_data" and "parse error". They are empty when the body of the
+	// response is not a response of the Prometheus HTTP API, e.g. of a proxy.
+	Type    string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Type == "" {
+		return e.Status
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Status, e.Type, e.Message)
+}
+
+// Temporary returns whether the query may succeed when retried, i.e. when the backend
+// was unavailable, overloaded, or timed out.
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+const (
+	// queryAttempts is the number of attempts of a query failing with a temporary
+	// error.
+	queryAttempts = 3
+	// queryBackoff is the delay before the first retry of a query, doubled before
+	// every other retry.
+	queryBackoff = 100 * time.Millisecond
+)
+
+// Read returns the time series matching matchers with samples after start and up to
+// end. The metric name is matched with the "__name__" label. Every attempt times out
+// after the RemoteTimeout of the Config, and the queries failing with a network
+// error, a timeout, or a temporary APIError are retried twice, unless ctx is done.
+func (c *QueryClient) Read(ctx context.Context, start, end time.Time, matchers ...*prompb.LabelMatcher) ([]*prompb.TimeSeries, error) {
+	query, err := rangeSelector(end.Sub(start), matchers)
+	if err != nil {
+		return nil, err
+	}
+	values := url.Values{
+		"query": {query},
+		"time":  {strconv.FormatFloat(float64(end.UnixNano())/float64(time.Second), 'f', -1, 64)},
+	}
+
+	backoff := queryBackoff
+	for attempt := 1; ; attempt++ {
+		timeseries, temporary, err := c.query(ctx, values)
+		if err == nil || !temporary || attempt == queryAttempts || ctx.Err() != nil {
+			return timeseries, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// query sends a single query with values, returning whether its error is temporary.
+func (c *QueryClient) query(ctx context.Context, values url.Values) ([]*prompb.TimeSeries, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.exporter.config.RemoteTimeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+"/api/v1/query?"+values.Encode(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.exporter.addConfigHeaders(req); err != nil {
+		return nil, false, err
+	}
+
+	if c.exporter.config.Client == nil {
+		client, err := c.exporter.buildClient()
+		if err != nil {
+			return nil, false, err
+		}
+		c.exporter.config.Client = client
+	}
+	res, err := c.exporter.config.Client.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, true, err
+	}
+	var response queryResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		if res.StatusCode != http.StatusOK {
+			apiErr := &APIError{StatusCode: res.StatusCode, Status: res.Status}
+			return nil, apiErr.Temporary(), apiErr
+		}
+		return nil, false, err
+	}
+	if response.Status != "success" {
+		apiErr := &APIError{StatusCode: res.StatusCode, Status: res.Status, Type: response.ErrorType, Message: response.Error}
+		return nil, apiErr.Temporary(), apiErr
+	}
+	if response.Data.ResultType != "matrix" {
+		return nil, false, fmt.Errorf("unexpected result type %q", response.Data.ResultType)
+	}
+	return fromMatrix(response.Data.Result), false, nil
+}
+
+// rangeSelector returns the PromQL range vector selector of the samples of the last
+// rangeDuration matching matchers.
+func rangeSelector(rangeDuration time.Duration, matchers []*prompb.LabelMatcher) (string, error) {
+	ms := rangeDuration.Milliseconds()
+	if ms <= 0 {
+		return "", fmt.Errorf("invalid query range %v", rangeDuration)
+	}
+
+	var selector strings.Builder
+	selector.WriteByte('{')
+	for i, m := range matchers {
+		if i > 0 {
+			selector.WriteByte(',')
+		}
+		var op string
+		switch m.Type {
+		case prompb.LabelMatcher_EQ:
+			op = "="
+		case prompb.LabelMatcher_NEQ:
+			op = "!="
+		case prompb.LabelMatcher_RE:
+			op = "=~"
+		case prompb.LabelMatcher_NRE:
+			op = "!~"
+		default:
+			return "", fmt.Errorf("invalid label matcher type %v", m.Type)
+		}
+		selector.WriteString(m.Name + op + strconv.Quote(m.Value))
+	}
+	fmt.Fprintf(&selector, "}[%dms]", ms)
+	return selector.String(), nil
+}
+
+// fromMatrix converts the time series of a Prometheus HTTP API matrix result, with
+// their labels sorted by name as in a remote read response.
+func fromMatrix(matrix model.Matrix) []*prompb.TimeSeries {
+	if len(matrix) == 0 {
+		return nil
+	}
+	timeseries := make([]*prompb.TimeSeries, 0, len(matrix))
+	for _, stream := range matrix {
+		ts := &prompb.TimeSeries{
+			Labels:  make([]prompb.Label, 0, len(stream.Metric)),
+			Samples: make([]prompb.Sample, 0, len(stream.Values)),
+		}
+		for name, value := range stream.Metric {
+			ts.Labels = append(ts.Labels, prompb.Label{Name: string(name), Value: string(value)})
+		}
+		sort.Slice(ts.Labels, func(i, j int) bool {
+			return ts.Labels[i].Name < ts.Labels[j].Name
+		})
+		for _, pair := range stream.Values {
+			ts.Samples = append(ts.Samples, prompb.Sample{
+				Value:     float64(pair.Value),
+				Timestamp: int64(pair.Timestamp),
+			})
+		}
+		timeseries = append(timeseries, ts)
+	}
+	return timeseries
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQueryClient tests whether a QueryClient sends an instant query of a range vector
+// and converts the returned matrix.
+func TestQueryClient(t *testing.T) {
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/api/v1/query", req.URL.Path)
+		assert.Equal(t, `{__name__="test_name",zone!~"a|b"}[100000ms]`, req.URL.Query().Get("query"))
+		assert.Equal(t, "200", req.URL.Query().Get("time"))
+		assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+		_, _ = rw.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"zone":"c","__name__":"test_name"},"values":[[150,"123"],[160.5,"124.5"]]}
+		]}}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	client, err := NewQueryClient(server.URL+"/", Config{BearerToken: "token"})
+	require.NoError(t, err)
+
+	got, err := client.Read(context.Background(), time.Unix(100, 0), time.Unix(200, 0),
+		&prompb.LabelMatcher{Type: prompb.LabelMatcher_EQ, Name: "__name__", Value: "test_name"},
+		&prompb.LabelMatcher{Type: prompb.LabelMatcher_NRE, Name: "zone", Value: "a|b"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []*prompb.TimeSeries{{
+		Labels:  []prompb.Label{{Name: "__name__", Value: "test_name"}, {Name: "zone", Value: "c"}},
+		Samples: []prompb.Sample{{Value: 123, Timestamp: 150000}, {Value: 124.5, Timestamp: 160500}},
+	}}, got)
+}
+
+// TestQueryClientFailure tests whether a QueryClient returns the error of a failed query.
+func TestQueryClientFailure(t *testing.T) {
+	body := `{"status":"error","errorType":"bad_data","error":"parse error"}`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if body == "" {
+			http.NotFound(rw, req)
+			return
+		}
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = rw.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := NewQueryClient(server.URL, Config{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.Read(ctx, time.Unix(0, 0), time.Unix(1, 0))
+	assert.EqualError(t, err, "400 Bad Request: bad_data: parse error")
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, &APIError{StatusCode: http.StatusBadRequest, Status: "400 Bad Request", Type: "bad_data", Message: "parse error"}, apiErr)
+	assert.False(t, apiErr.Temporary())
+
+	body = ""
+	_, err = client.Read(ctx, time.Unix(0, 0), time.Unix(1, 0))
+	assert.EqualError(t, err, "404 Not Found")
+
+	_, err = client.Read(ctx, time.Unix(1, 0), time.Unix(1, 0))
+	assert.EqualError(t, err, "invalid query range 0s")
+}
+
+// TestQueryClientRetry tests whether a QueryClient retries the queries failing with a
+// temporary error, and only them.
+func TestQueryClientRetry(t *testing.T) {
+	var attempts int32
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			rw.WriteHeader(status)
+			_, _ = rw.Write([]byte(`{"status":"error","errorType":"unavailable","error":"no store"}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewQueryClient(server.URL, Config{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.Read(ctx, time.Unix(0, 0), time.Unix(1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+
+	atomic.StoreInt32(&attempts, 0)
+	status = http.StatusUnprocessableEntity
+	_, err = client.Read(ctx, time.Unix(0, 0), time.Unix(1, 0))
+	assert.EqualError(t, err, "422 Unprocessable Entity: unavailable: no store")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+// TestQueryClientTimeout tests whether every attempt of a query times out after the
+// RemoteTimeout, and whether the query is not retried after its context is done.
+func TestQueryClientTimeout(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		<-req.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := NewQueryClient(server.URL, Config{Client: server.Client(), RemoteTimeout: 20 * time.Millisecond})
+	require.NoError(t, err)
+
+	_, err = client.Read(context.Background(), time.Unix(0, 0), time.Unix(1, 0))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(queryAttempts), atomic.LoadInt32(&attempts))
+
+	atomic.StoreInt32(&attempts, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.Read(ctx, time.Unix(0, 0), time.Unix(1, 0))
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.LessOrEqual(t, atomic.LoadInt32(&attempts), int32(1))
+}
+
+// TestNewQuerier tests whether the Querier of every Backend queries its endpoint.
+func TestNewQuerier(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.URL.Path)
+		// The queries are authenticated and scoped to the tenant of the Config.
+		assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+		assert.Equal(t, "team-a", req.Header.Get(TenantHeader))
+		http.NotFound(rw, req)
+	}))
+	defer server.Close()
+
+	config := Config{BearerToken: "token", Tenant: "team-a"}
+	for _, backend := range []Backend{BackendCortex, BackendMimir, BackendThanos, BackendVictoriaMetrics} {
+		querier, err := NewQuerier(backend, server.URL, config)
+		require.NoError(t, err)
+		_, err = querier.Read(context.Background(), time.Unix(0, 0), time.Unix(1, 0))
+		assert.Error(t, err)
+	}
+	assert.Equal(t, []string{"/api/prom/read", "/prometheus/api/v1/read", "/api/v1/query", "/api/v1/query"}, paths)
+
+	_, err := NewQuerier("prometheus", server.URL, Config{})
+	assert.True(t, errors.Is(err, ErrUnknownBackend))
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/pkg/relabel"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// relabelTimeSeries applies the relabel configs to the labels of ts, like the
+// write_relabel_configs of the Prometheus remote_write configuration, and reports
+// whether ts is kept.
+func relabelTimeSeries(ts *prompb.TimeSeries, configs []*relabel.Config) bool {
+	builder := labels.NewBuilder(nil)
+	for _, l := range ts.Labels {
+		builder.Set(l.Name, l.Value)
+	}
+	relabeled := relabel.Process(builder.Labels(), configs...)
+	if relabeled == nil {
+		return false
+	}
+
+	ts.Labels = make([]prompb.Label, 0, len(relabeled))
+	for _, l := range relabeled {
+		ts.Labels = append(ts.Labels, prompb.Label{Name: l.Name, Value: l.Value})
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/relabel"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRelabelConfigs(t *testing.T) {
+	exporter := Exporter{config: Config{
+		WriteRelabelConfigs: []*relabel.Config{
+			{
+				SourceLabels: model.LabelNames{"__name__"},
+				Regex:        relabel.MustNewRegexp("metric_lastvalue"),
+				Action:       relabel.Drop,
+			},
+			{
+				SourceLabels: model.LabelNames{"R"},
+				Separator:    ";",
+				Regex:        relabel.MustNewRegexp("(.*)"),
+				TargetLabel:  "resource",
+				Replacement:  "r-$1",
+				Action:       relabel.Replace,
+			},
+			{
+				Regex:  relabel.MustNewRegexp("R"),
+				Action: relabel.LabelDrop,
+			},
+		},
+	}}
+
+	timeseries, err := exporter.ConvertToTimeSeries(testResource, getLastValueReader(t, 1))
+	require.NoError(t, err)
+	assert.Empty(t, timeseries)
+
+	timeseries, err = exporter.ConvertToTimeSeries(testResource, getSumReader(t, 1))
+	require.NoError(t, err)
+	require.Len(t, timeseries, 1)
+	assert.Equal(t, []prompb.Label{
+		{Name: "__name__", Value: "metric_sum"},
+		{Name: "resource", Value: "r-V"},
+	}, timeseries[0].Labels)
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// ReadClient queries the time series written to a Prometheus-based backend,
+// e.g. by an Exporter, with the Prometheus Remote Read API. It can be used to
+// verify what was exported, e.g. in integration tests.
+type ReadClient struct {
+	exporter Exporter
+	endpoint string
+}
+
+// NewReadClient validates the Config struct and creates a ReadClient querying
+// endpoint, e.g. "http://cortex:9009/api/prom/read". The authentication, TLS
+// configuration, proxy, headers, and client of the Config are used for the
+// requests, its Endpoint is not.
+func NewReadClient(endpoint string, config Config) (*ReadClient, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &ReadClient{
+		exporter: Exporter{config: config},
+		endpoint: endpoint,
+	}, nil
+}
+
+// Read returns the time series matching matchers with samples between start
+// and end. The metric name is matched with the "__name__" label, e.g.
+//
+//	client.Read(ctx, start, end, &prompb.LabelMatcher{
+//		Type:  prompb.LabelMatcher_EQ,
+//		Name:  "__name__",
+//		Value: "example_counter",
+//	})
+func (c *ReadClient) Read(ctx context.Context, start, end time.Time, matchers ...*prompb.LabelMatcher) ([]*prompb.TimeSeries, error) {
+	readRequest := &prompb.ReadRequest{
+		Queries: []*prompb.Query{{
+			StartTimestampMs: toMillis(start),
+			EndTimestampMs:   toMillis(end),
+			Matchers:         matchers,
+		}},
+	}
+	message, err := readRequest.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.buildRequest(ctx, snappy.Encode(nil, message))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.exporter.config.Client == nil {
+		client, err := c.exporter.buildClient()
+		if err != nil {
+			return nil, err
+		}
+		c.exporter.config.Client = client
+	}
+	res, err := c.exporter.config.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%v", res.Status)
+	}
+
+	compressed, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	body, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, err
+	}
+	var readResponse prompb.ReadResponse
+	if err := readResponse.Unmarshal(body); err != nil {
+		return nil, err
+	}
+	if len(readResponse.Results) == 0 {
+		return nil, nil
+	}
+	return readResponse.Results[0].Timeseries, nil
+}
+
+// buildRequest creates an http POST request with a Snappy-compressed protocol buffer
+// ReadRequest message as the body and with the remote read, authorization, and Config
+// headers attached.
+func (c *ReadClient) buildRequest(ctx context.Context, message []byte) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewBuffer(message))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Add("X-Prometheus-Remote-Read-Version", "0.1.0")
+	req.Header.Add("Content-Encoding", "snappy")
+	req.Header.Add("Accept-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	if err := c.exporter.addConfigHeaders(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// toMillis returns the Unix time of t in milliseconds.
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReadClient tests whether a ReadClient sends a remote read request and decodes the
+// returned time series.
+func TestReadClient(t *testing.T) {
+	start := time.Unix(100, 0)
+	end := time.Unix(200, 0)
+	matcher := &prompb.LabelMatcher{Type: prompb.LabelMatcher_EQ, Name: "__name__", Value: "test_name"}
+	want := []*prompb.TimeSeries{{
+		Labels:  []prompb.Label{{Name: "__name__", Value: "test_name"}},
+		Samples: []prompb.Sample{{Value: 123, Timestamp: 150000}},
+	}}
+
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "snappy", req.Header.Get("Content-Encoding"))
+		assert.Equal(t, "0.1.0", req.Header.Get("X-Prometheus-Remote-Read-Version"))
+		assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+
+		compressed, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		body, err := snappy.Decode(nil, compressed)
+		require.NoError(t, err)
+		var readRequest prompb.ReadRequest
+		require.NoError(t, readRequest.Unmarshal(body))
+		require.Len(t, readRequest.Queries, 1)
+		assert.Equal(t, int64(100000), readRequest.Queries[0].StartTimestampMs)
+		assert.Equal(t, int64(200000), readRequest.Queries[0].EndTimestampMs)
+		assert.Equal(t, []*prompb.LabelMatcher{matcher}, readRequest.Queries[0].Matchers)
+
+		readResponse := &prompb.ReadResponse{
+			Results: []*prompb.QueryResult{{Timeseries: want}},
+		}
+		message, err := readResponse.Marshal()
+		require.NoError(t, err)
+		_, _ = rw.Write(snappy.Encode(nil, message))
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	client, err := NewReadClient(server.URL, Config{BearerToken: "token"})
+	require.NoError(t, err)
+
+	got, err := client.Read(context.Background(), start, end, matcher)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+// TestReadClientFailure tests whether a ReadClient returns the status of a failed request.
+func TestReadClientFailure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client, err := NewReadClient(server.URL, Config{})
+	require.NoError(t, err)
+
+	_, err = client.Read(context.Background(), time.Unix(0, 0), time.Now())
+	require.EqualError(t, err, "404 Not Found")
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoSRVRecords occurs when the SRV name of the Config has no SRV records.
+var ErrNoSRVRecords = fmt.Errorf("no SRV records found")
+
+// resolvingTransport is the transport of the built client when the endpoint is
+// resolved periodically. It re-resolves the host of the requests, or the SRV name,
+// at most once per interval and closes the idle connections when the addresses
+// changed, so that the new connections are dialed to the new addresses instead of
+// reusing the connections to the addresses that were removed from the DNS.
+type resolvingTransport struct {
+	base     *http.Transport
+	interval time.Duration
+	srvName  string
+	// endpointHost is the host of the endpoint, the only host whose requests
+	// are sent to the target of the SRV records.
+	endpointHost string
+	lookupHost   func(ctx context.Context, host string) ([]string, error)
+	lookupSRV    func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+
+	mu sync.Mutex
+	// resolutions holds the addresses of every host requests were sent to, or
+	// of the SRV name.
+	resolutions map[string]*resolution
+	// target is the host and port of the SRV record the requests are sent to,
+	// and failed is the target of the last request that failed.
+	target string
+	failed string
+}
+
+// resolution is the result of the last resolution of a host or SRV name.
+type resolution struct {
+	resolved time.Time
+	addrs    []string
+}
+
+// newResolvingTransport returns a resolvingTransport using the DNS resolver of the
+// net package.
+func newResolvingTransport(base *http.Transport, interval time.Duration, srvName, endpointHost string) *resolvingTransport {
+	return &resolvingTransport{
+		base:         base,
+		interval:     interval,
+		srvName:      srvName,
+		endpointHost: endpointHost,
+		lookupHost:   net.DefaultResolver.LookupHost,
+		lookupSRV:    net.DefaultResolver.LookupSRV,
+		resolutions:  make(map[string]*resolution),
+	}
+}
+
+// RoundTrip sends the request with the base transport, to the target of the SRV
+// records when the transport has an SRV name and the request is sent to the host
+// of the endpoint. Other requests, e.g. redirects to other hosts, are sent as is.
+func (t *resolvingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name := req.URL.Hostname()
+	if t.srvName != "" {
+		if name != t.endpointHost {
+			return t.base.RoundTrip(req)
+		}
+		name = t.srvName
+	}
+	target, err := t.resolve(req.Context(), name)
+	if err != nil {
+		return nil, err
+	}
+	if target != "" {
+		// The request must not be modified by a RoundTripper.
+		req = req.Clone(req.Context())
+		req.URL.Host = target
+		req.Host = ""
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		// Resolve again on the next request, as the address may be gone, and
+		// prefer another SRV record.
+		t.mu.Lock()
+		if r, ok := t.resolutions[name]; ok {
+			r.resolved = time.Time{}
+		}
+		t.failed = target
+		t.mu.Unlock()
+	}
+	return res, err
+}
+
+// resolve resolves the host, or the SRV name, when the interval elapsed since it
+// was last resolved. It returns the target of the SRV records.
+func (t *resolvingTransport) resolve(ctx context.Context, name string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, ok := t.resolutions[name]
+	if !ok {
+		r = &resolution{}
+		t.resolutions[name] = r
+	}
+	if !r.resolved.IsZero() && time.Since(r.resolved) < t.interval {
+		return t.target, nil
+	}
+
+	// Keep the previous addresses when the DNS cannot be queried.
+	var addrs []string
+	if t.srvName != "" {
+		_, records, err := t.lookupSRV(ctx, "", "", name)
+		if err == nil && len(records) == 0 {
+			err = ErrNoSRVRecords
+		}
+		if err != nil {
+			if t.target != "" {
+				return t.target, nil
+			}
+			return "", err
+		}
+		// The records are sorted by priority and randomized by weight.
+		for _, record := range records {
+			addrs = append(addrs, net.JoinHostPort(strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(record.Port))))
+		}
+		t.target = t.selectTarget(addrs)
+	} else {
+		var err error
+		if addrs, err = t.lookupHost(ctx, name); err != nil {
+			// The base transport resolves the host again when dialing.
+			return "", nil
+		}
+	}
+	sort.Strings(addrs)
+
+	if r.addrs != nil && !equalAddrs(r.addrs, addrs) {
+		t.base.CloseIdleConnections()
+	}
+	r.resolved = time.Now()
+	r.addrs = addrs
+	return t.target, nil
+}
+
+// selectTarget returns the target the requests are sent to among the targets of
+// the SRV records, in order of preference. The current target is kept while it is
+// a target of the records and its requests succeed.
+func (t *resolvingTransport) selectTarget(targets []string) string {
+	failed := t.failed
+	t.failed = ""
+	for _, target := range targets {
+		if target == t.target && target != failed {
+			return target
+		}
+	}
+	for _, target := range targets {
+		if target != failed {
+			return target
+		}
+	}
+	return targets[0]
+}
+
+// CloseIdleConnections closes the idle connections of the base transport.
+func (t *resolvingTransport) CloseIdleConnections() {
+	t.base.CloseIdleConnections()
+}
+
+// equalAddrs returns whether two sorted lists of addresses are equal.
+func equalAddrs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResolvingTransportHost checks whether the connections are dialed again when the
+// addresses of the endpoint host change.
+func TestResolvingTransportHost(t *testing.T) {
+	var connections int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt64(&connections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	addrs := []string{"127.0.0.1"}
+	var lookups int
+	transport := newResolvingTransport(&http.Transport{}, time.Hour, "", "")
+	transport.lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		lookups++
+		return addrs, nil
+	}
+	client := http.Client{Transport: transport}
+
+	send := func() {
+		res, err := client.Get(server.URL)
+		require.NoError(t, err)
+		res.Body.Close()
+	}
+
+	// The connection is reused within the interval.
+	send()
+	send()
+	assert.Equal(t, 1, lookups)
+	assert.Equal(t, int64(1), atomic.LoadInt64(&connections))
+
+	// The connection is reused when the addresses did not change.
+	transport.resolutions["127.0.0.1"].resolved = time.Time{}
+	send()
+	assert.Equal(t, 2, lookups)
+	assert.Equal(t, int64(1), atomic.LoadInt64(&connections))
+
+	// A new connection is dialed when the addresses changed.
+	addrs = []string{"127.0.0.1", "127.0.0.2"}
+	transport.resolutions["127.0.0.1"].resolved = time.Time{}
+	send()
+	assert.Equal(t, 3, lookups)
+	assert.Equal(t, int64(2), atomic.LoadInt64(&connections))
+}
+
+// TestResolvingTransportHosts checks whether the connections are reused when the
+// requests alternate between hosts with different addresses.
+func TestResolvingTransportHosts(t *testing.T) {
+	var connections int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt64(&connections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	// Every request resolves its host again.
+	transport := newResolvingTransport(&http.Transport{}, time.Nanosecond, "", "")
+	transport.lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		return []string{host}, nil
+	}
+	client := http.Client{Transport: transport}
+
+	for i := 0; i < 4; i++ {
+		for _, host := range []string{"127.0.0.1", "localhost"} {
+			res, err := client.Get("http://" + net.JoinHostPort(host, serverURL.Port()))
+			require.NoError(t, err)
+			res.Body.Close()
+		}
+	}
+	assert.Equal(t, int64(2), atomic.LoadInt64(&connections))
+}
+
+// TestResolvingTransportSRV checks whether the requests are sent to the target of the
+// SRV records and fail over to another target when a request fails.
+func TestResolvingTransportSRV(t *testing.T) {
+	var received []string
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			received = append(received, name)
+		}))
+	}
+	first := newServer("first")
+	defer first.Close()
+	second := newServer("second")
+	defer second.Close()
+
+	record := func(server *httptest.Server) *net.SRV {
+		serverURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		port, err := strconv.Atoi(serverURL.Port())
+		require.NoError(t, err)
+		return &net.SRV{Target: "localhost.", Port: uint16(port)}
+	}
+	records := []*net.SRV{record(first), record(second)}
+
+	transport := newResolvingTransport(&http.Transport{}, time.Hour, "_cortex._tcp.example.com", "cortex.invalid")
+	transport.lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		assert.Equal(t, "_cortex._tcp.example.com", name)
+		return "", records, nil
+	}
+	client := http.Client{Transport: transport}
+
+	res, err := client.Get("http://cortex.invalid/api/prom/push")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, []string{"first"}, received)
+
+	// The request to the closed target fails and the next request is sent to the
+	// other target.
+	first.Close()
+	_, err = client.Get("http://cortex.invalid/api/prom/push")
+	require.Error(t, err)
+	res, err = client.Get("http://cortex.invalid/api/prom/push")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, []string{"first", "second"}, received)
+
+	// The requests to other hosts, e.g. redirects, are not sent to the targets.
+	other := newServer("other")
+	defer other.Close()
+	res, err = client.Get(other.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, []string{"first", "second", "other"}, received)
+
+	// No request is sent without SRV records.
+	records = nil
+	transport = newResolvingTransport(&http.Transport{}, time.Hour, "_cortex._tcp.example.com", "cortex.invalid")
+	transport.lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		return "", records, nil
+	}
+	client = http.Client{Transport: transport}
+	_, err = client.Get("http://cortex.invalid/api/prom/push")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrNoSRVRecords.Error())
+}
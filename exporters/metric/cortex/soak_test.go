@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+)
+
+var (
+	soakDuration = flag.Duration("soak", time.Second, "duration of TestSoak, e.g. 4h to run it as a soak test")
+	soakInterval = flag.Duration("soak.interval", 20*time.Millisecond, "push interval of TestSoak")
+)
+
+// soakBackend stores the samples of the remote write requests by the value of their
+// series label.
+type soakBackend struct {
+	mu      sync.Mutex
+	samples map[string][]prompb.Sample
+	// checked is the number of samples of every series already checked.
+	checked map[string]int
+}
+
+func (s *soakBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	compressed, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	body, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var request prompb.WriteRequest
+	if err := request.Unmarshal(body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ts := range request.Timeseries {
+		for _, l := range ts.Labels {
+			if l.Name == "series" {
+				s.samples[l.Value] = append(s.samples[l.Value], ts.Samples...)
+			}
+		}
+	}
+}
+
+// check returns an error if a counter series was reset or missed pushes of interval
+// since the previous check. Only the last checked sample of a series is kept.
+func (s *soakBackend) check(interval time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The pushes are late when the process is busy, e.g. with the race detector.
+	maxGap := 5 * interval.Milliseconds()
+	for key, samples := range s.samples {
+		for i := s.checked[key]; i < len(samples); i++ {
+			if i == 0 {
+				continue
+			}
+			previous, sample := samples[i-1], samples[i]
+			if sample.Value < previous.Value {
+				return fmt.Errorf("series %s was reset from %v to %v at %d", key, previous.Value, sample.Value, sample.Timestamp)
+			}
+			if gap := sample.Timestamp - previous.Timestamp; gap > maxGap {
+				return fmt.Errorf("series %s has no samples for %dms before %d", key, gap, sample.Timestamp)
+			}
+		}
+		s.samples[key] = samples[len(samples)-1:]
+		s.checked[key] = 1
+	}
+	return nil
+}
+
+// last returns the value of the last sample of every series.
+func (s *soakBackend) last() map[string]float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := make(map[string]float64, len(s.samples))
+	for key, samples := range s.samples {
+		values[key] = samples[len(samples)-1].Value
+	}
+	return values
+}
+
+// TestSoak exports counters for the duration set by the -soak flag, continuously
+// checking that the pushed counters are never reset and that no push interval is
+// missed, and finally that the last pushes hold the totals of the counters. The heap
+// and goroutines are logged with -v to spot leaks, e.g.
+//
+//	go test -run TestSoak -v -timeout 0 -soak 4h -soak.interval 15s
+func TestSoak(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the soak test in short mode")
+	}
+	const series = 10
+	interval := *soakInterval
+
+	backend := &soakBackend{samples: make(map[string][]prompb.Sample), checked: make(map[string]int)}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	exporter, err := NewRawExporter(Config{Endpoint: server.URL})
+	require.NoError(t, err)
+	// The test collects and exports the controller itself, as the ticker of the
+	// controller cannot be stopped while it collects with this version of the SDK.
+	cont := controller.New(
+		processor.NewFactory(testAggregatorSelector{}, export.CumulativeExportKindSelector()),
+		controller.WithCollectPeriod(0),
+	)
+	ctx := context.Background()
+	counter := metric.Must(cont.Meter("soak")).NewInt64Counter("soak_requests_sum")
+	push := func() {
+		require.NoError(t, cont.Collect(ctx))
+		require.NoError(t, exporter.Export(ctx, testResource, cont))
+	}
+
+	totals := make([]int64, series)
+	pushTicker := time.NewTicker(interval)
+	defer pushTicker.Stop()
+	checkTicker := time.NewTicker(10 * interval)
+	defer checkTicker.Stop()
+	logTicker := time.NewTicker(time.Minute)
+	defer logTicker.Stop()
+	deadline := time.After(*soakDuration)
+
+	logRuntime := func() {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		t.Logf("heap: %d bytes, %d objects, goroutines: %d", stats.HeapAlloc, stats.HeapObjects, runtime.NumGoroutine())
+	}
+	logRuntime()
+	for i := 0; ; i++ {
+		select {
+		case <-deadline:
+			push()
+			require.NoError(t, backend.check(interval))
+			logRuntime()
+
+			want := make(map[string]float64, series)
+			for s, total := range totals {
+				want[strconv.Itoa(s)] = float64(total)
+			}
+			assert.Equal(t, want, backend.last())
+			return
+		case <-pushTicker.C:
+			push()
+		case <-checkTicker.C:
+			require.NoError(t, backend.check(interval))
+		case <-logTicker.C:
+			logRuntime()
+		default:
+			s := i % series
+			counter.Add(ctx, int64(s+1), attribute.Int("series", s))
+			totals[s] += int64(s + 1)
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
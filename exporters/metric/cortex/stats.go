@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Stats are statistics of an Exporter, to troubleshoot the export of the metrics.
+type Stats struct {
+	// LastPush is the time of the last request sent to Cortex.
+	LastPush time.Time `json:"last_push"`
+	// LastSuccess is the time of the last request successfully sent.
+	LastSuccess time.Time `json:"last_success"`
+	// LastError is the error of the last request that failed, and LastErrorTime
+	// the time it was sent.
+	LastError     string    `json:"last_error,omitempty"`
+	LastErrorTime time.Time `json:"last_error_time"`
+	// Pushes and FailedPushes are the numbers of requests sent and of requests
+	// that failed.
+	Pushes       uint64 `json:"pushes"`
+	FailedPushes uint64 `json:"failed_pushes"`
+	// BytesSent is the number of bytes of the requests sent, after compression.
+	BytesSent uint64 `json:"bytes_sent"`
+	// Series is the number of time series of the last export.
+	Series int `json:"series"`
+	// QueueLength is the number of requests queued, see Config.QueueSize.
+	QueueLength int `json:"queue_length"`
+	// DroppedSeries and OutOfOrderSamples are the values returned by the
+	// methods of the Exporter.
+	DroppedSeries     uint64 `json:"dropped_series"`
+	OutOfOrderSamples uint64 `json:"out_of_order_samples"`
+}
+
+// PushSummary describes a request sent to Cortex, see Config.PushCallback.
+// It has no retry count, as the Exporter sends every request once and does
+// not retry failed requests.
+type PushSummary struct {
+	// Samples is the number of samples of the request.
+	Samples int
+	// Bytes is the size of the request, after compression.
+	Bytes int
+	// Duration is the time spent sending the request and waiting for the
+	// response.
+	Duration time.Duration
+	// Err is the error of the request if it failed, or nil.
+	Err error
+}
+
+// exportStats records the statistics of the exports and requests.
+type exportStats struct {
+	mu    sync.Mutex
+	stats Stats
+}
+
+// exported records an export of series time series.
+func (s *exportStats) exported(series int) {
+	s.mu.Lock()
+	s.stats.Series = series
+	s.mu.Unlock()
+}
+
+// sent records a request of size bytes, which failed with err if not nil.
+func (s *exportStats) sent(size int, err error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.LastPush = now
+	s.stats.Pushes++
+	s.stats.BytesSent += uint64(size)
+	if err != nil {
+		s.stats.LastError = err.Error()
+		s.stats.LastErrorTime = now
+		s.stats.FailedPushes++
+		return
+	}
+	s.stats.LastSuccess = now
+}
+
+// Stats returns the statistics of the Exporter. They can be published with the
+// expvar package:
+//
+//	expvar.Publish("cortex", expvar.Func(func() interface{} { return exporter.Stats() }))
+func (e *Exporter) Stats() Stats {
+	e.stats.mu.Lock()
+	stats := e.stats.stats
+	e.stats.mu.Unlock()
+
+	stats.QueueLength = e.QueueLength()
+	stats.DroppedSeries = e.DroppedSeries()
+	stats.OutOfOrderSamples = e.OutOfOrderSamples()
+	return stats
+}
+
+// DebugHandler returns an http.Handler serving the Stats of the Exporter as JSON,
+// which can be mounted on an admin mux to troubleshoot the export of the metrics.
+func (e *Exporter) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(e.Stats()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	exporter, err := NewRawExporter(Config{Endpoint: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, Stats{}, exporter.Stats())
+
+	ctx := context.Background()
+	require.NoError(t, exporter.Export(ctx, testResource, getHistogramReader(t)))
+	stats := exporter.Stats()
+	assert.Equal(t, uint64(1), stats.Pushes)
+	assert.Zero(t, stats.FailedPushes)
+	assert.Equal(t, len(wantHistogramTimeSeries), stats.Series)
+	assert.NotZero(t, stats.BytesSent)
+	assert.False(t, stats.LastPush.IsZero())
+	assert.Equal(t, stats.LastPush, stats.LastSuccess)
+	assert.Empty(t, stats.LastError)
+
+	status = http.StatusBadRequest
+	require.Error(t, exporter.Export(ctx, testResource, getSumReader(t, 1)))
+	stats = exporter.Stats()
+	assert.Equal(t, uint64(2), stats.Pushes)
+	assert.Equal(t, uint64(1), stats.FailedPushes)
+	assert.Equal(t, 1, stats.Series)
+	assert.Equal(t, "400 Bad Request", stats.LastError)
+	assert.Equal(t, stats.LastPush, stats.LastErrorTime)
+	assert.False(t, stats.LastPush.Before(stats.LastSuccess))
+}
+
+func TestDebugHandler(t *testing.T) {
+	exporter := Exporter{config: validConfig}
+	exporter.stats.exported(3)
+	exporter.stats.sent(42, nil)
+
+	recorder := httptest.NewRecorder()
+	exporter.DebugHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/cortex", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	assert.Equal(t, 3.0, got["series"])
+	assert.Equal(t, 42.0, got["bytes_sent"])
+	assert.Equal(t, 1.0, got["pushes"])
+	assert.NotContains(t, got, "last_error")
+}
+
+func TestPushCallback(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	var summaries []PushSummary
+	exporter, err := NewRawExporter(Config{
+		Endpoint: server.URL,
+		PushCallback: func(summary PushSummary) {
+			summaries = append(summaries, summary)
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, exporter.Export(ctx, testResource, getHistogramReader(t)))
+	status = http.StatusBadRequest
+	require.Error(t, exporter.Export(ctx, testResource, getSumReader(t, 1)))
+
+	require.Len(t, summaries, 2)
+	assert.Equal(t, len(wantHistogramTimeSeries), summaries[0].Samples)
+	assert.NotZero(t, summaries[0].Bytes)
+	assert.NotZero(t, summaries[0].Duration)
+	assert.NoError(t, summaries[0].Err)
+	assert.Equal(t, 1, summaries[1].Samples)
+	assert.EqualError(t, summaries[1].Err, "400 Bad Request")
+	assert.Equal(t, exporter.Stats().BytesSent, uint64(summaries[0].Bytes+summaries[1].Bytes))
+}
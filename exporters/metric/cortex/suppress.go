@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+// seriesKey identifies the series of a record.
+type seriesKey struct {
+	name  string
+	attrs attribute.Distinct
+}
+
+// sentValue is the last value sent for a series.
+type sentValue struct {
+	value number.Number
+	time  time.Time
+}
+
+// unchangedFilter skips the last-value records whose value did not change since
+// it was last sent, until the heartbeat interval elapsed.
+type unchangedFilter struct {
+	mu   sync.Mutex
+	sent map[seriesKey]sentValue
+}
+
+// reader returns a reader of the records of checkpointSet without the unchanged
+// last-value records. Only the series of the records read are remembered, so
+// that the series that are no longer exported are forgotten.
+func (f *unchangedFilter) reader(checkpointSet export.InstrumentationLibraryReader, heartbeat time.Duration) export.InstrumentationLibraryReader {
+	return filteredLibraryReader{
+		InstrumentationLibraryReader: checkpointSet,
+		filter:                       f,
+		heartbeat:                    heartbeat,
+	}
+}
+
+// reset forgets the values sent, so that all the series are sent again after a
+// failed push.
+func (f *unchangedFilter) reset() {
+	f.mu.Lock()
+	f.sent = nil
+	f.mu.Unlock()
+}
+
+// keep reports whether record must be sent, recording its value in sent when it
+// is.
+func (f *unchangedFilter) keep(record export.Record, heartbeat time.Duration, sent map[seriesKey]sentValue) bool {
+	lastValue, ok := record.Aggregation().(aggregation.LastValue)
+	if !ok {
+		return true
+	}
+	value, _, err := lastValue.LastValue()
+	if err != nil {
+		// Let the conversion handle the error.
+		return true
+	}
+
+	key := seriesKey{
+		name:  record.Descriptor().Name(),
+		attrs: record.Labels().Equivalent(),
+	}
+	previous, found := f.sent[key]
+	if found && previous.value == value && record.EndTime().Sub(previous.time) < heartbeat {
+		sent[key] = previous
+		return false
+	}
+	sent[key] = sentValue{value: value, time: record.EndTime()}
+	return true
+}
+
+// filteredLibraryReader is an export.InstrumentationLibraryReader skipping the
+// unchanged last-value records.
+type filteredLibraryReader struct {
+	export.InstrumentationLibraryReader
+	filter    *unchangedFilter
+	heartbeat time.Duration
+}
+
+// ForEach calls readerFunc with readers skipping the unchanged last-value records.
+func (r filteredLibraryReader) ForEach(readerFunc func(instrumentation.Library, export.Reader) error) error {
+	r.filter.mu.Lock()
+	defer r.filter.mu.Unlock()
+
+	sent := make(map[seriesKey]sentValue, len(r.filter.sent))
+	err := r.InstrumentationLibraryReader.ForEach(func(library instrumentation.Library, reader export.Reader) error {
+		return readerFunc(library, filteredReader{Reader: reader, r: r, sent: sent})
+	})
+	r.filter.sent = sent
+	return err
+}
+
+// filteredReader is an export.Reader skipping the unchanged last-value records.
+type filteredReader struct {
+	export.Reader
+	r    filteredLibraryReader
+	sent map[seriesKey]sentValue
+}
+
+// ForEach calls recordFunc with the records that must be sent.
+func (r filteredReader) ForEach(selector export.ExportKindSelector, recordFunc func(export.Record) error) error {
+	return r.Reader.ForEach(selector, func(record export.Record) error {
+		if !r.r.filter.keep(record, r.r.heartbeat, r.sent) {
+			return nil
+		}
+		return recordFunc(record)
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// convertLastValue converts a last value record of value with exporter, returning the
+// number of time series.
+func convertLastValue(t *testing.T, exporter *Exporter, value int64) int {
+	// Wait for the samples to have a timestamp after the samples of the previous
+	// conversion, as out of order samples are skipped.
+	time.Sleep(time.Millisecond)
+	timeseries, err := exporter.ConvertToTimeSeries(testResource, getLastValueReader(t, value))
+	require.NoError(t, err)
+	return len(timeseries)
+}
+
+func TestSuppressUnchanged(t *testing.T) {
+	config := Config{SuppressUnchanged: true}
+	require.NoError(t, config.Validate())
+	assert.Equal(t, 5*time.Minute, config.HeartbeatInterval)
+	exporter := Exporter{config: config}
+
+	convert := func(t *testing.T, value int64) int {
+		return convertLastValue(t, &exporter, value)
+	}
+	assert.Equal(t, 1, convert(t, 5), "first value")
+	assert.Equal(t, 0, convert(t, 5), "unchanged value")
+	assert.Equal(t, 1, convert(t, 6), "changed value")
+	assert.Equal(t, 0, convert(t, 6), "unchanged value")
+
+	exporter.series.unchanged.reset()
+	assert.Equal(t, 1, convert(t, 6), "value after reset")
+
+	// Sums are always sent.
+	for i := 0; i < 2; i++ {
+		time.Sleep(time.Millisecond)
+		timeseries, err := exporter.ConvertToTimeSeries(testResource, getSumReader(t, 1))
+		require.NoError(t, err)
+		assert.Len(t, timeseries, 1)
+	}
+}
+
+func TestSuppressUnchangedHeartbeat(t *testing.T) {
+	exporter := Exporter{config: Config{SuppressUnchanged: true, HeartbeatInterval: time.Nanosecond}}
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 1, convertLastValue(t, &exporter, 5))
+	}
+}
+
+func TestSuppressUnchangedDisabled(t *testing.T) {
+	exporter := Exporter{config: validConfig}
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, 1, convertLastValue(t, &exporter, 5))
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex // import "go.opentelemetry.io/contrib/exporters/metric/cortex"
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// tenantKey is the context key of the tenant set by ContextWithTenant.
+type tenantKey struct{}
+
+// ContextWithTenant returns a copy of parent with tenant as the tenant of the
+// requests made with it. The metrics exported with the returned context, e.g.
+// by a push Controller started with it, are pushed with the tenant in the
+// X-Scope-OrgID header, instead of the Config Tenant and of the X-Scope-OrgID of
+// the configured headers. This lets an application running a Controller per
+// tenant share one Exporter between them.
+func ContextWithTenant(parent context.Context, tenant string) context.Context {
+	return context.WithValue(parent, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant set by ContextWithTenant in ctx, and
+// whether it is set and not empty.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant, tenant != ""
+}
+
+// setContextTenant sets the X-Scope-OrgID header of a http request to the tenant
+// of its context, replacing the header set with any casing, and reports whether
+// the context has a tenant.
+func setContextTenant(req *http.Request) bool {
+	tenant, ok := TenantFromContext(req.Context())
+	if !ok {
+		return false
+	}
+	for key := range req.Header {
+		if strings.EqualFold(key, TenantHeader) {
+			delete(req.Header, key)
+		}
+	}
+	req.Header.Set(TenantHeader, tenant)
+	return true
+}
+
+// seriesState is the state kept between the pushes about the series of a tenant.
+type seriesState struct {
+	// unchanged skips the unchanged last-value records when
+	// Config.SuppressUnchanged is set.
+	unchanged unchangedFilter
+	// cardinality limits the number of series of every metric when
+	// Config.MaxSeriesPerMetric is set.
+	cardinality cardinalityLimiter
+	// timestamps skips the samples that are out of order.
+	timestamps timestampGuard
+}
+
+// seriesState returns the state of the series of tenant, or of the pushes without a
+// context tenant when it is empty.
+func (e *Exporter) seriesState(tenant string) *seriesState {
+	if tenant == "" {
+		return &e.series
+	}
+	e.tenantsMu.Lock()
+	defer e.tenantsMu.Unlock()
+	state, ok := e.tenants[tenant]
+	if !ok {
+		if e.tenants == nil {
+			e.tenants = make(map[string]*seriesState)
+		}
+		state = &seriesState{}
+		e.tenants[tenant] = state
+	}
+	return state
+}
+
+// forEachSeriesState calls fn with the state of the series of every tenant.
+func (e *Exporter) forEachSeriesState(fn func(*seriesState)) {
+	fn(&e.series)
+	e.tenantsMu.Lock()
+	defer e.tenantsMu.Unlock()
+	for _, state := range e.tenants {
+		fn(state)
+	}
+}
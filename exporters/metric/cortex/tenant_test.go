@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantFromContext(t *testing.T) {
+	_, ok := TenantFromContext(context.Background())
+	assert.False(t, ok)
+	_, ok = TenantFromContext(ContextWithTenant(context.Background(), ""))
+	assert.False(t, ok)
+
+	tenant, ok := TenantFromContext(ContextWithTenant(context.Background(), "team-a"))
+	assert.True(t, ok)
+	assert.Equal(t, "team-a", tenant)
+}
+
+// TestAddContextTenant tests whether the tenant of the context of a request replaces
+// the Config Tenant and the configured X-Scope-OrgID header.
+func TestAddContextTenant(t *testing.T) {
+	tenantHeader := func(ctx context.Context, config Config) []string {
+		req, err := http.NewRequestWithContext(ctx, "POST", "test.com", nil)
+		require.NoError(t, err)
+		exporter := Exporter{config: config}
+		require.NoError(t, exporter.addHeaders(req))
+		// The values of the X-Scope-OrgID headers with any casing.
+		var values []string
+		for key, v := range req.Header {
+			if strings.EqualFold(key, TenantHeader) {
+				values = append(values, v...)
+			}
+		}
+		return values
+	}
+
+	ctx := ContextWithTenant(context.Background(), "team-c")
+	assert.Equal(t, []string{"team-c"}, tenantHeader(ctx, Config{}))
+	assert.Equal(t, []string{"team-c"}, tenantHeader(ctx, Config{Tenant: "team-a"}))
+	assert.Equal(t, []string{"team-c"}, tenantHeader(ctx, Config{
+		Tenant:       "team-a",
+		Headers:      map[string]string{"x-scope-orgid": "team-b"},
+		HeaderValues: map[string][]string{"X-SCOPE-ORGID": {"team-b"}},
+	}))
+}
+
+// TestExportContextTenant tests whether the pushes are sent to the tenant of the
+// context of Export, including the pushes queued for the worker.
+func TestExportContextTenant(t *testing.T) {
+	for _, queueSize := range []int{0, 2} {
+		var (
+			mu      sync.Mutex
+			tenants []string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			tenants = append(tenants, r.Header.Get(TenantHeader))
+		}))
+
+		exporter, err := NewRawExporter(Config{Endpoint: server.URL, Tenant: "default", QueueSize: queueSize, DrainOnShutdown: true})
+		require.NoError(t, err)
+
+		ctx := context.Background()
+		require.NoError(t, exporter.Export(ContextWithTenant(ctx, "team-a"), testResource, getSumReader(t, 1)))
+		require.NoError(t, exporter.Export(ctx, testResource, getSumReader(t, 2)))
+		require.NoError(t, exporter.Shutdown(ctx))
+		server.Close()
+
+		assert.Equal(t, []string{"team-a", "default"}, tenants, "queue size %d", queueSize)
+	}
+}
+
+// TestExportTenantSeries tests whether the samples of the series of a tenant are
+// only out of order with the previous samples of the tenant.
+func TestExportTenantSeries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	exporter, err := NewRawExporter(Config{Endpoint: server.URL})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	reader := getSumReader(t, 1)
+	require.NoError(t, exporter.Export(ContextWithTenant(ctx, "team-a"), testResource, reader))
+	require.NoError(t, exporter.Export(ContextWithTenant(ctx, "team-b"), testResource, reader))
+	require.NoError(t, exporter.Export(ctx, testResource, reader))
+	assert.Equal(t, uint64(0), exporter.OutOfOrderSamples())
+
+	require.NoError(t, exporter.Export(ContextWithTenant(ctx, "team-a"), testResource, reader))
+	assert.Equal(t, uint64(1), exporter.OutOfOrderSamples())
+}
@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/prometheus/prometheus/prompb"
 	"github.com/stretchr/testify/require"
@@ -73,7 +72,7 @@ func (testAggregatorSelector) AggregatorFor(desc *metric.Descriptor, aggPtrs ...
 	}
 }
 
-func testMeter(t *testing.T) (context.Context, metric.Meter, *controller.Controller) {
+func testMeter(t testing.TB) (context.Context, metric.Meter, *controller.Controller) {
 	aggSel := testAggregatorSelector{}
 	proc := processor.NewFactory(aggSel, export.CumulativeExportKindSelector())
 	cont := controller.New(proc,
@@ -365,7 +364,3 @@ var wantHistogramTimeSeries = []*prompb.TimeSeries{
 		}},
 	},
 }
-
-func toMillis(t time.Time) int64 {
-	return t.UnixNano() / int64(time.Millisecond)
-}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"sync"
+
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// timestampGuard skips the samples whose timestamp is not after the timestamp of
+// the previous sample of their series, which Cortex rejects as out of order.
+type timestampGuard struct {
+	mu      sync.Mutex
+	last    map[string]int64
+	skipped uint64
+}
+
+// start starts guarding the time series of a push, until end is called on the
+// returned guardPush. Only the series of the push are remembered, so that the
+// series that are no longer exported are forgotten.
+func (g *timestampGuard) start() *guardPush {
+	g.mu.Lock()
+	return &guardPush{timestamps: g, last: make(map[string]int64, len(g.last))}
+}
+
+// guardPush guards the time series of a push.
+type guardPush struct {
+	timestamps *timestampGuard
+	last       map[string]int64
+	skipped    int
+}
+
+// guard returns ts without its samples that are out of order, and whether it has
+// samples left.
+func (p *guardPush) guard(ts prompb.TimeSeries) (prompb.TimeSeries, bool) {
+	key, _ := labelsKey(ts.Labels)
+	previous, found := p.last[key]
+	if !found {
+		previous, found = p.timestamps.last[key]
+	}
+
+	samples := ts.Samples[:0]
+	for _, sample := range ts.Samples {
+		if found && sample.Timestamp <= previous {
+			p.skipped++
+			continue
+		}
+		samples = append(samples, sample)
+		previous, found = sample.Timestamp, true
+	}
+	if found {
+		p.last[key] = previous
+	}
+	ts.Samples = samples
+	return ts, len(samples) > 0
+}
+
+// end ends the push, returning the number of samples skipped.
+func (p *guardPush) end() int {
+	p.timestamps.last = p.last
+	p.timestamps.skipped += uint64(p.skipped)
+	p.timestamps.mu.Unlock()
+	return p.skipped
+}
+
+// skippedSamples returns the number of samples skipped.
+func (g *timestampGuard) skippedSamples() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.skipped
+}
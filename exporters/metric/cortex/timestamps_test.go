@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+)
+
+// guardAll guards timeseries as the time series of a push.
+func guardAll(guard *timestampGuard, timeseries ...prompb.TimeSeries) ([]prompb.TimeSeries, int) {
+	push := guard.start()
+	var guarded []prompb.TimeSeries
+	for _, ts := range timeseries {
+		if ts, ok := push.guard(ts); ok {
+			guarded = append(guarded, ts)
+		}
+	}
+	return guarded, push.end()
+}
+
+func TestTimestampGuard(t *testing.T) {
+	series := func(name string, timestamps ...int64) prompb.TimeSeries {
+		ts := prompb.TimeSeries{Labels: []prompb.Label{{Name: "__name__", Value: name}}}
+		for _, timestamp := range timestamps {
+			ts.Samples = append(ts.Samples, prompb.Sample{Value: 1, Timestamp: timestamp})
+		}
+		return ts
+	}
+	var guard timestampGuard
+
+	got, skipped := guardAll(&guard, series("a", 10), series("b", 10, 10, 20))
+	assert.Equal(t, []prompb.TimeSeries{series("a", 10), series("b", 10, 20)}, got)
+	assert.Equal(t, 1, skipped)
+	assert.Equal(t, uint64(1), guard.skippedSamples())
+
+	// Samples going backwards or duplicating the previous timestamp are skipped,
+	// and the series left without samples are removed.
+	got, skipped = guardAll(&guard, series("a", 10), series("b", 15, 30), series("c", 5))
+	assert.Equal(t, []prompb.TimeSeries{series("b", 30), series("c", 5)}, got)
+	assert.Equal(t, 2, skipped)
+	assert.Equal(t, uint64(3), guard.skippedSamples())
+
+	// The series that are no longer exported are forgotten.
+	got, _ = guardAll(&guard, series("c", 6))
+	assert.Equal(t, []prompb.TimeSeries{series("c", 6)}, got)
+	got, _ = guardAll(&guard, series("a", 1))
+	assert.Equal(t, []prompb.TimeSeries{series("a", 1)}, got)
+}
+
+func TestOutOfOrderSamples(t *testing.T) {
+	exporter := Exporter{config: validConfig}
+	reader := getSumReader(t, 1)
+
+	timeseries, err := exporter.ConvertToTimeSeries(testResource, reader)
+	assert.NoError(t, err)
+	assert.Len(t, timeseries, 1)
+
+	// Converting the same records again duplicates the timestamps.
+	timeseries, err = exporter.ConvertToTimeSeries(testResource, reader)
+	assert.NoError(t, err)
+	assert.Empty(t, timeseries)
+	assert.Equal(t, uint64(1), exporter.OutOfOrderSamples())
+}
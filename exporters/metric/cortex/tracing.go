@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer of the push spans.
+const instrumentationName = "go.opentelemetry.io/contrib/exporters/metric/cortex"
+
+// pushSpanName is the name of the client span of every request sent to Cortex.
+const pushSpanName = "cortex.push"
+
+// pushKey is the key of the context value marking the context of a push.
+type pushKey struct{}
+
+// startPushSpan starts the client span of a request of size bytes when
+// Config.TracerProvider is set. Otherwise, or when ctx is the context of a
+// push, e.g. when an instrumented Client exports its own telemetry with the
+// Exporter, the returned span records nothing, so that pushes do not trace
+// themselves recursively. The Exporter does not retry failed requests, so
+// the span has no retry count attribute.
+func (e *Exporter) startPushSpan(ctx context.Context, size int) (context.Context, trace.Span) {
+	if e.config.TracerProvider == nil || ctx.Value(pushKey{}) != nil {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+	ctx = context.WithValue(ctx, pushKey{}, true)
+	tracer := e.config.TracerProvider.Tracer(instrumentationName, trace.WithInstrumentationVersion(SemVersion()))
+	return tracer.Start(ctx, pushSpanName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String("POST"),
+			semconv.HTTPURLKey.String(e.config.Endpoint),
+			semconv.HTTPRequestContentLengthKey.Int(size),
+		),
+	)
+}
+
+// endPushSpan ends the span of a request that failed with err if not nil. The
+// status code of the response is recorded by sendRequest.
+func endPushSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPushSpan(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	exporter, err := NewRawExporter(Config{Endpoint: server.URL, TracerProvider: provider})
+	require.NoError(t, err)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "collect")
+	require.NoError(t, exporter.Export(ctx, testResource, getSumReader(t, 1)))
+	status = http.StatusBadRequest
+	require.Error(t, exporter.Export(ctx, testResource, getSumReader(t, 1)))
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	for i, wantStatus := range []int{http.StatusOK, http.StatusBadRequest} {
+		span := spans[i]
+		assert.Equal(t, pushSpanName, span.Name())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+
+		attributes := attribute.NewSet(span.Attributes()...)
+		url, _ := attributes.Value(semconv.HTTPURLKey)
+		assert.Equal(t, server.URL, url.AsString())
+		size, _ := attributes.Value(semconv.HTTPRequestContentLengthKey)
+		assert.NotZero(t, size.AsInt64())
+		code, _ := attributes.Value(semconv.HTTPStatusCodeKey)
+		assert.Equal(t, int64(wantStatus), code.AsInt64())
+	}
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestPushSpanSuppressed(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	exporter, err := NewRawExporter(Config{Endpoint: "http://localhost:9009", TracerProvider: provider})
+	require.NoError(t, err)
+
+	// A push made with the context of another push is not traced.
+	ctx, span := exporter.startPushSpan(context.Background(), 1)
+	_, nested := exporter.startPushSpan(ctx, 1)
+	assert.False(t, nested.IsRecording())
+	nested.End()
+	span.End()
+
+	require.Len(t, recorder.Ended(), 1)
+}
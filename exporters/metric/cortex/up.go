@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"sort"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex/prometheusremotewrite"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// UpMetricName is the name of the series sent with every push when
+// Config.SendUpSeries is set.
+const UpMetricName = "otel_exporter_up"
+
+// upTimeSeries returns the series sent with every push when Config.SendUpSeries is
+// set. Its value is 1 and its labels identify the exporter: the resource labels,
+// the exporter and its version, and the name of the Config when set.
+func (e *Exporter) upTimeSeries(res *resource.Resource) prompb.TimeSeries {
+	labels := []prompb.Label{
+		{Name: "__name__", Value: UpMetricName},
+		{Name: "exporter", Value: "cortex"},
+		{Name: "exporter_version", Value: Version()},
+	}
+	if e.config.Name != "" {
+		labels = append(labels, prompb.Label{Name: "remote_name", Value: e.config.Name})
+	}
+
+	iter := res.Set().Iter()
+	for iter.Next() {
+		attribute := iter.Label()
+		name := prometheusremotewrite.Sanitize(string(attribute.Key))
+		if hasLabel(labels, name) {
+			// The labels of the exporter take precedence.
+			continue
+		}
+		labels = append(labels, prompb.Label{Name: name, Value: attribute.Value.Emit()})
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Name < labels[j].Name
+	})
+
+	return prompb.TimeSeries{
+		Labels: labels,
+		Samples: []prompb.Sample{{
+			Value:     1,
+			Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+		}},
+	}
+}
+
+// hasLabel reports whether labels has a label with name.
+func hasLabel(labels []prompb.Label, name string) bool {
+	for _, l := range labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpSeries(t *testing.T) {
+	exporter := Exporter{config: Config{Name: "primary", SendUpSeries: true}}
+
+	timeseries, err := exporter.ConvertToTimeSeries(testResource, getSumReader(t, 1))
+	require.NoError(t, err)
+	require.Len(t, timeseries, 2)
+	up := timeseries[1]
+	assert.Equal(t, []prompb.Label{
+		{Name: "R", Value: "V"},
+		{Name: "__name__", Value: UpMetricName},
+		{Name: "exporter", Value: "cortex"},
+		{Name: "exporter_version", Value: Version()},
+		{Name: "remote_name", Value: "primary"},
+	}, up.Labels)
+	require.Len(t, up.Samples, 1)
+	assert.Equal(t, 1.0, up.Samples[0].Value)
+}
+
+func TestUpSeriesDisabled(t *testing.T) {
+	exporter := Exporter{config: Config{}}
+
+	timeseries, err := exporter.ConvertToTimeSeries(testResource, getSumReader(t, 1))
+	require.NoError(t, err)
+	assert.Len(t, timeseries, 1)
+}
@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command cortexconfig validates a YAML configuration of the Cortex exporter and
+// prints the resulting configuration, with the defaults applied and the secrets
+// redacted. It exits with a non-zero status when the configuration is invalid,
+// so that it can be used in CI and admission checks:
+//
+//	cortexconfig config.yml
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex"
+	"go.opentelemetry.io/contrib/exporters/metric/cortex/utils"
+)
+
+// redacted replaces the secrets of the printed configuration.
+const redacted = "<secret>"
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run validates the YAML file of args and prints its configuration to stdout,
+// returning the exit status of the command.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintln(stderr, "usage: cortexconfig <config.yml>")
+		return 2
+	}
+
+	// NewConfig reads the file of the environment variable instead of searching
+	// for the file.
+	if err := os.Setenv(utils.ConfigEnvVar, args[0]); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	config, err := utils.NewConfig(args[0])
+	if err != nil {
+		fmt.Fprintf(stderr, "invalid configuration %s: %v\n", args[0], err)
+		return 1
+	}
+
+	out, err := yaml.Marshal(redact(config))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if _, err := stdout.Write(out); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// redact returns the properties of config, keyed by their YAML names, with the
+// secrets replaced.
+func redact(config *cortex.Config) yaml.MapSlice {
+	var properties yaml.MapSlice
+	value := reflect.ValueOf(*config)
+	for i := 0; i < value.NumField(); i++ {
+		name := value.Type().Field(i).Tag.Get("mapstructure")
+		if name == "" || name == "-" {
+			continue
+		}
+		if v := property(value.Field(i).Interface()); v != nil {
+			properties = append(properties, yaml.MapItem{Key: name, Value: v})
+		}
+	}
+	if len(config.WriteRelabelConfigs) > 0 {
+		properties = append(properties, yaml.MapItem{Key: "write_relabel_configs", Value: config.WriteRelabelConfigs})
+	}
+
+	for i, item := range properties {
+		switch item.Key {
+		case "bearer_token":
+			if config.BearerToken != "" {
+				properties[i].Value = redacted
+			}
+		case "basic_auth", "proxy_basic_auth":
+			properties[i].Value = redactMap(item.Value.(map[string]string), "password")
+		case "tls_config":
+			properties[i].Value = redactMap(item.Value.(map[string]string), "key_pem")
+		case "headers":
+			properties[i].Value = redactMap(item.Value.(map[string]string), "authorization")
+		case "proxy_connect_headers":
+			properties[i].Value = redactMap(item.Value.(map[string]string), "proxy-authorization")
+		}
+	}
+
+	// The headers of the YAML file are printed with all their values.
+	if len(config.HeaderValues) > 0 {
+		headers := make(map[string][]string, len(config.HeaderValues))
+		for name, values := range config.HeaderValues {
+			headers[name] = values
+			if strings.EqualFold(name, "authorization") {
+				headers[name] = []string{redacted}
+			}
+		}
+		properties = append(properties, yaml.MapItem{Key: "headers", Value: headers})
+	}
+	return properties
+}
+
+// property returns the printed value of a property, or nil when it is not set.
+func property(v interface{}) interface{} {
+	switch v := v.(type) {
+	case time.Duration:
+		return v.String()
+	case *url.URL:
+		if v == nil {
+			return nil
+		}
+		return v.String()
+	case *bool:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case map[string]string:
+		if v == nil {
+			return nil
+		}
+		return v
+	case []float64:
+		if v == nil {
+			return nil
+		}
+		return v
+	}
+	return v
+}
+
+// redactMap returns a copy of m with the values of the keys replaced, ignoring
+// their case.
+func redactMap(m map[string]string, keys ...string) map[string]string {
+	copied := make(map[string]string, len(m))
+	for k, v := range m {
+		copied[k] = v
+		for _, key := range keys {
+			if strings.EqualFold(k, key) {
+				copied[k] = redacted
+			}
+		}
+	}
+	return copied
+}
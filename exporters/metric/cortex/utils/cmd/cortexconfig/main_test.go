@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex/utils"
+)
+
+// TestRun tests whether run prints a valid configuration with its secrets redacted and
+// fails on an invalid configuration.
+func TestRun(t *testing.T) {
+	defer os.Unsetenv(utils.ConfigEnvVar)
+	dir, err := ioutil.TempDir("", "cortexconfig")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	valid := filepath.Join(dir, "valid.yml")
+	require.NoError(t, ioutil.WriteFile(valid, []byte(`url: /api/prom/push
+basic_auth:
+  username: user
+  password: password
+headers:
+  Authorization: token
+  test: header
+`), 0644))
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 0, run([]string{valid}, &stdout, &stderr))
+	require.Empty(t, stderr.String())
+	require.Contains(t, stdout.String(), "url: /api/prom/push")
+	require.Contains(t, stdout.String(), "remote_timeout: 30s")
+	require.Contains(t, stdout.String(), "username: user")
+	require.Contains(t, stdout.String(), "test:\n  - header")
+	require.Contains(t, stdout.String(), "Authorization:\n  - <secret>")
+	require.NotContains(t, stdout.String(), "password: password")
+	require.NotContains(t, stdout.String(), "- token")
+
+	invalid := filepath.Join(dir, "invalid.yml")
+	require.NoError(t, ioutil.WriteFile(invalid, []byte(`bearer_token: token
+basic_auth:
+  username: user
+  password: password
+`), 0644))
+
+	stdout.Reset()
+	stderr.Reset()
+	require.Equal(t, 1, run([]string{invalid}, &stdout, &stderr))
+	require.Empty(t, stdout.String())
+	require.Contains(t, stderr.String(), "invalid configuration")
+
+	require.Equal(t, 2, run(nil, &stdout, &stderr))
+}
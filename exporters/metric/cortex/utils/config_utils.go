@@ -15,7 +15,10 @@
 package utils
 
 import (
+	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 
 	"github.com/spf13/afero"
 	"github.com/spf13/viper"
@@ -23,6 +26,14 @@ import (
 	"go.opentelemetry.io/contrib/exporters/metric/cortex"
 )
 
+// ErrNilViper occurs when NewConfigFromViper is called with a nil Viper instance, such
+// as the instance returned by the Sub method of Viper for a missing key.
+var ErrNilViper = fmt.Errorf("cannot create a Config from a nil Viper instance")
+
+// ConfigEnvVar is the environment variable with the path of the YAML file read by
+// NewConfig, which is then not searched for.
+const ConfigEnvVar = "CORTEX_EXPORTER_CONFIG"
+
 // Option sets an option for a Config struct.
 type Option interface {
 	Apply(*cortex.Config)
@@ -68,12 +79,22 @@ func (o clientOption) Apply(config *cortex.Config) {
 }
 
 // NewConfig creates a Config struct with a YAML file and applies Option functions to the
-// Config struct.
+// Config struct. The YAML file is the file named by the ConfigEnvVar environment
+// variable when it is set. Otherwise, it is searched for in the current directory, the
+// paths added with WithFilepath, /etc/otel and $HOME/.config/otel, in that order.
+// The YAML file can also have a Prometheus remote_write property with a single
+// remote write configuration, whose url, remote_timeout, name, headers, basic_auth,
+// authorization, bearer_token, bearer_token_file, tls_config, proxy_url,
+// proxy_from_environment, follow_redirects, metadata_config and write_relabel_configs
+// properties are supported. Its queue_config property is ignored. The headers are set
+// in the HeaderValues of the Config with their names as written in the YAML file, and
+// each header can have a single value or a list of values.
 func NewConfig(filename string, opts ...Option) (*cortex.Config, error) {
 	var config cortex.Config
 
 	// Use OS file system and look for YAML file in local directory by default.
-	viper.SetFs(afero.NewOsFs())
+	var fs afero.Fs = afero.NewOsFs()
+	viper.SetFs(fs)
 	viper.SetConfigName(filename)
 	viper.SetConfigType("yaml")
 	viper.AddConfigPath(".")
@@ -82,17 +103,72 @@ func NewConfig(filename string, opts ...Option) (*cortex.Config, error) {
 	// filepath.
 	for _, opt := range opts {
 		opt.Apply(&config)
+		if o, ok := opt.(fsOption); ok {
+			fs = o.fs
+		}
+	}
+
+	// Search the standard paths after the paths of the Options, or only read the
+	// file of the environment variable.
+	if path := os.Getenv(ConfigEnvVar); path != "" {
+		viper.SetConfigFile(path)
+	} else {
+		for _, path := range standardPaths() {
+			viper.AddConfigPath(path)
+		}
 	}
 
 	// Read YAML file into struct and then check its properties.
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, err
 	}
-	if err := viper.Unmarshal(&config); err != nil {
+	headers, err := rawHeaders(fs, viper.ConfigFileUsed())
+	if err != nil {
 		return nil, err
 	}
-	if err := config.Validate(); err != nil {
+	if err := unmarshalConfig(viper.GetViper(), &config, headers); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// NewConfigFromViper creates a Config struct with the properties of a Viper instance,
+// such as the sub-tree of the configuration of an application returned by its Sub
+// method, instead of reading a YAML file. The properties are the same as the
+// properties of the YAML file of NewConfig, but the header names are lower-cased by
+// Viper.
+func NewConfigFromViper(v *viper.Viper) (*cortex.Config, error) {
+	if v == nil {
+		return nil, ErrNilViper
+	}
+	var config cortex.Config
+	if err := unmarshalConfig(v, &config, nil); err != nil {
 		return nil, err
 	}
 	return &config, nil
 }
+
+// unmarshalConfig unmarshals the properties of v, including its remote_write
+// property, into config and then checks its properties. The headers property is
+// unmarshaled from headers when it is not nil.
+func unmarshalConfig(v *viper.Viper, config *cortex.Config, headers interface{}) error {
+	if err := unmarshal(v, config, headers); err != nil {
+		return err
+	}
+	if remoteWrite := v.Get("remote_write"); remoteWrite != nil {
+		if err := unmarshalRemoteWrite(remoteWrite, config); err != nil {
+			return err
+		}
+	}
+	return config.Validate()
+}
+
+// standardPaths returns the standard paths searched for the YAML file after the
+// current directory and the paths of the Options.
+func standardPaths() []string {
+	paths := []string{"/etc/otel"}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", "otel"))
+	}
+	return paths
+}
@@ -15,8 +15,12 @@
 package utils_test
 
 import (
+	"net/url"
 	"time"
 
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/relabel"
+
 	"go.opentelemetry.io/contrib/exporters/metric/cortex"
 )
 
@@ -176,12 +180,81 @@ var validConfig = cortex.Config{
 	},
 	ProxyURL:     nil,
 	PushInterval: 5 * time.Second,
-	Headers: map[string]string{
-		"test": "header",
+	HeaderValues: map[string][]string{
+		"test": {"header"},
 	},
 	Quantiles: []float64{0.5, 0.9, 0.95, 0.99},
 }
 
+// YAML file with a Prometheus remote_write property. It should produce a Config struct
+// without errors since the queue_config property is ignored.
+var remoteWriteYAML = []byte(`push_interval: 5s
+remote_write:
+  - url: /api/prom/push
+    remote_timeout: 30s
+    name: Valid Config Example
+    authorization:
+      type: Bearer
+      credentials: token
+    tls_config:
+      ca_file: cafile
+      cert_file: certfile
+      key_file: keyfile
+      server_name: server
+      insecure_skip_verify: true
+    proxy_url: http://proxy:8080
+    headers:
+      test: header
+      X-Scope-OrgID: [tenant-1, tenant-2]
+    queue_config:
+      capacity: 2500
+      max_shards: 200
+    metadata_config:
+      send: true
+    write_relabel_configs:
+      - source_labels: [__name__]
+        regex: go_.*
+        action: drop
+`)
+
+// YAML file with two remote write configurations. It should produce an error.
+var multipleRemoteWritesYAML = []byte(`remote_write:
+  - url: /api/prom/push
+  - url: /api/v1/push
+`)
+
+// YAML file with a remote_write property that is not a list. It should produce an
+// error.
+var invalidRemoteWriteYAML = []byte(`remote_write:
+  url: /api/prom/push
+`)
+
+// YAML file with a remote write configuration using the Basic authorization type. It
+// should produce an error since only the Bearer type is supported.
+var unsupportedAuthorizationYAML = []byte(`remote_write:
+  - url: /api/prom/push
+    authorization:
+      type: Basic
+      credentials: token
+`)
+
+// YAML file with headers whose names are not lower-case and with multiple values. It
+// should produce a Config struct keeping the casing of the names and every value.
+var headerValuesYAML = []byte(`url: /api/prom/push
+headers:
+  X-Scope-OrgID: tenant
+  X-API-Key:
+    - first
+    - second
+`)
+
+// YAML file with a header whose value is a map. It should produce an error.
+var invalidHeadersYAML = []byte(`url: /api/prom/push
+headers:
+  X-Scope-OrgID:
+    tenant: first
+`)
+
 // customQuantilesConfig is the resulting Config struct from reading quantilesYAML.
 var customQuantilesConfig = cortex.Config{
 	Endpoint:      "/api/prom/push",
@@ -202,8 +275,8 @@ var customQuantilesConfig = cortex.Config{
 	},
 	ProxyURL:     nil,
 	PushInterval: 5 * time.Second,
-	Headers: map[string]string{
-		"test": "header",
+	HeaderValues: map[string][]string{
+		"test": {"header"},
 	},
 	Quantiles: []float64{0, 0.5, 1},
 }
@@ -229,9 +302,57 @@ var customBucketBoundariesConfig = cortex.Config{
 	},
 	ProxyURL:     nil,
 	PushInterval: 5 * time.Second,
-	Headers: map[string]string{
-		"test": "header",
+	HeaderValues: map[string][]string{
+		"test": {"header"},
 	},
 	Quantiles:           []float64{0.5, 0.9, 0.95, 0.99},
 	HistogramBoundaries: []float64{100, 300, 500},
 }
+
+// remoteWriteConfig is the resulting Config struct from reading remoteWriteYAML.
+var remoteWriteConfig = cortex.Config{
+	Endpoint:      "/api/prom/push",
+	RemoteTimeout: 30 * time.Second,
+	Name:          "Valid Config Example",
+	BearerToken:   "token",
+	TLSConfig: map[string]string{
+		"ca_file":              "cafile",
+		"cert_file":            "certfile",
+		"key_file":             "keyfile",
+		"server_name":          "server",
+		"insecure_skip_verify": "1",
+	},
+	ProxyURL: &url.URL{
+		Scheme: "http",
+		Host:   "proxy:8080",
+	},
+	PushInterval: 5 * time.Second,
+	HeaderValues: map[string][]string{
+		"test":          {"header"},
+		"X-Scope-OrgID": {"tenant-1", "tenant-2"},
+	},
+	Quantiles:    []float64{0.5, 0.9, 0.95, 0.99},
+	SendMetadata: true,
+	WriteRelabelConfigs: []*relabel.Config{
+		{
+			SourceLabels: model.LabelNames{"__name__"},
+			Separator:    relabel.DefaultRelabelConfig.Separator,
+			Regex:        relabel.MustNewRegexp("go_.*"),
+			Modulus:      relabel.DefaultRelabelConfig.Modulus,
+			Replacement:  relabel.DefaultRelabelConfig.Replacement,
+			Action:       relabel.Drop,
+		},
+	},
+}
+
+// headerValuesConfig is the resulting Config struct from reading headerValuesYAML.
+var headerValuesConfig = cortex.Config{
+	Endpoint:      "/api/prom/push",
+	RemoteTimeout: 30 * time.Second,
+	PushInterval:  10 * time.Second,
+	HeaderValues: map[string][]string{
+		"X-Scope-OrgID": {"tenant"},
+		"X-API-Key":     {"first", "second"},
+	},
+	Quantiles: []float64{0.5, 0.9, 0.95, 0.99},
+}
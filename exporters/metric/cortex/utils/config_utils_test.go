@@ -15,12 +15,15 @@
 package utils_test
 
 import (
+	"bytes"
 	"net/http"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/spf13/afero"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/contrib/exporters/metric/cortex"
@@ -114,6 +117,54 @@ func TestNewConfig(t *testing.T) {
 			expectedConfig: &customBucketBoundariesConfig,
 			expectedError:  nil,
 		},
+		{
+			testName:       "Remote Write",
+			yamlByteString: remoteWriteYAML,
+			fileName:       "config.yml",
+			directoryPath:  "/test",
+			expectedConfig: &remoteWriteConfig,
+			expectedError:  nil,
+		},
+		{
+			testName:       "Multiple Remote Writes",
+			yamlByteString: multipleRemoteWritesYAML,
+			fileName:       "config.yml",
+			directoryPath:  "/test",
+			expectedConfig: nil,
+			expectedError:  utils.ErrMultipleRemoteWrites,
+		},
+		{
+			testName:       "Invalid Remote Write",
+			yamlByteString: invalidRemoteWriteYAML,
+			fileName:       "config.yml",
+			directoryPath:  "/test",
+			expectedConfig: nil,
+			expectedError:  utils.ErrInvalidRemoteWrite,
+		},
+		{
+			testName:       "Unsupported Authorization",
+			yamlByteString: unsupportedAuthorizationYAML,
+			fileName:       "config.yml",
+			directoryPath:  "/test",
+			expectedConfig: nil,
+			expectedError:  utils.ErrUnsupportedAuthorization,
+		},
+		{
+			testName:       "Header Values",
+			yamlByteString: headerValuesYAML,
+			fileName:       "config.yml",
+			directoryPath:  "/test",
+			expectedConfig: &headerValuesConfig,
+			expectedError:  nil,
+		},
+		{
+			testName:       "Invalid Headers",
+			yamlByteString: invalidHeadersYAML,
+			fileName:       "config.yml",
+			directoryPath:  "/test",
+			expectedConfig: nil,
+			expectedError:  utils.ErrInvalidHeaders,
+		},
 	}
 
 	for _, test := range tests {
@@ -190,6 +241,71 @@ func TestWithFilepath(t *testing.T) {
 	}
 }
 
+// TestStandardPaths tests whether NewConfig finds a YAML file in the standard paths.
+func TestStandardPaths(t *testing.T) {
+	fs, err := initYAML(validYAML, "/etc/otel/config.yml")
+	require.NoError(t, err)
+
+	config, err := utils.NewConfig("config.yml", utils.WithFilesystem(fs))
+	require.NoError(t, err)
+	require.Equal(t, &validConfig, config)
+}
+
+// TestConfigEnvVar tests whether NewConfig reads the YAML file named by the
+// environment variable instead of searching for it.
+func TestConfigEnvVar(t *testing.T) {
+	fs, err := initYAML(quantilesYAML, "/mounted/cortex.yaml")
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(fs, "/test/config.yml", validYAML, 0644))
+
+	require.NoError(t, os.Setenv(utils.ConfigEnvVar, "/mounted/cortex.yaml"))
+	defer os.Unsetenv(utils.ConfigEnvVar)
+
+	config, err := utils.NewConfig("config.yml", utils.WithFilepath("/test"), utils.WithFilesystem(fs))
+	require.NoError(t, err)
+	require.Equal(t, &customQuantilesConfig, config)
+
+	require.NoError(t, os.Setenv(utils.ConfigEnvVar, "/mounted/missing.yaml"))
+	_, err = utils.NewConfig("config.yml", utils.WithFilepath("/test"), utils.WithFilesystem(fs))
+	require.Error(t, err)
+}
+
+// TestNewConfigFromViper tests whether NewConfigFromViper creates a Config struct from a
+// sub-tree of the configuration of an application.
+func TestNewConfigFromViper(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(bytes.NewReader([]byte(`exporters:
+  cortex:
+    remote_timeout: 30s
+    push_interval: 5s
+    name: Valid Config Example
+    basic_auth:
+      username: user
+      password: password
+    tls_config:
+      ca_file: cafile
+      cert_file: certfile
+      key_file: keyfile
+      server_name: server
+      insecure_skip_verify: true
+    headers:
+      test: header
+  invalid:
+    quantiles: [2]
+`))))
+
+	config, err := utils.NewConfigFromViper(v.Sub("exporters.cortex"))
+	require.NoError(t, err)
+	require.Equal(t, &validConfig, config)
+
+	_, err = utils.NewConfigFromViper(v.Sub("exporters.missing"))
+	require.Equal(t, utils.ErrNilViper, err)
+
+	_, err = utils.NewConfigFromViper(v.Sub("exporters.invalid"))
+	require.Equal(t, cortex.ErrInvalidQuantiles, err)
+}
+
 // TestWithClient tests whether NewConfig successfully adds a HTTP client to the Config
 // struct.
 func TestWithClient(t *testing.T) {
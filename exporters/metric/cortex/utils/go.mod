@@ -2,11 +2,20 @@ module go.opentelemetry.io/contrib/exporters/metric/cortex/utils
 
 go 1.15
 
-replace go.opentelemetry.io/contrib/exporters/metric/cortex => ../
+replace (
+	go.opentelemetry.io/contrib => ../../../..
+	go.opentelemetry.io/contrib/exporters/metric/cortex => ../
+	go.opentelemetry.io/contrib/exporters/metric/cortex/prometheusremotewrite => ../prometheusremotewrite
+)
 
 require (
+	github.com/mitchellh/mapstructure v1.4.2
+	github.com/prometheus/common v0.30.1
+	github.com/prometheus/prometheus v1.8.2-0.20210928085443-fafb309d4027
 	github.com/spf13/afero v1.6.0
+	github.com/spf13/cast v1.4.1
 	github.com/spf13/viper v1.9.0
 	github.com/stretchr/testify v1.7.0
 	go.opentelemetry.io/contrib/exporters/metric/cortex v0.26.0
+	gopkg.in/yaml.v2 v2.4.0
 )
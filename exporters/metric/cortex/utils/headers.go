@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cast"
+	"gopkg.in/yaml.v2"
+)
+
+// ErrInvalidHeaders occurs when the headers property of the YAML file does not map
+// the header names to a value or a list of values.
+var ErrInvalidHeaders = fmt.Errorf("headers must map header names to a value or a list of values")
+
+// rawHeaders returns the headers property of a YAML file as it is written in the
+// file, since Viper lower-cases the header names.
+func rawHeaders(fs afero.Fs, filename string) (interface{}, error) {
+	b, err := afero.ReadFile(fs, filename)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Headers interface{} `yaml:"headers"`
+	}
+	if err := yaml.Unmarshal(b, &file); err != nil {
+		return nil, err
+	}
+	return file.Headers, nil
+}
+
+// headerValues converts a headers property, whose headers have either a single value
+// or a list of values, to the values of each header.
+func headerValues(headers interface{}) (map[string][]string, error) {
+	properties, err := cast.ToStringMapE(headers)
+	if err != nil {
+		return nil, ErrInvalidHeaders
+	}
+	values := make(map[string][]string, len(properties))
+	for name, property := range properties {
+		list, ok := property.([]interface{})
+		if !ok {
+			list = []interface{}{property}
+		}
+		for _, item := range list {
+			value, err := cast.ToStringE(item)
+			if err != nil {
+				return nil, ErrInvalidHeaders
+			}
+			values[name] = append(values[name], value)
+		}
+	}
+	return values, nil
+}
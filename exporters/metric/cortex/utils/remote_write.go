@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/prometheus/prometheus/pkg/relabel"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex"
+)
+
+var (
+	// ErrInvalidRemoteWrite occurs when the remote_write property of the YAML file
+	// is not a list of remote write configurations.
+	ErrInvalidRemoteWrite = fmt.Errorf("remote_write must be a list of remote write configurations")
+
+	// ErrMultipleRemoteWrites occurs when the remote_write property of the YAML file
+	// has more than one remote write configuration.
+	ErrMultipleRemoteWrites = fmt.Errorf("cannot have more than one remote write configuration")
+
+	// ErrUnsupportedAuthorization occurs when the type of the authorization of a
+	// remote write configuration is not Bearer.
+	ErrUnsupportedAuthorization = fmt.Errorf("only the Bearer authorization type is supported")
+)
+
+// unmarshalRemoteWrite unmarshals the remote write configuration of a Prometheus
+// remote_write property into config, overriding the properties set outside of
+// the remote_write property.
+func unmarshalRemoteWrite(remoteWrite interface{}, config *cortex.Config) error {
+	configs, ok := remoteWrite.([]interface{})
+	if !ok || len(configs) == 0 {
+		return ErrInvalidRemoteWrite
+	}
+	if len(configs) > 1 {
+		return ErrMultipleRemoteWrites
+	}
+	properties, err := cast.ToStringMapE(configs[0])
+	if err != nil {
+		return ErrInvalidRemoteWrite
+	}
+
+	// Keep the header names before Viper lower-cases them.
+	headers := properties["headers"]
+	v := viper.New()
+	if err := v.MergeConfigMap(properties); err != nil {
+		return err
+	}
+	if err := unmarshal(v, config, headers); err != nil {
+		return err
+	}
+
+	// The authorization property replaces bearer_token and bearer_token_file in
+	// recent Prometheus versions.
+	if v.IsSet("authorization") {
+		if t := v.GetString("authorization.type"); t != "" && !strings.EqualFold(t, "Bearer") {
+			return ErrUnsupportedAuthorization
+		}
+		config.BearerToken = v.GetString("authorization.credentials")
+		config.BearerTokenFile = v.GetString("authorization.credentials_file")
+	}
+	if v.IsSet("metadata_config.send") {
+		config.SendMetadata = v.GetBool("metadata_config.send")
+	}
+	// The queue_config property is ignored, as the exporter sends the time series
+	// of a push in a single request instead of queueing samples.
+	return nil
+}
+
+// unmarshal unmarshals the properties of v into config. The headers property is
+// unmarshaled from headers, which keeps the casing of the header names, or from v
+// when headers is nil.
+func unmarshal(v *viper.Viper, config *cortex.Config, headers interface{}) error {
+	// Unmarshal like Viper, but without the headers property, which is not
+	// unmarshaled into Config.Headers.
+	settings := v.AllSettings()
+	delete(settings, "headers")
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           config,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+			stringToURLHookFunc,
+		),
+	})
+	if err != nil {
+		return err
+	}
+	if err := decoder.Decode(settings); err != nil {
+		return err
+	}
+
+	if headers == nil {
+		headers = v.Get("headers")
+	}
+	if headers != nil {
+		values, err := headerValues(headers)
+		if err != nil {
+			return err
+		}
+		config.HeaderValues = values
+	}
+
+	relabelConfigs, err := unmarshalRelabelConfigs(v.Get("write_relabel_configs"))
+	if err != nil {
+		return err
+	}
+	config.WriteRelabelConfigs = relabelConfigs
+	return nil
+}
+
+// stringToURLHookFunc decodes the strings into the *url.URL properties, such as
+// proxy_url.
+func stringToURLHookFunc(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+	if f.Kind() != reflect.String || t != reflect.TypeOf(&url.URL{}) {
+		return data, nil
+	}
+	return url.Parse(data.(string))
+}
+
+// unmarshalRelabelConfigs unmarshals the relabel configurations of a
+// write_relabel_configs property with the YAML unmarshaling of Prometheus, which
+// applies the defaults of the relabel configurations.
+func unmarshalRelabelConfigs(writeRelabelConfigs interface{}) ([]*relabel.Config, error) {
+	if writeRelabelConfigs == nil {
+		return nil, nil
+	}
+	b, err := yaml.Marshal(writeRelabelConfigs)
+	if err != nil {
+		return nil, err
+	}
+	var configs []*relabel.Config
+	if err := yaml.Unmarshal(b, &configs); err != nil {
+		return nil, fmt.Errorf("invalid write_relabel_configs: %w", err)
+	}
+	return configs, nil
+}
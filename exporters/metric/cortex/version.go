@@ -14,6 +14,20 @@
 
 package cortex
 
+import (
+	"go.opentelemetry.io/contrib/registry"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func init() {
+	registry.Register(registry.Component{
+		Name:      instrumentationName,
+		Kind:      registry.KindExporter,
+		Version:   Version(),
+		SchemaURL: semconv.SchemaURL,
+	})
+}
+
 // Version is the current release version of the Cortex exporter.
 func Version() string {
 	return "0.26.0"
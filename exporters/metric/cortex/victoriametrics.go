@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"encoding/json"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// buildImportMessage creates the JSON lines of the VictoriaMetrics import format from a
+// slice of TimeSeries. The lines are written without encoding/json, which rejects the
+// NaN and infinite values that VictoriaMetrics accepts as NaN, Inf, and -Inf.
+func buildImportMessage(timeseries []prompb.TimeSeries) ([]byte, error) {
+	var buf []byte
+	for _, ts := range timeseries {
+		metric := make(map[string]string, len(ts.Labels))
+		for _, label := range ts.Labels {
+			metric[label.Name] = label.Value
+		}
+		// The keys of a map are sorted by json.Marshal.
+		encodedMetric, err := json.Marshal(metric)
+		if err != nil {
+			return nil, err
+		}
+
+		buf = append(buf, `{"metric":`...)
+		buf = append(buf, encodedMetric...)
+		buf = append(buf, `,"values":[`...)
+		for i, sample := range ts.Samples {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendImportValue(buf, sample.Value)
+		}
+		buf = append(buf, `],"timestamps":[`...)
+		for i, sample := range ts.Samples {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = strconv.AppendInt(buf, sample.Timestamp, 10)
+		}
+		buf = append(buf, "]}\n"...)
+	}
+	return buf, nil
+}
+
+// appendImportValue appends the value of a sample in the VictoriaMetrics import
+// format to buf.
+func appendImportValue(buf []byte, value float64) []byte {
+	switch {
+	case math.IsNaN(value):
+		return append(buf, "NaN"...)
+	case math.IsInf(value, 1):
+		return append(buf, "Inf"...)
+	case math.IsInf(value, -1):
+		return append(buf, "-Inf"...)
+	}
+	return strconv.AppendFloat(buf, value, 'g', -1, 64)
+}
+
+// addExtraLabels adds the labels to the query of u as extra_label arguments, which
+// VictoriaMetrics and vmagent add to all the time series of a request.
+func addExtraLabels(u *url.URL, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	query := u.Query()
+	for _, name := range names {
+		query.Add("extra_label", name+"="+labels[name])
+	}
+	u.RawQuery = query.Encode()
+}
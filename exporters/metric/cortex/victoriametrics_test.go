@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBuildImportMessage tests whether the time series are encoded as JSON lines.
+func TestBuildImportMessage(t *testing.T) {
+	exporter := Exporter{config: Config{Format: FormatVictoriaMetricsImport}}
+	timeseries := []prompb.TimeSeries{
+		{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "first"}, {Name: "job", Value: "test"}},
+			Samples: []prompb.Sample{{Value: 1.5, Timestamp: 1000}},
+		},
+		{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "second"}},
+			Samples: []prompb.Sample{{Value: 2, Timestamp: 2000}},
+		},
+	}
+
+	message, err := exporter.buildMessage(timeseries)
+	require.NoError(t, err)
+	require.Equal(t, `{"metric":{"__name__":"first","job":"test"},"values":[1.5],"timestamps":[1000]}
+{"metric":{"__name__":"second"},"values":[2],"timestamps":[2000]}
+`, string(message))
+}
+
+// TestBuildImportMessageNonFinite tests whether NaN and infinite values are written
+// as VictoriaMetrics reads them.
+func TestBuildImportMessageNonFinite(t *testing.T) {
+	exporter := Exporter{config: Config{Format: FormatVictoriaMetricsImport}}
+	timeseries := []prompb.TimeSeries{
+		{
+			Labels: []prompb.Label{{Name: "__name__", Value: "gauge"}},
+			Samples: []prompb.Sample{
+				{Value: math.NaN(), Timestamp: 1000},
+				{Value: math.Inf(1), Timestamp: 2000},
+				{Value: math.Inf(-1), Timestamp: 3000},
+				{Value: 0.25, Timestamp: 4000},
+			},
+		},
+	}
+
+	message, err := exporter.buildMessage(timeseries)
+	require.NoError(t, err)
+	require.Equal(t, `{"metric":{"__name__":"gauge"},"values":[NaN,Inf,-Inf,0.25],"timestamps":[1000,2000,3000,4000]}
+`, string(message))
+}
+
+// TestBuildImportRequest tests whether a VictoriaMetrics import request has the JSON
+// content type and the extra_label query arguments.
+func TestBuildImportRequest(t *testing.T) {
+	config := Config{
+		Format:      FormatVictoriaMetricsImport,
+		ExtraLabels: map[string]string{"region": "eu", "cluster": "a"},
+	}
+	require.NoError(t, config.Validate())
+	require.Equal(t, "/api/v1/import", config.Endpoint)
+	exporter := Exporter{config: config}
+
+	req, err := exporter.buildRequest(context.Background(), []byte("{}\n"))
+	require.NoError(t, err)
+
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, []string{"cluster=a", "region=eu"}, req.URL.Query()["extra_label"])
+	require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	require.Empty(t, req.Header.Get("Content-Encoding"))
+	require.Empty(t, req.Header.Get("X-Prometheus-Remote-Write-Version"))
+
+	body, err := ioutil.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, "{}\n", string(body))
+}
+
+// TestInvalidFormat tests whether an unknown format is rejected.
+func TestInvalidFormat(t *testing.T) {
+	config := Config{Format: "influx"}
+	require.Equal(t, ErrInvalidFormat, config.Validate())
+}
+
+// TestExportVictoriaMetricsImport tests whether an export to the VictoriaMetrics
+// import endpoint succeeds when it answers 204 No Content.
+func TestExportVictoriaMetricsImport(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/import" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	exporter, err := NewRawExporter(Config{
+		Endpoint: server.URL + "/api/v1/import",
+		Format:   FormatVictoriaMetricsImport,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, exporter.Export(context.Background(), testResource, getSumReader(t, 1, 2)))
+	require.Contains(t, string(body), `{"metric":{"R":"V","__name__":"metric_sum"},"values":[3],"timestamps":[`)
+}
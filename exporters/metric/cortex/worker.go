@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+)
+
+var (
+	// ErrQueueFull occurs when Export is called while the export queue is full.
+	// The metrics of the call are dropped.
+	ErrQueueFull = fmt.Errorf("export queue is full")
+
+	// ErrShutdown occurs when Export is called after Shutdown.
+	ErrShutdown = fmt.Errorf("exporter is shut down")
+)
+
+// exportWorker sends the requests queued by Export from its own goroutine, so that
+// the collection of the metrics does not wait for Cortex.
+type exportWorker struct {
+	exporter *Exporter
+	drain    bool
+
+	// mu guards closed and discard, and the closing of queue.
+	mu      sync.RWMutex
+	closed  bool
+	discard bool
+	queue   chan pushMessage
+	done    chan struct{}
+}
+
+// newExportWorker returns a started exportWorker of exporter with a queue of size
+// messages. The queued messages are sent on shutdown when drain is set.
+func newExportWorker(exporter *Exporter, size int, drain bool) *exportWorker {
+	w := &exportWorker{
+		exporter: exporter,
+		drain:    drain,
+		queue:    make(chan pushMessage, size),
+		done:     make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// run sends the queued messages until the queue is closed.
+func (w *exportWorker) run() {
+	defer close(w.done)
+	for message := range w.queue {
+		if w.discarding() {
+			continue
+		}
+		if err := w.exporter.send(context.Background(), message); err != nil {
+			w.exporter.logger().Error(err, "Failed to send queued metrics")
+			otel.Handle(err)
+		}
+	}
+}
+
+// discarding reports whether the queued messages are discarded.
+func (w *exportWorker) discarding() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.discard
+}
+
+// enqueue queues message, failing when the queue is full or closed.
+func (w *exportWorker) enqueue(message pushMessage) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return ErrShutdown
+	}
+	select {
+	case w.queue <- message:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// shutdown closes the queue, discarding the queued messages unless they are
+// drained, and waits for the worker to stop or ctx to be done.
+func (w *exportWorker) shutdown(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		w.discard = !w.drain
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// QueueLength returns the number of requests queued by Export and not sent yet.
+// It is always 0 when Config.QueueSize is not set.
+func (e *Exporter) QueueLength() int {
+	if e.worker == nil {
+		return 0
+	}
+	return len(e.worker.queue)
+}
+
+// Shutdown stops the worker sending the requests queued by Export when
+// Config.QueueSize is set, once the controller of the Exporter is stopped. The
+// requests still queued are sent before Shutdown returns when
+// Config.DrainOnShutdown is set, and are discarded otherwise. Shutdown returns
+// the error of ctx if it is done before the worker stopped.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	if e.worker == nil {
+		return nil
+	}
+	return e.worker.shutdown(ctx)
+}
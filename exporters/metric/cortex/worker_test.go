@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cortex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportWorkerDrain(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	exporter, err := NewRawExporter(Config{Endpoint: server.URL, QueueSize: 2, DrainOnShutdown: true})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, exporter.Export(ctx, testResource, getSumReader(t, 1)))
+	require.NoError(t, exporter.Export(ctx, testResource, getSumReader(t, 2)))
+	require.NoError(t, exporter.Shutdown(ctx))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Equal(t, 0, exporter.QueueLength())
+
+	assert.Equal(t, ErrShutdown, exporter.Export(ctx, testResource, getSumReader(t, 3)))
+	require.NoError(t, exporter.Shutdown(ctx))
+}
+
+func TestExportWorkerQueueFull(t *testing.T) {
+	var requests int32
+	received := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		received <- struct{}{}
+		<-release
+	}))
+	defer server.Close()
+
+	exporter, err := NewRawExporter(Config{Endpoint: server.URL, QueueSize: 1})
+	require.NoError(t, err)
+
+	// The first request is sent, while the second one is queued.
+	ctx := context.Background()
+	require.NoError(t, exporter.Export(ctx, testResource, getSumReader(t, 1)))
+	<-received
+	require.NoError(t, exporter.Export(ctx, testResource, getSumReader(t, 2)))
+	assert.Equal(t, 1, exporter.QueueLength())
+	assert.Equal(t, ErrQueueFull, exporter.Export(ctx, testResource, getSumReader(t, 3)))
+
+	// Without draining, the queued request is discarded on shutdown.
+	shutdown := make(chan error)
+	go func() {
+		shutdown <- exporter.Shutdown(ctx)
+	}()
+	close(release)
+	require.NoError(t, <-shutdown)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestExportWorkerShutdownTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	exporter, err := NewRawExporter(Config{Endpoint: server.URL, QueueSize: 1, DrainOnShutdown: true})
+	require.NoError(t, err)
+	require.NoError(t, exporter.Export(context.Background(), testResource, getSumReader(t, 1)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, exporter.Shutdown(ctx))
+}
+
+func TestSynchronousExporterShutdown(t *testing.T) {
+	exporter, err := NewRawExporter(validConfig)
+	require.NoError(t, err)
+	assert.Equal(t, 0, exporter.QueueLength())
+	assert.NoError(t, exporter.Shutdown(context.Background()))
+}
+
+func TestValidateQueueSize(t *testing.T) {
+	config := Config{QueueSize: -1}
+	assert.Equal(t, ErrInvalidQueueSize, config.Validate())
+}
@@ -31,6 +31,9 @@ import (
 type (
 	Config = statsd.Config
 
+	// Dialect is the statsd syntax variation of the exported data.
+	Dialect = statsd.Dialect
+
 	// Exporter implements a dogstatsd-format statsd exporter,
 	// which encodes attribute sets as independent fields in the
 	// output.
@@ -42,10 +45,21 @@ type (
 	Exporter struct {
 		*statsd.Exporter
 
+		dialect          Dialect
 		attributeEncoder *AttributeEncoder
 	}
 )
 
+const (
+	// DialectDogStatsD encodes attributes and resources as dogstatsd
+	// tags.  This is the default.
+	DialectDogStatsD = statsd.DogStatsD
+	// DialectStatsD appends the attribute values to the metric name,
+	// e.g. "requests.GET.200:1|c", as plain statsd has no tags.
+	// Resources are not exported.
+	DialectStatsD = statsd.StatsD
+)
+
 var (
 	_ export.Exporter = &Exporter{}
 )
@@ -53,6 +67,7 @@ var (
 // NewRawExporter returns a new Dogstatsd-syntax exporter for use in a pipeline.
 func NewRawExporter(config Config) (*Exporter, error) {
 	exp := &Exporter{
+		dialect:          config.Dialect,
 		attributeEncoder: NewAttributeEncoder(),
 	}
 
@@ -100,12 +115,23 @@ func NewExportPipeline(config Config, opts ...controller.Option) (*controller.Co
 }
 
 // AppendName is part of the stats-internal adapter interface.
-func (*Exporter) AppendName(rec export.Record, buf *bytes.Buffer) {
+func (e *Exporter) AppendName(rec export.Record, buf *bytes.Buffer) {
 	_, _ = buf.WriteString(rec.Descriptor().Name())
+	if e.dialect != DialectStatsD {
+		return
+	}
+	iter := rec.Labels().Iter()
+	for iter.Next() {
+		_, _ = buf.WriteRune('.')
+		writeNameSegment(buf, iter.Attribute().Value.Emit())
+	}
 }
 
 // AppendTags is part of the stats-internal adapter interface.
 func (e *Exporter) AppendTags(rec export.Record, res *resource.Resource, buf *bytes.Buffer) {
+	if e.dialect == DialectStatsD {
+		return
+	}
 	rencoded := res.Encoded(e.attributeEncoder)
 	lencoded := rec.Labels().Encoded(e.attributeEncoder)
 
@@ -129,3 +155,15 @@ func (e *Exporter) AppendTags(rec export.Record, res *resource.Resource, buf *by
 
 	_, _ = buf.WriteString(lencoded)
 }
+
+// writeNameSegment writes s as a segment of a plain statsd metric name,
+// replacing the characters that are part of the statsd syntax.
+func writeNameSegment(buf *bytes.Buffer, s string) {
+	for _, r := range s {
+		switch r {
+		case '.', ':', '|', '@', '#', ' ', '\n':
+			r = '_'
+		}
+		_, _ = buf.WriteRune(r)
+	}
+}
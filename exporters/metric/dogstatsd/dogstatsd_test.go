@@ -104,3 +104,32 @@ func TestDogstatsAttributes(t *testing.T) {
 		})
 	}
 }
+
+// TestStatsdDialect tests that attribute values are appended to the metric
+// name in the plain statsd dialect, and that resources are not exported.
+func TestStatsdDialect(t *testing.T) {
+	res := resource.NewWithAttributes(semconv.SchemaURL, attribute.String("R", "S"))
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	exp, err := dogstatsd.NewRawExporter(dogstatsd.Config{
+		Writer:  &buf,
+		Dialect: dogstatsd.DialectStatsD,
+	})
+	require.Nil(t, err)
+
+	aggSel := processortest.AggregatorSelector()
+	proc := processor.NewFactory(aggSel, export.StatelessExportKindSelector())
+	cont := controller.New(proc,
+		controller.WithExporter(exp),
+		controller.WithResource(res),
+	)
+	require.NoError(t, cont.Start(ctx))
+	meter := cont.Meter("test")
+	counter := metric.Must(meter).NewInt64Counter("test.sum")
+	counter.Add(ctx, 123, attribute.String("method", "GET"), attribute.String("route", "/a.b:c"))
+
+	require.NoError(t, cont.Stop(ctx))
+
+	require.Equal(t, "test.sum.GET./a_b_c:123|c\n", buf.String())
+}
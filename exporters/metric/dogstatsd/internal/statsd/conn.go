@@ -23,9 +23,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math/rand"
 	"net"
 	"net/url"
 	"strconv"
+	"time"
 
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/number"
@@ -56,6 +58,17 @@ type (
 		// Prefix will be prepended to every metric name.
 		Prefix string
 
+		// Dialect is the statsd syntax variation of the exported
+		// data.  Defaults to DogStatsD.
+		Dialect Dialect
+
+		// SampleRate is the fraction of the histogram and timing
+		// points that are exported.  Each exported point carries the
+		// sample rate so that the receiver can scale it.  Counters and
+		// gauges are always exported.  A rate of zero or of at least
+		// one disables sampling.
+		SampleRate float64
+
 		// TODO support Dial and Write timeouts
 	}
 
@@ -67,8 +80,12 @@ type (
 		conn    net.Conn
 		writer  io.Writer
 		buffer  bytes.Buffer
+		random  *rand.Rand
 	}
 
+	// Dialect is a statsd syntax variation.
+	Dialect int
+
 	// Adapter supports statsd syntax variations, primarily plain
 	// statsd vs. dogstatsd.
 	Adapter interface {
@@ -77,6 +94,14 @@ type (
 	}
 )
 
+const (
+	// DogStatsD encodes attributes and resources as dogstatsd tags.
+	DogStatsD Dialect = iota
+	// StatsD appends the attribute values to the metric name, as
+	// plain statsd has no tags.  Resources are not exported.
+	StatsD
+)
+
 const (
 	formatCounter   = "c"
 	formatHistogram = "h"
@@ -121,6 +146,7 @@ func NewExporter(config Config, adapter Adapter) (*Exporter, error) {
 		config:  config,
 		conn:    conn,
 		writer:  writer,
+		random:  rand.New(rand.NewSource(time.Now().UnixNano())),
 	}, err
 }
 
@@ -278,28 +304,34 @@ func (e *Exporter) formatMetric(rec export.Record, res *resource.Resource, pos i
 		if err != nil {
 			return err
 		}
-		e.formatSingleStat(rec, res, points[pos].Number, format, buf)
+		rate := e.config.SampleRate
+		if rate <= 0 || rate >= 1 {
+			rate = 1
+		} else if e.random.Float64() >= rate {
+			return nil
+		}
+		e.formatSingleStat(rec, res, points[pos].Number, format, rate, buf)
 
 	} else if sum, ok := agg.(aggregation.Sum); ok {
 		sum, err := sum.Sum()
 		if err != nil {
 			return err
 		}
-		e.formatSingleStat(rec, res, sum, formatCounter, buf)
+		e.formatSingleStat(rec, res, sum, formatCounter, 1, buf)
 
 	} else if lv, ok := agg.(aggregation.LastValue); ok {
 		lv, _, err := lv.LastValue()
 		if err != nil {
 			return err
 		}
-		e.formatSingleStat(rec, res, lv, formatGauge, buf)
+		e.formatSingleStat(rec, res, lv, formatGauge, 1, buf)
 	}
 	return nil
 }
 
 // formatSingleStat encodes a single item of statsd data followed by a
-// newline.
-func (e *Exporter) formatSingleStat(rec export.Record, res *resource.Resource, val number.Number, fmtStr string, buf *bytes.Buffer) {
+// newline.  A sample rate below one is encoded after the type.
+func (e *Exporter) formatSingleStat(rec export.Record, res *resource.Resource, val number.Number, fmtStr string, rate float64, buf *bytes.Buffer) {
 	if e.config.Prefix != "" {
 		_, _ = buf.WriteString(e.config.Prefix)
 	}
@@ -308,6 +340,10 @@ func (e *Exporter) formatSingleStat(rec export.Record, res *resource.Resource, v
 	writeNumber(buf, val, rec.Descriptor().NumberKind())
 	_, _ = buf.WriteRune('|')
 	_, _ = buf.WriteString(fmtStr)
+	if rate < 1 {
+		_, _ = buf.WriteString("|@")
+		_, _ = buf.Write(strconv.AppendFloat(nil, rate, 'g', -1, 64))
+	}
 	e.adapter.AppendTags(rec, res, buf)
 	_, _ = buf.WriteRune('\n')
 }
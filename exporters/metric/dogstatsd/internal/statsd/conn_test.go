@@ -483,3 +483,40 @@ func TestPrefix(t *testing.T) {
 	require.Equal(t, `veryspecial.histogram:100|h|#
 `, strings.Join(writer.vec, ""))
 }
+
+func TestSampleRate(t *testing.T) {
+	writer := &testWriter{}
+	config := statsd.Config{
+		Writer:     writer,
+		SampleRate: 0.5,
+	}
+	exp, err := statsd.NewExporter(config, newNoTagsAdapter())
+	require.NoError(t, err)
+
+	ctx, meter, cont := testMeter(t, exp)
+	histo := metric.Must(meter).NewInt64Histogram("histogram")
+	counter := metric.Must(meter).NewInt64Counter("counter")
+	require.NoError(t, cont.Start(ctx))
+
+	for i := 0; i < 1000; i++ {
+		histo.Record(ctx, 100)
+	}
+	counter.Add(ctx, 1000)
+
+	require.NoError(t, cont.Stop(ctx))
+
+	var sampled int
+	lines := strings.Split(strings.TrimSuffix(strings.Join(writer.vec, ""), "\n"), "\n")
+	for _, line := range lines {
+		if strings.HasPrefix(line, "counter:") {
+			require.Equal(t, "counter:1000|c", line)
+			continue
+		}
+		require.Equal(t, "histogram:100|h|@0.5", line)
+		sampled++
+	}
+	// The probability of sampling fewer than 400 or more than 600 of
+	// the 1000 points is negligible.
+	require.Greater(t, sampled, 400)
+	require.Less(t, sampled, 600)
+}
@@ -1,3 +1,5 @@
 module go.opentelemetry.io/contrib
 
 go 1.15
+
+require go.opentelemetry.io/otel v1.1.0
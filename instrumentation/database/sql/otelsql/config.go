@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+
+// config contains the options of the instrumentation.
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	attributes     []attribute.KeyValue
+	sanitizer      func(string) string
+
+	tracingDisabled bool
+	metricsDisabled bool
+}
+
+// Option applies a configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithTracerProvider sets the tracer provider used to create spans.
+// Defaults to the global tracer provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return optionFunc(func(c *config) {
+		if provider != nil {
+			c.tracerProvider = provider
+		}
+	})
+}
+
+// WithMeterProvider sets the meter provider used to create the
+// connection-pool instruments of RegisterDBStatsMetrics.
+// Defaults to the global meter provider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(c *config) {
+		if provider != nil {
+			c.meterProvider = provider
+		}
+	})
+}
+
+// WithDBSystem sets the db.system attribute of the spans and metrics to
+// the database management system, e.g. semconv.DBSystemPostgreSQL.Value.
+func WithDBSystem(system string) Option {
+	return WithAttributes(semconv.DBSystemKey.String(system))
+}
+
+// WithAttributes adds attrs to the spans and metrics, e.g. the db.name or
+// net.peer.name attributes of the database.
+func WithAttributes(attrs ...attribute.KeyValue) Option {
+	return optionFunc(func(c *config) {
+		c.attributes = append(c.attributes, attrs...)
+	})
+}
+
+// WithStatementSanitizer sets the function applied to the statements before
+// they are recorded with the db.statement attribute, e.g. SanitizeStatement
+// to remove the literal values, or the Sanitize method of a
+// sqlsanitize.Sanitizer to also truncate them. If fn returns an empty
+// string, the statement is not recorded. By default the statements are
+// recorded as is.
+func WithStatementSanitizer(fn func(query string) string) Option {
+	return optionFunc(func(c *config) {
+		c.sanitizer = fn
+	})
+}
+
+// WithTracingDisabled disables the spans of the instrumented driver, e.g.
+// to only report the connection-pool metrics of RegisterDBStatsMetrics
+// without a trace backend.
+func WithTracingDisabled() Option {
+	return optionFunc(func(c *config) {
+		c.tracingDisabled = true
+	})
+}
+
+// WithMetricsDisabled disables the connection-pool metrics, RegisterDBStatsMetrics
+// then registers no instruments.
+func WithMetricsDisabled() Option {
+	return optionFunc(func(c *config) {
+		c.metricsDisabled = true
+	})
+}
+
+func newConfig(opts ...Option) *config {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  global.GetMeterProvider(),
+	}
+	for _, o := range opts {
+		o.apply(c)
+	}
+	if c.tracingDisabled {
+		c.tracerProvider = trace.NewNoopTracerProvider()
+	}
+	return c
+}
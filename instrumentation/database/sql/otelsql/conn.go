@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+)
+
+// otConn is an instrumented driver.Conn. It implements the optional
+// interfaces of database/sql on top of the ones of the wrapped connection.
+type otConn struct {
+	conn   driver.Conn
+	tracer *tracer
+}
+
+// compile time assertions that otConn implements the optional interfaces
+// of driver.Conn.
+var (
+	_ driver.Pinger             = (*otConn)(nil)
+	_ driver.ExecerContext      = (*otConn)(nil)
+	_ driver.QueryerContext     = (*otConn)(nil)
+	_ driver.ConnPrepareContext = (*otConn)(nil)
+	_ driver.ConnBeginTx        = (*otConn)(nil)
+	_ driver.SessionResetter    = (*otConn)(nil)
+	_ driver.Validator          = (*otConn)(nil)
+	_ driver.NamedValueChecker  = (*otConn)(nil)
+)
+
+func newConn(conn driver.Conn, t *tracer) *otConn {
+	return &otConn{conn: conn, tracer: t}
+}
+
+// Prepare returns a prepared statement. It is not traced, database/sql
+// prepares with PrepareContext.
+func (c *otConn) Prepare(query string) (driver.Stmt, error) {
+	s, err := c.conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	return newStmt(s, c, query), nil
+}
+
+// Close closes the connection.
+func (c *otConn) Close() error {
+	return c.conn.Close()
+}
+
+// Begin starts a transaction. It is not traced, database/sql begins
+// transactions with BeginTx.
+//
+// Deprecated: Drivers should implement ConnBeginTx instead (or additionally).
+func (c *otConn) Begin() (driver.Tx, error) { //nolint:staticcheck
+	tx, err := c.conn.Begin() //nolint:staticcheck
+	if err != nil {
+		return nil, err
+	}
+	return newTx(context.Background(), tx, c.tracer), nil
+}
+
+// Ping verifies the connection is alive, if the wrapped connection
+// implements driver.Pinger.
+func (c *otConn) Ping(ctx context.Context) error {
+	p, ok := c.conn.(driver.Pinger)
+	if !ok {
+		return nil
+	}
+	ctx, span := c.tracer.start(ctx, spanPing, "")
+	err := p.Ping(ctx)
+	end(span, err)
+	return err
+}
+
+// ExecContext executes query without preparing it. It returns
+// driver.ErrSkip if the wrapped connection does not support it.
+func (c *otConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.conn.(driver.ExecerContext)
+	execerLegacy, okLegacy := c.conn.(driver.Execer) //nolint:staticcheck
+	if !ok && !okLegacy {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := c.tracer.start(ctx, spanExec, query)
+	var res driver.Result
+	var err error
+	if ok {
+		res, err = execer.ExecContext(ctx, query, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValueToValue(args); err == nil {
+			if err = ctx.Err(); err == nil {
+				res, err = execerLegacy.Exec(query, values)
+			}
+		}
+	}
+	end(span, err)
+	return res, err
+}
+
+// QueryContext executes query without preparing it. It returns
+// driver.ErrSkip if the wrapped connection does not support it.
+func (c *otConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.conn.(driver.QueryerContext)
+	queryerLegacy, okLegacy := c.conn.(driver.Queryer) //nolint:staticcheck
+	if !ok && !okLegacy {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := c.tracer.start(ctx, spanQuery, query)
+	var rows driver.Rows
+	var err error
+	if ok {
+		rows, err = queryer.QueryContext(ctx, query, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValueToValue(args); err == nil {
+			if err = ctx.Err(); err == nil {
+				rows, err = queryerLegacy.Query(query, values)
+			}
+		}
+	}
+	end(span, err)
+	return rows, err
+}
+
+// PrepareContext returns a prepared statement.
+func (c *otConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	ctx, span := c.tracer.start(ctx, spanPrepare, query)
+	var s driver.Stmt
+	var err error
+	if p, ok := c.conn.(driver.ConnPrepareContext); ok {
+		s, err = p.PrepareContext(ctx, query)
+	} else if s, err = c.conn.Prepare(query); err == nil {
+		if err = ctx.Err(); err != nil {
+			s.Close()
+		}
+	}
+	end(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return newStmt(s, c, query), nil
+}
+
+// BeginTx starts a transaction.
+func (c *otConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	spanCtx, span := c.tracer.start(ctx, spanBeginTx, "")
+	var tx driver.Tx
+	var err error
+	if b, ok := c.conn.(driver.ConnBeginTx); ok {
+		tx, err = b.BeginTx(spanCtx, opts)
+	} else {
+		tx, err = beginLegacy(spanCtx, c.conn, opts)
+	}
+	end(span, err)
+	if err != nil {
+		return nil, err
+	}
+	// The commit and rollback spans are siblings of the begin span.
+	return newTx(ctx, tx, c.tracer), nil
+}
+
+// ResetSession resets the session of the connection, if the wrapped
+// connection implements driver.SessionResetter.
+func (c *otConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid reports whether the connection is valid, if the wrapped
+// connection implements driver.Validator.
+func (c *otConn) IsValid() bool {
+	if v, ok := c.conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue checks an argument with the wrapped connection, if it
+// implements driver.NamedValueChecker, and returns driver.ErrSkip otherwise
+// for database/sql to apply its default conversion.
+func (c *otConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if nvc, ok := c.conn.(driver.NamedValueChecker); ok {
+		return nvc.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// beginLegacy starts a transaction on a connection that does not implement
+// driver.ConnBeginTx, as database/sql does.
+func beginLegacy(ctx context.Context, conn driver.Conn, opts driver.TxOptions) (driver.Tx, error) {
+	if opts.Isolation != driver.IsolationLevel(0) {
+		return nil, errors.New("sql: driver does not support non-default isolation level")
+	}
+	if opts.ReadOnly {
+		return nil, errors.New("sql: driver does not support read-only transactions")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return conn.Begin() //nolint:staticcheck
+}
+
+// namedValueToValue converts the arguments of a query for the deprecated
+// interfaces not supporting named arguments.
+func namedValueToValue(named []driver.NamedValue) ([]driver.Value, error) {
+	args := make([]driver.Value, len(named))
+	for i, nv := range named {
+		if nv.Name != "" {
+			return nil, errors.New("sql: driver does not support the use of Named Parameters")
+		}
+		args[i] = nv.Value
+	}
+	return args, nil
+}
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelsql instruments the database/sql package.
+//
+// A driver is instrumented by wrapping it with WrapDriver, or its connector
+// with WrapConnector, and the database handle is opened as usual with the
+// wrapped driver or connector. Open does both for a registered driver. The
+// instrumented driver creates a client span for each new connection, ping,
+// statement execution, query, prepare, and transaction begin, commit, and
+// rollback.
+//
+// The connection-pool statistics of a database handle are reported as
+// metrics by RegisterDBStatsMetrics.
+package otelsql // import "go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	"go.opentelemetry.io/contrib/instrumentation/database/sqlsanitize"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The names of the spans created by the instrumentation.
+const (
+	spanConnect   = "sql.connector.connect"
+	spanPing      = "sql.conn.ping"
+	spanPrepare   = "sql.conn.prepare"
+	spanExec      = "sql.conn.exec"
+	spanQuery     = "sql.conn.query"
+	spanBeginTx   = "sql.conn.begin_tx"
+	spanStmtExec  = "sql.stmt.exec"
+	spanStmtQuery = "sql.stmt.query"
+	spanCommit    = "sql.tx.commit"
+	spanRollback  = "sql.tx.rollback"
+)
+
+// tracer creates the spans of the instrumented driver.
+type tracer struct {
+	cfg    *config
+	tracer trace.Tracer
+}
+
+func newTracer(opts []Option) *tracer {
+	cfg := newConfig(opts...)
+	return &tracer{
+		cfg: cfg,
+		tracer: cfg.tracerProvider.Tracer(
+			instrumentationName,
+			trace.WithInstrumentationVersion(SemVersion()),
+		),
+	}
+}
+
+// start starts a client span. The query, if not empty, is recorded with
+// the db.statement attribute after sanitization, and its operation with
+// the db.operation attribute. When tracing is disabled, ctx is returned as is
+// with a span that is not recording.
+func (t *tracer) start(ctx context.Context, name, query string) (context.Context, trace.Span) {
+	if t.cfg.tracingDisabled {
+		_, span := t.tracer.Start(ctx, name)
+		return ctx, span
+	}
+	ctx, span := t.tracer.Start(
+		ctx,
+		name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(t.cfg.attributes...),
+	)
+	if query != "" && span.IsRecording() {
+		if op := sqlsanitize.Operation(query); op != "" {
+			span.SetAttributes(semconv.DBOperationKey.String(op))
+		}
+		if t.cfg.sanitizer != nil {
+			query = t.cfg.sanitizer(query)
+		}
+		if query != "" {
+			span.SetAttributes(semconv.DBStatementKey.String(query))
+		}
+	}
+	return ctx, span
+}
+
+// end ends span, recording err unless it is driver.ErrSkip, which only
+// tells database/sql to fall back to another method.
+func end(span trace.Span, err error) {
+	if err != nil && err != driver.ErrSkip {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// otDriver is an instrumented driver.Driver.
+type otDriver struct {
+	driver driver.Driver
+	tracer *tracer
+}
+
+// compile time assertion that otDriver implements driver.DriverContext.
+var _ driver.DriverContext = (*otDriver)(nil)
+
+// WrapDriver returns an instrumented driver wrapping d. It is meant to be
+// registered with sql.Register and opened with sql.Open.
+func WrapDriver(d driver.Driver, opts ...Option) driver.Driver {
+	return &otDriver{driver: d, tracer: newTracer(opts)}
+}
+
+// Open returns a new connection to the database. As no context is available
+// to parent a span, the connection is not traced; database/sql connects with
+// the connector of OpenConnector instead.
+func (d *otDriver) Open(name string) (driver.Conn, error) {
+	c, err := d.driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return newConn(c, d.tracer), nil
+}
+
+// OpenConnector returns an instrumented connector to the database.
+func (d *otDriver) OpenConnector(name string) (driver.Connector, error) {
+	var c driver.Connector = dsnConnector{dsn: name, driver: d.driver}
+	if dc, ok := d.driver.(driver.DriverContext); ok {
+		var err error
+		if c, err = dc.OpenConnector(name); err != nil {
+			return nil, err
+		}
+	}
+	return &otConnector{connector: c, driver: d, tracer: d.tracer}, nil
+}
+
+// otConnector is an instrumented driver.Connector.
+type otConnector struct {
+	connector driver.Connector
+	driver    driver.Driver
+	tracer    *tracer
+}
+
+// WrapConnector returns an instrumented connector wrapping c. It is meant to
+// be opened with sql.OpenDB.
+func WrapConnector(c driver.Connector, opts ...Option) driver.Connector {
+	t := newTracer(opts)
+	return &otConnector{
+		connector: c,
+		driver:    &otDriver{driver: c.Driver(), tracer: t},
+		tracer:    t,
+	}
+}
+
+// Connect returns a new connection to the database.
+func (c *otConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	ctx, span := c.tracer.start(ctx, spanConnect, "")
+	conn, err := c.connector.Connect(ctx)
+	end(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return newConn(conn, c.tracer), nil
+}
+
+// Driver returns the instrumented driver of the connector.
+func (c *otConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// dsnConnector is the connector of a driver that does not implement
+// driver.DriverContext.
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// Open opens a database with the instrumented driver registered with
+// sql.Register as driverName. Like sql.Open, it does not create any
+// connection.
+func Open(driverName, dataSourceName string, opts ...Option) (*sql.DB, error) {
+	// The registered driver is only exposed by a database handle.
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	d := db.Driver()
+	if err := db.Close(); err != nil {
+		return nil, err
+	}
+
+	c, err := WrapDriver(d, opts...).(driver.DriverContext).OpenConnector(dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(c), nil
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql_test
+
+import (
+	"context"
+	"log"
+
+	"go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func ExampleOpen() {
+	// The postgres driver is registered by importing it, e.g.
+	// _ "github.com/lib/pq".
+	db, err := otelsql.Open(
+		"postgres",
+		"postgres://localhost/app?sslmode=disable",
+		otelsql.WithDBSystem(semconv.DBSystemPostgreSQL.Value.AsString()),
+		otelsql.WithStatementSanitizer(otelsql.SanitizeStatement),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := otelsql.RegisterDBStatsMetrics(db, otelsql.WithDBSystem(semconv.DBSystemPostgreSQL.Value.AsString())); err != nil {
+		log.Fatal(err)
+	}
+
+	rows, err := db.QueryContext(context.Background(), "SELECT id FROM users WHERE name = 'alice'")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer rows.Close()
+}
@@ -0,0 +1,12 @@
+module go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql
+
+go 1.15
+
+replace go.opentelemetry.io/contrib/instrumentation/database/sqlsanitize => ../../sqlsanitize
+
+require (
+	go.opentelemetry.io/contrib/instrumentation/database/sqlsanitize v0.26.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/metric v0.24.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
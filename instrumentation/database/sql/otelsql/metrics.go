@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql
+
+import (
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+const (
+	// connectionStateKey is the attribute key of the state of the open
+	// connections, idle or in_use.
+	connectionStateKey = attribute.Key("state")
+	// closedReasonKey is the attribute key of the reason connections were
+	// closed for, max_idle, max_idle_time, or max_lifetime.
+	closedReasonKey = attribute.Key("reason")
+)
+
+// RegisterDBStatsMetrics reports the connection-pool statistics of db,
+// with the attributes set by WithDBSystem and WithAttributes. The
+// statistics are read from db.Stats when the metrics are collected:
+//
+//   - db.sql.connections.max_open: the maximum number of open connections.
+//   - db.sql.connections.open: the number of open connections, by state.
+//   - db.sql.connections.wait_count: the number of connections waited for.
+//   - db.sql.connections.wait_duration: the time blocked waiting for
+//     connections, in milliseconds.
+//   - db.sql.connections.closed: the number of connections closed by the
+//     pool, by reason.
+//
+// No instruments are registered with WithMetricsDisabled.
+func RegisterDBStatsMetrics(db *sql.DB, opts ...Option) error {
+	cfg := newConfig(opts...)
+	if cfg.metricsDisabled {
+		return nil
+	}
+	meter := cfg.meterProvider.Meter(
+		instrumentationName,
+		metric.WithInstrumentationVersion(SemVersion()),
+	)
+
+	var (
+		err          error
+		maxOpen      metric.Int64GaugeObserver
+		open         metric.Int64UpDownCounterObserver
+		waitCount    metric.Int64CounterObserver
+		waitDuration metric.Int64CounterObserver
+		closed       metric.Int64CounterObserver
+	)
+
+	labels := func(kv ...attribute.KeyValue) []attribute.KeyValue {
+		return append(append([]attribute.KeyValue{}, cfg.attributes...), kv...)
+	}
+	var (
+		poolLabels        = labels()
+		idleLabels        = labels(connectionStateKey.String("idle"))
+		inUseLabels       = labels(connectionStateKey.String("in_use"))
+		maxIdleLabels     = labels(closedReasonKey.String("max_idle"))
+		maxIdleTimeLabels = labels(closedReasonKey.String("max_idle_time"))
+		maxLifetimeLabels = labels(closedReasonKey.String("max_lifetime"))
+	)
+
+	batch := meter.NewBatchObserver(func(_ context.Context, result metric.BatchObserverResult) {
+		s := db.Stats()
+		result.Observe(poolLabels,
+			maxOpen.Observation(int64(s.MaxOpenConnections)),
+			waitCount.Observation(s.WaitCount),
+			waitDuration.Observation(s.WaitDuration.Milliseconds()),
+		)
+		result.Observe(idleLabels, open.Observation(int64(s.Idle)))
+		result.Observe(inUseLabels, open.Observation(int64(s.InUse)))
+		result.Observe(maxIdleLabels, closed.Observation(s.MaxIdleClosed))
+		result.Observe(maxIdleTimeLabels, closed.Observation(s.MaxIdleTimeClosed))
+		result.Observe(maxLifetimeLabels, closed.Observation(s.MaxLifetimeClosed))
+	})
+
+	if maxOpen, err = batch.NewInt64GaugeObserver(
+		"db.sql.connections.max_open",
+		metric.WithDescription("Maximum number of open connections to the database"),
+	); err != nil {
+		return err
+	}
+	if open, err = batch.NewInt64UpDownCounterObserver(
+		"db.sql.connections.open",
+		metric.WithDescription("Number of open connections to the database"),
+	); err != nil {
+		return err
+	}
+	if waitCount, err = batch.NewInt64CounterObserver(
+		"db.sql.connections.wait_count",
+		metric.WithDescription("Total number of connections waited for"),
+	); err != nil {
+		return err
+	}
+	if waitDuration, err = batch.NewInt64CounterObserver(
+		"db.sql.connections.wait_duration",
+		metric.WithUnit(unit.Milliseconds),
+		metric.WithDescription("Total time blocked waiting for a new connection"),
+	); err != nil {
+		return err
+	}
+	if closed, err = batch.NewInt64CounterObserver(
+		"db.sql.connections.closed",
+		metric.WithDescription("Total number of connections closed by the pool"),
+	); err != nil {
+		return err
+	}
+	return nil
+}
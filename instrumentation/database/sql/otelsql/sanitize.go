@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql
+
+import "go.opentelemetry.io/contrib/instrumentation/database/sqlsanitize"
+
+// SanitizeStatement returns query with its string and numeric literals
+// replaced by a ? placeholder, so that the values of a statement are not
+// recorded. Quoted identifiers and placeholders such as $1 are kept.
+//
+// It is meant to be used with WithStatementSanitizer. A
+// sqlsanitize.Sanitizer additionally truncates the statements.
+func SanitizeStatement(query string) string {
+	return sqlsanitize.Literals(query)
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// otStmt is an instrumented driver.Stmt.
+type otStmt struct {
+	stmt  driver.Stmt
+	conn  *otConn
+	query string
+}
+
+// compile time assertions that otStmt implements the optional interfaces
+// of driver.Stmt.
+var (
+	_ driver.StmtExecContext   = (*otStmt)(nil)
+	_ driver.StmtQueryContext  = (*otStmt)(nil)
+	_ driver.NamedValueChecker = (*otStmt)(nil)
+)
+
+func newStmt(stmt driver.Stmt, conn *otConn, query string) *otStmt {
+	return &otStmt{stmt: stmt, conn: conn, query: query}
+}
+
+// Close closes the statement.
+func (s *otStmt) Close() error {
+	return s.stmt.Close()
+}
+
+// NumInput returns the number of placeholder parameters of the statement.
+func (s *otStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+// Exec executes the statement. It is not traced, database/sql executes
+// statements with ExecContext.
+//
+// Deprecated: Drivers should implement StmtExecContext instead (or additionally).
+func (s *otStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.stmt.Exec(args) //nolint:staticcheck
+}
+
+// Query executes the query of the statement. It is not traced, database/sql
+// executes statements with QueryContext.
+//
+// Deprecated: Drivers should implement StmtQueryContext instead (or additionally).
+func (s *otStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.stmt.Query(args) //nolint:staticcheck
+}
+
+// ExecContext executes the statement.
+func (s *otStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, span := s.conn.tracer.start(ctx, spanStmtExec, s.query)
+	var res driver.Result
+	var err error
+	if e, ok := s.stmt.(driver.StmtExecContext); ok {
+		res, err = e.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValueToValue(args); err == nil {
+			if err = ctx.Err(); err == nil {
+				res, err = s.stmt.Exec(values) //nolint:staticcheck
+			}
+		}
+	}
+	end(span, err)
+	return res, err
+}
+
+// QueryContext executes the query of the statement.
+func (s *otStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, span := s.conn.tracer.start(ctx, spanStmtQuery, s.query)
+	var rows driver.Rows
+	var err error
+	if q, ok := s.stmt.(driver.StmtQueryContext); ok {
+		rows, err = q.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValueToValue(args); err == nil {
+			if err = ctx.Err(); err == nil {
+				rows, err = s.stmt.Query(values) //nolint:staticcheck
+			}
+		}
+	}
+	end(span, err)
+	return rows, err
+}
+
+// CheckNamedValue checks an argument with the wrapped statement, if it
+// implements driver.NamedValueChecker, and with the connection otherwise.
+func (s *otStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if nvc, ok := s.stmt.(driver.NamedValueChecker); ok {
+		return nvc.CheckNamedValue(nv)
+	}
+	return s.conn.CheckNamedValue(nv)
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+var errFake = errors.New("fake error")
+
+// failQuery is the statement the fake driver fails to execute.
+const failQuery = "FAIL"
+
+// fakeDriver is a driver.Driver implementing the context interfaces.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query}, nil }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+func (c *fakeConn) PrepareContext(_ context.Context, query string) (driver.Stmt, error) {
+	return c.Prepare(query)
+}
+
+func (c *fakeConn) Ping(context.Context) error { return nil }
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	return fakeExec(query)
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	return fakeQuery(query)
+}
+
+type fakeStmt struct{ query string }
+
+func (s fakeStmt) Close() error                               { return nil }
+func (s fakeStmt) NumInput() int                              { return -1 }
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) { return fakeExec(s.query) }
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error)  { return fakeQuery(s.query) }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+// fakeRows has a single row with a single column n set to 1.
+type fakeRows struct{ done bool }
+
+func (r *fakeRows) Columns() []string { return []string{"n"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
+
+func fakeExec(query string) (driver.Result, error) {
+	if query == failQuery {
+		return nil, errFake
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func fakeQuery(query string) (driver.Rows, error) {
+	if query == failQuery {
+		return nil, errFake
+	}
+	return &fakeRows{}, nil
+}
+
+// legacyDriver is a driver.Driver only implementing the required
+// interfaces.
+type legacyDriver struct{}
+
+func (legacyDriver) Open(string) (driver.Conn, error) { return legacyConn{}, nil }
+
+type legacyConn struct{}
+
+func (legacyConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query}, nil }
+func (legacyConn) Close() error                              { return nil }
+func (legacyConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+// legacyConnector is the connector of legacyDriver.
+type legacyConnector struct{}
+
+func (legacyConnector) Connect(context.Context) (driver.Conn, error) { return legacyConn{}, nil }
+func (legacyConnector) Driver() driver.Driver                        { return legacyDriver{} }
@@ -0,0 +1,17 @@
+module go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql/test
+
+go 1.15
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql v0.26.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/metric v0.24.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
+
+replace (
+	go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql => ../
+	go.opentelemetry.io/contrib/instrumentation/database/sqlsanitize => ../../../sqlsanitize
+)
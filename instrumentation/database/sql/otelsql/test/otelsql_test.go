@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/database/sql/otelsql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric/metrictest"
+	"go.opentelemetry.io/otel/metric/number"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func init() {
+	sql.Register("fake", fakeDriver{})
+}
+
+func TestOpen(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	db, err := otelsql.Open("fake", "dsn",
+		otelsql.WithTracerProvider(tp),
+		otelsql.WithDBSystem(semconv.DBSystemOtherSQL.Value.AsString()),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+
+	_, err = db.ExecContext(ctx, "UPDATE t SET a = 1")
+	require.NoError(t, err)
+
+	var n int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT n FROM t").Scan(&n))
+	assert.Equal(t, 1, n)
+
+	stmt, err := db.PrepareContext(ctx, "INSERT INTO t VALUES (?)")
+	require.NoError(t, err)
+	_, err = stmt.ExecContext(ctx, 1)
+	require.NoError(t, err)
+	require.NoError(t, stmt.Close())
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	_, err = db.ExecContext(ctx, failQuery)
+	assert.ErrorIs(t, err, errFake)
+
+	require.NoError(t, db.PingContext(ctx))
+	parent.End()
+
+	spans := sr.Ended()
+	var names []string
+	for _, s := range spans {
+		names = append(names, s.Name())
+	}
+	assert.Equal(t, []string{
+		"sql.connector.connect",
+		"sql.conn.exec",
+		"sql.conn.query",
+		"sql.conn.prepare",
+		"sql.stmt.exec",
+		"sql.conn.begin_tx",
+		"sql.tx.commit",
+		"sql.conn.exec",
+		"sql.conn.ping",
+		"parent",
+	}, names)
+
+	for _, s := range spans[:len(spans)-1] {
+		assert.Equal(t, trace.SpanKindClient, s.SpanKind(), s.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), s.Parent().SpanID(), s.Name())
+		assert.Contains(t, s.Attributes(), semconv.DBSystemOtherSQL, s.Name())
+	}
+
+	assert.Contains(t, spans[1].Attributes(), semconv.DBStatementKey.String("UPDATE t SET a = 1"))
+	assert.Contains(t, spans[2].Attributes(), semconv.DBStatementKey.String("SELECT n FROM t"))
+	assert.Contains(t, spans[3].Attributes(), semconv.DBStatementKey.String("INSERT INTO t VALUES (?)"))
+	assert.Contains(t, spans[4].Attributes(), semconv.DBStatementKey.String("INSERT INTO t VALUES (?)"))
+	assert.Contains(t, spans[1].Attributes(), semconv.DBOperationKey.String("UPDATE"))
+	assert.Contains(t, spans[2].Attributes(), semconv.DBOperationKey.String("SELECT"))
+
+	failed := spans[7]
+	assert.Equal(t, codes.Error, failed.Status().Code)
+	assert.Equal(t, errFake.Error(), failed.Status().Description)
+	require.Len(t, failed.Events(), 1)
+	assert.Equal(t, "exception", failed.Events()[0].Name)
+}
+
+func TestWrapConnectorLegacyDriver(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	db := sql.OpenDB(otelsql.WrapConnector(legacyConnector{},
+		otelsql.WithTracerProvider(tp),
+		otelsql.WithStatementSanitizer(otelsql.SanitizeStatement),
+	))
+	defer db.Close()
+
+	ctx := context.Background()
+	// The connection does not implement driver.ExecerContext, the statement
+	// is prepared and executed instead.
+	_, err := db.ExecContext(ctx, "UPDATE t SET a = 1")
+	require.NoError(t, err)
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback())
+
+	_, err = db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	assert.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 6)
+	assert.Equal(t, "sql.connector.connect", spans[0].Name())
+	assert.Equal(t, "sql.conn.prepare", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), semconv.DBStatementKey.String("UPDATE t SET a = ?"))
+	assert.Equal(t, "sql.stmt.exec", spans[2].Name())
+	assert.Contains(t, spans[2].Attributes(), semconv.DBStatementKey.String("UPDATE t SET a = ?"))
+	assert.Contains(t, spans[2].Attributes(), semconv.DBOperationKey.String("UPDATE"))
+	assert.Equal(t, "sql.conn.begin_tx", spans[3].Name())
+	assert.Equal(t, "sql.tx.rollback", spans[4].Name())
+	assert.Equal(t, "sql.conn.begin_tx", spans[5].Name())
+	assert.Equal(t, codes.Error, spans[5].Status().Code)
+}
+
+func TestRegisterDBStatsMetrics(t *testing.T) {
+	mp := metrictest.NewMeterProvider()
+
+	db, err := sql.Open("fake", "dsn")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(5)
+
+	require.NoError(t, otelsql.RegisterDBStatsMetrics(db,
+		otelsql.WithMeterProvider(mp),
+		otelsql.WithDBSystem("fake"),
+	))
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	mp.RunAsyncInstruments()
+
+	type key struct {
+		name  string
+		label attribute.KeyValue
+	}
+	got := map[key]number.Number{}
+	for _, m := range metrictest.AsStructs(mp.MeasurementBatches) {
+		assert.Equal(t, attribute.StringValue("fake"), m.Labels[semconv.DBSystemKey], m.Name)
+		var label attribute.KeyValue
+		for _, k := range []attribute.Key{"state", "reason"} {
+			if v, ok := m.Labels[k]; ok {
+				label = k.String(v.AsString())
+			}
+		}
+		got[key{m.Name, label}] = m.Number
+	}
+
+	assert.Equal(t, map[key]number.Number{
+		{"db.sql.connections.max_open", attribute.KeyValue{}}:                      number.NewInt64Number(5),
+		{"db.sql.connections.wait_count", attribute.KeyValue{}}:                    number.NewInt64Number(0),
+		{"db.sql.connections.wait_duration", attribute.KeyValue{}}:                 number.NewInt64Number(0),
+		{"db.sql.connections.open", attribute.String("state", "idle")}:             number.NewInt64Number(0),
+		{"db.sql.connections.open", attribute.String("state", "in_use")}:           number.NewInt64Number(1),
+		{"db.sql.connections.closed", attribute.String("reason", "max_idle")}:      number.NewInt64Number(0),
+		{"db.sql.connections.closed", attribute.String("reason", "max_idle_time")}: number.NewInt64Number(0),
+		{"db.sql.connections.closed", attribute.String("reason", "max_lifetime")}:  number.NewInt64Number(0),
+	}, got)
+}
+
+func TestTracingDisabled(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	db, err := otelsql.Open("fake", "dsn",
+		otelsql.WithTracerProvider(tp),
+		otelsql.WithTracingDisabled(),
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, err = db.ExecContext(ctx, "UPDATE t SET a = 1")
+	require.NoError(t, err)
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	// The parent span is not ended by the instrumentation.
+	assert.Empty(t, sr.Ended())
+	parent.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "parent", spans[0].Name())
+}
+
+func TestRegisterDBStatsMetricsDisabled(t *testing.T) {
+	mp := metrictest.NewMeterProvider()
+
+	db, err := sql.Open("fake", "dsn")
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, otelsql.RegisterDBStatsMetrics(db,
+		otelsql.WithMeterProvider(mp),
+		otelsql.WithMetricsDisabled(),
+	))
+	mp.RunAsyncInstruments()
+	assert.Empty(t, mp.MeasurementBatches)
+}
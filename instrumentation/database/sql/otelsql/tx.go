@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsql
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// otTx is an instrumented driver.Tx.
+type otTx struct {
+	// ctx is the context the transaction was started with. driver.Tx
+	// methods have no context, so it parents the commit and rollback spans.
+	ctx    context.Context
+	tx     driver.Tx
+	tracer *tracer
+}
+
+func newTx(ctx context.Context, tx driver.Tx, t *tracer) *otTx {
+	return &otTx{ctx: ctx, tx: tx, tracer: t}
+}
+
+// Commit commits the transaction.
+func (t *otTx) Commit() error {
+	_, span := t.tracer.start(t.ctx, spanCommit, "")
+	err := t.tx.Commit()
+	end(span, err)
+	return err
+}
+
+// Rollback aborts the transaction.
+func (t *otTx) Rollback() error {
+	_, span := t.tracer.start(t.ctx, spanRollback, "")
+	err := t.tx.Rollback()
+	end(span, err)
+	return err
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlsanitize
+
+// config contains the options of a Sanitizer.
+type config struct {
+	literals  bool
+	maxLength int
+}
+
+// Option applies a configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithLiterals sets whether the literals of the statements are replaced
+// with Literals. Defaults to true.
+func WithLiterals(enabled bool) Option {
+	return optionFunc(func(c *config) {
+		c.literals = enabled
+	})
+}
+
+// WithMaxLength sets the maximum length in bytes of the sanitized
+// statements, which are truncated with Truncate. A length that is not
+// positive disables the truncation. Defaults to DefaultMaxLength.
+func WithMaxLength(n int) Option {
+	return optionFunc(func(c *config) {
+		c.maxLength = n
+	})
+}
+
+func newConfig(opts ...Option) config {
+	c := config{
+		literals:  true,
+		maxLength: DefaultMaxLength,
+	}
+	for _, o := range opts {
+		o.apply(&c)
+	}
+	return c
+}
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlsanitize normalizes SQL statements before they are recorded by
+// instrumentation, e.g. with the db.statement attribute, so that the
+// recorded statements have a low cardinality and do not contain the values
+// of the queries.
+//
+// Literals replaces the string and numeric literals of a statement, Truncate
+// limits its length, and Operation extracts its operation, e.g. SELECT. A
+// Sanitizer combines them and is meant to be shared by the database
+// instrumentation packages, such as otelsql and otelgocql.
+package sqlsanitize // import "go.opentelemetry.io/contrib/instrumentation/database/sqlsanitize"
@@ -0,0 +1,5 @@
+module go.opentelemetry.io/contrib/instrumentation/database/sqlsanitize
+
+go 1.15
+
+require github.com/stretchr/testify v1.7.0
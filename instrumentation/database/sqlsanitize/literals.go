@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlsanitize
+
+import "strings"
+
+// Literals returns query with its string and numeric literals replaced by
+// a ? placeholder, so that the values of a statement are not recorded.
+// Quoted identifiers, placeholders such as $1, and comments are kept.
+func Literals(query string) string {
+	var b strings.Builder
+	b.Grow(len(query))
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '-' && strings.HasPrefix(query[i:], "--"),
+			c == '/' && strings.HasPrefix(query[i:], "/*"):
+			j := skipComment(query, i)
+			b.WriteString(query[i:j])
+			i = j
+		case c == '\'':
+			i = skipQuoted(query, i)
+			b.WriteByte('?')
+		case c == '"' || c == '`':
+			j := skipQuoted(query, i)
+			b.WriteString(query[i:j])
+			i = j
+		case isDigit(c) && (i == 0 || !isIdentifier(query[i-1])):
+			i = skipNumber(query, i)
+			b.WriteByte('?')
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// skipQuoted returns the index following the quoted literal starting at i.
+// A doubled quote is an escaped quote. In strings, a backslash escapes the
+// following character as well, as in MySQL.
+func skipQuoted(query string, i int) int {
+	quote := query[i]
+	for i++; i < len(query); i++ {
+		if query[i] == '\\' && quote != '`' {
+			i++
+			continue
+		}
+		if query[i] != quote {
+			continue
+		}
+		if i+1 < len(query) && query[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(query)
+}
+
+// skipComment returns the index following the -- or /* comment starting at
+// i. A -- comment ends at the end of the line.
+func skipComment(query string, i int) int {
+	if query[i] == '-' {
+		if j := strings.IndexByte(query[i:], '\n'); j >= 0 {
+			return i + j
+		}
+		return len(query)
+	}
+	if j := strings.Index(query[i+2:], "*/"); j >= 0 {
+		return i + 2 + j + 2
+	}
+	return len(query)
+}
+
+// skipNumber returns the index following the numeric literal starting at
+// i, including decimal, exponent, and hexadecimal forms.
+func skipNumber(query string, i int) int {
+	for i++; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case isIdentifier(c) || c == '.':
+		case (c == '+' || c == '-') && (query[i-1] == 'e' || query[i-1] == 'E'):
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// isIdentifier reports whether c can be part of an identifier or a
+// placeholder. Non-ASCII bytes are assumed to be part of identifiers.
+func isIdentifier(c byte) bool {
+	return isDigit(c) ||
+		'a' <= c && c <= 'z' ||
+		'A' <= c && c <= 'Z' ||
+		c == '_' || c == '$' || c == ':' || c == '@' ||
+		c >= 0x80
+}
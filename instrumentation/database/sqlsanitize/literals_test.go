@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlsanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiterals(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT * FROM users", "SELECT * FROM users"},
+		{"SELECT * FROM users WHERE id = 42", "SELECT * FROM users WHERE id = ?"},
+		{"SELECT * FROM users WHERE name = 'O''Brien' AND age > 3.5e+1", "SELECT * FROM users WHERE name = ? AND age > ?"},
+		{"INSERT INTO t (a, b) VALUES ('x', -12), ('', 0x1F)", "INSERT INTO t (a, b) VALUES (?, -?), (?, ?)"},
+		{"SELECT * FROM table1 WHERE id = $1 AND v = :2 AND w = ?", "SELECT * FROM table1 WHERE id = $1 AND v = :2 AND w = ?"},
+		{`SELECT "col 1", ` + "`it's`" + ` FROM t1 WHERE x = 'a'`, `SELECT "col 1", ` + "`it's`" + ` FROM t1 WHERE x = ?`},
+		{"SELECT 'unterminated", "SELECT ?"},
+		{"SELECT * FROM ta1 LIMIT 10", "SELECT * FROM ta1 LIMIT ?"},
+		// Backslash-escaped quotes, as in MySQL.
+		{`SELECT * FROM t WHERE a = 'a\'b c' AND b = 2`, "SELECT * FROM t WHERE a = ? AND b = ?"},
+		{`SELECT * FROM t WHERE a = 'c:\\' AND b = 'd'`, "SELECT * FROM t WHERE a = ? AND b = ?"},
+		{`SELECT "a\"b c" FROM t WHERE x = 'y\`, `SELECT "a\"b c" FROM t WHERE x = ?`},
+		// Quotes and numbers in comments.
+		{"SELECT * FROM t -- don't use 1\nWHERE a = 'x'", "SELECT * FROM t -- don't use 1\nWHERE a = ?"},
+		{"SELECT /* it's 2 */ * FROM t WHERE a = 'x'", "SELECT /* it's 2 */ * FROM t WHERE a = ?"},
+		{"SELECT 1 /* unterminated 'comment", "SELECT ? /* unterminated 'comment"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Literals(tt.query), tt.query)
+	}
+}
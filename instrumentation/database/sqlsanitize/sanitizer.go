@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlsanitize
+
+// DefaultMaxLength is the default maximum length in bytes of the statements
+// sanitized by a Sanitizer.
+const DefaultMaxLength = 2048
+
+// Sanitizer sanitizes SQL statements. By default the literals of a
+// statement are replaced and it is truncated to DefaultMaxLength bytes.
+type Sanitizer struct {
+	cfg config
+}
+
+// New returns a Sanitizer configured with opts.
+func New(opts ...Option) *Sanitizer {
+	return &Sanitizer{cfg: newConfig(opts...)}
+}
+
+// Sanitize returns the sanitized query. Its signature matches the
+// statement sanitizers of the database instrumentation packages, e.g.
+// otelsql.WithStatementSanitizer(sqlsanitize.New().Sanitize).
+func (s *Sanitizer) Sanitize(query string) string {
+	if s.cfg.literals {
+		query = Literals(query)
+	}
+	return Truncate(query, s.cfg.maxLength)
+}
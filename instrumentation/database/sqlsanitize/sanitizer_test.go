@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlsanitize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizer(t *testing.T) {
+	long := "SELECT * FROM t WHERE a IN (" + strings.Repeat("1, ", 1000) + "1)"
+
+	assert.Equal(t, "SELECT * FROM t WHERE id = ?", New().Sanitize("SELECT * FROM t WHERE id = 42"))
+	assert.Len(t, New().Sanitize(long), DefaultMaxLength)
+	assert.Equal(t, "SELECT * FROM t WHERE id = 42", New(WithLiterals(false)).Sanitize("SELECT * FROM t WHERE id = 42"))
+	assert.Equal(t, "SELECT * FROM t", New(WithMaxLength(15)).Sanitize("SELECT * FROM t WHERE id = 42"))
+	assert.Equal(t, Literals(long), New(WithMaxLength(0)).Sanitize(long))
+}
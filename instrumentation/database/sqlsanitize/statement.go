@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlsanitize
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Truncate returns query truncated to at most max bytes, without splitting
+// a UTF-8 encoded character. If max is not positive, query is returned as
+// is.
+func Truncate(query string, max int) string {
+	if max <= 0 || len(query) <= max {
+		return query
+	}
+	i := max
+	for i > 0 && !utf8.RuneStart(query[i]) {
+		i--
+	}
+	return query[:i]
+}
+
+// Operation returns the operation of query, its first keyword in upper
+// case, e.g. SELECT or INSERT. Leading white space, comments, and
+// parentheses are skipped. An empty string is returned if query does not
+// start with a keyword.
+func Operation(query string) string {
+	i := skipPrefix(query)
+	j := i
+	for j < len(query) && isLetter(query[j]) {
+		j++
+	}
+	return strings.ToUpper(query[i:j])
+}
+
+// skipPrefix returns the index of the first byte of query that is not
+// white space, a comment, or an opening parenthesis.
+func skipPrefix(query string) int {
+	i := 0
+	for i < len(query) {
+		switch {
+		case query[i] == ' ' || query[i] == '\t' || query[i] == '\n' || query[i] == '\r' || query[i] == '(':
+			i++
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return len(query)
+			}
+			i += end + 1
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return len(query)
+			}
+			i += end + 4
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlsanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		query string
+		max   int
+		want  string
+	}{
+		{"SELECT 1", 0, "SELECT 1"},
+		{"SELECT 1", 8, "SELECT 1"},
+		{"SELECT 1", 6, "SELECT"},
+		{"SELECT 'é'", 9, "SELECT '"},
+		{"SELECT 'é'", 10, "SELECT 'é"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Truncate(tt.query, tt.max), tt.query)
+	}
+}
+
+func TestOperation(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT * FROM users", "SELECT"},
+		{"  insert INTO t VALUES (1)", "INSERT"},
+		{"-- comment\nUPDATE t SET a = 1", "UPDATE"},
+		{"/* comment */ (SELECT 1) UNION (SELECT 2)", "SELECT"},
+		{"/* unterminated", ""},
+		{"", ""},
+		{"123", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Operation(tt.query), tt.query)
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package expvar exports the variables published with the standard library
+// expvar package as OpenTelemetry metrics.
+//
+// Start registers an asynchronous gauge named expvar.<name> for every
+// published variable. The variables are read every time the metrics are
+// collected. Variables of type *expvar.Int are reported as integers; the
+// numeric values found in the JSON representation of all other variables
+// are reported as floats. Numbers nested in JSON objects, such as the
+// entries of an *expvar.Map, are reported with the expvar.key attribute set
+// to their dot-separated path in the object. Strings, booleans and arrays
+// are not reported.
+//
+// Only the variables published when Start is called are exported. The
+// cmdline and memstats variables published by the expvar package are not
+// exported unless selected with WithVariables: the runtime instrumentation
+// reports the memory statistics without the cost of reading them all.
+package expvar // import "go.opentelemetry.io/contrib/instrumentation/expvar"
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvar
+
+import (
+	"context"
+	"encoding/json"
+	goexpvar "expvar"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+)
+
+// KeyKey is the attribute key of the path of a number nested in a JSON
+// object, such as the key of an *expvar.Map entry.
+const KeyKey = attribute.Key("expvar.key")
+
+// excluded are the variables published by the expvar package itself, which
+// are not exported unless selected with WithVariables.
+var excluded = map[string]bool{
+	"cmdline":  true,
+	"memstats": true,
+}
+
+// bridge exports expvar variables as OpenTelemetry metrics.
+type bridge struct {
+	config config
+	meter  metric.Meter
+}
+
+// config contains optional settings for exporting expvar variables.
+type config struct {
+	// MeterProvider sets the metric.MeterProvider.  If nil, the global
+	// Provider will be used.
+	MeterProvider metric.MeterProvider
+
+	// Variables are the names of the variables to export.  If empty,
+	// all published variables except cmdline and memstats are exported.
+	Variables []string
+}
+
+// Option supports configuring optional settings for exporting expvar
+// variables.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithMeterProvider sets the Metric implementation to use for
+// reporting.  If this option is not used, the global metric.MeterProvider
+// will be used.  `provider` must be non-nil.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(c *config) {
+		if provider != nil {
+			c.MeterProvider = provider
+		}
+	})
+}
+
+// WithVariables sets the names of the variables to export.  Start returns
+// an error if any of them is not published.
+func WithVariables(names ...string) Option {
+	return optionFunc(func(c *config) {
+		c.Variables = append(c.Variables, names...)
+	})
+}
+
+// newConfig computes a config from the supplied Options.
+func newConfig(opts ...Option) config {
+	c := config{
+		MeterProvider: global.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt.apply(&c)
+	}
+	return c
+}
+
+// Start initializes exporting the published expvar variables using the
+// supplied config.
+func Start(opts ...Option) error {
+	c := newConfig(opts...)
+	b := &bridge{
+		meter: c.MeterProvider.Meter(
+			"go.opentelemetry.io/contrib/instrumentation/expvar",
+			metric.WithInstrumentationVersion(SemVersion()),
+		),
+		config: c,
+	}
+	return b.register()
+}
+
+// variable is an exported expvar variable and the instrument reporting it.
+type variable struct {
+	name  string
+	value goexpvar.Var
+
+	// intGauge reports *expvar.Int variables, floatGauge all others.
+	intGauge   metric.Int64GaugeObserver
+	floatGauge metric.Float64GaugeObserver
+}
+
+// variables returns the variables selected by the config.
+func (b *bridge) variables() ([]*variable, error) {
+	var vars []*variable
+	if len(b.config.Variables) == 0 {
+		goexpvar.Do(func(kv goexpvar.KeyValue) {
+			if !excluded[kv.Key] {
+				vars = append(vars, &variable{name: kv.Key, value: kv.Value})
+			}
+		})
+		return vars, nil
+	}
+
+	for _, name := range b.config.Variables {
+		v := goexpvar.Get(name)
+		if v == nil {
+			return nil, fmt.Errorf("expvar variable %q is not published", name)
+		}
+		vars = append(vars, &variable{name: name, value: v})
+	}
+	return vars, nil
+}
+
+func (b *bridge) register() error {
+	vars, err := b.variables()
+	if err != nil {
+		return err
+	}
+
+	// lock prevents a race between batch observer and instrument registration.
+	var lock sync.Mutex
+	lock.Lock()
+	defer lock.Unlock()
+
+	batchObserver := b.meter.NewBatchObserver(func(ctx context.Context, result metric.BatchObserverResult) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		for _, v := range vars {
+			if i, ok := v.value.(*goexpvar.Int); ok {
+				result.Observe(nil, v.intGauge.Observation(i.Value()))
+				continue
+			}
+
+			var value interface{}
+			if err := json.Unmarshal([]byte(v.value.String()), &value); err != nil {
+				otel.Handle(fmt.Errorf("expvar variable %q: %w", v.name, err))
+				continue
+			}
+			walk("", value, func(key string, number float64) {
+				var attrs []attribute.KeyValue
+				if key != "" {
+					attrs = []attribute.KeyValue{KeyKey.String(key)}
+				}
+				result.Observe(attrs, v.floatGauge.Observation(number))
+			})
+		}
+	})
+
+	for _, v := range vars {
+		name := "expvar." + v.name
+		description := metric.WithDescription(fmt.Sprintf("Value of the %s expvar variable", v.name))
+		if _, ok := v.value.(*goexpvar.Int); ok {
+			if v.intGauge, err = batchObserver.NewInt64GaugeObserver(name, description); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if v.floatGauge, err = batchObserver.NewFloat64GaugeObserver(name, description); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walk calls fn for every number in value, the result of unmarshaling JSON
+// into an empty interface, with the dot-separated path of the number in the
+// nested objects of value.
+func walk(key string, value interface{}, fn func(key string, number float64)) {
+	switch value := value.(type) {
+	case float64:
+		fn(key, value)
+	case map[string]interface{}:
+		for k, v := range value {
+			if key != "" {
+				k = key + "." + k
+			}
+			walk(k, v, fn)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvar_test
+
+import (
+	goexpvar "expvar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/expvar"
+	"go.opentelemetry.io/otel/metric/metrictest"
+)
+
+var (
+	requests = goexpvar.NewInt("test.requests")
+	ratio    = goexpvar.NewFloat("test.ratio")
+	byCode   = goexpvar.NewMap("test.by_code")
+	info     = goexpvar.NewString("test.info")
+)
+
+func init() {
+	requests.Set(42)
+	ratio.Set(0.5)
+	byCode.Add("200", 3)
+	byCode.AddFloat("latency", 1.5)
+	inner := new(goexpvar.Map).Init()
+	inner.Add("hits", 7)
+	byCode.Set("cache", inner)
+	info.Set("not a number")
+	goexpvar.Publish("test.func", goexpvar.Func(func() interface{} {
+		return map[string]interface{}{"count": 9, "name": "ignored", "list": []int{1, 2}}
+	}))
+}
+
+// measurements returns the values of the measurements of the named float
+// metric keyed by their expvar.key attribute.
+func measurements(mp *metrictest.MeterProvider, name string) map[string]float64 {
+	out := map[string]float64{}
+	for _, m := range metrictest.AsStructs(mp.MeasurementBatches) {
+		if m.Name == name {
+			out[m.Labels[expvar.KeyKey].AsString()] = m.Number.AsFloat64()
+		}
+	}
+	return out
+}
+
+// intMeasurements returns the values of the measurements of the named
+// integer metric.
+func intMeasurements(mp *metrictest.MeterProvider, name string) []int64 {
+	var out []int64
+	for _, m := range metrictest.AsStructs(mp.MeasurementBatches) {
+		if m.Name == name {
+			out = append(out, m.Number.AsInt64())
+		}
+	}
+	return out
+}
+
+func TestStart(t *testing.T) {
+	mp := metrictest.NewMeterProvider()
+	require.NoError(t, expvar.Start(expvar.WithMeterProvider(mp)))
+	mp.RunAsyncInstruments()
+
+	assert.Equal(t, []int64{42}, intMeasurements(mp, "expvar.test.requests"))
+	assert.Equal(t, map[string]float64{"": 0.5}, measurements(mp, "expvar.test.ratio"))
+	assert.Equal(t, map[string]float64{"200": 3, "latency": 1.5, "cache.hits": 7}, measurements(mp, "expvar.test.by_code"))
+	assert.Equal(t, map[string]float64{"count": 9}, measurements(mp, "expvar.test.func"))
+	assert.Empty(t, measurements(mp, "expvar.test.info"))
+	assert.Empty(t, measurements(mp, "expvar.memstats"))
+	assert.Empty(t, measurements(mp, "expvar.cmdline"))
+
+	// Variables are read on every collection.
+	requests.Add(1)
+	mp.MeasurementBatches = nil
+	mp.RunAsyncInstruments()
+	assert.Equal(t, []int64{43}, intMeasurements(mp, "expvar.test.requests"))
+	requests.Add(-1)
+}
+
+func TestWithVariables(t *testing.T) {
+	mp := metrictest.NewMeterProvider()
+	require.NoError(t, expvar.Start(expvar.WithMeterProvider(mp), expvar.WithVariables("test.ratio", "memstats")))
+	mp.RunAsyncInstruments()
+
+	assert.Equal(t, map[string]float64{"": 0.5}, measurements(mp, "expvar.test.ratio"))
+	assert.Greater(t, measurements(mp, "expvar.memstats")["HeapAlloc"], 0.0)
+	assert.Empty(t, intMeasurements(mp, "expvar.test.requests"))
+}
+
+func TestWithVariablesNotPublished(t *testing.T) {
+	err := expvar.Start(expvar.WithMeterProvider(metrictest.NewMeterProvider()), expvar.WithVariables("test.missing"))
+	assert.Error(t, err)
+}
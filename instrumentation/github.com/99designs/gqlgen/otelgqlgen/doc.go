@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelgqlgen instruments the github.com/99designs/gqlgen package.
+//
+// The instrumentation is provided by a gqlgen handler extension that is
+// added to the GraphQL server:
+//
+//	srv := handler.NewDefaultServer(generated.NewExecutableSchema(cfg))
+//	srv.Use(otelgqlgen.Middleware())
+//
+// A span is created for every GraphQL operation, with the complexity of the
+// operation when the extension.ComplexityLimit extension is used, and for
+// every field resolved by a resolver. The errors of the response and of the
+// resolvers are recorded on these spans. Dataloader batches can be traced
+// with Tracer.StartDataloaderSpan.
+package otelgqlgen // import "go.opentelemetry.io/contrib/instrumentation/github.com/99designs/gqlgen/otelgqlgen"
@@ -0,0 +1,17 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/99designs/gqlgen/otelgqlgen/test
+
+go 1.15
+
+require (
+	github.com/99designs/gqlgen v0.14.0
+	github.com/stretchr/testify v1.7.0
+	github.com/vektah/gqlparser/v2 v2.2.0
+	go.opentelemetry.io/contrib/instrumentation/github.com/99designs/gqlgen/otelgqlgen v0.26.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
+
+replace go.opentelemetry.io/contrib/instrumentation/github.com/99designs/gqlgen/otelgqlgen => ../
+
+replace go.opentelemetry.io/contrib => ../../../../../../
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/99designs/gqlgen/otelgqlgen"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newServer returns a GraphQL server resolving the name field of the query
+// with resolve, simulating the code generated by gqlgen.
+func newServer(isResolver bool, resolve graphql.Resolver, opts ...otelgqlgen.Option) *handler.Server {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Input: `
+		type Query {
+			name: String!
+		}
+	`})
+
+	srv := handler.New(&graphql.ExecutableSchemaMock{
+		ExecFunc: func(context.Context) graphql.ResponseHandler {
+			ran := false
+			return func(ctx context.Context) *graphql.Response {
+				if ran {
+					return nil
+				}
+				ran = true
+
+				ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+					Object: "Query",
+					Field: graphql.CollectedField{
+						Field: &ast.Field{
+							Name:       "name",
+							Alias:      "name",
+							Definition: schema.Types["Query"].Fields.ForName("name"),
+						},
+					},
+					IsResolver: isResolver,
+				})
+				oc := graphql.GetOperationContext(ctx)
+				res, err := oc.ResolverMiddleware(ctx, resolve)
+				if err != nil {
+					graphql.AddError(ctx, err)
+					return &graphql.Response{Data: []byte(`null`)}
+				}
+				return &graphql.Response{Data: []byte(`{"name":"` + res.(string) + `"}`)}
+			}
+		},
+		SchemaFunc: func() *ast.Schema {
+			return schema
+		},
+		ComplexityFunc: func(string, string, int, map[string]interface{}) (int, bool) {
+			return 1, true
+		},
+	})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.FixedComplexityLimit(10))
+	srv.Use(otelgqlgen.Middleware(opts...))
+	return srv
+}
+
+func post(t *testing.T, srv http.Handler, query string) {
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(query))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func resolveName(context.Context) (interface{}, error) {
+	return "test", nil
+}
+
+func attrMap(span sdktrace.ReadOnlySpan) map[string]interface{} {
+	m := map[string]interface{}{}
+	for _, kv := range span.Attributes() {
+		m[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	return m
+}
+
+func TestOperationAndResolverSpans(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	srv := newServer(true, resolveName, otelgqlgen.WithTracerProvider(provider))
+
+	post(t, srv, `{"query":"query GetName { name }"}`)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	field, operation := spans[0], spans[1]
+
+	assert.Equal(t, "query GetName", operation.Name())
+	assert.Equal(t, trace.SpanKindServer, operation.SpanKind())
+	assert.Equal(t, codes.Unset, operation.Status().Code)
+	assert.Equal(t, map[string]interface{}{
+		"graphql.document":                   "query GetName { name }",
+		"graphql.operation.type":             "query",
+		"graphql.operation.name":             "GetName",
+		"graphql.operation.complexity":       int64(1),
+		"graphql.operation.complexity_limit": int64(10),
+	}, attrMap(operation))
+
+	assert.Equal(t, "Query.name", field.Name())
+	assert.Equal(t, trace.SpanKindInternal, field.SpanKind())
+	assert.Equal(t, operation.SpanContext().SpanID(), field.Parent().SpanID())
+	assert.Equal(t, map[string]interface{}{
+		"graphql.field.name": "name",
+		"graphql.field.path": "name",
+		"graphql.field.type": "String!",
+	}, attrMap(field))
+}
+
+func TestResolverError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	srv := newServer(true, func(context.Context) (interface{}, error) {
+		return nil, errors.New("resolver error")
+	}, otelgqlgen.WithTracerProvider(provider))
+
+	post(t, srv, `{"query":"{ name }"}`)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	field, operation := spans[0], spans[1]
+
+	assert.Equal(t, "query GraphQL Operation", operation.Name())
+	for _, span := range spans {
+		assert.Equal(t, codes.Error, span.Status().Code)
+		assert.Equal(t, "resolver error", span.Status().Description)
+		require.Len(t, span.Events(), 1)
+		assert.Equal(t, "exception", span.Events()[0].Name)
+	}
+	assert.Equal(t, operation.SpanContext().SpanID(), field.Parent().SpanID())
+}
+
+func TestTrivialFieldSpans(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	post(t, newServer(false, resolveName, otelgqlgen.WithTracerProvider(provider)), `{"query":"{ name }"}`)
+	require.Len(t, sr.Ended(), 1, "no span for a trivial field by default")
+
+	post(t, newServer(false, resolveName,
+		otelgqlgen.WithTracerProvider(provider),
+		otelgqlgen.WithTrivialFieldSpans(),
+	), `{"query":"{ name }"}`)
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "Query.name", spans[1].Name())
+}
+
+func TestDataloaderSpan(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	tracer := otelgqlgen.Middleware(otelgqlgen.WithTracerProvider(provider))
+
+	srv := newServer(true, func(ctx context.Context) (interface{}, error) {
+		_, span := tracer.StartDataloaderSpan(ctx, "users", 3)
+		span.End()
+		return "test", nil
+	}, otelgqlgen.WithTracerProvider(provider))
+
+	post(t, srv, `{"query":"{ name }"}`)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	loader, field := spans[0], spans[1]
+	assert.Equal(t, "dataloader.users", loader.Name())
+	assert.Equal(t, field.SpanContext().SpanID(), loader.Parent().SpanID())
+	assert.Equal(t, map[string]interface{}{
+		"graphql.dataloader.name": "users",
+		"graphql.dataloader.keys": int64(3),
+	}, attrMap(loader))
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgqlgen
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName    = "go.opentelemetry.io/contrib/instrumentation/github.com/99designs/gqlgen/otelgqlgen"
+	extensionName = "OpenTelemetry"
+
+	// defaultOperationName is the span name of the operations without a
+	// name.
+	defaultOperationName = "GraphQL Operation"
+)
+
+// Attribute keys of the GraphQL spans.
+const (
+	OperationNameKey            = attribute.Key("graphql.operation.name")
+	OperationTypeKey            = attribute.Key("graphql.operation.type")
+	OperationComplexityKey      = attribute.Key("graphql.operation.complexity")
+	OperationComplexityLimitKey = attribute.Key("graphql.operation.complexity_limit")
+	DocumentKey                 = attribute.Key("graphql.document")
+	FieldNameKey                = attribute.Key("graphql.field.name")
+	FieldAliasKey               = attribute.Key("graphql.field.alias")
+	FieldPathKey                = attribute.Key("graphql.field.path")
+	FieldTypeKey                = attribute.Key("graphql.field.type")
+	DataloaderNameKey           = attribute.Key("graphql.dataloader.name")
+	DataloaderKeysKey           = attribute.Key("graphql.dataloader.keys")
+)
+
+// Tracer is a gqlgen handler extension tracing GraphQL operations and field
+// resolvers.
+type Tracer struct {
+	tracer            trace.Tracer
+	trivialFieldSpans bool
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = Tracer{}
+
+// Middleware returns a gqlgen handler extension tracing GraphQL operations
+// and field resolvers.
+func Middleware(opts ...Option) Tracer {
+	cfg := newConfig(opts...)
+	return Tracer{
+		tracer: cfg.TracerProvider.Tracer(
+			tracerName,
+			trace.WithInstrumentationVersion(SemVersion()),
+		),
+		trivialFieldSpans: cfg.TrivialFieldSpans,
+	}
+}
+
+// ExtensionName returns the name of the extension.
+func (Tracer) ExtensionName() string {
+	return extensionName
+}
+
+// Validate validates the extension against the schema.
+func (Tracer) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptResponse starts a span for the GraphQL operation producing the
+// response.
+func (t Tracer) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+
+	oc := graphql.GetOperationContext(ctx)
+	opts := []trace.SpanStartOption{
+		trace.WithAttributes(operationAttributes(ctx, oc)...),
+		trace.WithSpanKind(trace.SpanKindServer),
+	}
+	if !oc.Stats.OperationStart.IsZero() {
+		opts = append(opts, trace.WithTimestamp(oc.Stats.OperationStart))
+	}
+	ctx, span := t.tracer.Start(ctx, operationName(oc), opts...)
+	defer span.End()
+
+	resp := next(ctx)
+	if resp != nil {
+		recordErrors(span, resp.Errors)
+	}
+	return resp
+}
+
+// InterceptField starts a span for the field resolved by a resolver or, when
+// WithTrivialFieldSpans is used, for any field.
+func (t Tracer) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || (!fc.IsResolver && !t.trivialFieldSpans) {
+		return next(ctx)
+	}
+
+	attrs := []attribute.KeyValue{
+		FieldNameKey.String(fc.Field.Name),
+		FieldPathKey.String(fc.Path().String()),
+	}
+	if fc.Field.Alias != "" && fc.Field.Alias != fc.Field.Name {
+		attrs = append(attrs, FieldAliasKey.String(fc.Field.Alias))
+	}
+	if def := fc.Field.Definition; def != nil && def.Type != nil {
+		attrs = append(attrs, FieldTypeKey.String(def.Type.String()))
+	}
+	ctx, span := t.tracer.Start(ctx, fc.Object+"."+fc.Field.Name, trace.WithAttributes(attrs...))
+	defer span.End()
+
+	res, err := next(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return res, err
+}
+
+// StartDataloaderSpan starts a span for a batch of keys loaded by the
+// dataloader named loader. The returned span must be ended by the caller once
+// the batch is loaded.
+func (t Tracer) StartDataloaderSpan(ctx context.Context, loader string, keys int) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, "dataloader."+loader, trace.WithAttributes(
+		DataloaderNameKey.String(loader),
+		DataloaderKeysKey.Int(keys),
+	))
+}
+
+// operationName returns the span name of the operation, e.g. "query GetUser".
+func operationName(oc *graphql.OperationContext) string {
+	name := oc.OperationName
+	if name == "" && oc.Operation != nil {
+		name = oc.Operation.Name
+	}
+	if name == "" {
+		name = defaultOperationName
+	}
+	if oc.Operation != nil {
+		return string(oc.Operation.Operation) + " " + name
+	}
+	return name
+}
+
+func operationAttributes(ctx context.Context, oc *graphql.OperationContext) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{DocumentKey.String(oc.RawQuery)}
+	if oc.Operation != nil {
+		attrs = append(attrs, OperationTypeKey.String(string(oc.Operation.Operation)))
+		if oc.Operation.Name != "" {
+			attrs = append(attrs, OperationNameKey.String(oc.Operation.Name))
+		}
+	}
+	if stats := extension.GetComplexityStats(ctx); stats != nil {
+		attrs = append(attrs,
+			OperationComplexityKey.Int(stats.Complexity),
+			OperationComplexityLimitKey.Int(stats.ComplexityLimit),
+		)
+	}
+	return attrs
+}
+
+// recordErrors records errs on span and sets its status to error if there
+// are any.
+func recordErrors(span trace.Span, errs gqlerror.List) {
+	if len(errs) == 0 {
+		return
+	}
+
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		span.RecordError(err)
+		msgs = append(msgs, err.Message)
+	}
+	span.SetStatus(codes.Error, strings.Join(msgs, "\n"))
+}
@@ -28,23 +28,31 @@ import (
 
 type consumerMessagesDispatcher interface {
 	Messages() <-chan *sarama.ConsumerMessage
+	HighWaterMarkOffset() int64
 }
 
 type consumerMessagesDispatcherWrapper struct {
 	d        consumerMessagesDispatcher
 	messages chan *sarama.ConsumerMessage
 
-	cfg config
+	cfg         config
+	instruments *instruments
 }
 
 func newConsumerMessagesDispatcherWrapper(d consumerMessagesDispatcher, cfg config) *consumerMessagesDispatcherWrapper {
 	return &consumerMessagesDispatcherWrapper{
-		d:        d,
-		messages: make(chan *sarama.ConsumerMessage),
-		cfg:      cfg,
+		d:           d,
+		messages:    make(chan *sarama.ConsumerMessage),
+		cfg:         cfg,
+		instruments: newInstruments(cfg.Meter),
 	}
 }
 
+// HighWaterMarkOffset returns the high water mark offset of the partition.
+func (w *consumerMessagesDispatcherWrapper) HighWaterMarkOffset() int64 {
+	return w.d.HighWaterMarkOffset()
+}
+
 // Messages returns the read channel for the messages that are returned by
 // the broker.
 func (w *consumerMessagesDispatcherWrapper) Messages() <-chan *sarama.ConsumerMessage {
@@ -73,6 +81,7 @@ func (w *consumerMessagesDispatcherWrapper) Run() {
 			trace.WithSpanKind(trace.SpanKindConsumer),
 		}
 		newCtx, span := w.cfg.Tracer.Start(parentSpanContext, "kafka.consume", opts...)
+		w.instruments.consumed(msg.Topic, msg.Partition, msg.Offset, w.d.HighWaterMarkOffset())
 
 		// Inject current span context, so consumers can use it to propagate span.
 		w.cfg.Propagators.Inject(newCtx, carrier)
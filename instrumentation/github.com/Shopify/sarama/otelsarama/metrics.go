@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsarama
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama/internal"
+)
+
+// The names of the metric instruments. The measurements are labeled with
+// the topic and partition of the messages.
+const (
+	ProducedMessages = "messaging.kafka.producer.messages" // Incremented by messages acknowledged by the broker
+	ProducerErrors   = "messaging.kafka.producer.errors"   // Incremented by messages that failed to be produced
+	ConsumedMessages = "messaging.kafka.consumer.messages" // Incremented by consumed messages
+	ConsumerLag      = "messaging.kafka.consumer.lag"      // Number of messages of the partition following a consumed message
+)
+
+// instruments are the metric instruments of producers and consumers.
+type instruments struct {
+	producedMessages metric.Int64Counter
+	producerErrors   metric.Int64Counter
+	consumedMessages metric.Int64Counter
+	consumerLag      metric.Int64Histogram
+}
+
+func newInstruments(meter metric.Meter) *instruments {
+	var (
+		i   instruments
+		err error
+	)
+	i.producedMessages, err = meter.NewInt64Counter(ProducedMessages,
+		metric.WithDescription("Number of messages acknowledged by the broker"))
+	handleErr(err)
+	i.producerErrors, err = meter.NewInt64Counter(ProducerErrors,
+		metric.WithDescription("Number of messages that failed to be produced"))
+	handleErr(err)
+	i.consumedMessages, err = meter.NewInt64Counter(ConsumedMessages,
+		metric.WithDescription("Number of consumed messages"))
+	handleErr(err)
+	i.consumerLag, err = meter.NewInt64Histogram(ConsumerLag,
+		metric.WithDescription("Number of messages of the partition following a consumed message"))
+	handleErr(err)
+	return &i
+}
+
+func handleErr(err error) {
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+func partitionLabels(topic string, partition int32) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.MessagingDestinationKey.String(topic),
+		internal.KafkaPartitionKey.Int64(int64(partition)),
+	}
+}
+
+// produced records a message sent to partition of topic.
+func (i *instruments) produced(topic string, partition int32, err error) {
+	labels := partitionLabels(topic, partition)
+	if err != nil {
+		i.producerErrors.Add(context.Background(), 1, labels...)
+		return
+	}
+	i.producedMessages.Add(context.Background(), 1, labels...)
+}
+
+// consumed records a message consumed at offset from partition of topic,
+// whose high water mark offset is highWaterMark.
+func (i *instruments) consumed(topic string, partition int32, offset, highWaterMark int64) {
+	labels := partitionLabels(topic, partition)
+	i.consumedMessages.Add(context.Background(), 1, labels...)
+	// The high water mark is the offset of the next message produced.
+	if lag := highWaterMark - offset - 1; lag >= 0 {
+		i.consumerLag.Record(context.Background(), lag, labels...)
+	}
+}
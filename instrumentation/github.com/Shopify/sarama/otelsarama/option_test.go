@@ -20,6 +20,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -38,6 +40,21 @@ func TestNewConfig(t *testing.T) {
 				TracerProvider: otel.GetTracerProvider(),
 				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(SemVersion())),
 				Propagators:    otel.GetTextMapPropagator(),
+				MeterProvider:  global.GetMeterProvider(),
+				Meter:          global.GetMeterProvider().Meter(defaultTracerName, metric.WithInstrumentationVersion(SemVersion())),
+			},
+		},
+		{
+			name: "with meter provider",
+			opts: []Option{
+				WithMeterProvider(global.GetMeterProvider()),
+			},
+			expected: config{
+				TracerProvider: otel.GetTracerProvider(),
+				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(SemVersion())),
+				Propagators:    otel.GetTextMapPropagator(),
+				MeterProvider:  global.GetMeterProvider(),
+				Meter:          global.GetMeterProvider().Meter(defaultTracerName, metric.WithInstrumentationVersion(SemVersion())),
 			},
 		},
 		{
@@ -49,6 +66,8 @@ func TestNewConfig(t *testing.T) {
 				TracerProvider: otel.GetTracerProvider(),
 				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(SemVersion())),
 				Propagators:    otel.GetTextMapPropagator(),
+				MeterProvider:  global.GetMeterProvider(),
+				Meter:          global.GetMeterProvider().Meter(defaultTracerName, metric.WithInstrumentationVersion(SemVersion())),
 			},
 		},
 	}
@@ -33,13 +33,14 @@ type syncProducer struct {
 	sarama.SyncProducer
 	cfg          config
 	saramaConfig *sarama.Config
+	instruments  *instruments
 }
 
 // SendMessage calls sarama.SyncProducer.SendMessage and traces the request.
 func (p *syncProducer) SendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error) {
 	span := startProducerSpan(p.cfg, p.saramaConfig.Version, msg)
 	partition, offset, err = p.SyncProducer.SendMessage(msg)
-	finishProducerSpan(span, partition, offset, err)
+	finishProducerSpan(p.instruments, span, msg.Topic, partition, offset, err)
 	return partition, offset, err
 }
 
@@ -53,7 +54,7 @@ func (p *syncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
 	}
 	err := p.SyncProducer.SendMessages(msgs)
 	for i, span := range spans {
-		finishProducerSpan(span, msgs[i].Partition, msgs[i].Offset, err)
+		finishProducerSpan(p.instruments, span, msgs[i].Topic, msgs[i].Partition, msgs[i].Offset, err)
 	}
 	return err
 }
@@ -70,6 +71,7 @@ func WrapSyncProducer(saramaConfig *sarama.Config, producer sarama.SyncProducer,
 		SyncProducer: producer,
 		cfg:          cfg,
 		saramaConfig: saramaConfig,
+		instruments:  newInstruments(cfg.Meter),
 	}
 }
 
@@ -142,6 +144,8 @@ func WrapAsyncProducer(saramaConfig *sarama.Config, p sarama.AsyncProducer, opts
 		closeAsyncSig: make(chan struct{}),
 	}
 
+	inst := newInstruments(cfg.Meter)
+
 	var (
 		mtx                     sync.Mutex
 		producerMessageContexts = make(map[interface{}]producerMessageContext)
@@ -203,7 +207,7 @@ func WrapAsyncProducer(saramaConfig *sarama.Config, p sarama.AsyncProducer, opts
 			mtx.Lock()
 			if mc, ok := producerMessageContexts[key]; ok {
 				delete(producerMessageContexts, key)
-				finishProducerSpan(mc.span, msg.Partition, msg.Offset, nil)
+				finishProducerSpan(inst, mc.span, msg.Topic, msg.Partition, msg.Offset, nil)
 				msg.Metadata = mc.metadataBackup // Restore message metadata
 			}
 			mtx.Unlock()
@@ -223,7 +227,7 @@ func WrapAsyncProducer(saramaConfig *sarama.Config, p sarama.AsyncProducer, opts
 			mtx.Lock()
 			if mc, ok := producerMessageContexts[key]; ok {
 				delete(producerMessageContexts, key)
-				finishProducerSpan(mc.span, errMsg.Msg.Partition, errMsg.Msg.Offset, errMsg.Err)
+				finishProducerSpan(inst, mc.span, errMsg.Msg.Topic, errMsg.Msg.Partition, errMsg.Msg.Offset, errMsg.Err)
 				errMsg.Msg.Metadata = mc.metadataBackup // Restore message metadata
 			}
 			mtx.Unlock()
@@ -271,7 +275,8 @@ func startProducerSpan(cfg config, version sarama.KafkaVersion, msg *sarama.Prod
 	return span
 }
 
-func finishProducerSpan(span trace.Span, partition int32, offset int64, err error) {
+func finishProducerSpan(inst *instruments, span trace.Span, topic string, partition int32, offset int64, err error) {
+	inst.produced(topic, partition, err)
 	span.SetAttributes(
 		semconv.MessagingMessageIDKey.String(strconv.FormatInt(offset, 10)),
 		internal.KafkaPartitionKey.Int64(int64(partition)),
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama/internal"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/metrictest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+var partitionLabels = map[attribute.Key]attribute.Value{
+	semconv.MessagingDestinationKey: attribute.StringValue(topic),
+	internal.KafkaPartitionKey:      attribute.Int64Value(0),
+}
+
+func TestSyncProducerMetrics(t *testing.T) {
+	mp := metrictest.NewMeterProvider()
+
+	cfg := newSaramaConfig()
+	mockSyncProducer := mocks.NewSyncProducer(t, cfg)
+	mockSyncProducer.ExpectSendMessageAndSucceed()
+	mockSyncProducer.ExpectSendMessageAndSucceed()
+	mockSyncProducer.ExpectSendMessageAndFail(errors.New("test"))
+
+	syncProducer := otelsarama.WrapSyncProducer(cfg, mockSyncProducer, otelsarama.WithMeterProvider(mp))
+	for i := 0; i < 3; i++ {
+		_, _, _ = syncProducer.SendMessage(&sarama.ProducerMessage{Topic: topic})
+	}
+	require.NoError(t, syncProducer.Close())
+
+	var produced, failed int64
+	for _, m := range metrictest.AsStructs(mp.MeasurementBatches) {
+		assert.Equal(t, topic, m.Labels[semconv.MessagingDestinationKey].AsString())
+		switch m.Name {
+		case otelsarama.ProducedMessages:
+			produced += m.Number.AsInt64()
+		case otelsarama.ProducerErrors:
+			failed += m.Number.AsInt64()
+		default:
+			t.Errorf("unexpected metric %s", m.Name)
+		}
+	}
+	assert.Equal(t, int64(2), produced)
+	assert.Equal(t, int64(1), failed)
+}
+
+func TestPartitionConsumerMetrics(t *testing.T) {
+	mp := metrictest.NewMeterProvider()
+
+	consumer := mocks.NewConsumer(t, sarama.NewConfig())
+	mockPartitionConsumer := consumer.ExpectConsumePartition(topic, 0, 0)
+	partitionConsumer, err := consumer.ConsumePartition(topic, 0, 0)
+	require.NoError(t, err)
+
+	// Yield the messages before wrapping for the high water mark to be the
+	// same when each of them is consumed.
+	for i := 0; i < 3; i++ {
+		mockPartitionConsumer.YieldMessage(&sarama.ConsumerMessage{})
+	}
+	partitionConsumer = otelsarama.WrapPartitionConsumer(partitionConsumer, otelsarama.WithMeterProvider(mp))
+	for i := 0; i < 3; i++ {
+		<-partitionConsumer.Messages()
+	}
+	require.NoError(t, partitionConsumer.Close())
+	// Wait for the channel to be closed
+	<-partitionConsumer.Messages()
+
+	var consumed []int64
+	var lags []int64
+	for _, m := range metrictest.AsStructs(mp.MeasurementBatches) {
+		assert.Equal(t, partitionLabels, m.Labels)
+		switch m.Name {
+		case otelsarama.ConsumedMessages:
+			consumed = append(consumed, m.Number.AsInt64())
+		case otelsarama.ConsumerLag:
+			lags = append(lags, m.Number.AsInt64())
+		default:
+			t.Errorf("unexpected metric %s", m.Name)
+		}
+	}
+	assert.Equal(t, []int64{1, 1, 1}, consumed)
+	assert.Equal(t, []int64{2, 1, 0}, lags)
+}
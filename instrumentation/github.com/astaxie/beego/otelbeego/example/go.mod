@@ -6,7 +6,9 @@ replace (
 	go.opentelemetry.io/contrib => ../../../../../../
 	go.opentelemetry.io/contrib/instrumentation/github.com/astaxie/beego/otelbeego => ../
 	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp => ../../../../../net/http/otelhttp
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../../../requestfilter
 	go.opentelemetry.io/contrib/propagators/b3 => ../../../../../../propagators/b3
+	go.opentelemetry.io/contrib/propagators/propagatortest => ../../../../../../propagators/propagatortest
 )
 
 require (
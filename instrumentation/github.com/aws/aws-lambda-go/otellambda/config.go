@@ -17,6 +17,7 @@ package otellambda
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -26,7 +27,9 @@ import (
 // very important in asynchronous settings because the Lambda runtime
 // may enter a 'frozen' state any time after the invocation completes.
 // Should this freeze happen and spans are left unexported, there can be a
-// long delay before those spans are exported.
+// long delay before those spans are exported. The cold start and duration
+// metrics of an invocation are recorded before the Flusher is called, so
+// a Flusher which also collects and exports metrics will include them.
 type Flusher interface {
 	ForceFlush(context.Context) error
 }
@@ -53,6 +56,13 @@ func emptyEventToCarrier([]byte) propagation.TextMapCarrier {
 // Compile time check our emptyEventToCarrier implements EventToCarrier
 var _ EventToCarrier = emptyEventToCarrier
 
+// A ContextToCarrier function is an EventToCarrier which is also given
+// the context of the invocation. The aws-lambda-go runtime stores the
+// X-Ray trace header of the invocation in this context, which makes it
+// a more reliable source of the trace header than the environment when
+// invocations may be handled concurrently.
+type ContextToCarrier func(ctx context.Context, eventJSON []byte) propagation.TextMapCarrier
+
 type Option interface {
 	apply(*config)
 }
@@ -70,20 +80,27 @@ type config struct {
 	// returned by otel.GetTracerProvider()
 	TracerProvider trace.TracerProvider
 
+	// MeterProvider is the MeterProvider which will be used
+	// to create the cold start and invocation duration instruments
+	// The default value of MeterProvider the global otel MeterProvider
+	// returned by global.GetMeterProvider()
+	MeterProvider metric.MeterProvider
+
 	// Flusher is the mechanism used to flush any unexported spans
-	// each Lambda Invocation to avoid spans being unexported for long
-	// when periods of time if Lambda freezes the execution environment
+	// and metrics each Lambda Invocation to avoid them being unexported
+	// for long periods of time if Lambda freezes the execution environment
 	// The default value of Flusher is a noop Flusher, using this
 	// default can result in long data delays in asynchronous settings
 	Flusher Flusher
 
-	// EventToCarrier is the mechanism used to retrieve the TraceID
-	// from the event or environment and generate a TextMapCarrier which
-	// can then be used by a Propagator to extract the TraceID into our context
-	// The default value of eventToCarrier is emptyEventToCarrier which returns
-	// an empty HeaderCarrier, using this default will cause new spans to be part
-	// of a new Trace and have no parent past our Lambda instrumentation span
-	EventToCarrier EventToCarrier
+	// ContextToCarrier is the mechanism used to retrieve the TraceID
+	// from the event, context or environment and generate a TextMapCarrier
+	// which can then be used by a Propagator to extract the TraceID into our
+	// context. It is set by both WithEventToCarrier and WithContextToCarrier.
+	// The default value of ContextToCarrier returns an empty HeaderCarrier,
+	// using this default will cause new spans to be part of a new Trace and
+	// have no parent past our Lambda instrumentation span
+	ContextToCarrier ContextToCarrier
 
 	// Propagator is the Propagator which will be used
 	// to extract Trace info into the context
@@ -104,9 +121,26 @@ func WithFlusher(flusher Flusher) Option {
 	})
 }
 
+func WithMeterProvider(meterProvider metric.MeterProvider) Option {
+	return optionFunc(func(c *config) {
+		c.MeterProvider = meterProvider
+	})
+}
+
 func WithEventToCarrier(eventToCarrier EventToCarrier) Option {
 	return optionFunc(func(c *config) {
-		c.EventToCarrier = eventToCarrier
+		c.ContextToCarrier = func(_ context.Context, eventJSON []byte) propagation.TextMapCarrier {
+			return eventToCarrier(eventJSON)
+		}
+	})
+}
+
+// WithContextToCarrier sets the ContextToCarrier used to retrieve the
+// trace information of an invocation. It replaces any EventToCarrier set
+// with WithEventToCarrier.
+func WithContextToCarrier(contextToCarrier ContextToCarrier) Option {
+	return optionFunc(func(c *config) {
+		c.ContextToCarrier = contextToCarrier
 	})
 }
 
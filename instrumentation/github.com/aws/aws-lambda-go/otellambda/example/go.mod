@@ -3,10 +3,12 @@ module go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/
 go 1.15
 
 replace (
+	go.opentelemetry.io/contrib => ../../../../../..
 	go.opentelemetry.io/contrib/detectors/aws/lambda => ../../../../../../detectors/aws/lambda
 	go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda => ../
 	go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws => ../../../aws-sdk-go-v2/otelaws
 	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp => ../../../../../net/http/otelhttp
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../../../requestfilter
 )
 
 require (
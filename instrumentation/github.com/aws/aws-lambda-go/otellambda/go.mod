@@ -8,5 +8,6 @@ require (
 	github.com/aws/aws-lambda-go v1.27.0
 	github.com/stretchr/testify v1.7.0
 	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/metric v0.24.0
 	go.opentelemetry.io/otel/trace v1.1.0
 )
@@ -19,11 +19,15 @@ import (
 	"log"
 	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/aws/aws-lambda-go/lambdacontext"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -38,28 +42,47 @@ type instrumentor struct {
 	configuration config
 	resAttrs      []attribute.KeyValue
 	tracer        trace.Tracer
+	instruments   instruments
+
+	// invoked is set once the first invocation has started, every
+	// invocation before it is a cold start.
+	invoked *int32
+}
+
+// invocation holds the state of an instrumented invocation.
+type invocation struct {
+	span      trace.Span
+	start     time.Time
+	coldStart bool
 }
 
 func newInstrumentor(opts ...Option) instrumentor {
 	cfg := config{
 		TracerProvider: otel.GetTracerProvider(),
+		MeterProvider:  global.GetMeterProvider(),
 		Flusher:        &noopFlusher{},
-		EventToCarrier: emptyEventToCarrier,
 		Propagator:     otel.GetTextMapPropagator(),
 	}
+	WithEventToCarrier(emptyEventToCarrier).apply(&cfg)
 	for _, opt := range opts {
 		opt.apply(&cfg)
 	}
 
+	meter := cfg.MeterProvider.Meter(tracerName, metric.WithInstrumentationVersion(SemVersion()))
 	return instrumentor{configuration: cfg,
-		tracer:   cfg.TracerProvider.Tracer(tracerName, trace.WithInstrumentationVersion(SemVersion())),
-		resAttrs: []attribute.KeyValue{}}
+		tracer:      cfg.TracerProvider.Tracer(tracerName, trace.WithInstrumentationVersion(SemVersion())),
+		instruments: newInstruments(meter),
+		resAttrs:    []attribute.KeyValue{},
+		invoked:     new(int32)}
 }
 
 // Logic to start OTel Tracing
-func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (context.Context, trace.Span) {
+func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (context.Context, invocation) {
+	start := time.Now()
+	coldStart := atomic.CompareAndSwapInt32(i.invoked, 0, 1)
+
 	// Add trace id to context
-	mc := i.configuration.EventToCarrier(eventJSON)
+	mc := i.configuration.ContextToCarrier(ctx, eventJSON)
 	ctx = i.configuration.Propagator.Extract(ctx, mc)
 
 	var span trace.Span
@@ -88,17 +111,19 @@ func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (cont
 		}
 		attributes = append(attributes, i.resAttrs...)
 	}
+	attributes = append(attributes, semconv.FaaSColdstartKey.Bool(coldStart))
 
 	ctx, span = i.tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...))
 
-	return ctx, span
+	return ctx, invocation{span: span, start: start, coldStart: coldStart}
 }
 
 // Logic to wrap up OTel Tracing
-func (i *instrumentor) tracingEnd(ctx context.Context, span trace.Span) {
-	span.End()
+func (i *instrumentor) tracingEnd(ctx context.Context, inv invocation) {
+	inv.span.End()
+	i.instruments.record(ctx, inv.start, inv.coldStart)
 
-	// force flush any tracing data since lambda may freeze
+	// force flush any tracing and metric data since lambda may freeze
 	err := i.configuration.Flusher.ForceFlush(ctx)
 	if err != nil {
 		errorLogger.Println("failed to force a flush, lambda may freeze before instrumentation exported: ", err)
@@ -27,6 +27,10 @@ import (
 	"github.com/aws/aws-lambda-go/lambda/messages"
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/metric/metrictest"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 )
 
 var (
@@ -293,3 +297,43 @@ func BenchmarkWrapHandler(b *testing.B) {
 		_, _ = wrapped.Invoke(mockContext, []byte{0})
 	}
 }
+
+func TestHandlerMetrics(t *testing.T) {
+	setEnvVars()
+
+	mp := metrictest.NewMeterProvider()
+	handler := WrapHandler(emptyHandler{}, WithMeterProvider(mp))
+	for i := 0; i < 3; i++ {
+		_, err := handler.Invoke(mockContext, []byte{})
+		assert.NoError(t, err)
+	}
+
+	var coldStarts int64
+	durations := map[bool]int{}
+	for _, m := range metrictest.AsStructs(mp.MeasurementBatches) {
+		switch m.Name {
+		case "faas.coldstarts":
+			coldStarts += m.Number.AsInt64()
+		case "faas.invoke_duration":
+			durations[m.Labels[semconv.FaaSColdstartKey].AsBool()]++
+		}
+	}
+	assert.Equal(t, int64(1), coldStarts)
+	assert.Equal(t, map[bool]int{true: 1, false: 2}, durations)
+}
+
+func TestContextToCarrier(t *testing.T) {
+	setEnvVars()
+
+	var gotCtx context.Context
+	var gotEvent []byte
+	handler := WrapHandler(emptyHandler{}, WithContextToCarrier(func(ctx context.Context, eventJSON []byte) propagation.TextMapCarrier {
+		gotCtx, gotEvent = ctx, eventJSON
+		return propagation.HeaderCarrier{}
+	}))
+	_, err := handler.Invoke(mockContext, []byte(`"event"`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, mockContext, gotCtx)
+	assert.Equal(t, []byte(`"event"`), gotEvent)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/unit"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+type instruments struct {
+	// coldStarts is the number of invocations that were cold starts.
+	coldStarts metric.Int64Counter
+
+	// duration is the duration of the invocations of the handler.
+	duration metric.Float64Histogram
+}
+
+// newInstruments will create instruments using the given meter.
+func newInstruments(meter metric.Meter) instruments {
+	var (
+		instruments instruments
+		err         error
+	)
+
+	if instruments.coldStarts, err = meter.NewInt64Counter(
+		"faas.coldstarts",
+		metric.WithDescription("Number of invocations that are cold starts"),
+	); err != nil {
+		errorLogger.Println("failed to create coldstarts instrument: ", err)
+	}
+
+	if instruments.duration, err = meter.NewFloat64Histogram(
+		"faas.invoke_duration",
+		metric.WithDescription("Duration of the handler invocations in milliseconds"),
+		metric.WithUnit(unit.Milliseconds),
+	); err != nil {
+		errorLogger.Println("failed to create invoke_duration instrument: ", err)
+	}
+
+	return instruments
+}
+
+// record records the metrics of an invocation started at start.
+func (i instruments) record(ctx context.Context, start time.Time, coldStart bool) {
+	if coldStart {
+		i.coldStarts.Add(ctx, 1)
+	}
+	i.duration.Record(ctx, float64(time.Since(start))/float64(time.Millisecond),
+		semconv.FaaSColdstartKey.Bool(coldStart))
+}
@@ -7,6 +7,7 @@ replace (
 	go.opentelemetry.io/contrib/detectors/aws/lambda => ../../../../../../detectors/aws/lambda
 	go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda => ../
 	go.opentelemetry.io/contrib/propagators/aws => ../../../../../../propagators/aws
+	go.opentelemetry.io/contrib/propagators/propagatortest => ../../../../../../propagators/propagatortest
 )
 
 require (
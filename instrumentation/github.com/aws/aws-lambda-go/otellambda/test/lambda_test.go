@@ -136,7 +136,8 @@ var (
 		EndTime:   time.Time{},
 		Attributes: []attribute.KeyValue{attribute.String("faas.execution", "123"),
 			attribute.String("faas.id", "arn:partition:service:region:account-id:resource-type:resource-id"),
-			attribute.String("cloud.account.id", "account-id")},
+			attribute.String("cloud.account.id", "account-id"),
+			attribute.Bool("faas.coldstart", true)},
 		Events:            nil,
 		Links:             nil,
 		Status:            sdktrace.Status{},
@@ -145,6 +146,7 @@ var (
 		DroppedLinks:      0,
 		ChildSpanCount:    0,
 		Resource: resource.NewWithAttributes(semconv.SchemaURL,
+			attribute.String("cloud.platform", "aws_lambda"),
 			attribute.String("cloud.provider", "aws"),
 			attribute.String("cloud.region", "us-texas-1"),
 			attribute.String("faas.name", "testFunction"),
@@ -324,7 +326,8 @@ var (
 		EndTime:   time.Time{},
 		Attributes: []attribute.KeyValue{attribute.String("faas.execution", "123"),
 			attribute.String("faas.id", "arn:partition:service:region:account-id:resource-type:resource-id"),
-			attribute.String("cloud.account.id", "account-id")},
+			attribute.String("cloud.account.id", "account-id"),
+			attribute.Bool("faas.coldstart", true)},
 		Events:            nil,
 		Links:             nil,
 		Status:            sdktrace.Status{},
@@ -333,6 +336,7 @@ var (
 		DroppedLinks:      0,
 		ChildSpanCount:    0,
 		Resource: resource.NewWithAttributes(semconv.SchemaURL,
+			attribute.String("cloud.platform", "aws_lambda"),
 			attribute.String("cloud.provider", "aws"),
 			attribute.String("cloud.region", "us-texas-1"),
 			attribute.String("faas.name", "testFunction"),
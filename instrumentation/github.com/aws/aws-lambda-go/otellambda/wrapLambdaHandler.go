@@ -152,8 +152,8 @@ func InstrumentHandler(handlerFunc interface{}, options ...Option) interface{} {
 func (whf *wrappedHandlerFunction) wrapper(handlerFunc interface{}) func(ctx context.Context, eventJSON []byte, event interface{}, takesContext bool) []reflect.Value {
 	return func(ctx context.Context, eventJSON []byte, event interface{}, takesContext bool) []reflect.Value {
 
-		ctx, span := whf.instrumentor.tracingBegin(ctx, eventJSON)
-		defer whf.instrumentor.tracingEnd(ctx, span)
+		ctx, inv := whf.instrumentor.tracingBegin(ctx, eventJSON)
+		defer whf.instrumentor.tracingEnd(ctx, inv)
 
 		handler := reflect.ValueOf(handlerFunc)
 		var args []reflect.Value
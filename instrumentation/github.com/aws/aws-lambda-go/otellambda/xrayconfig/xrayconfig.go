@@ -17,7 +17,6 @@ package xrayconfig
 import (
 	"context"
 	"log"
-	"os"
 	"runtime"
 
 	lambdadetector "go.opentelemetry.io/contrib/detectors/aws/lambda"
@@ -30,9 +29,8 @@ import (
 
 var errorLogger = log.New(log.Writer(), "OTel Lambda XRay Configuration Error: ", 0)
 
-func xrayEventToCarrier([]byte) propagation.TextMapCarrier {
-	xrayTraceID := os.Getenv("_X_AMZN_TRACE_ID")
-	return propagation.HeaderCarrier{"X-Amzn-Trace-Id": []string{xrayTraceID}}
+func xrayContextToCarrier(ctx context.Context, _ []byte) propagation.TextMapCarrier {
+	return xray.LambdaCarrier(ctx)
 }
 
 type asyncSafeFlusher struct {
@@ -80,11 +78,12 @@ func tracerProviderAndFlusher() ([]otellambda.Option, error) {
 }
 
 // EventToCarrier returns an otellambda.Option to enable
-// an otellambda.EventToCarrier function which reads the XRay trace
-// information from the environment and returns this information in
+// an otellambda.ContextToCarrier function which reads the XRay trace
+// information from the invocation context, or the environment if the
+// context does not hold it, and returns this information in
 // a propagation.HeaderCarrier
 func EventToCarrier() otellambda.Option {
-	return otellambda.WithEventToCarrier(xrayEventToCarrier)
+	return otellambda.WithContextToCarrier(xrayContextToCarrier)
 }
 
 // Propagator returns an otellambda.Option to enable the xray.Propagator
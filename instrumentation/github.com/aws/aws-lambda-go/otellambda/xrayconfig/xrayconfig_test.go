@@ -37,16 +37,27 @@ func TestEventToCarrier(t *testing.T) {
 	os.Clearenv()
 
 	_ = os.Setenv("_X_AMZN_TRACE_ID", "traceID")
-	carrier := xrayEventToCarrier([]byte{})
+	carrier := xrayContextToCarrier(context.Background(), []byte{})
 
 	assert.Equal(t, "traceID", carrier.Get("X-Amzn-Trace-Id"))
 }
 
+func TestContextToCarrier(t *testing.T) {
+	os.Clearenv()
+
+	_ = os.Setenv("_X_AMZN_TRACE_ID", "envTraceID")
+	//nolint:staticcheck // the aws-lambda-go runtime uses a string key.
+	ctx := context.WithValue(context.Background(), "x-amzn-trace-id", "ctxTraceID")
+	carrier := xrayContextToCarrier(ctx, []byte{})
+
+	assert.Equal(t, "ctxTraceID", carrier.Get("X-Amzn-Trace-Id"))
+}
+
 func TestEventToCarrierWithPropagator(t *testing.T) {
 	os.Clearenv()
 
 	_ = os.Setenv("_X_AMZN_TRACE_ID", "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1")
-	carrier := xrayEventToCarrier([]byte{})
+	carrier := xrayContextToCarrier(context.Background(), []byte{})
 	ctx := xray.Propagator{}.Extract(context.Background(), carrier)
 
 	expectedTraceID, _ := trace.TraceIDFromHex("5759e988bd862e3fe1be46a994272793")
@@ -95,7 +106,8 @@ var (
 			EndTimeUnixNano:   0,
 			Attributes: []*v1common.KeyValue{{Key: "faas.execution", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "123"}}},
 				{Key: "faas.id", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "arn:partition:service:region:account-id:resource-type:resource-id"}}},
-				{Key: "cloud.account.id", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "account-id"}}}},
+				{Key: "cloud.account.id", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "account-id"}}},
+				{Key: "faas.coldstart", Value: &v1common.AnyValue{Value: &v1common.AnyValue_BoolValue{BoolValue: true}}}},
 			DroppedAttributesCount: 0,
 			Events:                 nil,
 			DroppedEventsCount:     0,
@@ -107,7 +119,8 @@ var (
 	}
 
 	expectedSpanResource = v1resource.Resource{
-		Attributes: []*v1common.KeyValue{{Key: "cloud.provider", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "aws"}}},
+		Attributes: []*v1common.KeyValue{{Key: "cloud.platform", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "aws_lambda"}}},
+			{Key: "cloud.provider", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "aws"}}},
 			{Key: "cloud.region", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "us-texas-1"}}},
 			{Key: "faas.name", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "testFunction"}}},
 			{Key: "faas.version", Value: &v1common.AnyValue{Value: &v1common.AnyValue_StringValue{StringValue: "$LATEST"}}}},
@@ -122,11 +135,12 @@ var (
 )
 
 func assertResourceEquals(t *testing.T, expected *v1resource.Resource, actual *v1resource.Resource) {
-	assert.Len(t, actual.Attributes, 4)
+	assert.Len(t, actual.Attributes, 5)
 	assert.Equal(t, expected.Attributes[0].String(), actual.Attributes[0].String())
 	assert.Equal(t, expected.Attributes[1].String(), actual.Attributes[1].String())
 	assert.Equal(t, expected.Attributes[2].String(), actual.Attributes[2].String())
 	assert.Equal(t, expected.Attributes[3].String(), actual.Attributes[3].String())
+	assert.Equal(t, expected.Attributes[4].String(), actual.Attributes[4].String())
 	assert.Equal(t, expected.DroppedAttributesCount, actual.DroppedAttributesCount)
 }
 
@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// xrayTraceHeaderKey is the key used by the AWS X-Ray propagator.
+	xrayTraceHeaderKey = "X-Amzn-Trace-Id"
+	// awsTraceHeaderAttribute is the SQS system attribute carrying the
+	// X-Ray trace header.
+	awsTraceHeaderAttribute = string(sqstypes.MessageSystemAttributeNameAWSTraceHeader)
+	// stringDataType is the data type used for message attributes set by
+	// the carriers.
+	stringDataType = "String"
+)
+
+var (
+	_ propagation.TextMapCarrier = SQSSendMessageCarrier{}
+	_ propagation.TextMapCarrier = SQSSendMessageBatchEntryCarrier{}
+	_ propagation.TextMapCarrier = SQSMessageCarrier{}
+	_ propagation.TextMapCarrier = SNSPublishCarrier{}
+)
+
+func isXrayTraceHeader(key string) bool {
+	return strings.EqualFold(key, xrayTraceHeaderKey)
+}
+
+// SQSSendMessageCarrier injects and extracts values from the attributes of
+// an SQS SendMessage request.
+//
+// The X-Ray trace header is stored in the AWSTraceHeader system attribute
+// so that it is recognized by AWS X-Ray and surfaced to Lambda event
+// sources. All other keys are stored as String message attributes. SQS
+// limits messages to 10 message attributes, so only propagators with a
+// small number of fields should be used with this carrier.
+type SQSSendMessageCarrier struct {
+	input *sqs.SendMessageInput
+}
+
+// NewSQSSendMessageCarrier returns a carrier for the attributes of input.
+func NewSQSSendMessageCarrier(input *sqs.SendMessageInput) SQSSendMessageCarrier {
+	return SQSSendMessageCarrier{input: input}
+}
+
+// Get returns the value associated with the passed key.
+func (c SQSSendMessageCarrier) Get(key string) string {
+	return sqsGet(c.input.MessageSystemAttributes, c.input.MessageAttributes, key)
+}
+
+// Set stores the key-value pair.
+func (c SQSSendMessageCarrier) Set(key, value string) {
+	if isXrayTraceHeader(key) {
+		if c.input.MessageSystemAttributes == nil {
+			c.input.MessageSystemAttributes = make(map[string]sqstypes.MessageSystemAttributeValue)
+		}
+		c.input.MessageSystemAttributes[awsTraceHeaderAttribute] = sqsSystemAttributeValue(value)
+		return
+	}
+	if c.input.MessageAttributes == nil {
+		c.input.MessageAttributes = make(map[string]sqstypes.MessageAttributeValue)
+	}
+	c.input.MessageAttributes[key] = sqsAttributeValue(value)
+}
+
+// Keys lists the keys stored in this carrier.
+func (c SQSSendMessageCarrier) Keys() []string {
+	return sqsKeys(c.input.MessageSystemAttributes, c.input.MessageAttributes)
+}
+
+// SQSSendMessageBatchEntryCarrier injects and extracts values from the
+// attributes of a single SQS SendMessageBatch request entry. It stores
+// values in the same way as SQSSendMessageCarrier.
+type SQSSendMessageBatchEntryCarrier struct {
+	entry *sqstypes.SendMessageBatchRequestEntry
+}
+
+// NewSQSSendMessageBatchEntryCarrier returns a carrier for the attributes
+// of entry.
+func NewSQSSendMessageBatchEntryCarrier(entry *sqstypes.SendMessageBatchRequestEntry) SQSSendMessageBatchEntryCarrier {
+	return SQSSendMessageBatchEntryCarrier{entry: entry}
+}
+
+// Get returns the value associated with the passed key.
+func (c SQSSendMessageBatchEntryCarrier) Get(key string) string {
+	return sqsGet(c.entry.MessageSystemAttributes, c.entry.MessageAttributes, key)
+}
+
+// Set stores the key-value pair.
+func (c SQSSendMessageBatchEntryCarrier) Set(key, value string) {
+	if isXrayTraceHeader(key) {
+		if c.entry.MessageSystemAttributes == nil {
+			c.entry.MessageSystemAttributes = make(map[string]sqstypes.MessageSystemAttributeValue)
+		}
+		c.entry.MessageSystemAttributes[awsTraceHeaderAttribute] = sqsSystemAttributeValue(value)
+		return
+	}
+	if c.entry.MessageAttributes == nil {
+		c.entry.MessageAttributes = make(map[string]sqstypes.MessageAttributeValue)
+	}
+	c.entry.MessageAttributes[key] = sqsAttributeValue(value)
+}
+
+// Keys lists the keys stored in this carrier.
+func (c SQSSendMessageBatchEntryCarrier) Keys() []string {
+	return sqsKeys(c.entry.MessageSystemAttributes, c.entry.MessageAttributes)
+}
+
+// SQSMessageCarrier extracts values from a message received from SQS.
+//
+// The X-Ray trace header is read from the AWSTraceHeader system attribute,
+// which is only returned when it is requested with the AttributeNames
+// parameter of the ReceiveMessage call. If it is absent, the message
+// attributes are used instead.
+type SQSMessageCarrier struct {
+	msg *sqstypes.Message
+}
+
+// NewSQSMessageCarrier returns a carrier for the attributes of msg.
+func NewSQSMessageCarrier(msg *sqstypes.Message) SQSMessageCarrier {
+	return SQSMessageCarrier{msg: msg}
+}
+
+// Get returns the value associated with the passed key.
+func (c SQSMessageCarrier) Get(key string) string {
+	if isXrayTraceHeader(key) {
+		if v, ok := c.msg.Attributes[awsTraceHeaderAttribute]; ok {
+			return v
+		}
+	}
+	return sqsMessageAttribute(c.msg.MessageAttributes, key)
+}
+
+// Set stores the key-value pair as a String message attribute.
+func (c SQSMessageCarrier) Set(key, value string) {
+	if c.msg.MessageAttributes == nil {
+		c.msg.MessageAttributes = make(map[string]sqstypes.MessageAttributeValue)
+	}
+	c.msg.MessageAttributes[key] = sqsAttributeValue(value)
+}
+
+// Keys lists the keys stored in this carrier.
+func (c SQSMessageCarrier) Keys() []string {
+	out := make([]string, 0, len(c.msg.MessageAttributes)+1)
+	if _, ok := c.msg.Attributes[awsTraceHeaderAttribute]; ok {
+		out = append(out, xrayTraceHeaderKey)
+	}
+	for k := range c.msg.MessageAttributes {
+		out = append(out, k)
+	}
+	return out
+}
+
+// SNSPublishCarrier injects and extracts values from the message attributes
+// of an SNS Publish request. Values are stored as String message
+// attributes. SNS limits messages to 10 message attributes when they are
+// delivered to SQS subscribers, so only propagators with a small number of
+// fields should be used with this carrier.
+type SNSPublishCarrier struct {
+	input *sns.PublishInput
+}
+
+// NewSNSPublishCarrier returns a carrier for the message attributes of
+// input.
+func NewSNSPublishCarrier(input *sns.PublishInput) SNSPublishCarrier {
+	return SNSPublishCarrier{input: input}
+}
+
+// Get returns the value associated with the passed key.
+func (c SNSPublishCarrier) Get(key string) string {
+	if v, ok := c.input.MessageAttributes[key]; ok && v.StringValue != nil {
+		return *v.StringValue
+	}
+	return ""
+}
+
+// Set stores the key-value pair.
+func (c SNSPublishCarrier) Set(key, value string) {
+	if c.input.MessageAttributes == nil {
+		c.input.MessageAttributes = make(map[string]snstypes.MessageAttributeValue)
+	}
+	c.input.MessageAttributes[key] = snstypes.MessageAttributeValue{
+		DataType:    aws.String(stringDataType),
+		StringValue: aws.String(value),
+	}
+}
+
+// Keys lists the keys stored in this carrier.
+func (c SNSPublishCarrier) Keys() []string {
+	out := make([]string, 0, len(c.input.MessageAttributes))
+	for k := range c.input.MessageAttributes {
+		out = append(out, k)
+	}
+	return out
+}
+
+func sqsGet(system map[string]sqstypes.MessageSystemAttributeValue, attrs map[string]sqstypes.MessageAttributeValue, key string) string {
+	if isXrayTraceHeader(key) {
+		if v, ok := system[awsTraceHeaderAttribute]; ok && v.StringValue != nil {
+			return *v.StringValue
+		}
+	}
+	return sqsMessageAttribute(attrs, key)
+}
+
+func sqsKeys(system map[string]sqstypes.MessageSystemAttributeValue, attrs map[string]sqstypes.MessageAttributeValue) []string {
+	out := make([]string, 0, len(attrs)+1)
+	if _, ok := system[awsTraceHeaderAttribute]; ok {
+		out = append(out, xrayTraceHeaderKey)
+	}
+	for k := range attrs {
+		out = append(out, k)
+	}
+	return out
+}
+
+func sqsMessageAttribute(attrs map[string]sqstypes.MessageAttributeValue, key string) string {
+	if v, ok := attrs[key]; ok && v.StringValue != nil {
+		return *v.StringValue
+	}
+	return ""
+}
+
+func sqsAttributeValue(value string) sqstypes.MessageAttributeValue {
+	return sqstypes.MessageAttributeValue{
+		DataType:    aws.String(stringDataType),
+		StringValue: aws.String(value),
+	}
+}
+
+func sqsSystemAttributeValue(value string) sqstypes.MessageSystemAttributeValue {
+	return sqstypes.MessageSystemAttributeValue{
+		DataType:    aws.String(stringDataType),
+		StringValue: aws.String(value),
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const traceHeader = "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1"
+
+func TestSQSSendMessageCarrier(t *testing.T) {
+	input := &sqs.SendMessageInput{}
+	carrier := NewSQSSendMessageCarrier(input)
+
+	carrier.Set("X-Amzn-Trace-Id", traceHeader)
+	carrier.Set("foo", "bar")
+
+	require.Contains(t, input.MessageSystemAttributes, "AWSTraceHeader")
+	assert.Equal(t, traceHeader, aws.ToString(input.MessageSystemAttributes["AWSTraceHeader"].StringValue))
+	assert.Equal(t, "String", aws.ToString(input.MessageSystemAttributes["AWSTraceHeader"].DataType))
+	assert.NotContains(t, input.MessageAttributes, "X-Amzn-Trace-Id")
+	assert.Equal(t, "bar", aws.ToString(input.MessageAttributes["foo"].StringValue))
+
+	assert.Equal(t, traceHeader, carrier.Get("x-amzn-trace-id"))
+	assert.Equal(t, "bar", carrier.Get("foo"))
+	assert.Equal(t, "", carrier.Get("baz"))
+	assert.ElementsMatch(t, []string{"X-Amzn-Trace-Id", "foo"}, carrier.Keys())
+}
+
+func TestSQSSendMessageBatchEntryCarrier(t *testing.T) {
+	entry := &sqstypes.SendMessageBatchRequestEntry{}
+	carrier := NewSQSSendMessageBatchEntryCarrier(entry)
+
+	carrier.Set("X-Amzn-Trace-Id", traceHeader)
+	carrier.Set("foo", "bar")
+
+	assert.Equal(t, traceHeader, aws.ToString(entry.MessageSystemAttributes["AWSTraceHeader"].StringValue))
+	assert.Equal(t, "bar", aws.ToString(entry.MessageAttributes["foo"].StringValue))
+	assert.Equal(t, traceHeader, carrier.Get("X-Amzn-Trace-Id"))
+	assert.ElementsMatch(t, []string{"X-Amzn-Trace-Id", "foo"}, carrier.Keys())
+}
+
+func TestSQSMessageCarrierGet(t *testing.T) {
+	testCases := []struct {
+		name     string
+		msg      *sqstypes.Message
+		key      string
+		expected string
+	}{
+		{
+			name: "system attribute",
+			msg: &sqstypes.Message{
+				Attributes: map[string]string{"AWSTraceHeader": traceHeader},
+			},
+			key:      "X-Amzn-Trace-Id",
+			expected: traceHeader,
+		},
+		{
+			name: "system attribute preferred",
+			msg: &sqstypes.Message{
+				Attributes: map[string]string{"AWSTraceHeader": traceHeader},
+				MessageAttributes: map[string]sqstypes.MessageAttributeValue{
+					"X-Amzn-Trace-Id": sqsAttributeValue("other"),
+				},
+			},
+			key:      "X-Amzn-Trace-Id",
+			expected: traceHeader,
+		},
+		{
+			name: "message attribute fallback",
+			msg: &sqstypes.Message{
+				MessageAttributes: map[string]sqstypes.MessageAttributeValue{
+					"X-Amzn-Trace-Id": sqsAttributeValue(traceHeader),
+				},
+			},
+			key:      "X-Amzn-Trace-Id",
+			expected: traceHeader,
+		},
+		{
+			name: "binary message attribute",
+			msg: &sqstypes.Message{
+				MessageAttributes: map[string]sqstypes.MessageAttributeValue{
+					"foo": {DataType: aws.String("Binary"), BinaryValue: []byte("bar")},
+				},
+			},
+			key:      "foo",
+			expected: "",
+		},
+		{
+			name:     "not exists",
+			msg:      &sqstypes.Message{},
+			key:      "foo",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewSQSMessageCarrier(tc.msg).Get(tc.key))
+		})
+	}
+}
+
+func TestSQSMessageCarrierSetAndKeys(t *testing.T) {
+	msg := &sqstypes.Message{
+		Attributes: map[string]string{"AWSTraceHeader": traceHeader},
+	}
+	carrier := NewSQSMessageCarrier(msg)
+	carrier.Set("foo", "bar")
+
+	assert.Equal(t, "bar", carrier.Get("foo"))
+	assert.ElementsMatch(t, []string{"X-Amzn-Trace-Id", "foo"}, carrier.Keys())
+}
+
+func TestSNSPublishCarrier(t *testing.T) {
+	input := &sns.PublishInput{}
+	carrier := NewSNSPublishCarrier(input)
+
+	carrier.Set("X-Amzn-Trace-Id", traceHeader)
+
+	assert.Equal(t, snstypes.MessageAttributeValue{
+		DataType:    aws.String("String"),
+		StringValue: aws.String(traceHeader),
+	}, input.MessageAttributes["X-Amzn-Trace-Id"])
+	assert.Equal(t, traceHeader, carrier.Get("X-Amzn-Trace-Id"))
+	assert.Equal(t, "", carrier.Get("foo"))
+	assert.Equal(t, []string{"X-Amzn-Trace-Id"}, carrier.Keys())
+}
+
+func TestSQSCarrierRoundTrip(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	prop := propagation.TraceContext{}
+
+	input := &sqs.SendMessageInput{}
+	prop.Inject(ctx, NewSQSSendMessageCarrier(input))
+
+	msg := &sqstypes.Message{MessageAttributes: input.MessageAttributes}
+	got := trace.SpanContextFromContext(prop.Extract(context.Background(), NewSQSMessageCarrier(msg)))
+	assert.Equal(t, sc.WithRemote(true), got)
+}
@@ -6,7 +6,10 @@ replace go.opentelemetry.io/contrib => ../../../../../
 
 require (
 	github.com/aws/aws-sdk-go-v2 v1.10.0
+	github.com/aws/aws-sdk-go-v2/service/sns v1.9.0
+	github.com/aws/aws-sdk-go-v2/service/sqs v1.10.0
 	github.com/aws/smithy-go v1.8.1
+	github.com/stretchr/testify v1.7.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/trace v1.1.0
 )
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// TraceContextKey is the field of a JSON object the EventBridge and Step
+// Functions carriers store propagated values in.
+const TraceContextKey = "traceContext"
+
+const (
+	// sfnExecutionKey and sfnInputKey are the fields of the Step Functions
+	// context object holding the execution input.
+	sfnExecutionKey = "Execution"
+	sfnInputKey     = "Input"
+)
+
+var (
+	_ propagation.TextMapCarrier = EventBridgeDetailCarrier{}
+	_ propagation.TextMapCarrier = StepFunctionsCarrier{}
+)
+
+// EventBridgeDetailCarrier injects and extracts values from the detail of
+// an EventBridge event. EventBridge does not forward the TraceHeader of a
+// PutEvents request entry to all targets, so the values, including the
+// X-Ray trace header, are stored in the traceContext object of the decoded
+// detail:
+//
+//	{"orderId": "1234", "traceContext": {"X-Amzn-Trace-Id": "Root=..."}}
+//
+// The detail is encoded into the Detail field of a PutEvents request entry
+// after the trace context is injected, and decoded from the detail of a
+// received event before it is extracted.
+type EventBridgeDetailCarrier struct {
+	detail map[string]interface{}
+}
+
+// NewEventBridgeDetailCarrier returns a carrier for the decoded event detail.
+// detail must not be nil.
+func NewEventBridgeDetailCarrier(detail map[string]interface{}) EventBridgeDetailCarrier {
+	return EventBridgeDetailCarrier{detail: detail}
+}
+
+// Get returns the value associated with the passed key.
+func (c EventBridgeDetailCarrier) Get(key string) string {
+	return jsonGet(c.detail, key)
+}
+
+// Set stores the key-value pair.
+func (c EventBridgeDetailCarrier) Set(key, value string) {
+	jsonSet(c.detail, key, value)
+}
+
+// Keys lists the keys stored in this carrier.
+func (c EventBridgeDetailCarrier) Keys() []string {
+	return jsonKeys(c.detail)
+}
+
+// StepFunctionsCarrier injects and extracts values from the input of a Step
+// Functions execution or task. Values are stored in the traceContext object
+// of the decoded input, in the same way as EventBridgeDetailCarrier.
+//
+// The input of an execution is not passed on to the tasks of a state
+// machine unless the states forward it. A task can instead read the trace
+// context from the context object of the execution, either by selecting
+// it in its parameters:
+//
+//	"Parameters": {
+//	  "payload.$": "$",
+//	  "traceContext.$": "$$.Execution.Input.traceContext"
+//	}
+//
+// or by receiving the whole context object ("$$"). When the traceContext
+// object is missing from the input, Get reads it from the Execution.Input
+// object of a context object.
+type StepFunctionsCarrier struct {
+	input map[string]interface{}
+}
+
+// NewStepFunctionsCarrier returns a carrier for the decoded execution or task
+// input. input must not be nil.
+func NewStepFunctionsCarrier(input map[string]interface{}) StepFunctionsCarrier {
+	return StepFunctionsCarrier{input: input}
+}
+
+// Get returns the value associated with the passed key.
+func (c StepFunctionsCarrier) Get(key string) string {
+	return jsonGet(c.source(), key)
+}
+
+// Set stores the key-value pair.
+func (c StepFunctionsCarrier) Set(key, value string) {
+	jsonSet(c.input, key, value)
+}
+
+// Keys lists the keys stored in this carrier.
+func (c StepFunctionsCarrier) Keys() []string {
+	return jsonKeys(c.source())
+}
+
+// source returns the object holding the traceContext object: the input
+// itself, or the execution input of a context object.
+func (c StepFunctionsCarrier) source() map[string]interface{} {
+	if _, ok := c.input[TraceContextKey]; ok {
+		return c.input
+	}
+	execution, ok := c.input[sfnExecutionKey].(map[string]interface{})
+	if !ok {
+		return c.input
+	}
+	if input, ok := execution[sfnInputKey].(map[string]interface{}); ok {
+		return input
+	}
+	return c.input
+}
+
+func jsonTraceContext(obj map[string]interface{}) map[string]interface{} {
+	tc, _ := obj[TraceContextKey].(map[string]interface{})
+	return tc
+}
+
+func jsonGet(obj map[string]interface{}, key string) string {
+	tc := jsonTraceContext(obj)
+	if v, ok := tc[key].(string); ok {
+		return v
+	}
+	if isXrayTraceHeader(key) {
+		// Keys are case sensitive in JSON, but the X-Ray trace header may
+		// have been set by a producer using a different case.
+		for k, v := range tc {
+			if s, ok := v.(string); ok && isXrayTraceHeader(k) {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func jsonSet(obj map[string]interface{}, key, value string) {
+	tc := jsonTraceContext(obj)
+	if tc == nil {
+		tc = make(map[string]interface{})
+		obj[TraceContextKey] = tc
+	}
+	if isXrayTraceHeader(key) {
+		key = xrayTraceHeaderKey
+	}
+	tc[key] = value
+}
+
+func jsonKeys(obj map[string]interface{}) []string {
+	tc := jsonTraceContext(obj)
+	out := make([]string, 0, len(tc))
+	for k, v := range tc {
+		if _, ok := v.(string); ok {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
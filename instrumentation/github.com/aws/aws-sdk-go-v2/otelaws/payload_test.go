@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestEventBridgeDetailCarrier(t *testing.T) {
+	detail := map[string]interface{}{"orderId": "1234"}
+	carrier := NewEventBridgeDetailCarrier(detail)
+
+	carrier.Set("x-amzn-trace-id", traceHeader)
+	carrier.Set("foo", "bar")
+
+	assert.Equal(t, map[string]interface{}{
+		"orderId": "1234",
+		"traceContext": map[string]interface{}{
+			"X-Amzn-Trace-Id": traceHeader,
+			"foo":             "bar",
+		},
+	}, detail)
+	assert.Equal(t, traceHeader, carrier.Get("X-Amzn-Trace-Id"))
+	assert.Equal(t, "bar", carrier.Get("foo"))
+	assert.Equal(t, "", carrier.Get("baz"))
+	assert.Equal(t, []string{"X-Amzn-Trace-Id", "foo"}, carrier.Keys())
+}
+
+func TestEventBridgeDetailCarrierDecoded(t *testing.T) {
+	var detail map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"traceContext":{"x-amzn-trace-id":"`+traceHeader+`","n":1}}`), &detail))
+	carrier := NewEventBridgeDetailCarrier(detail)
+
+	assert.Equal(t, traceHeader, carrier.Get("X-Amzn-Trace-Id"))
+	assert.Equal(t, "", carrier.Get("n"))
+	assert.Equal(t, []string{"x-amzn-trace-id"}, carrier.Keys())
+}
+
+func TestStepFunctionsCarrier(t *testing.T) {
+	input := map[string]interface{}{}
+	carrier := NewStepFunctionsCarrier(input)
+	carrier.Set("X-Amzn-Trace-Id", traceHeader)
+
+	assert.Equal(t, map[string]interface{}{
+		"traceContext": map[string]interface{}{"X-Amzn-Trace-Id": traceHeader},
+	}, input)
+	assert.Equal(t, traceHeader, carrier.Get("X-Amzn-Trace-Id"))
+	assert.Equal(t, []string{"X-Amzn-Trace-Id"}, carrier.Keys())
+}
+
+func TestStepFunctionsCarrierContextObject(t *testing.T) {
+	var contextObject map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"Execution": {
+			"Id": "arn:aws:states:us-east-1:123456789012:execution:stateMachine:1",
+			"Input": {"traceContext": {"X-Amzn-Trace-Id": "`+traceHeader+`"}}
+		},
+		"State": {"Name": "Task"}
+	}`), &contextObject))
+	carrier := NewStepFunctionsCarrier(contextObject)
+
+	assert.Equal(t, traceHeader, carrier.Get("X-Amzn-Trace-Id"))
+	assert.Equal(t, []string{"X-Amzn-Trace-Id"}, carrier.Keys())
+}
+
+func TestEventBridgeCarrierRoundTrip(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	prop := propagation.TraceContext{}
+
+	detail := map[string]interface{}{"orderId": "1234"}
+	prop.Inject(ctx, NewEventBridgeDetailCarrier(detail))
+	data, err := json.Marshal(detail)
+	require.NoError(t, err)
+
+	var received map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &received))
+	got := trace.SpanContextFromContext(prop.Extract(context.Background(), NewEventBridgeDetailCarrier(received)))
+	assert.Equal(t, sc.WithRemote(true), got)
+}
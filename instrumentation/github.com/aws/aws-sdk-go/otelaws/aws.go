@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelaws instruments the AWS SDK for Go v1 with request handlers.
+// It creates the same spans as the instrumentation of the AWS SDK for Go v2,
+// go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws.
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go/otelaws"
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go/otelaws"
+
+	sendHandlerName     = "otelaws.SendHandler"
+	completeHandlerName = "otelaws.CompleteHandler"
+)
+
+// spanKey is the context key marking that the span of a request was started, so
+// that retries do not start another one.
+type spanKey struct{}
+
+type otelHandlers struct {
+	tracer trace.Tracer
+}
+
+// send starts the span of the request before it is first sent. The span
+// starts with the request and lasts until it is complete, including its
+// retries.
+func (h otelHandlers) send(r *request.Request) {
+	ctx := r.Context()
+	if ctx.Value(spanKey{}) != nil {
+		return
+	}
+
+	serviceID := r.ClientInfo.ServiceID
+	ctx, _ = h.tracer.Start(ctx, serviceID,
+		trace.WithTimestamp(r.Time),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(ServiceAttr(serviceID),
+			RegionAttr(aws.StringValue(r.Config.Region)),
+			OperationAttr(r.Operation.Name)),
+	)
+	r.SetContext(context.WithValue(ctx, spanKey{}, true))
+}
+
+// complete ends the span of the request.
+func (h otelHandlers) complete(r *request.Request) {
+	ctx := r.Context()
+	if ctx.Value(spanKey{}) == nil {
+		// The request failed before it was sent.
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if r.HTTPResponse != nil && r.HTTPResponse.StatusCode != 0 {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(r.HTTPResponse.StatusCode))
+	}
+	if r.RequestID != "" {
+		span.SetAttributes(RequestIDAttr(r.RequestID))
+	}
+	if r.Error != nil {
+		span.RecordError(r.Error)
+		span.SetStatus(codes.Error, r.Error.Error())
+	}
+	span.End()
+}
+
+// InstrumentHandlers adds the handlers tracing the requests to handlers,
+// e.g. the handlers of a session.Session to trace the requests of all the
+// clients created with it:
+//
+//	sess := session.Must(session.NewSession())
+//	otelaws.InstrumentHandlers(&sess.Handlers)
+//
+// A span is started when a request is first sent and ended when it is
+// complete.
+func InstrumentHandlers(handlers *request.Handlers, opts ...Option) {
+	cfg := config{
+		TracerProvider: otel.GetTracerProvider(),
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
+	h := otelHandlers{tracer: cfg.TracerProvider.Tracer(tracerName,
+		trace.WithInstrumentationVersion(SemVersion()))}
+	handlers.Send.PushFrontNamed(request.NamedHandler{Name: sendHandlerName, Fn: h.send})
+	handlers.Complete.PushBackNamed(request.NamedHandler{Name: completeHandlerName, Fn: h.complete})
+}
@@ -0,0 +1,9 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go/otelaws
+
+go 1.15
+
+require (
+	github.com/aws/aws-sdk-go v1.41.11
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go/otelaws"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newRoute53(t *testing.T, url, region string, maxRetries int, tp trace.TracerProvider) *route53.Route53 {
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(region),
+		Endpoint:    aws.String(url),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		MaxRetries:  aws.Int(maxRetries),
+	})
+	require.NoError(t, err)
+	otelaws.InstrumentHandlers(&sess.Handlers, otelaws.WithTracerProvider(tp))
+	return route53.New(sess)
+}
+
+func changeInput() *route53.ChangeResourceRecordSetsInput {
+	return &route53.ChangeResourceRecordSetsInput{
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action: aws.String(route53.ChangeActionCreate),
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name: aws.String("example.com"),
+					Type: aws.String(route53.RRTypeA),
+				},
+			}},
+			Comment: aws.String("mock"),
+		},
+		HostedZoneId: aws.String("zone"),
+	}
+}
+
+func TestInstrumentHandlers(t *testing.T) {
+	cases := map[string]struct {
+		responseStatus     int
+		responseBody       []byte
+		expectedRegion     string
+		expectedError      codes.Code
+		expectedStatusCode int
+	}{
+		"standardRestXMLError": {
+			responseStatus: 404,
+			responseBody: []byte(`<?xml version="1.0"?>
+		<ErrorResponse xmlns="http://route53.amazonaws.com/doc/2016-09-07/">
+		  <Error>
+		    <Type>Sender</Type>
+		    <Code>MalformedXML</Code>
+		    <Message>1 validation error detected: Value null at 'route53#ChangeSet' failed to satisfy constraint: Member must not be null</Message>
+		  </Error>
+		  <RequestId>1234567890A</RequestId>
+		</ErrorResponse>
+		`),
+			expectedRegion:     "us-west-1",
+			expectedError:      codes.Error,
+			expectedStatusCode: 404,
+		},
+
+		"Success response": {
+			responseStatus: 200,
+			responseBody: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<ChangeResourceRecordSetsResponse>
+   			<ChangeInfo>
+      		<Comment>mockComment</Comment>
+      		<Id>mockID</Id>
+   		</ChangeInfo>
+		</ChangeResourceRecordSetsResponse>`),
+			expectedRegion:     "us-west-2",
+			expectedStatusCode: 200,
+		},
+	}
+
+	for name, c := range cases {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Amzn-Requestid", "b25f48e8-84fd-11e6-80d9-574e0c4664cb")
+				w.WriteHeader(c.responseStatus)
+				_, err := w.Write(c.responseBody)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}))
+		defer server.Close()
+
+		t.Run(name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+			svc := newRoute53(t, server.URL, c.expectedRegion, 0, provider)
+			_, err := svc.ChangeResourceRecordSets(changeInput())
+			if c.expectedError == codes.Unset {
+				assert.NoError(t, err)
+			} else {
+				assert.NotNil(t, err)
+			}
+
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+
+			assert.Equal(t, "Route 53", span.Name())
+			assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+			assert.Equal(t, c.expectedError, span.Status().Code)
+			attrs := span.Attributes()
+			assert.Contains(t, attrs, attribute.Int("http.status_code", c.expectedStatusCode))
+			assert.Contains(t, attrs, attribute.String("aws.request_id", "b25f48e8-84fd-11e6-80d9-574e0c4664cb"))
+			assert.Contains(t, attrs, attribute.String("aws.service", "Route 53"))
+			assert.Contains(t, attrs, attribute.String("aws.region", c.expectedRegion))
+			assert.Contains(t, attrs, attribute.String("aws.operation", "ChangeResourceRecordSets"))
+		})
+	}
+}
+
+func TestInstrumentHandlersRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`<ChangeResourceRecordSetsResponse></ChangeResourceRecordSetsResponse>`))
+		}))
+	defer server.Close()
+
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	svc := newRoute53(t, server.URL, "us-east-1", 1, provider)
+	_, err := svc.ChangeResourceRecordSetsWithContext(ctx, changeInput())
+	require.NoError(t, err)
+	parent.End()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "Route 53", span.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.Contains(t, span.Attributes(), attribute.Int("http.status_code", http.StatusOK))
+}
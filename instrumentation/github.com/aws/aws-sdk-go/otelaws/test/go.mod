@@ -0,0 +1,14 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go/otelaws/test
+
+go 1.15
+
+require (
+	github.com/aws/aws-sdk-go v1.41.11
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go/otelaws v0.26.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
+
+replace go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go/otelaws => ../
@@ -15,11 +15,13 @@
 package otelmemcache
 
 import (
+	"go.opentelemetry.io/otel/metric"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 type config struct {
 	tracerProvider oteltrace.TracerProvider
+	meterProvider  metric.MeterProvider
 }
 
 // Option is used to configure the client.
@@ -42,3 +44,13 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 		}
 	})
 }
+
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.meterProvider = provider
+		}
+	})
+}
@@ -16,6 +16,7 @@ package otelmemcache
 
 import (
 	"context"
+	"time"
 
 	"github.com/bradfitz/gomemcache/memcache"
 
@@ -23,6 +24,8 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
@@ -33,8 +36,9 @@ const (
 // Client is a wrapper around *memcache.Client.
 type Client struct {
 	*memcache.Client
-	tracer oteltrace.Tracer
-	ctx    context.Context
+	tracer      oteltrace.Tracer
+	instruments *instruments
+	ctx         context.Context
 }
 
 // NewClientWithTracing wraps the provided memcache client to allow
@@ -44,8 +48,10 @@ type Client struct {
 //
 // Every client operation starts a span with appropriate attributes,
 // executes the operation and ends the span (additionally also sets a status
-// error code and message, if an error occurs). Optionally, client context can
-// be set before an operation with the WithContext method.
+// error code and message, if an error occurs). The latency of the operation
+// is recorded with a meter from the meter provider set with
+// WithMeterProvider, or the global one. Optionally, client context can be set
+// before an operation with the WithContext method.
 func NewClientWithTracing(client *memcache.Client, opts ...Option) *Client {
 	cfg := &config{}
 	for _, o := range opts {
@@ -55,6 +61,9 @@ func NewClientWithTracing(client *memcache.Client, opts ...Option) *Client {
 	if cfg.tracerProvider == nil {
 		cfg.tracerProvider = otel.GetTracerProvider()
 	}
+	if cfg.meterProvider == nil {
+		cfg.meterProvider = global.GetMeterProvider()
+	}
 
 	return &Client{
 		client,
@@ -62,6 +71,10 @@ func NewClientWithTracing(client *memcache.Client, opts ...Option) *Client {
 			tracerName,
 			oteltrace.WithInstrumentationVersion(SemVersion()),
 		),
+		newInstruments(cfg.meterProvider.Meter(
+			tracerName,
+			metric.WithInstrumentationVersion(SemVersion()),
+		)),
 		context.Background(),
 	}
 }
@@ -81,14 +94,24 @@ func (c *Client) attrsByOperationAndItemKey(operation internal.Operation, key ..
 	return attributes
 }
 
+// operationSpan is the span of an operation along with the operation name and
+// start time used to record its latency.
+type operationSpan struct {
+	oteltrace.Span
+	operation internal.Operation
+	start     time.Time
+}
+
 // Starts span with appropriate span kind and attributes
-func (c *Client) startSpan(operationName internal.Operation, itemKey ...string) oteltrace.Span {
+func (c *Client) startSpan(operationName internal.Operation, itemKey ...string) operationSpan {
+	start := time.Now()
 	opts := []oteltrace.SpanStartOption{
 		// for database client calls, always use CLIENT span kind
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
 		oteltrace.WithAttributes(
 			c.attrsByOperationAndItemKey(operationName, itemKey...)...,
 		),
+		oteltrace.WithTimestamp(start),
 	}
 
 	_, span := c.tracer.Start(
@@ -97,24 +120,34 @@ func (c *Client) startSpan(operationName internal.Operation, itemKey ...string)
 		opts...,
 	)
 
-	return span
+	return operationSpan{span, operationName, start}
 }
 
-// Ends span and, if applicable, sets error status
-func endSpan(s oteltrace.Span, err error) {
+// Ends span, records the operation latency and, if applicable, sets error
+// status
+func (c *Client) endSpan(s operationSpan, err error) {
+	end := time.Now()
+	c.instruments.latency.Record(
+		c.ctx,
+		end.Sub(s.start).Milliseconds(),
+		internal.MemcacheDBSystem(),
+		internal.MemcacheDBOperation(s.operation),
+	)
+
 	if err != nil {
 		s.SetStatus(codes.Error, err.Error())
 	}
-	s.End()
+	s.End(oteltrace.WithTimestamp(end))
 }
 
 // WithContext retruns a copy of the client with provided context
 func (c *Client) WithContext(ctx context.Context) *Client {
 	cc := c.Client
 	return &Client{
-		Client: cc,
-		tracer: c.tracer,
-		ctx:    ctx,
+		Client:      cc,
+		tracer:      c.tracer,
+		instruments: c.instruments,
+		ctx:         ctx,
 	}
 }
 
@@ -122,7 +155,7 @@ func (c *Client) WithContext(ctx context.Context) *Client {
 func (c *Client) Add(item *memcache.Item) error {
 	s := c.startSpan(internal.OperationAdd, item.Key)
 	err := c.Client.Add(item)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
@@ -130,7 +163,7 @@ func (c *Client) Add(item *memcache.Item) error {
 func (c *Client) CompareAndSwap(item *memcache.Item) error {
 	s := c.startSpan(internal.OperationCompareAndSwap, item.Key)
 	err := c.Client.CompareAndSwap(item)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
@@ -138,7 +171,7 @@ func (c *Client) CompareAndSwap(item *memcache.Item) error {
 func (c *Client) Decrement(key string, delta uint64) (uint64, error) {
 	s := c.startSpan(internal.OperationDecrement, key)
 	newValue, err := c.Client.Decrement(key, delta)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return newValue, err
 }
 
@@ -146,7 +179,7 @@ func (c *Client) Decrement(key string, delta uint64) (uint64, error) {
 func (c *Client) Delete(key string) error {
 	s := c.startSpan(internal.OperationDelete, key)
 	err := c.Client.Delete(key)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
@@ -154,7 +187,7 @@ func (c *Client) Delete(key string) error {
 func (c *Client) DeleteAll() error {
 	s := c.startSpan(internal.OperationDeleteAll)
 	err := c.Client.DeleteAll()
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
@@ -162,23 +195,31 @@ func (c *Client) DeleteAll() error {
 func (c *Client) FlushAll() error {
 	s := c.startSpan(internal.OperationFlushAll)
 	err := c.Client.FlushAll()
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
-// Get invokes the get operation and traces it
+// Get invokes the get operation and traces it, recording whether the item
+// was found in the cache
 func (c *Client) Get(key string) (*memcache.Item, error) {
 	s := c.startSpan(internal.OperationGet, key)
 	item, err := c.Client.Get(key)
-	endSpan(s, err)
+	if err == nil || err == memcache.ErrCacheMiss {
+		s.SetAttributes(internal.MemcacheDBCacheHit(err == nil))
+	}
+	c.endSpan(s, err)
 	return item, err
 }
 
-// GetMulti invokes the get operation for multiple keys and traces it
+// GetMulti invokes the get operation for multiple keys and traces it,
+// recording the number of items found in the cache
 func (c *Client) GetMulti(keys []string) (map[string]*memcache.Item, error) {
 	s := c.startSpan(internal.OperationGet, keys...)
 	items, err := c.Client.GetMulti(keys)
-	endSpan(s, err)
+	if err == nil {
+		s.SetAttributes(internal.MemcacheDBCacheHits(len(items)))
+	}
+	c.endSpan(s, err)
 	return items, err
 }
 
@@ -186,7 +227,7 @@ func (c *Client) GetMulti(keys []string) (map[string]*memcache.Item, error) {
 func (c *Client) Increment(key string, delta uint64) (uint64, error) {
 	s := c.startSpan(internal.OperationIncrement, key)
 	newValue, err := c.Client.Increment(key, delta)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return newValue, err
 }
 
@@ -194,7 +235,7 @@ func (c *Client) Increment(key string, delta uint64) (uint64, error) {
 func (c *Client) Ping() error {
 	s := c.startSpan(internal.OperationPing)
 	err := c.Client.Ping()
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
@@ -202,7 +243,7 @@ func (c *Client) Ping() error {
 func (c *Client) Replace(item *memcache.Item) error {
 	s := c.startSpan(internal.OperationReplace, item.Key)
 	err := c.Client.Replace(item)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
@@ -210,7 +251,7 @@ func (c *Client) Replace(item *memcache.Item) error {
 func (c *Client) Set(item *memcache.Item) error {
 	s := c.startSpan(internal.OperationSet, item.Key)
 	err := c.Client.Set(item)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
@@ -218,6 +259,6 @@ func (c *Client) Set(item *memcache.Item) error {
 func (c *Client) Touch(key string, seconds int32) error {
 	s := c.startSpan(internal.OperationTouch, key)
 	err := c.Client.Touch(key, seconds)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
@@ -28,4 +28,5 @@ func TestNewClientWithTracing(t *testing.T) {
 
 	assert.NotNil(t, c.Client)
 	assert.NotNil(t, c.tracer)
+	assert.NotNil(t, c.instruments)
 }
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmemcache
+
+import (
+	"log"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+type instruments struct {
+	// latency is the duration of client operations.
+	latency metric.Int64Histogram
+}
+
+// newInstruments will create instruments using the given meter.
+func newInstruments(meter metric.Meter) *instruments {
+	instruments := &instruments{}
+	var err error
+
+	if instruments.latency, err = meter.NewInt64Histogram(
+		"db.memcached.latency",
+		metric.WithDescription("Duration of client operations in milliseconds"),
+		metric.WithUnit(unit.Milliseconds),
+	); err != nil {
+		log.Printf("failed to create latency instrument, %v", err)
+	}
+
+	return instruments
+}
@@ -38,6 +38,9 @@ const (
 	MamcacheDBSystemValue = "memcached"
 
 	MemcacheDBItemKeyName attribute.Key = "db.memcached.item"
+
+	MemcacheDBCacheHitName  attribute.Key = "db.memcached.hit"
+	MemcacheDBCacheHitsName attribute.Key = "db.memcached.hits"
 )
 
 func MemcacheDBSystem() attribute.KeyValue {
@@ -55,3 +58,11 @@ func MemcacheDBItemKeys(itemKeys ...string) attribute.KeyValue {
 
 	return MemcacheDBItemKeyName.String(itemKeys[0])
 }
+
+func MemcacheDBCacheHit(hit bool) attribute.KeyValue {
+	return MemcacheDBCacheHitName.Bool(hit)
+}
+
+func MemcacheDBCacheHits(hits int) attribute.KeyValue {
+	return MemcacheDBCacheHitsName.Int(hits)
+}
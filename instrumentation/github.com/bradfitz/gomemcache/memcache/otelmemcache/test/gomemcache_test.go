@@ -25,9 +25,12 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache/internal"
 	"go.opentelemetry.io/contrib/internal/util"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric/metrictest"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
@@ -69,19 +72,69 @@ func TestOperationWithCacheMissError(t *testing.T) {
 	assert.Len(t, spans, 1)
 	assert.Equal(t, oteltrace.SpanKindClient, spans[0].SpanKind())
 	assert.Equal(t, string(internal.OperationGet), spans[0].Name())
-	assert.Len(t, spans[0].Attributes(), 3)
+	assert.Len(t, spans[0].Attributes(), 4)
 
 	attrs := spans[0].Attributes()
 	assert.Contains(t, attrs, internal.MemcacheDBSystem())
 	assert.Contains(t, attrs, internal.MemcacheDBOperation(internal.OperationGet))
 	assert.Contains(t, attrs, internal.MemcacheDBItemKeyName.String(key))
+	assert.Contains(t, attrs, internal.MemcacheDBCacheHit(false))
 
 	assert.Equal(t, codes.Error, spans[0].Status().Code)
 	assert.Equal(t, err.Error(), spans[0].Status().Description)
 }
 
+func TestOperationWithCacheHit(t *testing.T) {
+	c, sr := initClientWithSpanRecorder(t)
+
+	mi := &memcache.Item{
+		Key:   "foo",
+		Value: []byte("bar"),
+	}
+	require.NoError(t, c.Set(mi))
+	_, err := c.Get(mi.Key)
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, string(internal.OperationGet), spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), internal.MemcacheDBCacheHit(true))
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestGetMultiCacheHits(t *testing.T) {
+	c, sr := initClientWithSpanRecorder(t)
+
+	require.NoError(t, c.Set(&memcache.Item{Key: "foo", Value: []byte("bar")}))
+	items, err := c.GetMulti([]string{"foo", "baz"})
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Contains(t, spans[1].Attributes(), internal.MemcacheDBItemKeys("foo", "baz"))
+	assert.Contains(t, spans[1].Attributes(), internal.MemcacheDBCacheHits(1))
+}
+
+func TestLatencyMetric(t *testing.T) {
+	mp := metrictest.NewMeterProvider()
+	c, _ := initClientWithSpanRecorder(t, otelmemcache.WithMeterProvider(mp))
+
+	require.NoError(t, c.Set(&memcache.Item{Key: "foo", Value: []byte("bar")}))
+	_, err := c.Get("baz")
+	assert.Error(t, err)
+
+	var operations []string
+	for _, m := range metrictest.AsStructs(mp.MeasurementBatches) {
+		assert.Equal(t, "db.memcached.latency", m.Name)
+		assert.Equal(t, attribute.StringValue(internal.MamcacheDBSystemValue), m.Labels[semconv.DBSystemKey])
+		operations = append(operations, m.Labels[semconv.DBOperationKey].AsString())
+	}
+	assert.Equal(t, []string{string(internal.OperationSet), string(internal.OperationGet)}, operations)
+}
+
 // tests require running memcached instance
-func initClientWithSpanRecorder(t *testing.T) (*otelmemcache.Client, *tracetest.SpanRecorder) {
+func initClientWithSpanRecorder(t *testing.T, opts ...otelmemcache.Option) (*otelmemcache.Client, *tracetest.SpanRecorder) {
 	host, port := "localhost", "11211"
 
 	mc := memcache.New(host + ":" + port)
@@ -90,9 +143,11 @@ func initClientWithSpanRecorder(t *testing.T) (*otelmemcache.Client, *tracetest.
 	sr := tracetest.NewSpanRecorder()
 	c := otelmemcache.NewClientWithTracing(
 		mc,
-		otelmemcache.WithTracerProvider(
-			trace.NewTracerProvider(trace.WithSpanProcessor(sr)),
-		),
+		append([]otelmemcache.Option{
+			otelmemcache.WithTracerProvider(
+				trace.NewTracerProvider(trace.WithSpanProcessor(sr)),
+			),
+		}, opts...)...,
 	)
 
 	return c, sr
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkafka
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Consumer wraps a kafka.Consumer so that consumed messages are traced.
+//
+// A span is started for every message returned by Poll, ReadMessage or the
+// Events channel and is ended when the next message is returned or the
+// consumer is closed.
+type Consumer struct {
+	*kafka.Consumer
+	cfg config
+
+	mu         sync.Mutex
+	prev       trace.Span
+	events     chan kafka.Event
+	eventsOnce sync.Once
+}
+
+// NewConsumer calls kafka.NewConsumer and wraps the resulting Consumer.
+func NewConsumer(conf *kafka.ConfigMap, opts ...Option) (*Consumer, error) {
+	c, err := kafka.NewConsumer(conf)
+	if err != nil {
+		return nil, err
+	}
+	return WrapConsumer(c, opts...), nil
+}
+
+// WrapConsumer wraps a kafka.Consumer so that any consumed messages are
+// traced.
+func WrapConsumer(c *kafka.Consumer, opts ...Option) *Consumer {
+	return &Consumer{
+		Consumer: c,
+		cfg:      newConfig(opts...),
+	}
+}
+
+// Events returns the Events channel (if enabled) with a span started for
+// every message received on it.
+func (c *Consumer) Events() chan kafka.Event {
+	c.eventsOnce.Do(func() {
+		in := c.Consumer.Events()
+		if in == nil {
+			return
+		}
+		c.events = make(chan kafka.Event)
+		go func() {
+			defer close(c.events)
+			for e := range in {
+				c.trace(e)
+				c.events <- e
+			}
+		}()
+	})
+	return c.events
+}
+
+// Poll the consumer for messages or events. A span is started for a returned
+// message.
+func (c *Consumer) Poll(timeoutMs int) kafka.Event {
+	e := c.Consumer.Poll(timeoutMs)
+	c.trace(e)
+	return e
+}
+
+// ReadMessage polls the consumer for a message. A span is started for a
+// returned message.
+func (c *Consumer) ReadMessage(timeout time.Duration) (*kafka.Message, error) {
+	msg, err := c.Consumer.ReadMessage(timeout)
+	if err != nil {
+		return msg, err
+	}
+	c.trace(msg)
+	return msg, nil
+}
+
+// Close ends the span of the last consumed message and closes the consumer.
+func (c *Consumer) Close() error {
+	c.mu.Lock()
+	c.endPrev()
+	c.mu.Unlock()
+	return c.Consumer.Close()
+}
+
+// trace ends the span of the previously consumed message and, if e is a
+// message, starts a new span for it.
+func (c *Consumer) trace(e kafka.Event) {
+	msg, ok := e.(*kafka.Message)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.endPrev()
+	c.prev = c.startSpan(msg)
+}
+
+func (c *Consumer) endPrev() {
+	if c.prev != nil {
+		c.prev.End()
+		c.prev = nil
+	}
+}
+
+func (c *Consumer) startSpan(msg *kafka.Message) trace.Span {
+	// Extract a span context from message to link.
+	carrier := NewMessageCarrier(msg)
+	parentSpanContext := c.cfg.Propagators.Extract(context.Background(), carrier)
+
+	// Create a span.
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystemKey.String("kafka"),
+		semconv.MessagingDestinationKindTopic,
+		semconv.MessagingOperationReceive,
+		semconv.MessagingMessageIDKey.String(strconv.FormatInt(int64(msg.TopicPartition.Offset), 10)),
+		semconv.MessagingKafkaPartitionKey.Int64(int64(msg.TopicPartition.Partition)),
+	}
+	if topic := msg.TopicPartition.Topic; topic != nil {
+		attrs = append(attrs, semconv.MessagingDestinationKey.String(*topic))
+	}
+	if len(msg.Key) > 0 {
+		attrs = append(attrs, semconv.MessagingKafkaMessageKeyKey.String(string(msg.Key)))
+	}
+	opts := []trace.SpanStartOption{
+		trace.WithAttributes(attrs...),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+	}
+	newCtx, span := c.cfg.Tracer.Start(parentSpanContext, "kafka.consume", opts...)
+
+	// Inject current span context, so consumers can use it to propagate span.
+	c.cfg.Propagators.Inject(newCtx, carrier)
+
+	return span
+}
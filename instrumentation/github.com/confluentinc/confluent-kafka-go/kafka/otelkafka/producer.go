@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkafka
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Producer wraps a kafka.Producer so that produced messages are traced.
+//
+// A span is started for every produced message and ended once the delivery
+// report for that message is received, either on the delivery channel passed
+// to Produce or on the Events channel. Delivery reports must therefore be
+// enabled ("go.delivery.reports", the default) and consumed by the user.
+type Producer struct {
+	*kafka.Producer
+	cfg config
+
+	produceChannel chan *kafka.Message
+	produceDone    chan struct{}
+	events         chan kafka.Event
+}
+
+// NewProducer calls kafka.NewProducer and wraps the resulting Producer.
+func NewProducer(conf *kafka.ConfigMap, opts ...Option) (*Producer, error) {
+	p, err := kafka.NewProducer(conf)
+	if err != nil {
+		return nil, err
+	}
+	return WrapProducer(p, opts...), nil
+}
+
+// WrapProducer wraps a kafka.Producer so that any produced messages are
+// traced.
+func WrapProducer(p *kafka.Producer, opts ...Option) *Producer {
+	wrapped := &Producer{
+		Producer:       p,
+		cfg:            newConfig(opts...),
+		produceChannel: make(chan *kafka.Message),
+		produceDone:    make(chan struct{}),
+		events:         make(chan kafka.Event),
+	}
+	go wrapped.traceProduceChannel(p.ProduceChannel())
+	go wrapped.traceEvents(p.Events())
+	return wrapped
+}
+
+// traceProduceChannel starts a span for every message sent on the wrapped
+// produce channel before forwarding it to the underlying producer.
+func (p *Producer) traceProduceChannel(out chan *kafka.Message) {
+	defer close(p.produceDone)
+	for msg := range p.produceChannel {
+		p.startSpan(msg)
+		out <- msg
+	}
+}
+
+// traceEvents ends the span of any delivery report received on the
+// underlying events channel before forwarding the event to the user.
+func (p *Producer) traceEvents(in chan kafka.Event) {
+	defer close(p.events)
+	for e := range in {
+		if msg, ok := e.(*kafka.Message); ok {
+			p.finishSpan(msg)
+		}
+		p.events <- e
+	}
+}
+
+// Events returns the Events channel (read).
+func (p *Producer) Events() chan kafka.Event {
+	return p.events
+}
+
+// ProduceChannel returns the traced produce channel (write).
+func (p *Producer) ProduceChannel() chan *kafka.Message {
+	return p.produceChannel
+}
+
+// Produce produces a single message asynchronously and starts a span for it.
+// The span is ended when the delivery report for the message is received on
+// deliveryChan, or on the Events channel if deliveryChan is nil.
+func (p *Producer) Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error {
+	span := p.startSpan(msg)
+
+	var intercept chan kafka.Event
+	if deliveryChan != nil {
+		intercept = make(chan kafka.Event, 1)
+		go func() {
+			e, ok := <-intercept
+			if !ok {
+				return
+			}
+			if m, ok := e.(*kafka.Message); ok {
+				p.finishSpan(m)
+			}
+			deliveryChan <- e
+		}()
+	}
+
+	err := p.Producer.Produce(msg, intercept)
+	if err != nil {
+		// No delivery report will be sent for this message.
+		msg.Opaque = restoreOpaque(msg.Opaque)
+		endProducerSpan(span, msg, err)
+		if intercept != nil {
+			close(intercept)
+		}
+	}
+	return err
+}
+
+// Close closes the producer after all messages sent on the produce channel
+// have been handed to the underlying producer.
+func (p *Producer) Close() {
+	close(p.produceChannel)
+	<-p.produceDone
+	p.Producer.Close()
+}
+
+// tracedOpaque holds the span of a message between the time it is produced
+// and the time its delivery report is received.
+type tracedOpaque struct {
+	span   trace.Span
+	opaque interface{}
+}
+
+func restoreOpaque(opaque interface{}) interface{} {
+	if t, ok := opaque.(*tracedOpaque); ok {
+		return t.opaque
+	}
+	return opaque
+}
+
+func (p *Producer) startSpan(msg *kafka.Message) trace.Span {
+	// If there's a span context in the message, use that as the parent context.
+	carrier := NewMessageCarrier(msg)
+	ctx := p.cfg.Propagators.Extract(context.Background(), carrier)
+
+	// Create a span.
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystemKey.String("kafka"),
+		semconv.MessagingDestinationKindTopic,
+	}
+	if topic := msg.TopicPartition.Topic; topic != nil {
+		attrs = append(attrs, semconv.MessagingDestinationKey.String(*topic))
+	}
+	if len(msg.Key) > 0 {
+		attrs = append(attrs, semconv.MessagingKafkaMessageKeyKey.String(string(msg.Key)))
+	}
+	opts := []trace.SpanStartOption{
+		trace.WithAttributes(attrs...),
+		trace.WithSpanKind(trace.SpanKindProducer),
+	}
+	ctx, span := p.cfg.Tracer.Start(ctx, "kafka.produce", opts...)
+
+	// Inject current span context, so consumers can use it to propagate span.
+	p.cfg.Propagators.Inject(ctx, carrier)
+
+	// Keep track of the span until the delivery report is received.
+	msg.Opaque = &tracedOpaque{span: span, opaque: msg.Opaque}
+
+	return span
+}
+
+// finishSpan ends the span associated with the delivery report msg, if any,
+// and restores the original opaque value of the message.
+func (p *Producer) finishSpan(msg *kafka.Message) {
+	t, ok := msg.Opaque.(*tracedOpaque)
+	if !ok {
+		return
+	}
+	msg.Opaque = t.opaque
+	endProducerSpan(t.span, msg, msg.TopicPartition.Error)
+}
+
+func endProducerSpan(span trace.Span, msg *kafka.Message, err error) {
+	if err == nil {
+		span.SetAttributes(
+			semconv.MessagingMessageIDKey.String(strconv.FormatInt(int64(msg.TopicPartition.Offset), 10)),
+			semconv.MessagingKafkaPartitionKey.Int64(int64(msg.TopicPartition.Partition)),
+		)
+	} else {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
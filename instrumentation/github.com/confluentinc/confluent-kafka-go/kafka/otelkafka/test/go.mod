@@ -0,0 +1,16 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/confluentinc/confluent-kafka-go/kafka/otelkafka/test
+
+go 1.15
+
+require (
+	github.com/confluentinc/confluent-kafka-go v1.9.2
+	github.com/stretchr/testify v1.7.1
+	go.opentelemetry.io/contrib/instrumentation/github.com/confluentinc/confluent-kafka-go/kafka/otelkafka v0.26.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
+
+replace go.opentelemetry.io/contrib/instrumentation/github.com/confluentinc/confluent-kafka-go/kafka/otelkafka => ../
+
+replace go.opentelemetry.io/contrib => ../../../../../../../
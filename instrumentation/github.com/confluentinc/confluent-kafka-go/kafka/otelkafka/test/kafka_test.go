@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/confluentinc/confluent-kafka-go/kafka/otelkafka"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const topic = "gotest"
+
+func newCluster(t *testing.T) *kafka.MockCluster {
+	mc, err := kafka.NewMockCluster(1)
+	require.NoError(t, err)
+	t.Cleanup(mc.Close)
+	return mc
+}
+
+func newProducer(t *testing.T, mc *kafka.MockCluster, opts ...otelkafka.Option) *otelkafka.Producer {
+	p, err := otelkafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers": mc.BootstrapServers(),
+	}, opts...)
+	require.NoError(t, err)
+	return p
+}
+
+func newConsumer(t *testing.T, mc *kafka.MockCluster, opts ...otelkafka.Option) *otelkafka.Consumer {
+	c, err := otelkafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers": mc.BootstrapServers(),
+		"group.id":          "gotest",
+		"auto.offset.reset": "earliest",
+	}, opts...)
+	require.NoError(t, err)
+	require.NoError(t, c.Subscribe(topic, nil))
+	return c
+}
+
+func newMessage(value string) *kafka.Message {
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{
+			Topic:     func(s string) *string { return &s }(topic),
+			Partition: 0,
+		},
+		Key:   []byte("key"),
+		Value: []byte(value),
+	}
+}
+
+func spanByName(spans []sdktrace.ReadOnlySpan, name string) []sdktrace.ReadOnlySpan {
+	var out []sdktrace.ReadOnlySpan
+	for _, s := range spans {
+		if s.Name() == name {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func TestProducer(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	propagators := propagation.TraceContext{}
+	mc := newCluster(t)
+
+	p := newProducer(t, mc, otelkafka.WithTracerProvider(provider), otelkafka.WithPropagators(propagators))
+	defer p.Close()
+
+	// Produce a message with a span context and a delivery channel.
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	msg := newMessage("delivery channel")
+	msg.Opaque = "opaque"
+	propagators.Inject(ctx, otelkafka.NewMessageCarrier(msg))
+
+	deliveryChan := make(chan kafka.Event, 1)
+	require.NoError(t, p.Produce(msg, deliveryChan))
+	e := <-deliveryChan
+	m, ok := e.(*kafka.Message)
+	require.True(t, ok)
+	require.NoError(t, m.TopicPartition.Error)
+	assert.Equal(t, "opaque", m.Opaque, "original opaque value restored")
+	parent.End()
+
+	// Produce a message whose delivery report is sent on the Events channel.
+	require.NoError(t, p.Produce(newMessage("events"), nil))
+	e = <-p.Events()
+	m, ok = e.(*kafka.Message)
+	require.True(t, ok)
+	require.NoError(t, m.TopicPartition.Error)
+	assert.Nil(t, m.Opaque)
+
+	// Produce a message on the produce channel.
+	p.ProduceChannel() <- newMessage("produce channel")
+	e = <-p.Events()
+	m, ok = e.(*kafka.Message)
+	require.True(t, ok)
+	require.NoError(t, m.TopicPartition.Error)
+
+	spans := spanByName(sr.Ended(), "kafka.produce")
+	require.Len(t, spans, 3)
+	for i, s := range spans {
+		assert.Equal(t, trace.SpanKindProducer, s.SpanKind())
+		assert.Equal(t, codes.Unset, s.Status().Code)
+
+		attrs := s.Attributes()
+		assert.Contains(t, attrs, semconv.MessagingSystemKey.String("kafka"))
+		assert.Contains(t, attrs, semconv.MessagingDestinationKindTopic)
+		assert.Contains(t, attrs, semconv.MessagingDestinationKey.String(topic))
+		assert.Contains(t, attrs, semconv.MessagingKafkaMessageKeyKey.String("key"))
+		assert.Contains(t, attrs, semconv.MessagingKafkaPartitionKey.Int64(0))
+		assert.Contains(t, attrs, semconv.MessagingMessageIDKey.String(strconv.Itoa(i)))
+	}
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.False(t, spans[1].Parent().IsValid())
+}
+
+func TestProducerError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	mc := newCluster(t)
+
+	p := newProducer(t, mc, otelkafka.WithTracerProvider(provider))
+	defer p.Close()
+
+	// A message without a topic is rejected immediately.
+	msg := &kafka.Message{Value: []byte("no topic"), Opaque: "opaque"}
+	require.Error(t, p.Produce(msg, nil))
+	assert.Equal(t, "opaque", msg.Opaque)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "kafka.produce", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestConsumer(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	propagators := propagation.TraceContext{}
+	opts := []otelkafka.Option{
+		otelkafka.WithTracerProvider(provider),
+		otelkafka.WithPropagators(propagators),
+	}
+	mc := newCluster(t)
+
+	p := newProducer(t, mc, opts...)
+	for _, v := range []string{"first", "second"} {
+		require.NoError(t, p.Produce(newMessage(v), nil))
+		<-p.Events()
+	}
+	p.Close()
+
+	c := newConsumer(t, mc, opts...)
+
+	var msgs []*kafka.Message
+	for len(msgs) < 2 {
+		msg, err := c.ReadMessage(10 * time.Second)
+		require.NoError(t, err)
+		msgs = append(msgs, msg)
+	}
+	assert.Len(t, spanByName(sr.Ended(), "kafka.consume"), 1, "span of the first message ended by the second read")
+	require.NoError(t, c.Close())
+
+	produced := spanByName(sr.Ended(), "kafka.produce")
+	consumed := spanByName(sr.Ended(), "kafka.consume")
+	require.Len(t, produced, 2)
+	require.Len(t, consumed, 2)
+	for i, s := range consumed {
+		assert.Equal(t, trace.SpanKindConsumer, s.SpanKind())
+		assert.Equal(t, produced[i].SpanContext().SpanID(), s.Parent().SpanID())
+		assert.Equal(t, produced[i].SpanContext().TraceID(), s.SpanContext().TraceID())
+
+		attrs := s.Attributes()
+		assert.Contains(t, attrs, semconv.MessagingSystemKey.String("kafka"))
+		assert.Contains(t, attrs, semconv.MessagingDestinationKindTopic)
+		assert.Contains(t, attrs, semconv.MessagingDestinationKey.String(topic))
+		assert.Contains(t, attrs, semconv.MessagingOperationReceive)
+		assert.Contains(t, attrs, semconv.MessagingKafkaPartitionKey.Int64(0))
+		assert.Contains(t, attrs, semconv.MessagingMessageIDKey.String(msgs[i].TopicPartition.Offset.String()))
+
+		// The consumer span context is injected into the message.
+		sc := trace.SpanContextFromContext(propagators.Extract(context.Background(), otelkafka.NewMessageCarrier(msgs[i])))
+		assert.Equal(t, s.SpanContext().SpanID(), sc.SpanID())
+	}
+}
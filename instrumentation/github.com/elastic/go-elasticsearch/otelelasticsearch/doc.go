@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelelasticsearch instruments the github.com/elastic/go-elasticsearch
+// client.
+//
+// The instrumentation is provided by an http.RoundTripper that is set as the
+// Transport of the client configuration:
+//
+//	es, err := elasticsearch.NewClient(elasticsearch.Config{
+//		Transport: otelelasticsearch.NewTransport(nil),
+//	})
+//
+// A span is created for every request sent by the client. The span is named
+// after the API endpoint of the request, e.g. "search" or "cluster.health".
+package otelelasticsearch // import "go.opentelemetry.io/contrib/instrumentation/github.com/elastic/go-elasticsearch/otelelasticsearch"
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelelasticsearch
+
+import (
+	"net/http"
+	"strings"
+)
+
+// namespaces are the path prefixes of the APIs that are grouped by the next
+// path segment, e.g. "/_cluster/health" is the "cluster.health" endpoint.
+var namespaces = map[string]bool{
+	"_cat":      true,
+	"_cluster":  true,
+	"_ilm":      true,
+	"_ingest":   true,
+	"_license":  true,
+	"_nodes":    true,
+	"_security": true,
+	"_snapshot": true,
+	"_tasks":    true,
+}
+
+// endpoint returns the name of the API endpoint of a request with the given
+// method and URL path.
+func endpoint(method, path string) string {
+	var segments []string
+	for _, s := range strings.Split(path, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+
+	for i, s := range segments {
+		if !strings.HasPrefix(s, "_") {
+			continue
+		}
+
+		switch s {
+		case "_doc":
+			return documentEndpoint(method)
+		case "_source":
+			return "get_source"
+		}
+
+		name := strings.TrimPrefix(s, "_")
+		if namespaces[s] && i+1 < len(segments) && !strings.HasPrefix(segments[i+1], "_") {
+			name += "." + segments[i+1]
+		}
+		return name
+	}
+
+	switch len(segments) {
+	case 0:
+		if method == http.MethodHead {
+			return "ping"
+		}
+		return "info"
+	case 1:
+		// Index APIs: "/{index}".
+		return "indices." + indexEndpoint(method)
+	}
+	return strings.ToLower(method)
+}
+
+func documentEndpoint(method string) string {
+	switch method {
+	case http.MethodGet:
+		return "get"
+	case http.MethodHead:
+		return "exists"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "index"
+	}
+}
+
+func indexEndpoint(method string) string {
+	switch method {
+	case http.MethodPut:
+		return "create"
+	case http.MethodHead:
+		return "exists"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "get"
+	}
+}
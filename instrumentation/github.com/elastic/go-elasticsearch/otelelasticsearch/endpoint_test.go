@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelelasticsearch
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpoint(t *testing.T) {
+	testCases := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{http.MethodGet, "/", "info"},
+		{http.MethodHead, "/", "ping"},
+		{http.MethodPut, "/test", "indices.create"},
+		{http.MethodHead, "/test", "indices.exists"},
+		{http.MethodDelete, "/test/", "indices.delete"},
+		{http.MethodGet, "/test", "indices.get"},
+		{http.MethodPut, "/test/_doc/1", "index"},
+		{http.MethodPost, "/test/_doc", "index"},
+		{http.MethodGet, "/test/_doc/1", "get"},
+		{http.MethodHead, "/test/_doc/1", "exists"},
+		{http.MethodDelete, "/test/_doc/1", "delete"},
+		{http.MethodGet, "/test/_source/1", "get_source"},
+		{http.MethodPut, "/test/_create/1", "create"},
+		{http.MethodPost, "/test/_update/1", "update"},
+		{http.MethodPost, "/test/_search", "search"},
+		{http.MethodGet, "/_search", "search"},
+		{http.MethodPost, "/_bulk", "bulk"},
+		{http.MethodPost, "/test/_delete_by_query", "delete_by_query"},
+		{http.MethodGet, "/_cluster/health", "cluster.health"},
+		{http.MethodGet, "/_cluster/health/test", "cluster.health"},
+		{http.MethodGet, "/_cat/indices/test", "cat.indices"},
+		{http.MethodGet, "/test/_mapping", "mapping"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, endpoint(tc.method, tc.path), "%s %s", tc.method, tc.path)
+	}
+}
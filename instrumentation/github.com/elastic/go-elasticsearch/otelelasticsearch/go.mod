@@ -0,0 +1,12 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/elastic/go-elasticsearch/otelelasticsearch
+
+go 1.15
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib v1.1.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
+
+replace go.opentelemetry.io/contrib => ../../../../..
@@ -0,0 +1,16 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/elastic/go-elasticsearch/otelelasticsearch/test
+
+go 1.15
+
+require (
+	github.com/elastic/go-elasticsearch/v7 v7.15.1
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/instrumentation/github.com/elastic/go-elasticsearch/otelelasticsearch v0.26.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
+
+replace go.opentelemetry.io/contrib/instrumentation/github.com/elastic/go-elasticsearch/otelelasticsearch => ../
+
+replace go.opentelemetry.io/contrib => ../../../../../../
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/elastic/go-elasticsearch/otelelasticsearch"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const clusterName = "test-cluster"
+
+// newServer returns a server replying to the product check of the client
+// and with status to any other request.
+func newServer(t *testing.T, status int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("X-Found-Handling-Cluster", clusterName)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`{"version":{"number":"7.15.1","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+			return
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newClient(t *testing.T, srv *httptest.Server, opts ...otelelasticsearch.Option) *elasticsearch.Client {
+	es, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: []string{srv.URL},
+		Transport: otelelasticsearch.NewTransport(nil, opts...),
+	})
+	require.NoError(t, err)
+	return es
+}
+
+func TestTransport(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	srv := newServer(t, http.StatusOK)
+	es := newClient(t, srv, otelelasticsearch.WithTracerProvider(provider))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	res, err := es.Search(
+		es.Search.WithContext(ctx),
+		es.Search.WithIndex("test"),
+		es.Search.WithBody(strings.NewReader(`{"query":{"match_all":{}}}`)),
+	)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	parent.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "info", spans[0].Name(), "product check")
+
+	span := spans[1]
+	assert.Equal(t, "search", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+
+	attrs := span.Attributes()
+	assert.Contains(t, attrs, semconv.DBSystemElasticsearch)
+	assert.Contains(t, attrs, semconv.DBOperationKey.String("search"))
+	assert.Contains(t, attrs, semconv.HTTPMethodKey.String(http.MethodPost))
+	assert.Contains(t, attrs, semconv.HTTPStatusCodeKey.Int(http.StatusOK))
+	assert.Contains(t, attrs, semconv.NetPeerNameKey.String("127.0.0.1"))
+	assert.Contains(t, attrs, attribute.String("db.elasticsearch.cluster.name", clusterName))
+}
+
+func TestTransportClusterName(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	srv := newServer(t, http.StatusOK)
+	es := newClient(t, srv,
+		otelelasticsearch.WithTracerProvider(provider),
+		otelelasticsearch.WithClusterName("configured"),
+	)
+
+	res, err := es.Cluster.Health()
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "cluster.health", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), attribute.String("db.elasticsearch.cluster.name", "configured"))
+	assert.NotContains(t, spans[1].Attributes(), attribute.String("db.elasticsearch.cluster.name", clusterName))
+}
+
+func TestTransportErrorStatus(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	srv := newServer(t, http.StatusNotFound)
+	es := newClient(t, srv, otelelasticsearch.WithTracerProvider(provider))
+
+	res, err := es.Get("test", "1")
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "get", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusNotFound))
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+type errorTransport struct{}
+
+func (errorTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestTransportError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	transport := otelelasticsearch.NewTransport(errorTransport{}, otelelasticsearch.WithTracerProvider(provider))
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost:9200/_bulk", nil)
+	_, err := transport.RoundTrip(req)
+	assert.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "bulk", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "connection refused", spans[0].Status().Description)
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelelasticsearch
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/contrib/internal/httpconv"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// clusterNameKey is the attribute key of the name of the Elasticsearch
+	// cluster.
+	clusterNameKey = attribute.Key("db.elasticsearch.cluster.name")
+
+	// clusterHeader is the response header reporting the name of the
+	// cluster that handled the request.
+	clusterHeader = "X-Found-Handling-Cluster"
+)
+
+// Transport implements the http.RoundTripper interface and wraps the
+// requests sent by an Elasticsearch client with a span.
+type Transport struct {
+	rt  http.RoundTripper
+	cfg config
+}
+
+var _ http.RoundTripper = &Transport{}
+
+// NewTransport wraps the provided http.RoundTripper with one that starts a
+// span for every request sent to Elasticsearch.
+//
+// If the provided http.RoundTripper is nil, http.DefaultTransport will be used
+// as the base http.RoundTripper.
+func NewTransport(base http.RoundTripper, opts ...Option) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		rt:  base,
+		cfg: newConfig(opts...),
+	}
+}
+
+// RoundTrip creates a span named after the API endpoint of the request before
+// handing the request to the configured base RoundTripper. The span ends when
+// the response headers are received.
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	name := endpoint(r.Method, r.URL.Path)
+
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemElasticsearch,
+		semconv.DBOperationKey.String(name),
+	}
+	attrs = append(attrs, httpconv.ClientRequest(r)...)
+	attrs = append(attrs, peerAttributes(r)...)
+	if t.cfg.ClusterName != "" {
+		attrs = append(attrs, clusterNameKey.String(t.cfg.ClusterName))
+	}
+	opts := []trace.SpanStartOption{
+		trace.WithAttributes(attrs...),
+		trace.WithSpanKind(trace.SpanKindClient),
+	}
+	ctx, span := t.cfg.Tracer.Start(r.Context(), name, opts...)
+	defer span.End()
+
+	res, err := t.rt.RoundTrip(r.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return res, err
+	}
+
+	if t.cfg.ClusterName == "" {
+		if cluster := res.Header.Get(clusterHeader); cluster != "" {
+			span.SetAttributes(clusterNameKey.String(cluster))
+		}
+	}
+	span.SetAttributes(httpconv.StatusCode(res.StatusCode)...)
+	span.SetStatus(httpconv.SpanStatus(res.StatusCode))
+
+	return res, err
+}
+
+// peerAttributes returns the attributes of the Elasticsearch node the request
+// is sent to.
+func peerAttributes(r *http.Request) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.NetPeerNameKey.String(r.URL.Hostname())}
+	if port, err := strconv.Atoi(r.URL.Port()); err == nil {
+		attrs = append(attrs, semconv.NetPeerPortKey.Int(port))
+	}
+	return attrs
+}
@@ -6,6 +6,7 @@ replace (
 	go.opentelemetry.io/contrib => ../../../../../../
 	go.opentelemetry.io/contrib/instrumentation/github.com/emicklei/go-restful/otelrestful => ../
 	go.opentelemetry.io/contrib/propagators/b3 => ../../../../../../propagators/b3
+	go.opentelemetry.io/contrib/propagators/propagatortest => ../../../../../../propagators/propagatortest
 )
 
 require (
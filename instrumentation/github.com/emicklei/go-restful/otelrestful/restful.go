@@ -17,9 +17,9 @@ package otelrestful
 import (
 	"github.com/emicklei/go-restful/v3"
 
+	"go.opentelemetry.io/contrib/internal/httpconv"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
-	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
@@ -52,9 +52,7 @@ func OTelFilter(service string, opts ...Option) restful.FilterFunction {
 		spanName := route
 
 		ctx, span := tracer.Start(ctx, spanName,
-			oteltrace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", r)...),
-			oteltrace.WithAttributes(semconv.EndUserAttributesFromHTTPRequest(r)...),
-			oteltrace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(service, route, r)...),
+			oteltrace.WithAttributes(httpconv.ServerRequest(service, route, r)...),
 			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 		)
 		defer span.End()
@@ -64,8 +62,8 @@ func OTelFilter(service string, opts ...Option) restful.FilterFunction {
 
 		chain.ProcessFilter(req, resp)
 
-		attrs := semconv.HTTPAttributesFromHTTPStatusCode(resp.StatusCode())
-		spanStatus, spanMessage := semconv.SpanStatusFromHTTPStatusCode(resp.StatusCode())
+		attrs := httpconv.StatusCode(resp.StatusCode())
+		spanStatus, spanMessage := httpconv.SpanStatus(resp.StatusCode())
 		span.SetAttributes(attrs...)
 		span.SetStatus(spanStatus, spanMessage)
 	}
@@ -5,7 +5,9 @@ go 1.15
 replace (
 	go.opentelemetry.io/contrib => ../../../../../../
 	go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin => ../
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../../../requestfilter
 	go.opentelemetry.io/contrib/propagators/b3 => ../../../../../../propagators/b3
+	go.opentelemetry.io/contrib/propagators/propagatortest => ../../../../../../propagators/propagatortest
 )
 
 require (
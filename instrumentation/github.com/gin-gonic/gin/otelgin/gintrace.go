@@ -21,12 +21,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"go.opentelemetry.io/contrib/instrumentation/requestfilter"
+	"go.opentelemetry.io/contrib/internal/httpconv"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
-	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
@@ -55,15 +56,27 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 	}
 	return func(c *gin.Context) {
 		c.Set(tracerKey, tracer)
+		if len(cfg.Filters) > 0 {
+			req := requestfilter.FromHTTP(c.Request)
+			for _, f := range cfg.Filters {
+				if !f(req) {
+					// Serve the request without tracing it
+					c.Next()
+					return
+				}
+			}
+		}
 		savedCtx := c.Request.Context()
 		defer func() {
 			c.Request = c.Request.WithContext(savedCtx)
 		}()
 		ctx := cfg.Propagators.Extract(savedCtx, propagation.HeaderCarrier(c.Request.Header))
+		attrs := httpconv.ServerRequest(service, c.FullPath(), c.Request)
+		if cfg.Sanitizer != nil {
+			attrs = cfg.Sanitizer(attrs)
+		}
 		opts := []oteltrace.SpanStartOption{
-			oteltrace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", c.Request)...),
-			oteltrace.WithAttributes(semconv.EndUserAttributesFromHTTPRequest(c.Request)...),
-			oteltrace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(service, c.FullPath(), c.Request)...),
+			oteltrace.WithAttributes(attrs...),
 			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 		}
 		spanName := c.FullPath()
@@ -80,8 +93,8 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 		c.Next()
 
 		status := c.Writer.Status()
-		attrs := semconv.HTTPAttributesFromHTTPStatusCode(status)
-		spanStatus, spanMessage := semconv.SpanStatusFromHTTPStatusCode(status)
+		attrs = httpconv.StatusCode(status)
+		spanStatus, spanMessage := httpconv.SpanStatus(status)
 		span.SetAttributes(attrs...)
 		span.SetStatus(spanStatus, spanMessage)
 		if len(c.Errors) > 0 {
@@ -4,12 +4,16 @@ go 1.15
 
 replace (
 	go.opentelemetry.io/contrib => ../../../../../
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../../requestfilter
 	go.opentelemetry.io/contrib/propagators/b3 => ../../../../../propagators/b3
+	go.opentelemetry.io/contrib/propagators/propagatortest => ../../../../../propagators/propagatortest
 )
 
 require (
 	github.com/gin-gonic/gin v1.7.4
 	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib v1.1.0
+	go.opentelemetry.io/contrib/instrumentation/requestfilter v0.26.0
 	go.opentelemetry.io/contrib/propagators/b3 v1.1.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/trace v1.1.0
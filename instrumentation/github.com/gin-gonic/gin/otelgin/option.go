@@ -17,6 +17,7 @@
 package otelgin
 
 import (
+	"go.opentelemetry.io/contrib/instrumentation/requestfilter"
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
@@ -24,6 +25,8 @@ import (
 type config struct {
 	TracerProvider oteltrace.TracerProvider
 	Propagators    propagation.TextMapPropagator
+	Filters        []requestfilter.Filter
+	Sanitizer      requestfilter.Sanitizer
 }
 
 // Option specifies instrumentation configuration options.
@@ -57,3 +60,23 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 		}
 	})
 }
+
+// WithRequestFilter adds a filter shared with the other instrumentations to
+// the filters of the middleware. The requests are only traced if all the
+// filters return true for them.
+func WithRequestFilter(f requestfilter.Filter) Option {
+	return optionFunc(func(cfg *config) {
+		if f != nil {
+			cfg.Filters = append(cfg.Filters, f)
+		}
+	})
+}
+
+// WithRequestSanitizer sets the sanitizer applied to the attributes of the
+// requests before they are recorded, e.g. to drop the query strings or
+// redact the values read from the headers.
+func WithRequestSanitizer(s requestfilter.Sanitizer) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.Sanitizer = s
+	})
+}
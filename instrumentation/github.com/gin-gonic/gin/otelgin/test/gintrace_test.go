@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/requestfilter"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -104,6 +105,30 @@ func TestTrace200(t *testing.T) {
 	assert.Contains(t, attr, attribute.String("http.route", "/user/:id"))
 }
 
+func TestRequestFilterAndSanitizer(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	router := gin.New()
+	router.Use(otelgin.Middleware("foobar",
+		otelgin.WithTracerProvider(provider),
+		otelgin.WithRequestFilter(requestfilter.Not(requestfilter.HealthCheck())),
+		otelgin.WithRequestSanitizer(requestfilter.DropQuery()),
+	))
+	router.GET("/healthz", func(c *gin.Context) {})
+	router.GET("/user/:id", func(c *gin.Context) {})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/user/123?token=secret", nil))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "/user/:id", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("http.target", "/user/123"))
+}
+
 func TestError(t *testing.T) {
 	sr := tracetest.NewSpanRecorder()
 	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
@@ -19,15 +19,26 @@ package otelkit
 import (
 	"context"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
-// config holds the options for tracing an endpoint.
+// config holds the options for tracing an endpoint or a transport.
 type config struct {
 	// TracerProvider provides access to instrumentation Tracers.
 	TracerProvider trace.TracerProvider
 
+	// MeterProvider provides access to instrumentation Meters.
+	MeterProvider metric.MeterProvider
+
+	// Propagators is used by the transport middlewares to propagate the
+	// span context in the request headers or metadata.
+	Propagators propagation.TextMapPropagator
+
 	// IgnoreBusinessError if set to true will not treat a business error
 	// identified through the endpoint.Failer interface as a span error.
 	IgnoreBusinessError bool
@@ -49,7 +60,27 @@ type config struct {
 	GetAttributes func(ctx context.Context) []attribute.KeyValue
 }
 
-// Option configures an EndpointMiddleware.
+// newConfig returns a config with all Options set.
+func newConfig(options ...Option) *config {
+	cfg := &config{}
+	for _, o := range options {
+		o.apply(cfg)
+	}
+
+	if cfg.TracerProvider == nil {
+		cfg.TracerProvider = otel.GetTracerProvider()
+	}
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = global.GetMeterProvider()
+	}
+	if cfg.Propagators == nil {
+		cfg.Propagators = otel.GetTextMapPropagator()
+	}
+
+	return cfg
+}
+
+// Option configures an EndpointMiddleware or a transport middleware.
 type Option interface {
 	apply(*config)
 }
@@ -70,6 +101,27 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(o *config) {
+		if provider != nil {
+			o.MeterProvider = provider
+		}
+	})
+}
+
+// WithPropagators specifies propagators to use by the transport middlewares
+// for extracting and injecting the span context. If none are specified, global
+// ones will be used.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return optionFunc(func(o *config) {
+		if propagators != nil {
+			o.Propagators = propagators
+		}
+	})
+}
+
 // WithIgnoreBusinessError if set to true will not treat a business error
 // identified through the endpoint.Failer interface as a span error.
 func WithIgnoreBusinessError(val bool) Option {
@@ -80,6 +132,7 @@ func WithIgnoreBusinessError(val bool) Option {
 
 // WithOperation sets an operation name for an endpoint.
 // Use this when you register a middleware for each endpoint.
+// The transport middlewares use it as the span name.
 func WithOperation(operation string) Option {
 	return optionFunc(func(o *config) {
 		o.Operation = operation
@@ -14,9 +14,11 @@
 
 // Package otelkit instruments the github.com/go-kit/kit package.
 //
-// Compared to other instrumentation libraries provided by go-kit itself,
-// this package only provides instrumentation for the endpoint layer.
-// For instrumenting the transport layer,
-// look at the instrumentation libraries provided by go.opentelemetry.io/contrib.
+// The endpoint layer is instrumented by EndpointMiddleware, which traces the
+// endpoint and records the number of requests, the number of failed requests
+// and the duration of the requests per endpoint.
+// The HTTP and gRPC transport layers are instrumented by the HTTPServerTrace,
+// HTTPClientTrace, GRPCServerTrace and GRPCClientTrace options of the go-kit
+// transports, which trace the requests and propagate the span context.
 // Learn more about go-kit's layers at https://gokit.io/faq/#architecture-and-design.
 package otelkit // import "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
@@ -18,10 +18,11 @@ package otelkit
 
 import (
 	"context"
+	"time"
 
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/go-kit/kit/endpoint"
@@ -39,21 +40,20 @@ const (
 // This endpoint middleware should be used in combination with a Go kit Transport
 // tracing middleware, generic OpenTelemetry transport middleware or custom before
 // and after transport functions.
+//
+// The number of requests, the number of failed requests and the duration of the
+// requests are recorded per endpoint, identified by its operation name.
 func EndpointMiddleware(options ...Option) endpoint.Middleware {
-	cfg := &config{}
-
-	for _, o := range options {
-		o.apply(cfg)
-	}
-
-	if cfg.TracerProvider == nil {
-		cfg.TracerProvider = otel.GetTracerProvider()
-	}
+	cfg := newConfig(options...)
 
 	tracer := cfg.TracerProvider.Tracer(
 		tracerName,
 		trace.WithInstrumentationVersion(SemVersion()),
 	)
+	inst := newInstruments(cfg.MeterProvider.Meter(
+		tracerName,
+		metric.WithInstrumentationVersion(SemVersion()),
+	))
 
 	return func(next endpoint.Endpoint) endpoint.Endpoint {
 		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
@@ -78,6 +78,11 @@ func EndpointMiddleware(options ...Option) endpoint.Middleware {
 				opts = append(opts, trace.WithAttributes(cfg.GetAttributes(ctx)...))
 			}
 
+			start := time.Now()
+			defer func() {
+				inst.record(ctx, spanName, start, failed(cfg, response, err))
+			}()
+
 			ctx, span := tracer.Start(ctx, spanName, opts...)
 			defer span.End()
 
@@ -127,3 +132,15 @@ func EndpointMiddleware(options ...Option) endpoint.Middleware {
 		}
 	}
 }
+
+// failed returns whether a request with the response and err returned by the
+// endpoint is a failed request.
+func failed(cfg *config, response interface{}, err error) bool {
+	if err != nil {
+		return true
+	}
+	if res, ok := response.(endpoint.Failer); ok && res.Failed() != nil {
+		return !cfg.IgnoreBusinessError
+	}
+	return false
+}
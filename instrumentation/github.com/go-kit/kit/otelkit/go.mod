@@ -4,8 +4,11 @@ go 1.15
 
 require (
 	github.com/go-kit/kit v0.12.0
+	go.opentelemetry.io/contrib v1.1.0
 	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/metric v0.24.0
 	go.opentelemetry.io/otel/trace v1.1.0
+	google.golang.org/grpc v1.40.0
 )
 
 replace go.opentelemetry.io/contrib => ../../../../../
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Based on https://github.com/go-kit/kit/blob/3796a6b25f5c6c545454d3ed7187c4ced258083d/tracing/opencensus/grpc.go
+
+package otelkit
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+)
+
+// metadataCarrier adapts gRPC metadata to the propagation.TextMapCarrier
+// interface.
+type metadataCarrier metadata.MD
+
+var _ propagation.TextMapCarrier = metadataCarrier{}
+
+// Get returns the first value associated with key.
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set sets the value associated with key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys returns the keys of the metadata.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// GRPCClientTrace enables OpenTelemetry tracing of a Go kit gRPC transport
+// client. The span context is injected into the request metadata.
+func GRPCClientTrace(options ...Option) kitgrpc.ClientOption {
+	cfg := newConfig(options...)
+	tracer := cfg.TracerProvider.Tracer(
+		tracerName,
+		trace.WithInstrumentationVersion(SemVersion()),
+	)
+
+	clientBefore := kitgrpc.ClientBefore(
+		func(ctx context.Context, md *metadata.MD) context.Context {
+			name := cfg.Operation
+			method, _ := ctx.Value(kitgrpc.ContextKeyRequestMethod).(string)
+			if name == "" {
+				name = method
+			}
+
+			ctx, _ = tracer.Start(
+				ctx,
+				name,
+				trace.WithAttributes(cfg.Attributes...),
+				trace.WithAttributes(rpcAttributes(method)...),
+				trace.WithSpanKind(trace.SpanKindClient),
+			)
+
+			cfg.Propagators.Inject(ctx, metadataCarrier(*md))
+
+			return ctx
+		},
+	)
+
+	clientFinalizer := kitgrpc.ClientFinalizer(
+		func(ctx context.Context, err error) {
+			endRPCSpan(trace.SpanFromContext(ctx), err)
+		},
+	)
+
+	return func(c *kitgrpc.Client) {
+		clientBefore(c)
+		clientFinalizer(c)
+	}
+}
+
+// GRPCServerTrace enables OpenTelemetry tracing of a Go kit gRPC transport
+// server. The span context is extracted from the request metadata.
+//
+// The span is named after the gRPC method when the kitgrpc.Interceptor is
+// registered with the gRPC server.
+func GRPCServerTrace(options ...Option) kitgrpc.ServerOption {
+	cfg := newConfig(options...)
+	tracer := cfg.TracerProvider.Tracer(
+		tracerName,
+		trace.WithInstrumentationVersion(SemVersion()),
+	)
+
+	serverBefore := kitgrpc.ServerBefore(
+		func(ctx context.Context, md metadata.MD) context.Context {
+			name := cfg.Operation
+			method, _ := ctx.Value(kitgrpc.ContextKeyRequestMethod).(string)
+			if name == "" {
+				name = method
+				if name == "" {
+					// we can't find the gRPC method. probably the
+					// unaryInterceptor was not wired up.
+					name = "unknown grpc method"
+				}
+			}
+
+			ctx = cfg.Propagators.Extract(ctx, metadataCarrier(md))
+			ctx, _ = tracer.Start(
+				ctx,
+				name,
+				trace.WithAttributes(cfg.Attributes...),
+				trace.WithAttributes(rpcAttributes(method)...),
+				trace.WithSpanKind(trace.SpanKindServer),
+			)
+
+			return ctx
+		},
+	)
+
+	serverFinalizer := kitgrpc.ServerFinalizer(
+		func(ctx context.Context, err error) {
+			endRPCSpan(trace.SpanFromContext(ctx), err)
+		},
+	)
+
+	return func(s *kitgrpc.Server) {
+		serverBefore(s)
+		serverFinalizer(s)
+	}
+}
+
+// rpcAttributes returns the RPC attributes of the full gRPC method name,
+// e.g. "/package.Service/Method".
+func rpcAttributes(method string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.RPCSystemKey.String("grpc")}
+	if method == "" {
+		return attrs
+	}
+
+	method = strings.TrimPrefix(method, "/")
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		attrs = append(attrs,
+			semconv.RPCServiceKey.String(method[:i]),
+			semconv.RPCMethodKey.String(method[i+1:]),
+		)
+	}
+	return attrs
+}
+
+// endRPCSpan sets the status of span from err and ends it.
+func endRPCSpan(span trace.Span, err error) {
+	s, _ := status.FromError(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int64(int64(s.Code())))
+	if s.Code() != grpccodes.OK {
+		span.SetStatus(codes.Error, s.Message())
+	}
+	span.End()
+}
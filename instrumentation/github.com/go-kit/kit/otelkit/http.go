@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Based on https://github.com/go-kit/kit/blob/3796a6b25f5c6c545454d3ed7187c4ced258083d/tracing/opencensus/http.go
+
+package otelkit
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/internal/httpconv"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+// HTTPClientTrace enables OpenTelemetry tracing of a Go kit HTTP transport
+// client. The span context is injected into the request headers.
+func HTTPClientTrace(options ...Option) kithttp.ClientOption {
+	cfg := newConfig(options...)
+	tracer := cfg.TracerProvider.Tracer(
+		tracerName,
+		trace.WithInstrumentationVersion(SemVersion()),
+	)
+
+	clientBefore := kithttp.ClientBefore(
+		func(ctx context.Context, req *http.Request) context.Context {
+			name := cfg.Operation
+			if name == "" {
+				name = req.Method + " " + req.URL.Path
+			}
+
+			ctx, _ = tracer.Start(
+				ctx,
+				name,
+				trace.WithAttributes(cfg.Attributes...),
+				trace.WithAttributes(httpconv.ClientRequest(req)...),
+				trace.WithSpanKind(trace.SpanKindClient),
+			)
+
+			cfg.Propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			return ctx
+		},
+	)
+
+	clientAfter := kithttp.ClientAfter(
+		func(ctx context.Context, res *http.Response) context.Context {
+			span := trace.SpanFromContext(ctx)
+			span.SetAttributes(httpconv.StatusCode(res.StatusCode)...)
+			span.SetStatus(httpconv.SpanStatus(res.StatusCode))
+			return ctx
+		},
+	)
+
+	clientFinalizer := kithttp.ClientFinalizer(
+		func(ctx context.Context, err error) {
+			span := trace.SpanFromContext(ctx)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		},
+	)
+
+	return func(c *kithttp.Client) {
+		clientBefore(c)
+		clientAfter(c)
+		clientFinalizer(c)
+	}
+}
+
+// HTTPServerTrace enables OpenTelemetry tracing of a Go kit HTTP transport
+// server. The span context is extracted from the request headers.
+func HTTPServerTrace(options ...Option) kithttp.ServerOption {
+	cfg := newConfig(options...)
+	tracer := cfg.TracerProvider.Tracer(
+		tracerName,
+		trace.WithInstrumentationVersion(SemVersion()),
+	)
+
+	serverBefore := kithttp.ServerBefore(
+		func(ctx context.Context, req *http.Request) context.Context {
+			name := cfg.Operation
+			if name == "" {
+				name = req.Method + " " + req.URL.Path
+			}
+
+			ctx = cfg.Propagators.Extract(ctx, propagation.HeaderCarrier(req.Header))
+			ctx, _ = tracer.Start(
+				ctx,
+				name,
+				trace.WithAttributes(cfg.Attributes...),
+				trace.WithAttributes(httpconv.ServerRequest("", "", req)...),
+				trace.WithSpanKind(trace.SpanKindServer),
+			)
+
+			return ctx
+		},
+	)
+
+	serverFinalizer := kithttp.ServerFinalizer(
+		func(ctx context.Context, code int, _ *http.Request) {
+			span := trace.SpanFromContext(ctx)
+			span.SetAttributes(httpconv.StatusCode(code)...)
+			span.SetStatus(httpconv.SpanStatus(code))
+
+			if rs, ok := ctx.Value(kithttp.ContextKeyResponseSize).(int64); ok {
+				span.SetAttributes(semconv.HTTPResponseContentLengthKey.Int64(rs))
+			}
+
+			span.End()
+		},
+	)
+
+	return func(s *kithttp.Server) {
+		serverBefore(s)
+		serverFinalizer(s)
+	}
+}
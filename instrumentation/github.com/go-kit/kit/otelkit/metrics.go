@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkit
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+// EndpointKey is the attribute key of the operation name of the endpoint
+// the metrics are recorded for.
+const EndpointKey = attribute.Key("gokit.endpoint")
+
+type instruments struct {
+	// requests is the number of requests handled by an endpoint.
+	requests metric.Int64Counter
+
+	// errors is the number of failed requests handled by an endpoint.
+	errors metric.Int64Counter
+
+	// duration is the duration of the requests handled by an endpoint.
+	duration metric.Int64Histogram
+}
+
+// newInstruments will create instruments using the given meter.
+func newInstruments(meter metric.Meter) *instruments {
+	instruments := &instruments{}
+	var err error
+
+	if instruments.requests, err = meter.NewInt64Counter(
+		"gokit.endpoint.requests",
+		metric.WithDescription("Number of requests handled by the endpoint"),
+	); err != nil {
+		log.Printf("failed to create requests instrument, %v", err)
+	}
+
+	if instruments.errors, err = meter.NewInt64Counter(
+		"gokit.endpoint.errors",
+		metric.WithDescription("Number of failed requests handled by the endpoint"),
+	); err != nil {
+		log.Printf("failed to create errors instrument, %v", err)
+	}
+
+	if instruments.duration, err = meter.NewInt64Histogram(
+		"gokit.endpoint.duration",
+		metric.WithDescription("Duration of requests handled by the endpoint in milliseconds"),
+		metric.WithUnit(unit.Milliseconds),
+	); err != nil {
+		log.Printf("failed to create duration instrument, %v", err)
+	}
+
+	return instruments
+}
+
+// record records the metrics of a request to the endpoint named operation.
+func (i *instruments) record(ctx context.Context, operation string, start time.Time, failed bool) {
+	attrs := []attribute.KeyValue{EndpointKey.String(operation)}
+
+	i.requests.Add(ctx, 1, attrs...)
+	if failed {
+		i.errors.Add(ctx, 1, attrs...)
+	}
+	i.duration.Record(ctx, time.Since(start).Milliseconds(), attrs...)
+}
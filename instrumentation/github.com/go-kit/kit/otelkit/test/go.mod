@@ -7,8 +7,10 @@ require (
 	github.com/stretchr/testify v1.7.0
 	go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit v0.26.0
 	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/metric v0.24.0
 	go.opentelemetry.io/otel/sdk v1.1.0
 	go.opentelemetry.io/otel/trace v1.1.0
+	google.golang.org/grpc v1.40.0
 )
 
 replace go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit => ../
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// healthServer serves the gRPC health checking service with a Go kit server.
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	check kitgrpc.Handler
+}
+
+func (s *healthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	_, res, err := s.check.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*grpc_health_v1.HealthCheckResponse), nil
+}
+
+func passThrough(_ context.Context, v interface{}) (interface{}, error) {
+	return v, nil
+}
+
+func newHealthClient(t *testing.T, check kitgrpc.Handler, opts ...otelkit.Option) *kitgrpc.Client {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(grpc.UnaryInterceptor(kitgrpc.Interceptor))
+	grpc_health_v1.RegisterHealthServer(s, &healthServer{check: check})
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return kitgrpc.NewClient(
+		conn,
+		"grpc.health.v1.Health",
+		"Check",
+		passThrough,
+		passThrough,
+		grpc_health_v1.HealthCheckResponse{},
+		otelkit.GRPCClientTrace(opts...),
+	)
+}
+
+func TestGRPCTrace(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	opts := []otelkit.Option{
+		otelkit.WithTracerProvider(provider),
+		otelkit.WithPropagators(propagation.TraceContext{}),
+	}
+
+	var handlerSpan trace.SpanContext
+	check := kitgrpc.NewServer(
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			handlerSpan = trace.SpanContextFromContext(ctx)
+			return &grpc_health_v1.HealthCheckResponse{
+				Status: grpc_health_v1.HealthCheckResponse_SERVING,
+			}, nil
+		},
+		passThrough,
+		passThrough,
+		otelkit.GRPCServerTrace(opts...),
+	)
+	client := newHealthClient(t, check, opts...)
+
+	res, err := client.Endpoint()(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, res.(*grpc_health_v1.HealthCheckResponse).Status)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	serverSpan, clientSpan := spans[0], spans[1]
+
+	for _, span := range spans {
+		assert.Equal(t, "/grpc.health.v1.Health/Check", span.Name())
+		assert.Contains(t, span.Attributes(), semconv.RPCSystemKey.String("grpc"))
+		assert.Contains(t, span.Attributes(), semconv.RPCServiceKey.String("grpc.health.v1.Health"))
+		assert.Contains(t, span.Attributes(), semconv.RPCMethodKey.String("Check"))
+		assert.Contains(t, span.Attributes(), semconv.RPCGRPCStatusCodeOk)
+		assert.Equal(t, codes.Unset, span.Status().Code)
+	}
+	assert.Equal(t, trace.SpanKindClient, clientSpan.SpanKind())
+	assert.Equal(t, trace.SpanKindServer, serverSpan.SpanKind())
+	assert.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+	assert.Equal(t, clientSpan.SpanContext().TraceID(), serverSpan.SpanContext().TraceID())
+	assert.Equal(t, serverSpan.SpanContext(), handlerSpan)
+}
+
+func TestGRPCTraceError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	opts := []otelkit.Option{otelkit.WithTracerProvider(provider)}
+
+	check := kitgrpc.NewServer(
+		func(context.Context, interface{}) (interface{}, error) {
+			return nil, status.Error(grpccodes.NotFound, "unknown service")
+		},
+		passThrough,
+		passThrough,
+		otelkit.GRPCServerTrace(opts...),
+	)
+	client := newHealthClient(t, check, opts...)
+
+	_, err := client.Endpoint()(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	for _, span := range spans {
+		assert.Contains(t, span.Attributes(), semconv.RPCGRPCStatusCodeNotFound)
+		assert.Equal(t, codes.Error, span.Status().Code)
+		assert.Equal(t, "unknown service", span.Status().Description)
+	}
+}
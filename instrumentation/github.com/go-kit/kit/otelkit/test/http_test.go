@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func decodeHTTPRequest(context.Context, *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+func encodeHTTPResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeHTTPRequest(context.Context, *http.Request, interface{}) error {
+	return nil
+}
+
+func decodeHTTPResponse(context.Context, *http.Response) (interface{}, error) {
+	return nil, nil
+}
+
+func TestHTTPTrace(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	opts := []otelkit.Option{
+		otelkit.WithTracerProvider(provider),
+		otelkit.WithPropagators(propagation.TraceContext{}),
+	}
+
+	var handlerSpan trace.SpanContext
+	server := kithttp.NewServer(
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			handlerSpan = trace.SpanContextFromContext(ctx)
+			return "ok", nil
+		},
+		decodeHTTPRequest,
+		encodeHTTPResponse,
+		otelkit.HTTPServerTrace(opts...),
+	)
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/path")
+	require.NoError(t, err)
+	client := kithttp.NewClient(
+		http.MethodGet,
+		u,
+		encodeHTTPRequest,
+		decodeHTTPResponse,
+		otelkit.HTTPClientTrace(opts...),
+	)
+
+	_, err = client.Endpoint()(context.Background(), nil)
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	serverSpan, clientSpan := spans[0], spans[1]
+
+	assert.Equal(t, "GET /path", clientSpan.Name())
+	assert.Equal(t, trace.SpanKindClient, clientSpan.SpanKind())
+	assert.Contains(t, clientSpan.Attributes(), semconv.HTTPMethodKey.String(http.MethodGet))
+	assert.Contains(t, clientSpan.Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusOK))
+	assert.Equal(t, codes.Unset, clientSpan.Status().Code)
+
+	assert.Equal(t, "GET /path", serverSpan.Name())
+	assert.Equal(t, trace.SpanKindServer, serverSpan.SpanKind())
+	assert.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+	assert.Equal(t, clientSpan.SpanContext().TraceID(), serverSpan.SpanContext().TraceID())
+	assert.Contains(t, serverSpan.Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusOK))
+	assert.Contains(t, serverSpan.Attributes(), semconv.HTTPResponseContentLengthKey.Int64(5))
+	assert.Equal(t, serverSpan.SpanContext(), handlerSpan)
+}
+
+func TestHTTPTraceOperationAndError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	u, err := url.Parse("http://127.0.0.1:1/")
+	require.NoError(t, err)
+	client := kithttp.NewClient(
+		http.MethodPost,
+		u,
+		encodeHTTPRequest,
+		decodeHTTPResponse,
+		otelkit.HTTPClientTrace(
+			otelkit.WithTracerProvider(provider),
+			otelkit.WithOperation("operation"),
+		),
+	)
+
+	_, err = client.Endpoint()(context.Background(), nil)
+	require.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "operation", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
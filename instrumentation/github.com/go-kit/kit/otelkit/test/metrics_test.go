@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/metrictest"
+)
+
+func TestEndpointMiddlewareMetrics(t *testing.T) {
+	mp := metrictest.NewMeterProvider()
+
+	mw := otelkit.EndpointMiddleware(
+		otelkit.WithMeterProvider(mp),
+		otelkit.WithOperation("operation"),
+	)
+	_, _ = mw(passEndpoint)(context.Background(), nil)
+	_, _ = mw(passEndpoint)(context.Background(), errors.New("dummy"))
+	_, _ = mw(passEndpoint)(context.Background(), failedResponse{err: errors.New("dummy")})
+
+	ignoreMW := otelkit.EndpointMiddleware(
+		otelkit.WithMeterProvider(mp),
+		otelkit.WithOperation("ignore"),
+		otelkit.WithIgnoreBusinessError(true),
+	)
+	_, _ = ignoreMW(passEndpoint)(context.Background(), failedResponse{err: errors.New("dummy")})
+
+	counts := map[string]map[string]int64{}
+	for _, m := range metrictest.AsStructs(mp.MeasurementBatches) {
+		endpoint := m.Labels[otelkit.EndpointKey].AsString()
+		if counts[m.Name] == nil {
+			counts[m.Name] = map[string]int64{}
+		}
+		switch m.Name {
+		case "gokit.endpoint.requests", "gokit.endpoint.errors":
+			counts[m.Name][endpoint] += m.Number.AsInt64()
+		case "gokit.endpoint.duration":
+			counts[m.Name][endpoint]++
+		default:
+			t.Errorf("unexpected metric %q", m.Name)
+		}
+		assert.Equal(t, attribute.StringValue(endpoint), m.Labels[otelkit.EndpointKey])
+	}
+
+	assert.Equal(t, map[string]map[string]int64{
+		"gokit.endpoint.requests": {"operation": 3, "ignore": 1},
+		"gokit.endpoint.errors":   {"operation": 2},
+		"gokit.endpoint.duration": {"operation": 3, "ignore": 1},
+	}, counts)
+}
@@ -32,6 +32,7 @@ type config struct {
 	queryObserver     gocql.QueryObserver
 	batchObserver     gocql.BatchObserver
 	connectObserver   gocql.ConnectObserver
+	sanitizer         func(string) string
 }
 
 // Option applies a configuration option.
@@ -117,6 +118,18 @@ func WithConnectInstrumentation(enabled bool) Option {
 	})
 }
 
+// WithStatementSanitizer sets the function applied to the query statements
+// before they are recorded with the db.statement attribute, used as the
+// span name, and added to the query metrics, e.g. the Sanitize method of a
+// sqlsanitize.Sanitizer. If fn returns an empty string, the statement is
+// not recorded and the span is named after the operation of the statement.
+// By default the statements are recorded as is.
+func WithStatementSanitizer(fn func(stmt string) string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.sanitizer = fn
+	})
+}
+
 func newConfig(options ...Option) *config {
 	cfg := &config{
 		tracerProvider:    otel.GetTracerProvider(),
@@ -4,6 +4,7 @@ go 1.15
 
 replace (
 	go.opentelemetry.io/contrib => ../../../../../../
+	go.opentelemetry.io/contrib/instrumentation/database/sqlsanitize => ../../../../../database/sqlsanitize
 	go.opentelemetry.io/contrib/instrumentation/github.com/gocql/gocql/otelgocql => ../
 )
 
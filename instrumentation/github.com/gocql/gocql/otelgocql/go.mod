@@ -2,12 +2,16 @@ module go.opentelemetry.io/contrib/instrumentation/github.com/gocql/gocql/otelgo
 
 go 1.15
 
-replace go.opentelemetry.io/contrib => ../../../../../
+replace (
+	go.opentelemetry.io/contrib => ../../../../../
+	go.opentelemetry.io/contrib/instrumentation/database/sqlsanitize => ../../../../database/sqlsanitize
+)
 
 require (
 	github.com/gocql/gocql v0.0.0-20200624222514-34081eda590e
 	github.com/golang/snappy v0.0.1 // indirect
 	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/instrumentation/database/sqlsanitize v0.26.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/metric v0.24.0
 	go.opentelemetry.io/otel/trace v1.1.0
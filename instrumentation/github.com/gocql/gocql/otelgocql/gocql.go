@@ -34,10 +34,11 @@ func NewSessionWithTracing(ctx context.Context, cluster *gocql.ClusterConfig, op
 		trace.WithInstrumentationVersion(SemVersion()),
 	)
 	cluster.QueryObserver = &OTelQueryObserver{
-		enabled:  cfg.instrumentQuery,
-		observer: cfg.queryObserver,
-		tracer:   tracer,
-		inst:     instruments,
+		enabled:   cfg.instrumentQuery,
+		observer:  cfg.queryObserver,
+		tracer:    tracer,
+		inst:      instruments,
+		sanitizer: cfg.sanitizer,
 	}
 	cluster.BatchObserver = &OTelBatchObserver{
 		enabled:  cfg.instrumentBatch,
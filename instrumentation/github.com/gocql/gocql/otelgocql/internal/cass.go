@@ -53,6 +53,7 @@ const (
 	CassQueryAttemptsKey = attribute.Key("db.cassandra.attempts")
 
 	// Static span names
+	CassQueryName      = "Query"
 	CassBatchQueryName = "Batch Query"
 	CassConnectName    = "New Connection"
 
@@ -109,6 +110,12 @@ func CassStatement(stmt string) attribute.KeyValue {
 	return semconv.DBStatementKey.String(stmt)
 }
 
+// CassQueryOperation returns the operation of a query statement, e.g.
+// SELECT, as a semconv KeyValue pair (db.operation).
+func CassQueryOperation(operation string) attribute.KeyValue {
+	return semconv.DBOperationKey.String(operation)
+}
+
 // CassBatchQueryOperation returns the batch query operation
 // as a semconv KeyValue pair (db.operation). This is used in lieu of a
 // db.statement, which is not feasible to include in a span for a batch query
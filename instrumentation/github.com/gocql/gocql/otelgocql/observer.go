@@ -20,6 +20,7 @@ import (
 
 	"github.com/gocql/gocql"
 
+	"go.opentelemetry.io/contrib/instrumentation/database/sqlsanitize"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gocql/gocql/otelgocql/internal"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -28,10 +29,11 @@ import (
 // OTelQueryObserver implements the gocql.QueryObserver interface
 // to provide instrumentation to gocql queries.
 type OTelQueryObserver struct {
-	enabled  bool
-	observer gocql.QueryObserver
-	tracer   trace.Tracer
-	inst     *instruments
+	enabled   bool
+	observer  gocql.QueryObserver
+	tracer    trace.Tracer
+	inst      *instruments
+	sanitizer func(string) string
 }
 
 // OTelBatchObserver implements the gocql.BatchObserver interface
@@ -62,42 +64,49 @@ func (o *OTelQueryObserver) ObserveQuery(ctx context.Context, observedQuery gocq
 		keyspace := observedQuery.Keyspace
 		inst := o.inst
 
-		attributes := includeKeyValues(host,
-			internal.CassKeyspace(keyspace),
-			internal.CassStatement(observedQuery.Statement),
+		statement := observedQuery.Statement
+		if o.sanitizer != nil {
+			statement = o.sanitizer(statement)
+		}
+		operation := sqlsanitize.Operation(observedQuery.Statement)
+		spanName := statement
+		if spanName == "" {
+			spanName = operation
+		}
+		if spanName == "" {
+			spanName = internal.CassQueryName
+		}
+
+		// The statement attributes, shared by the span and the query count.
+		var statementAttributes []attribute.KeyValue
+		if statement != "" {
+			statementAttributes = append(statementAttributes, internal.CassStatement(statement))
+		}
+
+		attributes := includeKeyValues(host, internal.CassKeyspace(keyspace))
+		attributes = append(attributes, statementAttributes...)
+		if operation != "" {
+			attributes = append(attributes, internal.CassQueryOperation(operation))
+		}
+		attributes = append(attributes,
 			internal.CassRowsReturned(observedQuery.Rows),
 			internal.CassQueryAttempts(observedQuery.Metrics.Attempts),
 		)
 
 		ctx, span := o.tracer.Start(
 			ctx,
-			observedQuery.Statement,
+			spanName,
 			trace.WithTimestamp(observedQuery.Start),
 			trace.WithAttributes(attributes...),
 			trace.WithSpanKind(trace.SpanKindClient),
 		)
 
+		countAttributes := append(includeKeyValues(host, internal.CassKeyspace(keyspace)), statementAttributes...)
 		if observedQuery.Err != nil {
 			span.SetAttributes(internal.CassErrMsg(observedQuery.Err.Error()))
-			inst.queryCount.Add(
-				ctx,
-				1,
-				includeKeyValues(host,
-					internal.CassKeyspace(keyspace),
-					internal.CassStatement(observedQuery.Statement),
-					internal.CassErrMsg(observedQuery.Err.Error()),
-				)...,
-			)
-		} else {
-			inst.queryCount.Add(
-				ctx,
-				1,
-				includeKeyValues(host,
-					internal.CassKeyspace(keyspace),
-					internal.CassStatement(observedQuery.Statement),
-				)...,
-			)
+			countAttributes = append(countAttributes, internal.CassErrMsg(observedQuery.Err.Error()))
 		}
+		inst.queryCount.Add(ctx, 1, countAttributes...)
 
 		span.End(trace.WithTimestamp(observedQuery.End))
 
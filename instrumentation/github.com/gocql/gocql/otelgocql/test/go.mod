@@ -16,3 +16,5 @@ require (
 replace go.opentelemetry.io/contrib/instrumentation/github.com/gocql/gocql/otelgocql => ../
 
 replace go.opentelemetry.io/contrib => ../../../../../../
+
+replace go.opentelemetry.io/contrib/instrumentation/database/sqlsanitize => ../../../../../database/sqlsanitize
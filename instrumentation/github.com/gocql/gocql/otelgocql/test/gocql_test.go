@@ -103,6 +103,7 @@ func TestQuery(t *testing.T) {
 		switch span.Name() {
 		case insertStmt:
 			assert.Contains(t, span.Attributes(), semconv.DBStatementKey.String(insertStmt))
+			assert.Contains(t, span.Attributes(), semconv.DBOperationKey.String("INSERT"))
 			assert.Equal(t, parentSpan.SpanContext().SpanID().String(), span.Parent().SpanID().String())
 		default:
 			t.Fatalf("unexpected span name %s", span.Name())
@@ -177,6 +178,35 @@ func TestQuery(t *testing.T) {
 
 }
 
+func TestQueryWithStatementSanitizer(t *testing.T) {
+	defer afterEach()
+	cluster := getCluster()
+	sr := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	session, err := otelgocql.NewSessionWithTracing(
+		context.Background(),
+		cluster,
+		otelgocql.WithTracerProvider(tracerProvider),
+		otelgocql.WithConnectInstrumentation(false),
+		otelgocql.WithStatementSanitizer(func(string) string { return "" }),
+	)
+	require.NoError(t, err)
+	defer session.Close()
+	require.NoError(t, session.AwaitSchemaAgreement(context.Background()))
+
+	insertStmt := fmt.Sprintf("insert into %s (id, title) values (?, ?)", tableName)
+	require.NoError(t, session.Query(insertStmt, gocql.TimeUUID(), "example-title").Exec())
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "INSERT", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), semconv.DBOperationKey.String("INSERT"))
+	for _, kv := range spans[0].Attributes() {
+		assert.NotEqual(t, semconv.DBStatementKey, kv.Key)
+	}
+}
+
 func TestBatch(t *testing.T) {
 	defer afterEach()
 	cluster := getCluster()
@@ -15,6 +15,9 @@
 package otelmux
 
 import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/requestfilter"
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
@@ -23,8 +26,16 @@ import (
 type config struct {
 	TracerProvider oteltrace.TracerProvider
 	Propagators    propagation.TextMapPropagator
+	Filters        []Filter
+	Sanitizer      requestfilter.Sanitizer
+	PublicEndpoint bool
 }
 
+// Filter is a predicate used to determine whether a given http.Request
+// should be traced. A Filter must return true if the request should be
+// traced.
+type Filter func(*http.Request) bool
+
 // Option specifies instrumentation configuration options.
 type Option interface {
 	apply(*config)
@@ -56,3 +67,42 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 		}
 	})
 }
+
+// WithFilter adds a filter to the list of filters used by the middleware.
+// If any filter indicates to exclude a request then the request will not be
+// traced. All filters must allow a request to be traced for a Span to be created.
+// If no filters are provided then all requests are traced.
+// Filters will be invoked for each processed request, it is advised to make them
+// simple and fast.
+func WithFilter(f Filter) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.Filters = append(cfg.Filters, f)
+	})
+}
+
+// WithRequestFilter adds a filter shared with the other instrumentations to
+// the list of filters used by the middleware, as WithFilter does.
+func WithRequestFilter(f requestfilter.Filter) Option {
+	return WithFilter(func(r *http.Request) bool {
+		return f(requestfilter.FromHTTP(r))
+	})
+}
+
+// WithRequestSanitizer sets the sanitizer applied to the attributes of the
+// requests before they are recorded, e.g. to drop the query strings or
+// redact the values read from the headers.
+func WithRequestSanitizer(s requestfilter.Sanitizer) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.Sanitizer = s
+	})
+}
+
+// WithPublicEndpoint configures the middleware to start a new trace for
+// each request, linked to the span context extracted from the request
+// instead of being its child. It is meant for endpoints exposed to clients
+// whose trace context should not be trusted.
+func WithPublicEndpoint() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.PublicEndpoint = true
+	})
+}
@@ -5,6 +5,7 @@ go 1.15
 replace (
 	go.opentelemetry.io/contrib => ../../../../../../
 	go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux => ../
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../../../requestfilter
 )
 
 require (
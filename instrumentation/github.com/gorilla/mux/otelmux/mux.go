@@ -22,10 +22,11 @@ import (
 	"github.com/felixge/httpsnoop"
 	"github.com/gorilla/mux"
 
+	"go.opentelemetry.io/contrib/instrumentation/requestfilter"
+	"go.opentelemetry.io/contrib/internal/httpconv"
 	"go.opentelemetry.io/otel"
 
 	"go.opentelemetry.io/otel/propagation"
-	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
@@ -53,19 +54,25 @@ func Middleware(service string, opts ...Option) mux.MiddlewareFunc {
 	}
 	return func(handler http.Handler) http.Handler {
 		return traceware{
-			service:     service,
-			tracer:      tracer,
-			propagators: cfg.Propagators,
-			handler:     handler,
+			service:        service,
+			tracer:         tracer,
+			propagators:    cfg.Propagators,
+			filters:        cfg.Filters,
+			sanitizer:      cfg.Sanitizer,
+			publicEndpoint: cfg.PublicEndpoint,
+			handler:        handler,
 		}
 	}
 }
 
 type traceware struct {
-	service     string
-	tracer      oteltrace.Tracer
-	propagators propagation.TextMapPropagator
-	handler     http.Handler
+	service        string
+	tracer         oteltrace.Tracer
+	propagators    propagation.TextMapPropagator
+	filters        []Filter
+	sanitizer      requestfilter.Sanitizer
+	publicEndpoint bool
+	handler        http.Handler
 }
 
 type recordingResponseWriter struct {
@@ -115,6 +122,14 @@ func putRRW(rrw *recordingResponseWriter) {
 // ServeHTTP implements the http.Handler interface. It does the actual
 // tracing of the request.
 func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for _, f := range tw.filters {
+		if !f(r) {
+			// Simply pass through to the handler if a filter rejects the request
+			tw.handler.ServeHTTP(w, r)
+			return
+		}
+	}
+
 	ctx := tw.propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
 	spanName := ""
 	route := mux.CurrentRoute(r)
@@ -132,20 +147,28 @@ func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if spanName == "" {
 		spanName = fmt.Sprintf("HTTP %s route not found", r.Method)
 	}
+	attrs := httpconv.ServerRequest(tw.service, routeStr, r)
+	if tw.sanitizer != nil {
+		attrs = tw.sanitizer(attrs)
+	}
 	opts := []oteltrace.SpanStartOption{
-		oteltrace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", r)...),
-		oteltrace.WithAttributes(semconv.EndUserAttributesFromHTTPRequest(r)...),
-		oteltrace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(tw.service, routeStr, r)...),
+		oteltrace.WithAttributes(attrs...),
 		oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 	}
+	if tw.publicEndpoint {
+		opts = append(opts, oteltrace.WithNewRoot())
+		if sc := oteltrace.SpanContextFromContext(ctx); sc.IsValid() {
+			opts = append(opts, oteltrace.WithLinks(oteltrace.Link{SpanContext: sc}))
+		}
+	}
 	ctx, span := tw.tracer.Start(ctx, spanName, opts...)
 	defer span.End()
 	r2 := r.WithContext(ctx)
 	rrw := getRRW(w)
 	defer putRRW(rrw)
 	tw.handler.ServeHTTP(rrw.writer, r2)
-	attrs := semconv.HTTPAttributesFromHTTPStatusCode(rrw.status)
-	spanStatus, spanMessage := semconv.SpanStatusFromHTTPStatusCode(rrw.status)
+	attrs = httpconv.StatusCode(rrw.status)
+	spanStatus, spanMessage := httpconv.SpanStatus(rrw.status)
 	span.SetAttributes(attrs...)
 	span.SetStatus(spanStatus, spanMessage)
 }
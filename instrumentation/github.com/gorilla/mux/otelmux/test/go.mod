@@ -6,6 +6,7 @@ require (
 	github.com/gorilla/mux v1.8.0
 	github.com/stretchr/testify v1.7.0
 	go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux v0.26.0
+	go.opentelemetry.io/contrib/instrumentation/requestfilter v0.26.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/sdk v1.1.0
 	go.opentelemetry.io/otel/trace v1.1.0
@@ -14,4 +15,5 @@ require (
 replace (
 	go.opentelemetry.io/contrib => ../../../../../../
 	go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux => ../
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../../../requestfilter
 )
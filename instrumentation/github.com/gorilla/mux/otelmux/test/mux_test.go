@@ -15,6 +15,7 @@
 package test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -24,7 +25,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	"go.opentelemetry.io/contrib/instrumentation/requestfilter"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
@@ -71,6 +74,79 @@ func TestSDKIntegration(t *testing.T) {
 	)
 }
 
+func TestFilter(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	router := mux.NewRouter()
+	router.Use(otelmux.Middleware("foobar",
+		otelmux.WithTracerProvider(provider),
+		otelmux.WithFilter(func(r *http.Request) bool {
+			return r.URL.Path != "/healthz"
+		}),
+	))
+	router.HandleFunc("/healthz", ok)
+	router.HandleFunc("/book/{title}", ok)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/book/foo", nil))
+
+	require.Len(t, sr.Ended(), 1)
+	assert.Equal(t, "/book/{title}", sr.Ended()[0].Name())
+}
+
+func TestRequestFilterAndSanitizer(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	router := mux.NewRouter()
+	router.Use(otelmux.Middleware("foobar",
+		otelmux.WithTracerProvider(provider),
+		otelmux.WithRequestFilter(requestfilter.Not(requestfilter.HealthCheck())),
+		otelmux.WithRequestSanitizer(requestfilter.DropQuery()),
+	))
+	router.HandleFunc("/healthz", ok)
+	router.HandleFunc("/book/{title}", ok)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/healthz", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/book/foo?token=secret", nil))
+
+	require.Len(t, sr.Ended(), 1)
+	assert.Contains(t, sr.Ended()[0].Attributes(), attribute.String("http.target", "/book/foo"))
+}
+
+func TestPublicEndpoint(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	prop := propagation.TraceContext{}
+
+	router := mux.NewRouter()
+	router.Use(otelmux.Middleware("foobar",
+		otelmux.WithTracerProvider(provider),
+		otelmux.WithPropagators(prop),
+		otelmux.WithPublicEndpoint(),
+	))
+	router.HandleFunc("/book/{title}", ok)
+
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	r := httptest.NewRequest("GET", "/book/foo", nil)
+	prop.Inject(trace.ContextWithRemoteSpanContext(context.Background(), remote), propagation.HeaderCarrier(r.Header))
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	require.Len(t, sr.Ended(), 1)
+	span := sr.Ended()[0]
+	assert.False(t, span.Parent().IsValid())
+	assert.NotEqual(t, remote.TraceID(), span.SpanContext().TraceID())
+	require.Len(t, span.Links(), 1)
+	assert.True(t, remote.Equal(span.Links()[0].SpanContext.WithRemote(false)))
+}
+
 func assertSpan(t *testing.T, span sdktrace.ReadOnlySpan, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) {
 	assert.Equal(t, name, span.Name())
 	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
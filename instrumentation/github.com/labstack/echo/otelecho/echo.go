@@ -20,11 +20,11 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 
+	"go.opentelemetry.io/contrib/internal/httpconv"
 	"go.opentelemetry.io/otel"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
-	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
@@ -69,9 +69,7 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 			}()
 			ctx := cfg.Propagators.Extract(savedCtx, propagation.HeaderCarrier(request.Header))
 			opts := []oteltrace.SpanStartOption{
-				oteltrace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", request)...),
-				oteltrace.WithAttributes(semconv.EndUserAttributesFromHTTPRequest(request)...),
-				oteltrace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(service, c.Path(), request)...),
+				oteltrace.WithAttributes(httpconv.ServerRequest(service, c.Path(), request)...),
 				oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 			}
 			spanName := c.Path()
@@ -93,8 +91,8 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 				c.Error(err)
 			}
 
-			attrs := semconv.HTTPAttributesFromHTTPStatusCode(c.Response().Status)
-			spanStatus, spanMessage := semconv.SpanStatusFromHTTPStatusCode(c.Response().Status)
+			attrs := httpconv.StatusCode(c.Response().Status)
+			spanStatus, spanMessage := httpconv.SpanStatus(c.Response().Status)
 			span.SetAttributes(attrs...)
 			span.SetStatus(spanStatus, spanMessage)
 
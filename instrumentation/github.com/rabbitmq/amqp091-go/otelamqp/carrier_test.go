@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelamqp
+
+import (
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadersCarrier(t *testing.T) {
+	headers := amqp.Table{
+		"string": "foo",
+		"bytes":  []byte("bar"),
+		"int":    int32(42),
+	}
+	carrier := HeadersCarrier(headers)
+
+	assert.Equal(t, "foo", carrier.Get("string"))
+	assert.Equal(t, "bar", carrier.Get("bytes"))
+	assert.Equal(t, "42", carrier.Get("int"))
+	assert.Equal(t, "", carrier.Get("missing"))
+
+	carrier.Set("string", "baz")
+	assert.Equal(t, "baz", headers["string"])
+	assert.ElementsMatch(t, []string{"string", "bytes", "int"}, carrier.Keys())
+}
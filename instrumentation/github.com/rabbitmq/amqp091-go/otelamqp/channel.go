@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelamqp
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Channel wraps an *amqp.Channel so that published and consumed messages
+// are traced. All methods not overridden by Channel are passed through to
+// the wrapped channel.
+type Channel struct {
+	*amqp.Channel
+	cfg config
+}
+
+// WrapChannel wraps an *amqp.Channel so that published and consumed messages
+// are traced.
+func WrapChannel(ch *amqp.Channel, opts ...Option) *Channel {
+	return &Channel{
+		Channel: ch,
+		cfg:     newConfig(opts...),
+	}
+}
+
+// Publish publishes msg using a background context as the parent of the
+// created span. See PublishWithContext.
+func (c *Channel) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	return c.PublishWithContext(context.Background(), exchange, key, mandatory, immediate, msg)
+}
+
+// PublishWithContext publishes msg to the exchange with the routing key. A
+// span is started as a child of ctx and its span context is injected into
+// the headers of the published message. The headers of msg are copied
+// before injection so the Table passed by the caller is not modified.
+func (c *Channel) PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	attrs := append(destinationAttributes(exchange, key), semconv.MessagingMessagePayloadSizeBytesKey.Int(len(msg.Body)))
+	if msg.MessageId != "" {
+		attrs = append(attrs, semconv.MessagingMessageIDKey.String(msg.MessageId))
+	}
+	if msg.CorrelationId != "" {
+		attrs = append(attrs, semconv.MessagingConversationIDKey.String(msg.CorrelationId))
+	}
+	ctx, span := c.cfg.Tracer.Start(ctx, spanName(exchange, key, "send"),
+		trace.WithAttributes(attrs...),
+		trace.WithSpanKind(trace.SpanKindProducer),
+	)
+	defer span.End()
+
+	headers := make(amqp.Table, len(msg.Headers)+1)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	c.cfg.Propagators.Inject(ctx, HeadersCarrier(headers))
+	msg.Headers = headers
+
+	err := c.Channel.Publish(exchange, key, mandatory, immediate, msg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// Consume starts consuming deliveries from queue. Every delivery is traced
+// before it is forwarded to the returned channel, which is closed once the
+// channel of the wrapped *amqp.Channel is closed.
+func (c *Channel) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	deliveries, err := c.Channel.Consume(queue, consumer, autoAck, exclusive, noLocal, noWait, args)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan amqp.Delivery)
+	go func() {
+		defer close(out)
+		for d := range deliveries {
+			c.traceDelivery(queue, &d)
+			out <- d
+		}
+	}()
+	return out, nil
+}
+
+// Get synchronously receives a single delivery from queue. If a delivery is
+// available it is traced before being returned.
+func (c *Channel) Get(queue string, autoAck bool) (amqp.Delivery, bool, error) {
+	d, ok, err := c.Channel.Get(queue, autoAck)
+	if err != nil || !ok {
+		return d, ok, err
+	}
+	c.traceDelivery(queue, &d)
+	return d, ok, nil
+}
+
+func (c *Channel) traceDelivery(queue string, d *amqp.Delivery) {
+	if d.Headers == nil {
+		d.Headers = amqp.Table{}
+	}
+	carrier := HeadersCarrier(d.Headers)
+	parentSpanContext := c.cfg.Propagators.Extract(context.Background(), carrier)
+
+	attrs := append(destinationAttributes(d.Exchange, d.RoutingKey),
+		semconv.MessagingOperationReceive,
+		semconv.MessagingMessagePayloadSizeBytesKey.Int(len(d.Body)),
+		QueueKey.String(queue),
+	)
+	if d.MessageId != "" {
+		attrs = append(attrs, semconv.MessagingMessageIDKey.String(d.MessageId))
+	}
+	if d.CorrelationId != "" {
+		attrs = append(attrs, semconv.MessagingConversationIDKey.String(d.CorrelationId))
+	}
+	newCtx, span := c.cfg.Tracer.Start(parentSpanContext, spanName(d.Exchange, d.RoutingKey, "receive"),
+		trace.WithAttributes(attrs...),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+	)
+
+	// Inject current span context, so consumers can use it to propagate span.
+	c.cfg.Propagators.Inject(newCtx, carrier)
+
+	span.End()
+}
+
+// QueueKey is the attribute key used to record the name of the queue a
+// delivery was received from.
+const QueueKey = attribute.Key("messaging.rabbitmq.queue")
+
+// destinationAttributes returns the messaging attributes describing where a
+// message with the routing key is sent through exchange. Messages sent
+// through the default exchange are routed directly to the queue named by the
+// routing key.
+func destinationAttributes(exchange, key string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystemKey.String("rabbitmq"),
+		semconv.MessagingProtocolKey.String("AMQP"),
+		semconv.MessagingProtocolVersionKey.String("0.9.1"),
+		semconv.MessagingRabbitmqRoutingKeyKey.String(key),
+	}
+	if exchange == "" {
+		return append(attrs,
+			semconv.MessagingDestinationKindQueue,
+			semconv.MessagingDestinationKey.String(key),
+		)
+	}
+	return append(attrs, semconv.MessagingDestinationKey.String(exchange))
+}
+
+// spanName returns the name of a span for operation on a message sent
+// through exchange with the routing key.
+func spanName(exchange, key, operation string) string {
+	if exchange == "" {
+		return key + " " + operation
+	}
+	return exchange + " " + operation
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelamqp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func TestDestinationAttributes(t *testing.T) {
+	attrs := destinationAttributes("", "my-queue")
+	assert.Contains(t, attrs, semconv.MessagingDestinationKindQueue)
+	assert.Contains(t, attrs, semconv.MessagingDestinationKey.String("my-queue"))
+	assert.Contains(t, attrs, semconv.MessagingRabbitmqRoutingKeyKey.String("my-queue"))
+
+	attrs = destinationAttributes("logs", "info")
+	assert.NotContains(t, attrs, semconv.MessagingDestinationKindQueue)
+	assert.Contains(t, attrs, semconv.MessagingDestinationKey.String("logs"))
+	assert.Contains(t, attrs, semconv.MessagingRabbitmqRoutingKeyKey.String("info"))
+}
+
+func TestSpanName(t *testing.T) {
+	assert.Equal(t, "my-queue send", spanName("", "my-queue", "send"))
+	assert.Equal(t, "logs receive", spanName("logs", "info", "receive"))
+}
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelamqp instruments the github.com/rabbitmq/amqp091-go package.
+//
+// Channel wraps an *amqp.Channel. A span is created for every message
+// published with PublishWithContext (or Publish) and its span context is
+// injected into the headers of the published message. A span is created for
+// every delivery received from Consume or Get as a child of the span context
+// found in the delivery headers, and the span context of that span is
+// injected back into the delivery headers so processing can be continued
+// from it.
+package otelamqp // import "go.opentelemetry.io/contrib/instrumentation/github.com/rabbitmq/amqp091-go/otelamqp"
@@ -0,0 +1,10 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/rabbitmq/amqp091-go/otelamqp
+
+go 1.15
+
+require (
+	github.com/rabbitmq/amqp091-go v1.1.0
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
@@ -0,0 +1,17 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/rabbitmq/amqp091-go/otelamqp/test
+
+go 1.15
+
+require (
+	github.com/rabbitmq/amqp091-go v1.1.0
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib v1.1.0
+	go.opentelemetry.io/contrib/instrumentation/github.com/rabbitmq/amqp091-go/otelamqp v0.26.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
+
+replace go.opentelemetry.io/contrib/instrumentation/github.com/rabbitmq/amqp091-go/otelamqp => ../
+
+replace go.opentelemetry.io/contrib => ../../../../../../
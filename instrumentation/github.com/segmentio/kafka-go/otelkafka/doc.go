@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelkafka instruments the github.com/segmentio/kafka-go package.
+//
+// A single span is created for every call to Writer.WriteMessages. The span
+// context of the batch is injected into the headers of every written message
+// and any span context already present in those headers is added to the span
+// as a link. A span is created for every message returned by the Reader as a
+// child of the span context found in the message headers.
+//
+// Context propagation only works on Kafka versions higher than 0.11.0.0 which supports record headers.
+// (https://archive.apache.org/dist/kafka/0.11.0.0/RELEASE_NOTES.html)
+package otelkafka // import "go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka"
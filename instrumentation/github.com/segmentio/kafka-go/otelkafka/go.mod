@@ -0,0 +1,9 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka
+
+go 1.15
+
+require (
+	github.com/segmentio/kafka-go v0.4.25
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
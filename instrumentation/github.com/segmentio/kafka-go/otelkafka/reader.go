@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkafka
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/segmentio/kafka-go"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MessageReader is the interface of a kafka-go message reader. It is
+// implemented by *kafka.Reader.
+type MessageReader interface {
+	ReadMessage(ctx context.Context) (kafka.Message, error)
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+var _ MessageReader = (*kafka.Reader)(nil)
+
+// Reader wraps a MessageReader so that read messages are traced.
+type Reader struct {
+	MessageReader
+	cfg config
+}
+
+// WrapReader wraps a MessageReader so that any read messages are traced.
+func WrapReader(r MessageReader, opts ...Option) *Reader {
+	return &Reader{
+		MessageReader: r,
+		cfg:           newConfig(opts...),
+	}
+}
+
+// ReadMessage reads and commits the next message from the wrapped reader.
+// The span context of the created span is injected into the returned
+// message's headers.
+func (r *Reader) ReadMessage(ctx context.Context) (kafka.Message, error) {
+	msg, err := r.MessageReader.ReadMessage(ctx)
+	if err != nil {
+		return msg, err
+	}
+	r.traceMessage(&msg)
+	return msg, nil
+}
+
+// FetchMessage reads the next message from the wrapped reader without
+// committing it. The span context of the created span is injected into the
+// returned message's headers.
+func (r *Reader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	msg, err := r.MessageReader.FetchMessage(ctx)
+	if err != nil {
+		return msg, err
+	}
+	r.traceMessage(&msg)
+	return msg, nil
+}
+
+func (r *Reader) traceMessage(msg *kafka.Message) {
+	// Extract a span context from message to link.
+	carrier := NewMessageCarrier(msg)
+	parentSpanContext := r.cfg.Propagators.Extract(context.Background(), carrier)
+
+	// Create a span.
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystemKey.String("kafka"),
+		semconv.MessagingDestinationKindTopic,
+		semconv.MessagingDestinationKey.String(msg.Topic),
+		semconv.MessagingOperationReceive,
+		semconv.MessagingMessageIDKey.String(strconv.FormatInt(msg.Offset, 10)),
+		semconv.MessagingKafkaPartitionKey.Int(msg.Partition),
+	}
+	if len(msg.Key) > 0 {
+		attrs = append(attrs, semconv.MessagingKafkaMessageKeyKey.String(string(msg.Key)))
+	}
+	opts := []trace.SpanStartOption{
+		trace.WithAttributes(attrs...),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+	}
+	newCtx, span := r.cfg.Tracer.Start(parentSpanContext, "kafka.consume", opts...)
+
+	// Inject current span context, so consumers can use it to propagate span.
+	r.cfg.Propagators.Inject(newCtx, carrier)
+
+	span.End()
+}
@@ -0,0 +1,16 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka/test
+
+go 1.15
+
+require (
+	github.com/segmentio/kafka-go v0.4.25
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka v0.26.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
+
+replace go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka => ../
+
+replace go.opentelemetry.io/contrib => ../../../../../../
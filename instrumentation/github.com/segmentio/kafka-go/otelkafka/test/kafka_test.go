@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/segmentio/kafka-go/otelkafka"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const topic = "gotest"
+
+// mockBroker is an in-memory MessageWriter and MessageReader.
+type mockBroker struct {
+	msgs []kafka.Message
+	err  error
+}
+
+func (b *mockBroker) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	if b.err != nil {
+		return b.err
+	}
+	for _, msg := range msgs {
+		if msg.Topic == "" {
+			msg.Topic = topic
+		}
+		msg.Offset = int64(len(b.msgs))
+		b.msgs = append(b.msgs, msg)
+	}
+	return nil
+}
+
+func (b *mockBroker) ReadMessage(ctx context.Context) (kafka.Message, error) {
+	return b.FetchMessage(ctx)
+}
+
+func (b *mockBroker) FetchMessage(context.Context) (kafka.Message, error) {
+	if b.err != nil {
+		return kafka.Message{}, b.err
+	}
+	if len(b.msgs) == 0 {
+		return kafka.Message{}, errors.New("no messages")
+	}
+	msg := b.msgs[0]
+	b.msgs = b.msgs[1:]
+	return msg, nil
+}
+
+func (b *mockBroker) CommitMessages(context.Context, ...kafka.Message) error { return nil }
+
+func (b *mockBroker) Close() error { return nil }
+
+func TestWriter(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	propagators := propagation.TraceContext{}
+	broker := &mockBroker{}
+
+	w := otelkafka.WrapWriter(broker, otelkafka.WithTracerProvider(provider), otelkafka.WithPropagators(propagators))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, linked := provider.Tracer("test").Start(context.Background(), "linked")
+	msgWithSpanContext := kafka.Message{Key: []byte("foo"), Value: []byte("bar")}
+	propagators.Inject(trace.ContextWithSpan(context.Background(), linked), otelkafka.NewMessageCarrier(&msgWithSpanContext))
+	msgs := []kafka.Message{msgWithSpanContext, {Value: []byte("baz")}}
+
+	require.NoError(t, w.WriteMessages(ctx, msgs...))
+	parent.End()
+	linked.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	span := spans[0]
+	assert.Equal(t, "kafka.produce", span.Name())
+	assert.Equal(t, trace.SpanKindProducer, span.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		semconv.MessagingSystemKey.String("kafka"),
+		semconv.MessagingDestinationKindTopic,
+		attribute.Int("messaging.batch.message_count", 2),
+	}, span.Attributes())
+
+	require.Len(t, span.Links(), 1)
+	assert.Equal(t, linked.SpanContext().SpanID(), span.Links()[0].SpanContext.SpanID())
+
+	// The batch span context is injected into every written message.
+	require.Len(t, broker.msgs, 2)
+	for _, msg := range broker.msgs {
+		sc := trace.SpanContextFromContext(propagators.Extract(context.Background(), otelkafka.NewMessageCarrier(&msg)))
+		assert.Equal(t, span.SpanContext().SpanID(), sc.SpanID())
+	}
+
+	// The caller's messages are not modified.
+	sc := trace.SpanContextFromContext(propagators.Extract(context.Background(), otelkafka.NewMessageCarrier(&msgs[0])))
+	assert.Equal(t, linked.SpanContext().SpanID(), sc.SpanID())
+	assert.Empty(t, msgs[1].Headers)
+}
+
+func TestWriterTopic(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	w := otelkafka.WrapWriter(&mockBroker{}, otelkafka.WithTracerProvider(provider))
+	require.NoError(t, w.WriteMessages(context.Background(),
+		kafka.Message{Topic: topic},
+		kafka.Message{Topic: topic},
+	))
+	require.NoError(t, w.WriteMessages(context.Background(),
+		kafka.Message{Topic: topic},
+		kafka.Message{Topic: "other"},
+	))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Contains(t, spans[0].Attributes(), semconv.MessagingDestinationKey.String(topic))
+	for _, kv := range spans[1].Attributes() {
+		assert.NotEqual(t, semconv.MessagingDestinationKey, kv.Key)
+	}
+}
+
+func TestWriterError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	w := otelkafka.WrapWriter(&mockBroker{err: errors.New("write error")}, otelkafka.WithTracerProvider(provider))
+	assert.Error(t, w.WriteMessages(context.Background(), kafka.Message{Topic: topic}))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "write error", spans[0].Status().Description)
+}
+
+func TestReader(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	propagators := propagation.TraceContext{}
+	opts := []otelkafka.Option{
+		otelkafka.WithTracerProvider(provider),
+		otelkafka.WithPropagators(propagators),
+	}
+	broker := &mockBroker{}
+
+	w := otelkafka.WrapWriter(broker, opts...)
+	require.NoError(t, w.WriteMessages(context.Background(),
+		kafka.Message{Key: []byte("foo")},
+		kafka.Message{},
+	))
+
+	r := otelkafka.WrapReader(broker, opts...)
+	first, err := r.ReadMessage(context.Background())
+	require.NoError(t, err)
+	second, err := r.FetchMessage(context.Background())
+	require.NoError(t, err)
+	_, err = r.ReadMessage(context.Background())
+	assert.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	produce := spans[0]
+	for i, msg := range []kafka.Message{first, second} {
+		span := spans[i+1]
+		assert.Equal(t, "kafka.consume", span.Name())
+		assert.Equal(t, trace.SpanKindConsumer, span.SpanKind())
+		assert.Equal(t, produce.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Equal(t, produce.SpanContext().TraceID(), span.SpanContext().TraceID())
+
+		attrs := span.Attributes()
+		assert.Contains(t, attrs, semconv.MessagingSystemKey.String("kafka"))
+		assert.Contains(t, attrs, semconv.MessagingDestinationKindTopic)
+		assert.Contains(t, attrs, semconv.MessagingDestinationKey.String(topic))
+		assert.Contains(t, attrs, semconv.MessagingOperationReceive)
+		assert.Contains(t, attrs, semconv.MessagingKafkaPartitionKey.Int(0))
+		assert.Contains(t, attrs, semconv.MessagingMessageIDKey.String([]string{"0", "1"}[i]))
+
+		// The consumer span context is injected into the message.
+		sc := trace.SpanContextFromContext(propagators.Extract(context.Background(), otelkafka.NewMessageCarrier(&msg)))
+		assert.Equal(t, span.SpanContext().SpanID(), sc.SpanID())
+	}
+	assert.Contains(t, spans[1].Attributes(), semconv.MessagingKafkaMessageKeyKey.String("foo"))
+}
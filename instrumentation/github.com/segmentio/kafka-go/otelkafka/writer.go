@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkafka
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// batchMessageCountKey is the number of messages written in a batch.
+var batchMessageCountKey = attribute.Key("messaging.batch.message_count")
+
+// MessageWriter is the interface of a kafka-go message writer. It is
+// implemented by *kafka.Writer.
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+var _ MessageWriter = (*kafka.Writer)(nil)
+
+// Writer wraps a MessageWriter so that written messages are traced.
+type Writer struct {
+	MessageWriter
+	cfg config
+}
+
+// WrapWriter wraps a MessageWriter so that any written messages are traced.
+func WrapWriter(w MessageWriter, opts ...Option) *Writer {
+	return &Writer{
+		MessageWriter: w,
+		cfg:           newConfig(opts...),
+	}
+}
+
+// WriteMessages writes a batch of messages to the wrapped writer.
+//
+// A span is started as a child of ctx for the whole batch. Any span context
+// carried by the messages is linked to this span, and the span context of the
+// batch is injected into the messages' headers. The messages passed by the
+// caller are not modified.
+func (w *Writer) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	traced := make([]kafka.Message, len(msgs))
+	links := make([]trace.Link, 0, len(msgs))
+	for i, msg := range msgs {
+		// Copy headers to avoid modifying the backing array of the caller.
+		msg.Headers = append([]kafka.Header(nil), msg.Headers...)
+		traced[i] = msg
+
+		carrier := NewMessageCarrier(&traced[i])
+		sc := trace.SpanContextFromContext(w.cfg.Propagators.Extract(context.Background(), carrier))
+		if sc.IsValid() {
+			links = append(links, trace.Link{SpanContext: sc})
+		}
+	}
+
+	// Create a span.
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystemKey.String("kafka"),
+		semconv.MessagingDestinationKindTopic,
+		batchMessageCountKey.Int(len(msgs)),
+	}
+	if topic := w.topic(msgs); topic != "" {
+		attrs = append(attrs, semconv.MessagingDestinationKey.String(topic))
+	}
+	opts := []trace.SpanStartOption{
+		trace.WithAttributes(attrs...),
+		trace.WithLinks(links...),
+		trace.WithSpanKind(trace.SpanKindProducer),
+	}
+	ctx, span := w.cfg.Tracer.Start(ctx, "kafka.produce", opts...)
+	defer span.End()
+
+	// Inject current span context, so consumers can use it to propagate span.
+	for i := range traced {
+		w.cfg.Propagators.Inject(ctx, NewMessageCarrier(&traced[i]))
+	}
+
+	err := w.MessageWriter.WriteMessages(ctx, traced...)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// topic returns the topic all msgs are written to, or an empty string if
+// they are written to different topics.
+func (w *Writer) topic(msgs []kafka.Message) string {
+	var topic string
+	if kw, ok := w.MessageWriter.(*kafka.Writer); ok {
+		topic = kw.Topic
+	}
+	if topic != "" {
+		return topic
+	}
+
+	for i, msg := range msgs {
+		if i > 0 && msg.Topic != topic {
+			return ""
+		}
+		topic = msg.Topic
+	}
+	return topic
+}
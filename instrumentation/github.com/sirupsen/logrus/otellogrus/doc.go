@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otellogrus correlates the logs of the github.com/sirupsen/logrus
+// package with OpenTelemetry traces.
+//
+// The hook returned by NewHook adds the trace ID, span ID and trace flags of
+// the span found in the context of a log entry to its fields. The context of
+// an entry is set with WithContext.
+//
+//	logger.AddHook(otellogrus.NewHook())
+//	logger.WithContext(ctx).Info("handled request")
+//
+// The trace ID is written in the W3C Trace Context format, as used by Loki
+// derived fields and most tracing backends. WithXRayTraceID adds the trace ID
+// in the AWS X-Ray format as well, for correlation with X-Ray in CloudWatch.
+package otellogrus // import "go.opentelemetry.io/contrib/instrumentation/github.com/sirupsen/logrus/otellogrus"
@@ -0,0 +1,9 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/sirupsen/logrus/otellogrus
+
+go 1.15
+
+require (
+	github.com/sirupsen/logrus v1.8.1
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
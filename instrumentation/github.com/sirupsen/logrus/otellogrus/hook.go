@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellogrus
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Hook is a logrus.Hook adding the fields correlating log entries with the
+// span found in their context.
+type Hook struct {
+	cfg config
+}
+
+var _ logrus.Hook = (*Hook)(nil)
+
+// NewHook returns a Hook adding the fields correlating log entries with the
+// span found in their context.
+func NewHook(opts ...Option) *Hook {
+	return &Hook{cfg: newConfig(opts...)}
+}
+
+// Levels returns the levels of the log entries the hook adds fields to.
+func (h *Hook) Levels() []logrus.Level {
+	return h.cfg.Levels
+}
+
+// Fire adds the fields correlating entry with the span found in its
+// context. Entries without a context or a valid span context in it are left
+// unchanged.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	sc := trace.SpanContextFromContext(entry.Context)
+	if !sc.IsValid() {
+		return nil
+	}
+
+	entry.Data[h.cfg.TraceIDKey] = sc.TraceID().String()
+	entry.Data[h.cfg.SpanIDKey] = sc.SpanID().String()
+	entry.Data[h.cfg.TraceFlagsKey] = sc.TraceFlags().String()
+	if h.cfg.XRayTraceIDKey != "" {
+		entry.Data[h.cfg.XRayTraceIDKey] = xrayTraceID(sc.TraceID())
+	}
+	return nil
+}
+
+// xrayTraceID returns traceID in the 1-{epoch}-{random} format of AWS X-Ray,
+// where epoch is the first 8 and random the last 24 hex characters of
+// traceID.
+func xrayTraceID(traceID trace.TraceID) string {
+	s := traceID.String()
+	return "1-" + s[:8] + "-" + s[8:]
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellogrus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+var spanCtx = trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+	TraceID:    trace.TraceID{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0x03, 0x81, 0x03, 0xd2, 0x69, 0xb6, 0x33, 0x81, 0x3f, 0xc6, 0x0c},
+	SpanID:     trace.SpanID{0xee, 0xe1, 0x9b, 0x7e, 0xc3, 0xc1, 0xb1, 0x74},
+	TraceFlags: trace.FlagsSampled,
+}))
+
+func newLogger(hook logrus.Hook) (*logrus.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.Out = &buf
+	logger.Formatter = &logrus.JSONFormatter{DisableTimestamp: true}
+	logger.AddHook(hook)
+	return logger, &buf
+}
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	var fields map[string]interface{}
+	require.NoError(t, json.NewDecoder(buf).Decode(&fields))
+	return fields
+}
+
+func TestHook(t *testing.T) {
+	logger, buf := newLogger(NewHook())
+
+	logger.WithContext(spanCtx).WithField("foo", "bar").Info("traced")
+	assert.Equal(t, map[string]interface{}{
+		"level":       "info",
+		"msg":         "traced",
+		"foo":         "bar",
+		"trace_id":    "5b8efff798038103d269b633813fc60c",
+		"span_id":     "eee19b7ec3c1b174",
+		"trace_flags": "01",
+	}, decode(t, buf))
+
+	logger.WithContext(context.Background()).Info("untraced")
+	assert.Equal(t, map[string]interface{}{
+		"level": "info",
+		"msg":   "untraced",
+	}, decode(t, buf))
+
+	logger.Info("no context")
+	assert.Equal(t, map[string]interface{}{
+		"level": "info",
+		"msg":   "no context",
+	}, decode(t, buf))
+}
+
+func TestHookOptions(t *testing.T) {
+	logger, buf := newLogger(NewHook(
+		WithTraceIDKey("traceID"),
+		WithSpanIDKey("spanID"),
+		WithTraceFlagsKey("flags"),
+		WithXRayTraceID("AWS-XRAY-TRACE-ID"),
+		WithLevels(logrus.ErrorLevel),
+	))
+
+	logger.WithContext(spanCtx).Error("traced")
+	assert.Equal(t, map[string]interface{}{
+		"level":             "error",
+		"msg":               "traced",
+		"traceID":           "5b8efff798038103d269b633813fc60c",
+		"spanID":            "eee19b7ec3c1b174",
+		"flags":             "01",
+		"AWS-XRAY-TRACE-ID": "1-5b8efff7-98038103d269b633813fc60c",
+	}, decode(t, buf))
+
+	// The hook does not fire for other levels.
+	logger.WithContext(spanCtx).Info("not traced")
+	assert.Equal(t, map[string]interface{}{
+		"level": "info",
+		"msg":   "not traced",
+	}, decode(t, buf))
+}
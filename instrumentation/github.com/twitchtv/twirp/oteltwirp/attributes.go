@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oteltwirp
+
+import (
+	"context"
+
+	"github.com/twitchtv/twirp"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// ErrorCodeKey is the attribute key of the Twirp error code of a failed RPC.
+const ErrorCodeKey = attribute.Key("rpc.twirp.error_code")
+
+// rpcSystem is the value of the rpc.system attribute.
+var rpcSystem = semconv.RPCSystemKey.String("twirp")
+
+// rpcName returns the span name and the RPC attributes of the Twirp method
+// called with ctx.
+func rpcName(ctx context.Context) (string, []attribute.KeyValue) {
+	attrs := []attribute.KeyValue{rpcSystem}
+
+	service, _ := twirp.ServiceName(ctx)
+	if pkg, ok := twirp.PackageName(ctx); ok && pkg != "" {
+		service = pkg + "." + service
+	}
+	method, _ := twirp.MethodName(ctx)
+
+	if service != "" {
+		attrs = append(attrs, semconv.RPCServiceKey.String(service))
+	}
+	if method != "" {
+		attrs = append(attrs, semconv.RPCMethodKey.String(method))
+	}
+	return service + "/" + method, attrs
+}
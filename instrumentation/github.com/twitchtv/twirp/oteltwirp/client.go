@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oteltwirp
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/internal/httpconv"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTPClient is the interface of the HTTP client used by Twirp clients.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client is an HTTPClient tracing the RPCs sent by a Twirp client.
+type Client struct {
+	client HTTPClient
+	cfg    config
+}
+
+var _ HTTPClient = (*Client)(nil)
+
+// NewHTTPClient wraps client so that the RPCs sent by a Twirp client using it
+// are traced and the span context is propagated in the request headers.
+//
+// If client is nil, http.DefaultClient is used. Redirects are not followed
+// by an *http.Client, as done by the Twirp clients for unwrapped clients.
+func NewHTTPClient(client HTTPClient, opts ...Option) *Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+	return &Client{
+		client: client,
+		cfg:    newConfig(opts...),
+	}
+}
+
+// Do sends the request of an RPC in a span.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	name, attrs := rpcName(req.Context())
+	ctx, span := c.cfg.Tracer.Start(
+		req.Context(),
+		name,
+		trace.WithAttributes(attrs...),
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	defer span.End()
+
+	req = req.WithContext(ctx)
+	c.cfg.Propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+	span.SetStatus(httpconv.SpanStatus(resp.StatusCode))
+	return resp, nil
+}
+
+// withoutRedirects returns a copy of in that does not follow redirects.
+func withoutRedirects(in *http.Client) *http.Client {
+	c := *in
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if in.CheckRedirect != nil {
+			// Run the input's redirect if it exists, in case it has side
+			// effects, but ignore any error it returns.
+			_ = in.CheckRedirect(req, via)
+		}
+		return http.ErrUseLastResponse
+	}
+	return &c
+}
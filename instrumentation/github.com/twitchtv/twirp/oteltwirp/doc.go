@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package oteltwirp instruments the github.com/twitchtv/twirp package.
+//
+// Servers are instrumented with the hooks returned by NewServerHooks and the
+// handler returned by WithTraceContext, which extracts the span context
+// propagated by the client from the HTTP request headers:
+//
+//	server := example.NewHaberdasherServer(svc, oteltwirp.NewServerHooks())
+//	http.ListenAndServe(":8080", oteltwirp.WithTraceContext(server))
+//
+// Clients are instrumented with the HTTP client returned by NewHTTPClient,
+// which injects the span context into the HTTP request headers:
+//
+//	client := example.NewHaberdasherProtobufClient(url, oteltwirp.NewHTTPClient(nil))
+package oteltwirp // import "go.opentelemetry.io/contrib/instrumentation/github.com/twitchtv/twirp/oteltwirp"
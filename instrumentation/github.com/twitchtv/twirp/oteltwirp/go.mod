@@ -0,0 +1,13 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/twitchtv/twirp/oteltwirp
+
+go 1.15
+
+require (
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/twitchtv/twirp v8.1.0+incompatible
+	go.opentelemetry.io/contrib v1.1.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
+
+replace go.opentelemetry.io/contrib => ../../../../..
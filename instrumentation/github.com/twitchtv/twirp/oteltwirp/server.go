@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oteltwirp
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/twitchtv/twirp"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanKey is the context key of the span of the RPC handled by a server.
+type spanKey struct{}
+
+// NewServerHooks returns Twirp server hooks that start a span for every RPC
+// routed to a method of the server, and end it once the response is sent.
+//
+// The span is a child of the span context extracted from the request headers
+// by the handler returned by WithTraceContext.
+func NewServerHooks(opts ...Option) *twirp.ServerHooks {
+	cfg := newConfig(opts...)
+
+	return &twirp.ServerHooks{
+		RequestRouted: func(ctx context.Context) (context.Context, error) {
+			name, attrs := rpcName(ctx)
+			ctx, span := cfg.Tracer.Start(
+				ctx,
+				name,
+				trace.WithAttributes(attrs...),
+				trace.WithSpanKind(trace.SpanKindServer),
+			)
+			return context.WithValue(ctx, spanKey{}, span), nil
+		},
+		Error: func(ctx context.Context, err twirp.Error) context.Context {
+			if span, ok := ctx.Value(spanKey{}).(trace.Span); ok {
+				span.SetAttributes(ErrorCodeKey.String(string(err.Code())))
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Msg())
+			}
+			return ctx
+		},
+		ResponseSent: func(ctx context.Context) {
+			span, ok := ctx.Value(spanKey{}).(trace.Span)
+			if !ok {
+				return
+			}
+			if status, ok := twirp.StatusCode(ctx); ok {
+				if code, err := strconv.Atoi(status); err == nil {
+					span.SetAttributes(semconv.HTTPStatusCodeKey.Int(code))
+				}
+			}
+			span.End()
+		},
+	}
+}
+
+// WithTraceContext returns a handler that extracts the span context
+// propagated in the request headers before calling h, a Twirp server.
+func WithTraceContext(h http.Handler, opts ...Option) http.Handler {
+	cfg := newConfig(opts...)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := cfg.Propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,17 @@
+module go.opentelemetry.io/contrib/instrumentation/github.com/twitchtv/twirp/oteltwirp/test
+
+go 1.15
+
+require (
+	github.com/stretchr/testify v1.7.0
+	github.com/twitchtv/twirp v8.1.0+incompatible
+	go.opentelemetry.io/contrib/instrumentation/github.com/twitchtv/twirp/oteltwirp v0.26.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+	google.golang.org/protobuf v1.27.1 // indirect
+)
+
+replace go.opentelemetry.io/contrib/instrumentation/github.com/twitchtv/twirp/oteltwirp => ../
+
+replace go.opentelemetry.io/contrib => ../../../../../../
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+	"github.com/twitchtv/twirp/example"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/twitchtv/twirp/oteltwirp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type haberdasher struct {
+	span trace.SpanContext
+}
+
+func (h *haberdasher) MakeHat(ctx context.Context, size *example.Size) (*example.Hat, error) {
+	h.span = trace.SpanContextFromContext(ctx)
+	if size.Inches <= 0 {
+		return nil, twirp.InvalidArgumentError("Inches", "I can't make a hat that small!")
+	}
+	return &example.Hat{Size: size.Inches, Color: "blue", Name: "bowler"}, nil
+}
+
+func newClient(t *testing.T, svc example.Haberdasher, opts ...oteltwirp.Option) example.Haberdasher {
+	server := example.NewHaberdasherServer(svc, oteltwirp.NewServerHooks(opts...))
+	srv := httptest.NewServer(oteltwirp.WithTraceContext(server, opts...))
+	t.Cleanup(srv.Close)
+
+	return example.NewHaberdasherProtobufClient(srv.URL, oteltwirp.NewHTTPClient(srv.Client(), opts...))
+}
+
+func TestRPC(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	svc := &haberdasher{}
+	client := newClient(t, svc,
+		oteltwirp.WithTracerProvider(provider),
+		oteltwirp.WithPropagators(propagation.TraceContext{}),
+	)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	hat, err := client.MakeHat(ctx, &example.Size{Inches: 12})
+	require.NoError(t, err)
+	assert.Equal(t, int32(12), hat.Size)
+	parent.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	serverSpan, clientSpan := spans[0], spans[1]
+
+	for _, span := range []sdktrace.ReadOnlySpan{serverSpan, clientSpan} {
+		assert.Equal(t, "twitch.twirp.example.Haberdasher/MakeHat", span.Name())
+		assert.Equal(t, codes.Unset, span.Status().Code)
+		attrs := span.Attributes()
+		assert.Contains(t, attrs, semconv.RPCSystemKey.String("twirp"))
+		assert.Contains(t, attrs, semconv.RPCServiceKey.String("twitch.twirp.example.Haberdasher"))
+		assert.Contains(t, attrs, semconv.RPCMethodKey.String("MakeHat"))
+		assert.Contains(t, attrs, semconv.HTTPStatusCodeKey.Int(http.StatusOK))
+	}
+
+	assert.Equal(t, trace.SpanKindClient, clientSpan.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), clientSpan.Parent().SpanID())
+
+	assert.Equal(t, trace.SpanKindServer, serverSpan.SpanKind())
+	assert.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+	assert.Equal(t, clientSpan.SpanContext().TraceID(), serverSpan.SpanContext().TraceID())
+	assert.Equal(t, serverSpan.SpanContext(), svc.span)
+}
+
+func TestRPCError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	client := newClient(t, &haberdasher{}, oteltwirp.WithTracerProvider(provider))
+
+	_, err := client.MakeHat(context.Background(), &example.Size{Inches: 0})
+	require.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	serverSpan, clientSpan := spans[0], spans[1]
+
+	assert.Equal(t, codes.Error, serverSpan.Status().Code)
+	assert.Equal(t, "Inches I can't make a hat that small!", serverSpan.Status().Description)
+	assert.Contains(t, serverSpan.Attributes(), attribute.String("rpc.twirp.error_code", string(twirp.InvalidArgument)))
+	assert.Contains(t, serverSpan.Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusBadRequest))
+
+	assert.Equal(t, codes.Error, clientSpan.Status().Code)
+	assert.Contains(t, clientSpan.Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusBadRequest))
+}
+
+func TestServerHooksWithOuterSpan(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	hooks := oteltwirp.NewServerHooks(oteltwirp.WithTracerProvider(provider))
+
+	// A request that is not routed does not end a span of the context.
+	ctx, outer := provider.Tracer("test").Start(context.Background(), "outer")
+	ctx = hooks.Error(ctx, twirp.NewError(twirp.BadRoute, "no handler"))
+	hooks.ResponseSent(ctx)
+	assert.Empty(t, sr.Ended())
+
+	outer.End()
+	require.Len(t, sr.Ended(), 1)
+	assert.Equal(t, codes.Unset, sr.Ended()[0].Status().Code)
+}
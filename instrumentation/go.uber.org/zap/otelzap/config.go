@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelzap
+
+const (
+	// DefaultTraceIDKey is the default key of the trace ID field.
+	DefaultTraceIDKey = "trace_id"
+	// DefaultSpanIDKey is the default key of the span ID field.
+	DefaultSpanIDKey = "span_id"
+	// DefaultTraceFlagsKey is the default key of the trace flags field.
+	DefaultTraceFlagsKey = "trace_flags"
+	// DefaultXRayTraceIDKey is the default key of the X-Ray trace ID field
+	// added by WithXRayTraceID.
+	DefaultXRayTraceIDKey = "xray_trace_id"
+)
+
+type config struct {
+	TraceIDKey     string
+	SpanIDKey      string
+	TraceFlagsKey  string
+	XRayTraceIDKey string
+}
+
+// newConfig returns a config with all Options set.
+func newConfig(opts ...Option) config {
+	cfg := config{
+		TraceIDKey:    DefaultTraceIDKey,
+		SpanIDKey:     DefaultSpanIDKey,
+		TraceFlagsKey: DefaultTraceFlagsKey,
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	return cfg
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithTraceIDKey sets the key of the trace ID field. If none is specified,
+// DefaultTraceIDKey is used.
+func WithTraceIDKey(key string) Option {
+	return optionFunc(func(cfg *config) {
+		if key != "" {
+			cfg.TraceIDKey = key
+		}
+	})
+}
+
+// WithSpanIDKey sets the key of the span ID field. If none is specified,
+// DefaultSpanIDKey is used.
+func WithSpanIDKey(key string) Option {
+	return optionFunc(func(cfg *config) {
+		if key != "" {
+			cfg.SpanIDKey = key
+		}
+	})
+}
+
+// WithTraceFlagsKey sets the key of the trace flags field. If none is
+// specified, DefaultTraceFlagsKey is used.
+func WithTraceFlagsKey(key string) Option {
+	return optionFunc(func(cfg *config) {
+		if key != "" {
+			cfg.TraceFlagsKey = key
+		}
+	})
+}
+
+// WithXRayTraceID adds a field holding the trace ID in the AWS X-Ray format
+// (1-{epoch}-{random}). The key of the field is key, or
+// DefaultXRayTraceIDKey if key is empty.
+func WithXRayTraceID(key string) Option {
+	return optionFunc(func(cfg *config) {
+		if key == "" {
+			key = DefaultXRayTraceIDKey
+		}
+		cfg.XRayTraceIDKey = key
+	})
+}
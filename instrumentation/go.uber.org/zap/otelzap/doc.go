@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelzap correlates the logs of the go.uber.org/zap package with
+// OpenTelemetry traces.
+//
+// Fields returns the trace ID, span ID and trace flags of the span found in
+// a context as zap fields. Alternatively, wrap the zapcore.Core of a logger
+// with NewCore and pass Context(ctx) as a field when logging: the core
+// replaces it with the fields of the span found in ctx.
+//
+//	logger := zap.New(otelzap.NewCore(core))
+//	logger.Info("handled request", otelzap.Context(ctx))
+//
+// The trace ID is written in the W3C Trace Context format, as used by Loki
+// derived fields and most tracing backends. WithXRayTraceID adds the trace ID
+// in the AWS X-Ray format as well, for correlation with X-Ray in CloudWatch.
+package otelzap // import "go.opentelemetry.io/contrib/instrumentation/go.uber.org/zap/otelzap"
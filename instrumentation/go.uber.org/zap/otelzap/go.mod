@@ -0,0 +1,9 @@
+module go.opentelemetry.io/contrib/instrumentation/go.uber.org/zap/otelzap
+
+go 1.15
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/otel/trace v1.1.0
+	go.uber.org/zap v1.19.1
+)
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelzap
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Fields returns the fields correlating a log entry with the span found in
+// ctx. No fields are returned if ctx does not hold a valid span context.
+func Fields(ctx context.Context, opts ...Option) []zap.Field {
+	cfg := newConfig(opts...)
+	return cfg.fields(trace.SpanContextFromContext(ctx))
+}
+
+func (cfg config) fields(sc trace.SpanContext) []zap.Field {
+	if !sc.IsValid() {
+		return nil
+	}
+
+	fields := make([]zap.Field, 0, 4)
+	fields = append(fields,
+		zap.String(cfg.TraceIDKey, sc.TraceID().String()),
+		zap.String(cfg.SpanIDKey, sc.SpanID().String()),
+		zap.String(cfg.TraceFlagsKey, sc.TraceFlags().String()),
+	)
+	if cfg.XRayTraceIDKey != "" {
+		fields = append(fields, zap.String(cfg.XRayTraceIDKey, xrayTraceID(sc.TraceID())))
+	}
+	return fields
+}
+
+// xrayTraceID returns traceID in the 1-{epoch}-{random} format of AWS X-Ray,
+// where epoch is the first 8 and random the last 24 hex characters of
+// traceID.
+func xrayTraceID(traceID trace.TraceID) string {
+	s := traceID.String()
+	return "1-" + s[:8] + "-" + s[8:]
+}
+
+// contextValue is the value of a field returned by Context.
+type contextValue struct {
+	ctx context.Context
+}
+
+// Context returns a field carrying ctx. When logged through a core returned
+// by NewCore, the field is replaced with the fields correlating the log entry
+// with the span found in ctx. Other cores ignore the field.
+func Context(ctx context.Context) zap.Field {
+	return zap.Field{Type: zapcore.SkipType, Interface: contextValue{ctx: ctx}}
+}
+
+// core wraps a zapcore.Core to replace the fields returned by Context.
+type core struct {
+	zapcore.Core
+	cfg config
+}
+
+var _ zapcore.Core = core{}
+
+// NewCore returns a zapcore.Core writing to c that replaces any field
+// returned by Context with the fields correlating the log entry with the
+// span found in its context.
+func NewCore(c zapcore.Core, opts ...Option) zapcore.Core {
+	return core{Core: c, cfg: newConfig(opts...)}
+}
+
+// With adds structured context to the core.
+func (c core) With(fields []zapcore.Field) zapcore.Core {
+	return core{Core: c.Core.With(c.expand(fields)), cfg: c.cfg}
+}
+
+// Check determines whether the supplied entry should be logged.
+func (c core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write serializes the entry and fields to the wrapped core.
+func (c core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.expand(fields))
+}
+
+// expand returns fields with every field returned by Context replaced by
+// the correlation fields of its context.
+func (c core) expand(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		v, ok := f.Interface.(contextValue)
+		if !ok || f.Type != zapcore.SkipType {
+			if out != nil {
+				out = append(out, f)
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]zapcore.Field, i, len(fields)+3)
+			copy(out, fields[:i])
+		}
+		out = append(out, c.cfg.fields(trace.SpanContextFromContext(v.ctx))...)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
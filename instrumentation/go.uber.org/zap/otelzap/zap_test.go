@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelzap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	traceID = trace.TraceID{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0x03, 0x81, 0x03, 0xd2, 0x69, 0xb6, 0x33, 0x81, 0x3f, 0xc6, 0x0c}
+	spanID  = trace.SpanID{0xee, 0xe1, 0x9b, 0x7e, 0xc3, 0xc1, 0xb1, 0x74}
+
+	spanCtx = trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+)
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, []zap.Field{
+		zap.String("trace_id", "5b8efff798038103d269b633813fc60c"),
+		zap.String("span_id", "eee19b7ec3c1b174"),
+		zap.String("trace_flags", "01"),
+	}, Fields(spanCtx))
+
+	assert.Equal(t, []zap.Field{
+		zap.String("traceID", "5b8efff798038103d269b633813fc60c"),
+		zap.String("spanID", "eee19b7ec3c1b174"),
+		zap.String("flags", "01"),
+		zap.String("xray_trace_id", "1-5b8efff7-98038103d269b633813fc60c"),
+	}, Fields(spanCtx,
+		WithTraceIDKey("traceID"),
+		WithSpanIDKey("spanID"),
+		WithTraceFlagsKey("flags"),
+		WithXRayTraceID(""),
+	))
+
+	assert.Empty(t, Fields(context.Background()))
+}
+
+func TestCore(t *testing.T) {
+	obs, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(NewCore(obs, WithXRayTraceID("AWS-XRAY-TRACE-ID")))
+
+	logger.Info("traced", zap.String("before", "a"), Context(spanCtx), zap.String("after", "b"))
+	logger.Info("untraced", Context(context.Background()), zap.String("after", "b"))
+	logger.With(Context(spanCtx)).Info("with")
+	logger.Debug("disabled", Context(spanCtx))
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "traced", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{
+		"before":            "a",
+		"trace_id":          "5b8efff798038103d269b633813fc60c",
+		"span_id":           "eee19b7ec3c1b174",
+		"trace_flags":       "01",
+		"AWS-XRAY-TRACE-ID": "1-5b8efff7-98038103d269b633813fc60c",
+		"after":             "b",
+	}, entries[0].ContextMap())
+
+	assert.Equal(t, "untraced", entries[1].Message)
+	assert.Equal(t, map[string]interface{}{"after": "b"}, entries[1].ContextMap())
+
+	assert.Equal(t, "with", entries[2].Message)
+	assert.Equal(t, "5b8efff798038103d269b633813fc60c", entries[2].ContextMap()["trace_id"])
+}
+
+func TestContextWithoutCore(t *testing.T) {
+	obs, logs := observer.New(zapcore.InfoLevel)
+	zap.New(obs).Info("msg", Context(spanCtx))
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Empty(t, entries[0].ContextMap())
+}
@@ -5,6 +5,7 @@ go 1.15
 replace (
 	go.opentelemetry.io/contrib => ../../../../../
 	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc => ../
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../../requestfilter
 )
 
 require (
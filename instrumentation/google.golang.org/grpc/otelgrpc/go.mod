@@ -2,10 +2,14 @@ module go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgr
 
 go 1.15
 
-replace go.opentelemetry.io/contrib => ../../../../
+replace (
+	go.opentelemetry.io/contrib => ../../../../
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../requestfilter
+)
 
 require (
 	github.com/golang/protobuf v1.5.2
+	go.opentelemetry.io/contrib/instrumentation/requestfilter v0.26.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/trace v1.1.0
 	google.golang.org/grpc v1.41.0
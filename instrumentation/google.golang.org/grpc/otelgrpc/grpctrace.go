@@ -19,6 +19,7 @@ import (
 
 	"google.golang.org/grpc/metadata"
 
+	"go.opentelemetry.io/contrib/instrumentation/requestfilter"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/baggage"
@@ -37,6 +38,8 @@ const (
 type config struct {
 	Propagators    propagation.TextMapPropagator
 	TracerProvider trace.TracerProvider
+	Filters        []requestfilter.Filter
+	Sanitizer      requestfilter.Sanitizer
 }
 
 // Option applies an option value for a config.
@@ -84,31 +87,55 @@ func WithTracerProvider(tp trace.TracerProvider) Option {
 	return tracerProviderOption{tp: tp}
 }
 
-type metadataSupplier struct {
-	metadata *metadata.MD
+type requestFilterOption struct{ f requestfilter.Filter }
+
+func (o requestFilterOption) apply(c *config) {
+	if o.f != nil {
+		c.Filters = append(c.Filters, o.f)
+	}
 }
 
-// assert that metadataSupplier implements the TextMapCarrier interface
-var _ propagation.TextMapCarrier = &metadataSupplier{}
+// WithRequestFilter returns an Option adding a filter shared with the other
+// instrumentations to the filters of the interceptors. The calls are only
+// traced if all the filters return true for them, with the full method name
+// as path, the target or authority as host, and the metadata as headers.
+func WithRequestFilter(f requestfilter.Filter) Option {
+	return requestFilterOption{f: f}
+}
 
-func (s *metadataSupplier) Get(key string) string {
-	values := s.metadata.Get(key)
-	if len(values) == 0 {
-		return ""
-	}
-	return values[0]
+type requestSanitizerOption struct{ s requestfilter.Sanitizer }
+
+func (o requestSanitizerOption) apply(c *config) {
+	c.Sanitizer = o.s
 }
 
-func (s *metadataSupplier) Set(key string, value string) {
-	s.metadata.Set(key, value)
+// WithRequestSanitizer returns an Option to apply the Sanitizer to the
+// attributes of the calls before they are recorded.
+func WithRequestSanitizer(s requestfilter.Sanitizer) Option {
+	return requestSanitizerOption{s: s}
+}
+
+// traced returns whether the call of fullMethod to authority with the
+// metadata md passes the filters.
+func (c *config) traced(fullMethod, authority string, md metadata.MD) bool {
+	if len(c.Filters) == 0 {
+		return true
+	}
+	req := requestfilter.FromGRPC(fullMethod, authority, md)
+	for _, f := range c.Filters {
+		if !f(req) {
+			return false
+		}
+	}
+	return true
 }
 
-func (s *metadataSupplier) Keys() []string {
-	out := make([]string, 0, len(*s.metadata))
-	for key := range *s.metadata {
-		out = append(out, key)
+// sanitize returns attrs sanitized by the configured sanitizer.
+func (c *config) sanitize(attrs []attribute.KeyValue) []attribute.KeyValue {
+	if c.Sanitizer == nil {
+		return attrs
 	}
-	return out
+	return c.Sanitizer(attrs)
 }
 
 // Inject injects correlation context and span context into the gRPC
@@ -116,9 +143,7 @@ func (s *metadataSupplier) Keys() []string {
 // requests.
 func Inject(ctx context.Context, metadata *metadata.MD, opts ...Option) {
 	c := newConfig(opts)
-	c.Propagators.Inject(ctx, &metadataSupplier{
-		metadata: metadata,
-	})
+	c.Propagators.Inject(ctx, MetadataCarrier(*metadata))
 }
 
 // Extract returns the correlation context and span context that
@@ -126,9 +151,7 @@ func Inject(ctx context.Context, metadata *metadata.MD, opts ...Option) {
 // This function is meant to be used on incoming requests.
 func Extract(ctx context.Context, metadata *metadata.MD, opts ...Option) (baggage.Baggage, trace.SpanContext) {
 	c := newConfig(opts)
-	ctx = c.Propagators.Extract(ctx, &metadataSupplier{
-		metadata: metadata,
-	})
+	ctx = c.Propagators.Extract(ctx, MetadataCarrier(*metadata))
 
 	return baggage.FromContext(ctx), trace.SpanContextFromContext(ctx)
 }
@@ -74,9 +74,13 @@ func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
 		callOpts ...grpc.CallOption,
 	) error {
 		requestMetadata, _ := metadata.FromOutgoingContext(ctx)
+		cfg := newConfig(opts)
+		if !cfg.traced(method, cc.Target(), requestMetadata) {
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		}
 		metadataCopy := requestMetadata.Copy()
 
-		tracer := newConfig(opts).TracerProvider.Tracer(
+		tracer := cfg.TracerProvider.Tracer(
 			instrumentationName,
 			trace.WithInstrumentationVersion(SemVersion()),
 		)
@@ -87,7 +91,7 @@ func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
 			ctx,
 			name,
 			trace.WithSpanKind(trace.SpanKindClient),
-			trace.WithAttributes(attr...),
+			trace.WithAttributes(cfg.sanitize(attr)...),
 		)
 		defer span.End()
 
@@ -244,9 +248,13 @@ func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
 		callOpts ...grpc.CallOption,
 	) (grpc.ClientStream, error) {
 		requestMetadata, _ := metadata.FromOutgoingContext(ctx)
+		cfg := newConfig(opts)
+		if !cfg.traced(method, cc.Target(), requestMetadata) {
+			return streamer(ctx, desc, cc, method, callOpts...)
+		}
 		metadataCopy := requestMetadata.Copy()
 
-		tracer := newConfig(opts).TracerProvider.Tracer(
+		tracer := cfg.TracerProvider.Tracer(
 			instrumentationName,
 			trace.WithInstrumentationVersion(SemVersion()),
 		)
@@ -257,7 +265,7 @@ func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
 			ctx,
 			name,
 			trace.WithSpanKind(trace.SpanKindClient),
-			trace.WithAttributes(attr...),
+			trace.WithAttributes(cfg.sanitize(attr)...),
 		)
 
 		Inject(ctx, &metadataCopy, opts...)
@@ -301,12 +309,16 @@ func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
 		requestMetadata, _ := metadata.FromIncomingContext(ctx)
+		cfg := newConfig(opts)
+		if !cfg.traced(info.FullMethod, authority(requestMetadata), requestMetadata) {
+			return handler(ctx, req)
+		}
 		metadataCopy := requestMetadata.Copy()
 
 		bags, spanCtx := Extract(ctx, &metadataCopy, opts...)
 		ctx = baggage.ContextWithBaggage(ctx, bags)
 
-		tracer := newConfig(opts).TracerProvider.Tracer(
+		tracer := cfg.TracerProvider.Tracer(
 			instrumentationName,
 			trace.WithInstrumentationVersion(SemVersion()),
 		)
@@ -316,7 +328,7 @@ func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
 			trace.ContextWithRemoteSpanContext(ctx, spanCtx),
 			name,
 			trace.WithSpanKind(trace.SpanKindServer),
-			trace.WithAttributes(attr...),
+			trace.WithAttributes(cfg.sanitize(attr)...),
 		)
 		defer span.End()
 
@@ -390,12 +402,16 @@ func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
 		ctx := ss.Context()
 
 		requestMetadata, _ := metadata.FromIncomingContext(ctx)
+		cfg := newConfig(opts)
+		if !cfg.traced(info.FullMethod, authority(requestMetadata), requestMetadata) {
+			return handler(srv, ss)
+		}
 		metadataCopy := requestMetadata.Copy()
 
 		bags, spanCtx := Extract(ctx, &metadataCopy, opts...)
 		ctx = baggage.ContextWithBaggage(ctx, bags)
 
-		tracer := newConfig(opts).TracerProvider.Tracer(
+		tracer := cfg.TracerProvider.Tracer(
 			instrumentationName,
 			trace.WithInstrumentationVersion(SemVersion()),
 		)
@@ -405,7 +421,7 @@ func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
 			trace.ContextWithRemoteSpanContext(ctx, spanCtx),
 			name,
 			trace.WithSpanKind(trace.SpanKindServer),
-			trace.WithAttributes(attr...),
+			trace.WithAttributes(cfg.sanitize(attr)...),
 		)
 		defer span.End()
 
@@ -450,6 +466,15 @@ func peerAttr(addr string) []attribute.KeyValue {
 	}
 }
 
+// authority returns the authority of the call from the metadata of a
+// server, if any.
+func authority(md metadata.MD) string {
+	if values := md.Get(":authority"); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
 // peerFromCtx returns a peer address from a context, if one exists.
 func peerFromCtx(ctx context.Context) string {
 	p, ok := peer.FromContext(ctx)
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgrpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// MetadataCarrier adapts gRPC metadata to the propagation.TextMapCarrier
+// interface, so that any propagator can inject to and extract from it.
+//
+// Keys are case insensitive, as gRPC metadata keys are lowercase. Get
+// returns the values of a key that has several of them joined with commas,
+// as for HTTP headers, and Set replaces all the values of a key.
+type MetadataCarrier metadata.MD
+
+// assert that MetadataCarrier implements the TextMapCarrier interface
+var _ propagation.TextMapCarrier = MetadataCarrier{}
+
+// Get returns the values of key joined with commas, or an empty string if
+// key has no value.
+func (c MetadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.Join(values, ",")
+}
+
+// Set sets the value of key, replacing its previous values.
+func (c MetadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys returns the keys of the metadata.
+func (c MetadataCarrier) Keys() []string {
+	out := make([]string, 0, len(c))
+	for key := range c {
+		out = append(out, key)
+	}
+	return out
+}
+
+// InjectOutgoing returns a copy of ctx with the correlation context and span
+// context of ctx injected in its outgoing gRPC metadata. It is meant to be
+// used by clients not using the interceptors of this package.
+func InjectOutgoing(ctx context.Context, opts ...Option) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	Inject(ctx, &md, opts...)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// ExtractIncoming returns a copy of ctx with the correlation context and span
+// context extracted from its incoming gRPC metadata. It is meant to be used
+// by servers not using the interceptors of this package.
+func ExtractIncoming(ctx context.Context, opts ...Option) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	c := newConfig(opts)
+	return c.Propagators.Extract(ctx, MetadataCarrier(md))
+}
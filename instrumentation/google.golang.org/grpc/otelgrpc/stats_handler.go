@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgrpc
+
+import (
+	"context"
+	"sync/atomic"
+
+	grpc_codes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// gRPCContextKey is the key of the gRPCContext of an RPC in its context.
+type gRPCContextKey struct{}
+
+// gRPCContext holds the state of an RPC traced by a stats handler.
+type gRPCContext struct {
+	messagesReceived int64
+	messagesSent     int64
+}
+
+// compile time assertions that the handlers implement the stats.Handler
+// interface.
+var (
+	_ stats.Handler = (*serverHandler)(nil)
+	_ stats.Handler = (*clientHandler)(nil)
+)
+
+type serverHandler struct {
+	*config
+	tracer trace.Tracer
+}
+
+type clientHandler struct {
+	*config
+	tracer trace.Tracer
+}
+
+// NewServerHandler returns a grpc stats.Handler, to be used with the
+// grpc.StatsHandler server option, that traces the RPCs of a server.
+//
+// Unlike the server interceptors, the stats handler sees every message of
+// an RPC as it is read or written on the wire, so it records an event with
+// the compressed and uncompressed size of each message, including for
+// streaming RPCs. It is an alternative to the server interceptors and
+// should not be used along with them.
+func NewServerHandler(opts ...Option) stats.Handler {
+	c := newConfig(opts)
+	return &serverHandler{
+		config: c,
+		tracer: c.TracerProvider.Tracer(
+			instrumentationName,
+			trace.WithInstrumentationVersion(SemVersion()),
+		),
+	}
+}
+
+// TagConn can attach some information to the given context.
+func (h *serverHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn processes the Conn stats.
+func (h *serverHandler) HandleConn(ctx context.Context, info stats.ConnStats) {}
+
+// TagRPC starts the server span of the RPC.
+func (h *serverHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = h.Propagators.Extract(ctx, MetadataCarrier(md))
+	bags := baggage.FromContext(ctx)
+
+	name, attrs := spanInfo(info.FullMethodName, peerFromCtx(ctx))
+	ctx, _ = h.tracer.Start(
+		ctx,
+		name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...),
+	)
+	ctx = baggage.ContextWithBaggage(ctx, bags)
+	return context.WithValue(ctx, gRPCContextKey{}, &gRPCContext{})
+}
+
+// HandleRPC records the stats of the RPC on its span.
+func (h *serverHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	handleRPC(ctx, rs)
+}
+
+// NewClientHandler returns a grpc stats.Handler, to be used with the
+// grpc.WithStatsHandler dial option, that traces the RPCs of a client.
+//
+// Unlike the client interceptors, the stats handler sees every message of
+// an RPC as it is read or written on the wire, so it records an event with
+// the compressed and uncompressed size of each message, including for
+// streaming RPCs. It is an alternative to the client interceptors and
+// should not be used along with them.
+func NewClientHandler(opts ...Option) stats.Handler {
+	c := newConfig(opts)
+	return &clientHandler{
+		config: c,
+		tracer: c.TracerProvider.Tracer(
+			instrumentationName,
+			trace.WithInstrumentationVersion(SemVersion()),
+		),
+	}
+}
+
+// TagConn can attach some information to the given context.
+func (h *clientHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn processes the Conn stats.
+func (h *clientHandler) HandleConn(ctx context.Context, info stats.ConnStats) {}
+
+// TagRPC starts the client span of the RPC and injects its span context
+// into the outgoing metadata.
+func (h *clientHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	name, attrs := spanInfo(info.FullMethodName, "")
+	ctx, _ = h.tracer.Start(
+		ctx,
+		name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	h.Propagators.Inject(ctx, MetadataCarrier(md))
+	ctx = metadata.NewOutgoingContext(ctx, md)
+	return context.WithValue(ctx, gRPCContextKey{}, &gRPCContext{})
+}
+
+// HandleRPC records the stats of the RPC on its span.
+func (h *clientHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	handleRPC(ctx, rs)
+}
+
+func handleRPC(ctx context.Context, rs stats.RPCStats) {
+	span := trace.SpanFromContext(ctx)
+	gctx, _ := ctx.Value(gRPCContextKey{}).(*gRPCContext)
+	var messageID int64
+
+	switch rs := rs.(type) {
+	case *stats.InPayload:
+		if gctx != nil {
+			messageID = atomic.AddInt64(&gctx.messagesReceived, 1)
+		}
+		span.AddEvent("message", trace.WithAttributes(
+			RPCMessageTypeReceived,
+			RPCMessageIDKey.Int64(messageID),
+			RPCMessageCompressedSizeKey.Int(rs.WireLength),
+			RPCMessageUncompressedSizeKey.Int(rs.Length),
+		))
+	case *stats.OutPayload:
+		if gctx != nil {
+			messageID = atomic.AddInt64(&gctx.messagesSent, 1)
+		}
+		span.AddEvent("message", trace.WithAttributes(
+			RPCMessageTypeSent,
+			RPCMessageIDKey.Int64(messageID),
+			RPCMessageCompressedSizeKey.Int(rs.WireLength),
+			RPCMessageUncompressedSizeKey.Int(rs.Length),
+		))
+	case *stats.OutHeader:
+		if rs.Client && rs.RemoteAddr != nil {
+			span.SetAttributes(peerAttr(rs.RemoteAddr.String())...)
+		}
+	case *stats.End:
+		if rs.Error != nil {
+			s, _ := status.FromError(rs.Error)
+			span.SetStatus(codes.Error, s.Message())
+			span.SetAttributes(statusCodeAttr(s.Code()))
+		} else {
+			span.SetAttributes(statusCodeAttr(grpc_codes.OK))
+		}
+		span.End(trace.WithTimestamp(rs.EndTime))
+	}
+}
@@ -6,8 +6,10 @@ require (
 	github.com/golang/protobuf v1.5.2
 	github.com/stretchr/testify v1.7.0
 	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc v0.26.0
+	go.opentelemetry.io/contrib/instrumentation/requestfilter v0.26.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
 	go.uber.org/goleak v1.1.12
 	google.golang.org/grpc v1.41.0
 )
@@ -15,4 +17,5 @@ require (
 replace (
 	go.opentelemetry.io/contrib => ../../../../../
 	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc => ../
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../../requestfilter
 )
@@ -25,6 +25,7 @@ import (
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/internal"
+	"go.opentelemetry.io/contrib/instrumentation/requestfilter"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/sdk/trace"
@@ -662,6 +663,41 @@ func TestServerInterceptorError(t *testing.T) {
 	}, span.Events()[1].Attributes)
 }
 
+func TestServerInterceptorRequestFilter(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+	usi := otelgrpc.UnaryServerInterceptor(
+		otelgrpc.WithTracerProvider(tp),
+		otelgrpc.WithRequestFilter(requestfilter.Not(requestfilter.HealthCheck())),
+		otelgrpc.WithRequestFilter(requestfilter.Not(requestfilter.Header("x-probe", "kubelet"))),
+		otelgrpc.WithRequestSanitizer(func(attrs []attribute.KeyValue) []attribute.KeyValue {
+			return append(attrs, attribute.Bool("sanitized", true))
+		}),
+	)
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		return &mockProtoMessage{}, nil
+	}
+
+	calls := []struct {
+		method string
+		md     metadata.MD
+	}{
+		{"/grpc.health.v1.Health/Check", nil},
+		{"/github.com.foo.serviceName_123/method", metadata.Pairs("x-probe", "kubelet")},
+		{"/github.com.foo.serviceName_123/method", nil},
+	}
+	for _, c := range calls {
+		ctx := metadata.NewIncomingContext(context.Background(), c.md)
+		_, err := usi(ctx, &mockProtoMessage{}, &grpc.UnaryServerInfo{FullMethod: c.method}, handler)
+		require.NoError(t, err)
+	}
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "github.com.foo.serviceName_123/method", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.Bool("sanitized", true))
+}
+
 func TestParseFullMethod(t *testing.T) {
 	tests := []struct {
 		fullMethod string
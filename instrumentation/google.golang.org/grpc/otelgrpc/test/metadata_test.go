@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	testPropagators = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	testSpanContext = trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+)
+
+func TestMetadataCarrier(t *testing.T) {
+	md := metadata.Pairs("X-Amzn-Trace-Id", "Root=1", "tracestate", "a=1", "tracestate", "b=2")
+	carrier := otelgrpc.MetadataCarrier(md)
+
+	assert.Equal(t, "Root=1", carrier.Get("x-amzn-trace-id"))
+	assert.Equal(t, "Root=1", carrier.Get("X-Amzn-Trace-Id"))
+	assert.Equal(t, "a=1,b=2", carrier.Get("tracestate"))
+	assert.Equal(t, "", carrier.Get("missing"))
+
+	carrier.Set("Tracestate", "c=3")
+	assert.Equal(t, []string{"c=3"}, md.Get("tracestate"))
+	assert.ElementsMatch(t, []string{"x-amzn-trace-id", "tracestate"}, carrier.Keys())
+}
+
+func TestInjectOutgoingExtractIncoming(t *testing.T) {
+	m, err := baggage.NewMember("key", "value")
+	require.NoError(t, err)
+	bag, err := baggage.New(m)
+	require.NoError(t, err)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "existing", "value")
+	ctx = baggage.ContextWithBaggage(trace.ContextWithSpanContext(ctx, testSpanContext), bag)
+	ctx = otelgrpc.InjectOutgoing(ctx, otelgrpc.WithPropagators(testPropagators))
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, []string{"value"}, md.Get("existing"))
+	assert.NotEmpty(t, md.Get("traceparent"))
+
+	ctx = otelgrpc.ExtractIncoming(metadata.NewIncomingContext(context.Background(), md), otelgrpc.WithPropagators(testPropagators))
+	assert.Equal(t, testSpanContext.WithRemote(true), trace.SpanContextFromContext(ctx))
+	assert.Equal(t, "value", baggage.FromContext(ctx).Member("key").Value())
+}
+
+func TestExtractIncomingNoMetadata(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, otelgrpc.ExtractIncoming(ctx, otelgrpc.WithPropagators(testPropagators)))
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestStatsHandler(t *testing.T) {
+	clientSR := tracetest.NewSpanRecorder()
+	clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+
+	serverSR := tracetest.NewSpanRecorder()
+	serverTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverSR))
+
+	prop := otelgrpc.WithPropagators(propagation.TraceContext{})
+	assert.NoError(t, doCalls(
+		[]grpc.DialOption{
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithTracerProvider(clientTP), prop)),
+		},
+		[]grpc.ServerOption{
+			grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithTracerProvider(serverTP), prop)),
+		},
+	))
+
+	// The number of messages sent and received by the client for each call.
+	calls := []struct {
+		name           string
+		sent, received int
+	}{
+		{"grpc.testing.TestService/EmptyCall", 1, 1},
+		{"grpc.testing.TestService/UnaryCall", 1, 1},
+		{"grpc.testing.TestService/StreamingInputCall", 4, 1},
+		{"grpc.testing.TestService/StreamingOutputCall", 1, 4},
+		{"grpc.testing.TestService/FullDuplexCall", 4, 4},
+	}
+
+	t.Run("ClientSpans", func(t *testing.T) {
+		spans := clientSR.Ended()
+		require.Len(t, spans, len(calls))
+		for i, c := range calls {
+			checkStatsHandlerSpan(t, spans[i], c.name, oteltrace.SpanKindClient, c.sent, c.received)
+		}
+	})
+
+	t.Run("ServerSpans", func(t *testing.T) {
+		spans := serverSR.Ended()
+		require.Len(t, spans, len(calls))
+		for i, c := range calls {
+			checkStatsHandlerSpan(t, spans[i], c.name, oteltrace.SpanKindServer, c.received, c.sent)
+		}
+	})
+
+	t.Run("Propagation", func(t *testing.T) {
+		clientSpans := clientSR.Ended()
+		serverSpans := serverSR.Ended()
+		require.Len(t, serverSpans, len(clientSpans))
+		for i := range clientSpans {
+			assert.Equal(t, clientSpans[i].SpanContext().TraceID(), serverSpans[i].SpanContext().TraceID())
+			assert.Equal(t, clientSpans[i].SpanContext().SpanID(), serverSpans[i].Parent().SpanID())
+		}
+	})
+}
+
+func checkStatsHandlerSpan(t *testing.T, span trace.ReadOnlySpan, name string, kind oteltrace.SpanKind, sent, received int) {
+	assert.Equal(t, name, span.Name())
+	assert.Equal(t, kind, span.SpanKind())
+	assert.False(t, span.EndTime().IsZero())
+	assert.Contains(t, span.Attributes(), otelgrpc.GRPCStatusCodeKey.Int64(int64(codes.OK)))
+
+	var sentIDs, receivedIDs []int64
+	for _, e := range span.Events() {
+		assert.Equal(t, "message", e.Name)
+		attrs := attribute.NewSet(e.Attributes...)
+		for _, k := range []attribute.Key{otelgrpc.RPCMessageCompressedSizeKey, otelgrpc.RPCMessageUncompressedSizeKey} {
+			assert.True(t, attrs.HasValue(k), "%s: missing %s", name, k)
+		}
+		id, _ := attrs.Value(otelgrpc.RPCMessageIDKey)
+		typ, _ := attrs.Value(otelgrpc.RPCMessageTypeKey)
+		switch typ.AsString() {
+		case "SENT":
+			sentIDs = append(sentIDs, id.AsInt64())
+		case "RECEIVED":
+			receivedIDs = append(receivedIDs, id.AsInt64())
+		default:
+			t.Errorf("%s: unexpected message type %q", name, typ.AsString())
+		}
+	}
+	assert.Equal(t, sequence(sent), sentIDs, "%s: sent messages", name)
+	assert.Equal(t, sequence(received), receivedIDs, "%s: received messages", name)
+}
+
+// sequence returns the message IDs 1 to n.
+func sequence(n int) []int64 {
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	return ids
+}
@@ -5,10 +5,12 @@ go 1.15
 replace (
 	go.opentelemetry.io/contrib => ../../../..
 	go.opentelemetry.io/contrib/propagators/b3 => ../../../../propagators/b3
+	go.opentelemetry.io/contrib/propagators/propagatortest => ../../../../propagators/propagatortest
 )
 
 require (
 	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib v1.1.0
 	go.opentelemetry.io/contrib/propagators/b3 v1.1.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/trace v1.1.0
@@ -23,11 +23,22 @@
 //
 //   Name			Attribute
 // ----------------------------------------------------------------------
-//   process.cpu.time           state=user|system
-//   system.cpu.time            state=user|system|other|idle
-//   system.memory.usage        state=used|available
-//   system.memory.utilization  state=used|available
-//   system.network.io          direction=transmit|receive
+//   process.cpu.time             state=user|system
+//   system.cpu.time              state=user|system|other|idle
+//   system.cpu.load_average.1m   (none)
+//   system.cpu.load_average.5m   (none)
+//   system.cpu.load_average.15m  (none)
+//   system.memory.usage          state=used|available
+//   system.memory.utilization    state=used|available
+//   system.disk.io               device=<name>, direction=read|write
+//   system.disk.operations       device=<name>, direction=read|write
+//   system.network.io            direction=transmit|receive
+//
+// Load averages and disk statistics are omitted on platforms where they
+// cannot be read.  The statistics are read every time the metrics are
+// collected, at the collection interval of the configured
+// metric.MeterProvider (e.g. the push interval of an exporter).  Use
+// WithMinimumReadInterval to read them less often.
 //
 // See https://github.com/open-telemetry/oteps/blob/main/text/0119-standard-system-metrics.md
 // for the definition of these metric instruments.
@@ -19,8 +19,11 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/load"
 	"github.com/shirou/gopsutil/v3/mem"
 	"github.com/shirou/gopsutil/v3/net"
 	"github.com/shirou/gopsutil/v3/process"
@@ -40,6 +43,10 @@ type host struct {
 
 // config contains optional settings for reporting host metrics.
 type config struct {
+	// MinimumReadInterval sets the minimum interval between reads of
+	// the host statistics.  Negative values are ignored.
+	MinimumReadInterval time.Duration
+
 	// MeterProvider sets the metric.MeterProvider.  If nil, the global
 	// Provider will be used.
 	MeterProvider metric.MeterProvider
@@ -50,6 +57,29 @@ type Option interface {
 	apply(*config)
 }
 
+// DefaultMinimumReadInterval is the default minimum interval between
+// reads of the host statistics.  The default of zero reads the statistics
+// every time the metrics are collected.  Use the WithMinimumReadInterval()
+// option to modify this setting in Start().
+const DefaultMinimumReadInterval time.Duration = 0
+
+// WithMinimumReadInterval sets a minimum interval between reads of the host
+// statistics.  Collections that happen sooner than `d` after the previous
+// read report the previously read values, which bounds the cost of reading
+// the statistics independently of how often the metrics are collected.  This
+// setting is ignored when `d` is negative.
+func WithMinimumReadInterval(d time.Duration) Option {
+	return minimumReadIntervalOption(d)
+}
+
+type minimumReadIntervalOption time.Duration
+
+func (o minimumReadIntervalOption) apply(c *config) {
+	if o >= 0 {
+		c.MinimumReadInterval = time.Duration(o)
+	}
+}
+
 // WithMeterProvider sets the Metric implementation to use for
 // reporting.  If this option is not used, the global metric.MeterProvider
 // will be used.  `provider` must be non-nil.
@@ -83,12 +113,23 @@ var (
 
 	AttributeNetworkTransmit = []attribute.KeyValue{attribute.String("direction", "transmit")}
 	AttributeNetworkReceive  = []attribute.KeyValue{attribute.String("direction", "receive")}
+
+	// Attribute sets used for Disk measurements.  These are combined
+	// with the name of the device being measured.
+
+	AttributeDiskRead  = []attribute.KeyValue{attribute.String("direction", "read")}
+	AttributeDiskWrite = []attribute.KeyValue{attribute.String("direction", "write")}
 )
 
+// DeviceKey is the attribute key identifying the device of a disk
+// measurement.
+const DeviceKey = attribute.Key("device")
+
 // newConfig computes a config from a list of Options.
 func newConfig(opts ...Option) config {
 	c := config{
-		MeterProvider: global.GetMeterProvider(),
+		MeterProvider:       global.GetMeterProvider(),
+		MinimumReadInterval: DefaultMinimumReadInterval,
 	}
 	for _, opt := range opts {
 		opt.apply(&c)
@@ -99,6 +140,9 @@ func newConfig(opts ...Option) config {
 // Start initializes reporting of host metrics using the supplied config.
 func Start(opts ...Option) error {
 	c := newConfig(opts...)
+	if c.MinimumReadInterval < 0 {
+		c.MinimumReadInterval = DefaultMinimumReadInterval
+	}
 	if c.MeterProvider == nil {
 		c.MeterProvider = global.GetMeterProvider()
 	}
@@ -119,11 +163,28 @@ func (h *host) register() error {
 		processCPUTime metric.Float64CounterObserver
 		hostCPUTime    metric.Float64CounterObserver
 
+		hostLoad1  metric.Float64GaugeObserver
+		hostLoad5  metric.Float64GaugeObserver
+		hostLoad15 metric.Float64GaugeObserver
+
 		hostMemoryUsage       metric.Int64GaugeObserver
 		hostMemoryUtilization metric.Float64GaugeObserver
 
+		diskIOUsage      metric.Int64CounterObserver
+		diskIOOperations metric.Int64CounterObserver
+
 		networkIOUsage metric.Int64CounterObserver
 
+		// The most recently read statistics, reported until
+		// MinimumReadInterval has passed since lastRead.
+		lastRead     time.Time
+		processTimes *cpu.TimesStat
+		hostTime     cpu.TimesStat
+		loadAvg      *load.AvgStat
+		vmStats      *mem.VirtualMemoryStat
+		diskStats    map[string]disk.IOCountersStat
+		netStats     net.IOCountersStat
+
 		// lock prevents a race between batch observer and instrument registration.
 		lock sync.Mutex
 	)
@@ -133,48 +194,66 @@ func (h *host) register() error {
 		return fmt.Errorf("could not find this process: %w", err)
 	}
 
-	lock.Lock()
-	defer lock.Unlock()
-
-	batchObserver := h.meter.NewBatchObserver(func(ctx context.Context, result metric.BatchObserverResult) {
-		lock.Lock()
-		defer lock.Unlock()
-
+	// read refreshes the statistics shared by all instruments.  Load
+	// averages and disk counters are not available on every platform,
+	// so failing to read them only omits their measurements.
+	read := func(ctx context.Context) error {
 		// This follows the OpenTelemetry Collector's "hostmetrics"
 		// receiver/hostmetricsreceiver/internal/scraper/processscraper
 		// measures User and System IOwait time.
 		// TODO: the Collector has per-OS compilation modules to support
 		// specific metrics that are not universal.
-		processTimes, err := proc.TimesWithContext(ctx)
+		pt, err := proc.TimesWithContext(ctx)
 		if err != nil {
-			otel.Handle(err)
-			return
+			return err
 		}
 
 		hostTimeSlice, err := cpu.TimesWithContext(ctx, false)
 		if err != nil {
-			otel.Handle(err)
-			return
+			return err
 		}
 		if len(hostTimeSlice) != 1 {
-			otel.Handle(fmt.Errorf("host CPU usage: incorrect summary count"))
-			return
+			return fmt.Errorf("host CPU usage: incorrect summary count")
 		}
 
-		vmStats, err := mem.VirtualMemoryWithContext(ctx)
+		vm, err := mem.VirtualMemoryWithContext(ctx)
 		if err != nil {
-			otel.Handle(err)
-			return
+			return err
 		}
 
 		ioStats, err := net.IOCountersWithContext(ctx, false)
 		if err != nil {
-			otel.Handle(err)
-			return
+			return err
 		}
 		if len(ioStats) != 1 {
-			otel.Handle(fmt.Errorf("host network usage: incorrect summary count"))
-			return
+			return fmt.Errorf("host network usage: incorrect summary count")
+		}
+
+		processTimes, hostTime, vmStats, netStats = pt, hostTimeSlice[0], vm, ioStats[0]
+
+		if loadAvg, err = load.AvgWithContext(ctx); err != nil {
+			otel.Handle(fmt.Errorf("host load average: %w", err))
+		}
+		if diskStats, err = disk.IOCountersWithContext(ctx); err != nil {
+			otel.Handle(fmt.Errorf("host disk usage: %w", err))
+		}
+		return nil
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	batchObserver := h.meter.NewBatchObserver(func(ctx context.Context, result metric.BatchObserverResult) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		now := time.Now()
+		if now.Sub(lastRead) >= h.config.MinimumReadInterval {
+			if err := read(ctx); err != nil {
+				otel.Handle(err)
+				return
+			}
+			lastRead = now
 		}
 
 		// Process CPU time
@@ -182,7 +261,6 @@ func (h *host) register() error {
 		result.Observe(AttributeCPUTimeSystem, processCPUTime.Observation(processTimes.System))
 
 		// Host CPU time
-		hostTime := hostTimeSlice[0]
 		result.Observe(AttributeCPUTimeUser, hostCPUTime.Observation(hostTime.User))
 		result.Observe(AttributeCPUTimeSystem, hostCPUTime.Observation(hostTime.System))
 
@@ -204,6 +282,15 @@ func (h *host) register() error {
 		result.Observe(AttributeCPUTimeOther, hostCPUTime.Observation(other))
 		result.Observe(AttributeCPUTimeIdle, hostCPUTime.Observation(hostTime.Idle))
 
+		// Host load average
+		if loadAvg != nil {
+			result.Observe(nil,
+				hostLoad1.Observation(loadAvg.Load1),
+				hostLoad5.Observation(loadAvg.Load5),
+				hostLoad15.Observation(loadAvg.Load15),
+			)
+		}
+
 		// Host memory usage
 		result.Observe(AttributeMemoryUsed, hostMemoryUsage.Observation(int64(vmStats.Used)))
 		result.Observe(AttributeMemoryAvailable, hostMemoryUsage.Observation(int64(vmStats.Available)))
@@ -216,13 +303,28 @@ func (h *host) register() error {
 			hostMemoryUtilization.Observation(float64(vmStats.Available)/float64(vmStats.Total)),
 		)
 
+		// Host disk usage, per device
+		for name, stats := range diskStats {
+			device := DeviceKey.String(name)
+			readAttrs := append([]attribute.KeyValue{device}, AttributeDiskRead...)
+			writeAttrs := append([]attribute.KeyValue{device}, AttributeDiskWrite...)
+			result.Observe(readAttrs,
+				diskIOUsage.Observation(int64(stats.ReadBytes)),
+				diskIOOperations.Observation(int64(stats.ReadCount)),
+			)
+			result.Observe(writeAttrs,
+				diskIOUsage.Observation(int64(stats.WriteBytes)),
+				diskIOOperations.Observation(int64(stats.WriteCount)),
+			)
+		}
+
 		// Host network usage
 		//
 		// TODO: These can be broken down by network
 		// interface, with similar questions to those posed
 		// about per-CPU measurements above.
-		result.Observe(AttributeNetworkTransmit, networkIOUsage.Observation(int64(ioStats[0].BytesSent)))
-		result.Observe(AttributeNetworkReceive, networkIOUsage.Observation(int64(ioStats[0].BytesRecv)))
+		result.Observe(AttributeNetworkTransmit, networkIOUsage.Observation(int64(netStats.BytesSent)))
+		result.Observe(AttributeNetworkReceive, networkIOUsage.Observation(int64(netStats.BytesRecv)))
 	})
 
 	// TODO: .time units are in seconds, but "unit" package does
@@ -248,6 +350,30 @@ func (h *host) register() error {
 		return err
 	}
 
+	if hostLoad1, err = batchObserver.NewFloat64GaugeObserver(
+		"system.cpu.load_average.1m",
+		metric.WithUnit(unit.Dimensionless),
+		metric.WithDescription("Host load average over the last minute"),
+	); err != nil {
+		return err
+	}
+
+	if hostLoad5, err = batchObserver.NewFloat64GaugeObserver(
+		"system.cpu.load_average.5m",
+		metric.WithUnit(unit.Dimensionless),
+		metric.WithDescription("Host load average over the last 5 minutes"),
+	); err != nil {
+		return err
+	}
+
+	if hostLoad15, err = batchObserver.NewFloat64GaugeObserver(
+		"system.cpu.load_average.15m",
+		metric.WithUnit(unit.Dimensionless),
+		metric.WithDescription("Host load average over the last 15 minutes"),
+	); err != nil {
+		return err
+	}
+
 	if hostMemoryUsage, err = batchObserver.NewInt64GaugeObserver(
 		"system.memory.usage",
 		metric.WithUnit(unit.Bytes),
@@ -268,6 +394,25 @@ func (h *host) register() error {
 		return err
 	}
 
+	if diskIOUsage, err = batchObserver.NewInt64CounterObserver(
+		"system.disk.io",
+		metric.WithUnit(unit.Bytes),
+		metric.WithDescription(
+			"Bytes transferred attributed by device and direction (Read, Write)",
+		),
+	); err != nil {
+		return err
+	}
+
+	if diskIOOperations, err = batchObserver.NewInt64CounterObserver(
+		"system.disk.operations",
+		metric.WithDescription(
+			"Disk operations attributed by device and direction (Read, Write)",
+		),
+	); err != nil {
+		return err
+	}
+
 	if networkIOUsage, err = batchObserver.NewInt64CounterObserver(
 		"system.network.io",
 		metric.WithUnit(unit.Bytes),
@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/load"
 	"github.com/shirou/gopsutil/v3/mem"
 	"github.com/shirou/gopsutil/v3/net"
 	"github.com/shirou/gopsutil/v3/process"
@@ -58,6 +60,33 @@ func getMetric(provider *metrictest.MeterProvider, name string, lbl attribute.Ke
 	panic("Could not locate a metric in test output")
 }
 
+// getMetricValues returns all the measurements of the named metric that have
+// all of the attributes lbls.
+func getMetricValues(provider *metrictest.MeterProvider, name string, lbls ...attribute.KeyValue) []float64 {
+	var values []float64
+	for _, b := range provider.MeasurementBatches {
+		attrs := attribute.NewSet(b.Labels...)
+		matches := true
+		for _, lbl := range lbls {
+			if v, ok := attrs.Value(lbl.Key); !ok || v != lbl.Value {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+
+		for _, m := range b.Measurements {
+			if m.Instrument.Descriptor().Name() != name {
+				continue
+			}
+			values = append(values, m.Number.CoerceToFloat64(m.Instrument.Descriptor().NumberKind()))
+		}
+	}
+	return values
+}
+
 func TestHostCPU(t *testing.T) {
 	provider := metrictest.NewMeterProvider()
 	err := host.Start(
@@ -236,3 +265,71 @@ func TestHostNetwork(t *testing.T) {
 	require.LessOrEqual(t, uint64(howMuch), uint64(hostTransmit)-hostBefore[0].BytesSent)
 	require.LessOrEqual(t, uint64(howMuch), uint64(hostReceive)-hostBefore[0].BytesRecv)
 }
+
+func TestHostLoad(t *testing.T) {
+	if _, err := load.Avg(); err != nil {
+		t.Skipf("load average not available: %v", err)
+	}
+
+	provider := metrictest.NewMeterProvider()
+	err := host.Start(
+		host.WithMeterProvider(provider),
+	)
+	assert.NoError(t, err)
+
+	provider.RunAsyncInstruments()
+
+	for _, name := range []string{
+		"system.cpu.load_average.1m",
+		"system.cpu.load_average.5m",
+		"system.cpu.load_average.15m",
+	} {
+		values := getMetricValues(provider, name)
+		require.Len(t, values, 1, name)
+		assert.GreaterOrEqual(t, values[0], 0.0, name)
+	}
+}
+
+func TestHostDisk(t *testing.T) {
+	ctx := context.Background()
+	stats, err := disk.IOCountersWithContext(ctx)
+	if err != nil || len(stats) == 0 {
+		t.Skipf("disk statistics not available: %v", err)
+	}
+
+	provider := metrictest.NewMeterProvider()
+	err = host.Start(
+		host.WithMeterProvider(provider),
+	)
+	assert.NoError(t, err)
+
+	provider.RunAsyncInstruments()
+
+	// One read and one write measurement per device.
+	assert.Len(t, getMetricValues(provider, "system.disk.io"), 2*len(stats))
+	assert.Len(t, getMetricValues(provider, "system.disk.operations"), 2*len(stats))
+
+	for name := range stats {
+		read := getMetric(provider, "system.disk.io", host.DeviceKey.String(name))
+		assert.GreaterOrEqual(t, read, 0.0)
+	}
+}
+
+func TestMinimumReadInterval(t *testing.T) {
+	provider := metrictest.NewMeterProvider()
+	err := host.Start(
+		host.WithMeterProvider(provider),
+		host.WithMinimumReadInterval(time.Hour),
+	)
+	assert.NoError(t, err)
+
+	provider.RunAsyncInstruments()
+	require.NoError(t, sendBytes(t, 10000))
+	time.Sleep(time.Second)
+	provider.RunAsyncInstruments()
+
+	// Both collections report the statistics from the first read.
+	values := getMetricValues(provider, "system.network.io", host.AttributeNetworkTransmit[0])
+	require.Len(t, values, 2)
+	assert.Equal(t, values[0], values[1])
+}
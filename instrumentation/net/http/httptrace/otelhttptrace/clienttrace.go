@@ -42,6 +42,12 @@ var (
 	HTTPConnectionDoneNetwork  = attribute.Key("http.conn.done.network")
 	HTTPConnectionDoneAddr     = attribute.Key("http.conn.done.addr")
 	HTTPDNSAddrs               = attribute.Key("http.dns.addrs")
+
+	// Connection-level timings, in milliseconds, added by WithTimings.
+	HTTPDNSDuration       = attribute.Key("http.dns.duration")
+	HTTPConnectDuration   = attribute.Key("http.connect.duration")
+	HTTPTLSDuration       = attribute.Key("http.tls.duration")
+	HTTPFirstByteDuration = attribute.Key("http.first_byte.duration")
 )
 
 var (
@@ -112,6 +118,18 @@ func WithInsecureHeaders() ClientTraceOption {
 	})
 }
 
+// WithTimings will add the durations of the DNS lookup, of connecting, of
+// the TLS handshake, and from getting a connection to the first response
+// byte as attributes to the span found in the context, which typically is
+// the client span of the request. Only the durations of the stages that
+// take place are added; DNS lookups, connecting, and TLS handshakes are
+// skipped when a connection is reused.
+func WithTimings() ClientTraceOption {
+	return clientTraceOptionFunc(func(ct *clientTracer) {
+		ct.timings = &timings{}
+	})
+}
+
 // WithTracerProvider specifies a tracer provider for creating a tracer.
 // The global provider is used if none is specified.
 func WithTracerProvider(provider trace.TracerProvider) ClientTraceOption {
@@ -135,6 +153,7 @@ type clientTracer struct {
 	redactedHeaders map[string]struct{}
 	addHeaders      bool
 	useSpans        bool
+	timings         *timings
 }
 
 // NewClientTrace returns an httptrace.ClientTrace implementation that will
@@ -266,6 +285,7 @@ func (ct *clientTracer) span(hook string) trace.Span {
 }
 
 func (ct *clientTracer) getConn(host string) {
+	ct.timingStart(stageGetConn)
 	ct.start("http.getconn", "http.getconn", semconv.HTTPHostKey.String(host))
 }
 
@@ -287,10 +307,12 @@ func (ct *clientTracer) putIdleConn(err error) {
 }
 
 func (ct *clientTracer) gotFirstResponseByte() {
+	ct.timingEnd(stageGetConn, HTTPFirstByteDuration)
 	ct.start("http.receive", "http.receive")
 }
 
 func (ct *clientTracer) dnsStart(info httptrace.DNSStartInfo) {
+	ct.timingStart(stageDNS)
 	ct.start("http.dns", "http.dns", semconv.HTTPHostKey.String(info.Host))
 }
 
@@ -299,10 +321,12 @@ func (ct *clientTracer) dnsDone(info httptrace.DNSDoneInfo) {
 	for _, netAddr := range info.Addrs {
 		addrs = append(addrs, netAddr.String())
 	}
+	ct.timingEnd(stageDNS, HTTPDNSDuration)
 	ct.end("http.dns", info.Err, HTTPDNSAddrs.String(sliceToString(addrs)))
 }
 
 func (ct *clientTracer) connectStart(network, addr string) {
+	ct.timingStart(stageConnect)
 	ct.start("http.connect."+addr, "http.connect",
 		HTTPRemoteAddr.String(addr),
 		HTTPConnectionStartNetwork.String(network),
@@ -310,6 +334,7 @@ func (ct *clientTracer) connectStart(network, addr string) {
 }
 
 func (ct *clientTracer) connectDone(network, addr string, err error) {
+	ct.timingEnd(stageConnect, HTTPConnectDuration)
 	ct.end("http.connect."+addr, err,
 		HTTPConnectionDoneAddr.String(addr),
 		HTTPConnectionDoneNetwork.String(network),
@@ -317,10 +342,12 @@ func (ct *clientTracer) connectDone(network, addr string, err error) {
 }
 
 func (ct *clientTracer) tlsHandshakeStart() {
+	ct.timingStart(stageTLS)
 	ct.start("http.tls", "http.tls")
 }
 
 func (ct *clientTracer) tlsHandshakeDone(_ tls.ConnectionState, err error) {
+	ct.timingEnd(stageTLS, HTTPTLSDuration)
 	ct.end("http.tls", err)
 }
 
@@ -6,6 +6,7 @@ replace (
 	go.opentelemetry.io/contrib => ../../../../../../
 	go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace => ../
 	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp => ../../../otelhttp
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../../../requestfilter
 )
 
 require (
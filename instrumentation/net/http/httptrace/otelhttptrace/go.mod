@@ -6,6 +6,7 @@ replace go.opentelemetry.io/contrib => ../../../../..
 
 require (
 	github.com/google/go-cmp v0.5.6
+	go.opentelemetry.io/contrib v1.1.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/trace v1.1.0
 )
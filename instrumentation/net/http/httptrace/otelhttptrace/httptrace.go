@@ -18,11 +18,11 @@ import (
 	"context"
 	"net/http"
 
+	"go.opentelemetry.io/contrib/internal/httpconv"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
-	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -64,12 +64,7 @@ func Extract(ctx context.Context, req *http.Request, opts ...Option) ([]attribut
 	c := newConfig(opts)
 	ctx = c.propagators.Extract(ctx, propagation.HeaderCarrier(req.Header))
 
-	attrs := append(
-		semconv.HTTPServerAttributesFromHTTPRequest("", "", req),
-		semconv.NetAttributesFromHTTPRequest("tcp", req)...,
-	)
-
-	return attrs, baggage.FromContext(ctx), trace.SpanContextFromContext(ctx)
+	return httpconv.ServerRequest("", "", req), baggage.FromContext(ctx), trace.SpanContextFromContext(ctx)
 }
 
 func Inject(ctx context.Context, req *http.Request, opts ...Option) {
@@ -471,3 +471,44 @@ func TestWithInsecureHeaders(t *testing.T) {
 		gotAttributes,
 	)
 }
+
+func TestWithTimings(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(trace.NewTracerProvider(trace.WithSpanProcessor(sr)))
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	ctx, span := otel.Tracer("oteltest").Start(context.Background(), "root")
+	ctx = httptrace.WithClientTrace(ctx,
+		otelhttptrace.NewClientTrace(ctx,
+			otelhttptrace.WithoutSubSpans(),
+			otelhttptrace.WithoutHeaders(),
+			otelhttptrace.WithTimings(),
+		),
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+	resp, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	span.End()
+
+	recSpan, ok := getSpanFromRecorder(sr, "root")
+	require.True(t, ok)
+	durations := make(map[attribute.Key]float64)
+	for _, kv := range recSpan.Attributes() {
+		switch kv.Key {
+		case otelhttptrace.HTTPConnectDuration, otelhttptrace.HTTPTLSDuration, otelhttptrace.HTTPFirstByteDuration, otelhttptrace.HTTPDNSDuration:
+			durations[kv.Key] = kv.Value.AsFloat64()
+		}
+	}
+
+	// The server is reached by IP address, so there is no DNS lookup.
+	assert.NotContains(t, durations, otelhttptrace.HTTPDNSDuration)
+	for _, k := range []attribute.Key{otelhttptrace.HTTPConnectDuration, otelhttptrace.HTTPTLSDuration, otelhttptrace.HTTPFirstByteDuration} {
+		if assert.Contains(t, durations, k) {
+			assert.Greater(t, durations[k], 0.0, string(k))
+		}
+	}
+	assert.GreaterOrEqual(t, durations[otelhttptrace.HTTPFirstByteDuration], durations[otelhttptrace.HTTPTLSDuration])
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelhttptrace
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// stage is a connection-level stage of a request.
+type stage int
+
+const (
+	stageGetConn stage = iota
+	stageDNS
+	stageConnect
+	stageTLS
+	numStages
+)
+
+// timings holds the start times of the stages of a request.
+type timings struct {
+	mu    sync.Mutex
+	start [numStages]time.Time
+}
+
+// timingStart records the start time of a stage if timings are enabled.
+func (ct *clientTracer) timingStart(s stage) {
+	if ct.timings == nil {
+		return
+	}
+	ct.timings.mu.Lock()
+	defer ct.timings.mu.Unlock()
+	// Connections to several addresses may be attempted in parallel, in
+	// which case the duration is measured from the first attempt.
+	if ct.timings.start[s].IsZero() {
+		ct.timings.start[s] = time.Now()
+	}
+}
+
+// timingEnd adds the duration of a stage as the key attribute to the span
+// in the context if timings are enabled.
+func (ct *clientTracer) timingEnd(s stage, key attribute.Key) {
+	if ct.timings == nil {
+		return
+	}
+	ct.timings.mu.Lock()
+	begin := ct.timings.start[s]
+	ct.timings.start[s] = time.Time{}
+	ct.timings.mu.Unlock()
+	if begin.IsZero() {
+		return
+	}
+
+	d := time.Since(begin)
+	trace.SpanFromContext(ct.Context).SetAttributes(key.Float64(float64(d.Nanoseconds()) / 1e6))
+}
@@ -17,6 +17,7 @@ package otelhttp
 import (
 	"net/http"
 
+	"go.opentelemetry.io/contrib/instrumentation/requestfilter"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/global"
@@ -38,7 +39,11 @@ type config struct {
 	ReadEvent         bool
 	WriteEvent        bool
 	Filters           []Filter
+	Sanitizer         requestfilter.Sanitizer
 	SpanNameFormatter func(string, *http.Request) string
+	Routes            []routeTemplate
+	TracingDisabled   bool
+	MetricsDisabled   bool
 
 	TracerProvider trace.TracerProvider
 	MeterProvider  metric.MeterProvider
@@ -69,6 +74,9 @@ func newConfig(opts ...Option) *config {
 	if c.TracerProvider != nil {
 		c.Tracer = newTracer(c.TracerProvider)
 	}
+	if c.MetricsDisabled {
+		c.MeterProvider = metric.NewNoopMeterProvider()
+	}
 
 	c.Meter = c.MeterProvider.Meter(
 		instrumentationName,
@@ -98,6 +106,24 @@ func WithMeterProvider(provider metric.MeterProvider) Option {
 	})
 }
 
+// WithTracingDisabled disables the spans of the Handler and of the Transport,
+// which only record metrics, e.g. for applications exporting metrics without a
+// trace backend. The context of the incoming and outgoing requests is still
+// propagated.
+func WithTracingDisabled() Option {
+	return optionFunc(func(c *config) {
+		c.TracingDisabled = true
+	})
+}
+
+// WithMetricsDisabled disables the metrics of the Handler, which only creates
+// spans.
+func WithMetricsDisabled() Option {
+	return optionFunc(func(c *config) {
+		c.MetricsDisabled = true
+	})
+}
+
 // WithPublicEndpoint configures the Handler to link the span with an incoming
 // span context. If this option is not provided, then the association is a child
 // association instead of a link.
@@ -137,6 +163,23 @@ func WithFilter(f Filter) Option {
 	})
 }
 
+// WithRequestFilter adds a filter shared with the other instrumentations to
+// the list of filters used by the handler, as WithFilter does.
+func WithRequestFilter(f requestfilter.Filter) Option {
+	return WithFilter(func(r *http.Request) bool {
+		return f(requestfilter.FromHTTP(r))
+	})
+}
+
+// WithRequestSanitizer sets the sanitizer applied to the attributes of the
+// requests before they are recorded, e.g. to drop the query strings or
+// redact the values read from the headers.
+func WithRequestSanitizer(s requestfilter.Sanitizer) Option {
+	return optionFunc(func(c *config) {
+		c.Sanitizer = s
+	})
+}
+
 type event int
 
 // Different types of events that can be recorded, see WithMessageEvents
@@ -174,3 +217,17 @@ func WithSpanNameFormatter(f func(operation string, r *http.Request) string) Opt
 		c.SpanNameFormatter = f
 	})
 }
+
+// WithRouteTemplates sets route templates, such as "/users/{id}", that the
+// paths of the requests served by a Handler are matched against. The first
+// matching template is set as the http.route attribute of the span and of
+// the metrics, and replaces the operation as the span name, which keeps the
+// cardinality of span names and metrics low. A "{name}" segment matches any
+// path segment and a final "*" segment matches the remainder of the path.
+func WithRouteTemplates(templates ...string) Option {
+	return optionFunc(func(c *config) {
+		for _, t := range templates {
+			c.Routes = append(c.Routes, parseRouteTemplate(t))
+		}
+	})
+}
@@ -2,11 +2,16 @@ module go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp
 
 go 1.15
 
-replace go.opentelemetry.io/contrib => ../../../..
+replace (
+	go.opentelemetry.io/contrib => ../../../..
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../requestfilter
+)
 
 require (
 	github.com/felixge/httpsnoop v1.0.2
 	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib v1.1.0
+	go.opentelemetry.io/contrib/instrumentation/requestfilter v0.26.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/metric v0.24.0
 	go.opentelemetry.io/otel/trace v1.1.0
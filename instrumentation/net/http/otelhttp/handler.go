@@ -21,6 +21,8 @@ import (
 
 	"github.com/felixge/httpsnoop"
 
+	"go.opentelemetry.io/contrib/instrumentation/requestfilter"
+	"go.opentelemetry.io/contrib/internal/httpconv"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -46,7 +48,11 @@ type Handler struct {
 	readEvent         bool
 	writeEvent        bool
 	filters           []Filter
+	sanitizer         requestfilter.Sanitizer
 	spanNameFormatter func(string, *http.Request) string
+	routes            []routeTemplate
+	tracingDisabled   bool
+	metricsDisabled   bool
 	counters          map[string]metric.Int64Counter
 	valueRecorders    map[string]metric.Float64Histogram
 }
@@ -83,7 +89,11 @@ func (h *Handler) configure(c *config) {
 	h.readEvent = c.ReadEvent
 	h.writeEvent = c.WriteEvent
 	h.filters = c.Filters
+	h.sanitizer = c.Sanitizer
 	h.spanNameFormatter = c.SpanNameFormatter
+	h.routes = c.Routes
+	h.tracingDisabled = c.TracingDisabled
+	h.metricsDisabled = c.MetricsDisabled
 }
 
 func handleErr(err error) {
@@ -121,10 +131,15 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	route := matchRoute(h.routes, r.URL.Path)
+	ctx := h.propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	if h.tracingDisabled {
+		h.serve(w, r.WithContext(ctx), route, requestStartTime, nil)
+		return
+	}
+
 	opts := append([]trace.SpanStartOption{
-		trace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", r)...),
-		trace.WithAttributes(semconv.EndUserAttributesFromHTTPRequest(r)...),
-		trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(h.operation, "", r)...),
+		trace.WithAttributes(h.sanitize(httpconv.ServerRequest(h.operation, route, r))...),
 	}, h.spanStartOptions...) // start with the configured options
 
 	tracer := h.tracer
@@ -137,12 +152,22 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	ctx := h.propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
-	ctx, span := tracer.Start(ctx, h.spanNameFormatter(h.operation, r), opts...)
+	operation := h.operation
+	if route != "" {
+		operation = route
+	}
+	ctx, span := tracer.Start(ctx, h.spanNameFormatter(operation, r), opts...)
 	defer span.End()
 
+	h.serve(w, r.WithContext(ctx), route, requestStartTime, span)
+}
+
+// serve serves r with the wrapped handler and records the metrics of the request. The
+// span of the request is nil when tracing is disabled.
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request, route string, requestStartTime time.Time, span trace.Span) {
+	ctx := r.Context()
 	readRecordFunc := func(int64) {}
-	if h.readEvent {
+	if h.readEvent && span != nil {
 		readRecordFunc = func(n int64) {
 			span.AddEvent("read", trace.WithAttributes(ReadBytesKey.Int64(n)))
 		}
@@ -159,7 +184,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	writeRecordFunc := func(int64) {}
-	if h.writeEvent {
+	if h.writeEvent && span != nil {
 		writeRecordFunc = func(n int64) {
 			span.AddEvent("write", trace.WithAttributes(WroteBytesKey.Int64(n)))
 		}
@@ -188,10 +213,18 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	h.handler.ServeHTTP(w, r.WithContext(ctx))
 
-	setAfterServeAttributes(span, bw.read, rww.written, rww.statusCode, bw.err, rww.err)
+	if span != nil {
+		setAfterServeAttributes(span, bw.read, rww.written, rww.statusCode, bw.err, rww.err)
+	}
+	if h.metricsDisabled {
+		return
+	}
 
 	// Add metrics
-	attributes := append(labeler.Get(), semconv.HTTPServerMetricAttributesFromHTTPRequest(h.operation, r)...)
+	attributes := append(labeler.Get(), h.sanitize(httpconv.ServerMetricRequest(h.operation, r))...)
+	if route != "" {
+		attributes = append(attributes, semconv.HTTPRouteKey.String(route))
+	}
 	h.counters[RequestContentLength].Add(ctx, bw.read, attributes...)
 	h.counters[ResponseContentLength].Add(ctx, rww.written, attributes...)
 
@@ -200,6 +233,14 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.valueRecorders[ServerLatency].Record(ctx, elapsedTime, attributes...)
 }
 
+// sanitize returns attrs sanitized by the configured sanitizer.
+func (h *Handler) sanitize(attrs []attribute.KeyValue) []attribute.KeyValue {
+	if h.sanitizer == nil {
+		return attrs
+	}
+	return h.sanitizer(attrs)
+}
+
 func setAfterServeAttributes(span trace.Span, read, wrote int64, statusCode int, rerr, werr error) {
 	attributes := []attribute.KeyValue{}
 
@@ -215,8 +256,8 @@ func setAfterServeAttributes(span trace.Span, read, wrote int64, statusCode int,
 		attributes = append(attributes, WroteBytesKey.Int64(wrote))
 	}
 	if statusCode > 0 {
-		attributes = append(attributes, semconv.HTTPAttributesFromHTTPStatusCode(statusCode)...)
-		span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(statusCode))
+		attributes = append(attributes, httpconv.StatusCode(statusCode)...)
+		span.SetStatus(httpconv.SpanStatus(statusCode))
 	}
 	if werr != nil && werr != io.EOF {
 		attributes = append(attributes, WriteErrorKey.String(werr.Error()))
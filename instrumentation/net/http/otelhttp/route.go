@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelhttp
+
+import "strings"
+
+// routeTemplate is a parsed route template, such as /users/{id}/orders.
+type routeTemplate struct {
+	template string
+	segments []string
+	// wildcard is true if the template ends with a "*" segment.
+	wildcard bool
+}
+
+func parseRouteTemplate(template string) routeTemplate {
+	rt := routeTemplate{template: template}
+	segments := strings.Split(strings.Trim(template, "/"), "/")
+	if n := len(segments); n > 0 && segments[n-1] == "*" {
+		rt.wildcard = true
+		segments = segments[:n-1]
+	}
+	rt.segments = segments
+	return rt
+}
+
+// match reports whether path matches the template. A "{name}" segment of
+// the template matches any non-empty path segment, and a final "*" segment
+// matches the remainder of the path. All other segments match themselves.
+func (rt routeTemplate) match(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < len(rt.segments) || (!rt.wildcard && len(segments) != len(rt.segments)) {
+		return false
+	}
+	for i, s := range rt.segments {
+		if isRouteParam(s) {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if s != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isRouteParam(segment string) bool {
+	return len(segment) > 2 && segment[0] == '{' && segment[len(segment)-1] == '}'
+}
+
+// matchRoute returns the first of routes matching path, or an empty string
+// if none matches.
+func matchRoute(routes []routeTemplate, path string) string {
+	for _, rt := range routes {
+		if rt.match(path) {
+			return rt.template
+		}
+	}
+	return ""
+}
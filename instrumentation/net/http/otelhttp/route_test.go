@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelhttp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchRoute(t *testing.T) {
+	routes := []routeTemplate{
+		parseRouteTemplate("/"),
+		parseRouteTemplate("/users/{id}"),
+		parseRouteTemplate("/users/{id}/orders/{order}"),
+		parseRouteTemplate("/static/*"),
+		parseRouteTemplate("/users/me"),
+	}
+
+	testCases := []struct {
+		path  string
+		route string
+	}{
+		{"/", "/"},
+		{"/users/1234", "/users/{id}"},
+		{"/users/1234/", "/users/{id}"},
+		{"/users/me", "/users/{id}"},
+		{"/users/1234/orders/5", "/users/{id}/orders/{order}"},
+		{"/users/1234/orders", ""},
+		{"/users", ""},
+		{"/static", "/static/*"},
+		{"/static/css/main.css", "/static/*"},
+		{"/health", ""},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.route, matchRoute(routes, tc.path), tc.path)
+	}
+}
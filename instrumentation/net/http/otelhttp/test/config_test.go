@@ -24,8 +24,10 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/contrib/instrumentation/requestfilter"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 )
 
 func TestBasicFilter(t *testing.T) {
@@ -134,3 +136,25 @@ func TestSpanNameFormatter(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestFilterAndSanitizer(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(spanRecorder))
+
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		"test_handler",
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithRequestFilter(requestfilter.Not(requestfilter.HealthCheck())),
+		otelhttp.WithRequestSanitizer(requestfilter.DropQuery()),
+	)
+
+	for _, target := range []string{"/healthz", "/users?token=secret"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	spans := spanRecorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Contains(t, spans[0].Attributes(), semconv.HTTPTargetKey.String("/users"))
+	}
+}
@@ -5,6 +5,7 @@ go 1.15
 require (
 	github.com/stretchr/testify v1.7.0
 	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.26.0
+	go.opentelemetry.io/contrib/instrumentation/requestfilter v0.26.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/metric v0.24.0
 	go.opentelemetry.io/otel/sdk v1.1.0
@@ -14,4 +15,5 @@ require (
 replace (
 	go.opentelemetry.io/contrib => ../../../../../
 	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp => ../
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../../requestfilter
 )
@@ -34,6 +34,7 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func assertMetricAttributes(t *testing.T, expectedAttributes []attribute.KeyValue, measurementBatches []metrictest.Batch) {
@@ -143,3 +144,89 @@ func TestHandlerRequestWithTraceContext(t *testing.T) {
 	assert.NotEmpty(t, spans[0].Parent().SpanID())
 	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
 }
+
+func TestHandlerRouteTemplates(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	meterProvider := metrictest.NewMeterProvider()
+
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		"test_handler",
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithMeterProvider(meterProvider),
+		otelhttp.WithRouteTemplates("/users/{id}", "/static/*"),
+	)
+
+	for _, path := range []string{"/users/1234", "/static/css/main.css", "/health"} {
+		r, err := http.NewRequest(http.MethodGet, "http://localhost"+path, nil)
+		require.NoError(t, err)
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "/users/{id}", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPRouteKey.String("/users/{id}"))
+	assert.Equal(t, "/static/*", spans[1].Name())
+	assert.Equal(t, "test_handler", spans[2].Name())
+	for _, kv := range spans[2].Attributes() {
+		assert.NotEqual(t, semconv.HTTPRouteKey, kv.Key)
+	}
+
+	require.NotEmpty(t, meterProvider.MeasurementBatches)
+	assert.Contains(t, meterProvider.MeasurementBatches[0].Labels, semconv.HTTPRouteKey.String("/users/{id}"))
+}
+
+func TestHandlerTracingDisabled(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	meterProvider := metrictest.NewMeterProvider()
+
+	parent, parentSpan := provider.Tracer("").Start(context.Background(), "parent")
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The context of the request is still propagated.
+			assert.Equal(t, parentSpan.SpanContext().TraceID(), trace.SpanContextFromContext(r.Context()).TraceID())
+		}),
+		"test_handler",
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithMeterProvider(meterProvider),
+		otelhttp.WithPropagators(propagation.TraceContext{}),
+		otelhttp.WithMessageEvents(otelhttp.ReadEvents, otelhttp.WriteEvents),
+		otelhttp.WithTracingDisabled(),
+	)
+
+	r, err := http.NewRequest(http.MethodGet, "http://localhost/", strings.NewReader("foo"))
+	require.NoError(t, err)
+	propagation.TraceContext{}.Inject(parent, propagation.HeaderCarrier(r.Header))
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	parentSpan.End()
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "parent", spans[0].Name())
+	assert.Empty(t, spans[0].Events())
+	assert.NotEmpty(t, meterProvider.MeasurementBatches)
+}
+
+func TestHandlerMetricsDisabled(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	meterProvider := metrictest.NewMeterProvider()
+
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		"test_handler",
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithMeterProvider(meterProvider),
+		otelhttp.WithMetricsDisabled(),
+	)
+
+	r, err := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+	require.NoError(t, err)
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Len(t, spanRecorder.Ended(), 1)
+	assert.Empty(t, meterProvider.MeasurementBatches)
+}
@@ -166,3 +166,34 @@ func TestTransportRequestWithTraceContext(t *testing.T) {
 	assert.NotEmpty(t, spans[1].Parent().SpanID())
 	assert.Equal(t, spans[0].SpanContext().SpanID(), spans[1].Parent().SpanID())
 }
+
+func TestTransportTracingDisabled(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+	ctx, span := provider.Tracer("").Start(context.Background(), "test_span")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sc := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(r.Context(), propagation.HeaderCarrier(r.Header)))
+		assert.Equal(t, span.SpanContext(), sc.WithRemote(false))
+	}))
+	defer ts.Close()
+
+	r, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+
+	tr := otelhttp.NewTransport(
+		http.DefaultTransport,
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithPropagators(propagation.TraceContext{}),
+		otelhttp.WithTracingDisabled(),
+	)
+	res, err := tr.RoundTrip(r.WithContext(ctx))
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.Empty(t, r.Header.Get("Traceparent"), "the request must not be modified")
+	span.End()
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "test_span", spans[0].Name())
+}
@@ -19,10 +19,11 @@ import (
 	"io"
 	"net/http"
 
+	"go.opentelemetry.io/contrib/instrumentation/requestfilter"
+	"go.opentelemetry.io/contrib/internal/httpconv"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
-	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -35,7 +36,9 @@ type Transport struct {
 	propagators       propagation.TextMapPropagator
 	spanStartOptions  []trace.SpanStartOption
 	filters           []Filter
+	sanitizer         requestfilter.Sanitizer
 	spanNameFormatter func(string, *http.Request) string
+	tracingDisabled   bool
 }
 
 var _ http.RoundTripper = &Transport{}
@@ -70,7 +73,9 @@ func (t *Transport) applyConfig(c *config) {
 	t.propagators = c.Propagators
 	t.spanStartOptions = c.SpanStartOptions
 	t.filters = c.Filters
+	t.sanitizer = c.Sanitizer
 	t.spanNameFormatter = c.SpanNameFormatter
+	t.tracingDisabled = c.TracingDisabled
 }
 
 func defaultTransportFormatter(_ string, r *http.Request) string {
@@ -88,6 +93,13 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 		}
 	}
 
+	if t.tracingDisabled {
+		// Only propagate the context of the request.
+		r = r.Clone(r.Context())
+		t.propagators.Inject(r.Context(), propagation.HeaderCarrier(r.Header))
+		return t.rt.RoundTrip(r)
+	}
+
 	tracer := t.tracer
 
 	if tracer == nil {
@@ -103,7 +115,11 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 	ctx, span := tracer.Start(r.Context(), t.spanNameFormatter("", r), opts...)
 
 	r = r.WithContext(ctx)
-	span.SetAttributes(semconv.HTTPClientAttributesFromHTTPRequest(r)...)
+	attrs := httpconv.ClientRequest(r)
+	if t.sanitizer != nil {
+		attrs = t.sanitizer(attrs)
+	}
+	span.SetAttributes(attrs...)
 	t.propagators.Inject(ctx, propagation.HeaderCarrier(r.Header))
 
 	res, err := t.rt.RoundTrip(r)
@@ -114,8 +130,8 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 		return res, err
 	}
 
-	span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(res.StatusCode)...)
-	span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(res.StatusCode))
+	span.SetAttributes(httpconv.StatusCode(res.StatusCode)...)
+	span.SetStatus(httpconv.SpanStatus(res.StatusCode))
 	res.Body = &wrappedBody{ctx: ctx, span: span, body: res.Body}
 
 	return res, err
@@ -14,6 +14,20 @@
 
 package otelhttp
 
+import (
+	"go.opentelemetry.io/contrib/registry"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func init() {
+	registry.Register(registry.Component{
+		Name:      instrumentationName,
+		Kind:      registry.KindInstrumentation,
+		Version:   Version(),
+		SchemaURL: semconv.SchemaURL,
+	})
+}
+
 // Version is the current release version of the otelhttp instrumentation.
 func Version() string {
 	return "0.26.0"
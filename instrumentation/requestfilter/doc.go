@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package requestfilter provides the request filters and attribute
+// sanitizers shared by the HTTP, gRPC, and router instrumentations, so that
+// the requests not to trace, e.g. health checks, and the request data not to
+// record, e.g. query strings or credentials, are configured once for all of
+// them.
+//
+// A Filter matches the Request view of an HTTP request or of a gRPC call. The
+// instrumentations only trace the requests their filters return true for:
+//
+//	filter := requestfilter.Not(requestfilter.HealthCheck())
+//	sanitizer := requestfilter.Chain(
+//		requestfilter.DropQuery(),
+//		requestfilter.RedactHeaders("Authorization"),
+//	)
+//
+//	handler := otelhttp.NewHandler(mux, "server",
+//		otelhttp.WithRequestFilter(filter),
+//		otelhttp.WithRequestSanitizer(sanitizer),
+//	)
+//	server := grpc.NewServer(grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor(
+//		otelgrpc.WithRequestFilter(filter),
+//		otelgrpc.WithRequestSanitizer(sanitizer),
+//	)))
+package requestfilter // import "go.opentelemetry.io/contrib/instrumentation/requestfilter"
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestfilter // import "go.opentelemetry.io/contrib/instrumentation/requestfilter"
+
+import (
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+)
+
+// Request is the view of an HTTP request or of a gRPC call that filters
+// match.
+type Request struct {
+	// Method is the HTTP method of the request, POST for gRPC calls.
+	Method string
+	// Path is the URL path of the request, or the full method name of a gRPC
+	// call, e.g. "/grpc.health.v1.Health/Check".
+	Path string
+	// Query holds the query parameters of the request, nil for gRPC calls.
+	Query url.Values
+	// Host is the host of the request, or the authority of a gRPC call.
+	Host string
+	// Header holds the headers of the request, or the metadata of a gRPC
+	// call, by canonical key.
+	Header http.Header
+}
+
+// FromHTTP returns the Request view of r.
+func FromHTTP(r *http.Request) *Request {
+	host := r.Host
+	if host == "" && r.URL != nil {
+		host = r.URL.Host
+	}
+	req := &Request{Method: r.Method, Host: host, Header: r.Header}
+	if r.URL != nil {
+		req.Path = r.URL.Path
+		req.Query = r.URL.Query()
+	}
+	return req
+}
+
+// FromGRPC returns the Request view of the gRPC call of fullMethod to
+// authority with the metadata md, e.g. a metadata.MD.
+func FromGRPC(fullMethod, authority string, md map[string][]string) *Request {
+	header := make(http.Header, len(md))
+	for k, v := range md {
+		key := textproto.CanonicalMIMEHeaderKey(k)
+		header[key] = append(header[key], v...)
+	}
+	return &Request{Method: http.MethodPost, Path: fullMethod, Host: authority, Header: header}
+}
+
+// Filter returns whether a request matches. The instrumentations only trace
+// the requests their filters return true for.
+type Filter func(*Request) bool
+
+// Any returns a Filter that returns true if any of fs returns true.
+func Any(fs ...Filter) Filter {
+	return func(r *Request) bool {
+		for _, f := range fs {
+			if f(r) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// All returns a Filter that returns true only if all of fs return true.
+func All(fs ...Filter) Filter {
+	return func(r *Request) bool {
+		for _, f := range fs {
+			if !f(r) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// None returns a Filter that returns true only if none of fs return true.
+func None(fs ...Filter) Filter {
+	return Not(Any(fs...))
+}
+
+// Not returns a Filter inverting f.
+func Not(f Filter) Filter {
+	return func(r *Request) bool {
+		return !f(r)
+	}
+}
+
+// Method returns a Filter that returns true if the request method is m.
+func Method(m string) Filter {
+	return func(r *Request) bool {
+		return r.Method == m
+	}
+}
+
+// Host returns a Filter that returns true if the hostname of the request,
+// without the port, is h.
+func Host(h string) Filter {
+	return func(r *Request) bool {
+		host := r.Host
+		if u, err := url.Parse("//" + host); err == nil {
+			host = u.Hostname()
+		}
+		return host == h
+	}
+}
+
+// Path returns a Filter that returns true if the path of the request is p.
+func Path(p string) Filter {
+	return func(r *Request) bool {
+		return r.Path == p
+	}
+}
+
+// PathPrefix returns a Filter that returns true if the path of the request
+// starts with p.
+func PathPrefix(p string) Filter {
+	return func(r *Request) bool {
+		return strings.HasPrefix(r.Path, p)
+	}
+}
+
+// Query returns a Filter that returns true if the request has a query
+// parameter k with the value v.
+func Query(k, v string) Filter {
+	return func(r *Request) bool {
+		for _, qv := range r.Query[k] {
+			if qv == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Header returns a Filter that returns true if the request has a header, or
+// gRPC metadata, k with the value v.
+func Header(k, v string) Filter {
+	return func(r *Request) bool {
+		for _, hv := range r.Header.Values(k) {
+			if hv == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// healthCheckPaths are the paths of the usual health check endpoints of the
+// HTTP servers and of the gRPC health checking protocol.
+var healthCheckPaths = map[string]bool{
+	"/health":                      true,
+	"/healthz":                     true,
+	"/livez":                       true,
+	"/readyz":                      true,
+	"/ready":                       true,
+	"/ping":                        true,
+	"/grpc.health.v1.Health/Check": true,
+	"/grpc.health.v1.Health/Watch": true,
+}
+
+// HealthCheck returns a Filter that returns true for the health checks, the
+// requests of the /health, /healthz, /livez, /readyz, /ready, and /ping paths
+// and the calls of the gRPC health checking protocol. Not(HealthCheck())
+// skips them.
+func HealthCheck() Filter {
+	return func(r *Request) bool {
+		return healthCheckPaths[r.Path]
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestfilter
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestFilters(t *testing.T) {
+	r, err := http.NewRequest(http.MethodGet, "http://example.com:8080/healthz?verbose=1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set("X-Probe", "kubelet")
+	req := FromHTTP(r)
+	grpcReq := FromGRPC("/grpc.health.v1.Health/Check", "localhost:50051", map[string][]string{"x-probe": {"kubelet"}})
+
+	testCases := []struct {
+		name   string
+		filter Filter
+		req    *Request
+		want   bool
+	}{
+		{"method", Method(http.MethodGet), req, true},
+		{"grpc method", Method(http.MethodPost), grpcReq, true},
+		{"host", Host("example.com"), req, true},
+		{"other host", Host("example.org"), req, false},
+		{"path", Path("/healthz"), req, true},
+		{"path prefix", PathPrefix("/health"), req, true},
+		{"query", Query("verbose", "1"), req, true},
+		{"other query", Query("verbose", "0"), req, false},
+		{"header", Header("x-probe", "kubelet"), req, true},
+		{"metadata", Header("X-Probe", "kubelet"), grpcReq, true},
+		{"health check", HealthCheck(), req, true},
+		{"grpc health check", HealthCheck(), grpcReq, true},
+		{"not", Not(HealthCheck()), req, false},
+		{"any", Any(Path("/"), Method(http.MethodGet)), req, true},
+		{"all", All(Path("/"), Method(http.MethodGet)), req, false},
+		{"none", None(Path("/"), Method(http.MethodPut)), req, true},
+	}
+	for _, tc := range testCases {
+		if got := tc.filter(tc.req); got != tc.want {
+			t.Errorf("%s: got %t, want %t", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+module go.opentelemetry.io/contrib/instrumentation/requestfilter
+
+go 1.15
+
+require go.opentelemetry.io/otel v1.1.0
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestfilter // import "go.opentelemetry.io/contrib/instrumentation/requestfilter"
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// Redacted replaces the values of the redacted attributes.
+const Redacted = "REDACTED"
+
+// Sanitizer returns the attributes of a request to record instead of attrs.
+// It must not modify attrs.
+type Sanitizer func(attrs []attribute.KeyValue) []attribute.KeyValue
+
+// Chain returns a Sanitizer applying ss in order.
+func Chain(ss ...Sanitizer) Sanitizer {
+	return func(attrs []attribute.KeyValue) []attribute.KeyValue {
+		for _, s := range ss {
+			attrs = s(attrs)
+		}
+		return attrs
+	}
+}
+
+// DropQuery returns a Sanitizer removing the query string from the
+// http.target and http.url attributes.
+func DropQuery() Sanitizer {
+	return func(attrs []attribute.KeyValue) []attribute.KeyValue {
+		return mapValues(attrs, func(kv attribute.KeyValue) attribute.KeyValue {
+			switch kv.Key {
+			case semconv.HTTPTargetKey:
+				target := kv.Value.AsString()
+				if i := strings.IndexByte(target, '?'); i >= 0 {
+					return kv.Key.String(target[:i])
+				}
+			case semconv.HTTPURLKey:
+				if u, err := url.Parse(kv.Value.AsString()); err == nil && (u.RawQuery != "" || u.ForceQuery) {
+					u.RawQuery, u.ForceQuery = "", false
+					return kv.Key.String(u.String())
+				}
+			}
+			return kv
+		})
+	}
+}
+
+// headerKeys are the attributes recorded from the values of headers.
+var headerKeys = map[string][]attribute.Key{
+	"Authorization":   {semconv.EnduserIDKey},
+	"Host":            {semconv.HTTPHostKey},
+	"User-Agent":      {semconv.HTTPUserAgentKey},
+	"X-Forwarded-For": {semconv.HTTPClientIPKey},
+}
+
+// RedactHeaders returns a Sanitizer replacing with Redacted the values of the
+// attributes recorded from the headers, or gRPC metadata, named keys: the
+// http.request.header.<key> attributes, with the lowercase key and its dashes
+// replaced by underscores, and the attributes of the semantic conventions read
+// from the headers, e.g. enduser.id from the Authorization header or
+// http.user_agent from the User-Agent header.
+func RedactHeaders(keys ...string) Sanitizer {
+	redacted := make(map[attribute.Key]bool)
+	for _, k := range keys {
+		name := strings.ReplaceAll(strings.ToLower(k), "-", "_")
+		redacted[attribute.Key("http.request.header."+name)] = true
+		for _, key := range headerKeys[http.CanonicalHeaderKey(k)] {
+			redacted[key] = true
+		}
+	}
+	return func(attrs []attribute.KeyValue) []attribute.KeyValue {
+		return mapValues(attrs, func(kv attribute.KeyValue) attribute.KeyValue {
+			if redacted[kv.Key] {
+				return kv.Key.String(Redacted)
+			}
+			return kv
+		})
+	}
+}
+
+// mapValues returns attrs with the attributes replaced by fn, copying attrs
+// only if an attribute is replaced.
+func mapValues(attrs []attribute.KeyValue, fn func(attribute.KeyValue) attribute.KeyValue) []attribute.KeyValue {
+	var out []attribute.KeyValue
+	for i, kv := range attrs {
+		if mapped := fn(kv); mapped != kv {
+			if out == nil {
+				out = append([]attribute.KeyValue(nil), attrs...)
+			}
+			out[i] = mapped
+		}
+	}
+	if out == nil {
+		return attrs
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestfilter
+
+import (
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func TestSanitizer(t *testing.T) {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPTargetKey.String("/users?token=secret"),
+		semconv.HTTPURLKey.String("https://example.com/users?token=secret#top"),
+		semconv.HTTPUserAgentKey.String("curl/7.79.1"),
+		semconv.EnduserIDKey.String("alice"),
+		attribute.String("http.request.header.x_api_key", "secret"),
+		attribute.String("http.request.header.authorization", "Basic YWxpY2U6c2VjcmV0"),
+		semconv.HTTPStatusCodeKey.Int(200),
+	}
+	original := append([]attribute.KeyValue(nil), attrs...)
+
+	got := Chain(DropQuery(), RedactHeaders("authorization"))(attrs)
+	want := []attribute.KeyValue{
+		semconv.HTTPTargetKey.String("/users"),
+		semconv.HTTPURLKey.String("https://example.com/users#top"),
+		semconv.HTTPUserAgentKey.String("curl/7.79.1"),
+		semconv.EnduserIDKey.String(Redacted),
+		attribute.String("http.request.header.x_api_key", "secret"),
+		attribute.String("http.request.header.authorization", Redacted),
+		semconv.HTTPStatusCodeKey.Int(200),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(attrs, original) {
+		t.Errorf("the attributes were modified: %v", attrs)
+	}
+
+	unchanged := []attribute.KeyValue{semconv.HTTPTargetKey.String("/users")}
+	if got := DropQuery()(unchanged); &got[0] != &unchanged[0] {
+		t.Error("the attributes were copied without changes")
+	}
+}
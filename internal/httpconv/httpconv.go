@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpconv maps HTTP requests and responses to the attributes and span
+// statuses of the OpenTelemetry semantic conventions.
+//
+// It is shared by the HTTP instrumentations of the contrib repository, so that
+// they name the attributes of the clients and of the servers consistently and
+// move to a new version of the semantic conventions together. The package
+// follows the semantic conventions of SchemaURL.
+package httpconv // import "go.opentelemetry.io/contrib/internal/httpconv"
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// SchemaURL is the URL of the semantic conventions the attributes follow.
+const SchemaURL = semconv.SchemaURL
+
+// ClientRequest returns the attributes of req sent by an HTTP client.
+func ClientRequest(req *http.Request) []attribute.KeyValue {
+	return semconv.HTTPClientAttributesFromHTTPRequest(req)
+}
+
+// ServerRequest returns the attributes of req received by server, e.g. the
+// name of the service, and matched by route, e.g. "/users/:id". The network
+// peer and the authenticated user of the request are included. server and
+// route are omitted when empty.
+func ServerRequest(server, route string, req *http.Request) []attribute.KeyValue {
+	attrs := semconv.NetAttributesFromHTTPRequest("tcp", req)
+	attrs = append(attrs, semconv.EndUserAttributesFromHTTPRequest(req)...)
+	return append(attrs, semconv.HTTPServerAttributesFromHTTPRequest(server, route, req)...)
+}
+
+// ServerMetricRequest returns the attributes of req received by server that
+// are recorded with the metrics of the server, leaving out the attributes of
+// high cardinality.
+func ServerMetricRequest(server string, req *http.Request) []attribute.KeyValue {
+	return semconv.HTTPServerMetricAttributesFromHTTPRequest(server, req)
+}
+
+// StatusCode returns the attributes of the status code of a response.
+func StatusCode(code int) []attribute.KeyValue {
+	return semconv.HTTPAttributesFromHTTPStatusCode(code)
+}
+
+// SpanStatus returns the span status and description of the status code of a
+// response. The responses with a 4xx or 5xx status code are errors.
+func SpanStatus(code int) (codes.Code, string) {
+	return semconv.SpanStatusFromHTTPStatusCode(code)
+}
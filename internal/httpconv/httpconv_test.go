@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpconv
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func hasAttribute(attrs []attribute.KeyValue, expected attribute.KeyValue) bool {
+	for _, kv := range attrs {
+		if kv == expected {
+			return true
+		}
+	}
+	return false
+}
+
+func TestClientRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com:8080/users?id=1", nil)
+	attrs := ClientRequest(req)
+	for _, expected := range []attribute.KeyValue{
+		attribute.String("http.method", "GET"),
+		attribute.String("http.url", "http://example.com:8080/users?id=1"),
+	} {
+		if !hasAttribute(attrs, expected) {
+			t.Errorf("ClientRequest() = %v, missing %v", attrs, expected)
+		}
+	}
+}
+
+func TestServerRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/users/1", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.SetBasicAuth("alice", "secret")
+
+	attrs := ServerRequest("users", "/users/:id", req)
+	for _, expected := range []attribute.KeyValue{
+		attribute.String("http.method", "POST"),
+		attribute.String("http.target", "/users/1"),
+		attribute.String("http.server_name", "users"),
+		attribute.String("http.route", "/users/:id"),
+		attribute.String("net.peer.ip", "10.0.0.1"),
+		attribute.Int("net.peer.port", 1234),
+		attribute.String("enduser.id", "alice"),
+	} {
+		if !hasAttribute(attrs, expected) {
+			t.Errorf("ServerRequest() = %v, missing %v", attrs, expected)
+		}
+	}
+
+	attrs = ServerMetricRequest("users", req)
+	if hasAttribute(attrs, attribute.String("http.target", "/users/1")) {
+		t.Errorf("ServerMetricRequest() = %v, contains the target", attrs)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	if attrs := StatusCode(404); !hasAttribute(attrs, attribute.Int("http.status_code", 404)) {
+		t.Errorf("StatusCode(404) = %v", attrs)
+	}
+	for code, expected := range map[int]codes.Code{200: codes.Unset, 404: codes.Error, 503: codes.Error, 600: codes.Error} {
+		if c, _ := SpanStatus(code); c != expected {
+			t.Errorf("SpanStatus(%d) = %v, want %v", code, c, expected)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baggagecopy
+
+type config struct {
+	keys     map[string]struct{}
+	prefixes []string
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithKeys adds keys of baggage members to copy. When used along with
+// WithKeyPrefixes, members matching either are copied.
+func WithKeys(keys ...string) Option {
+	return optionFunc(func(c *config) {
+		if c.keys == nil {
+			c.keys = make(map[string]struct{}, len(keys))
+		}
+		for _, k := range keys {
+			c.keys[k] = struct{}{}
+		}
+	})
+}
+
+// WithKeyPrefixes adds prefixes of the keys of baggage members to copy, such
+// as "tenant." or "feature.". When used along with WithKeys, members
+// matching either are copied.
+func WithKeyPrefixes(prefixes ...string) Option {
+	return optionFunc(func(c *config) {
+		if c.prefixes == nil {
+			c.prefixes = make([]string, 0, len(prefixes))
+		}
+		c.prefixes = append(c.prefixes, prefixes...)
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package baggagecopy provides a span processor that copies baggage members
+// onto started spans as attributes.
+package baggagecopy
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SpanProcessor is a span processor that adds the members of the baggage
+// of the parent context to every started span as string attributes. The
+// attribute keys are the keys of the baggage members.
+//
+// Baggage is propagated to all downstream services, which may record it on
+// their spans as well. Only members that do not contain sensitive
+// information should be copied.
+type SpanProcessor struct {
+	cfg *config
+}
+
+// compile time assertion that SpanProcessor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*SpanProcessor)(nil)
+
+// NewSpanProcessor returns a SpanProcessor. By default all baggage members
+// are copied; WithKeys and WithKeyPrefixes restrict the members copied.
+func NewSpanProcessor(opts ...Option) *SpanProcessor {
+	return &SpanProcessor{cfg: newConfig(opts...)}
+}
+
+// OnStart adds the selected members of the baggage of parent to s.
+func (p *SpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	members := baggage.FromContext(parent).Members()
+	if len(members) == 0 {
+		return
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(members))
+	for _, m := range members {
+		if p.selected(m.Key()) {
+			attrs = append(attrs, attribute.String(m.Key(), m.Value()))
+		}
+	}
+	if len(attrs) > 0 {
+		s.SetAttributes(attrs...)
+	}
+}
+
+// OnEnd does nothing.
+func (p *SpanProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+// Shutdown does nothing.
+func (p *SpanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *SpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// selected reports whether the baggage member with the given key is copied.
+func (p *SpanProcessor) selected(key string) bool {
+	if p.cfg.keys == nil && p.cfg.prefixes == nil {
+		return true
+	}
+	if _, ok := p.cfg.keys[key]; ok {
+		return true
+	}
+	for _, prefix := range p.cfg.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
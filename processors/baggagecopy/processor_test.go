@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baggagecopy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func contextWithBaggage(t *testing.T, kvs ...string) context.Context {
+	var members []baggage.Member
+	for i := 0; i < len(kvs); i += 2 {
+		m, err := baggage.NewMember(kvs[i], kvs[i+1])
+		require.NoError(t, err)
+		members = append(members, m)
+	}
+	b, err := baggage.New(members...)
+	require.NoError(t, err)
+	return baggage.ContextWithBaggage(context.Background(), b)
+}
+
+func TestSpanProcessor(t *testing.T) {
+	ctx := contextWithBaggage(t,
+		"tenant.id", "acme",
+		"tenant.tier", "gold",
+		"feature", "new-checkout",
+		"session", "secret",
+	)
+
+	testCases := []struct {
+		name     string
+		opts     []Option
+		expected []attribute.KeyValue
+	}{
+		{
+			name: "all",
+			expected: []attribute.KeyValue{
+				attribute.String("feature", "new-checkout"),
+				attribute.String("session", "secret"),
+				attribute.String("tenant.id", "acme"),
+				attribute.String("tenant.tier", "gold"),
+			},
+		},
+		{
+			name: "keys",
+			opts: []Option{WithKeys("feature", "missing")},
+			expected: []attribute.KeyValue{
+				attribute.String("feature", "new-checkout"),
+			},
+		},
+		{
+			name: "prefixes",
+			opts: []Option{WithKeyPrefixes("tenant.")},
+			expected: []attribute.KeyValue{
+				attribute.String("tenant.id", "acme"),
+				attribute.String("tenant.tier", "gold"),
+			},
+		},
+		{
+			name: "keys and prefixes",
+			opts: []Option{WithKeys("feature"), WithKeyPrefixes("tenant.")},
+			expected: []attribute.KeyValue{
+				attribute.String("feature", "new-checkout"),
+				attribute.String("tenant.id", "acme"),
+				attribute.String("tenant.tier", "gold"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(
+				sdktrace.WithSpanProcessor(NewSpanProcessor(tc.opts...)),
+				sdktrace.WithSpanProcessor(sr),
+			)
+			_, span := tp.Tracer("test").Start(ctx, "span")
+			span.End()
+
+			require.Len(t, sr.Ended(), 1)
+			assert.ElementsMatch(t, tc.expected, sr.Ended()[0].Attributes())
+		})
+	}
+}
+
+func TestSpanProcessorNoBaggage(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewSpanProcessor()),
+		sdktrace.WithSpanProcessor(sr),
+	)
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+
+	require.Len(t, sr.Ended(), 1)
+	assert.Empty(t, sr.Ended()[0].Attributes())
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorfilter
+
+import "go.opentelemetry.io/otel/attribute"
+
+type config struct {
+	severityKey       attribute.Key
+	severityThreshold int64
+	okProbability     float64
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithSeverityThreshold makes spans whose key attribute is a severity of at
+// least threshold forwarded. The attribute value is either a severity
+// number, such as SeverityWarn, or a severity name, such as "warn", which is
+// matched case-insensitively. By default only the status of spans is used.
+func WithSeverityThreshold(key attribute.Key, threshold int64) Option {
+	return optionFunc(func(c *config) {
+		c.severityKey = key
+		c.severityThreshold = threshold
+	})
+}
+
+// WithOKProbability sets the probability with which spans that are neither
+// errors nor above the severity threshold are forwarded. The default is 0.
+// The value is clamped to [0, 1].
+func WithOKProbability(p float64) Option {
+	return optionFunc(func(c *config) {
+		switch {
+		case p < 0:
+			p = 0
+		case p > 1:
+			p = 1
+		}
+		c.okProbability = p
+	})
+}
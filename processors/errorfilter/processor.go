@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package errorfilter provides a span processor that only forwards spans
+// with errors, or with a high severity, to the next span processor.
+package errorfilter
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Severity numbers of the OpenTelemetry log data model, which are used for
+// the severity names of string severity attributes.
+const (
+	SeverityTrace = 1
+	SeverityDebug = 5
+	SeverityInfo  = 9
+	SeverityWarn  = 13
+	SeverityError = 17
+	SeverityFatal = 21
+)
+
+// severityNames maps the severity names, in upper case, to their numbers.
+var severityNames = map[string]int64{
+	"TRACE":   SeverityTrace,
+	"DEBUG":   SeverityDebug,
+	"INFO":    SeverityInfo,
+	"WARN":    SeverityWarn,
+	"WARNING": SeverityWarn,
+	"ERROR":   SeverityError,
+	"FATAL":   SeverityFatal,
+}
+
+// SpanProcessor is a span processor forwarding ended spans to the next span
+// processor only if they have an error status, if their severity attribute
+// is at least the severity threshold, or if they are selected by the
+// probability of OK spans. All other spans are dropped.
+//
+// The probability is applied based on the trace ID, so that the OK spans of
+// a trace are either all forwarded or all dropped.
+type SpanProcessor struct {
+	next sdktrace.SpanProcessor
+	cfg  *config
+}
+
+// compile time assertion that SpanProcessor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*SpanProcessor)(nil)
+
+// NewSpanProcessor returns a SpanProcessor forwarding spans to next, which
+// typically is a batch span processor.
+func NewSpanProcessor(next sdktrace.SpanProcessor, opts ...Option) *SpanProcessor {
+	return &SpanProcessor{next: next, cfg: newConfig(opts...)}
+}
+
+// OnStart is called when a span is started.
+func (p *SpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd forwards the span to the next span processor if it is kept.
+func (p *SpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if p.keep(s) {
+		p.next.OnEnd(s)
+	}
+}
+
+// Shutdown shuts down the next span processor.
+func (p *SpanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next span processor.
+func (p *SpanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+func (p *SpanProcessor) keep(s sdktrace.ReadOnlySpan) bool {
+	if s.Status().Code == codes.Error {
+		return true
+	}
+	if p.cfg.severityKey != "" {
+		for _, kv := range s.Attributes() {
+			if kv.Key != p.cfg.severityKey {
+				continue
+			}
+			if n, ok := severity(kv.Value); ok && n >= p.cfg.severityThreshold {
+				return true
+			}
+		}
+	}
+
+	id := s.SpanContext().TraceID()
+	x := binary.BigEndian.Uint64(id[8:16]) >> 1
+	return x < uint64(p.cfg.okProbability*(1<<63))
+}
+
+// severity returns the severity number of v, which is either a number or a
+// severity name.
+func severity(v attribute.Value) (int64, bool) {
+	switch v.Type() {
+	case attribute.INT64:
+		return v.AsInt64(), true
+	case attribute.FLOAT64:
+		return int64(v.AsFloat64()), true
+	case attribute.STRING:
+		n, ok := severityNames[strings.ToUpper(v.AsString())]
+		return n, ok
+	}
+	return 0, false
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorfilter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func endedNames(t *testing.T, opts []Option, spans func(trace.Tracer)) []string {
+	sr := tracetest.NewSpanRecorder()
+	p := NewSpanProcessor(sr, opts...)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+	spans(tp.Tracer("test"))
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	var names []string
+	for _, s := range sr.Ended() {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+func TestSpanProcessorErrors(t *testing.T) {
+	names := endedNames(t, nil, func(tracer trace.Tracer) {
+		_, span := tracer.Start(context.Background(), "ok")
+		span.End()
+		_, span = tracer.Start(context.Background(), "error")
+		span.SetStatus(codes.Error, "failed")
+		span.End()
+	})
+	assert.Equal(t, []string{"error"}, names)
+}
+
+func TestSpanProcessorSeverity(t *testing.T) {
+	opts := []Option{WithSeverityThreshold("severity", SeverityWarn)}
+	names := endedNames(t, opts, func(tracer trace.Tracer) {
+		for _, v := range []attribute.Value{
+			attribute.StringValue("info"),
+			attribute.StringValue("warning"),
+			attribute.StringValue("ERROR"),
+			attribute.StringValue("unknown"),
+			attribute.Int64Value(SeverityDebug),
+			attribute.Int64Value(SeverityFatal),
+			attribute.BoolValue(true),
+		} {
+			_, span := tracer.Start(context.Background(), v.Emit(),
+				trace.WithAttributes(attribute.KeyValue{Key: "severity", Value: v}))
+			span.End()
+		}
+	})
+	assert.Equal(t, []string{"warning", "ERROR", "21"}, names)
+}
+
+func TestSpanProcessorOKProbability(t *testing.T) {
+	names := endedNames(t, []Option{WithOKProbability(1)}, func(tracer trace.Tracer) {
+		_, span := tracer.Start(context.Background(), "ok")
+		span.End()
+	})
+	assert.Equal(t, []string{"ok"}, names)
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction
+
+import (
+	"regexp"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+var (
+	// CreditCardPattern matches payment card numbers of 13 to 19 digits,
+	// optionally separated by spaces or dashes.
+	CreditCardPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	// EmailPattern matches email addresses.
+	EmailPattern = regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`)
+)
+
+type config struct {
+	redactedKeys map[attribute.Key]struct{}
+	hashedKeys   map[attribute.Key]struct{}
+	queryKeys    map[attribute.Key]struct{}
+	patterns     []*regexp.Regexp
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithRedactedKeys sets attribute keys whose values are replaced with
+// Replacement.
+func WithRedactedKeys(keys ...attribute.Key) Option {
+	return optionFunc(func(c *config) {
+		c.redactedKeys = addKeys(c.redactedKeys, keys)
+	})
+}
+
+// WithHashedKeys sets attribute keys whose values are replaced with the hex
+// encoded SHA-256 hash of their string form. Unlike redacted values, hashed
+// values can still be used to correlate spans, but values with few
+// possibilities, such as phone numbers, can be recovered from the hash.
+func WithHashedKeys(keys ...attribute.Key) Option {
+	return optionFunc(func(c *config) {
+		c.hashedKeys = addKeys(c.hashedKeys, keys)
+	})
+}
+
+// WithPatterns sets patterns whose matches in string and string slice
+// attribute values are replaced with Replacement, such as
+// CreditCardPattern or EmailPattern.
+func WithPatterns(patterns ...*regexp.Regexp) Option {
+	return optionFunc(func(c *config) {
+		c.patterns = append(c.patterns, patterns...)
+	})
+}
+
+// WithQueryRedaction sets attribute keys of URLs or request targets whose
+// query parameter values are replaced with Replacement. If no keys are
+// passed, the http.url and http.target attributes are used.
+func WithQueryRedaction(keys ...attribute.Key) Option {
+	if len(keys) == 0 {
+		keys = []attribute.Key{semconv.HTTPURLKey, semconv.HTTPTargetKey}
+	}
+	return optionFunc(func(c *config) {
+		c.queryKeys = addKeys(c.queryKeys, keys)
+	})
+}
+
+func addKeys(set map[attribute.Key]struct{}, keys []attribute.Key) map[attribute.Key]struct{} {
+	if set == nil {
+		set = make(map[attribute.Key]struct{}, len(keys))
+	}
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redaction provides a span processor that scrubs or hashes
+// sensitive attribute values of spans before they are exported.
+package redaction
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Replacement is the value redacted values are replaced with.
+const Replacement = "REDACTED"
+
+// SpanProcessor is a span processor passing spans with redacted attributes
+// to the next span processor.
+//
+// The attributes of the spans and of their events are redacted when the
+// spans end, so all attributes are redacted regardless of when they were
+// set. The next span processor receives the redacted spans in OnEnd, while
+// OnStart receives the original spans, so that it does not need to be
+// wrapped by other span processors.
+type SpanProcessor struct {
+	next sdktrace.SpanProcessor
+	cfg  *config
+}
+
+// compile time assertion that SpanProcessor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*SpanProcessor)(nil)
+
+// NewSpanProcessor returns a SpanProcessor passing redacted spans to next,
+// which typically is a batch span processor.
+func NewSpanProcessor(next sdktrace.SpanProcessor, opts ...Option) *SpanProcessor {
+	return &SpanProcessor{next: next, cfg: newConfig(opts...)}
+}
+
+// OnStart is called when a span is started.
+func (p *SpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd redacts the attributes of the span and passes it to the next span
+// processor.
+func (p *SpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	attrs, changed := p.redact(s.Attributes())
+
+	events := s.Events()
+	var redactedEvents []sdktrace.Event
+	for i, e := range events {
+		eattrs, echanged := p.redact(e.Attributes)
+		if !echanged {
+			continue
+		}
+		if redactedEvents == nil {
+			redactedEvents = make([]sdktrace.Event, len(events))
+			copy(redactedEvents, events)
+		}
+		redactedEvents[i].Attributes = eattrs
+	}
+
+	if !changed && redactedEvents == nil {
+		p.next.OnEnd(s)
+		return
+	}
+	if redactedEvents == nil {
+		redactedEvents = events
+	}
+	p.next.OnEnd(redactedSpan{ReadOnlySpan: s, attrs: attrs, events: redactedEvents})
+}
+
+// Shutdown shuts down the next span processor.
+func (p *SpanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next span processor.
+func (p *SpanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// redact returns the redacted attributes and whether any was changed. The
+// passed attributes are not modified.
+func (p *SpanProcessor) redact(attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	var out []attribute.KeyValue
+	for i, kv := range attrs {
+		rkv, changed := p.redactAttribute(kv)
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = make([]attribute.KeyValue, len(attrs))
+			copy(out, attrs)
+		}
+		out[i] = rkv
+	}
+	if out == nil {
+		return attrs, false
+	}
+	return out, true
+}
+
+func (p *SpanProcessor) redactAttribute(kv attribute.KeyValue) (attribute.KeyValue, bool) {
+	if _, ok := p.cfg.redactedKeys[kv.Key]; ok {
+		return kv.Key.String(Replacement), true
+	}
+	if _, ok := p.cfg.hashedKeys[kv.Key]; ok {
+		return kv.Key.String(hash(kv.Value.Emit())), true
+	}
+
+	switch kv.Value.Type() {
+	case attribute.STRING:
+		v := kv.Value.AsString()
+		r := p.redactString(kv.Key, v)
+		return kv.Key.String(r), r != v
+	case attribute.STRINGSLICE:
+		// The slice is copied, since it is shared with the original value.
+		vs := append([]string(nil), kv.Value.AsStringSlice()...)
+		changed := false
+		for i, v := range vs {
+			if r := p.redactString(kv.Key, v); r != v {
+				vs[i] = r
+				changed = true
+			}
+		}
+		return kv.Key.StringSlice(vs), changed
+	}
+	return kv, false
+}
+
+func (p *SpanProcessor) redactString(key attribute.Key, v string) string {
+	if _, ok := p.cfg.queryKeys[key]; ok {
+		v = redactQuery(v)
+	}
+	for _, re := range p.cfg.patterns {
+		v = re.ReplaceAllLiteralString(v, Replacement)
+	}
+	return v
+}
+
+// redactQuery replaces the values of the query parameters of the URL or
+// request target v.
+func redactQuery(v string) string {
+	i := strings.IndexByte(v, '?')
+	if i < 0 {
+		return v
+	}
+	query, fragment := v[i+1:], ""
+	if j := strings.IndexByte(query, '#'); j >= 0 {
+		query, fragment = query[:j], query[j:]
+	}
+
+	params := strings.Split(query, "&")
+	for j, param := range params {
+		name := param
+		if k := strings.IndexByte(param, '='); k >= 0 {
+			name = param[:k]
+		}
+		if name != "" {
+			params[j] = name + "=" + url.QueryEscape(Replacement)
+		}
+	}
+	return v[:i+1] + strings.Join(params, "&") + fragment
+}
+
+func hash(v string) string {
+	sum := sha256.Sum256([]byte(v))
+	return hex.EncodeToString(sum[:])
+}
+
+// redactedSpan is a span with redacted attributes.
+type redactedSpan struct {
+	sdktrace.ReadOnlySpan
+
+	attrs  []attribute.KeyValue
+	events []sdktrace.Event
+}
+
+// Attributes returns the redacted attributes of the span.
+func (s redactedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
+
+// Events returns the events of the span with redacted attributes.
+func (s redactedSpan) Events() []sdktrace.Event {
+	return s.events
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanProcessor(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	unredacted := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewSpanProcessor(sr,
+			WithRedactedKeys("password"),
+			WithHashedKeys("user.id"),
+			WithPatterns(CreditCardPattern, EmailPattern),
+			WithQueryRedaction(),
+		)),
+		sdktrace.WithSpanProcessor(unredacted),
+	)
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span", trace.WithAttributes(
+		attribute.String("password", "hunter2"),
+		attribute.Int("user.id", 42),
+	))
+	span.SetAttributes(
+		attribute.String("http.url", "https://example.com/pay?card=4111111111111111&ok#top"),
+		attribute.String("http.target", "/search"),
+		attribute.StringSlice("recipients", []string{"jane@example.com", "team"}),
+		attribute.String("note", "card 4111 1111 1111 1111 expires soon"),
+		attribute.Int("retries", 3),
+	)
+	span.AddEvent("login", trace.WithAttributes(attribute.String("email", "jane@example.com")))
+	span.AddEvent("done")
+	span.End()
+
+	require.Len(t, sr.Ended(), 1)
+	s := sr.Ended()[0]
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("password", "REDACTED"),
+		attribute.String("user.id", "73475cb40a568e8da8a045ced110137e159f890ac4da883b6b17dc651b3a8049"),
+		attribute.String("http.url", "https://example.com/pay?card=REDACTED&ok=REDACTED#top"),
+		attribute.String("http.target", "/search"),
+		attribute.StringSlice("recipients", []string{"REDACTED", "team"}),
+		attribute.String("note", "card REDACTED expires soon"),
+		attribute.Int("retries", 3),
+	}, s.Attributes())
+	require.Len(t, s.Events(), 2)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("email", "REDACTED")}, s.Events()[0].Attributes)
+	assert.Empty(t, s.Events()[1].Attributes)
+
+	// The original span is not modified.
+	require.Len(t, unredacted.Ended(), 1)
+	orig := unredacted.Ended()[0]
+	assert.Contains(t, orig.Attributes(), attribute.StringSlice("recipients", []string{"jane@example.com", "team"}))
+	assert.Equal(t, []attribute.KeyValue{attribute.String("email", "jane@example.com")}, orig.Events()[0].Attributes)
+}
+
+func TestSpanProcessorUnchanged(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewSpanProcessor(sr, WithRedactedKeys("password"))))
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+
+	require.Len(t, sr.Ended(), 1)
+	_, wrapped := sr.Ended()[0].(redactedSpan)
+	assert.False(t, wrapped)
+}
+
+func TestRedactQuery(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"/users", "/users"},
+		{"/users?", "/users?"},
+		{"/users?id=1", "/users?id=REDACTED"},
+		{"/users?id=1&&flag", "/users?id=REDACTED&&flag=REDACTED"},
+		{"https://example.com/?q=a%20b#frag?x=1", "https://example.com/?q=REDACTED#frag?x=1"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.out, redactQuery(tc.in), tc.in)
+	}
+}
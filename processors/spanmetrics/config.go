@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+)
+
+// config is used to configure the span metrics processor.
+type config struct {
+	MeterProvider metric.MeterProvider
+
+	dimensions map[attribute.Key]struct{}
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) *config {
+	c := &config{
+		MeterProvider: global.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithMeterProvider sets the MeterProvider the metrics are recorded with.
+// If none is specified, the global MeterProvider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(c *config) {
+		if provider != nil {
+			c.MeterProvider = provider
+		}
+	})
+}
+
+// WithDimensions sets the span attributes added as labels to the metrics.
+// Only attributes with a small number of distinct values, such as
+// http.method or http.status_code, should be used, since every combination
+// of values creates a new time series.
+func WithDimensions(keys ...attribute.Key) Option {
+	return optionFunc(func(c *config) {
+		c.dimensions = make(map[attribute.Key]struct{}, len(keys))
+		for _, k := range keys {
+			c.dimensions[k] = struct{}{}
+		}
+	})
+}
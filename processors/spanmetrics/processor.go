@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spanmetrics provides a span processor that derives request, error,
+// and duration (RED) metrics from finished spans.
+//
+// The metrics are recorded on a MeterProvider, so they can be exported with
+// any metric exporter, such as the Cortex exporter, without instrumenting
+// the code with metrics separately.
+package spanmetrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/unit"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	instrumentationName = "go.opentelemetry.io/contrib/processors/spanmetrics"
+
+	// CallsMetric is the name of the counter of finished spans.
+	CallsMetric = "span.calls"
+	// ErrorsMetric is the name of the counter of finished spans with an
+	// error status.
+	ErrorsMetric = "span.errors"
+	// DurationMetric is the name of the histogram of span durations in
+	// milliseconds.
+	DurationMetric = "span.duration"
+
+	// SpanNameKey is the label holding the name of the span.
+	SpanNameKey = attribute.Key("span.name")
+	// SpanKindKey is the label holding the kind of the span.
+	SpanKindKey = attribute.Key("span.kind")
+)
+
+// Processor is a span processor recording metrics for every finished span.
+// All metrics have the name and kind of the span as labels, along with the
+// span attributes selected with WithDimensions.
+//
+// Spans are only seen by the Processor if they are recorded, so the
+// sampler of the TracerProvider needs to record all spans for the metrics to
+// be complete. Spans that are recorded but not sampled are not exported by
+// the span processors of the SDK.
+type Processor struct {
+	cfg *config
+
+	calls    metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// compile time assertion that Processor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*Processor)(nil)
+
+// NewProcessor returns a Processor recording metrics with a meter of the
+// configured MeterProvider.
+func NewProcessor(opts ...Option) (*Processor, error) {
+	p := &Processor{cfg: newConfig(opts...)}
+	meter := p.cfg.MeterProvider.Meter(
+		instrumentationName,
+		metric.WithInstrumentationVersion(SemVersion()),
+	)
+
+	var err error
+	if p.calls, err = meter.NewInt64Counter(
+		CallsMetric,
+		metric.WithDescription("Number of finished spans"),
+	); err != nil {
+		return nil, err
+	}
+	if p.errors, err = meter.NewInt64Counter(
+		ErrorsMetric,
+		metric.WithDescription("Number of finished spans with an error status"),
+	); err != nil {
+		return nil, err
+	}
+	if p.duration, err = meter.NewFloat64Histogram(
+		DurationMetric,
+		metric.WithDescription("Duration of finished spans"),
+		metric.WithUnit(unit.Milliseconds),
+	); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// OnStart does nothing.
+func (p *Processor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd records the metrics of the finished span.
+func (p *Processor) OnEnd(s sdktrace.ReadOnlySpan) {
+	ctx := context.Background()
+	labels := p.labels(s)
+
+	p.calls.Add(ctx, 1, labels...)
+	if s.Status().Code == codes.Error {
+		p.errors.Add(ctx, 1, labels...)
+	}
+	elapsed := s.EndTime().Sub(s.StartTime())
+	p.duration.Record(ctx, float64(elapsed.Nanoseconds())/1e6, labels...)
+}
+
+// Shutdown does nothing. The metrics are exported by the controller of the
+// MeterProvider.
+func (p *Processor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *Processor) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (p *Processor) labels(s sdktrace.ReadOnlySpan) []attribute.KeyValue {
+	labels := make([]attribute.KeyValue, 0, 2+len(p.cfg.dimensions))
+	labels = append(labels,
+		SpanNameKey.String(s.Name()),
+		SpanKindKey.String(s.SpanKind().String()),
+	)
+	if len(p.cfg.dimensions) == 0 {
+		return labels
+	}
+	for _, kv := range s.Attributes() {
+		if _, ok := p.cfg.dimensions[kv.Key]; ok {
+			labels = append(labels, kv)
+		}
+	}
+	return labels
+}
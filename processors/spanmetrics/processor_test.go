@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric/metrictest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestProcessor(t *testing.T) {
+	mp := metrictest.NewMeterProvider()
+	p, err := NewProcessor(WithMeterProvider(mp), WithDimensions("http.method"))
+	require.NoError(t, err)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+	tracer := tp.Tracer("test")
+
+	start := time.Now()
+	_, span := tracer.Start(context.Background(), "GET /users",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithTimestamp(start),
+		trace.WithAttributes(
+			attribute.String("http.method", "GET"),
+			attribute.String("http.target", "/users/1234"),
+		),
+	)
+	span.SetStatus(codes.Error, "failed")
+	span.End(trace.WithTimestamp(start.Add(1500 * time.Microsecond)))
+
+	_, span = tracer.Start(context.Background(), "query", trace.WithTimestamp(start))
+	span.End(trace.WithTimestamp(start.Add(time.Millisecond)))
+
+	serverLabels := map[attribute.Key]attribute.Value{
+		SpanNameKey:   attribute.StringValue("GET /users"),
+		SpanKindKey:   attribute.StringValue("server"),
+		"http.method": attribute.StringValue("GET"),
+	}
+	internalLabels := map[attribute.Key]attribute.Value{
+		SpanNameKey: attribute.StringValue("query"),
+		SpanKindKey: attribute.StringValue("internal"),
+	}
+
+	type record struct {
+		name   string
+		labels map[attribute.Key]attribute.Value
+		value  float64
+	}
+	var got []record
+	for _, m := range metrictest.AsStructs(mp.MeasurementBatches) {
+		assert.Equal(t, instrumentationName, m.Library.InstrumentationName)
+		var v float64
+		if m.Name == DurationMetric {
+			v = m.Number.AsFloat64()
+		} else {
+			v = float64(m.Number.AsInt64())
+		}
+		got = append(got, record{name: m.Name, labels: m.Labels, value: v})
+	}
+
+	assert.Equal(t, []record{
+		{CallsMetric, serverLabels, 1},
+		{ErrorsMetric, serverLabels, 1},
+		{DurationMetric, serverLabels, 1.5},
+		{CallsMetric, internalLabels, 1},
+		{DurationMetric, internalLabels, 1},
+	}, got)
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import "time"
+
+const (
+	defaultLatencyThreshold = time.Second
+	defaultDecisionWait     = 10 * time.Second
+	defaultMaxTraces        = 10000
+)
+
+type config struct {
+	latencyThreshold      time.Duration
+	backgroundProbability float64
+	decisionWait          time.Duration
+	maxTraces             int
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) *config {
+	c := &config{
+		latencyThreshold: defaultLatencyThreshold,
+		decisionWait:     defaultDecisionWait,
+		maxTraces:        defaultMaxTraces,
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// checkInterval returns how often the traces whose decision wait elapsed
+// are decided.
+func (c *config) checkInterval() time.Duration {
+	if d := c.decisionWait / 10; d > 10*time.Millisecond {
+		return d
+	}
+	return 10 * time.Millisecond
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithLatencyThreshold sets the duration from which a span makes its trace
+// kept. The default is 1 second.
+func WithLatencyThreshold(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.latencyThreshold = d
+	})
+}
+
+// WithBackgroundProbability sets the probability with which traces that are
+// neither slow nor contain errors are kept. The default is 0. The value is
+// clamped to [0, 1].
+func WithBackgroundProbability(p float64) Option {
+	return optionFunc(func(c *config) {
+		switch {
+		case p < 0:
+			p = 0
+		case p > 1:
+			p = 1
+		}
+		c.backgroundProbability = p
+	})
+}
+
+// WithDecisionWait sets how long the spans of a trace are buffered at most
+// before the trace is decided. The default is 10 seconds. Non-positive
+// values are ignored.
+func WithDecisionWait(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if d > 0 {
+			c.decisionWait = d
+		}
+	})
+}
+
+// WithMaxTraces sets the maximum number of traces buffered. When it is
+// exceeded, the oldest trace is decided. The default is 10000. Non-positive
+// values are ignored.
+func WithMaxTraces(n int) Option {
+	return optionFunc(func(c *config) {
+		if n > 0 {
+			c.maxTraces = n
+		}
+	})
+}
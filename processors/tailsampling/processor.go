@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tailsampling provides a span processor that makes sampling
+// decisions on complete local traces, keeping only the traces that are slow
+// or contain errors.
+package tailsampling
+
+import (
+	"container/list"
+	"context"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceBuffer holds the spans of a local trace until it is complete.
+type traceBuffer struct {
+	// open is the number of started spans that have not ended yet.
+	open    int
+	spans   []sdktrace.ReadOnlySpan
+	created time.Time
+	// elem is the element of the trace in the order of the Processor.
+	elem *list.Element
+}
+
+// Processor is a span processor buffering the spans of each trace until all
+// spans of the trace started in the process have ended. The spans of the
+// trace are then forwarded to the next span processor if one of them took
+// longer than the latency threshold or has an error status, or if the trace
+// is selected by the background probability. All other spans are dropped.
+//
+// A trace is decided with the spans buffered so far once the decision wait
+// elapses, or when the number of buffered traces exceeds the maximum. Spans
+// of a trace that end after its decision are buffered as a new trace.
+//
+// The Processor only sees spans that are recorded, so the spans to decide on
+// need to be sampled by the sampler of the TracerProvider.
+type Processor struct {
+	next sdktrace.SpanProcessor
+	cfg  *config
+
+	mu     sync.Mutex
+	traces map[trace.TraceID]*traceBuffer
+	// order holds the trace IDs of traces in the order they were buffered,
+	// so that a trace can be removed from it in constant time.
+	order *list.List
+
+	now      func() time.Time
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// compile time assertion that Processor implements the
+// sdktrace.SpanProcessor interface.
+var _ sdktrace.SpanProcessor = (*Processor)(nil)
+
+// NewProcessor returns a Processor forwarding the spans of the traces it
+// keeps to next, which typically is a batch span processor.
+func NewProcessor(next sdktrace.SpanProcessor, opts ...Option) *Processor {
+	p := &Processor{
+		next:   next,
+		cfg:    newConfig(opts...),
+		traces: make(map[trace.TraceID]*traceBuffer),
+		order:  list.New(),
+		now:    time.Now,
+		stopCh: make(chan struct{}),
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.run()
+	}()
+	return p
+}
+
+// OnStart is called when a span is started.
+func (p *Processor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.mu.Lock()
+	tb := p.buffer(s.SpanContext().TraceID())
+	tb.open++
+	decided := p.evict()
+	p.mu.Unlock()
+
+	p.next.OnStart(parent, s)
+	p.forward(decided)
+}
+
+// OnEnd is called when a span is ended. The span is buffered until the
+// trace is decided.
+func (p *Processor) OnEnd(s sdktrace.ReadOnlySpan) {
+	var decided [][]sdktrace.ReadOnlySpan
+
+	p.mu.Lock()
+	id := s.SpanContext().TraceID()
+	tb := p.buffer(id)
+	tb.spans = append(tb.spans, s)
+	if tb.open > 0 {
+		tb.open--
+	}
+	if tb.open == 0 {
+		decided = append(decided, p.remove(id))
+	}
+	decided = append(decided, p.evict()...)
+	p.mu.Unlock()
+
+	p.forward(decided)
+}
+
+// Shutdown decides all buffered traces and shuts down the next span
+// processor.
+func (p *Processor) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+	p.wg.Wait()
+	p.flush()
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush decides all buffered traces, including incomplete ones, and
+// flushes the next span processor.
+func (p *Processor) ForceFlush(ctx context.Context) error {
+	p.flush()
+	return p.next.ForceFlush(ctx)
+}
+
+// run decides the traces whose decision wait elapsed until the processor is
+// shut down.
+func (p *Processor) run() {
+	ticker := time.NewTicker(p.cfg.checkInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+			p.expire()
+		}
+	}
+}
+
+// expire decides the traces buffered for longer than the decision wait.
+func (p *Processor) expire() {
+	var decided [][]sdktrace.ReadOnlySpan
+
+	p.mu.Lock()
+	deadline := p.now().Add(-p.cfg.decisionWait)
+	for p.order.Len() > 0 {
+		id := p.order.Front().Value.(trace.TraceID)
+		if p.traces[id].created.After(deadline) {
+			break
+		}
+		decided = append(decided, p.remove(id))
+	}
+	p.mu.Unlock()
+
+	p.forward(decided)
+}
+
+// flush decides all buffered traces.
+func (p *Processor) flush() {
+	var decided [][]sdktrace.ReadOnlySpan
+
+	p.mu.Lock()
+	for p.order.Len() > 0 {
+		decided = append(decided, p.remove(p.order.Front().Value.(trace.TraceID)))
+	}
+	p.mu.Unlock()
+
+	p.forward(decided)
+}
+
+// buffer returns the buffer of the trace with the given ID, creating it if
+// needed. It must be called with mu held.
+func (p *Processor) buffer(id trace.TraceID) *traceBuffer {
+	tb, ok := p.traces[id]
+	if !ok {
+		tb = &traceBuffer{created: p.now(), elem: p.order.PushBack(id)}
+		p.traces[id] = tb
+	}
+	return tb
+}
+
+// evict removes the oldest traces while more than the maximum number of
+// traces are buffered, and returns their spans. It must be called with mu
+// held.
+func (p *Processor) evict() [][]sdktrace.ReadOnlySpan {
+	var decided [][]sdktrace.ReadOnlySpan
+	for p.order.Len() > p.cfg.maxTraces {
+		decided = append(decided, p.remove(p.order.Front().Value.(trace.TraceID)))
+	}
+	return decided
+}
+
+// remove removes the trace with the given ID from the buffer and returns its
+// spans. It must be called with mu held.
+func (p *Processor) remove(id trace.TraceID) []sdktrace.ReadOnlySpan {
+	tb := p.traces[id]
+	delete(p.traces, id)
+	p.order.Remove(tb.elem)
+	return tb.spans
+}
+
+// forward passes the spans of the decided traces that are kept to the next
+// span processor.
+func (p *Processor) forward(traces [][]sdktrace.ReadOnlySpan) {
+	for _, spans := range traces {
+		if !p.keep(spans) {
+			continue
+		}
+		for _, s := range spans {
+			p.next.OnEnd(s)
+		}
+	}
+}
+
+// keep reports whether the trace with the given spans is kept.
+func (p *Processor) keep(spans []sdktrace.ReadOnlySpan) bool {
+	if len(spans) == 0 {
+		return false
+	}
+	for _, s := range spans {
+		if s.Status().Code == codes.Error {
+			return true
+		}
+		if s.EndTime().Sub(s.StartTime()) >= p.cfg.latencyThreshold {
+			return true
+		}
+	}
+
+	// The background decision is based on the trace ID in the same way as
+	// the TraceIDRatioBased sampler of the SDK.
+	id := spans[0].SpanContext().TraceID()
+	x := binary.BigEndian.Uint64(id[8:16]) >> 1
+	return x < uint64(p.cfg.backgroundProbability*(1<<63))
+}
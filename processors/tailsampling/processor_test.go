@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestProvider(t *testing.T, opts ...Option) (trace.Tracer, *Processor, *tracetest.SpanRecorder) {
+	sr := tracetest.NewSpanRecorder()
+	p := NewProcessor(sr, opts...)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	return tp.Tracer("test"), p, sr
+}
+
+func spanNames(spans []sdktrace.ReadOnlySpan) []string {
+	names := make([]string, 0, len(spans))
+	for _, s := range spans {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+func TestProcessorKeepsSlowTraces(t *testing.T) {
+	tracer, _, sr := newTestProvider(t, WithLatencyThreshold(time.Second))
+	start := time.Now()
+
+	ctx, parent := tracer.Start(context.Background(), "slow", trace.WithTimestamp(start))
+	_, child := tracer.Start(ctx, "child", trace.WithTimestamp(start))
+	child.End(trace.WithTimestamp(start.Add(2 * time.Second)))
+	assert.Empty(t, sr.Ended(), "trace not complete")
+	parent.End(trace.WithTimestamp(start.Add(2 * time.Second)))
+
+	ctx, parent = tracer.Start(context.Background(), "fast", trace.WithTimestamp(start))
+	_, child = tracer.Start(ctx, "child", trace.WithTimestamp(start))
+	child.End(trace.WithTimestamp(start.Add(time.Millisecond)))
+	parent.End(trace.WithTimestamp(start.Add(time.Millisecond)))
+
+	assert.Equal(t, []string{"child", "slow"}, spanNames(sr.Ended()))
+}
+
+func TestProcessorKeepsErrorTraces(t *testing.T) {
+	tracer, _, sr := newTestProvider(t)
+
+	ctx, parent := tracer.Start(context.Background(), "error")
+	_, child := tracer.Start(ctx, "child")
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	parent.End()
+
+	_, span := tracer.Start(context.Background(), "ok")
+	span.End()
+
+	assert.Equal(t, []string{"child", "error"}, spanNames(sr.Ended()))
+}
+
+func TestProcessorBackgroundProbability(t *testing.T) {
+	tracer, _, sr := newTestProvider(t, WithBackgroundProbability(1))
+
+	_, span := tracer.Start(context.Background(), "ok")
+	span.End()
+
+	assert.Equal(t, []string{"ok"}, spanNames(sr.Ended()))
+}
+
+func TestProcessorDecisionWait(t *testing.T) {
+	tracer, _, sr := newTestProvider(t, WithDecisionWait(50*time.Millisecond), WithLatencyThreshold(0))
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+
+	require.Eventually(t, func() bool {
+		return len(sr.Ended()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"child"}, spanNames(sr.Ended()))
+
+	// The late span is buffered as a new trace.
+	parent.End()
+	assert.Equal(t, []string{"child", "parent"}, spanNames(sr.Ended()))
+}
+
+func TestProcessorMaxTraces(t *testing.T) {
+	tracer, _, sr := newTestProvider(t, WithMaxTraces(1), WithLatencyThreshold(0))
+
+	ctx, first := tracer.Start(context.Background(), "first")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	assert.Empty(t, sr.Ended())
+
+	// The first trace is decided when the second is buffered.
+	_, second := tracer.Start(context.Background(), "second")
+	assert.Equal(t, []string{"child"}, spanNames(sr.Ended()))
+
+	second.End()
+	first.End()
+	assert.Equal(t, []string{"child", "second", "first"}, spanNames(sr.Ended()))
+}
+
+func TestProcessorForceFlush(t *testing.T) {
+	tracer, p, sr := newTestProvider(t, WithLatencyThreshold(0))
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	assert.Empty(t, sr.Ended())
+
+	require.NoError(t, p.ForceFlush(context.Background()))
+	assert.Equal(t, []string{"child"}, spanNames(sr.Ended()))
+	parent.End()
+}
+
+func TestProcessorShutdown(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	p := NewProcessor(sr, WithLatencyThreshold(0))
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, child := tp.Tracer("test").Start(ctx, "child")
+	child.End()
+
+	require.NoError(t, tp.Shutdown(context.Background()))
+	assert.Equal(t, []string{"child"}, spanNames(sr.Ended()))
+	parent.End()
+}
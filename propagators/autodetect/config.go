@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+import (
+	"go.opentelemetry.io/otel/propagation"
+)
+
+type config struct {
+	// formats are the formats tried by Extract, in order.
+	formats []Format
+	// propagators are the propagators of custom formats.
+	propagators map[Format]propagation.TextMapPropagator
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) *config {
+	c := &config{
+		formats:     DefaultFormats(),
+		propagators: map[Format]propagation.TextMapPropagator{},
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithFormats sets the formats the propagator uses, in the order Extract
+// tries them. The default is DefaultFormats.
+func WithFormats(formats ...Format) Option {
+	return optionFunc(func(c *config) {
+		c.formats = formats
+	})
+}
+
+// WithPropagator sets the propagator used for format, which can be one of
+// the formats of this package to replace its default propagator, or a custom
+// format. A custom format is only used if it is also passed to WithFormats.
+func WithPropagator(format Format, p propagation.TextMapPropagator) Option {
+	return optionFunc(func(c *config) {
+		c.propagators[format] = p
+	})
+}
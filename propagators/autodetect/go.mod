@@ -0,0 +1,21 @@
+module go.opentelemetry.io/contrib/propagators/autodetect
+
+go 1.15
+
+replace (
+	go.opentelemetry.io/contrib/propagators/aws => ../aws
+	go.opentelemetry.io/contrib/propagators/b3 => ../b3
+	go.opentelemetry.io/contrib/propagators/datadog => ../datadog
+	go.opentelemetry.io/contrib/propagators/jaeger => ../jaeger
+	go.opentelemetry.io/contrib/propagators/propagatortest => ../propagatortest
+)
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/propagators/aws v1.1.0
+	go.opentelemetry.io/contrib/propagators/b3 v1.1.0
+	go.opentelemetry.io/contrib/propagators/datadog v0.26.0
+	go.opentelemetry.io/contrib/propagators/jaeger v1.1.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package autodetect implements a propagator extracting the trace context
+// from whichever of several formats the carrier holds, for ingress points
+// where the format used upstream is unknown.
+package autodetect // import "go.opentelemetry.io/contrib/propagators/autodetect"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/datadog"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+)
+
+// Format is a trace context propagation format.
+type Format string
+
+// The formats supported by default.
+const (
+	// FormatXRay is the AWS X-Ray X-Amzn-Trace-Id header.
+	FormatXRay Format = "xray"
+	// FormatTraceContext is the W3C traceparent and tracestate headers.
+	FormatTraceContext Format = "tracecontext"
+	// FormatB3 is the B3 single or multiple headers.
+	FormatB3 Format = "b3"
+	// FormatJaeger is the Jaeger uber-trace-id header.
+	FormatJaeger Format = "jaeger"
+	// FormatDatadog is the Datadog x-datadog-* headers.
+	FormatDatadog Format = "datadog"
+)
+
+// FormatKey is the attribute key of the format a trace context was
+// extracted from, see FormatAttribute.
+const FormatKey = attribute.Key("trace.propagation.format")
+
+// DefaultFormats returns the formats used by default, in the order they are
+// tried by Extract.
+func DefaultFormats() []Format {
+	return []Format{FormatXRay, FormatTraceContext, FormatB3, FormatJaeger, FormatDatadog}
+}
+
+func defaultPropagator(format Format) propagation.TextMapPropagator {
+	switch format {
+	case FormatXRay:
+		return xray.Propagator{}
+	case FormatTraceContext:
+		return propagation.TraceContext{}
+	case FormatB3:
+		return b3.New()
+	case FormatJaeger:
+		return jaeger.Jaeger{}
+	case FormatDatadog:
+		return datadog.Propagator{}
+	}
+	return nil
+}
+
+type formatKeyType int
+
+const formatContextKey formatKeyType = 0
+
+// FormatFromContext returns the format the remote span context of ctx was
+// extracted from by a Propagator, if any.
+func FormatFromContext(ctx context.Context) (Format, bool) {
+	f, ok := ctx.Value(formatContextKey).(Format)
+	return f, ok
+}
+
+// FormatAttribute returns the attribute recording the format the remote
+// span context of ctx was extracted from by a Propagator, to be added to
+// the span started from ctx.
+func FormatAttribute(ctx context.Context) (attribute.KeyValue, bool) {
+	f, ok := FormatFromContext(ctx)
+	if !ok {
+		return attribute.KeyValue{}, false
+	}
+	return FormatKey.String(string(f)), true
+}
+
+type formatPropagator struct {
+	format     Format
+	propagator propagation.TextMapPropagator
+}
+
+// Propagator extracts the trace context from the first of its formats the
+// carrier holds, and injects it in all of them.
+type Propagator struct {
+	propagators []formatPropagator
+}
+
+// Asserts that the propagator implements the otel.TextMapPropagator interface at compile time.
+var _ propagation.TextMapPropagator = &Propagator{}
+
+// New returns a Propagator configured with opts. Formats without a
+// propagator are ignored.
+func New(opts ...Option) *Propagator {
+	c := newConfig(opts...)
+	p := &Propagator{}
+	for _, f := range c.formats {
+		prop, ok := c.propagators[f]
+		if !ok {
+			prop = defaultPropagator(f)
+		}
+		if prop != nil {
+			p.propagators = append(p.propagators, formatPropagator{format: f, propagator: prop})
+		}
+	}
+	return p
+}
+
+// Inject injects the trace context of ctx in all the formats of the
+// propagator, as a composite propagator does.
+func (p *Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	for _, fp := range p.propagators {
+		fp.propagator.Inject(ctx, carrier)
+	}
+}
+
+// Extract extracts the trace context from the first format, in order, the
+// carrier holds a valid trace context in. The format is recorded in the
+// returned context, see FormatFromContext.
+func (p *Propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	orig := trace.SpanContextFromContext(ctx)
+	for _, fp := range p.propagators {
+		extracted := fp.propagator.Extract(ctx, carrier)
+		// Propagators return ctx as is if the carrier does not hold
+		// their format.
+		sc := trace.SpanContextFromContext(extracted)
+		if sc.IsValid() && sc.IsRemote() && !sc.Equal(orig) {
+			return context.WithValue(extracted, formatContextKey, fp.format)
+		}
+	}
+	return ctx
+}
+
+// Fields returns the fields of all the formats of the propagator.
+func (p *Propagator) Fields() []string {
+	seen := map[string]struct{}{}
+	var fields []string
+	for _, fp := range p.propagators {
+		for _, f := range fp.propagator.Fields() {
+			if _, ok := seen[f]; !ok {
+				seen[f] = struct{}{}
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	traceID = trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	spanID  = trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
+	sc      = trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+)
+
+func TestExtract(t *testing.T) {
+	testCases := []struct {
+		name    string
+		headers map[string]string
+		format  Format
+	}{
+		{
+			name:    "xray",
+			headers: map[string]string{"X-Amzn-Trace-Id": "Root=1-4bf92f35-77b34da6a3ce929d0e0e4736;Parent=00f067aa0ba902b7;Sampled=1"},
+			format:  FormatXRay,
+		},
+		{
+			name:    "tracecontext",
+			headers: map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			format:  FormatTraceContext,
+		},
+		{
+			name:    "b3",
+			headers: map[string]string{"b3": "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1"},
+			format:  FormatB3,
+		},
+		{
+			name:    "jaeger",
+			headers: map[string]string{"uber-trace-id": "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1"},
+			format:  FormatJaeger,
+		},
+		{
+			name: "first format wins",
+			headers: map[string]string{
+				"traceparent":   "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+				"uber-trace-id": "00000000000000000000000000000001:0000000000000001:0:1",
+			},
+			format: FormatTraceContext,
+		},
+		{
+			name: "invalid format skipped",
+			headers: map[string]string{
+				"traceparent": "invalid",
+				"b3":          "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1",
+			},
+			format: FormatB3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tc.headers {
+				header.Set(k, v)
+			}
+			ctx := New().Extract(context.Background(), propagation.HeaderCarrier(header))
+
+			assert.Equal(t, sc.WithRemote(true), trace.SpanContextFromContext(ctx))
+			format, ok := FormatFromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, tc.format, format)
+			attr, ok := FormatAttribute(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, FormatKey.String(string(tc.format)), attr)
+		})
+	}
+}
+
+func TestExtractDatadog(t *testing.T) {
+	header := http.Header{}
+	header.Set("x-datadog-trace-id", "11803532876627986230")
+	header.Set("x-datadog-parent-id", "67667974448284343")
+	header.Set("x-datadog-sampling-priority", "1")
+	ctx := New().Extract(context.Background(), propagation.HeaderCarrier(header))
+
+	format, ok := FormatFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, FormatDatadog, format)
+	assert.Equal(t, spanID, trace.SpanContextFromContext(ctx).SpanID())
+}
+
+func TestExtractNoFormat(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, New().Extract(ctx, propagation.HeaderCarrier(http.Header{})))
+	_, ok := FormatAttribute(ctx)
+	assert.False(t, ok)
+}
+
+func TestWithFormats(t *testing.T) {
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	header.Set("b3", "00000000000000000000000000000001-0000000000000001-1")
+
+	p := New(WithFormats(FormatB3, FormatTraceContext))
+	ctx := p.Extract(context.Background(), propagation.HeaderCarrier(header))
+	format, _ := FormatFromContext(ctx)
+	assert.Equal(t, FormatB3, format)
+
+	p = New(WithFormats(FormatJaeger))
+	ctx = p.Extract(context.Background(), propagation.HeaderCarrier(header))
+	_, ok := FormatFromContext(ctx)
+	assert.False(t, ok)
+}
+
+func TestWithPropagator(t *testing.T) {
+	custom := Format("custom")
+	p := New(
+		WithFormats(custom, FormatTraceContext),
+		WithPropagator(custom, propagation.TraceContext{}),
+	)
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := p.Extract(context.Background(), propagation.HeaderCarrier(header))
+	format, _ := FormatFromContext(ctx)
+	assert.Equal(t, custom, format)
+	assert.Equal(t, []string{"traceparent", "tracestate"}, p.Fields())
+}
+
+func TestInject(t *testing.T) {
+	header := http.Header{}
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	New().Inject(ctx, propagation.HeaderCarrier(header))
+
+	for _, f := range DefaultFormats() {
+		ctx := New(WithFormats(f)).Extract(context.Background(), propagation.HeaderCarrier(header))
+		format, ok := FormatFromContext(ctx)
+		require.True(t, ok, f)
+		assert.Equal(t, f, format)
+		assert.Equal(t, spanID, trace.SpanContextFromContext(ctx).SpanID(), f)
+	}
+}
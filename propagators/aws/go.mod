@@ -4,7 +4,10 @@ go 1.15
 
 require (
 	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/propagators/propagatortest v0.26.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/sdk v1.1.0
 	go.opentelemetry.io/otel/trace v1.1.0
 )
+
+replace go.opentelemetry.io/contrib/propagators/propagatortest => ../propagatortest
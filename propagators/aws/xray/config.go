@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+type config struct {
+	// UnsampledInjection determines how span contexts that are not sampled
+	// are injected. If no mode is specified (i.e. `InjectUnsampled`) the
+	// trace header is injected with `Sampled=0`.
+	UnsampledInjection UnsampledInjection
+}
+
+// Option configures a Propagator.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+func newConfig(opts ...Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// UnsampledInjection is the way the trace header of a span context that is
+// not sampled is injected.
+type UnsampledInjection uint8
+
+const (
+	// InjectUnsampled injects the trace header of unsampled span contexts
+	// with the sampling decision set to not sampled.
+	//    X-Amzn-Trace-Id: Root={traceId};Parent={parentId};Sampled=0
+	InjectUnsampled UnsampledInjection = iota
+	// SkipUnsampled does not inject a trace header for unsampled span
+	// contexts. Downstream services, and AWS load balancers in particular,
+	// will make their own sampling decision for the request.
+	SkipUnsampled
+)
+
+// WithUnsampledInjection sets the way span contexts that are not sampled
+// are injected. The default is InjectUnsampled.
+func WithUnsampledInjection(mode UnsampledInjection) Option {
+	return optionFunc(func(c *config) {
+		c.UnsampledInjection = mode
+	})
+}
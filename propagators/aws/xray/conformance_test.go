@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray_test
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/contrib/propagators/propagatortest"
+)
+
+func TestConformance(t *testing.T) {
+	propagatortest.Run(t, propagatortest.Config{
+		Propagator: xray.Propagator{},
+		Invalid: []map[string]string{
+			{"X-Amzn-Trace-Id": "invalid"},
+			{"X-Amzn-Trace-Id": "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Sampled=1"},
+			{"X-Amzn-Trace-Id": "Root=1-00000000-000000000000000000000000;Parent=53995c3f42cd8ad8;Sampled=1"},
+			{"X-Amzn-Trace-Id": "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Root=1-8a3c60f7-d188f8fa79d48a391a778fa6"},
+		},
+		Compatible: []propagation.TextMapPropagator{
+			xray.New(xray.WithUnsampledInjection(xray.InjectUnsampled)),
+		},
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package xray
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FuzzExtract checks that extract does not panic on any header, and that the
+// span contexts it extracts are injected and extracted again unchanged. The
+// corpus of testdata/fuzz/FuzzExtract holds the headers of the edge cases
+// found so far.
+func FuzzExtract(f *testing.F) {
+	f.Add("Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1")
+	f.Add("Self=1-67891234-12456789abcdef012345678;Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=?;Lineage=a87bd80c:1|68fd508a:5")
+	f.Add(" Root = 1-8a3c60f7-d188f8fa79d48a391a778fa6 ; Parent=53995c3f42cd8ad8 ;")
+	f.Add("Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Root=1-8a3c60f7-d188f8fa79d48a391a778fa6")
+
+	f.Fuzz(func(t *testing.T, header string) {
+		sc, err := extract(header)
+		if err != nil || !sc.IsValid() {
+			return
+		}
+
+		carrier := propagation.HeaderCarrier(http.Header{})
+		Propagator{}.Inject(trace.ContextWithSpanContext(context.Background(), sc), carrier)
+		got, err := extract(carrier.Get(traceHeaderKey))
+		if err != nil {
+			t.Fatalf("extract(%q) of the injected header of %q: %v", carrier.Get(traceHeaderKey), header, err)
+		}
+		if !got.Equal(sc.WithRemote(false)) {
+			t.Fatalf("extract(%q) = %v, want %v", carrier.Get(traceHeaderKey), got, sc)
+		}
+	})
+}
@@ -18,9 +18,7 @@ import (
 	"context"
 	crand "crypto/rand"
 	"encoding/binary"
-	"encoding/hex"
 	"math/rand"
-	"strconv"
 	"sync"
 	"time"
 
@@ -54,8 +52,7 @@ func (gen *IDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID
 	defer gen.Unlock()
 
 	tid := trace.TraceID{}
-	currentTime := getCurrentTimeHex()
-	copy(tid[:4], currentTime)
+	binary.BigEndian.PutUint32(tid[:4], uint32(time.Now().Unix()))
 	gen.randSource.Read(tid[4:])
 
 	sid := trace.SpanID{}
@@ -71,12 +68,3 @@ func NewIDGenerator() *IDGenerator {
 	gen.randSource = rand.New(rand.NewSource(rngSeed))
 	return gen
 }
-
-func getCurrentTimeHex() []uint8 {
-	currentTime := time.Now().Unix()
-	// Ignore error since no expected error should result from this operation
-	// Odd-length strings and non-hex digits are the only 2 error conditions for hex.DecodeString()
-	// strconv.FromatInt() do not produce odd-length strings or non-hex digits
-	currentTimeHex, _ := hex.DecodeString(strconv.FormatInt(currentTime, 16))
-	return currentTimeHex
-}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// lambdaTraceHeaderEnvVar is the environment variable the AWS Lambda
+	// runtime sets to the trace header of the current invocation.
+	lambdaTraceHeaderEnvVar = "_X_AMZN_TRACE_ID"
+	// lambdaTraceHeaderContextKey is the key the aws-lambda-go runtime
+	// uses to store the trace header of the current invocation in the
+	// handler context.
+	lambdaTraceHeaderContextKey = "x-amzn-trace-id"
+)
+
+// LambdaCarrier returns a carrier holding the X-Ray trace header of the
+// current AWS Lambda invocation.
+//
+// The header is read from ctx when it is the context passed to a handler by
+// the aws-lambda-go runtime, and from the _X_AMZN_TRACE_ID environment
+// variable otherwise. The returned carrier is empty if neither is set.
+func LambdaCarrier(ctx context.Context) propagation.TextMapCarrier {
+	carrier := propagation.HeaderCarrier{}
+	if header := lambdaTraceHeader(ctx); header != "" {
+		carrier.Set(traceHeaderKey, header)
+	}
+	return carrier
+}
+
+// ExtractLambda returns a copy of ctx with the remote span context of the
+// current AWS Lambda invocation. In AWS Lambda the incoming trace header is
+// not delivered with the event, so it is read as described by LambdaCarrier.
+// If no valid trace header is found, ctx is returned unchanged.
+func ExtractLambda(ctx context.Context) context.Context {
+	return Propagator{}.Extract(ctx, LambdaCarrier(ctx))
+}
+
+func lambdaTraceHeader(ctx context.Context) string {
+	if ctx != nil {
+		if header, ok := ctx.Value(lambdaTraceHeaderContextKey).(string); ok && header != "" {
+			return header
+		}
+	}
+	return os.Getenv(lambdaTraceHeaderEnvVar)
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	lambdaHeader      = "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1"
+	otherLambdaHeader = "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=0000000000000001;Sampled=0"
+)
+
+func setLambdaEnv(t *testing.T, value string) {
+	orig, ok := os.LookupEnv(lambdaTraceHeaderEnvVar)
+	_ = os.Setenv(lambdaTraceHeaderEnvVar, value)
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(lambdaTraceHeaderEnvVar, orig)
+		} else {
+			_ = os.Unsetenv(lambdaTraceHeaderEnvVar)
+		}
+	})
+}
+
+func TestLambdaCarrier(t *testing.T) {
+	setLambdaEnv(t, lambdaHeader)
+
+	carrier := LambdaCarrier(context.Background())
+	assert.Equal(t, lambdaHeader, carrier.Get(traceHeaderKey))
+	assert.Equal(t, []string{traceHeaderKey}, carrier.Keys())
+
+	//nolint:staticcheck // The aws-lambda-go runtime uses a string key.
+	ctx := context.WithValue(context.Background(), lambdaTraceHeaderContextKey, otherLambdaHeader)
+	carrier = LambdaCarrier(ctx)
+	assert.Equal(t, otherLambdaHeader, carrier.Get(traceHeaderKey))
+}
+
+func TestLambdaCarrierEmpty(t *testing.T) {
+	setLambdaEnv(t, "")
+
+	carrier := LambdaCarrier(context.Background())
+	assert.Equal(t, "", carrier.Get(traceHeaderKey))
+	assert.Empty(t, carrier.Keys())
+}
+
+func TestExtractLambda(t *testing.T) {
+	setLambdaEnv(t, lambdaHeader)
+
+	sc := trace.SpanContextFromContext(ExtractLambda(context.Background()))
+	assert.Equal(t, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     parentSpanID,
+		TraceFlags: traceFlagSampled,
+		Remote:     true,
+	}), sc)
+}
+
+func TestExtractLambdaInvalid(t *testing.T) {
+	setLambdaEnv(t, "Root=invalid")
+
+	ctx := context.Background()
+	assert.Equal(t, ctx, ExtractLambda(ctx))
+}
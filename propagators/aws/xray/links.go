@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceHeaderIDsKey is the context key of the TraceHeaderIDs of an extracted
+// trace header.
+type traceHeaderIDsKey struct{}
+
+// TraceHeaderIDs are the IDs of an X-Amzn-Trace-Id header that are not part
+// of the span context extracted from it, such as the Parent and Self fields
+// added by AWS services relaying the request:
+//
+//	X-Amzn-Trace-Id: Self=1-67891234-12456789abcdef012345678;Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Parent=f51ea8a9b6bd61a2
+//
+// The Propagator keeps them in the context it extracts, so that the
+// instrumentation can link the span of the request to the spans of the other
+// parents with LinksFromContext.
+type TraceHeaderIDs struct {
+	// Parents are the span IDs of the Parent fields following the first
+	// one, which is the parent of the extracted span context.
+	Parents []trace.SpanID
+	// Self are the values of the Self fields, which Application Load
+	// Balancers add to identify their hop in the format of the X-Ray trace
+	// IDs.
+	Self []string
+
+	// spanContext is the span context extracted from the header.
+	spanContext trace.SpanContext
+}
+
+// Links returns the links to the span contexts of the extra Parents, which
+// have the trace ID and sampling decision of the extracted span context.
+func (ids TraceHeaderIDs) Links() []trace.Link {
+	if len(ids.Parents) == 0 || !ids.spanContext.IsValid() {
+		return nil
+	}
+	links := make([]trace.Link, len(ids.Parents))
+	for i, parent := range ids.Parents {
+		links[i] = trace.Link{SpanContext: ids.spanContext.WithSpanID(parent)}
+	}
+	return links
+}
+
+// isEmpty returns whether the header had no extra IDs.
+func (ids TraceHeaderIDs) isEmpty() bool {
+	return len(ids.Parents) == 0 && len(ids.Self) == 0
+}
+
+// TraceHeaderIDsFromContext returns the TraceHeaderIDs of the trace header
+// the Propagator extracted ctx from, which are empty if it had none.
+func TraceHeaderIDsFromContext(ctx context.Context) TraceHeaderIDs {
+	ids, _ := ctx.Value(traceHeaderIDsKey{}).(TraceHeaderIDs)
+	return ids
+}
+
+// LinksFromContext returns the links to the extra parents of the trace header
+// the Propagator extracted ctx from, to be passed to the span started for the
+// request:
+//
+//	ctx = xray.Propagator{}.Extract(ctx, propagation.HeaderCarrier(r.Header))
+//	ctx, span := tracer.Start(ctx, "handler", trace.WithLinks(xray.LinksFromContext(ctx)...))
+func LinksFromContext(ctx context.Context) []trace.Link {
+	return TraceHeaderIDsFromContext(ctx).Links()
+}
+
+// contextWithTraceHeaderIDs returns a copy of ctx holding ids, or ctx if
+// neither ids nor ctx hold extra IDs.
+func contextWithTraceHeaderIDs(ctx context.Context, ids TraceHeaderIDs) context.Context {
+	if ids.isEmpty() && ctx.Value(traceHeaderIDsKey{}) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, traceHeaderIDsKey{}, ids)
+}
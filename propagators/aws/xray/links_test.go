@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// extractHeader extracts a context from the X-Amzn-Trace-Id header.
+func extractHeader(t *testing.T, header string) context.Context {
+	carrier := propagation.HeaderCarrier(http.Header{})
+	carrier.Set(traceHeaderKey, header)
+	ctx, err := Propagator{}.ExtractWithError(context.Background(), carrier)
+	require.NoError(t, err)
+	return ctx
+}
+
+func TestTraceHeaderIDs(t *testing.T) {
+	ctx := extractHeader(t, "Self=1-67891234-12456789abcdef012345678;Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Parent=f51ea8a9b6bd61a2;Sampled=1;Self=1-67891235-0123456789abcdef01234567")
+
+	sc := trace.SpanContextFromContext(ctx)
+	assert.Equal(t, traceID, sc.TraceID())
+	assert.Equal(t, parentSpanID, sc.SpanID())
+
+	ids := TraceHeaderIDsFromContext(ctx)
+	extraParent := trace.SpanID{0xf5, 0x1e, 0xa8, 0xa9, 0xb6, 0xbd, 0x61, 0xa2}
+	assert.Equal(t, []trace.SpanID{extraParent}, ids.Parents)
+	assert.Equal(t, []string{"1-67891234-12456789abcdef012345678", "1-67891235-0123456789abcdef01234567"}, ids.Self)
+
+	links := LinksFromContext(ctx)
+	require.Len(t, links, 1)
+	assert.Equal(t, sc.WithSpanID(extraParent), links[0].SpanContext)
+	assert.True(t, links[0].SpanContext.IsRemote())
+	assert.True(t, links[0].SpanContext.IsSampled())
+}
+
+func TestTraceHeaderIDsEmpty(t *testing.T) {
+	ctx := extractHeader(t, "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1")
+	assert.Equal(t, TraceHeaderIDs{}, TraceHeaderIDsFromContext(ctx))
+	assert.Nil(t, LinksFromContext(ctx))
+	assert.Nil(t, LinksFromContext(context.Background()))
+
+	// The IDs of a previously extracted header are replaced.
+	ctx = extractHeader(t, "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Parent=f51ea8a9b6bd61a2")
+	carrier := propagation.HeaderCarrier(http.Header{})
+	carrier.Set(traceHeaderKey, "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8")
+	ctx = Propagator{}.Extract(ctx, carrier)
+	assert.Nil(t, LinksFromContext(ctx))
+
+	// No IDs are kept from an invalid header.
+	carrier.Set(traceHeaderKey, "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=f51ea8a9b6bd61a2;Root=1-8a3c60f7-d188f8fa79d48a391a778fa6")
+	ctx = Propagator{}.Extract(context.Background(), carrier)
+	assert.Equal(t, TraceHeaderIDs{}, TraceHeaderIDsFromContext(ctx))
+}
+
+func TestLinksFromContextSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	ctx := extractHeader(t, "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Parent=f51ea8a9b6bd61a2;Sampled=1")
+	_, span := tracer.Start(ctx, "handler", trace.WithLinks(LinksFromContext(ctx)...))
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, parentSpanID, spans[0].Parent().SpanID())
+	require.Len(t, spans[0].Links(), 1)
+	assert.Equal(t, trace.SpanID{0xf5, 0x1e, 0xa8, 0xa9, 0xb6, 0xbd, 0x61, 0xa2}, spans[0].Links()[0].SpanContext.SpanID())
+}
@@ -30,6 +30,7 @@ const (
 	traceIDKey           = "Root"
 	sampleFlagKey        = "Sampled"
 	parentIDKey          = "Parent"
+	selfKey              = "Self"
 	traceIDVersion       = "1"
 	traceIDDelimiter     = "-"
 	isSampled            = "1"
@@ -41,7 +42,16 @@ const (
 	traceIDDelimitterIndex1 = 1
 	traceIDDelimitterIndex2 = 10
 	traceIDFirstPartLength  = 8
-	sampledFlagLength       = 1
+
+	// traceHeaderLength is the length of the injected trace headers.
+	traceHeaderLength = len(traceIDKey+kvDelimiter) + traceIDLength +
+		len(traceHeaderDelimiter+parentIDKey+kvDelimiter) + 16 +
+		len(traceHeaderDelimiter+sampleFlagKey+kvDelimiter) + len(isSampled)
+
+	// maxTraceHeaderLength bounds the length of the extracted trace headers,
+	// well above the length of the headers set by AWS services, so that a
+	// client cannot make the propagator parse arbitrarily large headers.
+	maxTraceHeaderLength = 1024
 )
 
 var (
@@ -51,6 +61,9 @@ var (
 	errLengthTraceIDHeader   = errors.New("incorrect length of X-Ray trace ID found, 35 character length expected")
 	errInvalidTraceIDVersion = errors.New("invalid X-Ray trace ID header found, does not have valid trace ID version")
 	errInvalidSpanIDLength   = errors.New("invalid span ID length, must be 16")
+	errInvalidSpanContext    = errors.New("X-Amzn-Trace-Id header does not hold a valid span context, it needs both Root and Parent")
+	errTraceHeaderTooLong    = errors.New("X-Amzn-Trace-Id header is longer than 1024 characters")
+	errDuplicateTraceHeader  = errors.New("X-Amzn-Trace-Id header holds a Root or Sampled field more than once")
 )
 
 // Propagator serializes Span Context to/from AWS X-Ray headers.
@@ -58,122 +71,166 @@ var (
 // Example AWS X-Ray format:
 //
 // X-Amzn-Trace-Id: Root={traceId};Parent={parentId};Sampled={samplingFlag}
-type Propagator struct{}
+//
+// The zero value of Propagator injects the trace header of all valid span
+// contexts. Use New to configure a Propagator with options.
+type Propagator struct {
+	cfg config
+}
 
 // Asserts that the propagator implements the otel.TextMapPropagator interface at compile time.
 var _ propagation.TextMapPropagator = &Propagator{}
 
+// New returns an AWS X-Ray Propagator configured with opts.
+func New(opts ...Option) Propagator {
+	return Propagator{cfg: *newConfig(opts...)}
+}
+
 // Inject injects a context to the carrier following AWS X-Ray format.
 func (xray Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
 	sc := trace.SpanFromContext(ctx).SpanContext()
 	if !sc.TraceID().IsValid() || !sc.SpanID().IsValid() {
 		return
 	}
-	otTraceID := sc.TraceID().String()
-	xrayTraceID := traceIDVersion + traceIDDelimiter + otTraceID[0:traceIDFirstPartLength] +
-		traceIDDelimiter + otTraceID[traceIDFirstPartLength:]
-	parentID := sc.SpanID()
-	samplingFlag := notSampled
-	if sc.TraceFlags() == traceFlagSampled {
-		samplingFlag = isSampled
+	if !sc.IsSampled() && xray.cfg.UnsampledInjection == SkipUnsampled {
+		return
 	}
-	headers := []string{traceIDKey, kvDelimiter, xrayTraceID, traceHeaderDelimiter, parentIDKey,
-		kvDelimiter, parentID.String(), traceHeaderDelimiter, sampleFlagKey, kvDelimiter, samplingFlag}
+	// The header is built in a buffer on the stack, so that only the string
+	// set in the carrier is allocated.
+	var b [traceHeaderLength]byte
+	carrier.Set(traceHeaderKey, string(appendTraceHeader(b[:0], sc)))
+}
 
-	carrier.Set(traceHeaderKey, strings.Join(headers, ""))
+// appendTraceHeader appends the trace header of sc to b.
+func appendTraceHeader(b []byte, sc trace.SpanContext) []byte {
+	b = append(b, traceIDKey+kvDelimiter...)
+	b = appendXRayTraceID(b, sc.TraceID())
+	b = append(b, traceHeaderDelimiter+parentIDKey+kvDelimiter...)
+	spanID := sc.SpanID()
+	b = appendHex(b, spanID[:])
+	b = append(b, traceHeaderDelimiter+sampleFlagKey+kvDelimiter...)
+	if sc.TraceFlags() == traceFlagSampled {
+		return append(b, isSampled...)
+	}
+	return append(b, notSampled...)
 }
 
 // Extract gets a context from the carrier if it contains AWS X-Ray headers.
 func (xray Propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	ctx, _ = xray.ExtractWithError(ctx, carrier)
+	return ctx
+}
+
+// ExtractWithError gets a context from the carrier if it contains AWS X-Ray
+// headers, as Extract does, and returns an error describing why the header
+// was rejected if it is invalid. No error is returned if the carrier does
+// not contain AWS X-Ray headers. The extra Parent and Self fields of the header
+// are kept in the returned context, see TraceHeaderIDsFromContext.
+func (xray Propagator) ExtractWithError(ctx context.Context, carrier propagation.TextMapCarrier) (context.Context, error) {
 	// extract tracing information
-	if header := carrier.Get(traceHeaderKey); header != "" {
-		sc, err := extract(header)
-		if err == nil && sc.IsValid() {
-			return trace.ContextWithRemoteSpanContext(ctx, sc)
-		}
+	header := carrier.Get(traceHeaderKey)
+	if header == "" {
+		return ctx, nil
 	}
-	return ctx
+	sc, ids, err := extractWithIDs(header)
+	if err != nil {
+		return ctx, err
+	}
+	if !sc.IsValid() {
+		return ctx, errInvalidSpanContext
+	}
+	ctx = contextWithTraceHeaderIDs(ctx, ids)
+	return trace.ContextWithRemoteSpanContext(ctx, sc), nil
 }
 
-// extract extracts Span Context from context.
+// extract extracts Span Context from the value of a trace header. The
+// fields of the header are separated by semicolons and may be surrounded by
+// whitespace. Empty fields and fields other than Root, Parent, and Sampled,
+// such as the Lineage field added by AWS services, are ignored. A header
+// holding the Root or Sampled field more than once is rejected, whether or not
+// the values differ, as the field to use cannot be told. The first Parent
+// field is the parent of the span context.
 func extract(headerVal string) (trace.SpanContext, error) {
+	sc, _, err := extractWithIDs(headerVal)
+	return sc, err
+}
+
+// extractWithIDs extracts the Span Context from the value of a trace header like
+// extract, along with the span IDs of the Parent fields following the first one
+// and the values of the Self fields.
+func extractWithIDs(headerVal string) (trace.SpanContext, TraceHeaderIDs, error) {
+	var ids TraceHeaderIDs
+	if len(headerVal) > maxTraceHeaderLength {
+		return empty, ids, errTraceHeaderTooLong
+	}
+
 	var (
-		scc            = trace.SpanContextConfig{}
-		err            error
-		delimiterIndex int
-		part           string
+		scc                          = trace.SpanContextConfig{}
+		hasRoot, hasParent, hasFlags bool
+		err                          error
 	)
-	pos := 0
-	for pos < len(headerVal) {
-		delimiterIndex = indexOf(headerVal, traceHeaderDelimiter, pos)
-		if delimiterIndex >= 0 {
-			part = headerVal[pos:delimiterIndex]
-			pos = delimiterIndex + 1
+	for rest := headerVal; rest != ""; {
+		var part string
+		if i := strings.Index(rest, traceHeaderDelimiter); i >= 0 {
+			part, rest = rest[:i], rest[i+1:]
 		} else {
-			//last part
-			part = strings.TrimSpace(headerVal[pos:])
-			pos = len(headerVal)
+			part, rest = rest, ""
+		}
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
 		}
+
 		equalsIndex := strings.Index(part, kvDelimiter)
 		if equalsIndex < 0 {
-			return empty, errInvalidTraceHeader
+			return empty, ids, errInvalidTraceHeader
 		}
-		value := part[equalsIndex+1:]
-		if strings.HasPrefix(part, traceIDKey) {
-			scc.TraceID, err = parseTraceID(value)
-			if err != nil {
-				return empty, err
+		key := strings.TrimSpace(part[:equalsIndex])
+		value := strings.TrimSpace(part[equalsIndex+1:])
+		switch key {
+		case traceIDKey:
+			if hasRoot {
+				return empty, ids, errDuplicateTraceHeader
 			}
-		} else if strings.HasPrefix(part, parentIDKey) {
-			//extract parentId
-			scc.SpanID, err = trace.SpanIDFromHex(value)
+			hasRoot = true
+			scc.TraceID, err = TraceIDFromXRay(value)
 			if err != nil {
-				return empty, errInvalidSpanIDLength
+				return empty, ids, err
 			}
-		} else if strings.HasPrefix(part, sampleFlagKey) {
-			//extract traceflag
+		case parentIDKey:
+			spanID, ok := spanIDFromHex(value)
+			if !ok {
+				return empty, ids, errInvalidSpanIDLength
+			}
+			if hasParent {
+				ids.Parents = append(ids.Parents, spanID)
+				continue
+			}
+			hasParent = true
+			scc.SpanID = spanID
+		case sampleFlagKey:
+			if hasFlags {
+				return empty, ids, errDuplicateTraceHeader
+			}
+			hasFlags = true
 			scc.TraceFlags = parseTraceFlag(value)
+		case selfKey:
+			ids.Self = append(ids.Self, value)
 		}
 	}
-	return trace.NewSpanContext(scc), nil
-}
-
-// indexOf returns position of the first occurrence of a substr in str starting at pos index.
-func indexOf(str string, substr string, pos int) int {
-	index := strings.Index(str[pos:], substr)
-	if index > -1 {
-		index += pos
-	}
-	return index
-}
-
-// parseTraceID returns trace ID if  valid else return invalid trace ID.
-func parseTraceID(xrayTraceID string) (trace.TraceID, error) {
-	if len(xrayTraceID) != traceIDLength {
-		return empty.TraceID(), errLengthTraceIDHeader
-	}
-	if !strings.HasPrefix(xrayTraceID, traceIDVersion) {
-		return empty.TraceID(), errInvalidTraceIDVersion
-	}
-
-	if xrayTraceID[traceIDDelimitterIndex1:traceIDDelimitterIndex1+1] != traceIDDelimiter ||
-		xrayTraceID[traceIDDelimitterIndex2:traceIDDelimitterIndex2+1] != traceIDDelimiter {
-		return empty.TraceID(), errMalformedTraceID
-	}
-
-	epochPart := xrayTraceID[traceIDDelimitterIndex1+1 : traceIDDelimitterIndex2]
-	uniquePart := xrayTraceID[traceIDDelimitterIndex2+1 : traceIDLength]
-
-	result := epochPart + uniquePart
-	return trace.TraceIDFromHex(result)
+	sc := trace.NewSpanContext(scc)
+	ids.spanContext = sc.WithRemote(true)
+	return sc, ids, nil
 }
 
-// parseTraceFlag returns a parsed trace flag.
+// parseTraceFlag returns a parsed trace flag. Only the "1" sampling decision
+// samples the trace; "0", the "?" of a decision left to the receiver, and
+// unknown values do not.
 func parseTraceFlag(xraySampledFlag string) trace.TraceFlags {
-	if len(xraySampledFlag) == sampledFlagLength && xraySampledFlag != isSampled {
-		return traceFlagNone
+	if xraySampledFlag == isSampled {
+		return trace.FlagsSampled
 	}
-	return trace.FlagsSampled
+	return traceFlagNone
 }
 
 // Fields returns list of fields used by HTTPTextFormat.
@@ -20,7 +20,6 @@ import (
 	"strings"
 	"testing"
 
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 
 	"github.com/stretchr/testify/assert"
@@ -102,16 +101,204 @@ func TestAwsXrayExtract(t *testing.T) {
 	}
 }
 
+func TestAwsXrayExtractStrict(t *testing.T) {
+	valid := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     parentSpanID,
+		TraceFlags: traceFlagSampled,
+	})
+
+	testCases := []struct {
+		name     string
+		header   string
+		expected trace.SpanContext
+		err      error
+	}{
+		{
+			name:     "surrounding whitespace",
+			header:   " Root = 1-8a3c60f7-d188f8fa79d48a391a778fa6 ;\tParent=53995c3f42cd8ad8; Sampled=1 ",
+			expected: valid,
+		},
+		{
+			name:     "unknown fields",
+			header:   "Self=1-67891234-12456789abcdef012345678;Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1;Lineage=a87bd80c:1|68fd508a:5",
+			expected: valid,
+		},
+		{
+			name:     "empty fields",
+			header:   ";Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;;Parent=53995c3f42cd8ad8;Sampled=1;",
+			expected: valid,
+		},
+		{
+			name:     "field prefixed by a known key",
+			header:   "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1;ParentX=0",
+			expected: valid,
+		},
+		{
+			name:     "deferred sampling decision",
+			header:   "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=?",
+			expected: valid.WithTraceFlags(traceFlagNone),
+		},
+		{
+			name:     "unknown sampling decision",
+			header:   "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=yes",
+			expected: valid.WithTraceFlags(traceFlagNone),
+		},
+		{
+			name:   "duplicate root",
+			header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Root=1-8a3c60f7-d188f8fa79d48a391a778fa6",
+			err:    errDuplicateTraceHeader,
+		},
+		{
+			name:     "extra parent",
+			header:   "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Parent=0000000000000001;Sampled=1",
+			expected: valid,
+		},
+		{
+			name:   "invalid extra parent",
+			header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Parent=1",
+			err:    errInvalidSpanIDLength,
+		},
+		{
+			name:   "duplicate sampling decision",
+			header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1;Sampled=1",
+			err:    errDuplicateTraceHeader,
+		},
+		{
+			name:   "field without value",
+			header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent",
+			err:    errInvalidTraceHeader,
+		},
+		{
+			name:   "too long",
+			header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1;Lineage=" + strings.Repeat("a", maxTraceHeaderLength),
+			err:    errTraceHeaderTooLong,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, err := extract(tc.header)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, sc)
+		})
+	}
+}
+
+func TestAwsXrayInject(t *testing.T) {
+	sampled := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     parentSpanID,
+		TraceFlags: traceFlagSampled,
+	})
+	unsampled := sampled.WithTraceFlags(traceFlagNone)
+
+	testData := []struct {
+		name       string
+		propagator Propagator
+		sc         trace.SpanContext
+		expected   string
+	}{
+		{
+			name:       "sampled",
+			propagator: Propagator{},
+			sc:         sampled,
+			expected:   "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1",
+		},
+		{
+			name:       "unsampled",
+			propagator: Propagator{},
+			sc:         unsampled,
+			expected:   "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=0",
+		},
+		{
+			name:       "unsampled injected",
+			propagator: New(WithUnsampledInjection(InjectUnsampled)),
+			sc:         unsampled,
+			expected:   "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=0",
+		},
+		{
+			name:       "unsampled skipped",
+			propagator: New(WithUnsampledInjection(SkipUnsampled)),
+			sc:         unsampled,
+			expected:   "",
+		},
+		{
+			name:       "sampled with unsampled skipped",
+			propagator: New(WithUnsampledInjection(SkipUnsampled)),
+			sc:         sampled,
+			expected:   "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1",
+		},
+		{
+			name:       "invalid",
+			propagator: Propagator{},
+			sc:         trace.SpanContext{},
+			expected:   "",
+		},
+	}
+
+	for _, test := range testData {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := trace.ContextWithSpanContext(context.Background(), test.sc)
+			header := http.Header{}
+			test.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+			assert.Equal(t, test.expected, header.Get(traceHeaderKey))
+		})
+	}
+}
+
+// TestAwsXrayAllocations checks that the trace header is parsed without
+// allocating, and that only the injected header string is allocated.
+func TestAwsXrayAllocations(t *testing.T) {
+	header := "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1;Lineage=a87bd80c:1"
+	assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() {
+		_, _ = extract(header)
+	}))
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     parentSpanID,
+		TraceFlags: traceFlagSampled,
+	}))
+	carrier := &stringCarrier{}
+	assert.Equal(t, 1.0, testing.AllocsPerRun(100, func() {
+		Propagator{}.Inject(ctx, carrier)
+	}))
+	assert.Equal(t, "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1", carrier.value)
+}
+
+// stringCarrier is a carrier of the trace header only, which does not
+// allocate.
+type stringCarrier struct {
+	value string
+}
+
+func (c *stringCarrier) Get(key string) string {
+	if key != traceHeaderKey {
+		return ""
+	}
+	return c.value
+}
+
+func (c *stringCarrier) Set(key, value string) {
+	if key == traceHeaderKey {
+		c.value = value
+	}
+}
+
+func (c *stringCarrier) Keys() []string {
+	return nil
+}
+
 func BenchmarkPropagatorExtract(b *testing.B) {
 	propagator := Propagator{}
 
 	ctx := context.Background()
 	req, _ := http.NewRequest("GET", "http://example.com", nil)
 
-	req.Header.Set("Root", "1-8a3c60f7-d188f8fa79d48a391a778fa6")
-	req.Header.Set("Parent", "53995c3f42cd8ad8")
-	req.Header.Set("Sampled", "1")
+	req.Header.Set(traceHeaderKey, "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1")
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_ = propagator.Extract(ctx, propagation.HeaderCarrier(req.Header))
@@ -120,13 +307,40 @@ func BenchmarkPropagatorExtract(b *testing.B) {
 
 func BenchmarkPropagatorInject(b *testing.B) {
 	propagator := Propagator{}
-	tracer := otel.Tracer("test")
 
 	req, _ := http.NewRequest("GET", "http://example.com", nil)
-	ctx, _ := tracer.Start(context.Background(), "Parent operation...")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     parentSpanID,
+		TraceFlags: traceFlagSampled,
+	}))
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
 	}
 }
+
+func TestAwsXrayExtractWithError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header string
+		err    error
+	}{
+		{name: "no header", header: ""},
+		{name: "valid", header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Parent=53995c3f42cd8ad8;Sampled=1"},
+		{name: "invalid trace ID", header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa;Parent=53995c3f42cd8ad8;Sampled=1", err: errLengthTraceIDHeader},
+		{name: "missing parent", header: "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6;Sampled=1", err: errInvalidSpanContext},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(traceHeaderKey, tc.header)
+			ctx, err := Propagator{}.ExtractWithError(context.Background(), propagation.HeaderCarrier(header))
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.err == nil && tc.header != "", trace.SpanContextFromContext(ctx).IsValid())
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const hexDigits = "0123456789abcdef"
+
+var errInvalidTraceID = errors.New("invalid trace ID, must not be all zero")
+
+// TraceIDToXRay returns the X-Ray representation of traceID, in the
+// 1-{epoch}-{random} format where epoch is the 8 hex characters of the first
+// 4 bytes of traceID, which hold the time it was created at if generated by
+// the IDGenerator of this package, and random the 24 hex characters of the
+// other bytes.
+func TraceIDToXRay(traceID trace.TraceID) string {
+	var b [traceIDLength]byte
+	return string(appendXRayTraceID(b[:0], traceID))
+}
+
+// appendXRayTraceID appends the X-Ray representation of traceID to b.
+func appendXRayTraceID(b []byte, traceID trace.TraceID) []byte {
+	b = append(b, traceIDVersion...)
+	b = append(b, traceIDDelimiter...)
+	b = appendHex(b, traceID[:traceIDFirstPartLength/2])
+	b = append(b, traceIDDelimiter...)
+	return appendHex(b, traceID[traceIDFirstPartLength/2:])
+}
+
+// TraceIDFromXRay returns the trace ID represented by xrayTraceID, in the
+// 1-{epoch}-{random} format described by TraceIDToXRay. An error is returned
+// if xrayTraceID is not in this format, or represents an invalid trace ID.
+func TraceIDFromXRay(xrayTraceID string) (trace.TraceID, error) {
+	if len(xrayTraceID) != traceIDLength {
+		return empty.TraceID(), errLengthTraceIDHeader
+	}
+	if xrayTraceID[:len(traceIDVersion)] != traceIDVersion {
+		return empty.TraceID(), errInvalidTraceIDVersion
+	}
+
+	if xrayTraceID[traceIDDelimitterIndex1:traceIDDelimitterIndex1+1] != traceIDDelimiter ||
+		xrayTraceID[traceIDDelimitterIndex2:traceIDDelimitterIndex2+1] != traceIDDelimiter {
+		return empty.TraceID(), errMalformedTraceID
+	}
+
+	var traceID trace.TraceID
+	if !decodeHex(traceID[:traceIDFirstPartLength/2], xrayTraceID[traceIDDelimitterIndex1+1:traceIDDelimitterIndex2]) ||
+		!decodeHex(traceID[traceIDFirstPartLength/2:], xrayTraceID[traceIDDelimitterIndex2+1:]) {
+		return empty.TraceID(), errMalformedTraceID
+	}
+	if !traceID.IsValid() {
+		return empty.TraceID(), errInvalidTraceID
+	}
+	return traceID, nil
+}
+
+// spanIDFromHex returns the span ID represented by the 16 lowercase hex
+// characters of h, as trace.SpanIDFromHex does without allocating. It returns
+// false if h does not represent a valid span ID.
+func spanIDFromHex(h string) (trace.SpanID, bool) {
+	var spanID trace.SpanID
+	if len(h) != 2*len(spanID) || !decodeHex(spanID[:], h) || !spanID.IsValid() {
+		return trace.SpanID{}, false
+	}
+	return spanID, true
+}
+
+// appendHex appends the lowercase hex encoding of src to b.
+func appendHex(b []byte, src []byte) []byte {
+	for _, v := range src {
+		b = append(b, hexDigits[v>>4], hexDigits[v&0x0f])
+	}
+	return b
+}
+
+// decodeHex decodes the lowercase hex characters of src, twice as many as the
+// bytes of dst, into dst. It returns false if src holds another character.
+func decodeHex(dst []byte, src string) bool {
+	for i := range dst {
+		hi, ok1 := fromHexChar(src[2*i])
+		lo, ok2 := fromHexChar(src[2*i+1])
+		if !ok1 || !ok2 {
+			return false
+		}
+		dst[i] = hi<<4 | lo
+	}
+	return true
+}
+
+// fromHexChar returns the value of a lowercase hex character.
+func fromHexChar(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceIDToXRay(t *testing.T) {
+	traceID, err := trace.TraceIDFromHex("5759e988bd862e3fe1be46a994272793")
+	assert.NoError(t, err)
+	assert.Equal(t, "1-5759e988-bd862e3fe1be46a994272793", TraceIDToXRay(traceID))
+}
+
+func TestTraceIDFromXRay(t *testing.T) {
+	testCases := []struct {
+		name     string
+		xrayID   string
+		expected string
+		err      error
+	}{
+		{
+			name:     "valid",
+			xrayID:   "1-5759e988-bd862e3fe1be46a994272793",
+			expected: "5759e988bd862e3fe1be46a994272793",
+		},
+		{name: "too short", xrayID: "1-5759e988-bd862e3fe1be46a99427279", err: errLengthTraceIDHeader},
+		{name: "invalid version", xrayID: "2-5759e988-bd862e3fe1be46a994272793", err: errInvalidTraceIDVersion},
+		{name: "invalid delimiter", xrayID: "1-5759e988_bd862e3fe1be46a994272793", err: errMalformedTraceID},
+		{name: "uppercase", xrayID: "1-5759E988-bd862e3fe1be46a994272793", err: errMalformedTraceID},
+		{name: "not hex", xrayID: "1-5759e988-bd862e3fe1be46a99427279g", err: errMalformedTraceID},
+		{name: "zero", xrayID: "1-00000000-000000000000000000000000", err: errInvalidTraceID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			traceID, err := TraceIDFromXRay(tc.xrayID)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, tc.expected, traceID.String())
+			} else {
+				assert.False(t, traceID.IsValid())
+			}
+		})
+	}
+}
+
+func TestTraceIDRoundTrip(t *testing.T) {
+	traceID, _ := NewIDGenerator().NewIDs(context.Background())
+	got, err := TraceIDFromXRay(TraceIDToXRay(traceID))
+	assert.NoError(t, err)
+	assert.Equal(t, traceID, got)
+}
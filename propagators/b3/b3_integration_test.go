@@ -137,10 +137,7 @@ func TestB3Propagator_Fields(t *testing.T) {
 			name:       "no encoding specified",
 			propagator: b3.New(),
 			want: []string{
-				b3TraceID,
-				b3SpanID,
-				b3Sampled,
-				b3Flags,
+				b3Context,
 			},
 		},
 		{
@@ -160,12 +160,14 @@ func (b3 propagator) Extract(ctx context.Context, carrier propagation.TextMapCar
 	return trace.ContextWithRemoteSpanContext(ctx, sc)
 }
 
+// Fields returns the headers injected with the configured encoding, the
+// single b3 header by default.
 func (b3 propagator) Fields() []string {
 	header := []string{}
-	if b3.cfg.InjectEncoding.supports(B3SingleHeader) {
+	if b3.cfg.InjectEncoding.supports(B3SingleHeader) || b3.cfg.InjectEncoding == B3Unspecified {
 		header = append(header, b3ContextHeader)
 	}
-	if b3.cfg.InjectEncoding.supports(B3MultipleHeader) || b3.cfg.InjectEncoding == B3Unspecified {
+	if b3.cfg.InjectEncoding.supports(B3MultipleHeader) {
 		header = append(header, b3TraceIDHeader, b3SpanIDHeader, b3SampledHeader, b3DebugFlagHeader)
 	}
 	return header
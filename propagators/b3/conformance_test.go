@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package b3_test
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/propagatortest"
+)
+
+func TestConformance(t *testing.T) {
+	propagatortest.Run(t, propagatortest.Config{
+		Propagator:      b3.New(),
+		InjectsSampling: true,
+		Invalid: []map[string]string{
+			{"b3": "invalid"},
+			{"b3": "00000000000000000000000000000000-00f067aa0ba902b7-1"},
+			{"x-b3-traceid": "4bf92f3577b34da6a3ce929d0e0e4736", "x-b3-sampled": "1"},
+		},
+		Compatible: []propagation.TextMapPropagator{
+			b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)),
+			b3.New(b3.WithInjectEncoding(b3.B3SingleHeader | b3.B3MultipleHeader)),
+		},
+	})
+}
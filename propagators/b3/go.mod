@@ -5,6 +5,9 @@ go 1.15
 require (
 	github.com/google/go-cmp v0.5.6
 	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/propagators/propagatortest v0.26.0
 	go.opentelemetry.io/otel v1.1.0
 	go.opentelemetry.io/otel/trace v1.1.0
 )
+
+replace go.opentelemetry.io/contrib/propagators/propagatortest => ../propagatortest
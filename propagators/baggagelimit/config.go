@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baggagelimit
+
+const (
+	// defaultMaxMembers is the number of members the W3C Baggage
+	// specification requires platforms to propagate at least.
+	defaultMaxMembers = 64
+	// defaultMaxBytes is the size of baggage the W3C Baggage specification
+	// requires platforms to propagate at least.
+	defaultMaxBytes = 8192
+)
+
+type config struct {
+	maxMembers  int
+	maxBytes    int
+	allowedKeys map[string]struct{}
+	deniedKeys  map[string]struct{}
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) *config {
+	c := &config{
+		maxMembers: defaultMaxMembers,
+		maxBytes:   defaultMaxBytes,
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithMaxMembers sets the maximum number of baggage members propagated. The
+// default is 64. A value of zero or less removes the limit.
+func WithMaxMembers(n int) Option {
+	return optionFunc(func(c *config) {
+		c.maxMembers = n
+	})
+}
+
+// WithMaxBytes sets the maximum size of the propagated baggage, measured as
+// its W3C Baggage header value. The default is 8192 bytes. A value of zero or
+// less removes the limit.
+func WithMaxBytes(n int) Option {
+	return optionFunc(func(c *config) {
+		c.maxBytes = n
+	})
+}
+
+// WithAllowedKeys sets the only baggage keys propagated. By default all keys
+// not denied are propagated.
+func WithAllowedKeys(keys ...string) Option {
+	return optionFunc(func(c *config) {
+		c.allowedKeys = keySet(keys)
+	})
+}
+
+// WithDeniedKeys sets baggage keys never propagated, even if allowed.
+func WithDeniedKeys(keys ...string) Option {
+	return optionFunc(func(c *config) {
+		c.deniedKeys = keySet(keys)
+	})
+}
+
+func keySet(keys []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return set
+}
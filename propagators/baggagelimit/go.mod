@@ -0,0 +1,8 @@
+module go.opentelemetry.io/contrib/propagators/baggagelimit
+
+go 1.15
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/otel v1.1.0
+)
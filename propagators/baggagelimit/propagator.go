@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package baggagelimit implements a propagator wrapper limiting the baggage
+// propagated by another propagator, to protect services and their headers
+// from unbounded baggage growth.
+package baggagelimit // import "go.opentelemetry.io/contrib/propagators/baggagelimit"
+
+import (
+	"context"
+	"sort"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Propagator limits the baggage injected and extracted by a wrapped
+// propagator.
+//
+// Members are dropped if their key is denied or not allowed. The others are
+// then kept in the order of their keys as long as they fit in the maximum
+// number of members and size of the baggage.
+type Propagator struct {
+	propagator propagation.TextMapPropagator
+	cfg        *config
+}
+
+// Asserts that the propagator implements the otel.TextMapPropagator interface at compile time.
+var _ propagation.TextMapPropagator = &Propagator{}
+
+// New returns a Propagator limiting the baggage propagated by p, e.g.
+// propagation.Baggage{}, with opts.
+func New(p propagation.TextMapPropagator, opts ...Option) *Propagator {
+	return &Propagator{propagator: p, cfg: newConfig(opts...)}
+}
+
+// Inject injects the limited baggage of ctx with the wrapped propagator.
+// The baggage of ctx is left unchanged for other propagators.
+func (p *Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	if bag := baggage.FromContext(ctx); bag.Len() > 0 {
+		ctx = baggage.ContextWithBaggage(ctx, p.limit(bag))
+	}
+	p.propagator.Inject(ctx, carrier)
+}
+
+// Extract extracts a context with the wrapped propagator and limits the
+// baggage of the returned context.
+func (p *Propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	ctx = p.propagator.Extract(ctx, carrier)
+	if bag := baggage.FromContext(ctx); bag.Len() > 0 {
+		ctx = baggage.ContextWithBaggage(ctx, p.limit(bag))
+	}
+	return ctx
+}
+
+// Fields returns the fields of the wrapped propagator.
+func (p *Propagator) Fields() []string {
+	return p.propagator.Fields()
+}
+
+// limit returns the members of bag within the limits of the propagator.
+func (p *Propagator) limit(bag baggage.Baggage) baggage.Baggage {
+	members := bag.Members()
+	sort.Slice(members, func(i, j int) bool { return members[i].Key() < members[j].Key() })
+
+	kept := make([]baggage.Member, 0, len(members))
+	size := 0
+	for _, m := range members {
+		if !p.allowed(m.Key()) {
+			continue
+		}
+		if p.cfg.maxMembers > 0 && len(kept) >= p.cfg.maxMembers {
+			break
+		}
+		// Members are separated by a comma in the header.
+		n := len(m.String())
+		if len(kept) > 0 {
+			n++
+		}
+		if p.cfg.maxBytes > 0 && size+n > p.cfg.maxBytes {
+			continue
+		}
+		size += n
+		kept = append(kept, m)
+	}
+
+	if len(kept) == len(members) {
+		return bag
+	}
+	limited, err := baggage.New(kept...)
+	if err != nil {
+		// The members are valid, as they come from valid baggage.
+		return baggage.Baggage{}
+	}
+	return limited
+}
+
+func (p *Propagator) allowed(key string) bool {
+	if _, ok := p.cfg.deniedKeys[key]; ok {
+		return false
+	}
+	if p.cfg.allowedKeys == nil {
+		return true
+	}
+	_, ok := p.cfg.allowedKeys[key]
+	return ok
+}
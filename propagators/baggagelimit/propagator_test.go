@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baggagelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func newBaggage(t *testing.T, kvs ...string) baggage.Baggage {
+	var members []baggage.Member
+	for i := 0; i < len(kvs); i += 2 {
+		m, err := baggage.NewMember(kvs[i], kvs[i+1])
+		require.NoError(t, err)
+		members = append(members, m)
+	}
+	bag, err := baggage.New(members...)
+	require.NoError(t, err)
+	return bag
+}
+
+func keys(bag baggage.Baggage) []string {
+	var ks []string
+	for _, m := range bag.Members() {
+		ks = append(ks, m.Key())
+	}
+	return ks
+}
+
+func TestLimit(t *testing.T) {
+	bag := newBaggage(t, "a", "1", "b", "22", "c", "333", "d", "4444")
+
+	testCases := []struct {
+		name     string
+		opts     []Option
+		expected []string
+	}{
+		{
+			name:     "defaults",
+			expected: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:     "max members",
+			opts:     []Option{WithMaxMembers(2)},
+			expected: []string{"a", "b"},
+		},
+		{
+			// a=1,b=22,c=333 is 14 bytes.
+			name:     "max bytes",
+			opts:     []Option{WithMaxBytes(14)},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "allowed keys",
+			opts:     []Option{WithAllowedKeys("b", "d", "e")},
+			expected: []string{"b", "d"},
+		},
+		{
+			name:     "denied keys",
+			opts:     []Option{WithAllowedKeys("b", "d"), WithDeniedKeys("d")},
+			expected: []string{"b"},
+		},
+		{
+			name:     "limits after filter",
+			opts:     []Option{WithDeniedKeys("a"), WithMaxMembers(1)},
+			expected: []string{"b"},
+		},
+		{
+			name:     "no limits",
+			opts:     []Option{WithMaxMembers(0), WithMaxBytes(0)},
+			expected: []string{"a", "b", "c", "d"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New(propagation.Baggage{}, tc.opts...)
+			assert.ElementsMatch(t, tc.expected, keys(p.limit(bag)))
+		})
+	}
+}
+
+func TestInject(t *testing.T) {
+	bag := newBaggage(t, "a", "1", "b", "2")
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	header := http.Header{}
+	New(propagation.Baggage{}, WithDeniedKeys("b")).Inject(ctx, propagation.HeaderCarrier(header))
+	assert.Equal(t, "a=1", header.Get("baggage"))
+	assert.Equal(t, bag, baggage.FromContext(ctx))
+}
+
+func TestExtract(t *testing.T) {
+	header := http.Header{}
+	header.Set("baggage", "a=1,b=2,c=3")
+
+	ctx := New(propagation.Baggage{}, WithMaxMembers(2)).Extract(context.Background(), propagation.HeaderCarrier(header))
+	assert.ElementsMatch(t, []string{"a", "b"}, keys(baggage.FromContext(ctx)))
+}
+
+func TestExtractNoBaggage(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, New(propagation.Baggage{}).Extract(ctx, propagation.HeaderCarrier(http.Header{})))
+}
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, propagation.Baggage{}.Fields(), New(propagation.Baggage{}).Fields())
+}
@@ -0,0 +1,12 @@
+module go.opentelemetry.io/contrib/propagators/datadog
+
+go 1.15
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/propagators/propagatortest v0.26.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/trace v1.1.0
+)
+
+replace go.opentelemetry.io/contrib/propagators/propagatortest => ../propagatortest
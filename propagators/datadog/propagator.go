@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceIDHeader          = "x-datadog-trace-id"
+	parentIDHeader         = "x-datadog-parent-id"
+	samplingPriorityHeader = "x-datadog-sampling-priority"
+	tagsHeader             = "x-datadog-tags"
+
+	// traceIDHighTag is the propagated tag holding the 64 high-order bits
+	// of 128-bit trace IDs as 16 hex characters.
+	traceIDHighTag = "_dd.p.tid"
+	tagsDelimiter  = ","
+	tagDelimiter   = "="
+
+	priorityAutoReject = "0"
+	priorityAutoKeep   = "1"
+)
+
+var (
+	empty = trace.SpanContext{}
+
+	errMalformedTraceID     = errors.New("cannot decode trace ID from header, should be a non zero unsigned 64-bit decimal number")
+	errMalformedParentID    = errors.New("cannot decode parent ID from header, should be a non zero unsigned 64-bit decimal number")
+	errMalformedPriority    = errors.New("cannot decode sampling priority from header, should be an integer")
+	errMalformedTraceIDHigh = errors.New("cannot decode _dd.p.tid tag, should be 16 hex characters")
+	errMissingTraceID       = errors.New("require both x-datadog-trace-id and x-datadog-parent-id headers")
+)
+
+// Propagator serializes Span Context to/from Datadog headers.
+//
+// Datadog format:
+//
+// x-datadog-trace-id: {64 low-order bits of the trace ID, in decimal}
+// x-datadog-parent-id: {span ID, in decimal}
+// x-datadog-sampling-priority: {sampling priority}
+// x-datadog-tags: _dd.p.tid={64 high-order bits of the trace ID, in hex}
+//
+// A positive sampling priority means the trace is sampled. The
+// x-datadog-tags header is only injected for trace IDs that do not fit in
+// 64 bits.
+type Propagator struct{}
+
+// Asserts that the propagator implements the otel.TextMapPropagator interface at compile time.
+var _ propagation.TextMapPropagator = &Propagator{}
+
+// Inject injects a context to the carrier following the Datadog format.
+func (p Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanFromContext(ctx).SpanContext()
+	if !sc.TraceID().IsValid() || !sc.SpanID().IsValid() {
+		return
+	}
+
+	traceID := sc.TraceID()
+	spanID := sc.SpanID()
+	carrier.Set(traceIDHeader, strconv.FormatUint(binary.BigEndian.Uint64(traceID[8:]), 10))
+	carrier.Set(parentIDHeader, strconv.FormatUint(binary.BigEndian.Uint64(spanID[:]), 10))
+	if sc.IsSampled() {
+		carrier.Set(samplingPriorityHeader, priorityAutoKeep)
+	} else {
+		carrier.Set(samplingPriorityHeader, priorityAutoReject)
+	}
+	if high := binary.BigEndian.Uint64(traceID[:8]); high != 0 {
+		carrier.Set(tagsHeader, fmt.Sprintf("%s%s%016x", traceIDHighTag, tagDelimiter, high))
+	}
+}
+
+// Extract gets a context from the carrier if it contains Datadog headers.
+func (p Propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	ctx, _ = p.ExtractWithError(ctx, carrier)
+	return ctx
+}
+
+// ExtractWithError gets a context from the carrier as Extract does, and
+// returns an error describing why the Datadog headers were rejected if they
+// are invalid. No error is returned if the carrier does not contain Datadog
+// headers.
+func (p Propagator) ExtractWithError(ctx context.Context, carrier propagation.TextMapCarrier) (context.Context, error) {
+	sc, err := extract(
+		carrier.Get(traceIDHeader),
+		carrier.Get(parentIDHeader),
+		carrier.Get(samplingPriorityHeader),
+		carrier.Get(tagsHeader),
+	)
+	if err != nil || !sc.IsValid() {
+		return ctx, err
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc), nil
+}
+
+// extract reconstructs a Span Context from the Datadog header values.
+func extract(traceID, parentID, priority, tags string) (trace.SpanContext, error) {
+	scc := trace.SpanContextConfig{}
+	if traceID == "" && parentID == "" {
+		return empty, nil
+	}
+
+	if traceID == "" || parentID == "" {
+		return empty, errMissingTraceID
+	}
+
+	low, err := strconv.ParseUint(traceID, 10, 64)
+	if err != nil || low == 0 {
+		return empty, errMalformedTraceID
+	}
+	binary.BigEndian.PutUint64(scc.TraceID[8:], low)
+
+	high, err := traceIDHigh(tags)
+	if err != nil {
+		return empty, err
+	}
+	binary.BigEndian.PutUint64(scc.TraceID[:8], high)
+
+	spanID, err := strconv.ParseUint(parentID, 10, 64)
+	if err != nil || spanID == 0 {
+		return empty, errMalformedParentID
+	}
+	binary.BigEndian.PutUint64(scc.SpanID[:], spanID)
+
+	if priority != "" {
+		p, err := strconv.Atoi(priority)
+		if err != nil {
+			return empty, errMalformedPriority
+		}
+		if p > 0 {
+			scc.TraceFlags = trace.FlagsSampled
+		}
+	}
+
+	return trace.NewSpanContext(scc), nil
+}
+
+// traceIDHigh returns the 64 high-order bits of the trace ID held by the
+// x-datadog-tags header value, or 0 if it holds none.
+func traceIDHigh(tags string) (uint64, error) {
+	for _, tag := range strings.Split(tags, tagsDelimiter) {
+		kv := strings.SplitN(strings.TrimSpace(tag), tagDelimiter, 2)
+		if len(kv) != 2 || kv[0] != traceIDHighTag {
+			continue
+		}
+		if len(kv[1]) != 16 {
+			return 0, errMalformedTraceIDHigh
+		}
+		high, err := strconv.ParseUint(kv[1], 16, 64)
+		if err != nil {
+			return 0, errMalformedTraceIDHigh
+		}
+		return high, nil
+	}
+	return 0, nil
+}
+
+// Fields returns the list of fields set with Inject.
+func (p Propagator) Fields() []string {
+	return []string{traceIDHeader, parentIDHeader, samplingPriorityHeader, tagsHeader}
+}
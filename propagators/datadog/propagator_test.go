@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	traceID64      = trace.TraceID{8: 0x7b, 15: 0x01}
+	traceID64Str   = "8863084066665136129"
+	traceID128     = trace.TraceID{0x64, 0x0c, 0xfd, 0x8d, 0, 0, 0, 0, 0x7b, 0, 0, 0, 0, 0, 0, 0x01}
+	traceIDHighStr = "640cfd8d00000000"
+	spanID         = trace.SpanID{6: 0x30, 7: 0x39}
+	spanIDStr      = "12345"
+)
+
+func TestExtract(t *testing.T) {
+	testCases := []struct {
+		name     string
+		headers  map[string]string
+		expected trace.SpanContextConfig
+	}{
+		{
+			name: "sampled",
+			headers: map[string]string{
+				traceIDHeader:          traceID64Str,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "1",
+			},
+			expected: trace.SpanContextConfig{
+				TraceID:    traceID64,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			},
+		},
+		{
+			name: "user keep",
+			headers: map[string]string{
+				traceIDHeader:          traceID64Str,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "2",
+			},
+			expected: trace.SpanContextConfig{
+				TraceID:    traceID64,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			},
+		},
+		{
+			name: "user reject",
+			headers: map[string]string{
+				traceIDHeader:          traceID64Str,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "-1",
+			},
+			expected: trace.SpanContextConfig{
+				TraceID: traceID64,
+				SpanID:  spanID,
+			},
+		},
+		{
+			name: "no priority",
+			headers: map[string]string{
+				traceIDHeader:  traceID64Str,
+				parentIDHeader: spanIDStr,
+			},
+			expected: trace.SpanContextConfig{
+				TraceID: traceID64,
+				SpanID:  spanID,
+			},
+		},
+		{
+			name: "128-bit trace ID",
+			headers: map[string]string{
+				traceIDHeader:          traceID64Str,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "1",
+				tagsHeader:             "_dd.p.dm=-0," + traceIDHighTag + "=" + traceIDHighStr,
+			},
+			expected: trace.SpanContextConfig{
+				TraceID:    traceID128,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			},
+		},
+		{name: "empty", headers: map[string]string{}},
+		{
+			name: "missing parent ID",
+			headers: map[string]string{
+				traceIDHeader: traceID64Str,
+			},
+		},
+		{
+			name: "zero trace ID",
+			headers: map[string]string{
+				traceIDHeader:  "0",
+				parentIDHeader: spanIDStr,
+			},
+		},
+		{
+			name: "hex trace ID",
+			headers: map[string]string{
+				traceIDHeader:  "7b00000000000001",
+				parentIDHeader: spanIDStr,
+			},
+		},
+		{
+			name: "invalid priority",
+			headers: map[string]string{
+				traceIDHeader:          traceID64Str,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "keep",
+			},
+		},
+		{
+			name: "invalid high trace ID",
+			headers: map[string]string{
+				traceIDHeader:  traceID64Str,
+				parentIDHeader: spanIDStr,
+				tagsHeader:     traceIDHighTag + "=640cfd8d",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tc.headers {
+				header.Set(k, v)
+			}
+			ctx := Propagator{}.Extract(context.Background(), propagation.HeaderCarrier(header))
+
+			expected := trace.NewSpanContext(tc.expected)
+			if expected.IsValid() {
+				expected = expected.WithRemote(true)
+			}
+			assert.Equal(t, expected, trace.SpanContextFromContext(ctx))
+		})
+	}
+}
+
+func TestInject(t *testing.T) {
+	testCases := []struct {
+		name     string
+		scc      trace.SpanContextConfig
+		expected map[string]string
+	}{
+		{
+			name: "sampled",
+			scc: trace.SpanContextConfig{
+				TraceID:    traceID64,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			},
+			expected: map[string]string{
+				traceIDHeader:          traceID64Str,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "1",
+			},
+		},
+		{
+			name: "not sampled",
+			scc: trace.SpanContextConfig{
+				TraceID: traceID64,
+				SpanID:  spanID,
+			},
+			expected: map[string]string{
+				traceIDHeader:          traceID64Str,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "0",
+			},
+		},
+		{
+			name: "128-bit trace ID",
+			scc: trace.SpanContextConfig{
+				TraceID:    traceID128,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			},
+			expected: map[string]string{
+				traceIDHeader:          traceID64Str,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "1",
+				tagsHeader:             "_dd.p.tid=" + traceIDHighStr,
+			},
+		},
+		{
+			name:     "invalid",
+			scc:      trace.SpanContextConfig{SpanID: spanID},
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(tc.scc))
+			Propagator{}.Inject(ctx, propagation.HeaderCarrier(header))
+			assert.Len(t, header, len(tc.expected))
+			for k, v := range tc.expected {
+				assert.Equal(t, v, header.Get(k), k)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0xff, 1, 2, 3, 4, 5, 6, 7, 0xfe, 1, 2, 3, 4, 5, 6, 7},
+		SpanID:     trace.SpanID{0xff, 1, 2, 3, 4, 5, 6, 7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	carrier := propagation.HeaderCarrier(http.Header{})
+	Propagator{}.Inject(trace.ContextWithSpanContext(context.Background(), sc), carrier)
+	ctx := Propagator{}.Extract(context.Background(), carrier)
+	assert.Equal(t, sc.WithRemote(true), trace.SpanContextFromContext(ctx))
+}
+
+func TestExtractWithError(t *testing.T) {
+	testCases := []struct {
+		name    string
+		headers map[string]string
+		err     error
+	}{
+		{name: "no headers", headers: map[string]string{}},
+		{
+			name:    "valid",
+			headers: map[string]string{traceIDHeader: traceID64Str, parentIDHeader: spanIDStr},
+		},
+		{
+			name:    "missing parent ID",
+			headers: map[string]string{traceIDHeader: traceID64Str},
+			err:     errMissingTraceID,
+		},
+		{
+			name:    "invalid trace ID",
+			headers: map[string]string{traceIDHeader: "abc", parentIDHeader: spanIDStr},
+			err:     errMalformedTraceID,
+		},
+		{
+			name:    "invalid priority",
+			headers: map[string]string{traceIDHeader: traceID64Str, parentIDHeader: spanIDStr, samplingPriorityHeader: "keep"},
+			err:     errMalformedPriority,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tc.headers {
+				header.Set(k, v)
+			}
+			ctx, err := Propagator{}.ExtractWithError(context.Background(), propagation.HeaderCarrier(header))
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.err == nil && len(tc.headers) > 0, trace.SpanContextFromContext(ctx).IsValid())
+		})
+	}
+}
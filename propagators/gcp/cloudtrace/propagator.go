@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudtrace implements the propagator of the X-Cloud-Trace-Context
+// header used by Google Cloud load balancers and services.
+package cloudtrace
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceContextHeader = "X-Cloud-Trace-Context"
+	spanIDDelimiter    = "/"
+	optionsDelimiter   = ";"
+	traceOption        = "o="
+	traceIDWidth       = 128 / 4
+
+	traceIDPadding = "00000000000000000000000000000000"
+)
+
+var (
+	empty = trace.SpanContext{}
+
+	errMalformedHeader  = errors.New("invalid X-Cloud-Trace-Context header value, should be TRACE_ID/SPAN_ID;o=TRACE_TRUE")
+	errMalformedTraceID = errors.New("cannot decode trace ID from header, should be a string of up to 32 hex characters")
+	errMalformedSpanID  = errors.New("cannot decode span ID from header, should be a non zero unsigned 64-bit decimal number")
+	errMalformedOption  = errors.New("cannot decode trace option from header, should be o=0 or o=1")
+)
+
+// Propagator serializes Span Context to/from the Google Cloud Trace header.
+//
+// Google Cloud Trace format:
+//
+// X-Cloud-Trace-Context: {trace-id}/{span-id};o={trace-true}
+//
+// The trace ID is 32 hex characters, the span ID an unsigned decimal
+// number, and the trace-true option 1 if the trace is sampled.
+type Propagator struct{}
+
+// Asserts that the propagator implements the otel.TextMapPropagator interface at compile time.
+var _ propagation.TextMapPropagator = &Propagator{}
+
+// Inject injects a context to the carrier following the Google Cloud Trace
+// format.
+func (p Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanFromContext(ctx).SpanContext()
+	if !sc.TraceID().IsValid() || !sc.SpanID().IsValid() {
+		return
+	}
+
+	spanID := sc.SpanID()
+	sampled := 0
+	if sc.IsSampled() {
+		sampled = 1
+	}
+	carrier.Set(traceContextHeader, fmt.Sprintf("%s%s%d%s%s%d",
+		sc.TraceID(), spanIDDelimiter, binary.BigEndian.Uint64(spanID[:]),
+		optionsDelimiter, traceOption, sampled))
+}
+
+// Extract gets a context from the carrier if it contains a Google Cloud
+// Trace header.
+func (p Propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	ctx, _ = p.ExtractWithError(ctx, carrier)
+	return ctx
+}
+
+// ExtractWithError gets a context from the carrier as Extract does, and
+// returns an error describing why the Google Cloud Trace header was rejected
+// if it is invalid. No error is returned if the carrier does not contain a
+// Google Cloud Trace header.
+func (p Propagator) ExtractWithError(ctx context.Context, carrier propagation.TextMapCarrier) (context.Context, error) {
+	header := carrier.Get(traceContextHeader)
+	if header == "" {
+		return ctx, nil
+	}
+	sc, err := extract(header)
+	if err != nil {
+		return ctx, err
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc), nil
+}
+
+// extract extracts a Span Context from the header value.
+func extract(header string) (trace.SpanContext, error) {
+	var (
+		scc = trace.SpanContextConfig{}
+		err error
+	)
+
+	parts := strings.SplitN(header, spanIDDelimiter, 2)
+	if len(parts) != 2 {
+		return empty, errMalformedHeader
+	}
+
+	traceID := parts[0]
+	if traceID == "" || len(traceID) > traceIDWidth {
+		return empty, errMalformedTraceID
+	}
+	// Some clients do not zero pad the trace ID.
+	traceID = traceIDPadding[len(traceID):] + strings.ToLower(traceID)
+	if scc.TraceID, err = trace.TraceIDFromHex(traceID); err != nil {
+		return empty, errMalformedTraceID
+	}
+
+	parts = strings.SplitN(parts[1], optionsDelimiter, 2)
+	spanID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || spanID == 0 {
+		return empty, errMalformedSpanID
+	}
+	binary.BigEndian.PutUint64(scc.SpanID[:], spanID)
+
+	if len(parts) == 2 {
+		switch parts[1] {
+		case traceOption + "1":
+			scc.TraceFlags = trace.FlagsSampled
+		case traceOption + "0", "":
+		default:
+			return empty, errMalformedOption
+		}
+	}
+
+	return trace.NewSpanContext(scc), nil
+}
+
+// Fields returns the list of fields set with Inject.
+func (p Propagator) Fields() []string {
+	return []string{traceContextHeader}
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudtrace
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	traceID    = trace.TraceID{0x10, 0x5e, 0x94, 0x2e, 0x5e, 0x3b, 0x4b, 0x2c, 0x8a, 0x9b, 0x1c, 0x4d, 0x2e, 0x3f, 0x4a, 0x5b}
+	traceIDStr = "105e942e5e3b4b2c8a9b1c4d2e3f4a5b"
+	spanID     = trace.SpanID{0, 0, 0, 0, 0, 0, 0x30, 0x39}
+	spanIDStr  = "12345"
+)
+
+func TestExtract(t *testing.T) {
+	testCases := []struct {
+		name     string
+		header   string
+		expected trace.SpanContextConfig
+	}{
+		{
+			name:   "sampled",
+			header: traceIDStr + "/" + spanIDStr + ";o=1",
+			expected: trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			},
+		},
+		{
+			name:   "not sampled",
+			header: traceIDStr + "/" + spanIDStr + ";o=0",
+			expected: trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  spanID,
+			},
+		},
+		{
+			name:   "no option",
+			header: traceIDStr + "/" + spanIDStr,
+			expected: trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  spanID,
+			},
+		},
+		{
+			name:   "uppercase unpadded trace ID",
+			header: "ABC/1;o=1",
+			expected: trace.SpanContextConfig{
+				TraceID:    trace.TraceID{14: 0x0a, 15: 0xbc},
+				SpanID:     trace.SpanID{7: 1},
+				TraceFlags: trace.FlagsSampled,
+			},
+		},
+		{
+			name:   "max span ID",
+			header: traceIDStr + "/18446744073709551615;o=1",
+			expected: trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     trace.SpanID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+				TraceFlags: trace.FlagsSampled,
+			},
+		},
+		{name: "empty", header: ""},
+		{name: "missing span ID", header: traceIDStr},
+		{name: "zero trace ID", header: "0/" + spanIDStr},
+		{name: "long trace ID", header: traceIDStr + "0/" + spanIDStr},
+		{name: "invalid trace ID", header: "xyz/" + spanIDStr},
+		{name: "zero span ID", header: traceIDStr + "/0;o=1"},
+		{name: "hex span ID", header: traceIDStr + "/30ab;o=1"},
+		{name: "span ID overflow", header: traceIDStr + "/18446744073709551616;o=1"},
+		{name: "invalid option", header: traceIDStr + "/" + spanIDStr + ";o=2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(traceContextHeader, tc.header)
+			ctx := Propagator{}.Extract(context.Background(), propagation.HeaderCarrier(header))
+
+			expected := trace.NewSpanContext(tc.expected)
+			if expected.IsValid() {
+				expected = expected.WithRemote(true)
+			}
+			assert.Equal(t, expected, trace.SpanContextFromContext(ctx))
+		})
+	}
+}
+
+func TestInject(t *testing.T) {
+	testCases := []struct {
+		name     string
+		scc      trace.SpanContextConfig
+		expected string
+	}{
+		{
+			name: "sampled",
+			scc: trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			},
+			expected: traceIDStr + "/" + spanIDStr + ";o=1",
+		},
+		{
+			name: "not sampled",
+			scc: trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  spanID,
+			},
+			expected: traceIDStr + "/" + spanIDStr + ";o=0",
+		},
+		{
+			name: "invalid",
+			scc: trace.SpanContextConfig{
+				TraceID: traceID,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(tc.scc))
+			Propagator{}.Inject(ctx, propagation.HeaderCarrier(header))
+			assert.Equal(t, tc.expected, header.Get(traceContextHeader))
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{0xff, 1, 2, 3, 4, 5, 6, 7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	header := http.Header{}
+	Propagator{}.Inject(trace.ContextWithSpanContext(context.Background(), sc), propagation.HeaderCarrier(header))
+	ctx := Propagator{}.Extract(context.Background(), propagation.HeaderCarrier(header))
+	assert.Equal(t, sc.WithRemote(true), trace.SpanContextFromContext(ctx))
+}
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, []string{"X-Cloud-Trace-Context"}, Propagator{}.Fields())
+}
+
+func TestExtractWithError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header string
+		err    error
+	}{
+		{name: "no header", header: ""},
+		{name: "valid", header: traceIDStr + "/" + spanIDStr + ";o=1"},
+		{name: "missing span ID", header: traceIDStr, err: errMalformedHeader},
+		{name: "invalid trace ID", header: "xyz/" + spanIDStr, err: errMalformedTraceID},
+		{name: "invalid span ID", header: traceIDStr + "/0", err: errMalformedSpanID},
+		{name: "invalid option", header: traceIDStr + "/" + spanIDStr + ";o=2", err: errMalformedOption},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(traceContextHeader, tc.header)
+			ctx, err := Propagator{}.ExtractWithError(context.Background(), propagation.HeaderCarrier(header))
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.err == nil && tc.header != "", trace.SpanContextFromContext(ctx).IsValid())
+		})
+	}
+}
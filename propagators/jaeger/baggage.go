@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// baggagePrefix is the prefix of the headers holding a baggage item
+	// each, e.g. uberctx-user-id: 42.
+	baggagePrefix = "uberctx-"
+	// adHocBaggageHeader holds baggage items set by clients without a
+	// trace, as comma-separated key=value pairs.
+	adHocBaggageHeader = "jaeger-baggage"
+)
+
+// injectBaggage sets a baggage prefix header for each member of the baggage
+// of ctx. The values are URL encoded as Jaeger clients do.
+func injectBaggage(ctx context.Context, carrier propagation.TextMapCarrier) {
+	for _, m := range baggage.FromContext(ctx).Members() {
+		value, err := url.PathUnescape(m.Value())
+		if err != nil {
+			value = m.Value()
+		}
+		carrier.Set(baggagePrefix+m.Key(), url.QueryEscape(value))
+	}
+}
+
+// extractBaggage returns a copy of ctx with the baggage items of the
+// baggage prefix headers and of the jaeger-baggage header added to its
+// baggage. Invalid items are ignored.
+func extractBaggage(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	bag := baggage.FromContext(ctx)
+	changed := false
+	set := func(key, value string) {
+		m, err := baggage.NewMember(strings.ToLower(key), url.PathEscape(value))
+		if err != nil {
+			return
+		}
+		if b, err := bag.SetMember(m); err == nil {
+			bag = b
+			changed = true
+		}
+	}
+
+	if h := carrier.Get(adHocBaggageHeader); h != "" {
+		for _, item := range strings.Split(h, ",") {
+			kv := strings.SplitN(strings.TrimSpace(item), "=", 2)
+			if len(kv) == 2 {
+				set(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+			}
+		}
+	}
+
+	for _, k := range carrier.Keys() {
+		if len(k) <= len(baggagePrefix) || !strings.EqualFold(k[:len(baggagePrefix)], baggagePrefix) {
+			continue
+		}
+		value, err := url.QueryUnescape(carrier.Get(k))
+		if err != nil {
+			continue
+		}
+		set(k[len(baggagePrefix):], value)
+	}
+
+	if !changed {
+		return ctx
+	}
+	return baggage.ContextWithBaggage(ctx, bag)
+}
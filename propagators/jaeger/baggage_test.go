@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInjectBaggage(t *testing.T) {
+	m1, err := baggage.NewMember("user-id", "42")
+	require.NoError(t, err)
+	m2, err := baggage.NewMember("greeting", "hello%20world")
+	require.NoError(t, err)
+	bag, err := baggage.New(m1, m2)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	header := http.Header{}
+	Jaeger{}.Inject(ctx, propagation.HeaderCarrier(header))
+	assert.Equal(t, http.Header{
+		"Uberctx-User-Id":  []string{"42"},
+		"Uberctx-Greeting": []string{"hello+world"},
+	}, header)
+}
+
+func TestExtractBaggage(t *testing.T) {
+	header := http.Header{}
+	header.Set("uberctx-user-id", "42")
+	header.Set("uberctx-greeting", "hello+world")
+	header.Set("jaeger-baggage", "k1=v1, k2=v2")
+	header.Set("uberctx-", "ignored")
+	header.Set(jaegerHeader, traceID128Str+"%3A"+spanIDStr+"%3A0%3A1")
+
+	ctx := Jaeger{}.Extract(context.Background(), propagation.HeaderCarrier(header))
+
+	bag := baggage.FromContext(ctx)
+	assert.Equal(t, 4, bag.Len())
+	assert.Equal(t, "42", bag.Member("user-id").Value())
+	assert.Equal(t, "hello%20world", bag.Member("greeting").Value())
+	assert.Equal(t, "v1", bag.Member("k1").Value())
+	assert.Equal(t, "v2", bag.Member("k2").Value())
+
+	sc := trace.SpanContextFromContext(ctx)
+	assert.Equal(t, traceID, sc.TraceID())
+	assert.Equal(t, spanID, sc.SpanID())
+	assert.True(t, sc.IsSampled())
+}
+
+func TestExtractBaggageMerged(t *testing.T) {
+	m, err := baggage.NewMember("existing", "v")
+	require.NoError(t, err)
+	bag, err := baggage.New(m)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	header := http.Header{}
+	header.Set("uberctx-user-id", "42")
+	ctx = Jaeger{}.Extract(ctx, propagation.HeaderCarrier(header))
+
+	bag = baggage.FromContext(ctx)
+	assert.Equal(t, "v", bag.Member("existing").Value())
+	assert.Equal(t, "42", bag.Member("user-id").Value())
+}
+
+func TestExtractNoBaggage(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, Jaeger{}.Extract(ctx, propagation.HeaderCarrier(http.Header{})))
+}
+
+func TestBaggageRoundTrip(t *testing.T) {
+	m, err := baggage.NewMember("note", "a%2Cb%3Bc")
+	require.NoError(t, err)
+	bag, err := baggage.New(m)
+	require.NoError(t, err)
+
+	header := http.Header{}
+	Jaeger{}.Inject(baggage.ContextWithBaggage(context.Background(), bag), propagation.HeaderCarrier(header))
+	ctx := Jaeger{}.Extract(context.Background(), propagation.HeaderCarrier(header))
+	assert.Equal(t, "a%2Cb%3Bc", baggage.FromContext(ctx).Member("note").Value())
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger_test
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/contrib/propagators/propagatortest"
+)
+
+func TestConformance(t *testing.T) {
+	propagatortest.Run(t, propagatortest.Config{
+		Propagator: jaeger.Jaeger{},
+		Invalid: []map[string]string{
+			{"uber-trace-id": "invalid"},
+			{"uber-trace-id": "00000000000000000000000000000000:00f067aa0ba902b7:0:1"},
+			{"uber-trace-id": "4bf92f3577b34da6a3ce929d0e0e4736:0000000000000000:0:1"},
+		},
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -50,20 +51,25 @@ var (
 	errInvalidSpanIDLength      = errors.New("invalid span id length, must be 16")
 	errMalformedSpanID          = errors.New("cannot decode span id from header, should be a string of hex, lowercase span id can't be all zero")
 	errMalformedFlag            = errors.New("cannot decode flag")
+	errInvalidSpanContext       = errors.New("header value of uber-trace-id does not hold a valid span context")
 )
 
-// Jaeger propagator serializes SpanContext to/from Jaeger Headers
+// Jaeger propagator serializes SpanContext and Baggage to/from Jaeger Headers
 //
 // Jaeger format:
 //
 // uber-trace-id: {trace-id}:{span-id}:{parent-span-id}:{flags}
+// uberctx-{baggage-key}: {baggage-value}
 type Jaeger struct{}
 
 var _ propagation.TextMapPropagator = &Jaeger{}
 
 // Inject injects a context to the carrier following jaeger format.
 // The parent span ID is set to an dummy parent span id as the most implementations do.
+// Each baggage member is injected in a uberctx-{key} header.
 func (jaeger Jaeger) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	injectBaggage(ctx, carrier)
+
 	sc := trace.SpanFromContext(ctx).SpanContext()
 	headers := []string{}
 	if !sc.TraceID().IsValid() || !sc.SpanID().IsValid() {
@@ -82,16 +88,37 @@ func (jaeger Jaeger) Inject(ctx context.Context, carrier propagation.TextMapCarr
 }
 
 // Extract extracts a context from the carrier if it contains Jaeger headers.
+// The baggage items of the uberctx-{key} and jaeger-baggage headers are added
+// to the baggage of the context, even if the carrier holds no trace.
 func (jaeger Jaeger) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	ctx, _ = jaeger.ExtractWithError(ctx, carrier)
+	return ctx
+}
+
+// ExtractWithError extracts a context from the carrier as Extract does, and
+// returns an error describing why the uber-trace-id header was rejected if it
+// is invalid. No error is returned if the carrier does not contain the
+// uber-trace-id header.
+func (jaeger Jaeger) ExtractWithError(ctx context.Context, carrier propagation.TextMapCarrier) (context.Context, error) {
+	ctx = extractBaggage(ctx, carrier)
+
 	// extract tracing information
-	if h := carrier.Get(jaegerHeader); h != "" {
-		ctx, sc, err := extract(ctx, h)
-		if err == nil && sc.IsValid() {
-			return trace.ContextWithRemoteSpanContext(ctx, sc)
-		}
+	h := carrier.Get(jaegerHeader)
+	if h == "" {
+		return ctx, nil
 	}
-
-	return ctx
+	// Jaeger clients URL encode the header value.
+	if unescaped, err := url.QueryUnescape(h); err == nil {
+		h = unescaped
+	}
+	extracted, sc, err := extract(ctx, h)
+	if err != nil {
+		return ctx, err
+	}
+	if !sc.IsValid() {
+		return ctx, errInvalidSpanContext
+	}
+	return trace.ContextWithRemoteSpanContext(extracted, sc), nil
 }
 
 func extract(ctx context.Context, headerVal string) (context.Context, trace.SpanContext, error) {
@@ -17,11 +17,13 @@ package jaeger
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -177,3 +179,27 @@ func TestJaeger_Extract(t *testing.T) {
 		assert.Equal(t, test.debug, debugFromContext(ctx))
 	}
 }
+
+func TestJaeger_ExtractWithError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header string
+		err    error
+	}{
+		{name: "no header", header: ""},
+		{name: "valid", header: strings.Join([]string{traceID128Str, spanIDStr, deprecatedParentSpanID, "1"}, separator)},
+		{name: "malformed", header: "invalid", err: errMalformedTraceContextVal},
+		{name: "invalid trace ID length", header: strings.Join([]string{"123", spanIDStr, deprecatedParentSpanID, "1"}, separator), err: errInvalidTraceIDLength},
+		{name: "missing span ID", header: strings.Join([]string{traceID128Str, "", deprecatedParentSpanID, "1"}, separator), err: errInvalidSpanContext},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(jaegerHeader, tc.header)
+			ctx, err := Jaeger{}.ExtractWithError(context.Background(), propagation.HeaderCarrier(header))
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.err == nil && tc.header != "", trace.SpanContextFromContext(ctx).IsValid())
+		})
+	}
+}
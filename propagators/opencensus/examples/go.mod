@@ -15,5 +15,6 @@ require (
 replace (
 	go.opentelemetry.io/contrib => ../../..
 	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc => ../../../instrumentation/google.golang.org/grpc/otelgrpc
+	go.opentelemetry.io/contrib/instrumentation/requestfilter => ../../../instrumentation/requestfilter
 	go.opentelemetry.io/contrib/propagators/opencensus => ../
 )
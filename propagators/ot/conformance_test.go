@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ot_test
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/contrib/propagators/ot"
+	"go.opentelemetry.io/contrib/propagators/propagatortest"
+)
+
+func TestConformance(t *testing.T) {
+	propagatortest.Run(t, propagatortest.Config{
+		Propagator: ot.OT{},
+		TraceID64:  true,
+		Invalid: []map[string]string{
+			{"ot-tracer-traceid": "invalid", "ot-tracer-spanid": "00f067aa0ba902b7", "ot-tracer-sampled": "true"},
+			{"ot-tracer-traceid": "a3ce929d0e0e4736", "ot-tracer-sampled": "true"},
+		},
+	})
+}
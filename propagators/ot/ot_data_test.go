@@ -38,11 +38,10 @@ var (
 	traceID32    = trace.TraceID{0xa1, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
 	spanID       = trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
 	emptyBaggage = map[string]string{}
-	// TODO: once baggage extraction is supported, re-enable this
-	// baggageSet   = attribute.NewSet(
-	// 	attribute.String(baggageKey, baggageValue),
-	// 	attribute.String(baggageKey2, baggageValue2),
-	// )
+	baggageSet   = map[string]string{
+		baggageKey:  baggageValue,
+		baggageKey2: baggageValue2,
+	}
 )
 
 type extractTest struct {
@@ -75,19 +74,18 @@ var extractHeaders = []extractTest{
 	{
 		"sampling state sampled",
 		map[string]string{
-			traceIDHeader: traceID32Str,
-			spanIDHeader:  spanIDStr,
-			sampledHeader: "1",
-			baggageHeader: baggageValue,
+			traceIDHeader:  traceID32Str,
+			spanIDHeader:   spanIDStr,
+			sampledHeader:  "1",
+			baggageHeader:  baggageValue,
+			baggageHeader2: baggageValue2,
 		},
 		trace.SpanContextConfig{
 			TraceID:    traceID32,
 			SpanID:     spanID,
 			TraceFlags: trace.FlagsSampled,
 		},
-		emptyBaggage,
-		// TODO: once baggage extraction is supported, re-enable this
-		// &baggageSet,
+		baggageSet,
 	},
 	{
 		"left padding 64 bit trace ID",
@@ -31,6 +31,7 @@ const (
 	traceIDHeader = "ot-tracer-traceid"
 	spanIDHeader  = "ot-tracer-spanid"
 	sampledHeader = "ot-tracer-sampled"
+	baggagePrefix = "ot-baggage-"
 
 	otTraceIDPadding = "0000000000000000"
 
@@ -44,6 +45,7 @@ var (
 	errInvalidTraceIDHeader = errors.New("invalid OT traceID header found")
 	errInvalidSpanIDHeader  = errors.New("invalid OT spanID header found")
 	errInvalidScope         = errors.New("require either both traceID and spanID or none")
+	errInvalidSpanContext   = errors.New("OT headers do not hold a valid span context")
 )
 
 // OT propagator serializes SpanContext to/from ot-trace-* headers.
@@ -72,33 +74,65 @@ func (o OT) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
 	}
 
 	for _, m := range baggage.FromContext(ctx).Members() {
-		carrier.Set(fmt.Sprintf("ot-baggage-%s", m.Key()), m.Value())
+		carrier.Set(fmt.Sprintf("%s%s", baggagePrefix, m.Key()), m.Value())
 	}
 
 }
 
 // Extract extracts a context from the carrier if it contains OT headers.
+// The ot-baggage-{key} headers are added to the baggage of the context along
+// with the trace.
 func (o OT) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
-	var (
-		sc  trace.SpanContext
-		err error
-	)
+	ctx, _ = o.ExtractWithError(ctx, carrier)
+	return ctx
+}
 
+// ExtractWithError extracts a context from the carrier as Extract does, and
+// returns an error describing why the OT headers were rejected if they are
+// invalid. No error is returned if the carrier does not contain OT headers.
+func (o OT) ExtractWithError(ctx context.Context, carrier propagation.TextMapCarrier) (context.Context, error) {
 	var (
 		traceID = carrier.Get(traceIDHeader)
 		spanID  = carrier.Get(spanIDHeader)
 		sampled = carrier.Get(sampledHeader)
 	)
-	sc, err = extract(traceID, spanID, sampled)
-	if err != nil || !sc.IsValid() {
+	if traceID == "" && spanID == "" && sampled == "" {
+		return ctx, nil
+	}
+	sc, err := extract(traceID, spanID, sampled)
+	if err != nil {
+		return ctx, err
+	}
+	if !sc.IsValid() {
+		return ctx, errInvalidSpanContext
+	}
+	ctx = extractBaggage(ctx, carrier)
+	return trace.ContextWithRemoteSpanContext(ctx, sc), nil
+}
+
+// extractBaggage returns a copy of ctx with the baggage items of the
+// ot-baggage-{key} headers added to its baggage. Invalid items are ignored.
+func extractBaggage(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	bag := baggage.FromContext(ctx)
+	changed := false
+	for _, k := range carrier.Keys() {
+		if len(k) <= len(baggagePrefix) || !strings.EqualFold(k[:len(baggagePrefix)], baggagePrefix) {
+			continue
+		}
+		m, err := baggage.NewMember(strings.ToLower(k[len(baggagePrefix):]), carrier.Get(k))
+		if err != nil {
+			continue
+		}
+		if b, err := bag.SetMember(m); err == nil {
+			bag = b
+			changed = true
+		}
+	}
+
+	if !changed {
 		return ctx
 	}
-	// TODO: implement extracting baggage
-	//
-	// this currently is not achievable without an implementation of `keys`
-	// on the carrier, see:
-	// https://github.com/open-telemetry/opentelemetry-go/issues/1493
-	return trace.ContextWithRemoteSpanContext(ctx, sc)
+	return baggage.ContextWithBaggage(ctx, bag)
 }
 
 func (o OT) Fields() []string {
@@ -15,11 +15,14 @@
 package ot
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -144,3 +147,44 @@ func TestOT_Extract(t *testing.T) {
 		assert.Equal(t, trace.NewSpanContext(test.expected), sc, info...)
 	}
 }
+
+func TestOT_ExtractWithError(t *testing.T) {
+	testCases := []struct {
+		name    string
+		headers map[string]string
+		err     error
+	}{
+		{name: "no headers", headers: map[string]string{}},
+		{
+			name:    "valid",
+			headers: map[string]string{traceIDHeader: traceID64Str, spanIDHeader: spanIDStr, sampledHeader: "true"},
+		},
+		{
+			name:    "invalid sampled",
+			headers: map[string]string{traceIDHeader: traceID64Str, spanIDHeader: spanIDStr, sampledHeader: "yes"},
+			err:     errInvalidSampledHeader,
+		},
+		{
+			name:    "missing span ID",
+			headers: map[string]string{traceIDHeader: traceID64Str},
+			err:     errInvalidScope,
+		},
+		{
+			name:    "sampled only",
+			headers: map[string]string{sampledHeader: "true"},
+			err:     errInvalidSpanContext,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tc.headers {
+				header.Set(k, v)
+			}
+			ctx, err := OT{}.ExtractWithError(context.Background(), propagation.HeaderCarrier(header))
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.err == nil && len(tc.headers) > 0, trace.SpanContextFromContext(ctx).IsValid())
+		})
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package propagatortest provides the conformance tests run by the
+// propagators of this repository, so that they all handle span contexts and
+// carriers the same way.
+//
+// A propagator is tested by calling Run from one of its tests:
+//
+//	func TestConformance(t *testing.T) {
+//		propagatortest.Run(t, propagatortest.Config{
+//			Propagator: Propagator{},
+//			Invalid: []map[string]string{
+//				{"X-Amzn-Trace-Id": "Root=1-8a3c60f7-d188f8fa79d48a391a778fa6"},
+//			},
+//		})
+//	}
+package propagatortest // import "go.opentelemetry.io/contrib/propagators/propagatortest"
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config describes the propagator tested by Run.
+type Config struct {
+	// Propagator is the propagator under test.
+	Propagator propagation.TextMapPropagator
+	// TraceID64 is set for propagators that only propagate the 64 low-order
+	// bits of the trace IDs, whose high-order bits are zero once extracted.
+	TraceID64 bool
+	// InjectsSampling is set for propagators that inject the sampling
+	// decision without a valid span context, as B3 does. Only fields of the
+	// propagator may be injected then, and no span context extracted from
+	// them.
+	InjectsSampling bool
+	// Invalid holds carriers, as header names and values, that do not hold a
+	// valid span context for the propagator. Extracting them must leave the
+	// context unchanged, and return an error if the propagator has an
+	// ExtractWithError method.
+	Invalid []map[string]string
+	// Compatible holds propagators of the same format. The span contexts
+	// injected by Propagator must be extracted by each of them, and the other
+	// way around.
+	Compatible []propagation.TextMapPropagator
+}
+
+// errorExtractor is implemented by the propagators that report why a
+// carrier was rejected.
+type errorExtractor interface {
+	ExtractWithError(ctx context.Context, carrier propagation.TextMapCarrier) (context.Context, error)
+}
+
+// contextKey is the key of the value set in the extraction contexts, which
+// must be kept by the propagators.
+type contextKey struct{}
+
+// SpanContexts returns the span contexts propagated by the round-trip tests.
+func SpanContexts() map[string]trace.SpanContext {
+	sampled := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	return map[string]trace.SpanContext{
+		"sampled":   sampled,
+		"unsampled": sampled.WithTraceFlags(0),
+		"64-bit trace ID": trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{8: 0xa3, 9: 0xce, 10: 0x92, 11: 0x9d, 12: 0x0e, 13: 0x0e, 14: 0x47, 15: 0x36},
+			SpanID:     trace.SpanID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			TraceFlags: trace.FlagsSampled,
+		}),
+	}
+}
+
+// Carriers returns new empty carriers of the kinds the propagators are
+// tested with: HTTP headers, whose names are canonicalized, maps whose keys
+// are case sensitive, and maps whose keys are lowercased as in gRPC metadata.
+func Carriers() map[string]func() propagation.TextMapCarrier {
+	return map[string]func() propagation.TextMapCarrier{
+		"header":    func() propagation.TextMapCarrier { return propagation.HeaderCarrier(http.Header{}) },
+		"map":       func() propagation.TextMapCarrier { return mapCarrier{} },
+		"lowercase": func() propagation.TextMapCarrier { return lowercaseCarrier{} },
+	}
+}
+
+// Run runs the conformance tests of cfg.Propagator as subtests of t.
+func Run(t *testing.T, cfg Config) {
+	require.NotNil(t, cfg.Propagator, "no propagator to test")
+
+	t.Run("Fields", func(t *testing.T) { testFields(t, cfg) })
+	t.Run("RoundTrip", func(t *testing.T) { testRoundTrip(t, cfg) })
+	t.Run("InjectInvalid", func(t *testing.T) { testInjectInvalid(t, cfg) })
+	t.Run("InjectOverwrite", func(t *testing.T) { testInjectOverwrite(t, cfg) })
+	t.Run("ExtractEmpty", func(t *testing.T) { testExtractEmpty(t, cfg) })
+	t.Run("ExtractInvalid", func(t *testing.T) { testExtractInvalid(t, cfg) })
+	if len(cfg.Compatible) > 0 {
+		t.Run("Compatible", func(t *testing.T) { testCompatible(t, cfg) })
+	}
+}
+
+// testFields checks that the propagator has fields and does not list a field
+// twice.
+func testFields(t *testing.T, cfg Config) {
+	fields := cfg.Propagator.Fields()
+	assert.NotEmpty(t, fields)
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		key := strings.ToLower(field)
+		assert.False(t, seen[key], "field %q listed twice", field)
+		seen[key] = true
+	}
+}
+
+// testRoundTrip checks that the span contexts injected in each kind of
+// carrier are extracted unchanged, and that only the fields of the
+// propagator are set.
+func testRoundTrip(t *testing.T, cfg Config) {
+	for scName, sc := range SpanContexts() {
+		for carrierName, newCarrier := range Carriers() {
+			sc := sc
+			newCarrier := newCarrier
+			t.Run(scName+"/"+carrierName, func(t *testing.T) {
+				carrier := newCarrier()
+				cfg.Propagator.Inject(trace.ContextWithSpanContext(context.Background(), sc), carrier)
+				require.NotEmpty(t, carrier.Keys(), "nothing injected")
+				for _, key := range carrier.Keys() {
+					assert.True(t, hasField(cfg.Propagator, key), "injected key %q is not a field", key)
+				}
+
+				ctx := cfg.Propagator.Extract(context.Background(), carrier)
+				assert.Equal(t, expected(cfg, sc), trace.SpanContextFromContext(ctx))
+			})
+		}
+	}
+}
+
+// testInjectInvalid checks that nothing is injected without a valid span
+// context, but the sampling decision of the propagators injecting it.
+func testInjectInvalid(t *testing.T, cfg Config) {
+	sc := SpanContexts()["sampled"]
+	for name, ctx := range map[string]context.Context{
+		"no span context":    context.Background(),
+		"invalid trace ID":   trace.ContextWithSpanContext(context.Background(), sc.WithTraceID(trace.TraceID{})),
+		"invalid span ID":    trace.ContextWithSpanContext(context.Background(), sc.WithSpanID(trace.SpanID{})),
+		"empty span context": trace.ContextWithSpanContext(context.Background(), trace.SpanContext{}),
+	} {
+		carrier := mapCarrier{}
+		cfg.Propagator.Inject(ctx, carrier)
+		if !cfg.InjectsSampling {
+			assert.Empty(t, carrier, name)
+			continue
+		}
+		for key := range carrier {
+			assert.True(t, hasField(cfg.Propagator, key), "%s: injected key %q is not a field", name, key)
+		}
+		extracted := cfg.Propagator.Extract(context.Background(), carrier)
+		assert.False(t, trace.SpanContextFromContext(extracted).IsValid(), name)
+	}
+}
+
+// testInjectOverwrite checks that injecting in a carrier holding the fields
+// of another span context replaces them.
+func testInjectOverwrite(t *testing.T, cfg Config) {
+	spanContexts := SpanContexts()
+	carrier := propagation.HeaderCarrier(http.Header{})
+	cfg.Propagator.Inject(trace.ContextWithSpanContext(context.Background(), spanContexts["64-bit trace ID"]), carrier)
+	cfg.Propagator.Inject(trace.ContextWithSpanContext(context.Background(), spanContexts["unsampled"]), carrier)
+
+	ctx := cfg.Propagator.Extract(context.Background(), carrier)
+	assert.Equal(t, expected(cfg, spanContexts["unsampled"]), trace.SpanContextFromContext(ctx))
+}
+
+// testExtractEmpty checks that extracting an empty carrier keeps the span
+// context and the values of the context.
+func testExtractEmpty(t *testing.T, cfg Config) {
+	sc := SpanContexts()["sampled"]
+	parent := context.WithValue(trace.ContextWithSpanContext(context.Background(), sc), contextKey{}, "value")
+
+	for name, newCarrier := range Carriers() {
+		ctx := cfg.Propagator.Extract(parent, newCarrier())
+		assert.Equal(t, sc, trace.SpanContextFromContext(ctx), name)
+		assert.Equal(t, "value", ctx.Value(contextKey{}), name)
+
+		if extractor, ok := cfg.Propagator.(errorExtractor); ok {
+			_, err := extractor.ExtractWithError(parent, newCarrier())
+			assert.NoError(t, err, name)
+		}
+	}
+}
+
+// testExtractInvalid checks that the invalid carriers do not change the
+// context, and are reported by the propagators that report errors.
+func testExtractInvalid(t *testing.T, cfg Config) {
+	parent := context.WithValue(context.Background(), contextKey{}, "value")
+	for _, headers := range cfg.Invalid {
+		carrier := propagation.HeaderCarrier(http.Header{})
+		for k, v := range headers {
+			carrier.Set(k, v)
+		}
+
+		ctx := cfg.Propagator.Extract(parent, carrier)
+		assert.False(t, trace.SpanContextFromContext(ctx).IsValid(), "span context extracted from %v", headers)
+		assert.Equal(t, "value", ctx.Value(contextKey{}), "context value lost extracting %v", headers)
+
+		if extractor, ok := cfg.Propagator.(errorExtractor); ok {
+			_, err := extractor.ExtractWithError(parent, carrier)
+			assert.Error(t, err, "no error extracting %v", headers)
+		}
+	}
+}
+
+// testCompatible checks that the span contexts injected by the propagator
+// are extracted by the compatible propagators, and the other way around.
+func testCompatible(t *testing.T, cfg Config) {
+	for _, other := range cfg.Compatible {
+		for name, sc := range SpanContexts() {
+			ctx := trace.ContextWithSpanContext(context.Background(), sc)
+			for _, pair := range [][2]propagation.TextMapPropagator{{cfg.Propagator, other}, {other, cfg.Propagator}} {
+				carrier := propagation.HeaderCarrier(http.Header{})
+				pair[0].Inject(ctx, carrier)
+				extracted := trace.SpanContextFromContext(pair[1].Extract(context.Background(), carrier))
+				assert.Equal(t, expected(cfg, sc), extracted, "%s injected by %T and extracted by %T", name, pair[0], pair[1])
+			}
+		}
+	}
+}
+
+// expected returns the span context extracted from the fields of sc.
+func expected(cfg Config, sc trace.SpanContext) trace.SpanContext {
+	if cfg.TraceID64 {
+		traceID := sc.TraceID()
+		copy(traceID[:8], make([]byte, 8))
+		sc = sc.WithTraceID(traceID)
+	}
+	return sc.WithRemote(true)
+}
+
+// hasField returns whether key is a field of p, ignoring the case as carriers
+// may change it.
+func hasField(p propagation.TextMapPropagator, key string) bool {
+	for _, field := range p.Fields() {
+		if strings.EqualFold(field, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// mapCarrier is a carrier whose keys are case sensitive.
+type mapCarrier map[string]string
+
+func (c mapCarrier) Get(key string) string {
+	return c[key]
+}
+
+func (c mapCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c mapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// lowercaseCarrier is a carrier whose keys are lowercased, as the keys of
+// gRPC metadata are.
+type lowercaseCarrier map[string]string
+
+func (c lowercaseCarrier) Get(key string) string {
+	return c[strings.ToLower(key)]
+}
+
+func (c lowercaseCarrier) Set(key, value string) {
+	c[strings.ToLower(key)] = value
+}
+
+func (c lowercaseCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
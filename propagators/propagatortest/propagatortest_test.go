@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagatortest
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestRunTraceContext(t *testing.T) {
+	Run(t, Config{
+		Propagator: propagation.TraceContext{},
+		Invalid: []map[string]string{
+			{"traceparent": "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+			{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"},
+			{"traceparent": "invalid"},
+		},
+		Compatible: []propagation.TextMapPropagator{
+			propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+		},
+	})
+}
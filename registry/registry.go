@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registry records the contrib components embedded in a binary.
+//
+// Components register themselves when their package is initialized, so the
+// registry lists which of them, and which versions of them, a binary embeds,
+// e.g. for platforms to report them. Currently the otelhttp instrumentation,
+// the cortex exporter, and the EKS detector register themselves:
+//
+//	for _, c := range registry.Components() {
+//		log.Printf("%s %s %s", c.Kind, c.Name, c.Version)
+//	}
+package registry // import "go.opentelemetry.io/contrib/registry"
+
+import (
+	"sort"
+	"sync"
+)
+
+// Kind is the kind of a component.
+type Kind string
+
+// The kinds of the components.
+const (
+	KindInstrumentation Kind = "instrumentation"
+	KindExporter        Kind = "exporter"
+	KindDetector        Kind = "detector"
+	KindPropagator      Kind = "propagator"
+	KindSampler         Kind = "sampler"
+	KindProcessor       Kind = "processor"
+)
+
+// Component is a registered contrib component.
+type Component struct {
+	// Name is the import path of the package of the component, e.g.
+	// "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp".
+	Name string
+	// Kind is the kind of the component.
+	Kind Kind
+	// Version is the release version of the component, e.g. "0.26.0".
+	Version string
+	// SchemaURL is the URL of the semantic conventions schema the telemetry
+	// of the component follows, or empty if it follows none.
+	SchemaURL string
+}
+
+var (
+	mu         sync.RWMutex
+	components = make(map[string]Component)
+)
+
+// Register registers component, replacing the registration of the component
+// with the same name, if any. Components register themselves in an init
+// function of their package.
+func Register(component Component) {
+	mu.Lock()
+	defer mu.Unlock()
+	components[component.Name] = component
+}
+
+// Lookup returns the registered component with name, and whether it is
+// registered.
+func Lookup(name string) (Component, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	c, ok := components[name]
+	return c, ok
+}
+
+// Components returns the registered components sorted by name.
+func Components() []Component {
+	mu.RLock()
+	defer mu.RUnlock()
+	list := make([]Component, 0, len(components))
+	for _, c := range components {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
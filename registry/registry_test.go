@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	http := Component{
+		Name:      "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp",
+		Kind:      KindInstrumentation,
+		Version:   "0.26.0",
+		SchemaURL: "https://opentelemetry.io/schemas/v1.7.0",
+	}
+	cortex := Component{
+		Name:    "go.opentelemetry.io/contrib/exporters/metric/cortex",
+		Kind:    KindExporter,
+		Version: "0.25.0",
+	}
+	Register(http)
+	Register(cortex)
+
+	got, ok := Lookup(http.Name)
+	if !ok || got != http {
+		t.Errorf("Lookup(%q) = %v, %v, want %v, true", http.Name, got, ok, http)
+	}
+	if _, ok := Lookup("go.opentelemetry.io/contrib/unknown"); ok {
+		t.Error("Lookup of an unregistered component succeeded")
+	}
+
+	// The last registration of a component replaces the previous ones.
+	cortex.Version = "0.26.0"
+	Register(cortex)
+	list := Components()
+	if len(list) != 2 || list[0] != cortex || list[1] != http {
+		t.Errorf("Components() = %v, want %v", list, []Component{cortex, http})
+	}
+}
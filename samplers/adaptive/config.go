@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import "time"
+
+const (
+	defaultInterval           = 10 * time.Second
+	defaultSmoothing          = 0.3
+	defaultInitialProbability = 1
+)
+
+type config struct {
+	interval           time.Duration
+	smoothing          float64
+	initialProbability float64
+	minProbability     float64
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) *config {
+	c := &config{
+		interval:           defaultInterval,
+		smoothing:          defaultSmoothing,
+		initialProbability: defaultInitialProbability,
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithAdjustmentInterval sets how often the sampling probability is
+// adjusted. The default is 10 seconds. Non-positive values are ignored.
+func WithAdjustmentInterval(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if d > 0 {
+			c.interval = d
+		}
+	})
+}
+
+// WithSmoothing sets the weight, between 0 and 1, of the span rate of the
+// last interval in the moving average the probability is computed from.
+// Lower values smooth over traffic fluctuations more, higher values adjust
+// to changes faster. The default is 0.3. Values outside of (0, 1] are
+// ignored.
+func WithSmoothing(weight float64) Option {
+	return optionFunc(func(c *config) {
+		if weight > 0 && weight <= 1 {
+			c.smoothing = weight
+		}
+	})
+}
+
+// WithInitialProbability sets the probability used until the end of the
+// first adjustment interval. The default is 1. The value is clamped to
+// [0, 1].
+func WithInitialProbability(p float64) Option {
+	return optionFunc(func(c *config) {
+		c.initialProbability = clamp(p)
+	})
+}
+
+// WithMinProbability sets a lower bound of the sampling probability, so
+// that some spans are sampled even under high load. The default is 0. The
+// value is clamped to [0, 1].
+func WithMinProbability(p float64) Option {
+	return optionFunc(func(c *config) {
+		c.minProbability = clamp(p)
+	})
+}
+
+func clamp(p float64) float64 {
+	if p < 0 {
+		return 0
+	}
+	if p > 1 {
+		return 1
+	}
+	return p
+}
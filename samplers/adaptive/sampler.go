@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package adaptive provides a sampler that adjusts its sampling probability
+// to sample a target number of spans per minute.
+package adaptive
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Sampler samples spans with a probability that is continuously adjusted so
+// that the number of sampled spans per minute approaches a target.
+//
+// The number of spans seen by the sampler is counted over adjustment
+// intervals. At the end of each interval the rate of spans is smoothed with
+// an exponentially weighted moving average, and the probability is set to
+// the ratio of the target rate to the smoothed rate. Short bursts of traffic
+// are therefore only partially reflected in the probability.
+//
+// As with the TraceIDRatioBased sampler of the SDK, the decision is based on
+// the trace ID, so that samplers with the same probability in different
+// services make the same decision for a trace. A Sampler is meant to be
+// used by a single service. It makes the decision for all spans it is called
+// for; wrap it with sdktrace.ParentBased to only sample root spans, in
+// which case the target is the number of sampled traces per minute.
+type Sampler struct {
+	// The fields accessed atomically come first to be 64-bit aligned.
+
+	// seen is the number of spans seen in the current interval.
+	seen uint64
+	// threshold is the upper bound of the trace ID values sampled.
+	threshold uint64
+	// end is the end of the current interval in Unix nanoseconds.
+	end int64
+
+	cfg    *config
+	target float64
+
+	mu          sync.Mutex
+	now         func() time.Time
+	start       time.Time
+	rate        float64
+	probability float64
+}
+
+// compile time assertion that Sampler implements the sdktrace.Sampler
+// interface.
+var _ sdktrace.Sampler = (*Sampler)(nil)
+
+// NewSampler returns a Sampler sampling targetPerMinute spans per minute.
+// A target of zero or less samples no spans.
+func NewSampler(targetPerMinute float64, opts ...Option) *Sampler {
+	s := &Sampler{
+		cfg:    newConfig(opts...),
+		target: math.Max(targetPerMinute, 0),
+		now:    time.Now,
+	}
+	s.start = s.now()
+	s.end = s.start.Add(s.cfg.interval).UnixNano()
+	s.setProbability(s.cfg.initialProbability)
+	if s.target == 0 {
+		s.setProbability(0)
+	}
+	return s
+}
+
+// ShouldSample returns a RecordAndSample decision for spans whose trace ID
+// falls within the current probability, and a Drop decision otherwise.
+func (s *Sampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	atomic.AddUint64(&s.seen, 1)
+	s.adjust()
+
+	psc := trace.SpanContextFromContext(p.ParentContext)
+	x := binary.BigEndian.Uint64(p.TraceID[8:16]) >> 1
+	if x < atomic.LoadUint64(&s.threshold) {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.RecordAndSample,
+			Tracestate: psc.TraceState(),
+		}
+	}
+	return sdktrace.SamplingResult{
+		Decision:   sdktrace.Drop,
+		Tracestate: psc.TraceState(),
+	}
+}
+
+// Description returns information describing the sampler.
+func (s *Sampler) Description() string {
+	return fmt.Sprintf("AdaptiveSampler{target:%g/min}", s.target)
+}
+
+// Probability returns the current sampling probability.
+func (s *Sampler) Probability() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.probability
+}
+
+// adjust updates the probability if the current adjustment interval has
+// ended.
+func (s *Sampler) adjust() {
+	now := s.now()
+	if now.UnixNano() < atomic.LoadInt64(&s.end) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := now.Sub(s.start)
+	if elapsed < s.cfg.interval {
+		// Another call adjusted the probability concurrently.
+		return
+	}
+
+	seen := atomic.SwapUint64(&s.seen, 0)
+	rate := float64(seen) / elapsed.Minutes()
+	if s.rate == 0 {
+		s.rate = rate
+	} else {
+		s.rate = s.cfg.smoothing*rate + (1-s.cfg.smoothing)*s.rate
+	}
+	s.start = now
+	atomic.StoreInt64(&s.end, now.Add(s.cfg.interval).UnixNano())
+
+	if s.target == 0 {
+		return
+	}
+	probability := 1.0
+	if s.rate > 0 {
+		probability = math.Min(s.target/s.rate, 1)
+	}
+	s.setProbability(math.Max(probability, s.cfg.minProbability))
+}
+
+// setProbability sets the probability and the corresponding threshold. It
+// must be called with mu held or before the sampler is used.
+func (s *Sampler) setProbability(probability float64) {
+	s.probability = probability
+	atomic.StoreUint64(&s.threshold, uint64(probability*(1<<63)))
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time { return c.t }
+
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestSampler(target float64, opts ...Option) (*Sampler, *clock) {
+	c := &clock{t: time.Unix(1000, 0)}
+	s := NewSampler(target, opts...)
+	s.now = c.now
+	s.start = c.now()
+	s.end = s.start.Add(s.cfg.interval).UnixNano()
+	return s, c
+}
+
+func traceIDFor(i uint64) trace.TraceID {
+	var tid trace.TraceID
+	// Spread the trace IDs over the whole range of the sampled bits.
+	binary.BigEndian.PutUint64(tid[8:], i*0x9E3779B97F4A7C15)
+	return tid
+}
+
+// sampleN makes n sampling decisions and returns the number of sampled spans.
+func sampleN(s sdktrace.Sampler, offset, n int) int {
+	sampled := 0
+	for i := 0; i < n; i++ {
+		res := s.ShouldSample(sdktrace.SamplingParameters{
+			ParentContext: context.Background(),
+			TraceID:       traceIDFor(uint64(offset + i)),
+			Name:          "span",
+		})
+		if res.Decision == sdktrace.RecordAndSample {
+			sampled++
+		}
+	}
+	return sampled
+}
+
+func TestSamplerConverges(t *testing.T) {
+	s, c := newTestSampler(600, WithAdjustmentInterval(time.Minute), WithSmoothing(1))
+	assert.Equal(t, 1.0, s.Probability())
+
+	// 6000 spans per minute.
+	assert.Equal(t, 6000, sampleN(s, 0, 6000))
+	c.advance(time.Minute)
+	sampleN(s, 6000, 1)
+	assert.InDelta(t, 0.1, s.Probability(), 0.001)
+
+	sampled := sampleN(s, 6001, 5999)
+	assert.InDelta(t, 600, sampled, 60)
+}
+
+func TestSamplerSmoothing(t *testing.T) {
+	s, c := newTestSampler(100, WithAdjustmentInterval(time.Minute), WithSmoothing(0.5))
+
+	sampleN(s, 0, 1000)
+	c.advance(time.Minute)
+	sampleN(s, 1000, 1)
+	assert.InDelta(t, 0.1, s.Probability(), 0.001)
+
+	// A burst of traffic is only partially reflected.
+	sampleN(s, 1001, 2999)
+	c.advance(time.Minute)
+	sampleN(s, 4000, 1)
+	assert.InDelta(t, 0.05, s.Probability(), 0.001)
+}
+
+func TestSamplerLowTraffic(t *testing.T) {
+	s, c := newTestSampler(100, WithAdjustmentInterval(time.Minute), WithInitialProbability(0.5))
+	assert.Equal(t, 0.5, s.Probability())
+
+	sampleN(s, 0, 10)
+	c.advance(time.Minute)
+	sampleN(s, 10, 1)
+	assert.Equal(t, 1.0, s.Probability())
+}
+
+func TestSamplerMinProbability(t *testing.T) {
+	s, c := newTestSampler(1, WithAdjustmentInterval(time.Minute), WithMinProbability(0.01))
+
+	sampleN(s, 0, 100000)
+	c.advance(time.Minute)
+	sampleN(s, 100000, 1)
+	assert.Equal(t, 0.01, s.Probability())
+}
+
+func TestSamplerZeroTarget(t *testing.T) {
+	s, _ := newTestSampler(0)
+	assert.Equal(t, 0.0, s.Probability())
+	assert.Equal(t, 0, sampleN(s, 0, 100))
+}
+
+func TestSamplerKeepsTraceState(t *testing.T) {
+	ts, err := trace.ParseTraceState("key=value")
+	assert.NoError(t, err)
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceState: ts,
+	})
+
+	s, _ := newTestSampler(100)
+	res := s.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: trace.ContextWithSpanContext(context.Background(), parent),
+		TraceID:       parent.TraceID(),
+	})
+	assert.Equal(t, sdktrace.RecordAndSample, res.Decision)
+	assert.Equal(t, ts, res.Tracestate)
+}
+
+func TestSamplerDescription(t *testing.T) {
+	assert.Equal(t, "AdaptiveSampler{target:100/min}", NewSampler(100).Description())
+}
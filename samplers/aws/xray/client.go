@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds each request sent to the sampling APIs.
+const requestTimeout = 10 * time.Second
+
+// samplingRule is a sampling rule of the GetSamplingRules API.
+type samplingRule struct {
+	RuleName      string            `json:"RuleName"`
+	Priority      int64             `json:"Priority"`
+	FixedRate     float64           `json:"FixedRate"`
+	ReservoirSize int64             `json:"ReservoirSize"`
+	ServiceName   string            `json:"ServiceName"`
+	ServiceType   string            `json:"ServiceType"`
+	Host          string            `json:"Host"`
+	HTTPMethod    string            `json:"HTTPMethod"`
+	URLPath       string            `json:"URLPath"`
+	ResourceARN   string            `json:"ResourceARN"`
+	Attributes    map[string]string `json:"Attributes"`
+	Version       int64             `json:"Version"`
+}
+
+type getSamplingRulesInput struct {
+	NextToken *string `json:"NextToken"`
+}
+
+type getSamplingRulesOutput struct {
+	SamplingRuleRecords []struct {
+		SamplingRule samplingRule `json:"SamplingRule"`
+	} `json:"SamplingRuleRecords"`
+	NextToken *string `json:"NextToken"`
+}
+
+// samplingStatistics reports the spans seen by a rule since the previous
+// SamplingTargets request.
+type samplingStatistics struct {
+	ClientID     string  `json:"ClientID"`
+	RuleName     string  `json:"RuleName"`
+	RequestCount int64   `json:"RequestCount"`
+	SampledCount int64   `json:"SampledCount"`
+	BorrowCount  int64   `json:"BorrowCount"`
+	Timestamp    float64 `json:"Timestamp"`
+}
+
+type getSamplingTargetsInput struct {
+	SamplingStatisticsDocuments []samplingStatistics `json:"SamplingStatisticsDocuments"`
+}
+
+// samplingTarget is the quota and fixed rate X-Ray assigns to a rule.
+type samplingTarget struct {
+	RuleName          string   `json:"RuleName"`
+	FixedRate         float64  `json:"FixedRate"`
+	ReservoirQuota    *int64   `json:"ReservoirQuota"`
+	ReservoirQuotaTTL *float64 `json:"ReservoirQuotaTTL"`
+	Interval          *int64   `json:"Interval"`
+}
+
+type getSamplingTargetsOutput struct {
+	SamplingTargetDocuments []samplingTarget `json:"SamplingTargetDocuments"`
+	LastRuleModification    float64          `json:"LastRuleModification"`
+}
+
+// client sends requests to the sampling APIs of X-Ray.
+type client struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func newClient(endpoint string) *client {
+	return &client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// getSamplingRules returns all the sampling rules, following the pages of
+// the response.
+func (c *client) getSamplingRules(ctx context.Context) ([]samplingRule, error) {
+	var (
+		rules []samplingRule
+		input getSamplingRulesInput
+	)
+	for {
+		var output getSamplingRulesOutput
+		if err := c.post(ctx, "/GetSamplingRules", input, &output); err != nil {
+			return nil, err
+		}
+		for _, record := range output.SamplingRuleRecords {
+			rules = append(rules, record.SamplingRule)
+		}
+		if output.NextToken == nil || *output.NextToken == "" {
+			return rules, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// getSamplingTargets reports statistics and returns the targets of the rules.
+func (c *client) getSamplingTargets(ctx context.Context, statistics []samplingStatistics) (*getSamplingTargetsOutput, error) {
+	var output getSamplingTargetsOutput
+	input := getSamplingTargetsInput{SamplingStatisticsDocuments: statistics}
+	if err := c.post(ctx, "/SamplingTargets", input, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+func (c *client) post(ctx context.Context, path string, input, output interface{}) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("xray: %s request failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("xray: %s request failed: %s", path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(output); err != nil {
+		return fmt.Errorf("xray: invalid %s response: %w", path, err)
+	}
+	return nil
+}
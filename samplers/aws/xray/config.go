@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"time"
+)
+
+const (
+	// DefaultEndpoint is the endpoint of the X-Ray daemon or the
+	// OpenTelemetry Collector proxying the sampling APIs of X-Ray.
+	DefaultEndpoint = "http://127.0.0.1:2000"
+	// DefaultSamplingRulesPollingInterval is the default interval between
+	// two requests of the sampling rules.
+	DefaultSamplingRulesPollingInterval = 5 * time.Minute
+	// DefaultPollingJitter is the default upper bound of the random delay
+	// added to the interval between two requests of the sampling rules.
+	DefaultPollingJitter = 5 * time.Second
+)
+
+type config struct {
+	endpoint      string
+	rulesInterval time.Duration
+	jitter        time.Duration
+	// reservoir and fixedRate configure the local fallback.
+	reservoir int64
+	fixedRate float64
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) *config {
+	c := &config{
+		endpoint:      DefaultEndpoint,
+		rulesInterval: DefaultSamplingRulesPollingInterval,
+		jitter:        DefaultPollingJitter,
+		// The default rule of X-Ray samples one request per second and
+		// five percent of the additional requests.
+		reservoir: 1,
+		fixedRate: 0.05,
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithEndpoint sets the endpoint serving the GetSamplingRules and
+// SamplingTargets APIs of X-Ray. The default is DefaultEndpoint.
+func WithEndpoint(endpoint string) Option {
+	return optionFunc(func(c *config) {
+		if endpoint != "" {
+			c.endpoint = endpoint
+		}
+	})
+}
+
+// WithSamplingRulesPollingInterval sets the interval between two requests of
+// the sampling rules. The default is DefaultSamplingRulesPollingInterval.
+func WithSamplingRulesPollingInterval(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if d > 0 {
+			c.rulesInterval = d
+		}
+	})
+}
+
+// WithPollingJitter sets the upper bound of the random delay added to the
+// interval between two requests of the sampling rules, so that the samplers
+// of many services started together do not send their requests at the same
+// time. The default is DefaultPollingJitter, and zero disables the jitter.
+func WithPollingJitter(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if d >= 0 {
+			c.jitter = d
+		}
+	})
+}
+
+// WithFallback sets the local fallback used while no sampling rule could be
+// retrieved, and for the spans matching a rule without a valid quota
+// assigned by X-Ray. The fallback samples up to reservoirPerSecond spans per
+// second and fixedRate of the additional spans, which is clamped to [0, 1].
+// The default samples one span per second and 5% of the additional spans,
+// as the default rule of X-Ray.
+func WithFallback(reservoirPerSecond int64, fixedRate float64) Option {
+	return optionFunc(func(c *config) {
+		if reservoirPerSecond < 0 {
+			reservoirPerSecond = 0
+		}
+		c.reservoir = reservoirPerSecond
+		c.fixedRate = clamp(fixedRate)
+	})
+}
+
+func clamp(rate float64) float64 {
+	if rate < 0 {
+		return 0
+	}
+	if rate > 1 {
+		return 1
+	}
+	return rate
+}
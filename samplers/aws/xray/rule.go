@@ -0,0 +1,400 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"encoding/binary"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxCachedMatches bounds the number of cached rule matches of a manifest.
+// The cache is emptied when it is full, e.g. because the URL paths contain
+// identifiers.
+const maxCachedMatches = 1024
+
+// reservoir counts the spans sampled in the current second against a quota.
+// It is not safe for concurrent use.
+type reservoir struct {
+	second int64
+	used   int64
+}
+
+// take returns whether a span can be sampled within quota spans per second,
+// and counts it if so.
+func (r *reservoir) take(now time.Time, quota int64) bool {
+	if second := now.Unix(); second != r.second {
+		r.second = second
+		r.used = 0
+	}
+	if r.used >= quota {
+		return false
+	}
+	r.used++
+	return true
+}
+
+// sampledByRate returns whether the trace with id is sampled with rate. As
+// with the TraceIDRatioBased sampler of the SDK, the decision only depends
+// on the trace ID.
+func sampledByRate(id trace.TraceID, rate float64) bool {
+	x := binary.BigEndian.Uint64(id[8:16]) >> 1
+	return x < uint64(rate*(1<<63))
+}
+
+// fallback is the local fallback rule: a reservoir of spans per second and a
+// fixed rate for the additional spans.
+type fallback struct {
+	quota     int64
+	fixedRate float64
+
+	mu        sync.Mutex
+	reservoir reservoir
+}
+
+// take returns whether a span can be sampled within the reservoir.
+func (f *fallback) take(now time.Time) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.reservoir.take(now, f.quota)
+}
+
+func (f *fallback) sample(p sdktrace.SamplingParameters, now time.Time) bool {
+	return f.take(now) || sampledByRate(p.TraceID, f.fixedRate)
+}
+
+// rule is a sampling rule of X-Ray with the target assigned to it and the
+// statistics of the spans it matched.
+type rule struct {
+	samplingRule
+	host, method, path, resourceARN pattern
+	service, serviceType            pattern
+	attributes                      map[attribute.Key]pattern
+
+	mu sync.Mutex
+	// quota and fixedRate are assigned by X-Ray and valid until expires.
+	quota     int64
+	fixedRate float64
+	expires   time.Time
+	reservoir reservoir
+	// The statistics of the spans matched since the last report.
+	requests, sampled, borrowed int64
+}
+
+func newRule(r samplingRule) *rule {
+	nr := &rule{
+		samplingRule: r,
+		host:         compilePattern(r.Host),
+		method:       compilePattern(r.HTTPMethod),
+		path:         compilePattern(r.URLPath),
+		resourceARN:  compilePattern(r.ResourceARN),
+		service:      compilePattern(r.ServiceName),
+		serviceType:  compilePattern(r.ServiceType),
+		fixedRate:    r.FixedRate,
+	}
+	if len(r.Attributes) > 0 {
+		nr.attributes = make(map[attribute.Key]pattern, len(r.Attributes))
+		for k, v := range r.Attributes {
+			nr.attributes[attribute.Key(k)] = compilePattern(v)
+		}
+	}
+	return nr
+}
+
+// sample returns whether a span matching the rule is sampled. Within the
+// validity of its target, the span is sampled within the quota assigned by
+// X-Ray, or else with the assigned fixed rate. Without a valid target the
+// local fallback is used, and the spans it samples within its reservoir are
+// reported as borrowed.
+func (r *rule) sample(p sdktrace.SamplingParameters, now time.Time, f *fallback) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests++
+
+	var sampled bool
+	if now.Before(r.expires) {
+		sampled = r.reservoir.take(now, r.quota) || sampledByRate(p.TraceID, r.fixedRate)
+	} else if f.take(now) {
+		r.borrowed++
+		sampled = true
+	} else {
+		sampled = sampledByRate(p.TraceID, f.fixedRate)
+	}
+	if sampled {
+		r.sampled++
+	}
+	return sampled
+}
+
+// setTarget applies the target assigned by X-Ray.
+func (r *rule) setTarget(t samplingTarget, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fixedRate = clamp(t.FixedRate)
+	if t.ReservoirQuota != nil {
+		r.quota = *t.ReservoirQuota
+	}
+	if t.ReservoirQuotaTTL != nil {
+		r.expires = time.Unix(0, int64(*t.ReservoirQuotaTTL*float64(time.Second)))
+	} else if t.ReservoirQuota != nil {
+		// A quota without expiry is valid until the next targets.
+		r.expires = now.Add(defaultTargetsInterval + targetsJitter)
+	}
+}
+
+// copyTarget copies the target assigned to the previous version of the rule.
+func (r *rule) copyTarget(prev *rule) {
+	prev.mu.Lock()
+	defer prev.mu.Unlock()
+	r.quota = prev.quota
+	r.fixedRate = prev.fixedRate
+	r.expires = prev.expires
+}
+
+// statistics returns the statistics of the spans matched since the last
+// report and resets them.
+func (r *rule) statistics(clientID string, now time.Time) samplingStatistics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := samplingStatistics{
+		ClientID:     clientID,
+		RuleName:     r.RuleName,
+		RequestCount: r.requests,
+		SampledCount: r.sampled,
+		BorrowCount:  r.borrowed,
+		Timestamp:    float64(now.UnixNano()) / float64(time.Second),
+	}
+	r.requests, r.sampled, r.borrowed = 0, 0, 0
+	return s
+}
+
+// spanProperties are the properties of a span the rules are matched against.
+type spanProperties struct {
+	host, method, path string
+	attributes         map[attribute.Key]string
+}
+
+func (r *rule) matches(s *spanProperties) bool {
+	if !r.host.match(s.host) || !r.method.match(s.method) || !r.path.match(s.path) {
+		return false
+	}
+	for k, pat := range r.attributes {
+		v, ok := s.attributes[k]
+		if !ok || !pat.match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// manifest is a set of rules ordered by priority, with a cache of the rules
+// matched by the spans.
+type manifest struct {
+	rules []*rule
+	// attributeKeys are the keys of the attributes matched by the rules.
+	attributeKeys map[attribute.Key]struct{}
+	fetched       time.Time
+
+	mu    sync.Mutex
+	cache map[string]*rule
+}
+
+// newManifest returns the manifest of the rules applying to the service.
+// The targets assigned to the rules of prev are kept.
+func newManifest(rules []samplingRule, service, serviceType string, prev *manifest, fetched time.Time) *manifest {
+	m := &manifest{
+		attributeKeys: make(map[attribute.Key]struct{}),
+		fetched:       fetched,
+		cache:         make(map[string]*rule),
+	}
+	previous := make(map[string]*rule)
+	if prev != nil {
+		for _, r := range prev.rules {
+			previous[r.RuleName] = r
+		}
+	}
+	for _, sr := range rules {
+		// Only version 1 of the rules is supported.
+		if sr.Version != 1 {
+			continue
+		}
+		r := newRule(sr)
+		// The properties of the service do not change, so the rules of other
+		// services are dropped instead of being matched for each span.
+		if !r.service.match(service) || !r.serviceType.match(serviceType) || !r.resourceARN.match("") {
+			continue
+		}
+		if p, ok := previous[r.RuleName]; ok {
+			r.copyTarget(p)
+		}
+		for k := range r.attributes {
+			m.attributeKeys[k] = struct{}{}
+		}
+		m.rules = append(m.rules, r)
+	}
+	sort.SliceStable(m.rules, func(i, j int) bool {
+		if m.rules[i].Priority != m.rules[j].Priority {
+			return m.rules[i].Priority < m.rules[j].Priority
+		}
+		return m.rules[i].RuleName < m.rules[j].RuleName
+	})
+	return m
+}
+
+// match returns the rule of highest priority matching the span, or nil if
+// none does. The matched rule is cached for the properties of the span.
+func (m *manifest) match(p sdktrace.SamplingParameters) *rule {
+	s := m.spanProperties(p)
+	key := s.key()
+
+	m.mu.Lock()
+	r, ok := m.cache[key]
+	m.mu.Unlock()
+	if ok {
+		return r
+	}
+
+	for _, candidate := range m.rules {
+		if candidate.matches(s) {
+			r = candidate
+			break
+		}
+	}
+
+	m.mu.Lock()
+	if len(m.cache) >= maxCachedMatches {
+		m.cache = make(map[string]*rule)
+	}
+	m.cache[key] = r
+	m.mu.Unlock()
+	return r
+}
+
+// spanProperties returns the properties of the span from the HTTP semantic
+// conventions, and the values of the attributes matched by the rules.
+func (m *manifest) spanProperties(p sdktrace.SamplingParameters) *spanProperties {
+	s := &spanProperties{}
+	var rawURL, hostName string
+	for _, kv := range p.Attributes {
+		switch kv.Key {
+		case "http.method":
+			s.method = kv.Value.AsString()
+		case "http.target":
+			s.path = kv.Value.AsString()
+		case "http.url":
+			rawURL = kv.Value.AsString()
+		case "http.host":
+			s.host = kv.Value.AsString()
+		case "net.host.name":
+			hostName = kv.Value.AsString()
+		}
+		if _, ok := m.attributeKeys[kv.Key]; ok {
+			if s.attributes == nil {
+				s.attributes = make(map[attribute.Key]string, len(m.attributeKeys))
+			}
+			s.attributes[kv.Key] = kv.Value.Emit()
+		}
+	}
+	if s.path == "" && rawURL != "" {
+		if u, err := url.Parse(rawURL); err == nil {
+			s.path = u.Path
+			if s.host == "" {
+				s.host = u.Host
+			}
+		}
+	}
+	if i := strings.IndexByte(s.path, '?'); i >= 0 {
+		s.path = s.path[:i]
+	}
+	if s.host == "" {
+		s.host = hostName
+	}
+	return s
+}
+
+// key returns the key of the matches of the properties in the cache.
+func (s *spanProperties) key() string {
+	var b strings.Builder
+	b.WriteString(s.host)
+	b.WriteByte(0)
+	b.WriteString(s.method)
+	b.WriteByte(0)
+	b.WriteString(s.path)
+	if len(s.attributes) > 0 {
+		keys := make([]string, 0, len(s.attributes))
+		for k := range s.attributes {
+			keys = append(keys, string(k))
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			b.WriteByte(0)
+			b.WriteString(k)
+			b.WriteByte('=')
+			b.WriteString(s.attributes[attribute.Key(k)])
+		}
+	}
+	return b.String()
+}
+
+// pattern is a pattern of a sampling rule, in which '*' matches any
+// sequence of characters and '?' matches any single character. Patterns are
+// matched case-insensitively.
+type pattern string
+
+func compilePattern(p string) pattern {
+	return pattern(strings.ToLower(p))
+}
+
+func (p pattern) match(s string) bool {
+	if p == "*" {
+		return true
+	}
+	return wildcardMatch(string(p), strings.ToLower(s))
+}
+
+// wildcardMatch matches s against p, backtracking to the last '*' on a
+// mismatch.
+func wildcardMatch(pat, str string) bool {
+	p, s := []rune(pat), []rune(str)
+	pi, si := 0, 0
+	star, next := -1, 0
+	for si < len(s) {
+		switch {
+		case pi < len(p) && (p[pi] == '?' || p[pi] == s[si]):
+			pi++
+			si++
+		case pi < len(p) && p[pi] == '*':
+			star, next = pi, si
+			pi++
+		case star >= 0:
+			pi = star + 1
+			next++
+			si = next
+		default:
+			return false
+		}
+	}
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
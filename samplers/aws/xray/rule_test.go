@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestPattern(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		s       string
+		match   bool
+	}{
+		{"*", "", true},
+		{"", "", true},
+		{"", "x", false},
+		{"/healthz", "/healthz", true},
+		{"/healthz", "/healthz/live", false},
+		{"/health?", "/healthz", true},
+		{"/api/*", "/api/v1/users", true},
+		{"*/healthz", "/v1/healthz", true},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "acb", false},
+		{"GET", "get", true},
+		{"*.Example.com", "api.example.COM", true},
+		{"?", "ü", true},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.match, compilePattern(tc.pattern).match(tc.s), "pattern %q, string %q", tc.pattern, tc.s)
+	}
+}
+
+func testRule(name string, priority int64, edit func(*samplingRule)) samplingRule {
+	r := samplingRule{
+		RuleName:    name,
+		Priority:    priority,
+		ServiceName: "*",
+		ServiceType: "*",
+		Host:        "*",
+		HTTPMethod:  "*",
+		URLPath:     "*",
+		ResourceARN: "*",
+		Version:     1,
+	}
+	if edit != nil {
+		edit(&r)
+	}
+	return r
+}
+
+func TestManifestMatch(t *testing.T) {
+	m := newManifest([]samplingRule{
+		testRule("Default", 10000, nil),
+		testRule("checkout", 10, func(r *samplingRule) {
+			r.HTTPMethod = "POST"
+			r.URLPath = "/checkout*"
+		}),
+		testRule("tenant", 20, func(r *samplingRule) {
+			r.Attributes = map[string]string{"tenant": "beta-*"}
+		}),
+		testRule("other service", 1, func(r *samplingRule) { r.ServiceName = "other" }),
+		testRule("other type", 1, func(r *samplingRule) { r.ServiceType = "AWS::Lambda::Function" }),
+		testRule("resource", 1, func(r *samplingRule) { r.ResourceARN = "arn:aws:ecs:*" }),
+		testRule("version 2", 1, func(r *samplingRule) { r.Version = 2 }),
+	}, "cart", "AWS::EC2::Instance", nil, time.Now())
+
+	names := make([]string, 0, len(m.rules))
+	for _, r := range m.rules {
+		names = append(names, r.RuleName)
+	}
+	assert.Equal(t, []string{"checkout", "tenant", "Default"}, names)
+
+	testCases := []struct {
+		name     string
+		attrs    []attribute.KeyValue
+		expected string
+	}{
+		{
+			name: "method and target",
+			attrs: []attribute.KeyValue{
+				attribute.String("http.method", "POST"),
+				attribute.String("http.target", "/checkout/confirm?id=1"),
+			},
+			expected: "checkout",
+		},
+		{
+			name: "url",
+			attrs: []attribute.KeyValue{
+				attribute.String("http.method", "POST"),
+				attribute.String("http.url", "https://shop.example.com/checkout"),
+			},
+			expected: "checkout",
+		},
+		{
+			name:     "attribute",
+			attrs:    []attribute.KeyValue{attribute.String("tenant", "beta-1")},
+			expected: "tenant",
+		},
+		{
+			name:     "attribute mismatch",
+			attrs:    []attribute.KeyValue{attribute.String("tenant", "prod")},
+			expected: "Default",
+		},
+		{
+			name:     "no attributes",
+			expected: "Default",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := m.match(sdktrace.SamplingParameters{Attributes: tc.attrs})
+			require.NotNil(t, r)
+			assert.Equal(t, tc.expected, r.RuleName)
+		})
+	}
+
+	// The matches are cached by the properties of the spans.
+	assert.Len(t, m.cache, len(testCases))
+	m.match(sdktrace.SamplingParameters{Attributes: []attribute.KeyValue{
+		attribute.String("tenant", "beta-1"),
+		attribute.String("unmatched", "x"),
+	}})
+	assert.Len(t, m.cache, len(testCases))
+}
+
+func TestManifestCacheLimit(t *testing.T) {
+	m := newManifest([]samplingRule{testRule("Default", 10000, nil)}, "cart", "", nil, time.Now())
+	for i := 0; i <= maxCachedMatches; i++ {
+		r := m.match(sdktrace.SamplingParameters{Attributes: []attribute.KeyValue{
+			attribute.String("http.target", "/users/"+strconv.Itoa(i)),
+		}})
+		require.NotNil(t, r)
+	}
+	assert.Len(t, m.cache, 1)
+}
+
+func TestManifestKeepsTargets(t *testing.T) {
+	now := time.Now()
+	quota := int64(3)
+	prev := newManifest([]samplingRule{testRule("Default", 10000, nil)}, "cart", "", nil, now)
+	prev.rules[0].setTarget(samplingTarget{RuleName: "Default", FixedRate: 0.5, ReservoirQuota: &quota}, now)
+
+	m := newManifest([]samplingRule{
+		testRule("Default", 10000, nil),
+		testRule("new", 1, nil),
+	}, "cart", "", prev, now)
+	require.Len(t, m.rules, 2)
+	assert.Equal(t, int64(0), m.rules[0].quota)
+	assert.Equal(t, quota, m.rules[1].quota)
+	assert.Equal(t, 0.5, m.rules[1].fixedRate)
+	assert.True(t, now.Before(m.rules[1].expires))
+}
@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package xray provides a sampler applying the sampling rules of AWS X-Ray.
+//
+// The sampler polls the sampling rules and the targets assigned to them from
+// the GetSamplingRules and SamplingTargets APIs, as proxied by the X-Ray
+// daemon or the OpenTelemetry Collector. While the APIs cannot be reached,
+// a local fallback rule with a reservoir and a fixed rate is applied.
+package xray
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	mathrand "math/rand"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// defaultTargetsInterval is the interval between two requests of the
+	// targets, unless X-Ray assigns another one.
+	defaultTargetsInterval = 10 * time.Second
+	// targetsJitter is the upper bound of the random delay added to the
+	// interval between two requests of the targets.
+	targetsJitter = 100 * time.Millisecond
+)
+
+// serviceTypes maps the cloud.platform values of the semantic conventions
+// to the service types of X-Ray matched by the rules.
+var serviceTypes = map[string]string{
+	"aws_ec2":               "AWS::EC2::Instance",
+	"aws_ecs":               "AWS::ECS::Container",
+	"aws_eks":               "AWS::EKS::Container",
+	"aws_elastic_beanstalk": "AWS::ElasticBeanstalk::Environment",
+	"aws_lambda":            "AWS::Lambda::Function",
+}
+
+// RemoteSampler samples spans with the sampling rules of AWS X-Ray.
+//
+// The rules applying to the service are matched by priority against the
+// http.host, http.method, and http.target or http.url attributes of the span
+// and the attributes named by the rules. Only the attributes passed at span
+// creation are available to the sampler. The rule matched by spans with the
+// same properties is cached until the rules are polled again.
+//
+// A matching rule samples spans within the reservoir quota per second that
+// X-Ray assigns to the service, and a fixed rate of the additional spans.
+// Until the rules are retrieved, the local fallback is applied to all spans.
+// It is also applied to the spans matching a rule while no quota is
+// assigned to the rule, e.g. because the SamplingTargets API cannot be
+// reached and the last quota expired; the spans the fallback samples within
+// its reservoir are then reported to X-Ray as borrowed.
+//
+// The sampler makes the decision for all spans it is called for; wrap it
+// with sdktrace.ParentBased to only sample root spans.
+type RemoteSampler struct {
+	cfg         *config
+	client      *client
+	service     string
+	serviceType string
+	clientID    string
+	fallback    *fallback
+	now         func() time.Time
+
+	// manifest is the *manifest of the last rules retrieved.
+	manifest atomic.Value
+}
+
+// compile time assertion that RemoteSampler implements the sdktrace.Sampler
+// interface.
+var _ sdktrace.Sampler = (*RemoteSampler)(nil)
+
+// NewRemoteSampler returns a RemoteSampler for the service named serviceName
+// running on cloudPlatform, a value of the cloud.platform attribute such as
+// "aws_ec2". The sampler polls the rules and targets until ctx is canceled.
+func NewRemoteSampler(ctx context.Context, serviceName, cloudPlatform string, opts ...Option) (*RemoteSampler, error) {
+	s, err := newRemoteSampler(serviceName, cloudPlatform, opts...)
+	if err != nil {
+		return nil, err
+	}
+	go s.poll(ctx)
+	return s, nil
+}
+
+func newRemoteSampler(serviceName, cloudPlatform string, opts ...Option) (*RemoteSampler, error) {
+	cfg := newConfig(opts...)
+	endpoint, err := url.Parse(cfg.endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("xray: invalid endpoint %q", cfg.endpoint)
+	}
+
+	var id [12]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, fmt.Errorf("xray: failed to generate the client ID: %w", err)
+	}
+	return &RemoteSampler{
+		cfg:         cfg,
+		client:      newClient(cfg.endpoint),
+		service:     serviceName,
+		serviceType: serviceTypes[cloudPlatform],
+		clientID:    hex.EncodeToString(id[:]),
+		fallback:    &fallback{quota: cfg.reservoir, fixedRate: cfg.fixedRate},
+		now:         time.Now,
+	}, nil
+}
+
+// ShouldSample returns a RecordAndSample decision for the spans sampled by
+// the rule they match, or by the local fallback, and a Drop decision
+// otherwise.
+func (s *RemoteSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	now := s.now()
+	var sampled bool
+	if r := s.match(p); r != nil {
+		sampled = r.sample(p, now, s.fallback)
+	} else {
+		sampled = s.fallback.sample(p, now)
+	}
+
+	decision := sdktrace.Drop
+	if sampled {
+		decision = sdktrace.RecordAndSample
+	}
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+// Description returns information describing the sampler.
+func (s *RemoteSampler) Description() string {
+	return fmt.Sprintf("AWSXRayRemoteSampler{fallback:%d/s,%g}", s.fallback.quota, s.fallback.fixedRate)
+}
+
+// match returns the rule matching the span, or nil if no rule was retrieved
+// or none matches.
+func (s *RemoteSampler) match(p sdktrace.SamplingParameters) *rule {
+	m, _ := s.manifest.Load().(*manifest)
+	if m == nil {
+		return nil
+	}
+	return m.match(p)
+}
+
+// poll polls the rules and targets until ctx is canceled. The rules are
+// requested right away, and again every minute until they are retrieved.
+func (s *RemoteSampler) poll(ctx context.Context) {
+	random := mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
+	jitter := func(max time.Duration) time.Duration {
+		if max <= 0 {
+			return 0
+		}
+		return time.Duration(random.Int63n(int64(max)))
+	}
+
+	rules := time.NewTimer(0)
+	defer rules.Stop()
+	targets := time.NewTimer(defaultTargetsInterval + jitter(targetsJitter))
+	defer targets.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-rules.C:
+			next := s.cfg.rulesInterval
+			if err := s.refreshRules(ctx); err != nil {
+				otel.Handle(err)
+				if s.manifest.Load() == nil && next > time.Minute {
+					next = time.Minute
+				}
+			}
+			rules.Reset(next + jitter(s.cfg.jitter))
+		case <-targets.C:
+			next, err := s.refreshTargets(ctx)
+			if err != nil {
+				otel.Handle(err)
+			}
+			targets.Reset(next + jitter(targetsJitter))
+		}
+	}
+}
+
+// refreshRules retrieves the rules. The targets assigned to the rules
+// retrieved before are kept.
+func (s *RemoteSampler) refreshRules(ctx context.Context) error {
+	rules, err := s.client.getSamplingRules(ctx)
+	if err != nil {
+		return err
+	}
+	prev, _ := s.manifest.Load().(*manifest)
+	s.manifest.Store(newManifest(rules, s.service, s.serviceType, prev, s.now()))
+	return nil
+}
+
+// refreshTargets reports the statistics of the rules and applies the
+// targets assigned to them. The rules are retrieved again if they were
+// modified since they were last retrieved. It returns the interval until the
+// next request of the targets.
+func (s *RemoteSampler) refreshTargets(ctx context.Context) (time.Duration, error) {
+	m, _ := s.manifest.Load().(*manifest)
+	if m == nil || len(m.rules) == 0 {
+		return defaultTargetsInterval, nil
+	}
+
+	now := s.now()
+	statistics := make([]samplingStatistics, 0, len(m.rules))
+	rules := make(map[string]*rule, len(m.rules))
+	for _, r := range m.rules {
+		statistics = append(statistics, r.statistics(s.clientID, now))
+		rules[r.RuleName] = r
+	}
+	output, err := s.client.getSamplingTargets(ctx, statistics)
+	if err != nil {
+		return defaultTargetsInterval, err
+	}
+
+	var interval time.Duration
+	for _, t := range output.SamplingTargetDocuments {
+		if r, ok := rules[t.RuleName]; ok {
+			r.setTarget(t, now)
+		}
+		if t.Interval != nil && *t.Interval > 0 {
+			if d := time.Duration(*t.Interval) * time.Second; interval == 0 || d < interval {
+				interval = d
+			}
+		}
+	}
+	if interval == 0 {
+		interval = defaultTargetsInterval
+	}
+
+	modified := time.Unix(0, int64(output.LastRuleModification*float64(time.Second)))
+	if modified.After(m.fetched) {
+		return interval, s.refreshRules(ctx)
+	}
+	return interval, nil
+}
@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// testXRay serves the sampling APIs of X-Ray.
+type testXRay struct {
+	mu               sync.Mutex
+	rules            []samplingRule
+	targets          []samplingTarget
+	lastModification time.Time
+	statistics       []samplingStatistics
+	rulesRequests    int
+}
+
+func (x *testXRay) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	var output interface{}
+	switch req.URL.Path {
+	case "/GetSamplingRules":
+		x.rulesRequests++
+		var rules getSamplingRulesOutput
+		for _, r := range x.rules {
+			rules.SamplingRuleRecords = append(rules.SamplingRuleRecords, struct {
+				SamplingRule samplingRule `json:"SamplingRule"`
+			}{r})
+		}
+		output = rules
+	case "/SamplingTargets":
+		var input getSamplingTargetsInput
+		if err := json.NewDecoder(req.Body).Decode(&input); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		x.statistics = input.SamplingStatisticsDocuments
+		output = getSamplingTargetsOutput{
+			SamplingTargetDocuments: x.targets,
+			LastRuleModification:    float64(x.lastModification.Unix()),
+		}
+	default:
+		http.NotFound(rw, req)
+		return
+	}
+	_ = json.NewEncoder(rw).Encode(output)
+}
+
+func newTestSampler(t *testing.T, endpoint string, opts ...Option) (*RemoteSampler, *time.Time) {
+	s, err := newRemoteSampler("cart", "aws_ec2", append([]Option{WithEndpoint(endpoint)}, opts...)...)
+	require.NoError(t, err)
+	now := time.Unix(1600000000, 0)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+// sampleN returns the number of n spans with the attributes that are sampled.
+func sampleN(s sdktrace.Sampler, n int, attrs ...attribute.KeyValue) int {
+	var sampled int
+	for i := 0; i < n; i++ {
+		res := s.ShouldSample(sdktrace.SamplingParameters{Attributes: attrs})
+		if res.Decision == sdktrace.RecordAndSample {
+			sampled++
+		}
+	}
+	return sampled
+}
+
+func TestNewConfig(t *testing.T) {
+	cfg := newConfig()
+	assert.Equal(t, DefaultEndpoint, cfg.endpoint)
+	assert.Equal(t, DefaultSamplingRulesPollingInterval, cfg.rulesInterval)
+	assert.Equal(t, DefaultPollingJitter, cfg.jitter)
+	assert.Equal(t, int64(1), cfg.reservoir)
+	assert.Equal(t, 0.05, cfg.fixedRate)
+
+	cfg = newConfig(
+		WithEndpoint("http://collector:2000"),
+		WithSamplingRulesPollingInterval(time.Minute),
+		WithPollingJitter(0),
+		WithFallback(-1, 2),
+	)
+	assert.Equal(t, "http://collector:2000", cfg.endpoint)
+	assert.Equal(t, time.Minute, cfg.rulesInterval)
+	assert.Equal(t, time.Duration(0), cfg.jitter)
+	assert.Equal(t, int64(0), cfg.reservoir)
+	assert.Equal(t, 1.0, cfg.fixedRate)
+}
+
+func TestNewRemoteSamplerInvalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"127.0.0.1:2000", "unix:///xray.sock", "http://"} {
+		_, err := NewRemoteSampler(context.Background(), "cart", "", WithEndpoint(endpoint))
+		assert.Error(t, err, endpoint)
+	}
+}
+
+func TestFallbackWithoutRules(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	s, now := newTestSampler(t, server.URL, WithFallback(2, 0))
+
+	require.Error(t, s.refreshRules(context.Background()))
+	assert.Equal(t, 2, sampleN(s, 5))
+	*now = now.Add(time.Second)
+	assert.Equal(t, 2, sampleN(s, 5))
+
+	s, _ = newTestSampler(t, server.URL, WithFallback(0, 1))
+	assert.Equal(t, 5, sampleN(s, 5))
+}
+
+func TestRemoteRules(t *testing.T) {
+	xray := &testXRay{rules: []samplingRule{
+		testRule("Default", 10000, nil),
+		testRule("checkout", 1, func(r *samplingRule) { r.URLPath = "/checkout" }),
+	}}
+	server := httptest.NewServer(xray)
+	defer server.Close()
+	s, now := newTestSampler(t, server.URL, WithFallback(1, 0))
+	ctx := context.Background()
+	checkout := attribute.String("http.target", "/checkout")
+
+	require.NoError(t, s.refreshRules(ctx))
+
+	// Without targets, the rules borrow from the reservoir of the fallback.
+	assert.Equal(t, 1, sampleN(s, 3))
+	assert.Equal(t, 0, sampleN(s, 3, checkout))
+
+	ttl := float64(now.Add(time.Minute).Unix())
+	quota, interval := int64(2), int64(5)
+	xray.targets = []samplingTarget{
+		{RuleName: "Default", FixedRate: 0, ReservoirQuota: &quota, ReservoirQuotaTTL: &ttl, Interval: &interval},
+		{RuleName: "checkout", FixedRate: 1},
+		{RuleName: "unknown", FixedRate: 1},
+	}
+	next, err := s.refreshTargets(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, next)
+	require.Len(t, xray.statistics, 2)
+	assert.Equal(t, samplingStatistics{
+		ClientID:     s.clientID,
+		RuleName:     "checkout",
+		RequestCount: 3,
+		Timestamp:    float64(now.Unix()),
+	}, xray.statistics[0])
+	assert.Equal(t, samplingStatistics{
+		ClientID:     s.clientID,
+		RuleName:     "Default",
+		RequestCount: 3,
+		SampledCount: 1,
+		BorrowCount:  1,
+		Timestamp:    float64(now.Unix()),
+	}, xray.statistics[1])
+
+	// The quota is applied and then the fixed rate. A rule assigned no quota
+	// still uses the fallback.
+	*now = now.Add(time.Second)
+	assert.Equal(t, 2, sampleN(s, 5))
+	assert.Equal(t, 1, sampleN(s, 5, checkout))
+
+	// The targets are kept when the rules are retrieved again.
+	require.NoError(t, s.refreshRules(ctx))
+	assert.Equal(t, 2, xray.rulesRequests)
+	*now = now.Add(time.Second)
+	assert.Equal(t, 2, sampleN(s, 5))
+
+	// The fallback is used again once the quota expired.
+	*now = now.Add(time.Minute)
+	assert.Equal(t, 1, sampleN(s, 5))
+}
+
+func TestRuleModification(t *testing.T) {
+	xray := &testXRay{rules: []samplingRule{testRule("Default", 10000, nil)}}
+	server := httptest.NewServer(xray)
+	defer server.Close()
+	s, now := newTestSampler(t, server.URL)
+	ctx := context.Background()
+
+	require.NoError(t, s.refreshRules(ctx))
+	xray.lastModification = now.Add(-time.Minute)
+	next, err := s.refreshTargets(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, defaultTargetsInterval, next)
+	assert.Equal(t, 1, xray.rulesRequests)
+
+	xray.lastModification = now.Add(time.Minute)
+	_, err = s.refreshTargets(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, xray.rulesRequests)
+}
+
+func TestPoll(t *testing.T) {
+	xray := &testXRay{rules: []samplingRule{testRule("Default", 10000, nil)}}
+	server := httptest.NewServer(xray)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := NewRemoteSampler(ctx, "cart", "aws_ec2", WithEndpoint(server.URL))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return s.manifest.Load() != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRemoteSamplerDescription(t *testing.T) {
+	s, _ := newTestSampler(t, DefaultEndpoint, WithFallback(2, 0.1))
+	assert.Equal(t, "AWSXRayRemoteSampler{fallback:2/s,0.1}", s.Description())
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consistent
+
+const defaultPrecision = 4
+
+type config struct {
+	precision int
+}
+
+// newConfig creates a new config struct and applies opts to it.
+func newConfig(opts ...Option) *config {
+	c := &config{
+		precision: defaultPrecision,
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// Option interface used for setting optional config properties.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// WithPrecision sets the number of significant hexadecimal digits of the
+// threshold encoded in the tracestate, from 1 to 14. The leading "f" digits of
+// the thresholds of small probabilities are not counted, so that their
+// relative precision is the same. The default is 4, which keeps the relative
+// error of the probability below 0.01%. Values outside of [1, 14] are ignored.
+func WithPrecision(digits int) Option {
+	return optionFunc(func(c *config) {
+		if digits >= 1 && digits <= thresholdDigits {
+			c.precision = digits
+		}
+	})
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package consistent provides samplers making consistent probability sampling
+// decisions, which are recorded in the tracestate.
+//
+// The samplers compare the 56-bit randomness of a trace with a rejection
+// threshold derived from their probability: a span is sampled when the
+// randomness is greater than or equal to the threshold. The randomness is the
+// "rv" value of the "ot" tracestate member if the trace has one, and the last
+// 56 bits of the trace ID otherwise. Since all the samplers of a trace compare
+// the same randomness, a trace sampled with a probability is sampled by every
+// sampler with a higher probability, so that the spans of the services of a
+// trace sampled at different rates are all sampled at the lowest of the rates.
+//
+// The threshold of a sampled span is recorded as the "th" value of the "ot"
+// tracestate member, e.g. "ot=th:c" for a probability of 25%, from which the
+// number of spans the span represents is derived by the backends.
+package consistent
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type probabilityBased struct {
+	fraction float64
+	// threshold is the rejection threshold of the sampler, and th its
+	// encoding. The sampler samples no spans when it is maxThreshold.
+	threshold uint64
+	th        string
+}
+
+// compile time assertion that probabilityBased implements the sdktrace.Sampler
+// interface.
+var _ sdktrace.Sampler = probabilityBased{}
+
+// ProbabilityBased returns a sampler sampling fraction of the traces with
+// consistent decisions, recording its threshold in the tracestate of the
+// sampled spans. Fractions greater than or equal to 1 sample all the spans,
+// and fractions less than or equal to 0 sample none.
+//
+// The sampler makes the decision for all spans it is called for; wrap it with
+// ParentProbabilityBased to only sample root spans.
+func ProbabilityBased(fraction float64, opts ...Option) sdktrace.Sampler {
+	c := newConfig(opts...)
+	s := probabilityBased{fraction: fraction, threshold: threshold(fraction, c.precision)}
+	if s.threshold < maxThreshold {
+		s.th = formatThreshold(s.threshold)
+	}
+	return s
+}
+
+// threshold returns the rejection threshold of fraction, rounded to precision
+// hexadecimal digits after its leading "f" digits.
+func threshold(fraction float64, precision int) uint64 {
+	if fraction >= 1 {
+		return 0
+	}
+	if fraction <= 0 {
+		return maxThreshold
+	}
+	t := maxThreshold - uint64(math.Round(fraction*float64(maxThreshold)))
+	if t == maxThreshold {
+		return t
+	}
+
+	leading := len(formatThreshold(t)) - len(strings.TrimLeft(formatThreshold(t), "f"))
+	shift := 4 * (thresholdDigits - leading - precision)
+	if shift <= 0 {
+		return t
+	}
+	t = (t + 1<<(shift-1)) >> shift << shift
+	if t >= maxThreshold {
+		// Keep the smallest probability of the precision instead of none.
+		t = maxThreshold - 1<<shift
+	}
+	return t
+}
+
+// ShouldSample returns a RecordAndSample decision with the threshold of the
+// sampler in the tracestate if the randomness of the trace is greater than or
+// equal to the threshold, and a Drop decision without a threshold otherwise.
+func (s probabilityBased) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	state := trace.SpanContextFromContext(p.ParentContext).TraceState()
+	ot := parseOTelState(state.Get(otelKey))
+	if s.threshold < maxThreshold && randomness(p.TraceID, ot) >= s.threshold {
+		ot.set(thresholdKey, s.th)
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.RecordAndSample,
+			Tracestate: withOTelState(state, ot),
+		}
+	}
+	ot.delete(thresholdKey)
+	return sdktrace.SamplingResult{
+		Decision:   sdktrace.Drop,
+		Tracestate: withOTelState(state, ot),
+	}
+}
+
+// Description returns information describing the sampler.
+func (s probabilityBased) Description() string {
+	return fmt.Sprintf("ProbabilityBased{%g}", s.fraction)
+}
+
+type parentProbabilityBased struct {
+	sdktrace.Sampler
+}
+
+// ParentProbabilityBased returns a sampler making the decisions of the root
+// spans with root, e.g. a ProbabilityBased sampler, and following the decision
+// of the parent of the other spans as sdktrace.ParentBased does. The
+// threshold of the parent is kept in the tracestate of the sampled spans if it
+// is consistent with the randomness of the trace, and removed otherwise.
+func ParentProbabilityBased(root sdktrace.Sampler, opts ...sdktrace.ParentBasedSamplerOption) sdktrace.Sampler {
+	return parentProbabilityBased{Sampler: sdktrace.ParentBased(root, opts...)}
+}
+
+// ShouldSample returns the decision of the parent-based sampler, removing the
+// threshold from the tracestate of the dropped spans and of the spans whose
+// threshold is invalid or inconsistent with their randomness.
+func (s parentProbabilityBased) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	res := s.Sampler.ShouldSample(p)
+	if !trace.SpanContextFromContext(p.ParentContext).IsValid() {
+		// The decision of the root sampler is kept as is.
+		return res
+	}
+
+	ot := parseOTelState(res.Tracestate.Get(otelKey))
+	th := ot.get(thresholdKey)
+	if th == "" {
+		return res
+	}
+	if t, ok := parseThreshold(th); ok && res.Decision == sdktrace.RecordAndSample && randomness(p.TraceID, ot) >= t {
+		return res
+	}
+	ot.delete(thresholdKey)
+	res.Tracestate = withOTelState(res.Tracestate, ot)
+	return res
+}
+
+// Description returns information describing the sampler.
+func (s parentProbabilityBased) Description() string {
+	return "ParentProbabilityBased{" + s.Sampler.Description() + "}"
+}
+
+// randomness returns the explicit randomness of ot if it has a valid one, and
+// the last 56 bits of traceID otherwise.
+func randomness(traceID trace.TraceID, ot otelState) uint64 {
+	if r, ok := parseRandomness(ot.get(randomnessKey)); ok {
+		return r
+	}
+	return binary.BigEndian.Uint64(traceID[8:]) & (maxThreshold - 1)
+}
+
+// withOTelState returns state with ot as its "ot" member, or without an "ot"
+// member if ot is empty. state is returned unchanged if ot cannot be set.
+func withOTelState(state trace.TraceState, ot otelState) trace.TraceState {
+	if len(ot.fields) == 0 {
+		if state.Get(otelKey) == "" {
+			return state
+		}
+		return state.Delete(otelKey)
+	}
+	updated, err := state.Insert(otelKey, ot.String())
+	if err != nil {
+		return state
+	}
+	return updated
+}
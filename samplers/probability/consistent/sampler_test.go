@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consistent
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func traceIDFor(i uint64) trace.TraceID {
+	var tid trace.TraceID
+	// Spread the trace IDs over the whole range of the random bits.
+	binary.BigEndian.PutUint64(tid[8:], i*0x9E3779B97F4A7C15)
+	return tid
+}
+
+// parentContext returns a context with a remote parent span of traceID with
+// tracestate.
+func parentContext(t *testing.T, traceID trace.TraceID, sampled bool, tracestate string) context.Context {
+	state, err := trace.ParseTraceState(tracestate)
+	require.NoError(t, err)
+	var flags trace.TraceFlags
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+	return trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{1},
+		TraceFlags: flags,
+		TraceState: state,
+	}))
+}
+
+func TestThreshold(t *testing.T) {
+	testCases := []struct {
+		fraction float64
+		opts     []Option
+		th       string
+	}{
+		{fraction: 1, th: "0"},
+		{fraction: 2, th: "0"},
+		{fraction: 0.5, th: "8"},
+		{fraction: 0.25, th: "c"},
+		{fraction: 0.75, th: "4"},
+		{fraction: 1.0 / 3, th: "aaab"},
+		{fraction: 1.0 / 3, opts: []Option{WithPrecision(2)}, th: "ab"},
+		{fraction: 1.0 / 3, opts: []Option{WithPrecision(20)}, th: "aaab"},
+		{fraction: 0.01, th: "fd70a"},
+		{fraction: 0.001, th: "ffbe77"},
+		{fraction: 1.0 / (1 << 56), opts: []Option{WithPrecision(14)}, th: "ffffffffffffff"},
+		{fraction: 0, th: ""},
+		{fraction: -1, th: ""},
+	}
+	for _, tc := range testCases {
+		s := ProbabilityBased(tc.fraction, tc.opts...).(probabilityBased)
+		assert.Equal(t, tc.th, s.th, "fraction %g", tc.fraction)
+	}
+}
+
+func TestProbabilityBasedConsistent(t *testing.T) {
+	low := ProbabilityBased(0.1)
+	high := ProbabilityBased(0.5)
+
+	const n = 10000
+	var sampledLow, sampledHigh int
+	for i := uint64(0); i < n; i++ {
+		p := sdktrace.SamplingParameters{ParentContext: context.Background(), TraceID: traceIDFor(i), Name: "span"}
+		l := low.ShouldSample(p).Decision == sdktrace.RecordAndSample
+		h := high.ShouldSample(p).Decision == sdktrace.RecordAndSample
+		if l {
+			sampledLow++
+			// A trace sampled at the lower probability is sampled at the higher one.
+			assert.True(t, h, "trace %d", i)
+		}
+		if h {
+			sampledHigh++
+		}
+	}
+	assert.InDelta(t, 0.1*n, sampledLow, 0.01*n)
+	assert.InDelta(t, 0.5*n, sampledHigh, 0.02*n)
+}
+
+func TestProbabilityBasedTracestate(t *testing.T) {
+	s := ProbabilityBased(0.25)
+	traceID := traceIDFor(1)
+
+	testCases := []struct {
+		name       string
+		tracestate string
+		decision   sdktrace.SamplingDecision
+		expected   string
+	}{
+		{
+			name:       "explicit randomness sampled",
+			tracestate: "vendor=a,ot=rv:f0000000000000;xy:1",
+			decision:   sdktrace.RecordAndSample,
+			expected:   "ot=rv:f0000000000000;xy:1;th:c,vendor=a",
+		},
+		{
+			name:       "threshold of the parent replaced",
+			tracestate: "ot=th:8;rv:c0000000000000",
+			decision:   sdktrace.RecordAndSample,
+			expected:   "ot=th:c;rv:c0000000000000",
+		},
+		{
+			name:       "explicit randomness dropped",
+			tracestate: "ot=th:0;rv:bfffffffffffff,vendor=a",
+			decision:   sdktrace.Drop,
+			expected:   "ot=rv:bfffffffffffff,vendor=a",
+		},
+		{
+			name:       "invalid randomness ignored",
+			tracestate: "vendor=a,ot=th:0;rv:01",
+			decision:   sdktrace.Drop,
+			expected:   "ot=rv:01,vendor=a",
+		},
+		{
+			name:       "only threshold dropped",
+			tracestate: "vendor=a,ot=th:0",
+			decision:   sdktrace.Drop,
+			expected:   "vendor=a",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := s.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: parentContext(t, traceID, true, tc.tracestate),
+				TraceID:       traceID,
+				Name:          "span",
+			})
+			assert.Equal(t, tc.decision, res.Decision)
+			assert.Equal(t, tc.expected, res.Tracestate.String())
+		})
+	}
+
+	var sampled trace.TraceID
+	binary.BigEndian.PutUint64(sampled[8:], 0xc0000000000000)
+	res := s.ShouldSample(sdktrace.SamplingParameters{ParentContext: context.Background(), TraceID: sampled})
+	assert.Equal(t, sdktrace.RecordAndSample, res.Decision)
+	assert.Equal(t, "ot=th:c", res.Tracestate.String())
+
+	binary.BigEndian.PutUint64(sampled[8:], 0xffbfffffffffffff)
+	res = s.ShouldSample(sdktrace.SamplingParameters{ParentContext: context.Background(), TraceID: sampled})
+	assert.Equal(t, sdktrace.Drop, res.Decision)
+	assert.Equal(t, "", res.Tracestate.String())
+
+	assert.Equal(t, "ProbabilityBased{0.25}", s.Description())
+}
+
+func TestParentProbabilityBased(t *testing.T) {
+	s := ParentProbabilityBased(ProbabilityBased(0.5))
+	var traceID trace.TraceID
+	binary.BigEndian.PutUint64(traceID[8:], 0xa0000000000000)
+
+	testCases := []struct {
+		name       string
+		sampled    bool
+		tracestate string
+		decision   sdktrace.SamplingDecision
+		expected   string
+	}{
+		{name: "consistent", sampled: true, tracestate: "ot=th:8", decision: sdktrace.RecordAndSample, expected: "ot=th:8"},
+		{name: "inconsistent", sampled: true, tracestate: "ot=th:c", decision: sdktrace.RecordAndSample, expected: ""},
+		{name: "invalid", sampled: true, tracestate: "ot=th:xyz;rv:a0000000000000", decision: sdktrace.RecordAndSample, expected: "ot=rv:a0000000000000"},
+		{name: "not sampled", tracestate: "vendor=a,ot=th:8", decision: sdktrace.Drop, expected: "vendor=a"},
+		{name: "no threshold", sampled: true, tracestate: "vendor=a", decision: sdktrace.RecordAndSample, expected: "vendor=a"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := s.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: parentContext(t, traceID, tc.sampled, tc.tracestate),
+				TraceID:       traceID,
+				Name:          "span",
+			})
+			assert.Equal(t, tc.decision, res.Decision)
+			assert.Equal(t, tc.expected, res.Tracestate.String())
+		})
+	}
+
+	// The root spans are sampled by the root sampler.
+	res := s.ShouldSample(sdktrace.SamplingParameters{ParentContext: context.Background(), TraceID: traceID})
+	assert.Equal(t, sdktrace.RecordAndSample, res.Decision)
+	assert.Equal(t, "ot=th:8", res.Tracestate.String())
+	assert.Contains(t, s.Description(), "ParentProbabilityBased{ParentBased{root:ProbabilityBased{0.5}")
+}
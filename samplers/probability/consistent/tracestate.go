@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consistent
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// otelKey is the tracestate key of the OpenTelemetry values.
+	otelKey = "ot"
+	// thresholdKey is the sub-key of the rejection threshold of a sampled span.
+	thresholdKey = "th"
+	// randomnessKey is the sub-key of the explicit randomness of a trace.
+	randomnessKey = "rv"
+
+	// randomBits is the number of bits of the randomness and of the thresholds.
+	randomBits = 56
+	// thresholdDigits is the number of hexadecimal digits of the thresholds.
+	thresholdDigits = randomBits / 4
+	// maxThreshold is the threshold rejecting all the spans. It is not
+	// encodable, as the spans are not sampled.
+	maxThreshold = uint64(1) << randomBits
+)
+
+// otelState is the value of the "ot" member of a tracestate, a list of
+// "key:value" fields separated by semicolons.
+type otelState struct {
+	fields []otelField
+}
+
+type otelField struct {
+	key, value string
+}
+
+// parseOTelState parses the value of the "ot" member of a tracestate. The
+// fields that are not "key:value" pairs are dropped.
+func parseOTelState(value string) otelState {
+	var s otelState
+	if value == "" {
+		return s
+	}
+	for _, field := range strings.Split(value, ";") {
+		i := strings.IndexByte(field, ':')
+		if i <= 0 {
+			continue
+		}
+		s.fields = append(s.fields, otelField{key: field[:i], value: field[i+1:]})
+	}
+	return s
+}
+
+// get returns the value of the field key, or an empty string.
+func (s otelState) get(key string) string {
+	for _, f := range s.fields {
+		if f.key == key {
+			return f.value
+		}
+	}
+	return ""
+}
+
+// set sets the value of the field key, replacing its value if it has one.
+func (s *otelState) set(key, value string) {
+	for i, f := range s.fields {
+		if f.key == key {
+			s.fields[i].value = value
+			return
+		}
+	}
+	s.fields = append(s.fields, otelField{key: key, value: value})
+}
+
+// delete removes the field key.
+func (s *otelState) delete(key string) {
+	fields := s.fields[:0]
+	for _, f := range s.fields {
+		if f.key != key {
+			fields = append(fields, f)
+		}
+	}
+	s.fields = fields
+}
+
+func (s otelState) String() string {
+	fields := make([]string, len(s.fields))
+	for i, f := range s.fields {
+		fields[i] = f.key + ":" + f.value
+	}
+	return strings.Join(fields, ";")
+}
+
+// parseThreshold parses a threshold of 1 to 14 hexadecimal digits, the omitted
+// trailing digits being zeros.
+func parseThreshold(value string) (uint64, bool) {
+	if value == "" || len(value) > thresholdDigits {
+		return 0, false
+	}
+	t, err := strconv.ParseUint(value, 16, 64)
+	if err != nil {
+		return 0, false
+	}
+	return t << (4 * (thresholdDigits - len(value))), true
+}
+
+// formatThreshold formats threshold t, which must be less than maxThreshold,
+// without its trailing zeros.
+func formatThreshold(t uint64) string {
+	s := strings.TrimRight(strconv.FormatUint(t|maxThreshold, 16)[1:], "0")
+	if s == "" {
+		return "0"
+	}
+	return s
+}
+
+// parseRandomness parses the randomness of exactly 14 hexadecimal digits.
+func parseRandomness(value string) (uint64, bool) {
+	if len(value) != thresholdDigits {
+		return 0, false
+	}
+	r, err := strconv.ParseUint(value, 16, 64)
+	return r, err == nil
+}
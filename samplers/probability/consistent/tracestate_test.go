@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consistent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOTelState(t *testing.T) {
+	s := parseOTelState("th:8;invalid;rv:01234567890abc;:x;xy:1")
+	assert.Equal(t, "th:8;rv:01234567890abc;xy:1", s.String())
+	assert.Equal(t, "01234567890abc", s.get(randomnessKey))
+
+	s.set(thresholdKey, "c")
+	s.delete("xy")
+	s.set("ab", "2")
+	assert.Equal(t, "th:c;rv:01234567890abc;ab:2", s.String())
+	assert.Equal(t, "", parseOTelState("").String())
+}
+
+func TestParseThreshold(t *testing.T) {
+	for value, expected := range map[string]uint64{
+		"0":              0,
+		"8":              1 << 55,
+		"c":              3 << 54,
+		"aaab":           0xaaab << 40,
+		"ffffffffffffff": maxThreshold - 1,
+	} {
+		th, ok := parseThreshold(value)
+		assert.True(t, ok, value)
+		assert.Equal(t, expected, th, value)
+		assert.Equal(t, value, formatThreshold(th))
+	}
+	for _, value := range []string{"", "g", "-1", "fffffffffffffff"} {
+		_, ok := parseThreshold(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestParseRandomness(t *testing.T) {
+	r, ok := parseRandomness("ffffffffffffff")
+	assert.True(t, ok)
+	assert.Equal(t, maxThreshold-1, r)
+	for _, value := range []string{"", "ff", "fffffffffffffff", "gfffffffffffff"} {
+		_, ok := parseRandomness(value)
+		assert.False(t, ok, value)
+	}
+}
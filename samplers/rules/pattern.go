@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import "strings"
+
+// pattern is a compiled glob pattern.
+type pattern struct {
+	// parts are the literal parts of the pattern separated by '*'. A nil
+	// parts matches everything.
+	parts [][]rune
+}
+
+func compilePattern(p string) pattern {
+	if p == "" || p == "*" {
+		return pattern{}
+	}
+	split := strings.Split(p, "*")
+	parts := make([][]rune, len(split))
+	for i, s := range split {
+		parts[i] = []rune(s)
+	}
+	return pattern{parts: parts}
+}
+
+// match reports whether s matches the pattern.
+func (p pattern) match(str string) bool {
+	if p.parts == nil {
+		return true
+	}
+	s := []rune(str)
+	last := len(p.parts) - 1
+	if last == 0 {
+		return len(s) == len(p.parts[0]) && matchLiteral(p.parts[0], s)
+	}
+
+	// The first part is anchored at the start and the last part at the end
+	// of s. The parts in between are matched at their leftmost position.
+	first := p.parts[0]
+	if len(s) < len(first) || !matchLiteral(first, s[:len(first)]) {
+		return false
+	}
+	s = s[len(first):]
+	for _, part := range p.parts[1:last] {
+		i := index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	end := p.parts[last]
+	return len(s) >= len(end) && matchLiteral(end, s[len(s)-len(end):])
+}
+
+// index returns the index of the first match of the literal part in s, or
+// -1 if part does not match anywhere in s.
+func index(s, part []rune) int {
+	for i := 0; i+len(part) <= len(s); i++ {
+		if matchLiteral(part, s[i:i+len(part)]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchLiteral reports whether s, which has the same length as part, matches
+// part, in which '?' matches any character.
+func matchLiteral(part, s []rune) bool {
+	for i := range part {
+		if part[i] != '?' && part[i] != s[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPattern(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		s       string
+		match   bool
+	}{
+		{"", "anything", true},
+		{"*", "", true},
+		{"/healthz", "/healthz", true},
+		{"/healthz", "/healthz/live", false},
+		{"/health?", "/healthz", true},
+		{"/health?", "/health", false},
+		{"/api/*", "/api/v1/users", true},
+		{"/api/*", "/api", false},
+		{"*/healthz", "/v1/healthz", true},
+		{"GET *", "GET /", true},
+		{"*.internal.*", "svc.internal.example", true},
+		{"*.internal.*", "svc.example", false},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "acb", false},
+		{"a*a", "a", false},
+		{"ü?", "üx", true},
+		{"?", "ü", true},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.match, compilePattern(tc.pattern).match(tc.s), "pattern %q, string %q", tc.pattern, tc.s)
+	}
+}
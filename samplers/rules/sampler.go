@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rules provides a sampler that makes sampling decisions based on an
+// ordered list of rules matching the name and attributes of spans.
+package rules
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Rule maps spans to a sampling decision.
+//
+// Span names and attribute values are matched against glob patterns, in
+// which '*' matches any sequence of characters, including '/', and '?'
+// matches any single character. All other characters match themselves.
+type Rule struct {
+	// SpanName is the pattern the span name is matched against. An empty
+	// pattern matches all span names.
+	SpanName string
+	// Attributes maps attribute keys to the patterns their values, in their
+	// string form, are matched against. A span matches only if it has all
+	// the attributes and all values match. Only the attributes passed at
+	// span creation are available to the sampler.
+	Attributes map[attribute.Key]string
+	// Decision is the sampling decision of spans matching the rule.
+	Decision sdktrace.SamplingDecision
+}
+
+// compiledRule is a Rule with its patterns compiled.
+type compiledRule struct {
+	spanName   pattern
+	attributes map[attribute.Key]pattern
+	decision   sdktrace.SamplingDecision
+}
+
+func (r compiledRule) matches(p sdktrace.SamplingParameters) bool {
+	if !r.spanName.match(p.Name) {
+		return false
+	}
+	if len(r.attributes) == 0 {
+		return true
+	}
+	matched := 0
+	for _, kv := range p.Attributes {
+		pat, ok := r.attributes[kv.Key]
+		if !ok {
+			continue
+		}
+		if !pat.match(kv.Value.Emit()) {
+			return false
+		}
+		matched++
+	}
+	return matched == len(r.attributes)
+}
+
+type sampler struct {
+	rules    []compiledRule
+	fallback sdktrace.Sampler
+}
+
+// compile time assertion that sampler implements the sdktrace.Sampler
+// interface.
+var _ sdktrace.Sampler = (*sampler)(nil)
+
+// NewSampler returns a sampler that applies the decision of the first of
+// rules matching a span. Spans not matching any rule are sampled by the
+// fallback sampler, which samples all spans whose parent is sampled as well
+// as all root spans unless set with WithFallback.
+//
+// The decision of a matching rule overrides the sampling decision of the
+// parent span. To only apply the rules to root spans, wrap the sampler with
+// sdktrace.ParentBased.
+func NewSampler(rules []Rule, opts ...Option) sdktrace.Sampler {
+	cfg := newConfig(opts...)
+	s := &sampler{
+		rules:    make([]compiledRule, 0, len(rules)),
+		fallback: cfg.fallback,
+	}
+	for _, r := range rules {
+		cr := compiledRule{
+			spanName: compilePattern(r.SpanName),
+			decision: r.Decision,
+		}
+		if len(r.Attributes) > 0 {
+			cr.attributes = make(map[attribute.Key]pattern, len(r.Attributes))
+			for k, v := range r.Attributes {
+				cr.attributes[k] = compilePattern(v)
+			}
+		}
+		s.rules = append(s.rules, cr)
+	}
+	return s
+}
+
+// ShouldSample returns the decision of the first rule matching the span, or
+// the decision of the fallback sampler if no rule matches.
+func (s *sampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for _, r := range s.rules {
+		if r.matches(p) {
+			return sdktrace.SamplingResult{
+				Decision:   r.decision,
+				Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+			}
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+// Description returns information describing the sampler.
+func (s *sampler) Description() string {
+	return fmt.Sprintf("RuleBased{rules:%d,fallback:%s}", len(s.rules), s.fallback.Description())
+}
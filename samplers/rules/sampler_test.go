@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSampler(t *testing.T) {
+	s := NewSampler([]Rule{
+		{
+			Attributes: map[attribute.Key]string{"http.target": "/healthz"},
+			Decision:   sdktrace.Drop,
+		},
+		{
+			SpanName: "db.*",
+			Decision: sdktrace.RecordOnly,
+		},
+		{
+			SpanName:   "HTTP GET",
+			Attributes: map[attribute.Key]string{"http.target": "/api/*", "http.status_code": "5??"},
+			Decision:   sdktrace.RecordAndSample,
+		},
+	}, WithFallback(sdktrace.NeverSample()))
+
+	testCases := []struct {
+		name     string
+		spanName string
+		attrs    []attribute.KeyValue
+		expected sdktrace.SamplingDecision
+	}{
+		{
+			name:     "health check",
+			spanName: "HTTP GET",
+			attrs:    []attribute.KeyValue{attribute.String("http.target", "/healthz")},
+			expected: sdktrace.Drop,
+		},
+		{
+			name:     "span name",
+			spanName: "db.query",
+			expected: sdktrace.RecordOnly,
+		},
+		{
+			name:     "all attributes",
+			spanName: "HTTP GET",
+			attrs: []attribute.KeyValue{
+				attribute.String("http.target", "/api/users"),
+				attribute.Int("http.status_code", 503),
+			},
+			expected: sdktrace.RecordAndSample,
+		},
+		{
+			name:     "attribute mismatch",
+			spanName: "HTTP GET",
+			attrs: []attribute.KeyValue{
+				attribute.String("http.target", "/api/users"),
+				attribute.Int("http.status_code", 200),
+			},
+			expected: sdktrace.Drop,
+		},
+		{
+			name:     "missing attribute",
+			spanName: "HTTP GET",
+			attrs:    []attribute.KeyValue{attribute.String("http.target", "/api/users")},
+			expected: sdktrace.Drop,
+		},
+		{
+			name:     "no match",
+			spanName: "HTTP POST",
+			expected: sdktrace.Drop,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := s.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: context.Background(),
+				Name:          tc.spanName,
+				Attributes:    tc.attrs,
+			})
+			assert.Equal(t, tc.expected, res.Decision)
+		})
+	}
+}
+
+func TestSamplerOverridesParent(t *testing.T) {
+	ts, err := trace.ParseTraceState("key=value")
+	assert.NoError(t, err)
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+		TraceState: ts,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), parent)
+
+	s := NewSampler([]Rule{{SpanName: "/healthz", Decision: sdktrace.Drop}})
+	res := s.ShouldSample(sdktrace.SamplingParameters{ParentContext: ctx, Name: "/healthz"})
+	assert.Equal(t, sdktrace.Drop, res.Decision)
+	assert.Equal(t, ts, res.Tracestate)
+
+	// The default fallback follows the parent.
+	res = s.ShouldSample(sdktrace.SamplingParameters{ParentContext: ctx, Name: "/users"})
+	assert.Equal(t, sdktrace.RecordAndSample, res.Decision)
+}
+
+func TestSamplerDescription(t *testing.T) {
+	s := NewSampler([]Rule{{Decision: sdktrace.Drop}}, WithFallback(sdktrace.AlwaysSample()))
+	assert.Equal(t, "RuleBased{rules:1,fallback:AlwaysOnSampler}", s.Description())
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws // import "go.opentelemetry.io/contrib/setup/aws"
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// ampService is the signing name of Amazon Managed Service for Prometheus.
+const ampService = "aps"
+
+type config struct {
+	// cortex is the configuration of the Cortex exporter. Metrics are only
+	// exported when it is set.
+	cortex *cortex.Config
+	// sigV4Region and sigV4Service are the region and the signing name of the
+	// service the pushes are signed for. The pushes are not signed when
+	// sigV4Service is empty.
+	sigV4Region  string
+	sigV4Service string
+	// credentials sign the pushes. The credentials of the default chain of
+	// the AWS SDK are used when nil.
+	credentials aws.CredentialsProvider
+
+	attributes     []attribute.KeyValue
+	spanProcessors []sdktrace.SpanProcessor
+	runtimeMetrics bool
+}
+
+func newConfig(opts ...Option) *config {
+	c := &config{runtimeMetrics: true}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// Option configures Install.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithCortexConfig exports the metrics with the Cortex exporter configured by
+// cfg. The Endpoint is overridden by WithAMPWorkspace.
+func WithCortexConfig(cfg cortex.Config) Option {
+	return optionFunc(func(c *config) {
+		c.cortex = &cfg
+	})
+}
+
+// WithAMPWorkspace exports the metrics to the remote write endpoint of the
+// Amazon Managed Service for Prometheus workspace in region, signing the
+// pushes with AWS Signature Version 4.
+func WithAMPWorkspace(region, workspaceID string) Option {
+	return optionFunc(func(c *config) {
+		if c.cortex == nil {
+			c.cortex = &cortex.Config{}
+		}
+		c.cortex.Endpoint = "https://aps-workspaces." + region + ".amazonaws.com/workspaces/" + workspaceID + "/api/v1/remote_write"
+		c.sigV4Region, c.sigV4Service = region, ampService
+	})
+}
+
+// WithSigV4 signs the pushes of the Cortex exporter with AWS Signature Version 4
+// for the service, e.g. "aps", in region, e.g. to push to a VPC endpoint of
+// Amazon Managed Service for Prometheus configured with WithCortexConfig.
+func WithSigV4(region, service string) Option {
+	return optionFunc(func(c *config) {
+		c.sigV4Region, c.sigV4Service = region, service
+	})
+}
+
+// WithCredentials sets the credentials the pushes are signed with. By default
+// the credentials are retrieved from the default chain of the AWS SDK: the
+// environment, the shared configuration files, and the roles of the ECS tasks
+// and EC2 instances.
+func WithCredentials(provider aws.CredentialsProvider) Option {
+	return optionFunc(func(c *config) {
+		c.credentials = provider
+	})
+}
+
+// WithServiceName sets the service.name attribute of the resource.
+func WithServiceName(name string) Option {
+	return WithAttributes(semconv.ServiceNameKey.String(name))
+}
+
+// WithAttributes adds attrs to the resource, overriding the detected
+// attributes.
+func WithAttributes(attrs ...attribute.KeyValue) Option {
+	return optionFunc(func(c *config) {
+		c.attributes = append(c.attributes, attrs...)
+	})
+}
+
+// WithSpanProcessor registers processor with the TracerProvider, e.g. a batch
+// span processor exporting the spans to the AWS Distro for OpenTelemetry
+// collector.
+func WithSpanProcessor(processor sdktrace.SpanProcessor) Option {
+	return optionFunc(func(c *config) {
+		c.spanProcessors = append(c.spanProcessors, processor)
+	})
+}
+
+// WithoutRuntimeMetrics disables the runtime metrics of the Go process.
+func WithoutRuntimeMetrics() Option {
+	return optionFunc(func(c *config) {
+		c.runtimeMetrics = false
+	})
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aws sets up the telemetry of a service running on AWS with one
+// call. Install detects the resource of the service, e.g. its EC2 instance,
+// ECS task, or EKS cluster, and installs as the global providers a tracer
+// provider generating X-Ray compatible trace IDs, with the X-Ray propagator,
+// and a meter provider pushing to Cortex or to an Amazon Managed Service for
+// Prometheus workspace, signing the pushes with AWS Signature Version 4:
+//
+//	telemetry, err := aws.Install(ctx,
+//		aws.WithServiceName("checkout"),
+//		aws.WithAMPWorkspace("us-west-2", "ws-0123456789"),
+//		aws.WithSpanProcessor(sdktrace.NewBatchSpanProcessor(exporter)),
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer telemetry.Shutdown(ctx)
+package aws // import "go.opentelemetry.io/contrib/setup/aws"
@@ -0,0 +1,34 @@
+module go.opentelemetry.io/contrib/setup/aws
+
+go 1.15
+
+replace (
+	go.opentelemetry.io/contrib => ../..
+	go.opentelemetry.io/contrib/detectors => ../../detectors
+	go.opentelemetry.io/contrib/detectors/aws => ../../detectors/aws
+	go.opentelemetry.io/contrib/detectors/aws/beanstalk => ../../detectors/aws/beanstalk
+	go.opentelemetry.io/contrib/detectors/aws/ec2 => ../../detectors/aws/ec2
+	go.opentelemetry.io/contrib/detectors/aws/ecs => ../../detectors/aws/ecs
+	go.opentelemetry.io/contrib/detectors/aws/eks => ../../detectors/aws/eks
+	go.opentelemetry.io/contrib/detectors/aws/internal => ../../detectors/aws/internal
+	go.opentelemetry.io/contrib/detectors/aws/lambda => ../../detectors/aws/lambda
+	go.opentelemetry.io/contrib/exporters/metric/cortex => ../../exporters/metric/cortex
+	go.opentelemetry.io/contrib/exporters/metric/cortex/prometheusremotewrite => ../../exporters/metric/cortex/prometheusremotewrite
+	go.opentelemetry.io/contrib/instrumentation/runtime => ../../instrumentation/runtime
+	go.opentelemetry.io/contrib/propagators/aws => ../../propagators/aws
+	go.opentelemetry.io/contrib/propagators/propagatortest => ../../propagators/propagatortest
+)
+
+require (
+	github.com/aws/aws-sdk-go-v2 v1.10.0
+	github.com/aws/aws-sdk-go-v2/config v1.9.0
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/detectors/aws v0.26.0
+	go.opentelemetry.io/contrib/exporters/metric/cortex v0.26.0
+	go.opentelemetry.io/contrib/instrumentation/runtime v0.26.0
+	go.opentelemetry.io/contrib/propagators/aws v1.1.0
+	go.opentelemetry.io/otel v1.1.0
+	go.opentelemetry.io/otel/metric v0.24.0
+	go.opentelemetry.io/otel/sdk v1.1.0
+	go.opentelemetry.io/otel/sdk/metric v0.24.0
+)
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws // import "go.opentelemetry.io/contrib/setup/aws"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+
+	awsdetector "go.opentelemetry.io/contrib/detectors/aws"
+	"go.opentelemetry.io/contrib/exporters/metric/cortex"
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric/global"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Telemetry holds the providers installed by Install.
+type Telemetry struct {
+	// Resource is the detected resource of the process, with the attributes
+	// set by WithServiceName and WithAttributes.
+	Resource *resource.Resource
+	// TracerProvider creates spans with X-Ray compatible trace IDs.
+	TracerProvider *sdktrace.TracerProvider
+	// Controller pushes the metrics to Cortex. It is nil when no Cortex
+	// exporter is configured.
+	Controller *controller.Controller
+
+	// processors is whether the TracerProvider has span processors to shut
+	// down.
+	processors bool
+}
+
+// Install detects the AWS environment of the process and installs as global
+// providers a TracerProvider generating X-Ray compatible trace IDs, the X-Ray
+// propagator, and, when configured with WithAMPWorkspace or WithCortexConfig,
+// a MeterProvider pushing the metrics and the runtime metrics of the process to
+// Amazon Managed Service for Prometheus or Cortex. The resource is detected
+// with the detectors of go.opentelemetry.io/contrib/detectors/aws.
+//
+// The returned Telemetry must be shut down to flush the spans and metrics.
+func Install(ctx context.Context, opts ...Option) (*Telemetry, error) {
+	c := newConfig(opts...)
+
+	res, err := resource.New(ctx,
+		resource.WithDetectors(awsdetector.NewResourceDetector()),
+		resource.WithAttributes(c.attributes...),
+	)
+	if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+		return nil, fmt.Errorf("detecting the resource: %w", err)
+	}
+	t := &Telemetry{Resource: res}
+
+	if c.cortex != nil {
+		cortexConfig, err := c.cortexConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		t.Controller, err = cortex.NewExportPipeline(cortexConfig,
+			controller.WithCollectPeriod(cortexConfig.PushInterval),
+			controller.WithResource(res),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if c.runtimeMetrics {
+			if err := runtime.Start(runtime.WithMeterProvider(t.Controller)); err != nil {
+				_ = t.Controller.Stop(ctx)
+				return nil, err
+			}
+		}
+		global.SetMeterProvider(t.Controller)
+	}
+
+	tpOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithIDGenerator(xray.NewIDGenerator()),
+	}
+	for _, sp := range c.spanProcessors {
+		tpOpts = append(tpOpts, sdktrace.WithSpanProcessor(sp))
+	}
+	t.TracerProvider = sdktrace.NewTracerProvider(tpOpts...)
+	t.processors = len(c.spanProcessors) > 0
+	otel.SetTracerProvider(t.TracerProvider)
+	otel.SetTextMapPropagator(xray.Propagator{})
+
+	return t, nil
+}
+
+// Shutdown pushes the last metrics and flushes the spans, then stops the
+// providers.
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	var err error
+	if t.Controller != nil {
+		err = t.Controller.Stop(ctx)
+	}
+	if t.processors {
+		if tpErr := t.TracerProvider.Shutdown(ctx); err == nil {
+			err = tpErr
+		}
+	}
+	return err
+}
+
+// cortexConfig returns the configuration of the Cortex exporter, whose client
+// signs the pushes when configured with WithAMPWorkspace or WithSigV4.
+func (c *config) cortexConfig(ctx context.Context) (cortex.Config, error) {
+	cortexConfig := *c.cortex
+	if err := cortexConfig.Validate(); err != nil {
+		return cortex.Config{}, err
+	}
+	if c.sigV4Service == "" {
+		return cortexConfig, nil
+	}
+
+	region, credentials := c.sigV4Region, c.credentials
+	if region == "" || credentials == nil {
+		awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return cortex.Config{}, fmt.Errorf("loading the AWS configuration: %w", err)
+		}
+		if region == "" {
+			region = awsConfig.Region
+		}
+		if credentials == nil {
+			credentials = awsConfig.Credentials
+		}
+	}
+	if credentials == nil {
+		return cortex.Config{}, errors.New("no AWS credentials to sign the pushes")
+	}
+
+	if _, ok := credentials.(*aws.CredentialsCache); !ok {
+		credentials = aws.NewCredentialsCache(credentials)
+	}
+
+	client := http.Client{Timeout: cortexConfig.RemoteTimeout}
+	if cortexConfig.Client != nil {
+		client = *cortexConfig.Client
+	}
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.Transport = &sigV4Transport{
+		base:        base,
+		signer:      v4.NewSigner(),
+		credentials: credentials,
+		region:      region,
+		service:     c.sigV4Service,
+		now:         time.Now,
+	}
+	cortexConfig.Client = &client
+	return cortexConfig, nil
+}
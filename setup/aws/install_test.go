@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/exporters/metric/cortex"
+	"go.opentelemetry.io/contrib/exporters/metric/cortex/cortextest"
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+func staticCredentials(accessKeyID, secretAccessKey string) aws.CredentialsProvider {
+	return aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: accessKeyID, SecretAccessKey: secretAccessKey}, nil
+	})
+}
+
+func TestMain(m *testing.M) {
+	// Skip the instance metadata service of the EC2, ECS, and EKS detectors.
+	os.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	os.Exit(m.Run())
+}
+
+func TestInstall(t *testing.T) {
+	server := cortextest.NewServer(cortextest.WithSigV4("us-west-2", "aps", "AKID", "SECRET"))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	ctx := context.Background()
+	telemetry, err := Install(ctx,
+		WithCortexConfig(cortex.Config{Endpoint: server.URL, PushInterval: time.Hour}),
+		WithSigV4("us-west-2", "aps"),
+		WithCredentials(staticCredentials("AKID", "SECRET")),
+		WithServiceName("checkout"),
+		WithSpanProcessor(recorder),
+	)
+	require.NoError(t, err)
+
+	assert.Contains(t, telemetry.Resource.Attributes(), semconv.ServiceNameKey.String("checkout"))
+	assert.Equal(t, xray.Propagator{}, otel.GetTextMapPropagator())
+
+	_, span := otel.Tracer("test").Start(ctx, "span")
+	span.End()
+	// The first 4 bytes of X-Ray trace IDs are the epoch time in seconds.
+	traceID := span.SpanContext().TraceID()
+	start := int64(traceID[0])<<24 | int64(traceID[1])<<16 | int64(traceID[2])<<8 | int64(traceID[3])
+	assert.InDelta(t, time.Now().Unix(), start, 60)
+
+	counter := metric.Must(global.Meter("test")).NewInt64Counter("requests")
+	counter.Add(ctx, 1)
+	require.NoError(t, telemetry.Shutdown(ctx))
+
+	require.Len(t, recorder.Ended(), 1)
+	assert.Equal(t, telemetry.Resource, recorder.Ended()[0].Resource())
+
+	assert.Empty(t, server.Rejections())
+	requests := server.Requests()
+	require.NotEmpty(t, requests)
+	names := make(map[string]bool)
+	for _, ts := range requests[0].WriteRequest.Timeseries {
+		for _, l := range ts.Labels {
+			if l.Name == "__name__" {
+				names[l.Value] = true
+			}
+		}
+	}
+	assert.True(t, names["requests"], "the counter is pushed: %v", names)
+	assert.True(t, names["runtime_go_goroutines"], "the runtime metrics are pushed: %v", names)
+}
+
+func TestInstallInvalidCredentials(t *testing.T) {
+	server := cortextest.NewServer(cortextest.WithSigV4("us-west-2", "aps", "AKID", "SECRET"))
+	defer server.Close()
+
+	ctx := context.Background()
+	telemetry, err := Install(ctx,
+		WithCortexConfig(cortex.Config{Endpoint: server.URL, PushInterval: time.Hour}),
+		WithSigV4("us-west-2", "aps"),
+		WithCredentials(staticCredentials("AKID", "OTHER")),
+		WithoutRuntimeMetrics(),
+	)
+	require.NoError(t, err)
+	metric.Must(global.Meter("test")).NewInt64Counter("requests").Add(ctx, 1)
+	assert.Error(t, telemetry.Shutdown(ctx))
+
+	assert.Empty(t, server.Requests())
+	assert.NotEmpty(t, server.Rejections())
+}
+
+func TestInstallWithoutMetrics(t *testing.T) {
+	telemetry, err := Install(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, telemetry.Controller)
+	assert.NoError(t, telemetry.Shutdown(context.Background()))
+}
+
+func TestWithAMPWorkspace(t *testing.T) {
+	c := newConfig(
+		WithCortexConfig(cortex.Config{Endpoint: "http://localhost:9009/api/prom/push"}),
+		WithAMPWorkspace("eu-west-1", "ws-1234"),
+	)
+	assert.Equal(t, "https://aps-workspaces.eu-west-1.amazonaws.com/workspaces/ws-1234/api/v1/remote_write", c.cortex.Endpoint)
+	assert.Equal(t, "eu-west-1", c.sigV4Region)
+	assert.Equal(t, "aps", c.sigV4Service)
+}
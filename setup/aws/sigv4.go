@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws // import "go.opentelemetry.io/contrib/setup/aws"
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// sigV4Transport signs the requests with AWS Signature Version 4 before sending
+// them with base.
+type sigV4Transport struct {
+	base        http.RoundTripper
+	signer      *v4.Signer
+	credentials aws.CredentialsProvider
+	region      string
+	service     string
+	now         func() time.Time
+}
+
+var _ http.RoundTripper = (*sigV4Transport)(nil)
+
+// RoundTrip signs a copy of req, including the hash of its body, and sends it.
+func (t *sigV4Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	signed := req.Clone(req.Context())
+	signed.Body = ioutil.NopCloser(bytes.NewReader(body))
+	signed.ContentLength = int64(len(body))
+
+	credentials, err := t.credentials.Retrieve(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(body)
+	err = t.signer.SignHTTP(req.Context(), credentials, signed, hex.EncodeToString(hash[:]), t.service, t.region, t.now())
+	if err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(signed)
+}
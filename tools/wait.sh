@@ -50,6 +50,18 @@ wait_for_gomemcache () {
   exit 1
 }
 
+wait_for_amqp091 () {
+  for ((i = 0; i < 5; ++i)); do
+    if docker exec "$1" rabbitmq-diagnostics -q check_port_connectivity; then
+      exit 0
+    fi
+    echo "RabbitMQ not yet available..."
+    sleep 10
+  done
+  echo "Timeout waiting for rabbitmq to initialize"
+  exit 1
+}
+
 if [ -z "$CMD" ]; then
   echo "CMD is undefined. exiting..."
   exit 1
@@ -64,6 +76,8 @@ elif [ "$CMD" == "mongo" ]; then
   wait_for_mongo "$IMG_NAME"
 elif [ "$CMD" == "gomemcache" ]; then
   wait_for_gomemcache
+elif [ "$CMD" == "amqp091" ]; then
+  wait_for_amqp091 "$IMG_NAME"
 else
   echo "unknown CMD"
   exit 1
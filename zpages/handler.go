@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zpages implements in-process debugging pages, which show live
+// information about the spans of a process without a tracing backend.
+//
+// The SpanProcessor keeps a summary of the active spans and samples of the
+// ended spans, by latency bucket and with an error status, of every span
+// name. The tracez page serving it can be mounted on an existing mux:
+//
+//	zsp := zpages.NewSpanProcessor()
+//	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(zsp))
+//	zpages.Handle(mux, "/debug", zsp) // Serves /debug/tracez.
+package zpages
+
+import (
+	"net/http"
+	"path"
+)
+
+// TracezPath is the path the tracez page is served at, relative to the path
+// prefix passed to Handle.
+const TracezPath = "tracez"
+
+// Handle registers the zpages handlers serving the data of sp on mux, under
+// pathPrefix. If mux is nil, http.DefaultServeMux is used.
+func Handle(mux *http.ServeMux, pathPrefix string, sp *SpanProcessor) {
+	if mux == nil {
+		mux = http.DefaultServeMux
+	}
+	mux.Handle(path.Join("/", pathPrefix, TracezPath), NewTracezHandler(sp))
+}
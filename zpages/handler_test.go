@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpages
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func get(t *testing.T, url string) (int, string) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestHandle(t *testing.T) {
+	zsp := NewSpanProcessor()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(zsp))
+	tracer := tp.Tracer("test")
+
+	_, active := tracer.Start(context.Background(), "activeSpan")
+	defer active.End()
+	_, failed := tracer.Start(context.Background(), "failedSpan")
+	failed.SetStatus(codes.Error, "boom")
+	failed.End()
+
+	mux := http.NewServeMux()
+	Handle(mux, "debug", zsp)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	status, body := get(t, srv.URL+"/debug/tracez")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "activeSpan")
+	assert.Contains(t, body, "failedSpan")
+	assert.Contains(t, body, "tracez?zspanname=failedSpan&ztype=2")
+
+	status, body = get(t, srv.URL+"/debug/tracez?zspanname=failedSpan&ztype=2")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "boom")
+
+	status, _ = get(t, srv.URL+"/tracez")
+	assert.Equal(t, http.StatusNotFound, status)
+}